@@ -163,6 +163,17 @@ const (
 	MongoOplogSize         = "MONGO_OPLOG_SIZE"
 	NumaCtlPreference      = "NUMA_CTL_PREFERENCE"
 	AllowsSecureConnection = "SECURE_CONTROLLER_CONNECTION"
+	MachineLockSlots       = "MACHINE_LOCK_SLOTS"
+
+	// The following keys hold limits placed on API connections by
+	// a controller; see apiserver.RateLimitConfig.
+	APIAgentLoginConcurrency  = "API_AGENT_LOGIN_CONCURRENCY"
+	APIClientLoginConcurrency = "API_CLIENT_LOGIN_CONCURRENCY"
+	APIMaxUserConnections     = "API_MAX_USER_CONNECTIONS"
+	APIUserRequestRate        = "API_USER_REQUEST_RATE"
+	APIUserRequestBurst       = "API_USER_REQUEST_BURST"
+	APIIPRequestRate          = "API_IP_REQUEST_RATE"
+	APIIPRequestBurst         = "API_IP_REQUEST_BURST"
 )
 
 // The Config interface is the sole way that the agent gets access to the
@@ -400,6 +400,7 @@ func stateServingInfo() params.StateServingInfo {
 		StatePort:      69,
 		APIPort:        47,
 		SharedSecret:   "shared",
+		SecretsKey:     "secrets",
 		SystemIdentity: "identity",
 	}
 }
@@ -510,6 +511,7 @@ func (*suite) TestStateServingInfo(c *gc.C) {
 		PrivateKey:     "new key",
 		CAPrivateKey:   "new ca key",
 		SharedSecret:   "new shared",
+		SecretsKey:     "new secrets",
 		SystemIdentity: "new identity",
 	}
 	conf.SetStateServingInfo(newInfo)
@@ -54,6 +54,7 @@ type format_1_18Serialization struct {
 	APIPort        int    `yaml:",omitempty"`
 	StatePort      int    `yaml:",omitempty"`
 	SharedSecret   string `yaml:",omitempty"`
+	SecretsKey     string `yaml:",omitempty"`
 	SystemIdentity string `yaml:",omitempty"`
 	MongoVersion   string `yaml:",omitempty"`
 }
@@ -124,6 +125,7 @@ func (formatter_1_18) unmarshal(data []byte) (*configInternal, error) {
 			APIPort:        format.APIPort,
 			StatePort:      format.StatePort,
 			SharedSecret:   format.SharedSecret,
+			SecretsKey:     format.SecretsKey,
 			SystemIdentity: format.SystemIdentity,
 		}
 		// There's a private key, then we need the state port,
@@ -178,6 +180,7 @@ func (formatter_1_18) marshal(config *configInternal) ([]byte, error) {
 		format.APIPort = config.servingInfo.APIPort
 		format.StatePort = config.servingInfo.StatePort
 		format.SharedSecret = config.servingInfo.SharedSecret
+		format.SecretsKey = config.servingInfo.SecretsKey
 		format.SystemIdentity = config.servingInfo.SystemIdentity
 	}
 	if config.stateDetails != nil {
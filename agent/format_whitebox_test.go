@@ -95,6 +95,7 @@ func (*formatSuite) TestReadWriteStateConfig(c *gc.C) {
 		Cert:         "some special cert",
 		PrivateKey:   "a special key",
 		CAPrivateKey: "ca special key",
+		SecretsKey:   "secrets special key",
 		StatePort:    12345,
 		APIPort:      23456,
 	}
@@ -51,6 +51,7 @@ func (s *servingInfoSuite) TestStateServingInfo(c *gc.C) {
 		PrivateKey:   ssi.PrivateKey,
 		Cert:         ssi.Cert,
 		SharedSecret: ssi.SharedSecret,
+		SecretsKey:   coretesting.SecretsKey,
 		APIPort:      ssi.APIPort,
 		StatePort:    ssi.StatePort,
 	}
@@ -72,6 +72,10 @@ type state struct {
 	// server does not report this during login.
 	serverVersion version.Number
 
+	// maintenance holds the controller's maintenance mode as reported
+	// by Login, or nil if the controller is not in maintenance mode.
+	maintenance *params.MaintenanceInfo
+
 	// hostPorts is the API server addresses returned from Login,
 	// which the client may cache and use for failover.
 	hostPorts [][]network.HostPort
@@ -217,6 +217,26 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// SetTrust grants or revokes the application's access to the model's
+// cloud credential.
+func (c *Client) SetTrust(application string, trusted bool) error {
+	args := params.ApplicationTrust{
+		ApplicationName: application,
+		Trusted:         trusted,
+	}
+	return c.facade.FacadeCall("SetTrust", args, nil)
+}
+
+// SetEndpointBindings changes the spaces the given endpoints of the
+// application are bound to.
+func (c *Client) SetEndpointBindings(application string, bindings map[string]string) error {
+	args := params.ApplicationSetEndpointBindings{
+		ApplicationName:  application,
+		EndpointBindings: bindings,
+	}
+	return c.facade.FacadeCall("SetEndpointBindings", args, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -163,3 +163,43 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestServiceSetEndpointBindings(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetEndpointBindings")
+		args, ok := a.(params.ApplicationSetEndpointBindings)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.ApplicationName, gc.Equals, "application")
+		c.Assert(args.EndpointBindings, jc.DeepEquals, map[string]string{
+			"":   "public",
+			"db": "internal",
+		})
+		return nil
+	})
+	err := s.client.SetEndpointBindings("application", map[string]string{
+		"":   "public",
+		"db": "internal",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceSetTrust(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetTrust")
+		args, ok := a.(params.ApplicationTrust)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.ApplicationTrust{
+			ApplicationName: "application",
+			Trusted:         true,
+		})
+		return nil
+	})
+	err := s.client.SetTrust("application", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -75,3 +76,45 @@ func (c *Client) SwitchBlockOff(blockType string) error {
 	}
 	return nil
 }
+
+// SwitchEntityBlockOn switches desired block on for an application or
+// machine in the current model. Valid block types are "BlockRemove"
+// and "BlockChange".
+func (c *Client) SwitchEntityBlockOn(blockType string, entity names.Tag, msg string) error {
+	if c.facade.BestAPIVersion() < 3 {
+		return errors.NotImplementedf("SwitchEntityBlockOn() (need V3+)")
+	}
+	args := params.BlockSwitchParams{
+		Type:    blockType,
+		Message: msg,
+		Entity:  entity.String(),
+	}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("SwitchBlockOn", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
+// SwitchEntityBlockOff switches desired block off for an application
+// or machine in the current model.
+func (c *Client) SwitchEntityBlockOff(blockType string, entity names.Tag) error {
+	if c.facade.BestAPIVersion() < 3 {
+		return errors.NotImplementedf("SwitchEntityBlockOff() (need V3+)")
+	}
+	args := params.BlockSwitchParams{
+		Type:   blockType,
+		Entity: entity.String(),
+	}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("SwitchBlockOff", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
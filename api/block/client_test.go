@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/block"
@@ -168,3 +169,75 @@ func (s *blockMockSuite) TestList(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, errmsg)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+// versionedCaller is an APICallerFunc that reports the given Block
+// facade version.
+type versionedCaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedCaller) BestFacadeVersion(facade string) int {
+	return c.version
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockOn(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Block")
+			c.Check(request, gc.Equals, "SwitchBlockOn")
+			c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+				Type:    state.RemoveBlock.String(),
+				Message: "protect the database",
+				Entity:  "application-postgresql",
+			})
+			return nil
+		})
+	blockClient := block.NewClient(versionedCaller{apiCaller, 3})
+	err := blockClient.SwitchEntityBlockOn(state.RemoveBlock.String(), names.NewApplicationTag("postgresql"), "protect the database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockOff(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(request, gc.Equals, "SwitchBlockOff")
+			c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+				Type:   state.ChangeBlock.String(),
+				Entity: "machine-0",
+			})
+			return nil
+		})
+	blockClient := block.NewClient(versionedCaller{apiCaller, 3})
+	err := blockClient.SwitchEntityBlockOff(state.ChangeBlock.String(), names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockOnOldServer(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		})
+	blockClient := block.NewClient(versionedCaller{apiCaller, 2})
+	err := blockClient.SwitchEntityBlockOn(state.RemoveBlock.String(), names.NewApplicationTag("postgresql"), "")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
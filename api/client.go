@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/downloader"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/tools"
@@ -96,6 +97,32 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 	return history, nil
 }
 
+// HookHistory returns at most size of the most recent hook, action
+// and juju-run executions on the named unit, newest first. A size of
+// zero returns them all.
+func (c *Client) HookHistory(unitName string, size int) ([]params.HookExecution, error) {
+	if !names.IsValidUnit(unitName) {
+		return nil, errors.NotValidf("unit name %q", unitName)
+	}
+	args := params.HookHistoryRequests{
+		Requests: []params.HookHistoryRequest{{
+			Tag:  names.NewUnitTag(unitName).String(),
+			Size: size,
+		}},
+	}
+	var results params.HookHistoryResults
+	if err := c.facade.FacadeCall("HookHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Executions, nil
+}
+
 // Resolved clears errors on a unit.
 func (c *Client) Resolved(unit string, retry bool) error {
 	p := params.Resolved{
@@ -266,6 +293,24 @@ func (c *Client) ModelGet() (map[string]interface{}, error) {
 	return result.Config, err
 }
 
+// ModelConfigValues returns all model settings along with the source
+// of each value.
+func (c *Client) ModelConfigValues() (config.ConfigValues, error) {
+	result := params.ModelConfigValuesResults{}
+	err := c.facade.FacadeCall("ModelConfigValues", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	values := make(config.ConfigValues)
+	for attr, val := range result.Config {
+		values[attr] = config.ConfigValue{
+			Value:  val.Value,
+			Source: val.Source,
+		}
+	}
+	return values, nil
+}
+
 // ModelSet sets the given key-value pairs in the model.
 func (c *Client) ModelSet(config map[string]interface{}) error {
 	args := params.ModelSet{Config: config}
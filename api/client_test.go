@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
@@ -234,6 +235,38 @@ func testMinVer(client *api.Client, t minverTest, c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestHookHistory(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	started := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	err := unit.AddHookExecution(state.HookExecution{
+		Kind:       "hook",
+		Name:       "install",
+		RelationId: -1,
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		Output:     "oops\n",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.APIState.Client().HookHistory(unit.Name(), 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []params.HookExecution{{
+		Kind:       "hook",
+		Name:       "install",
+		RelationId: -1,
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		Output:     "oops\n",
+	}})
+}
+
+func (s *clientSuite) TestHookHistoryInvalidUnit(c *gc.C) {
+	_, err := s.APIState.Client().HookHistory("not-a-unit", 0)
+	c.Assert(err, gc.ErrorMatches, `unit name "not-a-unit" not valid`)
+}
+
 func (s *clientSuite) TestOpenCharmFound(c *gc.C) {
 	client := s.APIState.Client()
 	curl, ch := addLocalCharm(c, client, "dummy")
@@ -522,6 +555,14 @@ func (s *clientSuite) TestEnvironmentGet(c *gc.C) {
 	c.Assert(env["type"], gc.Equals, "dummy")
 }
 
+func (s *clientSuite) TestModelConfigValues(c *gc.C) {
+	client := s.APIState.Client()
+	values, err := client.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["type"].Value, gc.Equals, "dummy")
+	c.Assert(values["name"].Source, gc.Equals, "model")
+}
+
 func (s *clientSuite) TestEnvironmentSet(c *gc.C) {
 	client := s.APIState.Client()
 	err := client.ModelSet(map[string]interface{}{
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cloud provides access to the clouds and cloud credentials
+// stored in the controller.
+package cloud
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+)
+
+// Client provides methods that the Juju client command uses to manage
+// the clouds and cloud credentials stored in the controller.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated
+// API connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Cloud")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddCloud registers the named cloud with the controller, so that
+// models may be added to it.
+func (c *Client) AddCloud(name string, in cloud.Cloud) error {
+	var authTypes []string
+	for _, authType := range in.AuthTypes {
+		authTypes = append(authTypes, string(authType))
+	}
+	var regions []params.CloudRegion
+	for _, region := range in.Regions {
+		regions = append(regions, params.CloudRegion{
+			Name:            region.Name,
+			Endpoint:        region.Endpoint,
+			StorageEndpoint: region.StorageEndpoint,
+		})
+	}
+	args := params.AddCloudArgs{
+		Name: name,
+		Cloud: params.Cloud{
+			Type:            in.Type,
+			AuthTypes:       authTypes,
+			Endpoint:        in.Endpoint,
+			StorageEndpoint: in.StorageEndpoint,
+			Regions:         regions,
+			Config:          in.Config,
+		},
+	}
+	return errors.Trace(c.facade.FacadeCall("AddCloud", args, nil))
+}
+
+// Clouds returns the clouds registered with the controller, in
+// addition to the one it runs in, keyed on cloud name.
+func (c *Client) Clouds() (map[string]cloud.Cloud, error) {
+	var result params.CloudsResult
+	if err := c.facade.FacadeCall("Clouds", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	clouds := make(map[string]cloud.Cloud)
+	for name, in := range result.Clouds {
+		var authTypes []cloud.AuthType
+		for _, authType := range in.AuthTypes {
+			authTypes = append(authTypes, cloud.AuthType(authType))
+		}
+		var regions []cloud.Region
+		for _, region := range in.Regions {
+			regions = append(regions, cloud.Region{
+				Name:            region.Name,
+				Endpoint:        region.Endpoint,
+				StorageEndpoint: region.StorageEndpoint,
+			})
+		}
+		clouds[name] = cloud.Cloud{
+			Type:            in.Type,
+			AuthTypes:       authTypes,
+			Endpoint:        in.Endpoint,
+			StorageEndpoint: in.StorageEndpoint,
+			Regions:         regions,
+			Config:          in.Config,
+		}
+	}
+	return clouds, nil
+}
+
+// CredentialInfo describes a credential stored in the controller,
+// without its attribute values.
+type CredentialInfo struct {
+	Name       string
+	AuthType   cloud.AuthType
+	Attributes []string
+	Revision   int
+}
+
+// UpdateCredential stores the named credential for the given owner and
+// cloud in the controller, replacing any existing credential with the
+// same name. Models using the credential pick up the change.
+func (c *Client) UpdateCredential(owner names.UserTag, cloudName, credentialName string, credential cloud.Credential) error {
+	args := params.UpdateCloudCredentials{
+		Credentials: []params.UpdateCloudCredential{{
+			OwnerTag: owner.String(),
+			Cloud:    cloudName,
+			Name:     credentialName,
+			Credential: params.CloudCredential{
+				AuthType:   string(credential.AuthType()),
+				Attributes: credential.Attributes(),
+			},
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdateCredentials", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Credentials returns the credentials stored in the controller for the
+// given owner and cloud.
+func (c *Client) Credentials(owner names.UserTag, cloudName string) ([]CredentialInfo, error) {
+	args := params.UserClouds{
+		UserClouds: []params.UserCloud{{
+			UserTag: owner.String(),
+			Cloud:   cloudName,
+		}},
+	}
+	var results params.CloudCredentialsResults
+	if err := c.facade.FacadeCall("Credentials", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	infos := make([]CredentialInfo, len(result.Credentials))
+	for i, info := range result.Credentials {
+		infos[i] = CredentialInfo{
+			Name:       info.Name,
+			AuthType:   cloud.AuthType(info.AuthType),
+			Attributes: info.Attributes,
+			Revision:   info.Revision,
+		}
+	}
+	return infos, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	cloudapi "github.com/juju/juju/api/cloud"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+)
+
+type cloudSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&cloudSuite{})
+
+func (s *cloudSuite) TestUpdateCredential(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "Cloud")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := cloudapi.NewClient(apiCaller)
+
+	err := client.UpdateCredential(names.NewUserTag("bob"), "aws", "default", cloud.NewCredential(
+		cloud.AccessKeyAuthType, map[string]string{"access-key": "key"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{{"UpdateCredentials", []interface{}{
+		params.UpdateCloudCredentials{
+			Credentials: []params.UpdateCloudCredential{{
+				OwnerTag: "user-bob",
+				Cloud:    "aws",
+				Name:     "default",
+				Credential: params.CloudCredential{
+					AuthType:   "access-key",
+					Attributes: map[string]string{"access-key": "key"},
+				},
+			}},
+		},
+	}}})
+}
+
+func (s *cloudSuite) TestUpdateCredentialError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "nope"}}},
+		}
+		return nil
+	})
+	client := cloudapi.NewClient(apiCaller)
+
+	err := client.UpdateCredential(names.NewUserTag("bob"), "aws", "default", cloud.NewCredential(
+		cloud.AccessKeyAuthType, nil,
+	))
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
+
+func (s *cloudSuite) TestCredentials(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "Cloud")
+		stub.AddCall(request, args)
+		*response.(*params.CloudCredentialsResults) = params.CloudCredentialsResults{
+			Results: []params.CloudCredentialsResult{{
+				Credentials: []params.CloudCredentialInfo{{
+					Name:       "default",
+					AuthType:   "access-key",
+					Attributes: []string{"access-key", "secret-key"},
+					Revision:   2,
+				}},
+			}},
+		}
+		return nil
+	})
+	client := cloudapi.NewClient(apiCaller)
+
+	infos, err := client.Credentials(names.NewUserTag("bob"), "aws")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, []cloudapi.CredentialInfo{{
+		Name:       "default",
+		AuthType:   cloud.AccessKeyAuthType,
+		Attributes: []string{"access-key", "secret-key"},
+		Revision:   2,
+	}})
+	stub.CheckCalls(c, []testing.StubCall{{"Credentials", []interface{}{
+		params.UserClouds{
+			UserClouds: []params.UserCloud{{UserTag: "user-bob", Cloud: "aws"}},
+		},
+	}}})
+}
+
+func (s *cloudSuite) TestAddCloud(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "Cloud")
+		stub.AddCall(request, args)
+		return nil
+	})
+	client := cloudapi.NewClient(apiCaller)
+
+	err := client.AddCloud("other", cloud.Cloud{
+		Type:      "ec2",
+		AuthTypes: []cloud.AuthType{cloud.AccessKeyAuthType},
+		Regions:   []cloud.Region{{Name: "north", Endpoint: "https://north"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{{"AddCloud", []interface{}{
+		params.AddCloudArgs{
+			Name: "other",
+			Cloud: params.Cloud{
+				Type:      "ec2",
+				AuthTypes: []string{"access-key"},
+				Regions:   []params.CloudRegion{{Name: "north", Endpoint: "https://north"}},
+			},
+		},
+	}}})
+}
+
+func (s *cloudSuite) TestClouds(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(request, gc.Equals, "Clouds")
+		*response.(*params.CloudsResult) = params.CloudsResult{
+			Clouds: map[string]params.Cloud{
+				"other": {
+					Type:    "openstack",
+					Regions: []params.CloudRegion{{Name: "north"}},
+				},
+			},
+		}
+		return nil
+	})
+	client := cloudapi.NewClient(apiCaller)
+
+	clouds, err := client.Clouds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clouds, jc.DeepEquals, map[string]cloud.Cloud{
+		"other": {
+			Type:    "openstack",
+			Regions: []cloud.Region{{Name: "north"}},
+		},
+	})
+}
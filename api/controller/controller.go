@@ -4,6 +4,8 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -86,6 +88,32 @@ func (c *Client) RemoveBlocks() error {
 	return c.facade.FacadeCall("RemoveBlocks", args, nil)
 }
 
+// EnableMaintenance puts the controller into maintenance mode, in which
+// users may only make read-only API calls. If expires is not the zero
+// time, maintenance mode ends of its own accord at that time.
+func (c *Client) EnableMaintenance(reason string, expires time.Time) error {
+	args := params.EnableMaintenanceArgs{Reason: reason}
+	if !expires.IsZero() {
+		args.Expires = &expires
+	}
+	return c.facade.FacadeCall("EnableMaintenance", args, nil)
+}
+
+// DisableMaintenance takes the controller out of maintenance mode.
+func (c *Client) DisableMaintenance() error {
+	return c.facade.FacadeCall("DisableMaintenance", nil, nil)
+}
+
+// ReplicaSetStatus returns the health of each member of the
+// controller's MongoDB replica set.
+func (c *Client) ReplicaSetStatus() ([]params.ReplicaSetMember, error) {
+	var result params.ReplicaSetStatusResult
+	if err := c.facade.FacadeCall("ReplicaSetStatus", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Members, nil
+}
+
 // WatchAllModels returns an AllWatcher, from which you can request
 // the Next collection of Deltas (for all models).
 func (c *Client) WatchAllModels() (*api.AllWatcher, error) {
@@ -203,3 +231,63 @@ func (c *Client) InitiateModelMigration(spec ModelMigrationSpec) (string, error)
 	}
 	return result.Id, nil
 }
+
+// CloneModelSpec holds the details required to clone a model into a
+// new model on the same or another controller.
+type CloneModelSpec struct {
+	ModelUUID            string
+	Name                 string
+	TargetControllerUUID string
+	TargetAddrs          []string
+	TargetCACert         string
+	TargetUser           string
+	TargetPassword       string
+}
+
+// Validate performs sanity checks on the clone configuration it holds.
+func (s *CloneModelSpec) Validate() error {
+	if !names.IsValidModel(s.ModelUUID) {
+		return errors.NotValidf("model UUID")
+	}
+	if !names.IsValidModelName(s.Name) {
+		return errors.NotValidf("model name %q", s.Name)
+	}
+	migrationSpec := ModelMigrationSpec{
+		ModelUUID:            s.ModelUUID,
+		TargetControllerUUID: s.TargetControllerUUID,
+		TargetAddrs:          s.TargetAddrs,
+		TargetCACert:         s.TargetCACert,
+		TargetUser:           s.TargetUser,
+		TargetPassword:       s.TargetPassword,
+	}
+	return migrationSpec.Validate()
+}
+
+// CloneModel copies the specified model into a new model, returning
+// the new model's UUID. The new model provisions its own machines
+// rather than adopting those of the source model.
+func (c *Client) CloneModel(spec CloneModelSpec) (string, error) {
+	if err := spec.Validate(); err != nil {
+		return "", errors.Trace(err)
+	}
+	args := params.CloneModelArgs{
+		ModelTag: names.NewModelTag(spec.ModelUUID).String(),
+		Name:     spec.Name,
+		TargetInfo: params.ModelMigrationTargetInfo{
+			ControllerTag: names.NewModelTag(spec.TargetControllerUUID).String(),
+			Addrs:         spec.TargetAddrs,
+			CACert:        spec.TargetCACert,
+			AuthTag:       names.NewUserTag(spec.TargetUser).String(),
+			Password:      spec.TargetPassword,
+		},
+	}
+	var result params.CloneModelResult
+	if err := c.facade.FacadeCall("CloneModel", args, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	modelTag, err := names.ParseModelTag(result.ModelTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return modelTag.Id(), nil
+}
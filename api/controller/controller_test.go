@@ -14,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/controller"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
@@ -114,6 +115,61 @@ func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	c.Assert(blocks, gc.HasLen, 0)
 }
 
+func (s *controllerSuite) TestEnableMaintenance(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	expires := time.Now().Add(time.Hour)
+	err := sysManager.EnableMaintenance("upgrading mongo", expires)
+	c.Assert(err, jc.ErrorIsNil)
+
+	maintenance, err := s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance.Reason, gc.Equals, "upgrading mongo")
+	c.Assert(maintenance.Owner, gc.Equals, s.AdminUserTag(c))
+	c.Assert(maintenance.Expires.IsZero(), jc.IsFalse)
+}
+
+func (s *controllerSuite) TestDisableMaintenance(c *gc.C) {
+	err := s.State.EnableMaintenance(s.AdminUserTag(c), "", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sysManager := s.OpenAPI(c)
+	err = sysManager.DisableMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ControllerMaintenance()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestReplicaSetStatus(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "ReplicaSetStatus")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.ReplicaSetStatusResult)) = params.ReplicaSetStatusResult{
+				Members: []params.ReplicaSetMember{{
+					MachineTag: "machine-0",
+					Address:    "10.0.0.1:37017",
+					State:      "PRIMARY",
+					Healthy:    true,
+					Votes:      1,
+				}},
+			}
+			return nil
+		},
+	)
+	client := controller.NewClient(apiCaller)
+	members, err := client.ReplicaSetStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(members, jc.DeepEquals, []params.ReplicaSetMember{{
+		MachineTag: "machine-0",
+		Address:    "10.0.0.1:37017",
+		State:      "PRIMARY",
+		Healthy:    true,
+		Votes:      1,
+	}})
+}
+
 func (s *controllerSuite) TestWatchAllModels(c *gc.C) {
 	// The WatchAllModels infrastructure is comprehensively tested
 	// else. This test just ensure that the API calls work end-to-end.
@@ -209,6 +265,60 @@ func (s *controllerSuite) TestInitiateModelMigrationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestCloneModel(c *gc.C) {
+	modelUUID := randomUUID()
+	controllerUUID := randomUUID()
+	newUUID := randomUUID()
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "CloneModel")
+			c.Check(arg, jc.DeepEquals, params.CloneModelArgs{
+				ModelTag: names.NewModelTag(modelUUID).String(),
+				Name:     "copy",
+				TargetInfo: params.ModelMigrationTargetInfo{
+					ControllerTag: names.NewModelTag(controllerUUID).String(),
+					Addrs:         []string{"1.2.3.4:5"},
+					CACert:        "cert",
+					AuthTag:       "user-someone",
+					Password:      "secret",
+				},
+			})
+			*(result.(*params.CloneModelResult)) = params.CloneModelResult{
+				ModelTag: names.NewModelTag(newUUID).String(),
+			}
+			return nil
+		},
+	)
+	client := controller.NewClient(apiCaller)
+	uuid, err := client.CloneModel(controller.CloneModelSpec{
+		ModelUUID:            modelUUID,
+		Name:                 "copy",
+		TargetControllerUUID: controllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuid, gc.Equals, newUUID)
+}
+
+func (s *controllerSuite) TestCloneModelInvalidName(c *gc.C) {
+	client := s.OpenAPI(c)
+	uuid, err := client.CloneModel(controller.CloneModelSpec{
+		ModelUUID:            randomUUID(),
+		Name:                 "Not Valid",
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	})
+	c.Check(uuid, gc.Equals, "")
+	c.Check(err, gc.ErrorMatches, `model name "Not Valid" not valid`)
+}
+
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
@@ -63,6 +63,17 @@ func (api *API) ListSubnets(args params.SubnetsFilters) (params.ListSubnetsResul
 	return result, nil
 }
 
+// ReconcileSpaceDrift reports the differences between the provider's
+// spaces and subnets and those in the model, and returns the drift as
+// recorded by the controller.
+func (api *API) ReconcileSpaceDrift(args params.ReconcileSpaceDriftParams) (params.SpaceDriftResults, error) {
+	var result params.SpaceDriftResults
+	if err := api.facade.FacadeCall("ReconcileSpaceDrift", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // ModelConfig returns the current model configuration.
 func (api *API) ModelConfig() (*config.Config, error) {
 	var result params.ModelConfigResult
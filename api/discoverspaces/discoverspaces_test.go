@@ -112,6 +112,23 @@ func (s *DiscoverSpacesSuite) TestCreateSpaces(c *gc.C) {
 	c.Assert(called, gc.Equals, 1)
 }
 
+func (s *DiscoverSpacesSuite) TestReconcileSpaceDrift(c *gc.C) {
+	var called int
+	expectedResult := params.SpaceDriftResults{
+		Results: []params.SpaceDrift{{Kind: "subnet-removed", CIDR: "10.0.0.0/24", Resolved: true}},
+	}
+	expectedArgs := params.ReconcileSpaceDriftParams{
+		Drift: []params.SpaceDrift{{Kind: "subnet-removed", CIDR: "10.0.0.0/24"}},
+	}
+	apiCaller := successAPICaller(c, "ReconcileSpaceDrift", expectedArgs, expectedResult, &called)
+	api := discoverspaces.NewAPI(apiCaller)
+
+	result, err := api.ReconcileSpaceDrift(expectedArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expectedResult)
+	c.Assert(called, gc.Equals, 1)
+}
+
 func (s *DiscoverSpacesSuite) TestModelConfig(c *gc.C) {
 	var called int
 	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig())
@@ -25,10 +25,11 @@ var facadeVersions = map[string]int{
 	"Application":                  1,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
-	"Block":                        2,
+	"Block":                        3,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
+	"Cloud":                        1,
 	"Client":                       1,
 	"Controller":                   3,
 	"Deployer":                     1,
@@ -37,7 +38,7 @@ var facadeVersions = map[string]int{
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
 	"Firewaller":                   3,
-	"HighAvailability":             2,
+	"HighAvailability":             3,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                2,
@@ -48,6 +49,7 @@ var facadeVersions = map[string]int{
 	"LifeFlag":                     1,
 	"Logger":                       1,
 	"MachineActions":               1,
+	"MachineFirewall":              1,
 	"MachineManager":               2,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -68,6 +70,7 @@ var facadeVersions = map[string]int{
 	"RelationUnitsWatcher":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
 	"Singular":                     1,
 	"Spaces":                       2,
 	"SSHClient":                    1,
@@ -78,7 +81,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       4,
+	"Uniter":                       5,
 	"Upgrader":                     1,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
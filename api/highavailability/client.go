@@ -38,12 +38,24 @@ func NewClient(caller base.APICallCloser) *Client {
 func (c *Client) EnableHA(
 	numControllers int, cons constraints.Value, series string, placement []string,
 ) (params.ControllersChanges, error) {
+	return c.EnableHAWithWitnesses(numControllers, 0, cons, series, placement)
+}
 
+// EnableHAWithWitnesses ensures the availability of Juju controllers,
+// keeping numWitnesses non-voting witness controllers ready to stand
+// in for unhealthy voting controllers.
+func (c *Client) EnableHAWithWitnesses(
+	numControllers, numWitnesses int, cons constraints.Value, series string, placement []string,
+) (params.ControllersChanges, error) {
+	if numWitnesses > 0 && c.facade.BestAPIVersion() < 3 {
+		return params.ControllersChanges{}, errors.NotImplementedf("EnableHAWithWitnesses() (need V3+)")
+	}
 	var results params.ControllersChangeResults
 	arg := params.ControllersSpecs{
 		Specs: []params.ControllersSpec{{
 			ModelTag:       c.modelTag.String(),
 			NumControllers: numControllers,
+			NumWitnesses:   numWitnesses,
 			Constraints:    cons,
 			Series:         series,
 			Placement:      placement,
@@ -77,5 +77,19 @@ func (s *clientSuite) TestClientEnableHA(c *gc.C) {
 
 func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
-	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
+	c.Assert(client.BestAPIVersion(), gc.Equals, 3)
+}
+
+func (s *clientSuite) TestClientEnableHAWithWitnesses(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	pingerA := setAgentPresence(c, &s.JujuConnSuite, "0")
+	defer assertKill(c, pingerA)
+
+	client := highavailability.NewClient(s.APIState)
+	result, err := client.EnableHAWithWitnesses(3, 1, constraints.Value{}, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Maintained, gc.DeepEquals, []string{"machine-0"})
+	c.Assert(result.Added, gc.DeepEquals, []string{"machine-1", "machine-2"})
+	c.Assert(result.AddedWitnesses, gc.DeepEquals, []string{"machine-3"})
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/api/unitassigner"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/api/upgrader"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
 )
@@ -153,6 +154,10 @@ type Connection interface {
 	Login(name names.Tag, password, nonce string, ms []macaroon.Slice) error
 	ServerVersion() (version.Number, bool)
 
+	// Maintenance returns the controller's maintenance mode as
+	// reported during Login, or nil if it is not in maintenance mode.
+	Maintenance() *params.MaintenanceInfo
+
 	// APICaller provides the facility to make API calls directly.
 	// This should not be used outside the api/* packages or tests.
 	base.APICaller
@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinefirewall implements the client-side API facade used
+// by the machinefirewall worker.
+package machinefirewall
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+// Facade provides access to the MachineFirewall API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side MachineFirewall facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "MachineFirewall"),
+	}
+}
+
+// FirewallMode returns the firewall mode of the model.
+func (f *Facade) FirewallMode() (string, error) {
+	var result params.StringResult
+	if err := f.caller.FacadeCall("FirewallMode", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// ModelIngressCIDRs returns the CIDRs of the model's machines and
+// their subnets, from which every machine must remain reachable.
+func (f *Facade) ModelIngressCIDRs() ([]string, error) {
+	var result params.StringsResult
+	if err := f.caller.FacadeCall("ModelIngressCIDRs", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
+// WatchModelIngressCIDRs returns a NotifyWatcher which notifies when
+// the CIDRs returned by ModelIngressCIDRs may have changed.
+func (f *Facade) WatchModelIngressCIDRs() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := f.caller.FacadeCall("WatchModelIngressCIDRs", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
+
+// WatchExposedPorts returns a NotifyWatcher which notifies when the
+// port ranges to allow in on the given machine may have changed.
+func (f *Facade) WatchExposedPorts(machineId string) (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	if err := f.caller.FacadeCall("WatchExposedPorts", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
+
+// ExposedPortRanges returns the port ranges opened on the given
+// machine by units of exposed applications.
+func (f *Facade) ExposedPortRanges(machineId string) ([]network.PortRange, error) {
+	var results params.MachinePortRangesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	if err := f.caller.FacadeCall("ExposedPortRanges", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	ranges := make([]network.PortRange, len(result.Ranges))
+	for i, portRange := range result.Ranges {
+		ranges[i] = portRange.NetworkPortRange()
+	}
+	return ranges, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinefirewall_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/machinefirewall"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestFirewallMode(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "MachineFirewall")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.StringResult) = params.StringResult{Result: "machine"}
+		return nil
+	})
+	facade := machinefirewall.NewFacade(apiCaller)
+
+	mode, err := facade.FirewallMode()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mode, gc.Equals, "machine")
+	stub.CheckCalls(c, []testing.StubCall{{"FirewallMode", []interface{}{nil}}})
+}
+
+func (s *facadeSuite) TestModelIngressCIDRs(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "MachineFirewall")
+		stub.AddCall(request, args)
+		*response.(*params.StringsResult) = params.StringsResult{
+			Result: []string{"10.0.0.0/24", "10.0.0.5/32"},
+		}
+		return nil
+	})
+	facade := machinefirewall.NewFacade(apiCaller)
+	cidrs, err := facade.ModelIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/24", "10.0.0.5/32"})
+	stub.CheckCalls(c, []testing.StubCall{{"ModelIngressCIDRs", []interface{}{nil}}})
+}
+
+func (s *facadeSuite) TestExposedPortRanges(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "MachineFirewall")
+		stub.AddCall(request, args)
+		*response.(*params.MachinePortRangesResults) = params.MachinePortRangesResults{
+			Results: []params.MachinePortRangesResult{{
+				Ranges: []params.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+			}},
+		}
+		return nil
+	})
+	facade := machinefirewall.NewFacade(apiCaller)
+
+	ranges, err := facade.ExposedPortRanges("42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ranges, jc.DeepEquals, []network.PortRange{{80, 80, "tcp"}})
+	stub.CheckCalls(c, []testing.StubCall{{
+		"ExposedPortRanges", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestExposedPortRangesInnerError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.MachinePortRangesResults) = params.MachinePortRangesResults{
+			Results: []params.MachinePortRangesResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := machinefirewall.NewFacade(apiCaller)
+
+	_, err := facade.ExposedPortRanges("42")
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestWatchExposedPortsCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := machinefirewall.NewFacade(apiCaller)
+
+	_, err := facade.WatchExposedPorts("42")
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
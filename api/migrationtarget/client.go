@@ -4,6 +4,7 @@
 package migrationtarget
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
@@ -18,6 +19,11 @@ type Client interface {
 	// controller.
 	Import([]byte) error
 
+	// ImportFresh takes a serialized model and imports it into the
+	// target controller as a fresh deployment of a new model with
+	// the given name, returning the new model's UUID.
+	ImportFresh([]byte, string) (string, error)
+
 	// Abort removes all data relating to a previously imported
 	// model.
 	Abort(string) error
@@ -42,6 +48,20 @@ func (c *client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// ImportFresh implements Client.
+func (c *client) ImportFresh(bytes []byte, name string) (string, error) {
+	args := params.ImportFreshModelArgs{Bytes: bytes, Name: name}
+	var result params.ImportFreshModelResult
+	if err := c.caller.FacadeCall("ImportFresh", args, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	tag, err := names.ParseModelTag(result.ModelTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return tag.Id(), nil
+}
+
 // Abort implements Client.
 func (c *client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
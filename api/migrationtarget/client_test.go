@@ -42,6 +42,32 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestImportFresh(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	_, err := client.ImportFresh([]byte("foo"), "clone")
+
+	expectedArg := params.ImportFreshModelArgs{Bytes: []byte("foo"), Name: "clone"}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ImportFresh", []interface{}{"", expectedArg}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestImportFreshResult(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ImportFreshModelResult)) = params.ImportFreshModelResult{
+			ModelTag: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d").String(),
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	uuid, err := client.ImportFresh([]byte("foo"), "clone")
+	c.Assert(err, gc.IsNil)
+	c.Assert(uuid, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/permission"
 )
 
@@ -52,16 +53,27 @@ func (c *Client) ConfigSkeleton(provider, region string) (params.ModelConfig, er
 }
 
 // CreateModel creates a new model using the account and
-// model config specified in the args.
-func (c *Client) CreateModel(owner string, account, config map[string]interface{}) (params.Model, error) {
+// model config specified in the args. If cloudName is empty,
+// the model is created in the controller's cloud; if cloudRegion
+// is empty, in the controller's region or the cloud's default
+// region. If credential is not empty, it names a credential
+// stored in the controller for the owner and cloud, which the
+// model will use.
+func (c *Client) CreateModel(
+	owner, cloudName, cloudRegion, credential string,
+	account, config map[string]interface{},
+) (params.Model, error) {
 	var result params.Model
 	if !names.IsValidUser(owner) {
 		return result, errors.Errorf("invalid owner name %q", owner)
 	}
 	createArgs := params.ModelCreateArgs{
-		OwnerTag: names.NewUserTag(owner).String(),
-		Account:  account,
-		Config:   config,
+		OwnerTag:        names.NewUserTag(owner).String(),
+		CloudName:       cloudName,
+		CloudRegion:     cloudRegion,
+		CloudCredential: credential,
+		Account:         account,
+		Config:          config,
 	}
 	err := c.facade.FacadeCall("CreateModel", createArgs, &result)
 	if err != nil {
@@ -118,6 +130,92 @@ func (c *Client) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, err
 	return results.Results, nil
 }
 
+// ModelDefaults returns the default values of the model config
+// attributes at each level at which they are set.
+func (c *Client) ModelDefaults() (config.ModelDefaultAttributes, error) {
+	var result params.ModelDefaultsResult
+	err := c.facade.FacadeCall("ModelDefaults", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values := make(config.ModelDefaultAttributes)
+	for attr, val := range result.Config {
+		defaults := config.AttributeDefaultValues{
+			Default:    val.Default,
+			Controller: val.Controller,
+		}
+		for _, region := range val.Regions {
+			defaults.Regions = append(defaults.Regions, config.RegionDefaultValue{
+				CloudName: region.CloudName,
+				Name:      region.RegionName,
+				Value:     region.Value,
+			})
+		}
+		values[attr] = defaults
+	}
+	return values, nil
+}
+
+// SetModelDefaults sets the model defaults of the given cloud region,
+// or the controller-wide model defaults if cloudRegion is empty. If
+// cloudName is empty, the region is in the controller's cloud.
+func (c *Client) SetModelDefaults(cloudName, cloudRegion string, attrs map[string]interface{}) error {
+	args := params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			CloudName:   cloudName,
+			CloudRegion: cloudRegion,
+			Config:      attrs,
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("SetModelDefaults", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// UnsetModelDefaults removes the given keys from the model defaults of
+// the given cloud region, or from the controller-wide model defaults if
+// cloudRegion is empty.
+func (c *Client) UnsetModelDefaults(cloudName, cloudRegion string, keys ...string) error {
+	args := params.UnsetModelDefaults{
+		Keys: []params.ModelUnsetKeys{{
+			CloudName:   cloudName,
+			CloudRegion: cloudRegion,
+			Keys:        keys,
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("UnsetModelDefaults", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// ChangeModelOwner transfers ownership of the model with the given
+// UUID to the named user.
+func (c *Client) ChangeModelOwner(modelUUID, owner string) error {
+	if !names.IsValidModel(modelUUID) {
+		return errors.Errorf("invalid model: %q", modelUUID)
+	}
+	if !names.IsValidUser(owner) {
+		return errors.Errorf("invalid username: %q", owner)
+	}
+	args := params.ChangeModelOwners{
+		Changes: []params.ChangeModelOwner{{
+			ModelTag: names.NewModelTag(modelUUID).String(),
+			OwnerTag: names.NewUserTag(owner).String(),
+		}},
+	}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("ChangeModelOwner", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
 // ParseModelAccess parses an access permission argument into
 // a type suitable for making an API facade call.
 func ParseModelAccess(access string) (params.ModelAccessPermission, error) {
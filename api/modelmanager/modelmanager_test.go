@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	jujutesting "github.com/juju/juju/juju/testing"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
@@ -52,13 +53,13 @@ func (s *modelmanagerSuite) TestConfigSkeleton(c *gc.C) {
 
 func (s *modelmanagerSuite) TestCreateModelBadUser(c *gc.C) {
 	modelManager := s.OpenAPI(c)
-	_, err := modelManager.CreateModel("not a user", nil, nil)
+	_, err := modelManager.CreateModel("not a user", "", "", "", nil, nil)
 	c.Assert(err, gc.ErrorMatches, `invalid owner name "not a user"`)
 }
 
 func (s *modelmanagerSuite) TestCreateModelMissingConfig(c *gc.C) {
 	modelManager := s.OpenAPI(c)
-	_, err := modelManager.CreateModel("owner", nil, nil)
+	_, err := modelManager.CreateModel("owner", "", "", "", nil, nil)
 	c.Assert(err, gc.ErrorMatches, `failed to create config: creating config from values failed: name: expected string, got nothing`)
 }
 
@@ -66,7 +67,7 @@ func (s *modelmanagerSuite) TestCreateModel(c *gc.C) {
 	modelManager := s.OpenAPI(c)
 	user := s.Factory.MakeUser(c, nil)
 	owner := user.UserTag().Canonical()
-	newEnv, err := modelManager.CreateModel(owner, nil, map[string]interface{}{
+	newEnv, err := modelManager.CreateModel(owner, "", "", "", nil, map[string]interface{}{
 		"name":            "new-model",
 		"authorized-keys": "ssh-key",
 		// dummy needs controller
@@ -78,6 +79,35 @@ func (s *modelmanagerSuite) TestCreateModel(c *gc.C) {
 	c.Assert(utils.IsValidUUIDString(newEnv.UUID), jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	err := modelManager.SetModelDefaults("", "", map[string]interface{}{
+		"apt-mirror": "http://mirror",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = modelManager.SetModelDefaults("dummy", "some-region", map[string]interface{}{
+		"apt-mirror": "http://regionmirror",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defaults, err := modelManager.ModelDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["apt-mirror"], jc.DeepEquals, config.AttributeDefaultValues{
+		Controller: "http://mirror",
+		Regions: []config.RegionDefaultValue{{
+			CloudName: "dummy",
+			Name:      "some-region",
+			Value:     "http://regionmirror",
+		}},
+	})
+
+	err = modelManager.UnsetModelDefaults("", "", "apt-mirror")
+	c.Assert(err, jc.ErrorIsNil)
+	defaults, err = modelManager.ModelDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["apt-mirror"].Controller, gc.IsNil)
+}
+
 func (s *modelmanagerSuite) TestListModelsBadUser(c *gc.C) {
 	modelManager := s.OpenAPI(c)
 	_, err := modelManager.ListModels("not a user")
@@ -101,3 +131,24 @@ func (s *modelmanagerSuite) TestListModels(c *gc.C) {
 	ownerNames := []string{models[0].Owner, models[1].Owner}
 	c.Assert(ownerNames, jc.DeepEquals, []string{"user@remote", "user@remote"})
 }
+
+func (s *modelmanagerSuite) TestChangeModelOwner(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "first"})
+	defer st.Close()
+
+	modelManager := s.OpenAPI(c)
+	err := modelManager.ChangeModelOwner(st.ModelUUID(), "user@remote")
+	c.Assert(err, jc.ErrorIsNil)
+
+	models, err := modelManager.ListModels("user@remote")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+	c.Assert(models[0].Name, gc.Equals, "first")
+	c.Assert(models[0].Owner, gc.Equals, "user@remote")
+}
+
+func (s *modelmanagerSuite) TestChangeModelOwnerBadUser(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	err := modelManager.ChangeModelOwner(s.State.ModelUUID(), "not a user")
+	c.Assert(err, gc.ErrorMatches, `invalid username: "not a user"`)
+}
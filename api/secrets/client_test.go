@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Secrets")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListSecrets")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ListSecretResults{})
+			*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+				Results: []params.SecretMetadata{{
+					ID:       "secret-id",
+					OwnerTag: "unit-wordpress-0",
+					Revision: 2,
+				}},
+			}
+			return nil
+		})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, []params.SecretMetadata{{
+		ID:       "secret-id",
+		OwnerTag: "unit-wordpress-0",
+		Revision: 2,
+	}})
+}
+
+func (s *secretsSuite) TestListSecretsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := secrets.NewClient(apiCaller)
+	_, err := client.ListSecrets()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -72,3 +72,14 @@ func (api *API) ListSpaces() ([]params.Space, error) {
 	}
 	return response.Results, err
 }
+
+// ListSpaceDrift returns the latest differences recorded between the
+// provider's spaces and subnets and those in the model.
+func (api *API) ListSpaceDrift() (params.SpaceDriftResults, error) {
+	var response params.SpaceDriftResults
+	err := api.facade.FacadeCall("ListSpaceDrift", nil, &response)
+	if params.IsCodeNotSupported(err) {
+		return response, errors.NewNotSupported(nil, err.Error())
+	}
+	return response, err
+}
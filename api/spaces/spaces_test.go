@@ -171,3 +171,23 @@ func (s *SpacesSuite) TestListSpacesManyResults(c *gc.C) {
 func (s *SpacesSuite) TestListSpacesServerError(c *gc.C) {
 	s.testListSpaces(c, nil, errors.New("boom"), "boom")
 }
+
+func (s *SpacesSuite) TestListSpaceDrift(c *gc.C) {
+	expectResults := params.SpaceDriftResults{
+		Results: []params.SpaceDrift{{
+			Kind:      "space-removed",
+			SpaceName: "dmz",
+			Conflict:  "space still has 1 subnet(s)",
+		}},
+	}
+	args := apitesting.CheckArgs{
+		Facade:  "Spaces",
+		Method:  "ListSpaceDrift",
+		Results: expectResults,
+	}
+	s.init(c, &args, nil)
+	results, err := s.api.ListSpaceDrift()
+	c.Assert(s.called, gc.Equals, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectResults)
+}
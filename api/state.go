@@ -114,9 +114,19 @@ func (st *state) loginForVersion(tag names.Tag, password, nonce string, macaroon
 	if err != nil {
 		return errors.Trace(err)
 	}
+	st.maintenance = result.Maintenance
 	return nil
 }
 
+// IdentityProvider returns the details of the OpenID Connect provider
+// that external users log in to the controller with. It may be called
+// before logging in.
+func IdentityProvider(caller base.APICaller) (params.IdentityProviderResult, error) {
+	var result params.IdentityProviderResult
+	err := caller.APICall("Admin", 3, "", "IdentityProvider", nil, &result)
+	return result, errors.Trace(err)
+}
+
 func (st *state) setLoginResult(tag names.Tag, modelTag, controllerTag string, servers [][]network.HostPort, facades []params.FacadeVersions) error {
 	st.authTag = tag
 	st.modelTag = modelTag
@@ -275,6 +285,12 @@ func (st *state) ServerVersion() (version.Number, bool) {
 	return st.serverVersion, st.serverVersion != version.Zero
 }
 
+// Maintenance returns the controller's maintenance mode as reported
+// during login, or nil if the controller was not in maintenance mode.
+func (st *state) Maintenance() *params.MaintenanceInfo {
+	return st.maintenance
+}
+
 // MetadataUpdater returns access to the imageMetadata API
 func (st *state) MetadataUpdater() *imagemetadata.Client {
 	return imagemetadata.NewClient(st)
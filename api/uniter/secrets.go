@@ -0,0 +1,141 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// AddSecret adds a secret owned by the given application or unit, or
+// rotates the value of the owner's existing secret with the same label,
+// and returns the secret's id.
+func (st *State) AddSecret(owner names.Tag, label string, data map[string]string) (string, error) {
+	if st.BestAPIVersion() < 5 {
+		return "", errors.NotImplementedf("AddSecret() (need V5+)")
+	}
+	args := params.AddSecretArgs{
+		Args: []params.AddSecretArg{{
+			OwnerTag: owner.String(),
+			Label:    label,
+			Data:     data,
+		}},
+	}
+	var results params.StringResults
+	if err := st.facade.FacadeCall("AddSecrets", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// SecretValue returns the value of the secret with the given id.
+func (st *State) SecretValue(id string) (map[string]string, error) {
+	if st.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("SecretValue() (need V5+)")
+	}
+	args := params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{ID: id}},
+	}
+	var results params.SecretValueResults
+	if err := st.facade.FacadeCall("GetSecretValues", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Data, nil
+}
+
+// GrantSecret grants the application at the other end of the given
+// relation access to the secret with the given id.
+func (st *State) GrantSecret(id string, relation names.RelationTag) error {
+	return st.changeSecretGrant("GrantSecrets", id, relation)
+}
+
+// RevokeSecret revokes access to the secret with the given id from
+// the application at the other end of the given relation.
+func (st *State) RevokeSecret(id string, relation names.RelationTag) error {
+	return st.changeSecretGrant("RevokeSecrets", id, relation)
+}
+
+func (st *State) changeSecretGrant(method, id string, relation names.RelationTag) error {
+	if st.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("%s() (need V5+)", method)
+	}
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{
+			ID:          id,
+			RelationTag: relation.String(),
+		}},
+	}
+	var results params.ErrorResults
+	if err := st.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchSecrets returns a watcher that notifies when the secrets the
+// unit has been granted access to may have changed.
+func (u *Unit) WatchSecrets() (watcher.NotifyWatcher, error) {
+	if u.st.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("WatchSecrets() (need V5+)")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("WatchSecrets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// SecretRevisions returns the current revision of each secret the
+// unit has been granted access to, keyed by secret id.
+func (u *Unit) SecretRevisions() (map[string]int, error) {
+	if u.st.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("SecretRevisions() (need V5+)")
+	}
+	var results params.SecretRevisionsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("SecretRevisions", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Revisions, nil
+}
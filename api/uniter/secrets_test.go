@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/watcher/watchertest"
+)
+
+type secretsSuite struct {
+	uniterSuite
+
+	apiUnit  *uniter.Unit
+	relation *state.Relation
+	mysql    *state.Application
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.uniterSuite.SetUpTest(c)
+
+	var err error
+	s.apiUnit, err = s.uniter.Unit(s.wordpressUnit.Tag().(names.UnitTag))
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, s.mysql, _ = s.addRelatedService(c, "wordpress", "mysql", s.wordpressUnit)
+}
+
+func (s *secretsSuite) TestAddSecret(c *gc.C) {
+	id, err := s.uniter.AddSecret(s.wordpressUnit.Tag(), "admin", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Label(), gc.Equals, "admin")
+	c.Check(secret.Revision(), gc.Equals, 1)
+
+	value, err := s.uniter.SecretValue(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *secretsSuite) TestAddSecretPermissionDenied(c *gc.C) {
+	_, err := s.uniter.AddSecret(s.mysql.Tag(), "", map[string]string{"password": "sekrit"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *secretsSuite) TestGrantAndRevokeSecret(c *gc.C) {
+	id, err := s.uniter.AddSecret(s.wordpressUnit.Tag(), "", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.GrantSecret(id, s.relation.Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), jc.DeepEquals, []state.SecretGrant{{
+		RelationKey: s.relation.String(),
+		Application: "mysql",
+	}})
+
+	err = s.uniter.RevokeSecret(id, s.relation.Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), gc.HasLen, 0)
+}
+
+func (s *secretsSuite) TestWatchSecrets(c *gc.C) {
+	w, err := s.apiUnit.WatchSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	mysqlUnit, err := s.State.Unit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: mysqlUnit.Tag(),
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.GrantSecret(id, s.relation, "wordpress", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	revisions, err := s.apiUnit.SecretRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, jc.DeepEquals, map[string]int{id: 1})
+}
@@ -711,6 +711,133 @@ func (u *Unit) AddStorage(constraints map[string][]params.StorageConstraints) er
 	return results.Combine()
 }
 
+// AddHookExecution records the execution of a hook, action or juju-run
+// command in the unit's hook history.
+func (u *Unit) AddHookExecution(execution params.HookExecution) error {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("AddHookExecution() (need V5+)")
+	}
+	args := params.HookExecutionArgs{
+		Args: []params.HookExecutionArg{{
+			Tag:       u.tag.String(),
+			Execution: execution,
+		}},
+	}
+	var result params.ErrorResults
+	if err := u.st.facade.FacadeCall("AddHookExecutions", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// State returns the charm state stored for the unit.
+func (u *Unit) State() (map[string]string, error) {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("State() (need V5+)")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	var results params.UnitStateResults
+	if err := u.st.facade.FacadeCall("UnitStates", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.State, nil
+}
+
+// UpdateState updates the charm state stored for the unit. Empty values
+// clear the corresponding keys.
+func (u *Unit) UpdateState(updates map[string]string) error {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("UpdateState() (need V5+)")
+	}
+	args := params.UpdateUnitStateArgs{
+		Args: []params.UpdateUnitStateArg{{
+			Tag:   u.tag.String(),
+			State: updates,
+		}},
+	}
+	var result params.ErrorResults
+	if err := u.st.facade.FacadeCall("UpdateUnitStates", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// SetWorkloadVersion sets the version of the workload the unit is
+// running.
+func (u *Unit) SetWorkloadVersion(version string) error {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("SetWorkloadVersion() (need V5+)")
+	}
+	args := params.EntityWorkloadVersions{
+		Entities: []params.EntityWorkloadVersion{{
+			Tag:             u.tag.String(),
+			WorkloadVersion: version,
+		}},
+	}
+	var result params.ErrorResults
+	if err := u.st.facade.FacadeCall("SetWorkloadVersion", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// GoalState returns the intended deployment around the unit: the
+// units of its application and the units expected on each of its
+// relations.
+func (u *Unit) GoalState() (params.GoalState, error) {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return params.GoalState{}, errors.NotImplementedf("GoalState() (need V5+)")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	var results params.GoalStateResults
+	if err := u.st.facade.FacadeCall("GoalStates", args, &results); err != nil {
+		return params.GoalState{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.GoalState{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.GoalState{}, result.Error
+	}
+	return *result.Result, nil
+}
+
+// CloudSpec returns the cloud the model is deployed to, including the
+// credential used to access it. The unit's application must have been
+// trusted with the credential.
+func (u *Unit) CloudSpec() (params.CloudSpec, error) {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return params.CloudSpec{}, errors.NotImplementedf("CloudSpec() (need V5+)")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	var results params.CloudSpecResults
+	if err := u.st.facade.FacadeCall("CloudSpec", args, &results); err != nil {
+		return params.CloudSpec{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.CloudSpec{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.CloudSpec{}, result.Error
+	}
+	return *result.Result, nil
+}
+
 // NetworkConfig requests network config information for the unit and the given
 // bindingName.
 func (u *Unit) NetworkConfig(bindingName string) ([]params.NetworkConfig, error) {
@@ -122,6 +122,82 @@ func (s *unitSuite) TestSetUnitStatus(c *gc.C) {
 	c.Assert(agentStatusInfo.Data, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestAddHookExecution(c *gc.C) {
+	started := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	err := s.apiUnit.AddHookExecution(params.HookExecution{
+		Kind:       "hook",
+		Name:       "config-changed",
+		RelationId: -1,
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		Output:     "configured\n",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.wordpressUnit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookExecution{{
+		Kind:       "hook",
+		Name:       "config-changed",
+		RelationId: -1,
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		Output:     "configured\n",
+	}})
+}
+
+func (s *unitSuite) TestState(c *gc.C) {
+	unitState, err := s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+
+	err = s.apiUnit.UpdateState(map[string]string{"initialised": "true", "cursor": "42"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.UpdateState(map[string]string{"cursor": ""})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err = s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"initialised": "true"})
+	unitState, err = s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"initialised": "true"})
+}
+
+func (s *unitSuite) TestSetWorkloadVersion(c *gc.C) {
+	err := s.apiUnit.SetWorkloadVersion("5.7.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	version, err := s.wordpressUnit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "5.7.1")
+}
+
+func (s *unitSuite) TestGoalState(c *gc.C) {
+	_, err := s.wordpressService.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	goalState, err := s.apiUnit.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(goalState.Units, gc.HasLen, 2)
+	c.Check(goalState.Units["wordpress/1"].Status, gc.Equals, "waiting")
+	c.Check(goalState.Relations, gc.HasLen, 0)
+}
+
+func (s *unitSuite) TestCloudSpec(c *gc.C) {
+	_, err := s.apiUnit.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" is not trusted with the cloud credential`)
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+
+	err = s.wordpressService.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	spec, err := s.apiUnit.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Type, gc.Equals, "dummy")
+	c.Assert(spec.Credential, gc.NotNil)
+	c.Assert(spec.Credential.AuthType, gc.Equals, "empty")
+}
+
 func (s *unitSuite) TestUnitStatus(c *gc.C) {
 	now := time.Now()
 	sInfo := status.StatusInfo{
@@ -72,9 +72,12 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 // newStateV4 creates a new client-side Uniter facade, version 4.
 var newStateV4 = newStateForVersionFn(4)
 
+// newStateV5 creates a new client-side Uniter facade, version 5.
+var newStateV5 = newStateForVersionFn(5)
+
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV4
+var NewState = newStateV5
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
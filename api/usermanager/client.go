@@ -112,6 +112,11 @@ func (c *Client) EnableUser(username string) error {
 	return c.userCall(username, "EnableUser")
 }
 
+// RemoveUser removes a user, revoking their access to all models.
+func (c *Client) RemoveUser(username string) error {
+	return c.userCall(username, "RemoveUser")
+}
+
 // IncludeDisabled is a type alias to avoid bare true/false values
 // in calls to the client method.
 type IncludeDisabled bool
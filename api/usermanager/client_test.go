@@ -135,6 +135,21 @@ func (s *usermanagerSuite) TestEnableUserBadName(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
 }
 
+func (s *usermanagerSuite) TestRemoveUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+
+	err := s.usermanager.RemoveUser(user.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = user.Refresh()
+	c.Assert(err, gc.ErrorMatches, `user "foobar" has been removed`)
+}
+
+func (s *usermanagerSuite) TestRemoveUserBadName(c *gc.C) {
+	err := s.usermanager.RemoveUser("not!good")
+	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
+}
+
 func (s *usermanagerSuite) TestCantRemoveAdminUser(c *gc.C) {
 	err := s.usermanager.DisableUser(s.AdminUserTag(c).Name())
 	c.Assert(err, gc.ErrorMatches, "failed to disable user: cannot disable controller model owner")
@@ -78,14 +78,25 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		kind, err = names.TagKind(req.AuthTag)
 		if err != nil || kind != names.UserTagKind {
 			isUser = false
-			// Users are not rate limited, all other entities are.
-			if !a.srv.limiter.Acquire() {
-				logger.Debugf("rate limiting for agent %s", req.AuthTag)
-				return fail, common.ErrTryAgain
+			// Agent logins are limited separately from user
+			// logins, so that users cannot starve agents.
+			if a.srv.limiter != nil {
+				if !a.srv.limiter.Acquire() {
+					logger.Debugf("rate limiting for agent %s", req.AuthTag)
+					return fail, common.ErrTryAgain
+				}
+				defer a.srv.limiter.Release()
 			}
-			defer a.srv.limiter.Release()
 		}
 	}
+	if isUser {
+		release, err := a.srv.clientLimits.acquireLogin(a.root.remoteAddr)
+		if err != nil {
+			logger.Debugf("rate limiting login from %s: %v", a.root.remoteAddr, err)
+			return fail, errors.Trace(err)
+		}
+		defer release()
+	}
 
 	serverOnlyLogin := a.root.modelUUID == ""
 
@@ -135,6 +146,15 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		a.reqNotifier.login(entity.Tag().String())
 	}
 
+	if isUser {
+		release, err := a.srv.clientLimits.acquireConnection(entity.Tag().String())
+		if err != nil {
+			logger.Debugf("rate limiting connection for %s: %v", entity.Tag(), err)
+			return fail, errors.Trace(err)
+		}
+		a.root.setQuotaRelease(release)
+	}
+
 	// We have authenticated the user; enable the appropriate API
 	// to serve to them.
 	a.loggedIn = true
@@ -145,6 +165,17 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		}
 	}
 
+	if isUser && serverOnlyLogin {
+		// External users may be granted controller access through
+		// their groups, by way of access to the controller model.
+		if userTag, ok := entity.Tag().(names.UserTag); ok && !userTag.IsLocal() {
+			_, err := a.root.state.SyncGroupModelUser(userTag)
+			if err != nil && !errors.IsNotFound(err) {
+				return fail, errors.Trace(err)
+			}
+		}
+	}
+
 	var maybeUserInfo *params.AuthUserInfo
 	var envUser *state.ModelUser
 	// Send back user info if user
@@ -203,6 +234,18 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 	if envUser != nil {
 		authedApi = newClientAuthRoot(authedApi, envUser)
 	}
+	if isUser {
+		// Users may only read from the controller while it is in
+		// maintenance mode; agents are not affected.
+		authedApi = newMaintenanceRoot(authedApi, a.srv.maintenance)
+		maintenance, err := a.srv.maintenance.get()
+		if err == nil {
+			loginResult.Maintenance = maintenanceInfo(maintenance)
+		} else if !errors.IsNotFound(err) {
+			return fail, errors.Trace(err)
+		}
+		authedApi = newRateLimitedRoot(authedApi, a.srv.clientLimits, entity.Tag().String(), a.root.remoteAddr)
+	}
 
 	a.root.rpcConn.ServeFinder(authedApi, serverError)
 
@@ -320,19 +363,29 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 	if !ok {
 		return f.st.FindEntity(tag)
 	}
-	modelUser, err := f.st.ModelUser(utag)
-	if err != nil {
-		return nil, err
-	}
-	u := &modelUserEntity{
-		modelUser: modelUser,
-	}
 	if utag.IsLocal() {
+		modelUser, err := f.st.ModelUser(utag)
+		if err != nil {
+			return nil, err
+		}
 		user, err := f.st.User(utag)
 		if err != nil {
 			return nil, err
 		}
-		u.user = user
+		return &modelUserEntity{modelUser: modelUser, user: user}, nil
+	}
+	// External users may have been granted access to the model
+	// through their groups.
+	modelUser, err := f.st.SyncGroupModelUser(utag)
+	if err != nil {
+		return nil, err
+	}
+	u := &modelUserEntity{modelUser: modelUser}
+	externalUser, err := f.st.ExternalUser(utag)
+	if err == nil {
+		u.externalUser = externalUser
+	} else if !errors.IsNotFound(err) {
+		return nil, err
 	}
 	return u, nil
 }
@@ -341,12 +394,15 @@ var _ loginEntity = &modelUserEntity{}
 
 // modelUserEntity encapsulates an model user
 // and, if the user is local, the local state user
-// as well. This enables us to implement FindEntity
+// as well, or if the user is external and has logged
+// in with an identity provider, the external user.
+// This enables us to implement FindEntity
 // in such a way that the authentication mechanisms
 // can work without knowing these details.
 type modelUserEntity struct {
-	modelUser *state.ModelUser
-	user      *state.User
+	modelUser    *state.ModelUser
+	user         *state.User
+	externalUser *state.ExternalUser
 }
 
 // Refresh implements state.Authenticator.Refresh.
@@ -389,6 +445,9 @@ func (u *modelUserEntity) LastLogin() (time.Time, error) {
 			// There's a global user, so use that login time instead.
 			return u.user.LastLogin()
 		}
+		if u.externalUser != nil {
+			return u.externalUser.LastLogin()
+		}
 		// Since we're implementing LastLogin, we need
 		// to implement LastLogin error semantics too.
 		err = state.NeverLoggedInError(err.Error())
@@ -399,11 +458,14 @@ func (u *modelUserEntity) LastLogin() (time.Time, error) {
 // UpdateLastLogin implements loginEntity.UpdateLastLogin.
 func (u *modelUserEntity) UpdateLastLogin() error {
 	err := u.modelUser.UpdateLastConnection()
+	var err1 error
 	if u.user != nil {
-		err1 := u.user.UpdateLastLogin()
-		if err == nil {
-			err = err1
-		}
+		err1 = u.user.UpdateLastLogin()
+	} else if u.externalUser != nil {
+		err1 = u.externalUser.UpdateLastLogin()
+	}
+	if err == nil {
+		err = err1
 	}
 	return err
 }
@@ -33,6 +33,7 @@ import (
 type baseLoginSuite struct {
 	jujutesting.JujuConnSuite
 	setAdminApi func(*apiserver.Server)
+	rateLimit   apiserver.RateLimitConfig
 }
 
 type loginSuite struct {
@@ -50,6 +51,7 @@ var _ = gc.Suite(&loginSuite{
 func (s *baseLoginSuite) SetUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
 	loggo.GetLogger("juju.apiserver").SetLogLevel(loggo.TRACE)
+	s.rateLimit = apiserver.RateLimitConfig{}
 }
 
 func (s *baseLoginSuite) setupServer(c *gc.C) (api.Connection, func()) {
@@ -392,6 +394,77 @@ func (s *loginSuite) TestLoginRateLimited(c *gc.C) {
 	}
 }
 
+func (s *loginSuite) TestUserConnectionsLimited(c *gc.C) {
+	s.rateLimit.MaxUserConnections = 1
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+	adminUser := s.AdminUserTag(c)
+
+	st := s.openAPIWithoutLogin(c, info)
+	err := st.Login(adminUser, "dummy-secret", "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	other := s.openAPIWithoutLogin(c, info)
+	defer other.Close()
+	err = other.Login(adminUser, "dummy-secret", "", nil)
+	c.Assert(err, jc.Satisfies, params.IsCodeRateLimitExceeded)
+	c.Assert(err, gc.ErrorMatches, `rate limit exceeded: too many connections for user-admin@local`)
+
+	// Once the first connection is closed, the user may connect again.
+	st.Close()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		again := s.openAPIWithoutLogin(c, info)
+		err = again.Login(adminUser, "dummy-secret", "", nil)
+		again.Close()
+		if !params.IsCodeRateLimitExceeded(err) {
+			break
+		}
+	}
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *loginSuite) TestUserRequestsRateLimited(c *gc.C) {
+	s.rateLimit.UserRequestRate = 0.001
+	s.rateLimit.UserRequestBurst = 1
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+
+	st := s.openAPIWithoutLogin(c, info)
+	defer st.Close()
+	err := st.Login(s.AdminUserTag(c), "dummy-secret", "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = st.Client().AgentVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.Client().AgentVersion()
+	c.Assert(err, jc.Satisfies, params.IsCodeRateLimitExceeded)
+	apiErr, ok := errors.Cause(err).(*params.Error)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(apiErr.Info, gc.NotNil)
+	c.Assert(apiErr.Info.RetryAfter > 0, jc.IsTrue)
+
+	// Pings are never limited.
+	err = st.Ping()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *loginSuite) TestAgentsNotRequestRateLimited(c *gc.C) {
+	s.rateLimit.UserRequestRate = 0.001
+	s.rateLimit.UserRequestBurst = 1
+	s.rateLimit.IPRequestRate = 0.001
+	s.rateLimit.IPRequestBurst = 1
+	info, cleanup := s.setupMachineAndServer(c)
+	defer cleanup()
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	for i := 0; i < 3; i++ {
+		_, err := apimachiner.NewState(st).Machine(info.Tag.(names.MachineTag))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
 func (s *loginSuite) TestUsersLoginWhileRateLimited(c *gc.C) {
 	info, cleanup := s.setupMachineAndServer(c)
 	defer cleanup()
@@ -581,6 +654,7 @@ func (s *baseLoginSuite) setupServerForEnvironmentWithValidator(c *gc.C, modelTa
 			Validator: validator,
 			Tag:       names.NewMachineTag("0"),
 			LogDir:    c.MkDir(),
+			RateLimit: s.rateLimit,
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
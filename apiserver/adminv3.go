@@ -4,6 +4,8 @@
 package apiserver
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 )
@@ -37,3 +39,20 @@ func (r *adminApiV3) Admin(id string) (*adminApiV3, error) {
 func (a *adminApiV3) Login(req params.LoginRequest) (params.LoginResultV1, error) {
 	return a.doLogin(req, 3)
 }
+
+// IdentityProvider returns the details of the OpenID Connect provider
+// that external users log in with, so that clients can obtain an ID
+// token to log in with.
+func (a *adminApiV3) IdentityProvider() (params.IdentityProviderResult, error) {
+	cfg, err := a.srv.state.ModelConfig()
+	if err != nil {
+		return params.IdentityProviderResult{}, errors.Trace(err)
+	}
+	if cfg.IdentityClientID() == "" {
+		return params.IdentityProviderResult{}, errors.NotFoundf("OpenID Connect identity provider")
+	}
+	return params.IdentityProviderResult{
+		Issuer:   cfg.IdentityURL(),
+		ClientID: cfg.IdentityClientID(),
+	}, nil
+}
@@ -92,12 +92,27 @@ func (api *AgentAPIV2) getEntity(tag names.Tag) (result params.AgentGetEntitiesR
 	return
 }
 
-func (api *AgentAPIV2) StateServingInfo() (result state.StateServingInfo, err error) {
+func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err error) {
 	if !api.auth.AuthModelManager() {
 		err = common.ErrPerm
 		return
 	}
-	return api.st.StateServingInfo()
+	info, err := api.st.StateServingInfo()
+	if err != nil {
+		return result, err
+	}
+	// The secrets key is not stored in the database, so it is
+	// passed on from the controller serving the request.
+	return params.StateServingInfo{
+		APIPort:        info.APIPort,
+		StatePort:      info.StatePort,
+		Cert:           info.Cert,
+		PrivateKey:     info.PrivateKey,
+		CAPrivateKey:   info.CAPrivateKey,
+		SharedSecret:   info.SharedSecret,
+		SecretsKey:     api.st.SecretsKey(),
+		SystemIdentity: info.SystemIdentity,
+	}, nil
 }
 
 // MongoIsMaster is called by the IsMaster API call
@@ -31,10 +31,6 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver")
 
-// loginRateLimit defines how many concurrent Login requests we will
-// accept
-const loginRateLimit = 10
-
 // Server holds the server side of the API.
 type Server struct {
 	tomb              tomb.Tomb
@@ -46,10 +42,12 @@ type Server struct {
 	dataDir           string
 	logDir            string
 	limiter           utils.Limiter
+	clientLimits      *clientLimits
 	validator         LoginValidator
 	adminApiFactories map[int]adminApiFactory
 	modelUUID         string
 	authCtxt          *authContext
+	maintenance       *maintenanceCache
 	connections       int32 // count of active websocket connections
 }
 
@@ -68,6 +66,9 @@ type ServerConfig struct {
 	Validator   LoginValidator
 	CertChanged chan params.StateServingInfo
 
+	// RateLimit holds the limits placed on logins and requests.
+	RateLimit RateLimitConfig
+
 	// This field only exists to support testing.
 	StatePool *state.StatePool
 }
@@ -198,18 +199,21 @@ func newServer(s *state.State, lis *net.TCPListener, cfg ServerConfig) (_ *Serve
 		stPool = state.NewStatePool(s)
 	}
 
+	rateLimit := cfg.RateLimit.withDefaults()
 	srv := &Server{
-		state:     s,
-		statePool: stPool,
-		lis:       newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
-		tag:       cfg.Tag,
-		dataDir:   cfg.DataDir,
-		logDir:    cfg.LogDir,
-		limiter:   utils.NewLimiter(loginRateLimit),
-		validator: cfg.Validator,
+		state:        s,
+		statePool:    stPool,
+		lis:          newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
+		tag:          cfg.Tag,
+		dataDir:      cfg.DataDir,
+		logDir:       cfg.LogDir,
+		limiter:      newConcurrencyLimiter(rateLimit.AgentLoginConcurrency),
+		clientLimits: newClientLimits(rateLimit),
+		validator:    cfg.Validator,
 		adminApiFactories: map[int]adminApiFactory{
 			3: newAdminApiV3,
 		},
+		maintenance: newMaintenanceCache(s),
 	}
 	srv.authCtxt, err = newAuthContext(s)
 	if err != nil {
@@ -343,6 +347,12 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.mongoPinger())
 	}()
 
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.maintenance.watch(&srv.tomb))
+	}()
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -578,6 +588,10 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifie
 	if err != nil {
 		conn.ServeFinder(&errRoot{err}, serverError)
 	} else {
+		h.remoteAddr = remoteHost(wsConn.Request().RemoteAddr)
+		// Release any connection quota taken by the login once the
+		// connection has closed.
+		defer h.releaseQuota()
 		adminApis := make(map[int]interface{})
 		for apiVersion, factory := range srv.adminApiFactories {
 			adminApis[apiVersion] = factory(srv, h, reqNotifier)
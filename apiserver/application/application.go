@@ -11,6 +11,7 @@ import (
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -230,7 +231,7 @@ func parseSettingsCompatible(ch *state.Charm, settings map[string]string) (charm
 // All parameters in params.ApplicationUpdate except the application name are optional.
 func (api *API) Update(args params.ApplicationUpdate) error {
 	if !args.ForceCharmUrl {
-		if err := api.check.ChangeAllowed(); err != nil {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -274,7 +275,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 	// when forced units in error, don't block
 	if !args.ForceUnits {
-		if err := api.check.ChangeAllowed(); err != nil {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -378,7 +379,7 @@ func (api *API) GetCharmURL(args params.ApplicationGet) (params.StringResult, er
 // It does not unset values that are set to an empty string.
 // Unset should be used for that.
 func (api *API) Set(p params.ApplicationSet) error {
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(p.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(p.ApplicationName)
@@ -401,7 +402,7 @@ func (api *API) Set(p params.ApplicationSet) error {
 
 // Unset implements the server side of Client.Unset.
 func (api *API) Unset(p params.ApplicationUnset) error {
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(p.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(p.ApplicationName)
@@ -436,7 +437,7 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Expose(args params.ApplicationExpose) error {
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(args.ApplicationName)
@@ -449,7 +450,7 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Unexpose(args params.ApplicationUnexpose) error {
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(args.ApplicationName)
@@ -459,6 +460,55 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	return svc.ClearExposed()
 }
 
+// SetTrust grants or revokes an application's access to the model's
+// cloud credential, which its units may then read with credential-get.
+// Only administrators of the model may do so.
+func (api *API) SetTrust(args params.ApplicationTrust) error {
+	if err := common.CheckModelAdmin(common.NewModelAdminBackend(api.state), api.authorizer.GetAuthTag()); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	if args.Trusted {
+		return app.SetTrusted()
+	}
+	return app.ClearTrusted()
+}
+
+// SetEndpointBindings changes the spaces the endpoints of a deployed
+// application are bound to.
+func (api *API) SetEndpointBindings(args params.ApplicationSetEndpointBindings) error {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	bindings := args.EndpointBindings
+	if defaultSpace, ok := bindings[""]; ok {
+		current, err := app.EndpointBindings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		bindings = make(map[string]string, len(current))
+		for endpoint := range current {
+			bindings[endpoint] = defaultSpace
+		}
+		for endpoint, space := range args.EndpointBindings {
+			if endpoint != "" {
+				bindings[endpoint] = space
+			}
+		}
+	}
+	return app.SetEndpointBindings(bindings)
+}
+
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(st *state.State, args params.AddApplicationUnits) ([]*state.Unit, error) {
 	application, err := st.Application(args.ApplicationName)
@@ -473,7 +523,7 @@ func addApplicationUnits(st *state.State, args params.AddApplicationUnits) ([]*s
 
 // AddUnits adds a given number of units to an application.
 func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplicationUnitsResults, error) {
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
 	units, err := addApplicationUnits(api.state, args)
@@ -489,7 +539,7 @@ func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplication
 
 // DestroyUnits removes a given set of application units.
 func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
-	if err := api.check.RemoveAllowed(); err != nil {
+	if err := api.check.RemoveAllowedFor(unitApplicationTags(args.UnitNames)...); err != nil {
 		return errors.Trace(err)
 	}
 	var errs []string
@@ -513,9 +563,31 @@ func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 	return common.DestroyErr("units", args.UnitNames, errs)
 }
 
+// unitApplicationTags returns the tags of the applications of the
+// named units, ignoring any invalid unit names.
+func unitApplicationTags(unitNames []string) []names.Tag {
+	var tags []names.Tag
+	for _, name := range unitNames {
+		if appName, err := names.UnitApplication(name); err == nil {
+			tags = append(tags, names.NewApplicationTag(appName))
+		}
+	}
+	return tags
+}
+
+// endpointApplicationTags returns the tags of the applications of the
+// given endpoints.
+func endpointApplicationTags(eps []state.Endpoint) []names.Tag {
+	tags := make([]names.Tag, len(eps))
+	for i, ep := range eps {
+		tags[i] = names.NewApplicationTag(ep.ApplicationName)
+	}
+	return tags
+}
+
 // Destroy destroys a given application.
 func (api *API) Destroy(args params.ApplicationDestroy) error {
-	if err := api.check.RemoveAllowed(); err != nil {
+	if err := api.check.RemoveAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(args.ApplicationName)
@@ -537,7 +609,7 @@ func (api *API) GetConstraints(args params.GetApplicationConstraints) (params.Ge
 
 // SetConstraints sets the constraints for a given application.
 func (api *API) SetConstraints(args params.SetConstraints) error {
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(args.ApplicationName)
@@ -556,6 +628,9 @@ func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults,
 	if err != nil {
 		return params.AddRelationResults{}, err
 	}
+	if err := api.check.ChangeAllowedFor(endpointApplicationTags(inEps)...); err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
 	rel, err := api.state.AddRelation(inEps...)
 	if err != nil {
 		return params.AddRelationResults{}, err
@@ -580,6 +655,9 @@ func (api *API) DestroyRelation(args params.DestroyRelation) error {
 	if err != nil {
 		return err
 	}
+	if err := api.check.RemoveAllowedFor(endpointApplicationTags(eps)...); err != nil {
+		return errors.Trace(err)
+	}
 	rel, err := api.state.EndpointsRelation(eps...)
 	if err != nil {
 		return err
@@ -23,6 +23,7 @@ import (
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -1874,6 +1875,98 @@ func (s *serviceSuite) TestAddUnitToMachineNotFound(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `adding new machine to host unit "dummy/0": machine 42 not found`)
 }
 
+func (s *serviceSuite) TestServiceSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("public", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("internal", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
+
+	err = s.applicationApi.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName: "riak",
+		EndpointBindings: map[string]string{
+			"":      "public",
+			"admin": "internal",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	application, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, jc.DeepEquals, map[string]string{
+		"endpoint": "public",
+		"ring":     "public",
+		"admin":    "internal",
+	})
+}
+
+func (s *serviceSuite) TestServiceSetEndpointBindingsUnknownSpace(c *gc.C) {
+	s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
+
+	err := s.applicationApi.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName:  "riak",
+		EndpointBindings: map[string]string{"admin": "missing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "riak": unknown space "missing" not valid`)
+}
+
+func (s *serviceSuite) TestServiceSetTrust(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationApi.SetTrust(params.ApplicationTrust{
+		ApplicationName: "dummy-service",
+		Trusted:         true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsTrusted(), jc.IsTrue)
+
+	err = s.applicationApi.SetTrust(params.ApplicationTrust{
+		ApplicationName: "dummy-service",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsTrusted(), jc.IsFalse)
+
+	err = s.applicationApi.SetTrust(params.ApplicationTrust{
+		ApplicationName: "unknown-service",
+		Trusted:         true,
+	})
+	c.Assert(err, gc.ErrorMatches, `application "unknown-service" not found`)
+}
+
+func (s *serviceSuite) TestServiceSetTrustRequiresModelAdmin(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	user := s.Factory.MakeUser(c, &factory.UserParams{Access: state.ModelReadAccess})
+	api, err := application.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: user.UserTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.SetTrust(params.ApplicationTrust{
+		ApplicationName: "dummy-service",
+		Trusted:         true,
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	app, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsTrusted(), jc.IsFalse)
+}
+
+func (s *serviceSuite) TestServiceSetTrustBlocked(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestServiceSetTrustBlocked")
+	err := s.applicationApi.SetTrust(params.ApplicationTrust{
+		ApplicationName: "dummy-service",
+		Trusted:         true,
+	})
+	s.AssertBlocked(c, err, "TestServiceSetTrustBlocked")
+}
+
 func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
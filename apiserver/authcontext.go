@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/oidc"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/bakerystorage"
 )
@@ -32,6 +33,10 @@ type authContext struct {
 	macaroonAuthOnce   sync.Once
 	_macaroonAuth      *authentication.ExternalMacaroonAuthenticator
 	_macaroonAuthError error
+
+	// oidcAuthMutex guards the field below it.
+	oidcAuthMutex sync.Mutex
+	_oidcAuth     *authentication.OIDCAuthenticator
 }
 
 // newAuthContext creates a new authentication context for st.
@@ -58,6 +63,17 @@ func newAuthContext(st *state.State) (*authContext, error) {
 // by choosing the right kind of authentication for the given
 // tag.
 func (ctxt *authContext) Authenticate(entityFinder authentication.EntityFinder, tag names.Tag, req params.LoginRequest) (state.Entity, error) {
+	if userTag, ok := tag.(names.UserTag); ok && !userTag.IsLocal() && req.Credentials != "" {
+		// External users supply an ID token as their credentials
+		// if the controller uses an OpenID Connect provider.
+		auth, err := ctxt.oidcAuth()
+		if err == nil {
+			return auth.Authenticate(entityFinder, tag, req)
+		}
+		if errors.Cause(err) != errOIDCAuthNotConfigured {
+			return nil, errors.Trace(err)
+		}
+	}
 	auth, err := ctxt.authenticatorForTag(tag)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -146,6 +162,60 @@ func newExternalMacaroonAuth(st *state.State) (*authentication.ExternalMacaroonA
 	return &auth, nil
 }
 
+// oidcAuth returns an authenticator that can authenticate external users
+// logging in with ID tokens. Unlike macaroonAuth, failing to reach the
+// identity provider is not remembered, so that logins may succeed once
+// it is available again.
+func (ctxt *authContext) oidcAuth() (authentication.EntityAuthenticator, error) {
+	ctxt.oidcAuthMutex.Lock()
+	defer ctxt.oidcAuthMutex.Unlock()
+	if ctxt._oidcAuth == nil {
+		auth, err := newOIDCAuth(ctxt.st)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ctxt._oidcAuth = auth
+	}
+	return ctxt._oidcAuth, nil
+}
+
+var errOIDCAuthNotConfigured = errors.New("OpenID Connect authentication is not configured")
+
+// identityHTTPClient is used to communicate with the OpenID Connect
+// provider.
+var identityHTTPClient = http.DefaultClient
+
+// newOIDCAuth returns an authenticator that can authenticate external
+// users logging in with ID tokens issued by the controller's OpenID
+// Connect provider. This is just a helper function for
+// authCtxt.oidcAuth.
+func newOIDCAuth(st *state.State) (*authentication.OIDCAuthenticator, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get model config")
+	}
+	clientID := cfg.IdentityClientID()
+	if clientID == "" {
+		return nil, errOIDCAuthNotConfigured
+	}
+	provider, err := oidc.Discover(identityHTTPClient, cfg.IdentityURL())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &authentication.OIDCAuthenticator{
+		Verifier: &oidc.Verifier{
+			Provider:    provider,
+			ClientID:    clientID,
+			GroupsClaim: cfg.IdentityGroupsClaim(),
+			Client:      identityHTTPClient,
+			Clock:       state.GetClock(),
+		},
+		UpdateUser: func(tag names.UserTag, claims *oidc.Claims) error {
+			return st.UpdateExternalUser(tag, claims.Name, claims.Groups)
+		},
+	}, nil
+}
+
 // newBakeryService creates a new bakery.Service.
 func newBakeryService(
 	st *state.State,
@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/oidc"
+	"github.com/juju/juju/state"
+)
+
+// OIDCAuthenticator performs authentication for external users logging
+// in with an ID token issued by an OpenID Connect provider, which they
+// supply as their credentials.
+type OIDCAuthenticator struct {
+	// Verifier verifies the ID tokens.
+	Verifier *oidc.Verifier
+
+	// UpdateUser is called with the claims of the ID token of each
+	// user that authenticates, before the user's entity is found.
+	// It allows the user's details, including their groups, to be
+	// recorded for use in determining the user's access.
+	UpdateUser func(names.UserTag, *oidc.Claims) error
+}
+
+var _ EntityAuthenticator = (*OIDCAuthenticator)(nil)
+
+// Authenticate implements EntityAuthenticator.Authenticate.
+func (a *OIDCAuthenticator) Authenticate(
+	entityFinder EntityFinder, tag names.Tag, req params.LoginRequest,
+) (state.Entity, error) {
+	userTag, ok := tag.(names.UserTag)
+	if !ok || userTag.IsLocal() {
+		return nil, errors.Errorf("invalid request")
+	}
+	claims, err := a.Verifier.Verify(req.Credentials)
+	if err != nil {
+		logger.Debugf("ID token authentication failed: %v", err)
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	claimedTag, err := ClaimedUserTag(claims)
+	if err != nil {
+		logger.Debugf("ID token authentication failed: %v", err)
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if claimedTag.Canonical() != userTag.Canonical() {
+		logger.Debugf("ID token for %q used to log in as %q", claimedTag.Canonical(), userTag.Canonical())
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if a.UpdateUser != nil {
+		if err := a.UpdateUser(userTag, claims); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	entity, err := entityFinder.FindEntity(userTag)
+	if errors.IsNotFound(err) {
+		// The user is who they say they are, but has not
+		// been granted access.
+		return nil, errors.Trace(common.ErrPerm)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entity, nil
+}
+
+// ClaimedUserTag returns the tag of the external user identified by
+// the email address in the given claims. The provider must have
+// verified the address, as otherwise anyone able to register it with
+// the provider could log in as the user.
+func ClaimedUserTag(claims *oidc.Claims) (names.UserTag, error) {
+	if !claims.EmailVerified {
+		return names.UserTag{}, errors.Errorf("identity provider has not verified email %q", claims.Email)
+	}
+	if !names.IsValidUser(claims.Email) {
+		return names.UserTag{}, errors.NotValidf("user name %q", claims.Email)
+	}
+	tag := names.NewUserTag(claims.Email)
+	if tag.IsLocal() {
+		return names.UserTag{}, errors.Errorf("identity provider has provided ostensibly local name %q", claims.Email)
+	}
+	return tag, nil
+}
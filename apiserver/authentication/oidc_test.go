@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/oidc"
+	"github.com/juju/juju/oidc/oidctest"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type oidcAuthenticatorSuite struct {
+	coretesting.BaseSuite
+	issuer  *oidctest.Issuer
+	user    oidctest.User
+	updated []names.UserTag
+}
+
+var _ = gc.Suite(&oidcAuthenticatorSuite{})
+
+func (s *oidcAuthenticatorSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.user = oidctest.User{
+		Email:  "bob@example.com",
+		Name:   "Bob Brown",
+		Groups: []string{"devs"},
+	}
+	s.issuer = oidctest.NewIssuer("juju", s.user)
+	s.AddCleanup(func(*gc.C) { s.issuer.Close() })
+	s.updated = nil
+}
+
+func (s *oidcAuthenticatorSuite) authenticator(c *gc.C) *authentication.OIDCAuthenticator {
+	provider, err := oidc.Discover(s.issuer.Client(), s.issuer.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	return &authentication.OIDCAuthenticator{
+		Verifier: &oidc.Verifier{
+			Provider:    provider,
+			ClientID:    "juju",
+			GroupsClaim: "groups",
+			Client:      s.issuer.Client(),
+		},
+		UpdateUser: func(tag names.UserTag, claims *oidc.Claims) error {
+			c.Check(claims.Groups, jc.DeepEquals, []string{"devs"})
+			s.updated = append(s.updated, tag)
+			return nil
+		},
+	}
+}
+
+func (s *oidcAuthenticatorSuite) login(c *gc.C, tag names.UserTag, finder authentication.EntityFinder) error {
+	token := s.issuer.IDToken(s.user, time.Now().Add(time.Hour))
+	_, err := s.authenticator(c).Authenticate(finder, tag, params.LoginRequest{
+		AuthTag:     tag.String(),
+		Credentials: token,
+	})
+	return err
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticate(c *gc.C) {
+	tag := names.NewUserTag("bob@example.com")
+	finder := simpleEntityFinder{tag.String(): true}
+	err := s.login(c, tag, finder)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.updated, jc.DeepEquals, []names.UserTag{tag})
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateNoAccess(c *gc.C) {
+	tag := names.NewUserTag("bob@example.com")
+	err := s.login(c, tag, simpleEntityFinder{})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	// The user's details are still recorded.
+	c.Assert(s.updated, jc.DeepEquals, []names.UserTag{tag})
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateOtherUser(c *gc.C) {
+	tag := names.NewUserTag("alice@example.com")
+	finder := simpleEntityFinder{tag.String(): true}
+	err := s.login(c, tag, finder)
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+	c.Assert(s.updated, gc.HasLen, 0)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateEmailUnverified(c *gc.C) {
+	s.user.EmailUnverified = true
+	tag := names.NewUserTag("bob@example.com")
+	err := s.login(c, tag, simpleEntityFinder{tag.String(): true})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+	c.Assert(s.updated, gc.HasLen, 0)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateBadToken(c *gc.C) {
+	tag := names.NewUserTag("bob@example.com")
+	_, err := s.authenticator(c).Authenticate(simpleEntityFinder{}, tag, params.LoginRequest{
+		AuthTag:     tag.String(),
+		Credentials: "not-a-token",
+	})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+	c.Assert(s.updated, gc.HasLen, 0)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateLocalUser(c *gc.C) {
+	tag := names.NewLocalUserTag("bob")
+	err := s.login(c, tag, simpleEntityFinder{tag.String(): true})
+	c.Assert(err, gc.ErrorMatches, "invalid request")
+}
+
+func (s *oidcAuthenticatorSuite) TestClaimedUserTag(c *gc.C) {
+	tag, err := authentication.ClaimedUserTag(&oidc.Claims{
+		Email:         "bob@example.com",
+		EmailVerified: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, names.NewUserTag("bob@example.com"))
+
+	_, err = authentication.ClaimedUserTag(&oidc.Claims{Email: "", EmailVerified: true})
+	c.Assert(err, gc.ErrorMatches, `user name "" not valid`)
+
+	_, err = authentication.ClaimedUserTag(&oidc.Claims{Email: "bob@example.com"})
+	c.Assert(err, gc.ErrorMatches, `identity provider has not verified email "bob@example.com"`)
+}
@@ -52,6 +52,9 @@ var _ EntityAuthenticator = (*UserAuthenticator)(nil)
 //
 // If and only if no password is supplied, then Authenticate will check for any
 // valid macaroons. Otherwise, password authentication will be performed.
+// External users may only authenticate with macaroons created by
+// CreateLocalLoginMacaroon after they have logged in with their identity
+// provider.
 func (u *UserAuthenticator) Authenticate(
 	entityFinder EntityFinder, tag names.Tag, req params.LoginRequest,
 ) (state.Entity, error) {
@@ -59,7 +62,7 @@ func (u *UserAuthenticator) Authenticate(
 	if !ok {
 		return nil, errors.Errorf("invalid request")
 	}
-	if req.Credentials == "" && userTag.IsLocal() {
+	if req.Credentials == "" {
 		return u.authenticateMacaroons(entityFinder, userTag, req)
 	}
 	return u.AgentAuthenticator.Authenticate(entityFinder, tag, req)
@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -12,7 +13,7 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("Block", 2, NewAPI)
+	common.RegisterStandardFacade("Block", 3, NewAPI)
 }
 
 // Block defines the methods on the block API end point.
@@ -21,11 +22,11 @@ type Block interface {
 	List() (params.BlockResults, error)
 
 	// SwitchBlockOn switches desired block type on for this
-	// environment.
+	// environment, or for an application or machine in it.
 	SwitchBlockOn(params.BlockSwitchParams) params.ErrorResult
 
 	// SwitchBlockOff switches desired block type off for this
-	// environment.
+	// environment, or for an application or machine in it.
 	SwitchBlockOff(params.BlockSwitchParams) params.ErrorResult
 }
 
@@ -81,18 +82,55 @@ func convertBlock(b state.Block) params.BlockResult {
 		Tag:     tag.String(),
 		Type:    b.Type().String(),
 		Message: b.Message(),
+		Owner:   b.Owner(),
+	}
+	if created := b.Created(); !created.IsZero() {
+		result.Result.Created = &created
 	}
 	return result
 }
 
+// blockEntity returns the tag of the application or machine that a
+// block applies to, or nil if it applies to the whole model.
+func blockEntity(entity string) (names.Tag, error) {
+	if entity == "" {
+		return nil, nil
+	}
+	tag, err := names.ParseTag(entity)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag.(type) {
+	case names.ApplicationTag, names.MachineTag:
+		return tag, nil
+	}
+	return nil, errors.NotValidf("block on %s", names.ReadableString(tag))
+}
+
 // SwitchBlockOn implements Block.SwitchBlockOn().
 func (a *API) SwitchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
-	err := a.access.SwitchBlockOn(state.ParseBlockType(args.Type), args.Message)
+	entity, err := blockEntity(args.Entity)
+	if err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	blockArgs := state.BlockArgs{
+		Type:    state.ParseBlockType(args.Type),
+		Entity:  entity,
+		Message: args.Message,
+	}
+	if owner, ok := a.authorizer.GetAuthTag().(names.UserTag); ok {
+		blockArgs.Owner = owner
+	}
+	err = a.access.AddBlock(blockArgs)
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
 
 // SwitchBlockOff implements Block.SwitchBlockOff().
 func (a *API) SwitchBlockOff(args params.BlockSwitchParams) params.ErrorResult {
-	err := a.access.SwitchBlockOff(state.ParseBlockType(args.Type))
+	entity, err := blockEntity(args.Entity)
+	if err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	err = a.access.RemoveBlock(state.ParseBlockType(args.Type), entity)
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
@@ -79,3 +79,57 @@ func (s *blockSuite) TestSwitchBlockOff(c *gc.C) {
 	c.Assert(err.Error, gc.IsNil)
 	s.assertBlockList(c, 0)
 }
+
+func (s *blockSuite) TestSwitchBlockOnRecordsOwner(c *gc.C) {
+	s.assertSwitchBlockOn(c, state.ChangeBlock.String(), "freeze")
+
+	all, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	c.Assert(all.Results[0].Result.Owner, gc.Equals, s.AdminUserTag(c).Canonical())
+	c.Assert(all.Results[0].Result.Created, gc.NotNil)
+}
+
+func (s *blockSuite) TestSwitchApplicationBlock(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	args := params.BlockSwitchParams{
+		Type:    state.RemoveBlock.String(),
+		Message: "protect the database",
+		Entity:  app.Tag().String(),
+	}
+	result := s.api.SwitchBlockOn(args)
+	c.Assert(result.Error, gc.IsNil)
+
+	all, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	c.Assert(all.Results[0].Result.Tag, gc.Equals, app.Tag().String())
+	c.Assert(all.Results[0].Result.Message, gc.Equals, "protect the database")
+
+	// The model itself is not blocked.
+	_, found, err := s.State.GetBlockForType(state.RemoveBlock)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+
+	result = s.api.SwitchBlockOff(params.BlockSwitchParams{
+		Type:   state.RemoveBlock.String(),
+		Entity: app.Tag().String(),
+	})
+	c.Assert(result.Error, gc.IsNil)
+	s.assertBlockList(c, 0)
+}
+
+func (s *blockSuite) TestSwitchBlockOnInvalidEntity(c *gc.C) {
+	result := s.api.SwitchBlockOn(params.BlockSwitchParams{
+		Type:   state.RemoveBlock.String(),
+		Entity: "unit-mysql-0",
+	})
+	c.Assert(result.Error, gc.ErrorMatches, `block on unit "mysql/0" not valid`)
+
+	result = s.api.SwitchBlockOn(params.BlockSwitchParams{
+		Type:   state.RemoveBlock.String(),
+		Entity: "invalid",
+	})
+	c.Assert(result.Error, gc.ErrorMatches, `"invalid" is not a valid tag`)
+	s.assertBlockList(c, 0)
+}
@@ -3,12 +3,16 @@
 
 package block
 
-import "github.com/juju/juju/state"
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
 
 type blockAccess interface {
 	AllBlocks() ([]state.Block, error)
-	SwitchBlockOn(t state.BlockType, msg string) error
-	SwitchBlockOff(t state.BlockType) error
+	AddBlock(args state.BlockArgs) error
+	RemoveBlock(t state.BlockType, entity names.Tag) error
 }
 
 type stateShim struct {
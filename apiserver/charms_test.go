@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
+	coretesting "github.com/juju/juju/testing"
 )
 
 // charmsCommonSuite wraps authHttpSuite and adds
@@ -196,6 +198,25 @@ func (s *charmsSuite) TestUploadBumpsRevision(c *gc.C) {
 	c.Assert(sch.BundleSha256(), gc.Not(gc.Equals), "")
 }
 
+func (s *charmsSuite) TestUploadBlockedDuringMaintenance(c *gc.C) {
+	err := s.State.EnableMaintenance(s.AdminUserTag(c), "upgrading mongo", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The API server learns of the change of maintenance mode
+	// from a watcher.
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.BackingState.StartSync()
+		resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", ch.Path)
+		if resp.StatusCode == http.StatusServiceUnavailable {
+			s.assertErrorResponse(c, resp, http.StatusServiceUnavailable, "controller is in maintenance mode: upgrading mongo")
+			return
+		}
+		resp.Body.Close()
+	}
+	c.Fatalf("charm upload not blocked during maintenance")
+}
+
 func (s *charmsSuite) TestUploadRespectsLocalRevision(c *gc.C) {
 	// Make a dummy charm dir with revision 123.
 	dir := testcharms.Repo.ClonedDir(c.MkDir(), "dummy")
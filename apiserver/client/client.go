@@ -310,7 +310,13 @@ func (c *Client) ProvisioningScript(args params.ProvisioningScriptParams) (param
 
 // DestroyMachines removes a given set of machines.
 func (c *Client) DestroyMachines(args params.DestroyMachines) error {
-	if err := c.check.RemoveAllowed(); !args.Force && err != nil {
+	var machineTags []names.Tag
+	for _, id := range args.MachineNames {
+		if names.IsValidMachine(id) {
+			machineTags = append(machineTags, names.NewMachineTag(id))
+		}
+	}
+	if err := c.check.RemoveAllowedFor(machineTags...); !args.Force && err != nil {
 		return errors.Trace(err)
 	}
 
@@ -404,6 +410,25 @@ func (c *Client) ModelGet() (params.ModelConfigResults, error) {
 	return result, nil
 }
 
+// ModelConfigValues returns the model config values along with the
+// source of each: the Juju defaults, the controller or region model
+// defaults, or the model itself.
+func (c *Client) ModelConfigValues() (params.ModelConfigValuesResults, error) {
+	result := params.ModelConfigValuesResults{}
+	values, err := c.api.stateAccessor.ModelConfigValues()
+	if err != nil {
+		return result, err
+	}
+	result.Config = make(map[string]params.ConfigValue)
+	for attr, val := range values {
+		result.Config[attr] = params.ConfigValue{
+			Value:  val.Value,
+			Source: val.Source,
+		}
+	}
+	return result, nil
+}
+
 // ModelSet implements the server-side part of the
 // set-model-config CLI command.
 func (c *Client) ModelSet(args params.ModelSet) error {
@@ -832,6 +832,20 @@ func (s *serverSuite) TestClientModelGet(c *gc.C) {
 	c.Assert(result.Config, gc.DeepEquals, envConfig.AllAttrs())
 }
 
+func (s *serverSuite) TestClientModelConfigValues(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://mirror",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.client.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config["apt-mirror"], jc.DeepEquals, params.ConfigValue{
+		Value:  "http://mirror",
+		Source: "controller",
+	})
+	c.Assert(result.Config["name"].Source, gc.Equals, "model")
+}
+
 func (s *serverSuite) assertEnvValue(c *gc.C, key string, expected interface{}) {
 	envConfig, err := s.State.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
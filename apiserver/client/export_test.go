@@ -19,6 +19,10 @@ var (
 
 type MachineAndContainers machineAndContainers
 
+func ModelWarnings(c *Client) []string {
+	return c.modelWarnings()
+}
+
 var (
 	GetEnvironment = &getEnvironment
 )
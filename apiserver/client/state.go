@@ -4,6 +4,8 @@
 package client
 
 import (
+	"time"
+
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -26,6 +28,7 @@ type Unit interface {
 	PrivateAddress() (network.Address, error)
 	Resolve(retryHooks bool) error
 	AgentHistory() status.StatusHistoryGetter
+	HookHistory(size int) ([]state.HookExecution, error)
 }
 
 // stateInterface contains the state.State methods used in this package,
@@ -43,6 +46,7 @@ type stateInterface interface {
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	ModelConstraints() (constraints.Value, error)
 	ModelConfig() (*config.Config, error)
+	ModelConfigValues() (config.ConfigValues, error)
 	UpdateModelConfig(map[string]interface{}, []string, state.ValidateConfigFunc) error
 	SetModelConstraints(constraints.Value) error
 	ModelUUID() string
@@ -62,12 +66,24 @@ type stateInterface interface {
 	Watch() *state.Multiwatcher
 	AbortCurrentUpgrade() error
 	APIHostPorts() ([][]network.HostPort, error)
+	SpaceDrift() ([]state.SpaceDrift, time.Time, error)
+	IsControllerAdministrator(names.UserTag) (bool, error)
+	ModelUserAccess(names.UserTag) (state.ModelAccess, error)
 }
 
 type stateShim struct {
 	*state.State
 }
 
+// ModelUserAccess returns the access the given user has to the model.
+func (s *stateShim) ModelUserAccess(user names.UserTag) (state.ModelAccess, error) {
+	modelUser, err := s.State.ModelUser(user)
+	if err != nil {
+		return "", err
+	}
+	return modelUser.Access(), nil
+}
+
 func (s *stateShim) Unit(name string) (Unit, error) {
 	u, err := s.State.Unit(name)
 	if err != nil {
@@ -6,6 +6,7 @@ package client
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
@@ -152,6 +153,59 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// HookHistory returns the most recent hook, action and juju-run
+// executions of each requested unit, newest first. Since the output
+// of the executions may hold sensitive data, only administrators of
+// the model may see it.
+func (c *Client) HookHistory(args params.HookHistoryRequests) (params.HookHistoryResults, error) {
+	if err := common.CheckModelAdmin(c.api.stateAccessor, c.api.auth.GetAuthTag()); err != nil {
+		return params.HookHistoryResults{}, errors.Trace(err)
+	}
+	results := params.HookHistoryResults{
+		Results: make([]params.HookHistoryResult, len(args.Requests)),
+	}
+	for i, request := range args.Requests {
+		executions, err := c.unitHookHistory(request)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(
+				errors.Annotatef(err, "fetching hook history for %q", request.Tag),
+			)
+			continue
+		}
+		results.Results[i].Executions = executions
+	}
+	return results, nil
+}
+
+func (c *Client) unitHookHistory(request params.HookHistoryRequest) ([]params.HookExecution, error) {
+	tag, err := names.ParseUnitTag(request.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := c.api.stateAccessor.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := unit.HookHistory(request.Size)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	executions := make([]params.HookExecution, len(history))
+	for i, e := range history {
+		executions[i] = params.HookExecution{
+			Kind:       e.Kind,
+			Name:       e.Name,
+			RelationId: e.RelationId,
+			RemoteUnit: e.RemoteUnit,
+			Started:    e.Started,
+			Finished:   e.Finished,
+			ExitCode:   e.ExitCode,
+			Output:     e.Output,
+		}
+	}
+	return executions, nil
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	cfg, err := c.api.stateAccessor.ModelConfig()
@@ -273,6 +327,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			Name:             cfg.Name(),
 			Version:          modelVersion,
 			AvailableVersion: newToolsVersion,
+			Warnings:         c.modelWarnings(),
 		},
 		Machines:     processMachines(context.machines),
 		Applications: context.processServices(),
@@ -280,6 +335,33 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}, nil
 }
 
+// modelWarnings returns a warning for each difference between the
+// provider's spaces and subnets and the model's that could not be
+// reconciled safely. Failing to read the drift must not prevent the
+// rest of the status from being reported, so it is logged and reported
+// as a warning of its own.
+func (c *Client) modelWarnings() []string {
+	drift, _, err := c.api.stateAccessor.SpaceDrift()
+	if err != nil {
+		logger.Errorf("cannot determine space drift: %v", err)
+		return []string{"space drift could not be determined"}
+	}
+	var warnings []string
+	for _, entry := range drift {
+		if entry.Conflict == "" {
+			continue
+		}
+		subject := fmt.Sprintf("space %q", entry.SpaceName)
+		if entry.CIDR != "" {
+			subject = fmt.Sprintf("subnet %q", entry.CIDR)
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: %s (%s)", subject, entry.Kind, entry.Conflict,
+		))
+	}
+	return warnings
+}
+
 // newToolsVersionAvailable will return a string representing a tools
 // version only if the latest check is newer than current tools.
 func (c *Client) newToolsVersionAvailable() (string, error) {
@@ -592,6 +674,11 @@ func (context *statusContext) processService(service *state.Application) params.
 		processedStatus.Err = err
 		return processedStatus
 	}
+	processedStatus.WorkloadVersion, err = applicationWorkloadVersion(context.units[service.Name()])
+	if err != nil {
+		processedStatus.Err = err
+		return processedStatus
+	}
 	if service.IsPrincipal() {
 		processedStatus.Units = context.processUnits(context.units[service.Name()], serviceCharmURL.String())
 		applicationStatus, err := service.Status()
@@ -609,6 +696,41 @@ func (context *statusContext) processService(service *state.Application) params.
 	return processedStatus
 }
 
+// applicationWorkloadVersion returns the workload version reported by
+// the lowest-numbered unit that has set one.
+func applicationWorkloadVersion(units map[string]*state.Unit) (string, error) {
+	var unitNames []string
+	for name := range units {
+		unitNames = append(unitNames, name)
+	}
+	sort.Sort(byUnitNumber(unitNames))
+	for _, name := range unitNames {
+		version, err := units[name].WorkloadVersion()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if version != "" {
+			return version, nil
+		}
+	}
+	return "", nil
+}
+
+// byUnitNumber sorts the names of the units of one application by
+// unit number.
+type byUnitNumber []string
+
+func (n byUnitNumber) Len() int      { return len(n) }
+func (n byUnitNumber) Swap(i, j int) { n[i], n[j] = n[j], n[i] }
+func (n byUnitNumber) Less(i, j int) bool {
+	return unitNumber(n[i]) < unitNumber(n[j])
+}
+
+func unitNumber(unitName string) int {
+	number, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return number
+}
+
 func isColorStatus(code state.MeterStatusCode) bool {
 	return code == state.MeterGreen || code == state.MeterAmber || code == state.MeterRed
 }
@@ -660,6 +782,11 @@ func (context *statusContext) processUnit(unit *state.Unit, serviceCharm string)
 		result.Charm = curl.String()
 	}
 	processUnitAndAgentStatus(unit, &result)
+	if version, err := unit.WorkloadVersion(); err == nil {
+		result.WorkloadVersion = version
+	} else {
+		logger.Debugf("error fetching workload version: %v", err)
+	}
 
 	if subUnits := unit.SubordinateNames(); len(subUnits) > 0 {
 		result.Subordinates = make(map[string]params.UnitStatus)
@@ -4,8 +4,12 @@
 package client_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/charmrevisionupdater/testing"
@@ -16,6 +20,7 @@ import (
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -50,6 +55,79 @@ func (s *statusSuite) TestFullStatus(c *gc.C) {
 	c.Check(resultMachine.Series, gc.Equals, machine.Series())
 }
 
+func (s *statusSuite) TestFullStatusSpaceDriftWarnings(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", "", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ReconcileSpaceDrift([]state.SpaceDrift{{
+		Kind:      state.SpaceRemovedDrift,
+		SpaceName: "db",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Model.Warnings, jc.DeepEquals, []string{
+		`space "db": space-removed (space still has 1 subnet(s))`,
+	})
+}
+
+var _ = gc.Suite(&modelWarningsSuite{})
+
+type modelWarningsSuite struct {
+	coretesting.BaseSuite
+	st  *driftState
+	api *client.Client
+}
+
+func (s *modelWarningsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.st = &driftState{}
+	client.PatchState(s, s.st)
+	authorizer := &apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("user")}
+	var err error
+	s.api, err = client.NewClient(nil, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelWarningsSuite) TestModelWarningsSpaceDriftError(c *gc.C) {
+	s.st.err = errors.New("boom")
+	c.Assert(client.ModelWarnings(s.api), jc.DeepEquals, []string{
+		"space drift could not be determined",
+	})
+}
+
+func (s *modelWarningsSuite) TestModelWarningsIgnoresReconciledDrift(c *gc.C) {
+	s.st.drift = []state.SpaceDrift{{
+		Kind:      state.SpaceRemovedDrift,
+		SpaceName: "db",
+	}, {
+		Kind:      state.SpaceRemovedDrift,
+		SpaceName: "web",
+		Conflict:  "space still has 1 subnet(s)",
+	}}
+	c.Assert(client.ModelWarnings(s.api), jc.DeepEquals, []string{
+		`space "web": space-removed (space still has 1 subnet(s))`,
+	})
+}
+
+// driftState is a client.StateInterface that reports the given
+// space drift.
+type driftState struct {
+	client.StateInterface
+	drift []state.SpaceDrift
+	err   error
+}
+
+func (st *driftState) SpaceDrift() ([]state.SpaceDrift, time.Time, error) {
+	return st.drift, time.Time{}, st.err
+}
+
+func (st *driftState) ModelUUID() string {
+	return "uuid"
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
@@ -153,6 +231,31 @@ func (s *statusUnitTestSuite) TestMeterStatus(c *gc.C) {
 	}
 }
 
+func (s *statusUnitTestSuite) TestWorkloadVersion(c *gc.C) {
+	application := s.MakeApplication(c, nil)
+	var units []*state.Unit
+	for i := 0; i < 11; i++ {
+		unit, err := application.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		units = append(units, unit)
+	}
+	// unit/0 hasn't reported a version; unit/1 is the lowest that has,
+	// even though unit/10 sorts before it as a string.
+	err := units[1].SetWorkloadVersion("1.0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = units[10].SetWorkloadVersion("1.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	applicationStatus, ok := status.Applications[application.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(applicationStatus.WorkloadVersion, gc.Equals, "1.0")
+	c.Check(applicationStatus.Units[units[0].Name()].WorkloadVersion, gc.Equals, "")
+	c.Check(applicationStatus.Units[units[1].Name()].WorkloadVersion, gc.Equals, "1.0")
+	c.Check(applicationStatus.Units[units[10].Name()].WorkloadVersion, gc.Equals, "1.1")
+}
+
 type statusUpgradeUnitSuite struct {
 	testing.CharmSuite
 	jujutesting.JujuConnSuite
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/client"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
@@ -210,10 +211,77 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestHookHistory(c *gc.C) {
+	s.st.modelAccess = state.ModelAdminAccess
+	started := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.st.hookHistory = []state.HookExecution{{
+		Kind:       "hook",
+		Name:       "db-relation-changed",
+		RelationId: 2,
+		RemoteUnit: "mysql/0",
+		Started:    started.Add(time.Minute),
+		Finished:   started.Add(2 * time.Minute),
+		ExitCode:   1,
+		Output:     "oops",
+	}, {
+		Kind:       "action",
+		Name:       "backup",
+		RelationId: -1,
+		Started:    started,
+		Finished:   started.Add(time.Second),
+	}}
+	results, err := s.api.HookHistory(params.HookHistoryRequests{
+		Requests: []params.HookHistoryRequest{
+			{Tag: "unit-unit-0", Size: 1},
+			{Tag: "unit-unit-1"},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Executions, jc.DeepEquals, []params.HookExecution{{
+		Kind:       "hook",
+		Name:       "db-relation-changed",
+		RelationId: 2,
+		RemoteUnit: "mysql/0",
+		Started:    started.Add(time.Minute),
+		Finished:   started.Add(2 * time.Minute),
+		ExitCode:   1,
+		Output:     "oops",
+	}})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `fetching hook history for "unit-unit-1": unit/1 not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `fetching hook history for "machine-0": "machine-0" is not a valid unit tag`)
+}
+
+func (s *statusHistoryTestSuite) TestHookHistoryRequiresAdmin(c *gc.C) {
+	for _, access := range []state.ModelAccess{state.ModelUndefinedAccess, state.ModelReadAccess} {
+		c.Logf("access %q", access)
+		s.st.modelAccess = access
+		_, err := s.api.HookHistory(params.HookHistoryRequests{
+			Requests: []params.HookHistoryRequest{{Tag: "unit-unit-0"}},
+		})
+		c.Assert(err, gc.ErrorMatches, "permission denied")
+	}
+}
+
 type mockState struct {
 	client.StateInterface
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+	hookHistory  []state.HookExecution
+	modelAccess  state.ModelAccess
+}
+
+func (m *mockState) IsControllerAdministrator(names.UserTag) (bool, error) {
+	return false, nil
+}
+
+func (m *mockState) ModelUserAccess(user names.UserTag) (state.ModelAccess, error) {
+	if m.modelAccess == "" {
+		return "", errors.NotFoundf("model user %q", user.Id())
+	}
+	return m.modelAccess, nil
 }
 
 func (m *mockState) ModelUUID() string {
@@ -227,15 +295,24 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 	return &mockUnit{
 		status: m.unitHistory,
 		agent:  &mockUnitAgent{m.agentHistory},
+		hooks:  m.hookHistory,
 	}, nil
 }
 
 type mockUnit struct {
 	status statuses
 	agent  *mockUnitAgent
+	hooks  []state.HookExecution
 	client.Unit
 }
 
+func (m *mockUnit) HookHistory(size int) ([]state.HookExecution, error) {
+	if size == 0 || size > len(m.hooks) {
+		size = len(m.hooks)
+	}
+	return m.hooks[:size], nil
+}
+
 func (m *mockUnit) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
 	return m.status.StatusHistory(filter)
 }
@@ -0,0 +1,214 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cloud defines an API end point for functions dealing with
+// the clouds and cloud credentials stored in the controller.
+package cloud
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Cloud", 1, newFacade)
+}
+
+// Backend defines the State API used by the cloud facade.
+type Backend interface {
+	IsControllerAdministrator(user names.UserTag) (bool, error)
+	AddCloud(name string, cloud cloud.Cloud) error
+	Clouds() (map[string]cloud.Cloud, error)
+	UpdateCloudCredential(owner names.UserTag, cloudName, credentialName string, credential cloud.Credential) error
+	CloudCredentials(owner names.UserTag, cloudName string) (map[string]state.CloudCredential, error)
+}
+
+// CloudAPI implements the cloud interface and is the concrete
+// implementation of the api end point.
+type CloudAPI struct {
+	backend Backend
+	apiUser names.UserTag
+	isAdmin bool
+}
+
+func newFacade(st *state.State, resources *common.Resources, auth common.Authorizer) (*CloudAPI, error) {
+	return NewCloudAPI(st, auth)
+}
+
+// NewCloudAPI creates a new API server endpoint for managing the
+// clouds and cloud credentials stored in the controller.
+func NewCloudAPI(backend Backend, authorizer common.Authorizer) (*CloudAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	apiUser, _ := authorizer.GetAuthTag().(names.UserTag)
+	isAdmin, err := backend.IsControllerAdministrator(apiUser)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &CloudAPI{
+		backend: backend,
+		apiUser: apiUser,
+		isAdmin: isAdmin,
+	}, nil
+}
+
+// canAccess returns whether the API user may access the credentials
+// owned by the given user. Users may access their own credentials,
+// and controller administrators may access anyone's.
+func (api *CloudAPI) canAccess(owner names.UserTag) bool {
+	return api.isAdmin || api.apiUser.Canonical() == owner.Canonical()
+}
+
+// AddCloud registers a cloud with the controller, so that models may
+// be added to it. Only controller administrators may add clouds.
+func (api *CloudAPI) AddCloud(args params.AddCloudArgs) error {
+	if !api.isAdmin {
+		return common.ErrPerm
+	}
+	return api.backend.AddCloud(args.Name, cloudFromParams(args.Cloud))
+}
+
+// Clouds returns the clouds registered with the controller, in
+// addition to the one it runs in.
+func (api *CloudAPI) Clouds() (params.CloudsResult, error) {
+	clouds, err := api.backend.Clouds()
+	if err != nil {
+		return params.CloudsResult{}, errors.Trace(err)
+	}
+	result := params.CloudsResult{
+		Clouds: make(map[string]params.Cloud),
+	}
+	for name, c := range clouds {
+		result.Clouds[name] = cloudToParams(c)
+	}
+	return result, nil
+}
+
+func cloudFromParams(in params.Cloud) cloud.Cloud {
+	var authTypes []cloud.AuthType
+	for _, authType := range in.AuthTypes {
+		authTypes = append(authTypes, cloud.AuthType(authType))
+	}
+	var regions []cloud.Region
+	for _, region := range in.Regions {
+		regions = append(regions, cloud.Region{
+			Name:            region.Name,
+			Endpoint:        region.Endpoint,
+			StorageEndpoint: region.StorageEndpoint,
+		})
+	}
+	return cloud.Cloud{
+		Type:            in.Type,
+		AuthTypes:       authTypes,
+		Endpoint:        in.Endpoint,
+		StorageEndpoint: in.StorageEndpoint,
+		Regions:         regions,
+		Config:          in.Config,
+	}
+}
+
+func cloudToParams(in cloud.Cloud) params.Cloud {
+	var authTypes []string
+	for _, authType := range in.AuthTypes {
+		authTypes = append(authTypes, string(authType))
+	}
+	var regions []params.CloudRegion
+	for _, region := range in.Regions {
+		regions = append(regions, params.CloudRegion{
+			Name:            region.Name,
+			Endpoint:        region.Endpoint,
+			StorageEndpoint: region.StorageEndpoint,
+		})
+	}
+	return params.Cloud{
+		Type:            in.Type,
+		AuthTypes:       authTypes,
+		Endpoint:        in.Endpoint,
+		StorageEndpoint: in.StorageEndpoint,
+		Regions:         regions,
+		Config:          in.Config,
+	}
+}
+
+// UpdateCredentials stores the given credentials in the controller,
+// replacing any existing credentials with the same owner, cloud and
+// name. Models using a replaced credential pick up the change.
+func (api *CloudAPI) UpdateCredentials(args params.UpdateCloudCredentials) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Credentials)),
+	}
+	for i, arg := range args.Credentials {
+		owner, err := names.ParseUserTag(arg.OwnerTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !api.canAccess(owner) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		credential := cloud.NewCredential(
+			cloud.AuthType(arg.Credential.AuthType),
+			arg.Credential.Attributes,
+		)
+		err = api.backend.UpdateCloudCredential(owner, arg.Cloud, arg.Name, credential)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// Credentials returns the names, auth types, attribute names and
+// revisions of the credentials stored in the controller for the given
+// users and clouds. Attribute values are never returned.
+func (api *CloudAPI) Credentials(args params.UserClouds) (params.CloudCredentialsResults, error) {
+	results := params.CloudCredentialsResults{
+		Results: make([]params.CloudCredentialsResult, len(args.UserClouds)),
+	}
+	for i, arg := range args.UserClouds {
+		owner, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !api.canAccess(owner) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		credentials, err := api.backend.CloudCredentials(owner, arg.Cloud)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		infos := make([]params.CloudCredentialInfo, 0, len(credentials))
+		for _, credential := range credentials {
+			attributes := make([]string, 0, len(credential.Attributes))
+			for name := range credential.Attributes {
+				attributes = append(attributes, name)
+			}
+			sort.Strings(attributes)
+			infos = append(infos, params.CloudCredentialInfo{
+				Name:       credential.Name,
+				AuthType:   string(credential.AuthType),
+				Attributes: attributes,
+				Revision:   credential.Revision,
+			})
+		}
+		sort.Sort(credentialInfosByName(infos))
+		results.Results[i].Credentials = infos
+	}
+	return results, nil
+}
+
+type credentialInfosByName []params.CloudCredentialInfo
+
+func (s credentialInfosByName) Len() int           { return len(s) }
+func (s credentialInfosByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+func (s credentialInfosByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
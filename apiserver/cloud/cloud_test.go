@@ -0,0 +1,236 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	cloudfacade "github.com/juju/juju/apiserver/cloud"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type cloudSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *cloudfacade.CloudAPI
+}
+
+var _ = gc.Suite(&cloudSuite{})
+
+func (s *cloudSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		credentials: map[string]state.CloudCredential{
+			"one": {
+				Owner:    names.NewUserTag("bruce"),
+				Cloud:    "dummy",
+				Name:     "one",
+				AuthType: cloud.UserPassAuthType,
+				Attributes: map[string]string{
+					"username": "bruce",
+					"password": "sekrit",
+				},
+				Revision: 3,
+			},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bruce"),
+	}
+	s.api = s.newAPI(c)
+}
+
+func (s *cloudSuite) newAPI(c *gc.C) *cloudfacade.CloudAPI {
+	api, err := cloudfacade.NewCloudAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *cloudSuite) TestNewCloudAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := cloudfacade.NewCloudAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *cloudSuite) TestUpdateCredentials(c *gc.C) {
+	results, err := s.api.UpdateCredentials(params.UpdateCloudCredentials{
+		Credentials: []params.UpdateCloudCredential{{
+			OwnerTag: "user-bruce",
+			Cloud:    "dummy",
+			Name:     "two",
+			Credential: params.CloudCredential{
+				AuthType:   "userpass",
+				Attributes: map[string]string{"username": "b", "password": "p"},
+			},
+		}, {
+			OwnerTag: "user-alfred",
+			Cloud:    "dummy",
+			Name:     "three",
+		}, {
+			OwnerTag: "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: `"machine-0" is not a valid user tag`}},
+		},
+	})
+	s.backend.CheckCalls(c, []jujutesting.StubCall{
+		{"IsControllerAdministrator", []interface{}{names.NewUserTag("bruce")}},
+		{"UpdateCloudCredential", []interface{}{
+			names.NewUserTag("bruce"), "dummy", "two",
+			cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+				"username": "b", "password": "p",
+			}),
+		}},
+	})
+}
+
+func (s *cloudSuite) TestUpdateCredentialsAdmin(c *gc.C) {
+	s.backend.isAdmin = true
+	api := s.newAPI(c)
+	results, err := api.UpdateCredentials(params.UpdateCloudCredentials{
+		Credentials: []params.UpdateCloudCredential{{
+			OwnerTag: "user-alfred",
+			Cloud:    "dummy",
+			Name:     "three",
+			Credential: params.CloudCredential{
+				AuthType: "userpass",
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *cloudSuite) TestCredentials(c *gc.C) {
+	results, err := s.api.Credentials(params.UserClouds{
+		UserClouds: []params.UserCloud{{
+			UserTag: "user-bruce",
+			Cloud:   "dummy",
+		}, {
+			UserTag: "user-alfred",
+			Cloud:   "dummy",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.CloudCredentialsResults{
+		Results: []params.CloudCredentialsResult{{
+			Credentials: []params.CloudCredentialInfo{{
+				Name:       "one",
+				AuthType:   "userpass",
+				Attributes: []string{"password", "username"},
+				Revision:   3,
+			}},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+	s.backend.CheckCalls(c, []jujutesting.StubCall{
+		{"IsControllerAdministrator", []interface{}{names.NewUserTag("bruce")}},
+		{"CloudCredentials", []interface{}{names.NewUserTag("bruce"), "dummy"}},
+	})
+}
+
+func (s *cloudSuite) TestAddCloud(c *gc.C) {
+	s.backend.isAdmin = true
+	api := s.newAPI(c)
+	err := api.AddCloud(params.AddCloudArgs{
+		Name: "other",
+		Cloud: params.Cloud{
+			Type:      "ec2",
+			AuthTypes: []string{"access-key"},
+			Regions: []params.CloudRegion{{
+				Name:     "north",
+				Endpoint: "https://north.example.com",
+			}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "IsControllerAdministrator", "AddCloud")
+	s.backend.CheckCall(c, 1, "AddCloud", "other", cloud.Cloud{
+		Type:      "ec2",
+		AuthTypes: []cloud.AuthType{cloud.AccessKeyAuthType},
+		Regions: []cloud.Region{{
+			Name:     "north",
+			Endpoint: "https://north.example.com",
+		}},
+	})
+}
+
+func (s *cloudSuite) TestAddCloudNotAdmin(c *gc.C) {
+	err := s.api.AddCloud(params.AddCloudArgs{
+		Name:  "other",
+		Cloud: params.Cloud{Type: "ec2"},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "IsControllerAdministrator")
+}
+
+func (s *cloudSuite) TestClouds(c *gc.C) {
+	s.backend.clouds = map[string]cloud.Cloud{
+		"other": {
+			Type:     "openstack",
+			Endpoint: "https://keystone.example.com",
+			Regions:  []cloud.Region{{Name: "north"}},
+			Config:   map[string]interface{}{"network": "net"},
+		},
+	}
+	result, err := s.api.Clouds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudsResult{
+		Clouds: map[string]params.Cloud{
+			"other": {
+				Type:     "openstack",
+				Endpoint: "https://keystone.example.com",
+				Regions:  []params.CloudRegion{{Name: "north"}},
+				Config:   map[string]interface{}{"network": "net"},
+			},
+		},
+	})
+}
+
+type mockBackend struct {
+	jujutesting.Stub
+	isAdmin     bool
+	clouds      map[string]cloud.Cloud
+	credentials map[string]state.CloudCredential
+}
+
+func (b *mockBackend) AddCloud(name string, cloud cloud.Cloud) error {
+	b.MethodCall(b, "AddCloud", name, cloud)
+	return b.NextErr()
+}
+
+func (b *mockBackend) Clouds() (map[string]cloud.Cloud, error) {
+	b.MethodCall(b, "Clouds")
+	return b.clouds, b.NextErr()
+}
+
+func (b *mockBackend) IsControllerAdministrator(user names.UserTag) (bool, error) {
+	b.MethodCall(b, "IsControllerAdministrator", user)
+	return b.isAdmin, b.NextErr()
+}
+
+func (b *mockBackend) UpdateCloudCredential(owner names.UserTag, cloudName, credentialName string, credential cloud.Credential) error {
+	b.MethodCall(b, "UpdateCloudCredential", owner, cloudName, credentialName, credential)
+	return b.NextErr()
+}
+
+func (b *mockBackend) CloudCredentials(owner names.UserTag, cloudName string) (map[string]state.CloudCredential, error) {
+	b.MethodCall(b, "CloudCredentials", owner, cloudName)
+	return b.credentials, b.NextErr()
+}
@@ -4,13 +4,17 @@
 package common
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/state"
 )
 
 type BlockGetter interface {
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
+	GetBlockForEntity(t state.BlockType, entity names.Tag) (state.Block, bool, error)
 }
 
 // BlockChecker checks for current blocks if any.
@@ -54,6 +58,45 @@ func (c *BlockChecker) DestroyAllowed() error {
 	return c.checkBlock(state.ChangeBlock)
 }
 
+// ChangeAllowedFor checks if a change block is in place for the model
+// or any of the given applications or machines.
+func (c *BlockChecker) ChangeAllowedFor(entities ...names.Tag) error {
+	if err := c.ChangeAllowed(); err != nil {
+		return err
+	}
+	return c.checkEntityBlocks(entities, state.ChangeBlock)
+}
+
+// RemoveAllowedFor checks if a remove or change block is in place
+// for the model or any of the given applications or machines.
+func (c *BlockChecker) RemoveAllowedFor(entities ...names.Tag) error {
+	if err := c.RemoveAllowed(); err != nil {
+		return err
+	}
+	return c.checkEntityBlocks(entities, state.RemoveBlock, state.ChangeBlock)
+}
+
+// checkEntityBlocks checks if any of the specified block types are in
+// place for any of the given entities.
+func (c *BlockChecker) checkEntityBlocks(entities []names.Tag, blockTypes ...state.BlockType) error {
+	for _, entity := range entities {
+		for _, blockType := range blockTypes {
+			aBlock, isEnabled, err := c.getter.GetBlockForEntity(blockType, entity)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if isEnabled {
+				msg := aBlock.Message()
+				if msg == "" {
+					msg = fmt.Sprintf("the operation has been blocked for %s", names.ReadableString(entity))
+				}
+				return OperationBlockedError(msg)
+			}
+		}
+	}
+	return nil
+}
+
 // checkBlock checks if specified operation must be blocked.
 // If it does, the method throws specific error that can be examined
 // to stop operation execution.
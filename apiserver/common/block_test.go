@@ -35,6 +35,7 @@ type blockCheckerSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	aBlock                  state.Block
 	destroy, remove, change state.Block
+	entityBlocks            map[names.Tag]state.Block
 
 	blockchecker *common.BlockChecker
 }
@@ -46,6 +47,7 @@ func (s *blockCheckerSuite) SetUpTest(c *gc.C) {
 	s.destroy = mockBlock{t: state.DestroyBlock, m: "Mock BLOCK testing: DESTROY"}
 	s.remove = mockBlock{t: state.RemoveBlock, m: "Mock BLOCK testing: REMOVE"}
 	s.change = mockBlock{t: state.ChangeBlock, m: "Mock BLOCK testing: CHANGE"}
+	s.entityBlocks = make(map[names.Tag]state.Block)
 	s.blockchecker = common.NewBlockChecker(s)
 }
 
@@ -57,6 +59,34 @@ func (mock *blockCheckerSuite) GetBlockForType(t state.BlockType) (state.Block,
 	}
 }
 
+func (mock *blockCheckerSuite) GetBlockForEntity(t state.BlockType, entity names.Tag) (state.Block, bool, error) {
+	if b, ok := mock.entityBlocks[entity]; ok && b.Type() == t {
+		return b, true, nil
+	}
+	return nil, false, nil
+}
+
+func (s *blockCheckerSuite) TestRemoveAllowedForEntity(c *gc.C) {
+	s.aBlock = s.destroy
+	app := names.NewApplicationTag("postgresql")
+	other := names.NewApplicationTag("wordpress")
+	s.entityBlocks[app] = mockBlock{t: state.RemoveBlock, m: "protect the database"}
+
+	s.assertErrorBlocked(c, true, s.blockchecker.RemoveAllowedFor(other, app), "protect the database")
+	s.assertErrorBlocked(c, false, s.blockchecker.RemoveAllowedFor(other), "")
+	s.assertErrorBlocked(c, false, s.blockchecker.ChangeAllowedFor(app), "")
+
+	s.entityBlocks[app] = mockBlock{t: state.ChangeBlock}
+	s.assertErrorBlocked(c, true, s.blockchecker.RemoveAllowedFor(app), `the operation has been blocked for application "postgresql"`)
+	s.assertErrorBlocked(c, true, s.blockchecker.ChangeAllowedFor(app), `the operation has been blocked for application "postgresql"`)
+}
+
+func (s *blockCheckerSuite) TestChangeAllowedForModelBlock(c *gc.C) {
+	s.aBlock = s.change
+	s.assertErrorBlocked(c, true, s.blockchecker.ChangeAllowedFor(names.NewMachineTag("0")), s.change.Message())
+	s.assertErrorBlocked(c, true, s.blockchecker.ChangeAllowedFor(), s.change.Message())
+}
+
 func (s *blockCheckerSuite) TestDestroyBlockChecker(c *gc.C) {
 	s.aBlock = s.destroy
 	s.assertErrorBlocked(c, true, s.blockchecker.DestroyAllowed(), s.destroy.Message())
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/txn"
@@ -77,6 +78,51 @@ func IsDischargeRequiredError(err error) bool {
 	return ok
 }
 
+// RateLimitExceededError is the error returned when a client has
+// exceeded a limit on its logins, connections or requests.
+type RateLimitExceededError struct {
+	// Reason describes the limit that was exceeded.
+	Reason string
+
+	// RetryAfter holds how long the client should wait before
+	// retrying, if known.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *RateLimitExceededError) Error() string {
+	return fmt.Sprintf("rate limit exceeded: %s", e.Reason)
+}
+
+// IsRateLimitExceededError reports whether the cause
+// of the error is a *RateLimitExceededError.
+func IsRateLimitExceededError(err error) bool {
+	_, ok := errors.Cause(err).(*RateLimitExceededError)
+	return ok
+}
+
+// MaintenanceModeError is the error returned when a user attempts to
+// change anything while the controller is in maintenance mode.
+type MaintenanceModeError struct {
+	// Reason holds why the controller is in maintenance mode.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *MaintenanceModeError) Error() string {
+	if e.Reason == "" {
+		return "controller is in maintenance mode"
+	}
+	return fmt.Sprintf("controller is in maintenance mode: %s", e.Reason)
+}
+
+// IsMaintenanceModeError reports whether the cause
+// of the error is a *MaintenanceModeError.
+func IsMaintenanceModeError(err error) bool {
+	_, ok := errors.Cause(err).(*MaintenanceModeError)
+	return ok
+}
+
 // IsUpgradeInProgress returns true if this error is caused
 // by an upgrade in progress.
 func IsUpgradeInProgressError(err error) bool {
@@ -179,6 +225,10 @@ func ServerErrorAndStatus(err error) (*params.Error, int) {
 		status = http.StatusForbidden
 	case params.CodeDischargeRequired:
 		status = http.StatusUnauthorized
+	case params.CodeRateLimitExceeded:
+		status = http.StatusTooManyRequests
+	case params.CodeMaintenanceMode:
+		status = http.StatusServiceUnavailable
 	}
 	return err1, status
 }
@@ -225,6 +275,8 @@ func ServerError(err error) *params.Error {
 		code = params.CodeBadRequest
 	case errors.IsMethodNotAllowed(err):
 		code = params.CodeMethodNotAllowed
+	case IsMaintenanceModeError(err):
+		code = params.CodeMaintenanceMode
 	default:
 		if err, ok := err.(*DischargeRequiredError); ok {
 			code = params.CodeDischargeRequired
@@ -235,6 +287,15 @@ func ServerError(err error) *params.Error {
 			}
 			break
 		}
+		if err, ok := err.(*RateLimitExceededError); ok {
+			code = params.CodeRateLimitExceeded
+			if err.RetryAfter > 0 {
+				info = &params.ErrorInfo{
+					RetryAfter: err.RetryAfter,
+				}
+			}
+			break
+		}
 		code = params.ErrCode(err)
 	}
 	return &params.Error{
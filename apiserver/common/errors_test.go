@@ -6,6 +6,7 @@ package common_test
 import (
 	stderrors "errors"
 	"net/http"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -176,6 +177,25 @@ var errorTransformTests = []struct {
 		}
 		return true
 	},
+}, {
+	err: &common.RateLimitExceededError{
+		Reason:     "too many requests",
+		RetryAfter: 2 * time.Second,
+	},
+	status: http.StatusTooManyRequests,
+	code:   params.CodeRateLimitExceeded,
+	helperFunc: func(err error) bool {
+		err1, ok := err.(*params.Error)
+		if !ok || err1.Info == nil || err1.Info.RetryAfter != 2*time.Second {
+			return false
+		}
+		return params.IsCodeRateLimitExceeded(err)
+	},
+}, {
+	err:        &common.MaintenanceModeError{Reason: "upgrading mongo"},
+	code:       params.CodeMaintenanceMode,
+	status:     http.StatusServiceUnavailable,
+	helperFunc: params.IsCodeMaintenanceMode,
 }, {
 	err:    unhashableError{"foo"},
 	status: http.StatusInternalServerError,
@@ -232,7 +252,9 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeNoAddressSet,
 			params.CodeUpgradeInProgress,
 			params.CodeMachineHasAttachedStorage,
-			params.CodeDischargeRequired:
+			params.CodeDischargeRequired,
+			params.CodeRateLimitExceeded,
+			params.CodeMaintenanceMode:
 			continue
 		case params.CodeNotFound:
 			if common.IsUnknownModelError(t.err) {
@@ -57,3 +57,56 @@ func StateToParamsModelAccess(stateAccess state.ModelAccess) (params.ModelAccess
 	}
 	return "", errors.Errorf("invalid model access permission %q", stateAccess)
 }
+
+// ModelAdminBackend defines the state methods required to check
+// whether a user administers a model.
+type ModelAdminBackend interface {
+	IsControllerAdministrator(names.UserTag) (bool, error)
+	ModelUserAccess(names.UserTag) (state.ModelAccess, error)
+}
+
+// NewModelAdminBackend returns a ModelAdminBackend for the model of
+// the given State.
+func NewModelAdminBackend(st *state.State) ModelAdminBackend {
+	return modelAdminBackend{st}
+}
+
+type modelAdminBackend struct {
+	*state.State
+}
+
+// ModelUserAccess is part of the ModelAdminBackend interface.
+func (b modelAdminBackend) ModelUserAccess(user names.UserTag) (state.ModelAccess, error) {
+	modelUser, err := b.State.ModelUser(user)
+	if err != nil {
+		return "", err
+	}
+	return modelUser.Access(), nil
+}
+
+// CheckModelAdmin returns ErrPerm unless the entity with the given
+// tag is a user who is a controller administrator or has admin access
+// to the model.
+func CheckModelAdmin(backend ModelAdminBackend, tag names.Tag) error {
+	user, ok := tag.(names.UserTag)
+	if !ok {
+		return ErrPerm
+	}
+	isAdmin, err := backend.IsControllerAdministrator(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isAdmin {
+		return nil
+	}
+	access, err := backend.ModelUserAccess(user)
+	if errors.IsNotFound(err) {
+		return ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if access != state.ModelAdminAccess {
+		return ErrPerm
+	}
+	return nil
+}
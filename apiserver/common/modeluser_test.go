@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+type modelUserSuite struct{}
+
+var _ = gc.Suite(&modelUserSuite{})
+
+type fakeModelAdminBackend struct {
+	controllerAdmin bool
+	access          state.ModelAccess
+	err             error
+}
+
+func (b *fakeModelAdminBackend) IsControllerAdministrator(names.UserTag) (bool, error) {
+	return b.controllerAdmin, nil
+}
+
+func (b *fakeModelAdminBackend) ModelUserAccess(user names.UserTag) (state.ModelAccess, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if b.access == state.ModelUndefinedAccess {
+		return "", errors.NotFoundf("model user %q", user.Canonical())
+	}
+	return b.access, nil
+}
+
+func (*modelUserSuite) TestCheckModelAdmin(c *gc.C) {
+	user := names.NewUserTag("fred")
+	for i, test := range []struct {
+		about   string
+		tag     names.Tag
+		backend fakeModelAdminBackend
+		err     string
+	}{{
+		about:   "controller administrator",
+		tag:     user,
+		backend: fakeModelAdminBackend{controllerAdmin: true},
+	}, {
+		about:   "model administrator",
+		tag:     user,
+		backend: fakeModelAdminBackend{access: state.ModelAdminAccess},
+	}, {
+		about:   "read only model user",
+		tag:     user,
+		backend: fakeModelAdminBackend{access: state.ModelReadAccess},
+		err:     "permission denied",
+	}, {
+		about: "not a model user",
+		tag:   user,
+		err:   "permission denied",
+	}, {
+		about: "not a user",
+		tag:   names.NewMachineTag("0"),
+		err:   "permission denied",
+	}, {
+		about:   "backend error",
+		tag:     user,
+		backend: fakeModelAdminBackend{err: errors.New("boom")},
+		err:     "boom",
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		err := common.CheckModelAdmin(&test.backend, test.tag)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
@@ -141,6 +141,55 @@ func (s *stateShim) AllSubnets() ([]BackingSubnet, error) {
 	return subnets, nil
 }
 
+func (s *stateShim) ReconcileSpaceDrift(drift []params.SpaceDrift) ([]params.SpaceDrift, error) {
+	stateDrift := make([]state.SpaceDrift, len(drift))
+	for i, entry := range drift {
+		stateDrift[i] = state.SpaceDrift{
+			Kind:         state.SpaceDriftKind(entry.Kind),
+			SpaceName:    entry.SpaceName,
+			CIDR:         entry.CIDR,
+			ProviderId:   network.Id(entry.ProviderId),
+			ToSpaceName:  entry.ToSpaceName,
+			Resolved:     entry.Resolved,
+			Conflict:     entry.Conflict,
+			Applications: entry.Applications,
+		}
+	}
+	recorded, err := s.st.ReconcileSpaceDrift(stateDrift)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return spaceDriftToParams(recorded), nil
+}
+
+func (s *stateShim) SpaceDrift() (params.SpaceDriftResults, error) {
+	drift, updated, err := s.st.SpaceDrift()
+	if err != nil {
+		return params.SpaceDriftResults{}, errors.Trace(err)
+	}
+	return params.SpaceDriftResults{
+		Updated: updated,
+		Results: spaceDriftToParams(drift),
+	}, nil
+}
+
+func spaceDriftToParams(drift []state.SpaceDrift) []params.SpaceDrift {
+	results := make([]params.SpaceDrift, len(drift))
+	for i, entry := range drift {
+		results[i] = params.SpaceDrift{
+			Kind:         string(entry.Kind),
+			SpaceName:    entry.SpaceName,
+			CIDR:         entry.CIDR,
+			ProviderId:   string(entry.ProviderId),
+			ToSpaceName:  entry.ToSpaceName,
+			Resolved:     entry.Resolved,
+			Conflict:     entry.Conflict,
+			Applications: entry.Applications,
+		}
+	}
+	return results
+}
+
 type availZoneShim struct{}
 
 func (availZoneShim) Name() string    { return "not-set" }
@@ -136,6 +136,14 @@ type NetworkBacking interface {
 
 	// AllSubnets returns all backing subnets.
 	AllSubnets() ([]BackingSubnet, error)
+
+	// ReconcileSpaceDrift updates the model to match the provider's
+	// spaces and subnets where it is safe to do so, and records the
+	// resulting drift.
+	ReconcileSpaceDrift([]params.SpaceDrift) ([]params.SpaceDrift, error)
+
+	// SpaceDrift returns the latest drift recorded for the model.
+	SpaceDrift() (params.SpaceDriftResults, error)
 }
 
 func BackingSubnetToParamsSubnet(subnet BackingSubnet) params.Subnet {
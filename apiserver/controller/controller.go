@@ -6,16 +6,25 @@
 package controller
 
 import (
+	"io"
+	"io/ioutil"
+	"os"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/state"
 )
 
@@ -35,6 +44,10 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
 	InitiateModelMigration(params.InitiateModelMigrationArgs) (params.InitiateModelMigrationResults, error)
+	EnableMaintenance(args params.EnableMaintenanceArgs) error
+	DisableMaintenance() error
+	ReplicaSetStatus() (params.ReplicaSetStatusResult, error)
+	CloneModel(args params.CloneModelArgs) (params.CloneModelResult, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -147,15 +160,19 @@ func (s *ControllerAPI) ListBlockedModels() (params.ModelBlockInfoList, error) {
 	}
 
 	envBlocks := make(map[string][]string)
+	entityBlocks := make(map[string][]params.Block)
 	for _, block := range blocks {
 		uuid := block.ModelUUID()
-		types, ok := envBlocks[uuid]
-		if !ok {
-			types = []string{block.Type().String()}
-		} else {
-			types = append(types, block.Type().String())
+		// Blocks on individual applications or machines are
+		// reported separately from those on the whole model.
+		if tag, err := block.Tag(); err == nil && tag.Kind() != names.ModelTagKind {
+			entityBlocks[uuid] = append(entityBlocks[uuid], entityBlockInfo(tag, block))
+			if _, ok := envBlocks[uuid]; !ok {
+				envBlocks[uuid] = nil
+			}
+			continue
 		}
-		envBlocks[uuid] = types
+		envBlocks[uuid] = append(envBlocks[uuid], block.Type().String())
 	}
 
 	for uuid, blocks := range envBlocks {
@@ -165,10 +182,11 @@ func (s *ControllerAPI) ListBlockedModels() (params.ModelBlockInfoList, error) {
 			continue
 		}
 		results.Models = append(results.Models, params.ModelBlockInfo{
-			UUID:     envInfo.UUID(),
-			Name:     envInfo.Name(),
-			OwnerTag: envInfo.Owner().String(),
-			Blocks:   blocks,
+			UUID:         envInfo.UUID(),
+			Name:         envInfo.Name(),
+			OwnerTag:     envInfo.Owner().String(),
+			Blocks:       blocks,
+			EntityBlocks: entityBlocks[uuid],
 		})
 	}
 
@@ -178,6 +196,22 @@ func (s *ControllerAPI) ListBlockedModels() (params.ModelBlockInfoList, error) {
 	return results, nil
 }
 
+// entityBlockInfo returns the params representation of a block
+// on the application or machine with the given tag.
+func entityBlockInfo(tag names.Tag, b state.Block) params.Block {
+	info := params.Block{
+		Id:      b.Id(),
+		Tag:     tag.String(),
+		Type:    b.Type().String(),
+		Message: b.Message(),
+		Owner:   b.Owner(),
+	}
+	if created := b.Created(); !created.IsZero() {
+		info.Created = &created
+	}
+	return info
+}
+
 // ModelConfig returns the environment config for the controller
 // environment.  For information on the current environment, use
 // client.ModelGet
@@ -206,6 +240,52 @@ func (s *ControllerAPI) RemoveBlocks(args params.RemoveBlocksArgs) error {
 	return errors.Trace(s.state.RemoveAllBlocksForController())
 }
 
+// EnableMaintenance puts the controller into maintenance mode, in
+// which users may only make read-only API calls.
+func (s *ControllerAPI) EnableMaintenance(args params.EnableMaintenanceArgs) error {
+	var expires time.Time
+	if args.Expires != nil {
+		expires = *args.Expires
+	}
+	return errors.Trace(s.state.EnableMaintenance(s.apiUser, args.Reason, expires))
+}
+
+// DisableMaintenance takes the controller out of maintenance mode.
+func (s *ControllerAPI) DisableMaintenance() error {
+	return errors.Trace(s.state.DisableMaintenance())
+}
+
+// replicaSetStatus is patched by tests, which do not run against a
+// replica set.
+var replicaSetStatus = (*state.State).ReplicaSetStatus
+
+// ReplicaSetStatus returns the health of each member of the
+// controller's MongoDB replica set.
+func (s *ControllerAPI) ReplicaSetStatus() (params.ReplicaSetStatusResult, error) {
+	members, err := replicaSetStatus(s.state)
+	if err != nil {
+		return params.ReplicaSetStatusResult{}, errors.Trace(err)
+	}
+	result := params.ReplicaSetStatusResult{
+		Members: make([]params.ReplicaSetMember, len(members)),
+	}
+	for i, m := range members {
+		var machineTag string
+		if names.IsValidMachine(m.MachineId) {
+			machineTag = names.NewMachineTag(m.MachineId).String()
+		}
+		result.Members[i] = params.ReplicaSetMember{
+			MachineTag: machineTag,
+			Address:    m.Address,
+			State:      m.State,
+			Healthy:    m.Healthy,
+			Votes:      m.Votes,
+			Lag:        m.Lag,
+		}
+	}
+	return result, nil
+}
+
 // WatchAllModels starts watching events for all models in the
 // controller. The returned AllWatcherId should be used with Next on the
 // AllModelWatcher endpoint to receive deltas.
@@ -312,7 +392,7 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 
 	args := state.ModelMigrationSpec{
 		InitiatedBy: c.apiUser,
-		TargetInfo: migration.TargetInfo{
+		TargetInfo: coremigration.TargetInfo{
 			ControllerTag: controllerTag,
 			Addrs:         targetInfo.Addrs,
 			CACert:        targetInfo.CACert,
@@ -327,6 +407,165 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 	return mig.Id(), nil
 }
 
+// These are patched by tests.
+var (
+	apiOpen         = api.Open
+	uploadBinaries  = migration.UploadBinaries
+	uploadResources = uploadCloneResources
+)
+
+// CloneModel copies the description, config, charms and resources of a
+// model into a new model on the same or another controller. Unlike a
+// migration, the new model adopts none of the source model's machines;
+// they are provisioned afresh once the new model is active.
+func (c *ControllerAPI) CloneModel(args params.CloneModelArgs) (params.CloneModelResult, error) {
+	var empty params.CloneModelResult
+	modelTag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return empty, errors.Annotate(err, "model tag")
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return empty, errors.Annotate(err, "unable to read model")
+	}
+	if !names.IsValidModelName(args.Name) {
+		return empty, errors.NotValidf("model name %q", args.Name)
+	}
+	authTag, err := names.ParseUserTag(args.TargetInfo.AuthTag)
+	if err != nil {
+		return empty, errors.Annotate(err, "auth tag")
+	}
+
+	hostedState, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	defer hostedState.Close()
+
+	bytes, err := migration.ExportModel(hostedState)
+	if err != nil {
+		return empty, errors.Annotate(err, "exporting model")
+	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
+	apiInfo := &api.Info{
+		Addrs:    args.TargetInfo.Addrs,
+		CACert:   args.TargetInfo.CACert,
+		Tag:      authTag,
+		Password: args.TargetInfo.Password,
+	}
+	conn, err := apiOpen(apiInfo, api.DefaultDialOpts())
+	if err != nil {
+		return empty, errors.Annotate(err, "connecting to target controller")
+	}
+	defer conn.Close()
+
+	targetClient := migrationtarget.NewClient(conn)
+	newUUID, err := targetClient.ImportFresh(bytes, args.Name)
+	if err != nil {
+		return empty, errors.Annotate(err, "importing model")
+	}
+	if err := c.uploadCloneBinaries(hostedState, model, apiInfo, newUUID); err != nil {
+		if abortErr := targetClient.Abort(newUUID); abortErr != nil {
+			logger.Errorf("aborting clone of model %q: %v", args.Name, abortErr)
+		}
+		return empty, errors.Annotate(err, "uploading binaries")
+	}
+	if err := targetClient.Activate(newUUID); err != nil {
+		return empty, errors.Annotate(err, "activating model")
+	}
+	return params.CloneModelResult{
+		ModelTag: names.NewModelTag(newUUID).String(),
+	}, nil
+}
+
+// uploadCloneBinaries sends the tools, charms and resources used by the
+// model to the newly imported model with the given UUID.
+func (c *ControllerAPI) uploadCloneBinaries(
+	st *state.State, model description.Model, controllerInfo *api.Info, modelUUID string,
+) error {
+	modelInfo := *controllerInfo
+	modelInfo.ModelTag = names.NewModelTag(modelUUID)
+	conn, err := apiOpen(&modelInfo, api.DefaultDialOpts())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer conn.Close()
+	if err := uploadBinaries(migration.NewUploadBinariesConfig(st, model, conn)); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(uploadResources(st, model, conn), "resources")
+}
+
+// uploadCloneResources sends the content of the resources of the
+// model's applications to the model that the given connection is to.
+// Resources from the charm store are uploaded like any other, so the
+// new model does not fetch them from the store again.
+func uploadCloneResources(st *state.State, model description.Model, target api.Connection) error {
+	applications := model.Applications()
+	if len(applications) == 0 {
+		return nil
+	}
+	resources, err := st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := resourceadapters.NewAPIClient(func() (api.Connection, error) {
+		return target, nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, application := range applications {
+		serviceResources, err := resources.ListResources(application.Name())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, res := range serviceResources.Resources {
+			if res.IsPlaceholder() {
+				// Nothing has been stored for the resource yet.
+				continue
+			}
+			logger.Debugf("send resource %q of application %q to target", res.Name, application.Name())
+			if err := uploadCloneResource(resources, client.Upload, application.Name(), res.Name, res.Path); err != nil {
+				return errors.Annotatef(err, "resource %q of application %q", res.Name, application.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// uploadCloneResource sends the content of a single resource using the
+// given upload function. The content is staged in a temporary file, as
+// the upload must be able to seek through it.
+func uploadCloneResource(
+	resources state.Resources,
+	upload func(application, name, filename string, reader io.ReadSeeker) error,
+	application, name, filename string,
+) error {
+	_, reader, err := resources.OpenResource(application, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer reader.Close()
+
+	tempFile, err := ioutil.TempFile("", "juju-resource")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+	if _, err := io.Copy(tempFile, reader); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := tempFile.Seek(0, 0); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(upload(application, name, filename, tempFile))
+}
+
 func (c *ControllerAPI) environStatus(tag string) (params.ModelStatus, error) {
 	var status params.ModelStatus
 	modelTag, err := names.ParseModelTag(tag)
@@ -6,18 +6,22 @@ package controller_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/controller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/description"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/testing"
@@ -142,6 +146,29 @@ func (s *controllerSuite) TestListBlockedModels(c *gc.C) {
 
 }
 
+func (s *controllerSuite) TestListBlockedModelsEntityBlocks(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.AddBlock(state.BlockArgs{
+		Type:    state.RemoveBlock,
+		Entity:  app.Tag(),
+		Owner:   s.AdminUserTag(c),
+		Message: "protect the database",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	list, err := s.controller.ListBlockedModels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Models, gc.HasLen, 1)
+	model := list.Models[0]
+	c.Assert(model.Blocks, gc.HasLen, 0)
+	c.Assert(model.EntityBlocks, gc.HasLen, 1)
+	c.Assert(model.EntityBlocks[0].Tag, gc.Equals, app.Tag().String())
+	c.Assert(model.EntityBlocks[0].Type, gc.Equals, "BlockRemove")
+	c.Assert(model.EntityBlocks[0].Message, gc.Equals, "protect the database")
+	c.Assert(model.EntityBlocks[0].Owner, gc.Equals, s.AdminUserTag(c).Canonical())
+	c.Assert(model.EntityBlocks[0].Created, gc.NotNil)
+}
+
 func (s *controllerSuite) TestListBlockedModelsNoBlocks(c *gc.C) {
 	list, err := s.controller.ListBlockedModels()
 	c.Assert(err, jc.ErrorIsNil)
@@ -190,6 +217,72 @@ func (s *controllerSuite) TestRemoveBlocksNotAll(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "not supported")
 }
 
+func (s *controllerSuite) TestEnableMaintenance(c *gc.C) {
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	err := s.controller.EnableMaintenance(params.EnableMaintenanceArgs{
+		Reason:  "upgrading mongo",
+		Expires: &expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	maintenance, err := s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance.Reason, gc.Equals, "upgrading mongo")
+	c.Assert(maintenance.Owner, gc.Equals, s.AdminUserTag(c))
+	c.Assert(maintenance.Expires.Equal(expires), jc.IsTrue)
+}
+
+func (s *controllerSuite) TestDisableMaintenance(c *gc.C) {
+	err := s.State.EnableMaintenance(s.AdminUserTag(c), "", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.controller.DisableMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ControllerMaintenance()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestReplicaSetStatus(c *gc.C) {
+	s.PatchValue(controller.ReplicaSetStatus, func(*state.State) ([]state.ReplicaSetMemberStatus, error) {
+		return []state.ReplicaSetMemberStatus{{
+			MachineId: "0",
+			Address:   "10.0.0.1:37017",
+			State:     "PRIMARY",
+			Healthy:   true,
+			Votes:     1,
+		}, {
+			Address: "10.0.0.2:37017",
+			State:   "SECONDARY",
+			Healthy: true,
+			Lag:     2 * time.Second,
+		}}, nil
+	})
+	result, err := s.controller.ReplicaSetStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ReplicaSetStatusResult{
+		Members: []params.ReplicaSetMember{{
+			MachineTag: "machine-0",
+			Address:    "10.0.0.1:37017",
+			State:      "PRIMARY",
+			Healthy:    true,
+			Votes:      1,
+		}, {
+			Address: "10.0.0.2:37017",
+			State:   "SECONDARY",
+			Healthy: true,
+			Lag:     2 * time.Second,
+		}},
+	})
+}
+
+func (s *controllerSuite) TestReplicaSetStatusError(c *gc.C) {
+	s.PatchValue(controller.ReplicaSetStatus, func(*state.State) ([]state.ReplicaSetMemberStatus, error) {
+		return nil, errors.New("boom")
+	})
+	_, err := s.controller.ReplicaSetStatus()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *controllerSuite) TestWatchAllModels(c *gc.C) {
 	watcherId, err := s.controller.WatchAllModels()
 	c.Assert(err, jc.ErrorIsNil)
@@ -268,6 +361,112 @@ func (s *controllerSuite) TestModelStatus(c *gc.C) {
 	}})
 }
 
+func (s *controllerSuite) cloneTargetInfo(c *gc.C) params.ModelMigrationTargetInfo {
+	info := s.APIInfo(c)
+	return params.ModelMigrationTargetInfo{
+		ControllerTag: s.State.ModelTag().String(),
+		Addrs:         info.Addrs,
+		CACert:        info.CACert,
+		AuthTag:       info.Tag.String(),
+		Password:      info.Password,
+	}
+}
+
+func (s *controllerSuite) TestCloneModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	result, err := s.controller.CloneModel(params.CloneModelArgs{
+		ModelTag:   st.ModelTag().String(),
+		Name:       "copy",
+		TargetInfo: s.cloneTargetInfo(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tag, err := names.ParseModelTag(result.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Not(gc.Equals), st.ModelTag())
+
+	model, err := s.State.GetModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Name(), gc.Equals, "copy")
+	c.Check(model.Owner(), gc.Equals, s.AdminUserTag(c))
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeActive)
+}
+
+func (s *controllerSuite) TestCloneModelUploadFailureAborts(c *gc.C) {
+	s.PatchValue(controller.UploadBinaries, func(migration.UploadBinariesConfig) error {
+		return errors.New("boom")
+	})
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	_, err := s.controller.CloneModel(params.CloneModelArgs{
+		ModelTag:   st.ModelTag().String(),
+		Name:       "copy",
+		TargetInfo: s.cloneTargetInfo(c),
+	})
+	c.Assert(err, gc.ErrorMatches, "uploading binaries: boom")
+
+	models, err := s.State.AllModels()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, model := range models {
+		c.Check(model.Name(), gc.Not(gc.Equals), "copy")
+	}
+}
+
+func (s *controllerSuite) TestCloneModelUploadsResources(c *gc.C) {
+	// The charm made by the factory has no content to upload.
+	s.PatchValue(controller.UploadBinaries, func(migration.UploadBinariesConfig) error {
+		return nil
+	})
+	var uploaded []string
+	s.PatchValue(controller.UploadResources, func(st *state.State, model description.Model, _ api.Connection) error {
+		for _, application := range model.Applications() {
+			uploaded = append(uploaded, application.Name())
+		}
+		return errors.New("boom")
+	})
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st)
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+
+	_, err := s.controller.CloneModel(params.CloneModelArgs{
+		ModelTag:   st.ModelTag().String(),
+		Name:       "copy",
+		TargetInfo: s.cloneTargetInfo(c),
+	})
+	c.Assert(err, gc.ErrorMatches, "uploading binaries: resources: boom")
+	c.Assert(uploaded, jc.DeepEquals, []string{"wordpress"})
+
+	models, err := s.State.AllModels()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, model := range models {
+		c.Check(model.Name(), gc.Not(gc.Equals), "copy")
+	}
+}
+
+func (s *controllerSuite) TestCloneModelInvalidName(c *gc.C) {
+	_, err := s.controller.CloneModel(params.CloneModelArgs{
+		ModelTag:   s.State.ModelTag().String(),
+		Name:       "Not Valid",
+		TargetInfo: s.cloneTargetInfo(c),
+	})
+	c.Assert(err, gc.ErrorMatches, `model name "Not Valid" not valid`)
+}
+
+func (s *controllerSuite) TestCloneModelMissingModel(c *gc.C) {
+	_, err := s.controller.CloneModel(params.CloneModelArgs{
+		ModelTag:   randomModelTag(),
+		Name:       "copy",
+		TargetInfo: s.cloneTargetInfo(c),
+	})
+	c.Assert(err, gc.ErrorMatches, "unable to read model: .+")
+}
+
 func (s *controllerSuite) TestInitiateModelMigration(c *gc.C) {
 	// Create two hosted models to migrate.
 	st1 := s.Factory.MakeModel(c, nil)
@@ -99,3 +99,14 @@ func (api *DiscoverSpacesAPI) AddSubnets(args params.AddSubnetsParams) (params.E
 func (api *DiscoverSpacesAPI) ListSubnets(args params.SubnetsFilters) (results params.ListSubnetsResults, err error) {
 	return networkingcommon.ListSubnets(api.st, args)
 }
+
+// ReconcileSpaceDrift updates the model to match the provider's spaces
+// and subnets where it is safe to do so, and records the drift for
+// reporting. The recorded drift, including any conflicts, is returned.
+func (api *DiscoverSpacesAPI) ReconcileSpaceDrift(args params.ReconcileSpaceDriftParams) (params.SpaceDriftResults, error) {
+	drift, err := api.st.ReconcileSpaceDrift(args.Drift)
+	if err != nil {
+		return params.SpaceDriftResults{}, errors.Trace(err)
+	}
+	return params.SpaceDriftResults{Results: drift}, nil
+}
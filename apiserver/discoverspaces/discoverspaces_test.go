@@ -132,3 +132,29 @@ func (s *DiscoverSpacesSuite) TestListSpacesFailure(c *gc.C) {
 
 	apiservertesting.BackingInstance.CheckCallNames(c, "AllSpaces")
 }
+
+func (s *DiscoverSpacesSuite) TestReconcileSpaceDrift(c *gc.C) {
+	drift := []params.SpaceDrift{{
+		Kind:      "subnet-removed",
+		SpaceName: "dmz",
+		CIDR:      "192.168.1.0/24",
+	}}
+	result, err := s.facade.ReconcileSpaceDrift(params.ReconcileSpaceDriftParams{
+		Drift: drift,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, drift)
+
+	apiservertesting.BackingInstance.CheckCallNames(c, "ReconcileSpaceDrift")
+	apiservertesting.BackingInstance.CheckCall(c, 0, "ReconcileSpaceDrift", drift)
+}
+
+func (s *DiscoverSpacesSuite) TestReconcileSpaceDriftFailure(c *gc.C) {
+	apiservertesting.BackingInstance.SetErrors(errors.New("boom"))
+
+	result, err := s.facade.ReconcileSpaceDrift(params.ReconcileSpaceDriftParams{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(result, jc.DeepEquals, params.SpaceDriftResults{})
+
+	apiservertesting.BackingInstance.CheckCallNames(c, "ReconcileSpaceDrift")
+}
@@ -94,9 +94,10 @@ func TestingApiHandler(c *gc.C, srvSt, st *state.State) (*apiHandler, *common.Re
 	authCtxt, err := newAuthContext(srvSt)
 	c.Assert(err, jc.ErrorIsNil)
 	srv := &Server{
-		authCtxt: authCtxt,
-		state:    srvSt,
-		tag:      names.NewMachineTag("0"),
+		authCtxt:    authCtxt,
+		state:       srvSt,
+		tag:         names.NewMachineTag("0"),
+		maintenance: newMaintenanceCache(srvSt),
 	}
 	h, err := newApiHandler(srv, st, nil, nil, st.ModelUUID())
 	c.Assert(err, jc.ErrorIsNil)
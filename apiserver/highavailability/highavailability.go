@@ -14,13 +14,16 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	providercommon "github.com/juju/juju/provider/common"
 	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.highavailability")
 
 func init() {
-	common.RegisterStandardFacade("HighAvailability", 2, NewHighAvailabilityAPI)
+	common.RegisterStandardFacade("HighAvailability", 3, NewHighAvailabilityAPI)
 }
 
 // HighAvailability defines the methods on the highavailability API end point.
@@ -79,6 +82,8 @@ func controllersChanges(change state.ControllersChanges) params.ControllersChang
 		Promoted:   machineIdsToTags(change.Promoted...),
 		Demoted:    machineIdsToTags(change.Demoted...),
 		Converted:  machineIdsToTags(change.Converted...),
+
+		AddedWitnesses: machineIdsToTags(change.AddedWitnesses...),
 	}
 }
 
@@ -160,13 +165,82 @@ func EnableHASingle(st *state.State, spec params.ControllersSpec) (params.Contro
 		}
 	}
 
-	changes, err := st.EnableHA(spec.NumControllers, spec.Constraints, series, spec.Placement)
+	placement := spec.Placement
+	if len(placement) == 0 {
+		numControllers := spec.NumControllers
+		if numControllers == 0 {
+			// The default number of controllers.
+			numControllers = 3
+		}
+		placement = controllerZonePlacement(st, numControllers+spec.NumWitnesses)
+	}
+	changes, err := st.EnableHAWithWitnesses(
+		spec.NumControllers, spec.NumWitnesses, spec.Constraints, series, placement,
+	)
 	if err != nil {
 		return params.ControllersChanges{}, err
 	}
 	return controllersChanges(changes), nil
 }
 
+// distributeZones is patched by tests, as the dummy provider
+// only has a single available zone.
+var distributeZones = providercommon.DistributeZones
+
+// controllerZonePlacement returns "zone=" placement directives that
+// spread new controller machines across the model's availability
+// zones, taking into account the zones of the existing controllers.
+// It returns nil if the provider does not support availability zones
+// or has only one available, in which case the provider is left to
+// choose where to start the machines.
+func controllerZonePlacement(st *state.State, count int) []string {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		logger.Warningf("cannot get model config to spread controllers across zones: %v", err)
+		return nil
+	}
+	env, err := environs.New(cfg)
+	if err != nil {
+		logger.Warningf("cannot open environ to spread controllers across zones: %v", err)
+		return nil
+	}
+	zonedEnv, ok := env.(providercommon.ZonedEnviron)
+	if !ok {
+		return nil
+	}
+	info, err := st.ControllerInfo()
+	if err != nil {
+		logger.Warningf("cannot get controllers to spread across zones: %v", err)
+		return nil
+	}
+	var group []instance.Id
+	for _, id := range info.MachineIds {
+		m, err := st.Machine(id)
+		if err != nil {
+			logger.Warningf("cannot get controller machine %v: %v", id, err)
+			return nil
+		}
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			logger.Warningf("cannot get instance id of controller machine %v: %v", id, err)
+			return nil
+		}
+		group = append(group, instId)
+	}
+	zones, err := distributeZones(zonedEnv, group, count)
+	if err != nil {
+		logger.Warningf("cannot spread controllers across zones: %v", err)
+		return nil
+	}
+	placement := make([]string, len(zones))
+	for i, zone := range zones {
+		placement[i] = "zone=" + zone
+	}
+	return placement
+}
+
 // StopHAReplicationForUpgrade will prompt the HA cluster to enter upgrade
 // mongo mode.
 func (api *HighAvailabilityAPI) StopHAReplicationForUpgrade(args params.UpgradeMongoParams) (params.MongoUpgradeResults, error) {
@@ -16,7 +16,9 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
+	providercommon "github.com/juju/juju/provider/common"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/presence"
 	coretesting "github.com/juju/juju/testing"
@@ -256,6 +258,71 @@ func (s *clientSuite) TestEnableHAPlacement(c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestEnableHAWitnesses(c *gc.C) {
+	results, err := s.haServer.EnableHA(params.ControllersSpecs{
+		Specs: []params.ControllersSpec{{
+			NumControllers: 3,
+			NumWitnesses:   1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	enableHAResult := results.Results[0].Result
+	c.Assert(enableHAResult.Maintained, gc.DeepEquals, []string{"machine-0"})
+	c.Assert(enableHAResult.Added, gc.DeepEquals, []string{"machine-1", "machine-2"})
+	c.Assert(enableHAResult.AddedWitnesses, gc.DeepEquals, []string{"machine-3"})
+
+	witness, err := s.State.Machine("3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(witness.IsWitness(), jc.IsTrue)
+	c.Assert(witness.WantsVote(), jc.IsFalse)
+}
+
+func (s *clientSuite) TestEnableHAZonePlacement(c *gc.C) {
+	m, err := s.State.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var gotGroup []instance.Id
+	var gotCount int
+	s.PatchValue(highavailability.DistributeZones, func(
+		env providercommon.ZonedEnviron, group []instance.Id, count int,
+	) ([]string, error) {
+		gotGroup, gotCount = group, count
+		return []string{"az1", "az2"}, nil
+	})
+	// The dummy provider rejects all placement directives but "valid",
+	// which shows that the zone placement reached the provider.
+	_, err = s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, gc.ErrorMatches, `.*zone=az1 placement is invalid`)
+	c.Assert(gotGroup, jc.DeepEquals, []instance.Id{"inst-0"})
+	c.Assert(gotCount, gc.Equals, 3)
+}
+
+func (s *clientSuite) TestEnableHAZonePlacementNotUsedWithPlacement(c *gc.C) {
+	s.PatchValue(highavailability.DistributeZones, func(
+		providercommon.ZonedEnviron, []instance.Id, int,
+	) ([]string, error) {
+		c.Fatalf("unexpected call")
+		return nil, nil
+	})
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, []string{"valid"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestEnableHAZonePlacementError(c *gc.C) {
+	s.PatchValue(highavailability.DistributeZones, func(
+		providercommon.ZonedEnviron, []instance.Id, int,
+	) ([]string, error) {
+		return nil, errors.New("boom")
+	})
+	enableHAResult, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enableHAResult.Added, gc.DeepEquals, []string{"machine-1", "machine-2"})
+}
+
 func (s *clientSuite) TestEnableHAPlacementTo(c *gc.C) {
 	machine1Cons := constraints.MustParse("mem=8G")
 	_, err := s.State.AddMachines(state.MachineTemplate{
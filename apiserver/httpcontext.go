@@ -88,7 +88,9 @@ func checkPermissions(tag names.Tag, acceptFunc common.GetAuthFunc) (bool, error
 }
 
 // stateForRequestAuthenticatedUser is like stateForRequestAuthenticated
-// except that it also verifies that the authenticated entity is a user.
+// except that it also verifies that the authenticated entity is a user,
+// and that the request does not change anything while the controller
+// is in maintenance mode.
 func (ctxt *httpContext) stateForRequestAuthenticatedUser(r *http.Request) (*state.State, state.Entity, error) {
 	st, entity, err := ctxt.stateForRequestAuthenticated(r)
 	if err != nil {
@@ -97,6 +99,9 @@ func (ctxt *httpContext) stateForRequestAuthenticatedUser(r *http.Request) (*sta
 	if ok, err := checkPermissions(entity.Tag(), common.AuthFuncForTagKind(names.UserTagKind)); !ok {
 		return nil, nil, err
 	}
+	if err := checkMaintenanceForRequest(r, ctxt.srv.maintenance.get); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	return st, entity, nil
 }
 
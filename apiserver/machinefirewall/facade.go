@@ -0,0 +1,172 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinefirewall implements the API facade used by the
+// machinefirewall worker, which manages the host firewall of a
+// machine when the model uses the "machine" firewall mode.
+package machinefirewall
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+func init() {
+	common.RegisterStandardFacade("MachineFirewall", 1, newFacade)
+}
+
+// Backend defines the State API used by the machinefirewall facade.
+type Backend interface {
+	ModelConfig() (*config.Config, error)
+	Machine(id string) (Machine, error)
+	ModelIngressCIDRs() ([]string, error)
+	WatchModelIngressCIDRs() state.NotifyWatcher
+}
+
+// Machine defines the machine methods used by the machinefirewall
+// facade.
+type Machine interface {
+	ExposedPortRanges() ([]network.PortRange, error)
+	WatchExposedPorts() state.NotifyWatcher
+}
+
+// Facade implements the API required by the machinefirewall worker.
+type Facade struct {
+	backend      Backend
+	resources    *common.Resources
+	getCanAccess common.GetAuthFunc
+}
+
+// New returns a new API facade for the machinefirewall worker.
+func New(backend Backend, resources *common.Resources, authorizer common.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: resources,
+		getCanAccess: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// FirewallMode returns the firewall mode of the model.
+func (facade *Facade) FirewallMode() (params.StringResult, error) {
+	cfg, err := facade.backend.ModelConfig()
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringResult{Result: cfg.FirewallMode()}, nil
+}
+
+// ModelIngressCIDRs returns the CIDRs of the model's machines and
+// their subnets, from which every machine must remain reachable.
+func (facade *Facade) ModelIngressCIDRs() (params.StringsResult, error) {
+	cidrs, err := facade.backend.ModelIngressCIDRs()
+	if err != nil {
+		return params.StringsResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringsResult{Result: cidrs}, nil
+}
+
+// WatchModelIngressCIDRs returns a NotifyWatcher which notifies when
+// the CIDRs returned by ModelIngressCIDRs may have changed.
+func (facade *Facade) WatchModelIngressCIDRs() (params.NotifyWatchResult, error) {
+	watch := facade.backend.WatchModelIngressCIDRs()
+	// Consume the initial event, as in watchExposedPorts.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: facade.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
+
+// WatchExposedPorts returns a NotifyWatcher for each given machine,
+// notifying when the port ranges to allow in on that machine may have
+// changed.
+func (facade *Facade) WatchExposedPorts(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, err
+	}
+	for i, entity := range args.Entities {
+		id, err := facade.watchExposedPorts(canAccess, entity.Tag)
+		results.Results[i].NotifyWatcherId = id
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (facade *Facade) watchExposedPorts(canAccess common.AuthFunc, tagString string) (string, error) {
+	machine, err := facade.machine(canAccess, tagString)
+	if err != nil {
+		return "", err
+	}
+	watch := machine.WatchExposedPorts()
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return facade.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
+// ExposedPortRanges returns, for each given machine, the port ranges
+// opened on it by units of exposed applications.
+func (facade *Facade) ExposedPortRanges(args params.Entities) (params.MachinePortRangesResults, error) {
+	results := params.MachinePortRangesResults{
+		Results: make([]params.MachinePortRangesResult, len(args.Entities)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, err
+	}
+	for i, entity := range args.Entities {
+		ranges, err := facade.exposedPortRanges(canAccess, entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Ranges = ranges
+	}
+	return results, nil
+}
+
+func (facade *Facade) exposedPortRanges(canAccess common.AuthFunc, tagString string) ([]params.PortRange, error) {
+	machine, err := facade.machine(canAccess, tagString)
+	if err != nil {
+		return nil, err
+	}
+	portRanges, err := machine.ExposedPortRanges()
+	if err != nil {
+		return nil, err
+	}
+	ranges := make([]params.PortRange, len(portRanges))
+	for i, portRange := range portRanges {
+		ranges[i] = params.FromNetworkPortRange(portRange)
+	}
+	return ranges, nil
+}
+
+func (facade *Facade) machine(canAccess common.AuthFunc, tagString string) (Machine, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	return facade.backend.Machine(tag.Id())
+}
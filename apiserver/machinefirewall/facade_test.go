@@ -0,0 +1,179 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinefirewall_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/machinefirewall"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *machinefirewall.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		config: testing.CustomModelConfig(c, testing.Attrs{
+			"firewall-mode": config.FwMachine,
+		}),
+		machine: &mockMachine{
+			ranges: []network.PortRange{{80, 80, "tcp"}, {8000, 8080, "tcp"}},
+		},
+		cidrs: []string{"10.0.0.0/24", "10.0.0.5/32"},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	facade, err := machinefirewall.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewNotMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := machinefirewall.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestFirewallMode(c *gc.C) {
+	result, err := s.facade.FirewallMode()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResult{Result: config.FwMachine})
+}
+
+func (s *facadeSuite) TestFirewallModeError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.New("boom"))
+	result, err := s.facade.FirewallMode()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (s *facadeSuite) TestExposedPortRanges(c *gc.C) {
+	result, err := s.facade.ExposedPortRanges(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MachinePortRangesResults{
+		Results: []params.MachinePortRangesResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Ranges: []params.PortRange{
+				{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				{FromPort: 8000, ToPort: 8080, Protocol: "tcp"},
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Machine", []interface{}{"1"}},
+	})
+}
+
+func (s *facadeSuite) TestWatchExposedPorts(c *gc.C) {
+	result, err := s.facade.WatchExposedPorts(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(s.resources.Get(result.Results[1].NotifyWatcherId), gc.NotNil)
+}
+
+func (s *facadeSuite) TestModelIngressCIDRs(c *gc.C) {
+	result, err := s.facade.ModelIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResult{
+		Result: []string{"10.0.0.0/24", "10.0.0.5/32"},
+	})
+}
+
+func (s *facadeSuite) TestModelIngressCIDRsError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.New("blam"))
+	result, err := s.facade.ModelIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestWatchModelIngressCIDRs(c *gc.C) {
+	result, err := s.facade.WatchModelIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
+}
+
+type mockBackend struct {
+	stub    jujutesting.Stub
+	config  *config.Config
+	machine *mockMachine
+	cidrs   []string
+}
+
+func (backend *mockBackend) ModelConfig() (*config.Config, error) {
+	backend.stub.AddCall("ModelConfig")
+	if err := backend.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return backend.config, nil
+}
+
+func (backend *mockBackend) Machine(id string) (machinefirewall.Machine, error) {
+	backend.stub.AddCall("Machine", id)
+	if err := backend.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return backend.machine, nil
+}
+
+func (backend *mockBackend) ModelIngressCIDRs() ([]string, error) {
+	backend.stub.AddCall("ModelIngressCIDRs")
+	if err := backend.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return backend.cidrs, nil
+}
+
+func (backend *mockBackend) WatchModelIngressCIDRs() state.NotifyWatcher {
+	backend.stub.AddCall("WatchModelIngressCIDRs")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+type mockMachine struct {
+	ranges []network.PortRange
+}
+
+func (machine *mockMachine) ExposedPortRanges() ([]network.PortRange, error) {
+	return machine.ranges, nil
+}
+
+func (machine *mockMachine) WatchExposedPorts() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinefirewall
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, res *common.Resources, auth common.Authorizer) (*Facade, error) {
+	return New(backendShim{st}, res, auth)
+}
+
+// backendShim adapts *state.State to the Backend interface.
+type backendShim struct {
+	*state.State
+}
+
+// Machine is part of the Backend interface.
+func (shim backendShim) Machine(id string) (Machine, error) {
+	machine, err := shim.State.Machine(id)
+	if err != nil {
+		return nil, err
+	}
+	return machine, nil
+}
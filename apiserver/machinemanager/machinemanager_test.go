@@ -135,6 +135,10 @@ func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, erro
 	return &mockBlock{}, false, nil
 }
 
+func (st *mockState) GetBlockForEntity(t state.BlockType, entity names.Tag) (state.Block, bool, error) {
+	return &mockBlock{}, false, nil
+}
+
 func (st *mockState) ModelConfig() (*config.Config, error) {
 	panic("not implemented")
 }
@@ -4,6 +4,8 @@
 package machinemanager
 
 import (
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
@@ -13,6 +15,7 @@ type stateInterface interface {
 	ModelConfig() (*config.Config, error)
 	Model() (*state.Model, error)
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
+	GetBlockForEntity(t state.BlockType, entity names.Tag) (state.Block, bool, error)
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
@@ -34,6 +37,10 @@ func (s stateShim) GetBlockForType(t state.BlockType) (state.Block, bool, error)
 	return s.State.GetBlockForType(t)
 }
 
+func (s stateShim) GetBlockForEntity(t state.BlockType, entity names.Tag) (state.Block, bool, error) {
+	return s.State.GetBlockForEntity(t, entity)
+}
+
 func (s stateShim) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
 	return s.State.AddOneMachine(template)
 }
@@ -0,0 +1,197 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// maintenanceCache holds the controller's maintenance mode as last
+// reported by a watcher, so that it need not be read from the
+// database for every API call.
+type maintenanceCache struct {
+	st *state.State
+
+	mu sync.Mutex
+	// known records whether the maintenance mode has been read
+	// since the cache was created.
+	known   bool
+	current *state.ControllerMaintenance
+}
+
+// newMaintenanceCache returns a maintenanceCache for the controller
+// of the given state. It is not kept up to date until watch is called.
+func newMaintenanceCache(st *state.State) *maintenanceCache {
+	return &maintenanceCache{st: st}
+}
+
+// get returns the controller's maintenance mode. It returns an error
+// satisfying errors.IsNotFound if the controller is not in maintenance
+// mode. Until the watcher has reported the maintenance mode, it is
+// read from the database.
+func (m *maintenanceCache) get() (*state.ControllerMaintenance, error) {
+	m.mu.Lock()
+	known, current := m.known, m.current
+	m.mu.Unlock()
+	if !known {
+		return m.st.ControllerMaintenance()
+	}
+	if current == nil || (!current.Expires.IsZero() && !current.Expires.After(time.Now())) {
+		return nil, errors.NotFoundf("controller maintenance")
+	}
+	return current, nil
+}
+
+// refresh reads the controller's maintenance mode into the cache.
+func (m *maintenanceCache) refresh() error {
+	current, err := m.st.ControllerMaintenance()
+	if errors.IsNotFound(err) {
+		current = nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.known = true
+	m.current = current
+	return nil
+}
+
+// watch keeps the cache up to date until the given tomb is killed.
+func (m *maintenanceCache) watch(t *tomb.Tomb) error {
+	w := m.st.WatchControllerMaintenance()
+	defer watcher.Stop(w, t)
+	for {
+		select {
+		case <-t.Dying():
+			return tomb.ErrDying
+		case _, ok := <-w.Changes():
+			if !ok {
+				return watcher.EnsureErr(w)
+			}
+			if err := m.refresh(); err != nil {
+				return errors.Annotate(err, "cannot refresh controller maintenance")
+			}
+		}
+	}
+}
+
+// maintenanceRoot restricts the API calls users may make to read-only
+// ones while the controller is in maintenance mode.
+type maintenanceRoot struct {
+	rpc.MethodFinder
+	maintenance func() (*state.ControllerMaintenance, error)
+}
+
+// newMaintenanceRoot returns a new maintenanceRoot.
+func newMaintenanceRoot(finder rpc.MethodFinder, cache *maintenanceCache) *maintenanceRoot {
+	return &maintenanceRoot{finder, cache.get}
+}
+
+// allowedMethodsDuringMaintenance stores the api calls, other than
+// those known to be read only, that are not blocked while the
+// controller is in maintenance mode.
+var allowedMethodsDuringMaintenance = map[string]set.Strings{
+	"AllModelWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"AllWatcher": set.NewStrings(
+		"Next",
+		"Stop",
+	),
+	"Controller": set.NewStrings(
+		"AllModels",
+		"DisableMaintenance",
+		"EnableMaintenance",
+		"ListBlockedModels",
+		"ModelConfig",
+		"ModelStatus",
+		"ReplicaSetStatus",
+		"WatchAllModels",
+	),
+	"ModelManager": set.NewStrings(
+		"ListModels",
+	),
+	"Pinger": set.NewStrings(
+		"Ping",
+	),
+}
+
+func isMethodAllowedDuringMaintenance(rootName, methodName string) bool {
+	if isCallReadOnly(rootName, methodName) {
+		return true
+	}
+	methods, ok := allowedMethodsDuringMaintenance[rootName]
+	if !ok {
+		return false
+	}
+	return methods.Contains(methodName)
+}
+
+// FindMethod returns a MaintenanceModeError for API calls that may
+// change anything while the controller is in maintenance mode.
+func (r *maintenanceRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.MethodFinder.FindMethod(rootName, version, methodName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if isMethodAllowedDuringMaintenance(rootName, methodName) {
+		return caller, nil
+	}
+	maintenance, err := r.maintenance()
+	if errors.IsNotFound(err) {
+		return caller, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("Facade (%v) method (%v) was called during maintenance but it was blocked.", rootName, methodName)
+	return nil, &common.MaintenanceModeError{Reason: maintenance.Reason}
+}
+
+// checkMaintenanceForRequest returns a MaintenanceModeError if the
+// given HTTP request may change anything while the controller is in
+// maintenance mode, such as uploading a charm, tools, a resource or a
+// backup.
+func checkMaintenanceForRequest(r *http.Request, maintenance func() (*state.ControllerMaintenance, error)) error {
+	if r.Method == "GET" || r.Method == "HEAD" {
+		return nil
+	}
+	m, err := maintenance()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("%s %s was requested during maintenance but it was blocked.", r.Method, r.URL.Path)
+	return &common.MaintenanceModeError{Reason: m.Reason}
+}
+
+// maintenanceInfo returns the params representation of the
+// controller's maintenance mode.
+func maintenanceInfo(m *state.ControllerMaintenance) *params.MaintenanceInfo {
+	info := &params.MaintenanceInfo{
+		Reason:   m.Reason,
+		OwnerTag: m.Owner.String(),
+		Started:  m.Started,
+	}
+	if !m.Expires.IsZero() {
+		expires := m.Expires
+		info.Expires = &expires
+	}
+	return info
+}
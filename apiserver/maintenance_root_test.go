@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type maintenanceRootSuite struct {
+	coretesting.BaseSuite
+	maintenance *state.ControllerMaintenance
+	err         error
+	root        *maintenanceRoot
+}
+
+var _ = gc.Suite(&maintenanceRootSuite{})
+
+func (s *maintenanceRootSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.maintenance = nil
+	s.err = errors.NotFoundf("controller maintenance")
+	s.root = &maintenanceRoot{&fakeFinder{}, func() (*state.ControllerMaintenance, error) {
+		return s.maintenance, s.err
+	}}
+}
+
+func (s *maintenanceRootSuite) enable(reason string) {
+	s.maintenance = &state.ControllerMaintenance{Reason: reason}
+	s.err = nil
+}
+
+func (s *maintenanceRootSuite) TestNotInMaintenance(c *gc.C) {
+	caller, err := s.root.FindMethod("Application", 1, "Deploy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller, gc.NotNil)
+}
+
+func (s *maintenanceRootSuite) TestWritesBlocked(c *gc.C) {
+	s.enable("upgrading mongo")
+	caller, err := s.root.FindMethod("Application", 1, "Deploy")
+	c.Assert(err, gc.ErrorMatches, "controller is in maintenance mode: upgrading mongo")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeMaintenanceMode)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (s *maintenanceRootSuite) TestAllowedMethods(c *gc.C) {
+	s.enable("")
+	checkAllowed := func(facade string, version int, method string) {
+		caller, err := s.root.FindMethod(facade, version, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	checkAllowed("Client", 1, "FullStatus")
+	checkAllowed("UserManager", 1, "UserInfo")
+	checkAllowed("Pinger", 1, "Ping")
+	checkAllowed("ModelManager", 2, "ListModels")
+	checkAllowed("Controller", 3, "DisableMaintenance")
+	checkAllowed("Controller", 3, "ReplicaSetStatus")
+}
+
+func (s *maintenanceRootSuite) TestMaintenanceError(c *gc.C) {
+	s.err = errors.New("boom")
+	_, err := s.root.FindMethod("Application", 1, "Deploy")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *maintenanceRootSuite) TestFindNonExistentMethod(c *gc.C) {
+	s.enable("")
+	caller, err := s.root.FindMethod("Foo", 0, "Bar")
+	c.Assert(err, gc.ErrorMatches, `unknown object type "Foo"`)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (s *maintenanceRootSuite) TestMaintenanceInfo(c *gc.C) {
+	started := time.Date(2016, 9, 1, 12, 0, 0, 0, time.UTC)
+	info := maintenanceInfo(&state.ControllerMaintenance{
+		Reason:  "upgrading mongo",
+		Owner:   names.NewUserTag("admin@local"),
+		Started: started,
+	})
+	c.Assert(info, jc.DeepEquals, &params.MaintenanceInfo{
+		Reason:   "upgrading mongo",
+		OwnerTag: "user-admin@local",
+		Started:  started,
+	})
+
+	expires := started.Add(time.Hour)
+	info = maintenanceInfo(&state.ControllerMaintenance{
+		Owner:   names.NewUserTag("admin@local"),
+		Started: started,
+		Expires: expires,
+	})
+	c.Assert(info.Expires, gc.NotNil)
+	c.Assert(*info.Expires, gc.Equals, expires)
+}
+
+func (s *maintenanceRootSuite) TestCheckMaintenanceForRequest(c *gc.C) {
+	maintenance := s.root.maintenance
+	get, err := http.NewRequest("GET", "/charms", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	put, err := http.NewRequest("PUT", "/backups", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(checkMaintenanceForRequest(put, maintenance), jc.ErrorIsNil)
+
+	s.enable("upgrading mongo")
+	c.Assert(checkMaintenanceForRequest(get, maintenance), jc.ErrorIsNil)
+	err = checkMaintenanceForRequest(put, maintenance)
+	c.Assert(err, gc.ErrorMatches, "controller is in maintenance mode: upgrading mongo")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeMaintenanceMode)
+}
@@ -69,6 +69,20 @@ func (api *API) Import(serialized params.SerializedModel) error {
 	return err
 }
 
+// ImportFresh takes a serialized Juju model, deserializes it, and
+// creates a fresh deployment of it as a new model in the receiving
+// controller, owned by the authenticated user. As with Import, the new
+// model must be activated once its charms and tools have been uploaded.
+func (api *API) ImportFresh(args params.ImportFreshModelArgs) (params.ImportFreshModelResult, error) {
+	owner := api.authorizer.GetAuthTag().(names.UserTag)
+	model, st, err := migration.ImportFreshModel(api.state, args.Bytes, args.Name, owner)
+	if err != nil {
+		return params.ImportFreshModelResult{}, errors.Trace(err)
+	}
+	defer st.Close()
+	return params.ImportFreshModelResult{ModelTag: model.ModelTag().String()}, nil
+}
+
 func (api *API) getModel(args params.ModelArgs) (*state.Model, error) {
 	tag, err := names.ParseModelTag(args.ModelTag)
 	if err != nil {
@@ -97,6 +97,24 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestImportFresh(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, bytes := s.makeExportedModel(c)
+	result, err := api.ImportFresh(params.ImportFreshModelArgs{
+		Bytes: bytes,
+		Name:  "clone",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tag, err := names.ParseModelTag(result.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.GetModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Name(), gc.Equals, "clone")
+	c.Assert(model.Owner(), gc.Equals, s.Owner)
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -193,6 +194,7 @@ type mockState struct {
 	gitjujutesting.Stub
 
 	common.APIHostPortsGetter
+	common.BlockGetter
 	common.ModelConfigGetter
 	common.ToolsStorageGetter
 
@@ -227,6 +229,36 @@ func (st *mockState) ControllerModel() (*state.Model, error) {
 	return nil, st.NextErr()
 }
 
+func (st *mockState) ControllerInfo() (*state.ControllerInfo, error) {
+	st.MethodCall(st, "ControllerInfo")
+	return nil, st.NextErr()
+}
+
+func (st *mockState) CloudCredential(owner names.UserTag, cloudName, credentialName string) (state.CloudCredential, error) {
+	st.MethodCall(st, "CloudCredential", owner, cloudName, credentialName)
+	return state.CloudCredential{}, st.NextErr()
+}
+
+func (st *mockState) Cloud(name string) (cloud.Cloud, error) {
+	st.MethodCall(st, "Cloud", name)
+	return cloud.Cloud{}, st.NextErr()
+}
+
+func (st *mockState) InheritedModelConfig(cloudName, regionName string) (map[string]interface{}, error) {
+	st.MethodCall(st, "InheritedModelConfig", cloudName, regionName)
+	return nil, st.NextErr()
+}
+
+func (st *mockState) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
+	st.MethodCall(st, "ModelConfigDefaultValues")
+	return nil, st.NextErr()
+}
+
+func (st *mockState) UpdateModelConfigDefaultValues(updateAttrs map[string]interface{}, removeAttrs []string, region *state.RegionSpec) error {
+	st.MethodCall(st, "UpdateModelConfigDefaultValues", updateAttrs, removeAttrs, region)
+	return st.NextErr()
+}
+
 func (st *mockState) ForModel(tag names.ModelTag) (modelmanager.Backend, error) {
 	st.MethodCall(st, "ForModel", tag)
 	return st, st.NextErr()
@@ -277,6 +309,11 @@ func (m *mockModel) Owner() names.UserTag {
 	return m.owner
 }
 
+func (m *mockModel) SetOwner(owner names.UserTag) error {
+	m.MethodCall(m, "SetOwner", owner)
+	return m.NextErr()
+}
+
 func (m *mockModel) Life() state.Life {
 	m.MethodCall(m, "Life")
 	m.PopNoErr()
@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/permission"
@@ -151,11 +152,21 @@ func (mm *ModelManagerAPI) configSkeleton(source ConfigSource, requestedProvider
 	return result, nil
 }
 
-func (mm *ModelManagerAPI) newModelConfig(args params.ModelCreateArgs, source ConfigSource) (*config.Config, error) {
+func (mm *ModelManagerAPI) newModelConfig(
+	args params.ModelCreateArgs,
+	defaults map[string]interface{},
+	credential map[string]string,
+	cloudSpec *modelmanager.CloudSpec,
+	source ConfigSource,
+) (*config.Config, error) {
 	// For now, we just smash to the two maps together as we store
 	// the account values and the model config together in the
-	// *config.Config instance.
+	// *config.Config instance. The model starts from the model
+	// defaults of its cloud region.
 	joint := make(map[string]interface{})
+	for key, value := range defaults {
+		joint[key] = value
+	}
 	for key, value := range args.Config {
 		joint[key] = value
 	}
@@ -163,6 +174,10 @@ func (mm *ModelManagerAPI) newModelConfig(args params.ModelCreateArgs, source Co
 	for key, value := range args.Account {
 		joint[key] = value
 	}
+	// Credential attributes stored in the controller override both.
+	for key, value := range credential {
+		joint[key] = value
+	}
 	if _, ok := joint["uuid"]; ok {
 		return nil, errors.New("uuid is generated, you cannot specify one")
 	}
@@ -180,6 +195,7 @@ func (mm *ModelManagerAPI) newModelConfig(args params.ModelCreateArgs, source Co
 			}
 			return result.List, nil
 		},
+		Cloud: cloudSpec,
 	}
 	return creator.NewModelConfig(mm.isAdmin, baseConfig, joint)
 }
@@ -208,12 +224,64 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 		return result, errors.Trace(err)
 	}
 
-	// TODO(axw) the user should specify a cloud, region and
-	// credential when creating the model. For now we just
-	// assume they're the same as in the controller model.
-	cloudRegion := controllerModel.CloudRegion()
+	// If no cloud or region is specified, the model is created in
+	// the controller's cloud region.
+	info, err := mm.state.ControllerInfo()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	cloudName := args.CloudName
+	if cloudName == "" {
+		cloudName = info.CloudName
+	}
+	cloudRegion := args.CloudRegion
+	controllerRegion := controllerModel.CloudRegion()
+	inControllerRegion := cloudName == info.CloudName && (cloudRegion == "" || cloudRegion == controllerRegion)
+	if inControllerRegion {
+		cloudRegion = controllerRegion
+	}
 
-	newConfig, err := mm.newModelConfig(args, controllerModel)
+	// If a credential is specified, it must already be stored in the
+	// controller for the model owner and cloud.
+	var credential state.CloudCredential
+	if args.CloudCredential != "" {
+		credential, err = mm.state.CloudCredential(ownerTag, cloudName, args.CloudCredential)
+		if err != nil {
+			return result, errors.Annotate(err, "getting credential")
+		}
+	}
+
+	// Models in another cloud, or another region of the controller's
+	// cloud, are configured for that region by its provider, and must
+	// have their own credential.
+	var cloudSpec *modelmanager.CloudSpec
+	if !inControllerRegion {
+		if args.CloudCredential == "" {
+			return result, errors.Errorf("a credential is required to add a model to cloud %q", cloudName)
+		}
+		modelCloud, err := mm.state.Cloud(cloudName)
+		if err != nil {
+			return result, errors.Annotate(err, "getting cloud")
+		}
+		region, err := cloudRegionByName(modelCloud, cloudRegion)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		cloudRegion = region.Name
+		cloudSpec = &modelmanager.CloudSpec{
+			Type:            modelCloud.Type,
+			Region:          region.Name,
+			Endpoint:        region.Endpoint,
+			StorageEndpoint: region.StorageEndpoint,
+			Credential:      credential.Credential(),
+		}
+	}
+
+	defaults, err := mm.state.InheritedModelConfig(cloudName, cloudRegion)
+	if err != nil {
+		return result, errors.Annotate(err, "getting model defaults")
+	}
+	newConfig, err := mm.newModelConfig(args, defaults, credential.Attributes, cloudSpec, controllerModel)
 	if err != nil {
 		return result, errors.Annotate(err, "failed to create config")
 	}
@@ -221,9 +289,11 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 	// version, it is not supported, also check existing tools, and if we don't
 	// have tools for that version, also die.
 	model, st, err := mm.state.NewModel(state.ModelArgs{
-		CloudRegion: cloudRegion,
-		Config:      newConfig,
-		Owner:       ownerTag,
+		CloudName:       cloudName,
+		CloudRegion:     cloudRegion,
+		CloudCredential: args.CloudCredential,
+		Config:          newConfig,
+		Owner:           ownerTag,
 	})
 	if err != nil {
 		return result, errors.Annotate(err, "failed to create new model")
@@ -237,6 +307,38 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 	return result, nil
 }
 
+// cloudRegionByName returns the named region of the cloud, inheriting
+// any endpoints not set on the region from the cloud. If no region is
+// named, the cloud's default region is returned.
+func cloudRegionByName(c cloud.Cloud, name string) (cloud.Region, error) {
+	region := cloud.Region{Name: name}
+	if len(c.Regions) > 0 {
+		if name == "" {
+			region = c.Regions[0]
+		} else {
+			found := false
+			for _, r := range c.Regions {
+				if r.Name == name {
+					region, found = r, true
+					break
+				}
+			}
+			if !found {
+				return cloud.Region{}, errors.NotFoundf("region %q", name)
+			}
+		}
+	} else if name != "" {
+		return cloud.Region{}, errors.NotFoundf("region %q", name)
+	}
+	if region.Endpoint == "" {
+		region.Endpoint = c.Endpoint
+	}
+	if region.StorageEndpoint == "" {
+		region.StorageEndpoint = c.StorageEndpoint
+	}
+	return region, nil
+}
+
 // ListModels returns the models that the specified user
 // has access to in the current server.  Only that controller owner
 // can list models for any user (at this stage).  Other users
@@ -282,6 +384,84 @@ func (mm *ModelManagerAPI) ListModels(user params.Entity) (params.UserModelList,
 	return result, nil
 }
 
+// ModelDefaults returns the default values of the model config
+// attributes at each level at which they are set.
+func (mm *ModelManagerAPI) ModelDefaults() (params.ModelDefaultsResult, error) {
+	result := params.ModelDefaultsResult{}
+	values, err := mm.state.ModelConfigDefaultValues()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Config = make(map[string]params.ModelDefaults)
+	for attr, val := range values {
+		defaults := params.ModelDefaults{
+			Default:    val.Default,
+			Controller: val.Controller,
+		}
+		for _, region := range val.Regions {
+			defaults.Regions = append(defaults.Regions, params.RegionDefaults{
+				CloudName:  region.CloudName,
+				RegionName: region.Name,
+				Value:      region.Value,
+			})
+		}
+		result.Config[attr] = defaults
+	}
+	return result, nil
+}
+
+// SetModelDefaults sets the model defaults of the controller, or of
+// cloud regions. Only controller administrators may set model defaults.
+func (mm *ModelManagerAPI) SetModelDefaults(args params.SetModelDefaults) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Config)),
+	}
+	for i, arg := range args.Config {
+		results.Results[i].Error = common.ServerError(
+			mm.updateModelDefaults(arg.CloudName, arg.CloudRegion, arg.Config, nil),
+		)
+	}
+	return results, nil
+}
+
+// UnsetModelDefaults removes model defaults from the controller, or
+// from cloud regions. Only controller administrators may unset model
+// defaults.
+func (mm *ModelManagerAPI) UnsetModelDefaults(args params.UnsetModelDefaults) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Keys)),
+	}
+	for i, arg := range args.Keys {
+		results.Results[i].Error = common.ServerError(
+			mm.updateModelDefaults(arg.CloudName, arg.CloudRegion, nil, arg.Keys),
+		)
+	}
+	return results, nil
+}
+
+// updateModelDefaults updates the model defaults of the specified
+// cloud region, or the controller-wide model defaults if no region is
+// specified.
+func (mm *ModelManagerAPI) updateModelDefaults(cloudName, cloudRegion string, attrs map[string]interface{}, keys []string) error {
+	if !mm.isAdmin {
+		return common.ErrPerm
+	}
+	var region *state.RegionSpec
+	if cloudRegion != "" {
+		if cloudName == "" {
+			info, err := mm.state.ControllerInfo()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			cloudName = info.CloudName
+		}
+		region = &state.RegionSpec{Cloud: cloudName, Region: cloudRegion}
+	} else if cloudName != "" {
+		return errors.NotValidf("cloud %q without region", cloudName)
+	}
+	return mm.state.UpdateModelConfigDefaultValues(attrs, keys, region)
+}
+
 // ModelInfo returns information about the specified models.
 func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
 	results := params.ModelInfoResults{
@@ -404,6 +584,50 @@ func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest
 	return result, nil
 }
 
+// ChangeModelOwner transfers ownership of models to other users. Only
+// controller administrators and the current owners of the models may
+// change their owner, and not while changes to the models are blocked.
+func (m *ModelManagerAPI) ChangeModelOwner(args params.ChangeModelOwners) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, arg := range args.Changes {
+		result.Results[i].Error = common.ServerError(m.changeModelOwner(arg))
+	}
+	return result, nil
+}
+
+func (m *ModelManagerAPI) changeModelOwner(arg params.ChangeModelOwner) error {
+	modelTag, err := names.ParseModelTag(arg.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ownerTag, err := names.ParseUserTag(arg.OwnerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st, err := m.state.ForModel(modelTag)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return errors.Trace(common.ErrPerm)
+		}
+		return errors.Trace(err)
+	}
+	defer st.Close()
+
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.authCheck(model.Owner()); err != nil {
+		return errors.Trace(err)
+	}
+	if err := common.NewBlockChecker(st).ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.SetOwner(ownerTag))
+}
+
 // resolveStateAccess returns the state representation of the logical model
 // access type.
 func resolveStateAccess(access permission.ModelAccess) (state.ModelAccess, error) {
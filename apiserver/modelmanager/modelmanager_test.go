@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -135,6 +136,183 @@ func (s *modelManagerSuite) TestNonAdminCannotCreateModelForSomeoneElse(c *gc.C)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *modelManagerSuite) TestCreateModelWithCloudCredential(c *gc.C) {
+	owner := names.NewUserTag("external@remote")
+	s.setAPIUser(c, owner)
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateCloudCredential(owner, info.CloudName, "default", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "beef"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := s.createArgs(c, owner)
+	args.CloudCredential = "default"
+	model, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.State.ForModel(names.NewModelTag(model.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	newModel, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	credentialName, ok := newModel.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(credentialName, gc.Equals, "default")
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["secret"], gc.Equals, "beef")
+}
+
+func (s *modelManagerSuite) TestCreateModelMissingCloudCredential(c *gc.C) {
+	owner := names.NewUserTag("external@remote")
+	s.setAPIUser(c, owner)
+	args := s.createArgs(c, owner)
+	args.CloudCredential = "missing"
+	_, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, `getting credential: credential "missing" for user "external@remote" on cloud .* not found`)
+}
+
+func (s *modelManagerSuite) TestCreateModelOtherCloudRequiresCredential(c *gc.C) {
+	err := s.State.AddCloud("other", cloud.Cloud{Type: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	owner := names.NewUserTag("external@remote")
+	s.setAPIUser(c, owner)
+	args := s.createArgs(c, owner)
+	args.CloudName = "other"
+	_, err = s.modelmanager.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, `a credential is required to add a model to cloud "other"`)
+}
+
+func (s *modelManagerSuite) TestCreateModelUnknownCloud(c *gc.C) {
+	owner := names.NewUserTag("external@remote")
+	s.setAPIUser(c, owner)
+	err := s.State.UpdateCloudCredential(owner, "missing", "default", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "beef"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	args := s.createArgs(c, owner)
+	args.CloudName = "missing"
+	args.CloudCredential = "default"
+	_, err = s.modelmanager.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, `getting cloud: cloud "missing" not found`)
+}
+
+func (s *modelManagerSuite) TestCreateModelUnknownRegion(c *gc.C) {
+	err := s.State.AddCloud("other", cloud.Cloud{
+		Type:    "dummy",
+		Regions: []cloud.Region{{Name: "north"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	owner := names.NewUserTag("external@remote")
+	s.setAPIUser(c, owner)
+	err = s.State.UpdateCloudCredential(owner, "other", "default", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "beef"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	args := s.createArgs(c, owner)
+	args.CloudName = "other"
+	args.CloudRegion = "east"
+	args.CloudCredential = "default"
+	_, err = s.modelmanager.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, `region "east" not found`)
+}
+
+func (s *modelManagerSuite) TestCreateModelInheritsModelDefaults(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	controllerModel, err := s.State.ControllerModel()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.modelmanager.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{
+				"apt-mirror": "http://mirror",
+				"http-proxy": "http://proxy",
+			},
+		}, {
+			CloudRegion: controllerModel.CloudRegion(),
+			Config:      map[string]interface{}{"apt-mirror": "http://regionmirror"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	owner := names.NewUserTag("external@remote")
+	args := s.createArgs(c, owner)
+	args.Config["http-proxy"] = "http://modelproxy"
+	model, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.State.ForModel(names.NewModelTag(model.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	values, err := st.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.ConfigValue{
+		Value:  "http://regionmirror",
+		Source: config.JujuRegionSource,
+	})
+	c.Assert(values["http-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value:  "http://modelproxy",
+		Source: config.JujuModelConfigSource,
+	})
+
+	// Changing a default the model doesn't override changes the
+	// model's config.
+	result, err = s.modelmanager.UnsetModelDefaults(params.UnsetModelDefaults{
+		Keys: []params.ModelUnsetKeys{{
+			CloudRegion: controllerModel.CloudRegion(),
+			Keys:        []string{"apt-mirror"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AptMirror(), gc.Equals, "http://mirror")
+}
+
+func (s *modelManagerSuite) TestModelDefaults(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://mirror",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setAPIUser(c, names.NewUserTag("external@remote"))
+	result, err := s.modelmanager.ModelDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config["apt-mirror"], jc.DeepEquals, params.ModelDefaults{
+		Controller: "http://mirror",
+	})
+	c.Assert(result.Config["firewall-mode"].Default, gc.Equals, "instance")
+}
+
+func (s *modelManagerSuite) TestSetModelDefaultsNotAdmin(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("external@remote"))
+	result, err := s.modelmanager.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"apt-mirror": "http://mirror"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+	result, err = s.modelmanager.UnsetModelDefaults(params.UnsetModelDefaults{
+		Keys: []params.ModelUnsetKeys{{Keys: []string{"apt-mirror"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestSetModelDefaultsCloudWithoutRegion(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	result, err := s.modelmanager.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			CloudName: "dummy",
+			Config:    map[string]interface{}{"apt-mirror": "http://mirror"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `cloud "dummy" without region not valid`)
+}
+
 func (s *modelManagerSuite) TestConfigSkeleton(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("non-admin@remote"))
 
@@ -623,6 +801,94 @@ func (s *modelManagerSuite) TestModifyModelAccessInvalidAction(c *gc.C) {
 	c.Assert(result.OneError(), gc.ErrorMatches, expectedErr)
 }
 
+func (s *modelManagerSuite) changeOwner(c *gc.C, model names.ModelTag, owner names.UserTag) error {
+	result, err := s.modelmanager.ChangeModelOwner(params.ChangeModelOwners{
+		Changes: []params.ChangeModelOwner{{
+			ModelTag: model.String(),
+			OwnerTag: owner.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return result.OneError()
+}
+
+func (s *modelManagerSuite) TestChangeModelOwner(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+
+	err := s.changeOwner(c, st.ModelTag(), bob)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Owner(), gc.Equals, bob)
+	modelUser, err := st.ModelUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+
+	// The new owner sees the model listed as theirs.
+	s.setAPIUser(c, bob)
+	models, err := s.modelmanager.ListModels(params.Entity{Tag: bob.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models.UserModels, gc.HasLen, 1)
+	c.Assert(models.UserModels[0].OwnerTag, gc.Equals, bob.String())
+}
+
+func (s *modelManagerSuite) TestChangeModelOwnerByOwner(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Owner: bob})
+	defer st.Close()
+	charlie := s.Factory.MakeUser(c, &factory.UserParams{Name: "charlie", NoModelUser: true}).UserTag()
+
+	s.setAPIUser(c, bob)
+	err := s.changeOwner(c, st.ModelTag(), charlie)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Bob no longer owns the model, so cannot change its owner back.
+	err = s.changeOwner(c, st.ModelTag(), bob)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestChangeModelOwnerDenied(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+
+	s.setAPIUser(c, bob)
+	err := s.changeOwner(c, st.ModelTag(), bob)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Owner().Canonical(), gc.Equals, s.AdminUserTag(c).Canonical())
+}
+
+func (s *modelManagerSuite) TestChangeModelOwnerBlocked(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	err := st.SwitchBlockOn(state.ChangeBlock, "TestChangeModelOwnerBlocked")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.changeOwner(c, st.ModelTag(), bob)
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "TestChangeModelOwnerBlocked")
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Owner().Canonical(), gc.Equals, s.AdminUserTag(c).Canonical())
+}
+
+func (s *modelManagerSuite) TestChangeModelOwnerUnknownUser(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	err := s.changeOwner(c, st.ModelTag(), names.NewUserTag("nobody"))
+	c.Assert(err, gc.ErrorMatches, `cannot change owner of model ".*": user "nobody" not found`)
+}
+
 type fakeProvider struct {
 	environs.EnvironProvider
 }
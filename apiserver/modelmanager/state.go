@@ -7,6 +7,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -14,6 +15,7 @@ import (
 
 type Backend interface {
 	common.APIHostPortsGetter
+	common.BlockGetter
 	common.ModelConfigGetter
 	common.ToolsStorageGetter
 
@@ -22,6 +24,12 @@ type Backend interface {
 	IsControllerAdministrator(user names.UserTag) (bool, error)
 	NewModel(state.ModelArgs) (*state.Model, *state.State, error)
 	ControllerModel() (*state.Model, error)
+	ControllerInfo() (*state.ControllerInfo, error)
+	CloudCredential(owner names.UserTag, cloudName, credentialName string) (state.CloudCredential, error)
+	Cloud(name string) (cloud.Cloud, error)
+	InheritedModelConfig(cloudName, regionName string) (map[string]interface{}, error)
+	ModelConfigDefaultValues() (config.ModelDefaultAttributes, error)
+	UpdateModelConfigDefaultValues(updateAttrs map[string]interface{}, removeAttrs []string, region *state.RegionSpec) error
 	ForModel(tag names.ModelTag) (Backend, error)
 	Model() (Model, error)
 	AddModelUser(state.ModelUserSpec) (*state.ModelUser, error)
@@ -34,6 +42,7 @@ type Model interface {
 	Config() (*config.Config, error)
 	Life() state.Life
 	Owner() names.UserTag
+	SetOwner(names.UserTag) error
 	Status() (status.StatusInfo, error)
 	CloudRegion() string
 	Users() ([]common.ModelUser, error)
@@ -5,6 +5,7 @@ package params
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/macaroon.v1"
@@ -39,6 +40,11 @@ type ErrorInfo struct {
 	// If it is empty, the macaroon will be associated with
 	// the original URL from which the error was returned.
 	MacaroonPath string `json:",omitempty"`
+
+	// RetryAfter holds how long the client should wait before
+	// retrying the request. This field is associated with the
+	// CodeRateLimitExceeded error code.
+	RetryAfter time.Duration `json:",omitempty"`
 }
 
 func (e Error) Error() string {
@@ -85,6 +91,8 @@ const (
 	CodeMethodNotAllowed          = "method not allowed"
 	CodeForbidden                 = "forbidden"
 	CodeDischargeRequired         = "macaroon discharge required"
+	CodeRateLimitExceeded         = "rate limit exceeded"
+	CodeMaintenanceMode           = "controller in maintenance mode"
 )
 
 // ErrCode returns the error code associated with
@@ -179,6 +187,19 @@ func IsCodeTryAgain(err error) bool {
 	return ErrCode(err) == CodeTryAgain
 }
 
+// IsCodeRateLimitExceeded reports whether the request was refused
+// because the client has exceeded a rate or connection limit, and
+// should back off before retrying.
+func IsCodeRateLimitExceeded(err error) bool {
+	return ErrCode(err) == CodeRateLimitExceeded
+}
+
+// IsCodeMaintenanceMode reports whether the request was refused
+// because the controller is in maintenance mode.
+func IsCodeMaintenanceMode(err error) bool {
+	return ErrCode(err) == CodeMaintenanceMode
+}
+
 func IsCodeNotImplemented(err error) bool {
 	return ErrCode(err) == CodeNotImplemented
 }
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // Block describes a Juju block that protects model from
 // corruption.
 type Block struct {
@@ -19,6 +21,12 @@ type Block struct {
 	// Message is a descriptive or an explanatory message
 	// that the block was created with.
 	Message string `json:"message,omitempty"`
+
+	// Owner is the name of the user that created the block, if known.
+	Owner string `json:"owner,omitempty"`
+
+	// Created holds when the block was created, if known.
+	Created *time.Time `json:"created,omitempty"`
 }
 
 // BlockSwitchParams holds the parameters for switching
@@ -31,6 +39,10 @@ type BlockSwitchParams struct {
 	// Message is a descriptive or an explanatory message
 	// that accompanies the switch.
 	Message string `json:"message,omitempty"`
+
+	// Entity holds the tag of the application or machine that the
+	// block applies to. If empty, the block applies to the model.
+	Entity string `json:"entity,omitempty"`
 }
 
 // BlockResult holds the result of an API call to retrieve details
@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// Cloud holds the definition of a cloud.
+type Cloud struct {
+	Type            string                 `json:"type"`
+	AuthTypes       []string               `json:"auth-types,omitempty"`
+	Endpoint        string                 `json:"endpoint,omitempty"`
+	StorageEndpoint string                 `json:"storage-endpoint,omitempty"`
+	Regions         []CloudRegion          `json:"regions,omitempty"`
+	Config          map[string]interface{} `json:"config,omitempty"`
+}
+
+// CloudRegion holds the definition of a region of a cloud.
+type CloudRegion struct {
+	Name            string `json:"name"`
+	Endpoint        string `json:"endpoint,omitempty"`
+	StorageEndpoint string `json:"storage-endpoint,omitempty"`
+}
+
+// AddCloudArgs holds a cloud to register with the controller.
+type AddCloudArgs struct {
+	Name  string `json:"name"`
+	Cloud Cloud  `json:"cloud"`
+}
+
+// CloudsResult holds the clouds registered with the controller, keyed
+// on cloud name.
+type CloudsResult struct {
+	Clouds map[string]Cloud `json:"clouds,omitempty"`
+}
+
+// CloudCredential contains a cloud credential, including any secret
+// attributes.
+type CloudCredential struct {
+	AuthType   string            `json:"auth-type"`
+	Attributes map[string]string `json:"attrs,omitempty"`
+}
+
+// UpdateCloudCredential holds a credential to store in the controller,
+// replacing any existing credential with the same owner, cloud and name.
+type UpdateCloudCredential struct {
+	OwnerTag   string          `json:"owner-tag"`
+	Cloud      string          `json:"cloud"`
+	Name       string          `json:"name"`
+	Credential CloudCredential `json:"credential"`
+}
+
+// UpdateCloudCredentials holds credentials to store in the controller.
+type UpdateCloudCredentials struct {
+	Credentials []UpdateCloudCredential `json:"credentials"`
+}
+
+// UserCloud identifies a user and a cloud.
+type UserCloud struct {
+	UserTag string `json:"user-tag"`
+	Cloud   string `json:"cloud"`
+}
+
+// UserClouds holds the arguments for the Cloud.Credentials API call.
+type UserClouds struct {
+	UserClouds []UserCloud `json:"user-clouds"`
+}
+
+// CloudCredentialInfo describes a credential stored in the controller,
+// without its attribute values.
+type CloudCredentialInfo struct {
+	Name       string   `json:"name"`
+	AuthType   string   `json:"auth-type"`
+	Attributes []string `json:"attrs,omitempty"`
+	Revision   int      `json:"revision"`
+}
+
+// CloudCredentialsResult holds the credentials of a user for a cloud,
+// or an error.
+type CloudCredentialsResult struct {
+	Error       *Error                `json:"error,omitempty"`
+	Credentials []CloudCredentialInfo `json:"credentials,omitempty"`
+}
+
+// CloudCredentialsResults holds the results of the Cloud.Credentials
+// API call.
+type CloudCredentialsResults struct {
+	Results []CloudCredentialsResult `json:"results"`
+}
+
+// CloudSpec holds the cloud region a model is deployed to, along with
+// the credential used to access it.
+type CloudSpec struct {
+	Type            string           `json:"type"`
+	Name            string           `json:"name"`
+	Region          string           `json:"region,omitempty"`
+	Endpoint        string           `json:"endpoint,omitempty"`
+	StorageEndpoint string           `json:"storage-endpoint,omitempty"`
+	Credential      *CloudCredential `json:"credential,omitempty"`
+}
+
+// CloudSpecResult holds the result of an API call to get the cloud
+// spec of a model.
+type CloudSpecResult struct {
+	Result *CloudSpec `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// CloudSpecResults holds the results of a bulk API call to get the
+// cloud specs of models.
+type CloudSpecResults struct {
+	Results []CloudSpecResult `json:"results,omitempty"`
+}
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -18,6 +20,10 @@ type ModelBlockInfo struct {
 	UUID     string   `json:"model-uuid"`
 	OwnerTag string   `json:"owner-tag"`
 	Blocks   []string `json:"blocks"`
+
+	// EntityBlocks holds the blocks on individual applications
+	// and machines in the model.
+	EntityBlocks []Block `json:"entity-blocks,omitempty" yaml:"entityblocks,omitempty"`
 }
 
 // ModelBlockInfoList holds information about the blocked models
@@ -46,3 +52,45 @@ type ModelStatus struct {
 type ModelStatusResults struct {
 	Results []ModelStatus `json:"models"`
 }
+
+// EnableMaintenanceArgs holds the arguments for putting a controller
+// into maintenance mode.
+type EnableMaintenanceArgs struct {
+	// Reason holds why the controller is being put into
+	// maintenance mode.
+	Reason string `json:"reason,omitempty"`
+
+	// Expires holds when maintenance mode should end of its own
+	// accord. If it is nil, maintenance mode does not expire.
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// MaintenanceInfo describes a controller's maintenance mode.
+type MaintenanceInfo struct {
+	Reason   string     `json:"reason,omitempty"`
+	OwnerTag string     `json:"owner-tag"`
+	Started  time.Time  `json:"started"`
+	Expires  *time.Time `json:"expires,omitempty"`
+}
+
+// ReplicaSetMember describes the health of one member of the
+// controller's MongoDB replica set.
+type ReplicaSetMember struct {
+	// MachineTag holds the tag of the controller machine hosting
+	// the member, if known.
+	MachineTag string `json:"machine-tag,omitempty"`
+	Address    string `json:"address"`
+	// State holds the member's replica set state, such as
+	// PRIMARY or SECONDARY.
+	State   string `json:"state"`
+	Healthy bool   `json:"healthy"`
+	Votes   int    `json:"votes"`
+	// Lag holds how far the member's oplog is behind the primary's.
+	Lag time.Duration `json:"lag"`
+}
+
+// ReplicaSetStatusResult holds the status of the controller's
+// MongoDB replica set.
+type ReplicaSetStatusResult struct {
+	Members []ReplicaSetMember `json:"members"`
+}
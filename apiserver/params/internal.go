@@ -102,6 +102,21 @@ type ModelCreateArgs struct {
 	// interact with the provider to create, list and destroy machines.
 	Account map[string]interface{}
 
+	// CloudName is the name of the cloud to create the model in. If
+	// it is empty, the controller's cloud is used; any other cloud
+	// must have been added to the controller.
+	CloudName string `json:",omitempty"`
+
+	// CloudRegion is the name of the cloud region to create the model
+	// in. If it is empty, the controller's region is used for the
+	// controller's cloud, and the default region for other clouds.
+	CloudRegion string `json:",omitempty"`
+
+	// CloudCredential is the name of a credential, owned by the model
+	// owner and stored in the controller, for the model to use. If it
+	// is set, the credential's attributes take precedence over Account.
+	CloudCredential string `json:",omitempty"`
+
 	// Config defines the model config, which includes the name of the
 	// model.  An model UUID is allocated by the API server during
 	// the creation of the model.
@@ -51,6 +51,35 @@ type SerializedModel struct {
 	Bytes []byte `json:"bytes"`
 }
 
+// ImportFreshModelArgs holds a serialized Juju model to be imported
+// as a fresh deployment of a new model, when cloning a model.
+type ImportFreshModelArgs struct {
+	Bytes []byte `json:"bytes"`
+	// Name holds the name of the new model.
+	Name string `json:"name"`
+}
+
+// ImportFreshModelResult holds the tag of a model created by
+// importing a fresh deployment.
+type ImportFreshModelResult struct {
+	ModelTag string `json:"model-tag"`
+}
+
+// CloneModelArgs holds the arguments for cloning a model into a new
+// model on the same or another controller.
+type CloneModelArgs struct {
+	ModelTag string `json:"model-tag"`
+	// Name holds the name of the new model.
+	Name       string                   `json:"name"`
+	TargetInfo ModelMigrationTargetInfo `json:"target-info"`
+}
+
+// CloneModelResult holds the result of cloning a model.
+type CloneModelResult struct {
+	// ModelTag holds the tag of the new model.
+	ModelTag string `json:"model-tag"`
+}
+
 // ModelArgs wraps a simple model tag.
 type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
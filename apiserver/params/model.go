@@ -15,6 +15,68 @@ type ModelConfigResults struct {
 	Config map[string]interface{}
 }
 
+// ConfigValue holds a model config value and its source.
+type ConfigValue struct {
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// ModelConfigValuesResults contains the result of client API calls
+// to get model config values along with their sources.
+type ModelConfigValuesResults struct {
+	Config map[string]ConfigValue `json:"config"`
+}
+
+// RegionDefaults holds the default value of a model config attribute
+// for a cloud region.
+type RegionDefaults struct {
+	CloudName  string      `json:"cloud-name"`
+	RegionName string      `json:"region-name"`
+	Value      interface{} `json:"value"`
+}
+
+// ModelDefaults holds the default values of a model config attribute
+// at each level at which they are set.
+type ModelDefaults struct {
+	Default    interface{}      `json:"default,omitempty"`
+	Controller interface{}      `json:"controller,omitempty"`
+	Regions    []RegionDefaults `json:"regions,omitempty"`
+}
+
+// ModelDefaultsResult contains the result of a ModelDefaults API call.
+type ModelDefaultsResult struct {
+	Config map[string]ModelDefaults `json:"config"`
+}
+
+// ModelDefaultValues holds model defaults to set for a cloud region,
+// or for the controller if no region is specified. If a region is
+// specified without a cloud, the controller's cloud is assumed.
+type ModelDefaultValues struct {
+	CloudName   string                 `json:"cloud-name,omitempty"`
+	CloudRegion string                 `json:"cloud-region,omitempty"`
+	Config      map[string]interface{} `json:"config"`
+}
+
+// SetModelDefaults contains the arguments for a SetModelDefaults API
+// call.
+type SetModelDefaults struct {
+	Config []ModelDefaultValues `json:"config"`
+}
+
+// ModelUnsetKeys holds the model defaults to unset for a cloud region,
+// or for the controller if no region is specified.
+type ModelUnsetKeys struct {
+	CloudName   string   `json:"cloud-name,omitempty"`
+	CloudRegion string   `json:"cloud-region,omitempty"`
+	Keys        []string `json:"keys"`
+}
+
+// UnsetModelDefaults contains the arguments for an UnsetModelDefaults
+// API call.
+type UnsetModelDefaults struct {
+	Keys []ModelUnsetKeys `json:"keys"`
+}
+
 // ModelSet contains the arguments for ModelSet client API
 // call.
 type ModelSet struct {
@@ -122,6 +184,19 @@ type ModifyModelAccess struct {
 	ModelTag string                `json:"model-tag"`
 }
 
+// ChangeModelOwners holds the parameters for changing the owners
+// of models.
+type ChangeModelOwners struct {
+	Changes []ChangeModelOwner `json:"changes"`
+}
+
+// ChangeModelOwner holds the parameters for changing the owner
+// of a model.
+type ChangeModelOwner struct {
+	ModelTag string `json:"model-tag"`
+	OwnerTag string `json:"owner-tag"`
+}
+
 // ModelAction is an action that can be performed on a model.
 type ModelAction string
 
@@ -5,6 +5,7 @@ package params
 
 import (
 	"net"
+	"time"
 
 	"github.com/juju/juju/network"
 )
@@ -466,6 +467,19 @@ type MachinePortsResults struct {
 	Results []MachinePortsResult `json:"Results"`
 }
 
+// MachinePortRangesResult holds the port ranges to allow in on a
+// single machine, or an error.
+type MachinePortRangesResult struct {
+	Error  *Error      `json:"Error,omitempty"`
+	Ranges []PortRange `json:"Ranges"`
+}
+
+// MachinePortRangesResults holds all the results of the
+// MachineFirewall.ExposedPortRanges() API call.
+type MachinePortRangesResults struct {
+	Results []MachinePortRangesResult `json:"Results"`
+}
+
 // APIHostPortsResult holds the result of an APIHostPorts
 // call. Each element in the top level slice holds
 // the addresses for one API server.
@@ -586,6 +600,31 @@ type ProviderSpace struct {
 	Error      *Error   `json:"Error,omitempty"`
 }
 
+// SpaceDrift holds a single difference between the spaces and subnets
+// known to the provider and those recorded in the model.
+type SpaceDrift struct {
+	Kind         string   `json:"Kind"`
+	SpaceName    string   `json:"SpaceName,omitempty"`
+	CIDR         string   `json:"CIDR,omitempty"`
+	ProviderId   string   `json:"ProviderId,omitempty"`
+	ToSpaceName  string   `json:"ToSpaceName,omitempty"`
+	Resolved     bool     `json:"Resolved,omitempty"`
+	Conflict     string   `json:"Conflict,omitempty"`
+	Applications []string `json:"Applications,omitempty"`
+}
+
+// ReconcileSpaceDriftParams holds the drift detected by the space
+// discovery worker.
+type ReconcileSpaceDriftParams struct {
+	Drift []SpaceDrift `json:"Drift"`
+}
+
+// SpaceDriftResults holds the latest space drift recorded for a model.
+type SpaceDriftResults struct {
+	Updated time.Time    `json:"Updated"`
+	Results []SpaceDrift `json:"Results"`
+}
+
 type ProxyConfig struct {
 	HTTP    string `json:"HTTP"`
 	HTTPS   string `json:"HTTPS"`
@@ -252,6 +252,21 @@ type ApplicationExpose struct {
 	ApplicationName string
 }
 
+// ApplicationTrust holds the parameters for granting or revoking an
+// application's access to the model's cloud credential.
+type ApplicationTrust struct {
+	ApplicationName string
+	Trusted         bool
+}
+
+// ApplicationSetEndpointBindings holds the parameters for changing the
+// endpoint bindings of a deployed application. An empty endpoint name
+// binds all endpoints not otherwise specified to the given space.
+type ApplicationSetEndpointBindings struct {
+	ApplicationName  string
+	EndpointBindings map[string]string
+}
+
 // ApplicationSet holds the parameters for an application Set
 // command. Options contains the configuration data.
 type ApplicationSet struct {
@@ -384,6 +399,13 @@ type LoginRequest struct {
 	Macaroons   []macaroon.Slice `json:"macaroons"`
 }
 
+// IdentityProviderResult holds the details of the OpenID Connect
+// provider that external users log in with.
+type IdentityProviderResult struct {
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"client-id"`
+}
+
 // LoginRequestCompat holds credentials for identifying an entity to the Login v1
 // or earlier (v0 or even pre-facade).
 type LoginRequestCompat struct {
@@ -473,7 +495,11 @@ type StateServingInfo struct {
 	// cert can be generated when needed.
 	CAPrivateKey string
 	// this will be passed as the KeyFile argument to MongoDB
-	SharedSecret   string
+	SharedSecret string
+	// The key used to encrypt the values of charm secrets. It is
+	// held only in the controller agents' configuration, and never
+	// stored in the database.
+	SecretsKey     string
 	SystemIdentity string
 }
 
@@ -514,6 +540,7 @@ type ContainerConfig struct {
 	AptProxy                proxy.Settings
 	AptMirror               string
 	AllowLXCLoopMounts      bool
+	MachineLockSlots        int
 	*UpdateBehavior
 }
 
@@ -639,6 +666,10 @@ type LoginResultV1 struct {
 	// ServerVersion is the string representation of the server version
 	// if the server supports it.
 	ServerVersion string `json:"server-version,omitempty"`
+
+	// Maintenance describes the controller's maintenance mode, if
+	// it is in maintenance mode.
+	Maintenance *MaintenanceInfo `json:"maintenance,omitempty"`
 }
 
 // ControllersServersSpec contains arguments for
@@ -652,6 +683,9 @@ type ControllersSpec struct {
 	Series string `json:"series,omitempty"`
 	// Placement defines specific machines to become new controller machines.
 	Placement []string `json:"placement,omitempty"`
+	// NumWitnesses is the number of non-voting controller machines
+	// that should be kept ready to stand in for an unhealthy voter.
+	NumWitnesses int `json:"num-witnesses,omitempty"`
 }
 
 // ControllersServersSpecs contains all the arguments
@@ -684,6 +718,8 @@ type ControllersChanges struct {
 	Promoted   []string `json:"promoted,omitempty"`
 	Demoted    []string `json:"demoted,omitempty"`
 	Converted  []string `json:"converted,omitempty"`
+	// AddedWitnesses holds the new non-voting witness machines.
+	AddedWitnesses []string `json:"added-witnesses,omitempty"`
 }
 
 // FindToolsParams defines parameters for the FindTools method.
@@ -840,3 +876,68 @@ type MacaroonResult struct {
 	Result *macaroon.Macaroon `json:"result,omitempty"`
 	Error  *Error             `json:"error,omitempty"`
 }
+
+// UnitStateResult holds the charm state stored for a unit, or an error.
+type UnitStateResult struct {
+	State map[string]string `json:"state,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// UnitStateResults holds the results of a UnitStates call.
+type UnitStateResults struct {
+	Results []UnitStateResult `json:"results"`
+}
+
+// UpdateUnitStateArg holds the changes to a unit's charm state. Empty
+// values clear the corresponding keys.
+type UpdateUnitStateArg struct {
+	Tag   string            `json:"tag"`
+	State map[string]string `json:"state"`
+}
+
+// UpdateUnitStateArgs holds the parameters of an UpdateUnitStates call.
+type UpdateUnitStateArgs struct {
+	Args []UpdateUnitStateArg `json:"args"`
+}
+
+// EntityWorkloadVersion holds the workload version for an entity.
+type EntityWorkloadVersion struct {
+	Tag             string `json:"tag"`
+	WorkloadVersion string `json:"workload-version"`
+}
+
+// EntityWorkloadVersions holds the parameters for setting the workload
+// version for a set of entities.
+type EntityWorkloadVersions struct {
+	Entities []EntityWorkloadVersion `json:"entities"`
+}
+
+// GoalStateStatus holds the intended status of a unit in a goal state,
+// and the time since which its current status has been held.
+type GoalStateStatus struct {
+	Status string     `json:"status"`
+	Since  *time.Time `json:"since,omitempty"`
+}
+
+// UnitsGoalState holds the intended status of a number of units,
+// keyed on unit name.
+type UnitsGoalState map[string]GoalStateStatus
+
+// GoalState describes the intended deployment around a unit: the units
+// of its application, and the units expected on each of its relations,
+// keyed on the relation's endpoint name.
+type GoalState struct {
+	Units     UnitsGoalState            `json:"units"`
+	Relations map[string]UnitsGoalState `json:"relations"`
+}
+
+// GoalStateResult holds the goal state of a unit, or an error.
+type GoalStateResult struct {
+	Result *GoalState `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// GoalStateResults holds the results of a GoalStates call.
+type GoalStateResults struct {
+	Results []GoalStateResult `json:"results"`
+}
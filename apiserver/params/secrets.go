@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AddSecretArgs holds the arguments for adding or rotating secrets.
+type AddSecretArgs struct {
+	Args []AddSecretArg `json:"args"`
+}
+
+// AddSecretArg holds the arguments for adding a secret, or rotating
+// the value of an existing one.
+type AddSecretArg struct {
+	// OwnerTag is the tag of the application or unit that owns
+	// the secret.
+	OwnerTag string `json:"owner-tag"`
+
+	// Label optionally names the secret. Adding a secret with a label
+	// its owner already uses rotates the value of that secret.
+	Label string `json:"label,omitempty"`
+
+	// Data holds the secret's value.
+	Data map[string]string `json:"data"`
+}
+
+// GetSecretValueArgs holds the ids of secrets whose values are
+// requested.
+type GetSecretValueArgs struct {
+	Args []GetSecretValueArg `json:"args"`
+}
+
+// GetSecretValueArg identifies a secret whose value is requested.
+type GetSecretValueArg struct {
+	ID string `json:"id"`
+}
+
+// SecretValueResults holds the values of a number of secrets.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult holds the value of a secret, or an error.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// GrantSecretArgs holds the arguments for granting or revoking access
+// to secrets.
+type GrantSecretArgs struct {
+	Args []GrantSecretArg `json:"args"`
+}
+
+// GrantSecretArg identifies a secret, and the relation over which
+// access to it is granted or revoked. Access is granted to the
+// application at the other end of the relation from the secret's owner.
+type GrantSecretArg struct {
+	ID          string `json:"id"`
+	RelationTag string `json:"relation-tag"`
+}
+
+// SecretRevisionsResults holds the revisions of the secrets each of a
+// number of units has access to.
+type SecretRevisionsResults struct {
+	Results []SecretRevisionsResult `json:"results"`
+}
+
+// SecretRevisionsResult holds the current revision of each secret a
+// unit has been granted access to, keyed by secret id, or an error.
+type SecretRevisionsResult struct {
+	Revisions map[string]int `json:"revisions,omitempty"`
+	Error     *Error         `json:"error,omitempty"`
+}
+
+// ListSecretResults holds the metadata of all the secrets in a model.
+type ListSecretResults struct {
+	Results []SecretMetadata `json:"results"`
+}
+
+// SecretMetadata describes a secret, without revealing its value.
+type SecretMetadata struct {
+	ID       string        `json:"id"`
+	OwnerTag string        `json:"owner-tag"`
+	Label    string        `json:"label,omitempty"`
+	Revision int           `json:"revision"`
+	Created  time.Time     `json:"created"`
+	Updated  time.Time     `json:"updated"`
+	Grants   []SecretGrant `json:"grants,omitempty"`
+}
+
+// SecretGrant describes the access to a secret granted to an
+// application over a relation.
+type SecretGrant struct {
+	RelationKey string `json:"relation"`
+	Application string `json:"application"`
+}
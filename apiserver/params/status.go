@@ -31,9 +31,10 @@ type FullStatus struct {
 
 // ModelStatusInfo holds status information about the model itself.
 type ModelStatusInfo struct {
-	Name             string `json:"name"`
-	Version          string `json:"version"`
-	AvailableVersion string `json:"available-version"`
+	Name             string   `json:"name"`
+	Version          string   `json:"version"`
+	AvailableVersion string   `json:"available-version"`
+	Warnings         []string `json:"warnings,omitempty"`
 }
 
 // MachineStatus holds status info about a machine.
@@ -54,16 +55,17 @@ type MachineStatus struct {
 
 // ApplicationStatus holds status info about an application.
 type ApplicationStatus struct {
-	Err           error                  `json:"err,omitempty"`
-	Charm         string                 `json:"charm"` // series isn't always defined in the charm now
-	Exposed       bool                   `json:"exposed"`
-	Life          string                 `json:"life"`
-	Relations     map[string][]string    `json:"relations"`
-	CanUpgradeTo  string                 `json:"can-upgrade-to"`
-	SubordinateTo []string               `json:"subordinate-to"`
-	Units         map[string]UnitStatus  `json:"units"`
-	MeterStatuses map[string]MeterStatus `json:"meter-statuses"`
-	Status        DetailedStatus         `json:"status"`
+	Err             error                  `json:"err,omitempty"`
+	Charm           string                 `json:"charm"` // series isn't always defined in the charm now
+	Exposed         bool                   `json:"exposed"`
+	Life            string                 `json:"life"`
+	Relations       map[string][]string    `json:"relations"`
+	CanUpgradeTo    string                 `json:"can-upgrade-to"`
+	SubordinateTo   []string               `json:"subordinate-to"`
+	Units           map[string]UnitStatus  `json:"units"`
+	MeterStatuses   map[string]MeterStatus `json:"meter-statuses"`
+	Status          DetailedStatus         `json:"status"`
+	WorkloadVersion string                 `json:"workload-version"`
 }
 
 // MeterStatus represents the meter status of a unit.
@@ -80,6 +82,10 @@ type UnitStatus struct {
 	// WorkloadStatus holds the status for a unit's workload
 	WorkloadStatus DetailedStatus `json:"workload-status"`
 
+	// WorkloadVersion is the version of the workload reported by
+	// the unit's charm.
+	WorkloadVersion string `json:"workload-version"`
+
 	Machine       string                `json:"machine"`
 	OpenedPorts   []string              `json:"opened-ports"`
 	PublicAddress string                `json:"public-address"`
@@ -166,6 +172,53 @@ type StatusHistoryPruneArgs struct {
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
 
+// HookExecution describes a single execution of a hook, action or
+// juju-run command on a unit.
+type HookExecution struct {
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"`
+	RelationId int       `json:"relation-id"`
+	RemoteUnit string    `json:"remote-unit,omitempty"`
+	Started    time.Time `json:"started"`
+	Finished   time.Time `json:"finished"`
+	ExitCode   int       `json:"exit-code"`
+	Output     string    `json:"output"`
+}
+
+// HookExecutionArg holds a hook execution to record for a unit.
+type HookExecutionArg struct {
+	Tag       string        `json:"tag"`
+	Execution HookExecution `json:"execution"`
+}
+
+// HookExecutionArgs holds hook executions to record.
+type HookExecutionArgs struct {
+	Args []HookExecutionArg `json:"args"`
+}
+
+// HookHistoryRequest holds the parameters of a hook history query for
+// a unit. A Size of zero returns the entire history.
+type HookHistoryRequest struct {
+	Tag  string `json:"tag"`
+	Size int    `json:"size"`
+}
+
+// HookHistoryRequests holds a slice of HookHistoryRequest.
+type HookHistoryRequests struct {
+	Requests []HookHistoryRequest `json:"requests"`
+}
+
+// HookHistoryResult holds the hook executions of a unit, newest first.
+type HookHistoryResult struct {
+	Executions []HookExecution `json:"executions"`
+	Error      *Error          `json:"error,omitempty"`
+}
+
+// HookHistoryResults holds a slice of HookHistoryResult.
+type HookHistoryResults struct {
+	Results []HookHistoryResult `json:"results"`
+}
+
 // StatusResult holds an entity status, extra information, or an
 // error.
 type StatusResult struct {
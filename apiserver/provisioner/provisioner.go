@@ -302,6 +302,7 @@ func (p *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.AptProxy = config.AptProxySettings()
 	result.AptMirror = config.AptMirror()
 	result.AllowLXCLoopMounts, _ = config.AllowLXCLoopMounts()
+	result.MachineLockSlots, _ = config.MachineLockSlots()
 
 	return result, nil
 }
@@ -1080,6 +1080,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 		"http-proxy":            "http://proxy.example.com:9000",
 		"allow-lxc-loop-mounts": true,
 		"apt-mirror":            "http://example.mirror.com",
+		"machine-lock-slots":    4,
 	}
 	err := s.State.UpdateModelConfig(attrs, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1097,6 +1098,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 	c.Check(results.AptProxy, gc.DeepEquals, expectedProxy)
 	c.Check(results.AptMirror, gc.DeepEquals, "http://example.mirror.com")
 	c.Check(results.AllowLXCLoopMounts, jc.IsTrue)
+	c.Check(results.MachineLockSlots, gc.Equals, 4)
 }
 
 func (s *withoutControllerSuite) TestSetSupportedContainers(c *gc.C) {
@@ -0,0 +1,381 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+const (
+	// loginRateLimit defines how many concurrent agent Login
+	// requests we will accept by default.
+	loginRateLimit = 10
+
+	// defaultClientLoginConcurrency defines how many concurrent
+	// user Login requests we will accept by default.
+	defaultClientLoginConcurrency = 10
+
+	// defaultMaxUserConnections defines how many API connections
+	// a single user may have by default.
+	defaultMaxUserConnections = 100
+
+	// defaultUserRequestRate and defaultUserRequestBurst define the
+	// token bucket used by default for each user's requests.
+	defaultUserRequestRate  = 50
+	defaultUserRequestBurst = 200
+
+	// defaultIPRequestRate and defaultIPRequestBurst define the
+	// token bucket used by default for user requests from each
+	// remote address.
+	defaultIPRequestRate  = 100
+	defaultIPRequestBurst = 400
+
+	// clientLoginRetryDelay is how long users are asked to wait when
+	// too many user logins are already being processed.
+	clientLoginRetryDelay = time.Second
+
+	// bucketSweepInterval is how often idle token buckets are
+	// discarded.
+	bucketSweepInterval = time.Minute
+)
+
+// RateLimitConfig holds the limits the API server places on logins and
+// requests, so that misbehaving clients cannot starve agents of
+// service. Agents are only subject to AgentLoginConcurrency; all other
+// limits apply to users alone.
+//
+// A zero value for any limit selects its default, and a negative value
+// disables the limit.
+type RateLimitConfig struct {
+	// AgentLoginConcurrency is the maximum number of agent logins
+	// processed at once.
+	AgentLoginConcurrency int
+
+	// ClientLoginConcurrency is the maximum number of user logins
+	// processed at once. User logins are counted separately from
+	// agent logins, so that they never hold agents up.
+	ClientLoginConcurrency int
+
+	// MaxUserConnections is the maximum number of logged in API
+	// connections a single user may have.
+	MaxUserConnections int
+
+	// UserRequestRate is the number of requests per second a single
+	// user may make, and UserRequestBurst the number of requests the
+	// user may make at once before being held to that rate.
+	UserRequestRate  float64
+	UserRequestBurst int
+
+	// IPRequestRate is the number of user requests per second,
+	// including logins, that may be made from a single address, and
+	// IPRequestBurst the number that may be made at once before being
+	// held to that rate.
+	IPRequestRate  float64
+	IPRequestBurst int
+
+	// Clock is used to refill the token buckets. If it is nil, the
+	// wall clock is used.
+	Clock clock.Clock
+}
+
+// withDefaults returns a copy of the configuration with defaults
+// filled in for any unset values.
+func (c RateLimitConfig) withDefaults() RateLimitConfig {
+	if c.AgentLoginConcurrency == 0 {
+		c.AgentLoginConcurrency = loginRateLimit
+	}
+	if c.ClientLoginConcurrency == 0 {
+		c.ClientLoginConcurrency = defaultClientLoginConcurrency
+	}
+	if c.MaxUserConnections == 0 {
+		c.MaxUserConnections = defaultMaxUserConnections
+	}
+	if c.UserRequestRate == 0 {
+		c.UserRequestRate = defaultUserRequestRate
+	}
+	if c.UserRequestBurst == 0 {
+		c.UserRequestBurst = defaultUserRequestBurst
+	}
+	if c.IPRequestRate == 0 {
+		c.IPRequestRate = defaultIPRequestRate
+	}
+	if c.IPRequestBurst == 0 {
+		c.IPRequestBurst = defaultIPRequestBurst
+	}
+	if c.Clock == nil {
+		c.Clock = clock.WallClock
+	}
+	return c
+}
+
+// newConcurrencyLimiter returns a limiter allowing max concurrent
+// holders, or nil if max is negative.
+func newConcurrencyLimiter(max int) utils.Limiter {
+	if max < 0 {
+		return nil
+	}
+	return utils.NewLimiter(max)
+}
+
+// clientLimits holds the limits applied to user connections. A nil
+// *clientLimits imposes no limits.
+type clientLimits struct {
+	logins          utils.Limiter
+	userConnections *connectionQuota
+	userRequests    *requestLimiter
+	ipRequests      *requestLimiter
+}
+
+func newClientLimits(cfg RateLimitConfig) *clientLimits {
+	return &clientLimits{
+		logins:          newConcurrencyLimiter(cfg.ClientLoginConcurrency),
+		userConnections: newConnectionQuota(cfg.MaxUserConnections),
+		userRequests:    newRequestLimiter(cfg.Clock, cfg.UserRequestRate, cfg.UserRequestBurst),
+		ipRequests:      newRequestLimiter(cfg.Clock, cfg.IPRequestRate, cfg.IPRequestBurst),
+	}
+}
+
+// acquireLogin reserves a slot for a user login from the given address.
+// The returned function must be called to release the slot once the
+// login has been processed.
+func (l *clientLimits) acquireLogin(addr string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if wait, ok := l.ipRequests.take(addr); !ok {
+		return nil, &common.RateLimitExceededError{
+			Reason:     fmt.Sprintf("too many requests from %s", addr),
+			RetryAfter: wait,
+		}
+	}
+	if l.logins == nil {
+		return func() {}, nil
+	}
+	if !l.logins.Acquire() {
+		return nil, &common.RateLimitExceededError{
+			Reason:     "too many concurrent logins",
+			RetryAfter: clientLoginRetryDelay,
+		}
+	}
+	return func() { l.logins.Release() }, nil
+}
+
+// acquireConnection counts a new logged in connection against the
+// given user's quota. The returned function must be called when the
+// connection is closed.
+func (l *clientLimits) acquireConnection(user string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if !l.userConnections.acquire(user) {
+		return nil, &common.RateLimitExceededError{
+			Reason: fmt.Sprintf("too many connections for %s", user),
+		}
+	}
+	return func() { l.userConnections.release(user) }, nil
+}
+
+// takeRequest accounts for a request made by the given user from the
+// given address.
+func (l *clientLimits) takeRequest(user, addr string) error {
+	if l == nil {
+		return nil
+	}
+	if wait, ok := l.userRequests.take(user); !ok {
+		return &common.RateLimitExceededError{
+			Reason:     fmt.Sprintf("too many requests for %s", user),
+			RetryAfter: wait,
+		}
+	}
+	if wait, ok := l.ipRequests.take(addr); !ok {
+		return &common.RateLimitExceededError{
+			Reason:     fmt.Sprintf("too many requests from %s", addr),
+			RetryAfter: wait,
+		}
+	}
+	return nil
+}
+
+// remoteHost returns the host part of the given remote address, so
+// that all connections from one address share their limits.
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// connectionQuota counts the connections held by each user.
+type connectionQuota struct {
+	max int
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// newConnectionQuota returns a quota allowing max connections per
+// user, or nil if max is negative.
+func newConnectionQuota(max int) *connectionQuota {
+	if max < 0 {
+		return nil
+	}
+	return &connectionQuota{
+		max:    max,
+		counts: make(map[string]int),
+	}
+}
+
+// acquire reports whether the given user may open another connection,
+// counting it if so.
+func (q *connectionQuota) acquire(user string) bool {
+	if q == nil {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.counts[user] >= q.max {
+		return false
+	}
+	q.counts[user]++
+	return true
+}
+
+// release records that one of the user's connections has closed.
+func (q *connectionQuota) release(user string) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.counts[user] <= 1 {
+		delete(q.counts, user)
+		return
+	}
+	q.counts[user]--
+}
+
+// tokenBucket holds the tokens available to a single key of a
+// requestLimiter.
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// requestLimiter limits the rate of requests made for each key (a
+// user or an address) with a token bucket per key.
+type requestLimiter struct {
+	clock clock.Clock
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// newRequestLimiter returns a limiter refilling each bucket at rate
+// tokens per second up to burst tokens, or nil if rate is negative.
+func newRequestLimiter(clock clock.Clock, rate float64, burst int) *requestLimiter {
+	if rate < 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &requestLimiter{
+		clock:     clock,
+		rate:      rate,
+		burst:     float64(burst),
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: clock.Now(),
+	}
+}
+
+// take removes a token from the bucket for the given key. If the bucket
+// is empty, it returns false and how long it will be until a token is
+// available.
+func (l *requestLimiter) take(key string) (time.Duration, bool) {
+	if l == nil {
+		return 0, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+	if b.tokens < 1 {
+		if l.rate == 0 {
+			return 0, false
+		}
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return wait, false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// sweep discards the buckets that have refilled since they were last
+// used, so that the limiter does not grow without bound as users and
+// addresses come and go.
+func (l *requestLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < bucketSweepInterval || l.rate == 0 {
+		return
+	}
+	l.lastSweep = now
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// rateLimitedRoot limits the rate at which a logged in user may make
+// API calls.
+type rateLimitedRoot struct {
+	rpc.MethodFinder
+	limits *clientLimits
+	user   string
+	addr   string
+}
+
+// newRateLimitedRoot returns a new rateLimitedRoot.
+func newRateLimitedRoot(finder rpc.MethodFinder, limits *clientLimits, user, addr string) *rateLimitedRoot {
+	return &rateLimitedRoot{
+		MethodFinder: finder,
+		limits:       limits,
+		user:         user,
+		addr:         addr,
+	}
+}
+
+// FindMethod returns a rate limit exceeded error if the user has made
+// too many requests, other than pings, which are never limited so that
+// idle connections are not dropped.
+func (r *rateLimitedRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	if !(rootName == "Pinger" && methodName == "Ping") {
+		if err := r.limits.takeRequest(r.user, r.addr); err != nil {
+			return nil, err
+		}
+	}
+	return r.MethodFinder.FindMethod(rootName, version, methodName)
+}
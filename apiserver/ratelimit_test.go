@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type rateLimitSuite struct {
+	coretesting.BaseSuite
+	clock *coretesting.Clock
+}
+
+var _ = gc.Suite(&rateLimitSuite{})
+
+func (s *rateLimitSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Time{})
+}
+
+func (s *rateLimitSuite) TestDefaults(c *gc.C) {
+	cfg := RateLimitConfig{
+		MaxUserConnections: -1,
+		UserRequestRate:    5,
+	}.withDefaults()
+	c.Assert(cfg.AgentLoginConcurrency, gc.Equals, loginRateLimit)
+	c.Assert(cfg.ClientLoginConcurrency, gc.Equals, defaultClientLoginConcurrency)
+	c.Assert(cfg.MaxUserConnections, gc.Equals, -1)
+	c.Assert(cfg.UserRequestRate, gc.Equals, 5.0)
+	c.Assert(cfg.UserRequestBurst, gc.Equals, defaultUserRequestBurst)
+	c.Assert(cfg.IPRequestRate, gc.Equals, float64(defaultIPRequestRate))
+	c.Assert(cfg.IPRequestBurst, gc.Equals, defaultIPRequestBurst)
+	c.Assert(cfg.Clock, gc.NotNil)
+}
+
+func (s *rateLimitSuite) TestRequestLimiterBurst(c *gc.C) {
+	l := newRequestLimiter(s.clock, 2, 3)
+	for i := 0; i < 3; i++ {
+		_, ok := l.take("bob")
+		c.Assert(ok, jc.IsTrue)
+	}
+	wait, ok := l.take("bob")
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(wait, gc.Equals, 500*time.Millisecond)
+
+	// Other keys have their own buckets.
+	_, ok = l.take("mary")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *rateLimitSuite) TestRequestLimiterRefill(c *gc.C) {
+	l := newRequestLimiter(s.clock, 2, 1)
+	_, ok := l.take("bob")
+	c.Assert(ok, jc.IsTrue)
+	_, ok = l.take("bob")
+	c.Assert(ok, jc.IsFalse)
+
+	s.clock.Advance(250 * time.Millisecond)
+	wait, ok := l.take("bob")
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(wait, gc.Equals, 250*time.Millisecond)
+
+	s.clock.Advance(250 * time.Millisecond)
+	_, ok = l.take("bob")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *rateLimitSuite) TestRequestLimiterSweep(c *gc.C) {
+	l := newRequestLimiter(s.clock, 1, 1)
+	l.take("bob")
+	c.Assert(l.buckets, gc.HasLen, 1)
+
+	s.clock.Advance(bucketSweepInterval)
+	l.take("mary")
+	c.Assert(l.buckets, gc.HasLen, 1)
+	c.Assert(l.buckets["mary"], gc.NotNil)
+}
+
+func (s *rateLimitSuite) TestRequestLimiterDisabled(c *gc.C) {
+	l := newRequestLimiter(s.clock, -1, 1)
+	c.Assert(l, gc.IsNil)
+	_, ok := l.take("bob")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *rateLimitSuite) TestConnectionQuota(c *gc.C) {
+	q := newConnectionQuota(2)
+	c.Assert(q.acquire("bob"), jc.IsTrue)
+	c.Assert(q.acquire("bob"), jc.IsTrue)
+	c.Assert(q.acquire("bob"), jc.IsFalse)
+	c.Assert(q.acquire("mary"), jc.IsTrue)
+
+	q.release("bob")
+	c.Assert(q.acquire("bob"), jc.IsTrue)
+	q.release("mary")
+	c.Assert(q.counts, jc.DeepEquals, map[string]int{"bob": 2})
+}
+
+func (s *rateLimitSuite) TestClientLoginConcurrency(c *gc.C) {
+	limits := newClientLimits(RateLimitConfig{
+		ClientLoginConcurrency: 1,
+		Clock:                  s.clock,
+	}.withDefaults())
+	release, err := limits.acquireLogin("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = limits.acquireLogin("10.0.0.2")
+	c.Assert(err, gc.ErrorMatches, "rate limit exceeded: too many concurrent logins")
+	c.Assert(err.(*common.RateLimitExceededError).RetryAfter, gc.Equals, clientLoginRetryDelay)
+
+	release()
+	release, err = limits.acquireLogin("10.0.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+	release()
+}
+
+func (s *rateLimitSuite) TestClientLoginAddressRate(c *gc.C) {
+	limits := newClientLimits(RateLimitConfig{
+		IPRequestRate:  1,
+		IPRequestBurst: 1,
+		Clock:          s.clock,
+	}.withDefaults())
+	release, err := limits.acquireLogin("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	release()
+
+	_, err = limits.acquireLogin("10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, "rate limit exceeded: too many requests from 10.0.0.1")
+	c.Assert(err.(*common.RateLimitExceededError).RetryAfter, gc.Equals, time.Second)
+}
+
+func (s *rateLimitSuite) TestRateLimitedRoot(c *gc.C) {
+	limits := newClientLimits(RateLimitConfig{
+		UserRequestRate:  1,
+		UserRequestBurst: 1,
+		Clock:            s.clock,
+	}.withDefaults())
+	root := newRateLimitedRoot(&fakeFinder{}, limits, "user-bob@local", "10.0.0.1")
+
+	caller, err := root.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller, gc.NotNil)
+
+	_, err = root.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, gc.ErrorMatches, "rate limit exceeded: too many requests for user-bob@local")
+
+	// Pings are not limited.
+	_, err = root.FindMethod("Pinger", 1, "Ping")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(time.Second)
+	_, err = root.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *rateLimitSuite) TestRemoteHost(c *gc.C) {
+	c.Assert(remoteHost("10.0.0.1:1234"), gc.Equals, "10.0.0.1")
+	c.Assert(remoteHost("[::1]:1234"), gc.Equals, "::1")
+	c.Assert(remoteHost("unknown"), gc.Equals, "unknown")
+}
@@ -39,6 +39,7 @@ var readOnlyCalls = set.NewStrings(
 	// to deploying the bundle or changes. But... let's leave it here anyway.
 	"Client.GetBundleChanges",
 	"Client.GetModelConstraints",
+	"Client.HookHistory",
 	"Client.PrivateAddress",
 	"Client.PublicAddress",
 	// ResolveCharms, while being technically read only, isn't a useful
@@ -49,6 +50,7 @@ var readOnlyCalls = set.NewStrings(
 	// TODO: add controller work.
 	"KeyManager.ListKeys",
 	"ModelManager.ModelInfo",
+	"Secrets.ListSecrets",
 	"Spaces.ListSpaces",
 	"Storage.ListStorageDetails",
 	"Storage.ListFilesystems",
@@ -51,6 +51,13 @@ type apiHandler struct {
 	// path, logins processed with v2 or later will only offer the
 	// user manager and model manager api endpoints from here.
 	modelUUID string
+	// remoteAddr holds the host the connection was made from.
+	remoteAddr string
+
+	mu sync.Mutex
+	// quotaRelease, if set, releases the connection quota taken
+	// when a user logged in.
+	quotaRelease func()
 }
 
 var _ = (*apiHandler)(nil)
@@ -88,6 +95,25 @@ func (r *apiHandler) getRpcConn() *rpc.Conn {
 	return r.rpcConn
 }
 
+// setQuotaRelease records the function to call to release the
+// connection quota taken when a user logged in.
+func (r *apiHandler) setQuotaRelease(release func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.quotaRelease = release
+}
+
+// releaseQuota releases any connection quota held by the handler.
+func (r *apiHandler) releaseQuota() {
+	r.mu.Lock()
+	release := r.quotaRelease
+	r.quotaRelease = nil
+	r.mu.Unlock()
+	if release != nil {
+		release()
+	}
+}
+
 // Kill implements rpc.Killer, cleaning up any resources that need
 // cleaning up to ensure that all outstanding requests return.
 func (r *apiHandler) Kill() {
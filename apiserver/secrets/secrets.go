@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets implements the API endpoint used by Juju clients to
+// inspect the charm secrets stored in a model.
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Secrets", 1, NewAPI)
+}
+
+// SecretsBackend defines the state methods used by the secrets facade.
+type SecretsBackend interface {
+	AllSecrets() ([]*state.Secret, error)
+}
+
+// API implements the Secrets facade.
+type API struct {
+	backend SecretsBackend
+}
+
+// NewAPI returns a new Secrets API facade. Only administrators of the
+// model may use it.
+func NewAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	if err := common.CheckModelAdmin(common.NewModelAdminBackend(st), authorizer.GetAuthTag()); err != nil {
+		return nil, err
+	}
+	return &API{backend: st}, nil
+}
+
+// ListSecrets returns the metadata of all the secrets in the model.
+// Secret values are never returned.
+func (api *API) ListSecrets() (params.ListSecretResults, error) {
+	secrets, err := api.backend.AllSecrets()
+	if err != nil {
+		return params.ListSecretResults{}, errors.Trace(err)
+	}
+	result := params.ListSecretResults{
+		Results: make([]params.SecretMetadata, len(secrets)),
+	}
+	for i, secret := range secrets {
+		owner, err := secret.Owner()
+		if err != nil {
+			return params.ListSecretResults{}, errors.Trace(err)
+		}
+		metadata := params.SecretMetadata{
+			ID:       secret.Id(),
+			OwnerTag: owner.String(),
+			Label:    secret.Label(),
+			Revision: secret.Revision(),
+			Created:  secret.Created(),
+			Updated:  secret.Updated(),
+		}
+		for _, grant := range secret.Grants() {
+			metadata.Grants = append(metadata.Grants, params.SecretGrant{
+				RelationKey: grant.RelationKey,
+				Application: grant.Application,
+			})
+		}
+		result.Results[i] = metadata
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/secrets"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type secretsSuite struct {
+	jujutesting.JujuConnSuite
+
+	api *secrets.API
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	var err error
+	s.api, err = secrets.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := secrets.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *secretsSuite) TestNewAPIRequiresModelAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Access: state.ModelReadAccess})
+	_, err := secrets.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: user.UserTag(),
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+
+	admin := s.Factory.MakeUser(c, &factory.UserParams{Access: state.ModelAdminAccess})
+	_, err = secrets.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: admin.UserTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: unit.Tag(),
+		Label: "admin",
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.GrantSecret(id, rel, "mysql", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListSecretResults{
+		Results: []params.SecretMetadata{{
+			ID:       id,
+			OwnerTag: "unit-wordpress-0",
+			Label:    "admin",
+			Revision: 1,
+			Created:  secret.Created(),
+			Updated:  secret.Updated(),
+			Grants: []params.SecretGrant{{
+				RelationKey: rel.String(),
+				Application: "mysql",
+			}},
+		}},
+	})
+}
@@ -20,6 +20,7 @@ func init() {
 type API interface {
 	CreateSpaces(params.CreateSpacesParams) (params.ErrorResults, error)
 	ListSpaces() (params.ListSpacesResults, error)
+	ListSpaceDrift() (params.SpaceDriftResults, error)
 }
 
 // spacesAPI implements the API interface.
@@ -88,3 +89,17 @@ func (api *spacesAPI) ListSpaces() (results params.ListSpacesResults, err error)
 	}
 	return results, nil
 }
+
+// ListSpaceDrift returns the latest differences recorded between the
+// spaces and subnets known to the provider and those in the model.
+func (api *spacesAPI) ListSpaceDrift() (params.SpaceDriftResults, error) {
+	err := networkingcommon.SupportsSpaces(api.backing)
+	if err != nil {
+		return params.SpaceDriftResults{}, common.ServerError(errors.Trace(err))
+	}
+	drift, err := api.backing.SpaceDrift()
+	if err != nil {
+		return params.SpaceDriftResults{}, errors.Trace(err)
+	}
+	return drift, nil
+}
@@ -350,3 +350,28 @@ func (s *SpacesSuite) TestListSpacesNotSupportedError(c *gc.C) {
 	_, err := s.facade.ListSpaces()
 	c.Assert(err, gc.ErrorMatches, "spaces not supported")
 }
+
+func (s *SpacesSuite) TestListSpaceDrift(c *gc.C) {
+	drift := []params.SpaceDrift{{
+		Kind:         "space-removed",
+		SpaceName:    "dmz",
+		Conflict:     "application endpoints bound to space: mysql",
+		Applications: []string{"mysql"},
+	}}
+	apiservertesting.BackingInstance.Drift.Results = drift
+
+	result, err := s.facade.ListSpaceDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, drift)
+}
+
+func (s *SpacesSuite) TestListSpaceDriftNotSupportedError(c *gc.C) {
+	apiservertesting.SharedStub.SetErrors(
+		nil, // Backing.ModelConfig()
+		nil, // Provider.Open
+		errors.NotSupportedf("spaces"), // ZonedNetworkingEnviron.SupportsSpaces()
+	)
+
+	_, err := s.facade.ListSpaceDrift()
+	c.Assert(err, gc.ErrorMatches, "spaces not supported")
+}
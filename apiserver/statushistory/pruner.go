@@ -4,6 +4,8 @@
 package statushistory
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
@@ -27,12 +29,15 @@ func NewAPI(st *state.State, _ *common.Resources, auth common.Authorizer) (*API,
 	}, nil
 }
 
-// Prune endpoint removes status history entries until
-// only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
+// Prune endpoint removes status history and hook history entries
+// until only the ones newer than now - p.MaxHistoryTime remain and
+// each history is smaller than p.MaxHistoryMB.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthModelManager() {
 		return common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	if err := state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(state.PruneHookHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB))
 }
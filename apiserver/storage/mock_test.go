@@ -155,6 +155,10 @@ func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, erro
 	return st.getBlockForType(t)
 }
 
+func (st *mockState) GetBlockForEntity(t state.BlockType, entity names.Tag) (state.Block, bool, error) {
+	return nil, false, nil
+}
+
 func (st *mockState) BlockDevices(m names.MachineTag) ([]state.BlockDeviceInfo, error) {
 	if st.blockDevices != nil {
 		return st.blockDevices(m)
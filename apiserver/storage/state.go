@@ -82,6 +82,10 @@ type storageAccess interface {
 
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
+
+	// GetBlockForEntity is required to block operations on
+	// individual applications and machines.
+	GetBlockForEntity(t state.BlockType, entity names.Tag) (state.Block, bool, error)
 }
 
 var getState = func(st *state.State) storageAccess {
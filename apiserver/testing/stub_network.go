@@ -340,6 +340,7 @@ type StubBacking struct {
 	Zones   []providercommon.AvailabilityZone
 	Spaces  []networkingcommon.BackingSpace
 	Subnets []networkingcommon.BackingSubnet
+	Drift   params.SpaceDriftResults
 }
 
 var _ networkingcommon.NetworkBacking = (*StubBacking)(nil)
@@ -368,6 +369,7 @@ func (sb *StubBacking) SetUp(c *gc.C, envName string, withZones, withSpaces, wit
 		"name": envName,
 	}
 	sb.EnvConfig = coretesting.CustomModelConfig(c, extraAttrs)
+	sb.Drift = params.SpaceDriftResults{}
 	sb.Zones = []providercommon.AvailabilityZone{}
 	if withZones {
 		sb.Zones = make([]providercommon.AvailabilityZone, len(ProviderInstance.Zones))
@@ -499,6 +501,23 @@ func (sb *StubBacking) AddSpace(name string, providerId network.Id, subnets []st
 	return nil
 }
 
+func (sb *StubBacking) ReconcileSpaceDrift(drift []params.SpaceDrift) ([]params.SpaceDrift, error) {
+	sb.MethodCall(sb, "ReconcileSpaceDrift", drift)
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	sb.Drift.Results = drift
+	return drift, nil
+}
+
+func (sb *StubBacking) SpaceDrift() (params.SpaceDriftResults, error) {
+	sb.MethodCall(sb, "SpaceDrift")
+	if err := sb.NextErr(); err != nil {
+		return params.SpaceDriftResults{}, err
+	}
+	return sb.Drift, nil
+}
+
 // GoString implements fmt.GoStringer.
 func (se *StubBacking) GoString() string {
 	return "&StubBacking{}"
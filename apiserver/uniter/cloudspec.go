@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// CloudSpec returns the cloud the model is deployed to, including the
+// credential used to access it, for each given unit. The unit's
+// application must have been trusted with the credential by the model
+// owner, and every retrieval is recorded in the audit log.
+func (u *UniterAPIV5) CloudSpec(args params.Entities) (params.CloudSpecResults, error) {
+	result := params.CloudSpecResults{
+		Results: make([]params.CloudSpecResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.CloudSpecResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			result.Results[i].Result, err = u.oneCloudSpec(unit)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV5) oneCloudSpec(unit *state.Unit) (*params.CloudSpec, error) {
+	application, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !application.IsTrusted() {
+		return nil, errors.Unauthorizedf(
+			"application %q is not trusted with the cloud credential", application.Name(),
+		)
+	}
+	spec, err := u.st.ModelCloudSpec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.CloudSpec{
+		Type:            spec.Type,
+		Name:            spec.Name,
+		Region:          spec.Region,
+		Endpoint:        spec.Endpoint,
+		StorageEndpoint: spec.StorageEndpoint,
+	}
+	if spec.Credential != nil {
+		result.Credential = &params.CloudCredential{
+			AuthType:   string(spec.Credential.AuthType()),
+			Attributes: spec.Credential.Attributes(),
+		}
+	} else {
+		// Models without a controller-stored credential hold
+		// their credential attributes in the model config.
+		cfg, err := u.st.ModelConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Credential, err = configCredential(cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	audit.Audit(auditEntity(unit.Tag().String()), "read cloud credential for model %s", u.st.ModelUUID())
+	return result, nil
+}
+
+// configCredential returns the credential held in the given model
+// config, matching it against the credential schemas of the model's
+// provider. The schema with the most attributes set in the config is
+// chosen, or nil is returned if the config satisfies none of them.
+func configCredential(cfg *config.Config) (*params.CloudCredential, error) {
+	provider, err := environs.Provider(cfg.Type())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	schemas := provider.CredentialSchemas()
+	authTypes := make([]string, 0, len(schemas))
+	for authType := range schemas {
+		authTypes = append(authTypes, string(authType))
+	}
+	sort.Strings(authTypes)
+
+	allAttrs := cfg.AllAttrs()
+	var result *params.CloudCredential
+	for _, authType := range authTypes {
+		attrs := make(map[string]string)
+		complete := true
+		for _, attr := range schemas[cloud.AuthType(authType)] {
+			if value, ok := allAttrs[attr.Name]; ok && value != "" {
+				attrs[attr.Name] = fmt.Sprint(value)
+			} else if !attr.Optional {
+				complete = false
+				break
+			}
+		}
+		if !complete {
+			continue
+		}
+		if result == nil || len(attrs) > len(result.Attributes) {
+			result = &params.CloudCredential{
+				AuthType:   authType,
+				Attributes: attrs,
+			}
+		}
+	}
+	return result, nil
+}
+
+// auditEntity identifies the entity performing an audited action.
+type auditEntity string
+
+// Tag is part of the audit.Tagger interface.
+func (e auditEntity) Tag() string {
+	return string(e)
+}
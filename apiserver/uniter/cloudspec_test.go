@@ -0,0 +1,53 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *uniterSuite) TestCloudSpec(c *gc.C) {
+	err := s.wordpress.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.newUniterAPIV5(c, s.wordpressUnit)
+	result, err := api.CloudSpec(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+		{Tag: "application-wordpress"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Check(result.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[0].Result, jc.DeepEquals, &params.CloudSpec{
+		Type:   "dummy",
+		Name:   "dummy",
+		Region: "some-region",
+		Credential: &params.CloudCredential{
+			AuthType:   "empty",
+			Attributes: map[string]string{},
+		},
+	})
+	c.Check(c.GetTestLog(), jc.Contains, "unit-wordpress-0: read cloud credential for model "+s.State.ModelUUID())
+}
+
+func (s *uniterSuite) TestCloudSpecNotTrusted(c *gc.C) {
+	api := s.newUniterAPIV5(c, s.wordpressUnit)
+	result, err := api.CloudSpec(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, &params.Error{
+		Code:    params.CodeUnauthorized,
+		Message: `application "wordpress" is not trusted with the cloud credential`,
+	})
+	c.Assert(result.Results[0].Result, gc.IsNil)
+}
@@ -0,0 +1,187 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// Goal state statuses of units.
+const (
+	// goalStateWaiting is the status of a unit that is expected, but
+	// is still being assigned or provisioned, or has not yet joined
+	// the relation.
+	goalStateWaiting = "waiting"
+
+	// goalStateActive is the status of a unit that is deployed, or
+	// has joined the relation.
+	goalStateActive = "active"
+
+	// goalStateDying is the status of a unit, or a relation, that is
+	// going away.
+	goalStateDying = "dying"
+)
+
+// GoalStates returns the intended deployment around each given unit:
+// the units of its application, and the units expected on each of
+// its relations, with their intended status.
+func (u *UniterAPIV5) GoalStates(args params.Entities) (params.GoalStateResults, error) {
+	result := params.GoalStateResults{
+		Results: make([]params.GoalStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.GoalStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			result.Results[i].Result, err = u.oneGoalState(unit)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV5) oneGoalState(unit *state.Unit) (*params.GoalState, error) {
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	assignments, err := u.st.AllUnitAssignments()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pending := set.NewStrings()
+	for _, assignment := range assignments {
+		pending.Add(assignment.Unit)
+	}
+
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	goalState := &params.GoalState{
+		Units:     make(params.UnitsGoalState),
+		Relations: make(map[string]params.UnitsGoalState),
+	}
+	for _, appUnit := range units {
+		unitStatus, err := unitGoalStatus(appUnit, pending)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		goalState.Units[appUnit.Name()] = unitStatus
+	}
+
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, relation := range relations {
+		endpoint, err := relation.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relationUnits, ok := goalState.Relations[endpoint.Name]
+		if !ok {
+			relationUnits = make(params.UnitsGoalState)
+			goalState.Relations[endpoint.Name] = relationUnits
+		}
+		if err := u.relationGoalStatus(relation, unit, pending, relationUnits); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return goalState, nil
+}
+
+// relationGoalStatus adds the units expected at the other end of the
+// relation, as seen by the given unit, to relationUnits.
+func (u *UniterAPIV5) relationGoalStatus(relation *state.Relation, unit *state.Unit, pending set.Strings, relationUnits params.UnitsGoalState) error {
+	related, err := relation.RelatedEndpoints(unit.ApplicationName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, endpoint := range related {
+		app, err := u.st.Application(endpoint.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, relatedUnit := range units {
+			if relatedUnit.Name() == unit.Name() {
+				// A unit does not see itself on a peer relation.
+				continue
+			}
+			unitStatus, err := unitGoalStatus(relatedUnit, pending)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			switch {
+			case relation.Life() != state.Alive:
+				unitStatus.Status = goalStateDying
+			case unitStatus.Status == goalStateActive:
+				ru, err := relation.Unit(relatedUnit)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				inScope, err := ru.InScope()
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if !inScope {
+					unitStatus.Status = goalStateWaiting
+				}
+			}
+			relationUnits[relatedUnit.Name()] = unitStatus
+		}
+	}
+	return nil
+}
+
+// unitGoalStatus returns the goal state status of the unit. Units
+// that have yet to be assigned to a machine, or whose agent is still
+// being allocated, are waiting; units that are not alive are dying.
+func unitGoalStatus(unit *state.Unit, pending set.Strings) (params.GoalStateStatus, error) {
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return params.GoalStateStatus{}, errors.Trace(err)
+	}
+	result := params.GoalStateStatus{
+		Status: goalStateActive,
+		Since:  agentStatus.Since,
+	}
+	if unit.Life() != state.Alive {
+		result.Status = goalStateDying
+		return result, nil
+	}
+	if pending.Contains(unit.Name()) || agentStatus.Status == status.StatusAllocating {
+		result.Status = goalStateWaiting
+		return result, nil
+	}
+	if _, err := unit.AssignedMachineId(); errors.IsNotAssigned(err) {
+		result.Status = goalStateWaiting
+	} else if err != nil {
+		return params.GoalStateStatus{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/status"
+)
+
+func (s *uniterSuite) setAgentIdle(c *gc.C, agent interface {
+	SetStatus(status.StatusInfo) error
+}, since time.Time) {
+	err := agent.SetStatus(status.StatusInfo{Status: status.StatusIdle, Since: &since})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) TestGoalStates(c *gc.C) {
+	since := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.setAgentIdle(c, s.wordpressUnit.Agent(), since)
+	s.setAgentIdle(c, s.mysqlUnit.Agent(), since)
+
+	// A second wordpress unit, not yet assigned to a machine.
+	_, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// mysql/0 joins the relation; mysql/1 is yet to be provisioned.
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.newUniterAPIV5(c, s.wordpressUnit)
+	result, err := api.GoalStates(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+		{Tag: "application-wordpress"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Check(result.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	goalState := result.Results[0].Result
+	c.Assert(goalState, gc.NotNil)
+	c.Check(statuses(goalState.Units), jc.DeepEquals, map[string]string{
+		"wordpress/0": "active",
+		"wordpress/1": "waiting",
+	})
+	c.Check(goalState.Units["wordpress/0"].Since.Equal(since), jc.IsTrue)
+	c.Assert(goalState.Relations, gc.HasLen, 1)
+	c.Check(statuses(goalState.Relations["db"]), jc.DeepEquals, map[string]string{
+		"mysql/0": "active",
+		"mysql/1": "waiting",
+	})
+
+	// Once the relation is dying, all related units are going away.
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = api.GoalStates(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Check(statuses(result.Results[0].Result.Relations["db"]), jc.DeepEquals, map[string]string{
+		"mysql/0": "dying",
+		"mysql/1": "dying",
+	})
+}
+
+func statuses(units params.UnitsGoalState) map[string]string {
+	result := make(map[string]string)
+	for name, unitStatus := range units {
+		result[name] = unitStatus.Status
+	}
+	return result
+}
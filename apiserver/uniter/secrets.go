@@ -0,0 +1,217 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// AddSecrets adds secrets owned by the authenticated unit or its
+// application, or rotates the values of existing ones with the same
+// label, and returns their ids. Only the application's leader may
+// manage the application's secrets.
+func (u *UniterAPIV5) AddSecrets(args params.AddSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		id, err := u.addOneSecret(arg)
+		result.Results[i].Result = id
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV5) addOneSecret(arg params.AddSecretArg) (string, error) {
+	owner, err := names.ParseTag(arg.OwnerTag)
+	if err != nil {
+		return "", common.ErrPerm
+	}
+	token, err := u.secretOwnerToken(owner)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return u.st.AddSecret(state.AddSecretArgs{
+		Owner: owner,
+		Label: arg.Label,
+		Data:  arg.Data,
+		Token: token,
+	})
+}
+
+// GetSecretValues returns the values of the given secrets, which must
+// be owned by the authenticated unit or its application, or have been
+// granted to its application.
+func (u *UniterAPIV5) GetSecretValues(args params.GetSecretValueArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		secret, err := u.st.Secret(arg.ID)
+		if err == nil && !secret.CanRead(u.unit.UnitTag()) {
+			err = common.ErrPerm
+		}
+		if err == nil {
+			result.Results[i].Data, err = secret.Value()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GrantSecrets grants the applications at the other end of the given
+// relations access to the given secrets, which must be owned by the
+// authenticated unit or its application.
+func (u *UniterAPIV5) GrantSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return u.changeSecretGrants(args, u.st.GrantSecret)
+}
+
+// RevokeSecrets revokes access to the given secrets previously granted
+// to the applications at the other end of the given relations.
+func (u *UniterAPIV5) RevokeSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return u.changeSecretGrants(args, u.st.RevokeSecret)
+}
+
+type secretGrantFunc func(id string, relation *state.Relation, application string, token leadership.Token) error
+
+func (u *UniterAPIV5) changeSecretGrants(args params.GrantSecretArgs, change secretGrantFunc) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := u.changeOneSecretGrant(arg, change)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV5) changeOneSecretGrant(arg params.GrantSecretArg, change secretGrantFunc) error {
+	secret, err := u.st.Secret(arg.ID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !secret.OwnedBy(u.unit.UnitTag()) {
+		return common.ErrPerm
+	}
+	owner, err := secret.Owner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	token, err := u.secretOwnerToken(owner)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	relationTag, err := names.ParseRelationTag(arg.RelationTag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	relation, err := u.st.KeyRelation(relationTag.Id())
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	related, err := relation.RelatedEndpoints(u.unit.ApplicationName())
+	if err != nil {
+		return common.ErrPerm
+	}
+	if len(related) != 1 || related[0].ApplicationName == u.unit.ApplicationName() {
+		return errors.NotValidf("granting secrets over peer relation %q", relation)
+	}
+	return change(secret.Id(), relation, related[0].ApplicationName, token)
+}
+
+// secretOwnerToken checks that the authenticated unit may manage
+// secrets owned by the given entity, and returns the leadership token
+// needed to manage secrets owned by its application.
+func (u *UniterAPIV5) secretOwnerToken(owner names.Tag) (leadership.Token, error) {
+	switch owner {
+	case u.unit.Tag():
+		return nil, nil
+	case names.NewApplicationTag(u.unit.ApplicationName()):
+		checker := u.st.LeadershipChecker()
+		return checker.LeadershipCheck(u.unit.ApplicationName(), u.unit.Name()), nil
+	}
+	return nil, common.ErrPerm
+}
+
+// WatchSecrets returns a NotifyWatcher for each given unit, that
+// notifies when the secrets it has been granted access to may have
+// changed.
+func (u *UniterAPIV5) WatchSecrets(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchOneUnitSecrets(tag)
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV5) watchOneUnitSecrets(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	watch := unit.WatchSecrets()
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
+// SecretRevisions returns, for each given unit, the current revision
+// of each secret it has been granted access to.
+func (u *UniterAPIV5) SecretRevisions(args params.Entities) (params.SecretRevisionsResults, error) {
+	result := params.SecretRevisionsResults{
+		Results: make([]params.SecretRevisionsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretRevisionsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				result.Results[i].Revisions, err = unit.SecretRevisions()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/uniter"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+func (s *uniterSuite) newUniterAPIV5(c *gc.C, unit *state.Unit) *uniter.UniterAPIV5 {
+	authorizer := s.authorizer
+	authorizer.Tag = unit.Tag()
+	api, err := uniter.NewUniterAPIV5(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *uniterSuite) TestAddSecrets(c *gc.C) {
+	api := s.newUniterAPIV5(c, s.wordpressUnit)
+	data := map[string]string{"password": "sekrit"}
+
+	result, err := api.AddSecrets(params.AddSecretArgs{Args: []params.AddSecretArg{
+		{OwnerTag: "unit-wordpress-0", Label: "mine", Data: data},
+		{OwnerTag: "unit-mysql-0", Data: data},
+		{OwnerTag: "application-mysql", Data: data},
+		{OwnerTag: "application-wordpress", Data: data},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Check(result.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Check(result.Results[3].Error, gc.ErrorMatches, "cannot add secret: prerequisites failed: .*")
+
+	secret, err := s.State.Secret(result.Results[0].Result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Label(), gc.Equals, "mine")
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, data)
+}
+
+func (s *uniterSuite) TestAddApplicationSecretAsLeader(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	api := s.newUniterAPIV5(c, s.wordpressUnit)
+
+	result, err := api.AddSecrets(params.AddSecretArgs{Args: []params.AddSecretArg{
+		{OwnerTag: "application-wordpress", Data: map[string]string{"key": "value"}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	secret, err := s.State.Secret(result.Results[0].Result)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, err := secret.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(owner, gc.Equals, s.wordpress.Tag())
+}
+
+func (s *uniterSuite) TestGrantAndGetSecret(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	wordpressAPI := s.newUniterAPIV5(c, s.wordpressUnit)
+	mysqlAPI := s.newUniterAPIV5(c, s.mysqlUnit)
+
+	added, err := wordpressAPI.AddSecrets(params.AddSecretArgs{Args: []params.AddSecretArg{
+		{OwnerTag: "unit-wordpress-0", Data: map[string]string{"password": "sekrit"}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added.Results[0].Error, gc.IsNil)
+	id := added.Results[0].Result
+	get := params.GetSecretValueArgs{Args: []params.GetSecretValueArg{{ID: id}}}
+
+	// The secret isn't readable by mysql until it has been granted.
+	values, err := mysqlAPI.GetSecretValues(get)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(values.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	grant := params.GrantSecretArgs{Args: []params.GrantSecretArg{
+		{ID: id, RelationTag: rel.Tag().String()},
+	}}
+	// Only the owner may grant access to its secrets.
+	result, err := mysqlAPI.GrantSecrets(grant)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	result, err = wordpressAPI.GrantSecrets(grant)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results[0].Error, gc.IsNil)
+
+	values, err = mysqlAPI.GetSecretValues(get)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(values.Results[0], jc.DeepEquals, params.SecretValueResult{
+		Data: map[string]string{"password": "sekrit"},
+	})
+	revisions, err := mysqlAPI.SecretRevisions(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"}, {Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, jc.DeepEquals, params.SecretRevisionsResults{
+		Results: []params.SecretRevisionsResult{
+			{Revisions: map[string]int{id: 1}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	result, err = wordpressAPI.RevokeSecrets(grant)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	values, err = mysqlAPI.GetSecretValues(get)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(values.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *uniterSuite) TestWatchSecrets(c *gc.C) {
+	api := s.newUniterAPIV5(c, s.wordpressUnit)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := api.WatchSecrets(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+}
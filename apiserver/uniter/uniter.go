@@ -28,6 +28,7 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV5)
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -139,6 +140,149 @@ func NewUniterAPIV4(st *state.State, resources *common.Resources, authorizer com
 	}, nil
 }
 
+// UniterAPIV5 implements the API version 5, used by the uniter worker.
+// It adds recording of hook executions in each unit's hook history,
+// charm secrets, charm state stored for each unit, goal states, the
+// workload version reported by each unit, and the model's cloud spec
+// for trusted applications.
+type UniterAPIV5 struct {
+	UniterAPIV3
+}
+
+// NewUniterAPIV5 creates a new instance of the Uniter API, version 5.
+func NewUniterAPIV5(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*UniterAPIV5, error) {
+	baseAPI, err := NewUniterAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV5{*baseAPI}, nil
+}
+
+// AddHookExecutions records hook, action and juju-run executions in
+// the hook history of each given unit.
+func (u *UniterAPIV5) AddHookExecutions(args params.HookExecutionArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			e := arg.Execution
+			err = unit.AddHookExecution(state.HookExecution{
+				Kind:       e.Kind,
+				Name:       e.Name,
+				RelationId: e.RelationId,
+				RemoteUnit: e.RemoteUnit,
+				Started:    e.Started,
+				Finished:   e.Finished,
+				ExitCode:   e.ExitCode,
+				Output:     e.Output,
+			})
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// UnitStates returns the charm state stored for each given unit.
+func (u *UniterAPIV5) UnitStates(args params.Entities) (params.UnitStateResults, error) {
+	result := params.UnitStateResults{
+		Results: make([]params.UnitStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UnitStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			result.Results[i].State, err = unit.State()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// UpdateUnitStates updates the charm state stored for each given unit.
+// Empty values clear the corresponding keys.
+func (u *UniterAPIV5) UpdateUnitStates(args params.UpdateUnitStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			err = unit.UpdateState(arg.State)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetWorkloadVersion sets the workload version reported by the charm of
+// each given unit.
+func (u *UniterAPIV5) SetWorkloadVersion(args params.EntityWorkloadVersions) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			err = unit.SetWorkloadVersion(entity.WorkloadVersion)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // AllMachinePorts returns all opened port ranges for each given
 // machine (on all networks).
 func (u *UniterAPIV3) AllMachinePorts(args params.Entities) (params.MachinePortsResults, error) {
@@ -1577,6 +1721,15 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 		return nil, errors.Trace(err)
 	}
 
+	addresses, err := machine.AllAddresses()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get devices addresses")
+	}
+	logger.Debugf(
+		"getting network config for machine %q with addresses %+v, hosting unit %q of application %q, with bindings %+v",
+		machineID, addresses, unit.Name(), service.Name(), bindings,
+	)
+
 	var results []params.NetworkConfig
 	if boundSpace == "" {
 		logger.Debugf(
@@ -1589,27 +1742,23 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 			return nil, errors.Annotatef(err, "getting machine %q preferred private address", machineID)
 		}
 
-		results = append(results, params.NetworkConfig{
+		// Report the device details of the preferred private address
+		// when it is known, and just the address otherwise.
+		config := params.NetworkConfig{
 			Address: privateAddress.Value,
-		})
-		return results, nil
-	} else {
-		logger.Debugf("endpoint %q is explicitly bound to space %q", bindingName, boundSpace)
-	}
-
-	// TODO(dimitern): Use NetworkInterfaces() instead later, this is just for
-	// the PoC to enable minimal network-get implementation returning just the
-	// primary address.
-	//
-	// LKK Card: https://canonical.leankit.com/Boards/View/101652562/119258804
-	addresses, err := machine.AllAddresses()
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot get devices addresses")
+		}
+		for _, addr := range addresses {
+			if addr.Value() != privateAddress.Value {
+				continue
+			}
+			if config, err = networkConfigFromAddress(addr); err != nil {
+				return nil, errors.Trace(err)
+			}
+			break
+		}
+		return append(results, config), nil
 	}
-	logger.Infof(
-		"geting network config for machine %q with addresses %+v, hosting unit %q of application %q, with bindings %+v",
-		machineID, addresses, unit.Name(), service.Name(), bindings,
-	)
+	logger.Debugf("endpoint %q is explicitly bound to space %q", bindingName, boundSpace)
 
 	for _, addr := range addresses {
 		subnet, err := addr.Subnet()
@@ -1626,11 +1775,48 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 		}
 		logger.Debugf("endpoint %q bound to space %q has address %q", bindingName, boundSpace, addr)
 
-		// TODO(dimitern): Fill in the rest later (see linked LKK card above).
-		results = append(results, params.NetworkConfig{
-			Address: addr.Value(),
-		})
+		config, err := networkConfigFromAddress(addr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results = append(results, config)
 	}
 
 	return results, nil
 }
+
+// networkConfigFromAddress returns the network config for the given machine
+// address, including the details of the link-layer device it is assigned to
+// and of its subnet, when known.
+func networkConfigFromAddress(addr *state.Address) (params.NetworkConfig, error) {
+	device, err := addr.Device()
+	if err != nil {
+		return params.NetworkConfig{}, errors.Annotatef(err, "cannot get device for address %q", addr)
+	}
+	config := params.NetworkConfig{
+		InterfaceName:       device.Name(),
+		ParentInterfaceName: device.ParentName(),
+		InterfaceType:       string(device.Type()),
+		MACAddress:          device.MACAddress(),
+		MTU:                 int(device.MTU()),
+		ProviderId:          string(device.ProviderID()),
+		Disabled:            !device.IsUp(),
+		NoAutoStart:         !device.IsAutoStart(),
+		ConfigType:          string(addr.ConfigMethod()),
+		Address:             addr.Value(),
+		CIDR:                addr.SubnetCIDR(),
+		ProviderAddressId:   string(addr.ProviderID()),
+		DNSServers:          addr.DNSServers(),
+		DNSSearchDomains:    addr.DNSSearchDomains(),
+		GatewayAddress:      addr.GatewayAddress(),
+	}
+	subnet, err := addr.Subnet()
+	if errors.IsNotFound(err) {
+		return config, nil
+	} else if err != nil {
+		return params.NetworkConfig{}, errors.Annotatef(err, "cannot get subnet for address %q", addr)
+	}
+	config.ProviderSubnetId = string(subnet.ProviderId())
+	config.VLANTag = subnet.VLANTag()
+	return config, nil
+}
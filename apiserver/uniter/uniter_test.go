@@ -780,6 +780,134 @@ func (s *uniterSuite) TestSetCharmURL(c *gc.C) {
 	c.Assert(needsUpgrade, jc.IsTrue)
 }
 
+func (s *uniterSuite) TestAddHookExecutions(c *gc.C) {
+	uniterAPIV5, err := uniter.NewUniterAPIV5(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	started := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	execution := params.HookExecution{
+		Kind:       "hook",
+		Name:       "db-relation-joined",
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		Output:     "oops\n",
+	}
+	args := params.HookExecutionArgs{Args: []params.HookExecutionArg{
+		{Tag: "unit-mysql-0", Execution: execution},
+		{Tag: "unit-wordpress-0", Execution: execution},
+		{Tag: "application-wordpress", Execution: execution},
+	}}
+	result, err := uniterAPIV5.AddHookExecutions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	history, err := s.wordpressUnit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookExecution{{
+		Kind:       "hook",
+		Name:       "db-relation-joined",
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		Output:     "oops\n",
+	}})
+	history, err = s.mysqlUnit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *uniterSuite) TestUnitStates(c *gc.C) {
+	uniterAPIV5, err := uniter.NewUniterAPIV5(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpressUnit.UpdateState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := uniterAPIV5.UnitStates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitStateResults{
+		Results: []params.UnitStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{State: map[string]string{"initialised": "true"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestUpdateUnitStates(c *gc.C) {
+	uniterAPIV5, err := uniter.NewUniterAPIV5(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpressUnit.UpdateState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	updates := map[string]string{"initialised": "", "cursor": "42"}
+	args := params.UpdateUnitStateArgs{Args: []params.UpdateUnitStateArg{
+		{Tag: "unit-mysql-0", State: updates},
+		{Tag: "unit-wordpress-0", State: updates},
+		{Tag: "application-wordpress", State: updates},
+	}}
+	result, err := uniterAPIV5.UpdateUnitStates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	unitState, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"cursor": "42"})
+	unitState, err = s.mysqlUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+}
+
+func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
+	uniterAPIV5, err := uniter.NewUniterAPIV5(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.EntityWorkloadVersions{Entities: []params.EntityWorkloadVersion{
+		{Tag: "unit-mysql-0", WorkloadVersion: "4.5"},
+		{Tag: "unit-wordpress-0", WorkloadVersion: "4.5"},
+		{Tag: "application-wordpress", WorkloadVersion: "4.5"},
+	}}
+	result, err := uniterAPIV5.SetWorkloadVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	version, err := s.wordpressUnit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "4.5")
+	version, err = s.mysqlUnit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "")
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -2511,6 +2639,25 @@ func (s *uniterNetworkConfigSuite) makeMachineDevicesAndAddressesArgs(addrSuffix
 		}}
 }
 
+// expectedNetworkConfig returns the network config expected for an address
+// set up by makeMachineDevicesAndAddressesArgs.
+func expectedNetworkConfig(deviceName, parentName, address, cidr string) params.NetworkConfig {
+	deviceType := state.EthernetDevice
+	if parentName != "" {
+		deviceType = state.VLAN_8021QDevice
+	}
+	return params.NetworkConfig{
+		InterfaceName:       deviceName,
+		ParentInterfaceName: parentName,
+		InterfaceType:       string(deviceType),
+		Disabled:            true,
+		NoAutoStart:         true,
+		ConfigType:          string(state.StaticAddress),
+		Address:             address,
+		CIDR:                cidr,
+	}
+}
+
 func (s *uniterNetworkConfigSuite) TearDownTest(c *gc.C) {
 	s.base.JujuConnSuite.TearDownTest(c)
 }
@@ -2578,14 +2725,14 @@ func (s *uniterNetworkConfigSuite) TestNetworkConfigForExplicitlyBoundEndpoint(c
 	// addresses bound to the "internal" space, where the "db" endpoint itself
 	// is bound to.
 	expectedConfigWithRelationName := []params.NetworkConfig{
-		{Address: "10.0.0.10"},
-		{Address: "10.0.0.11"},
+		expectedNetworkConfig("eth0.100", "eth0", "10.0.0.10", "10.0.0.0/24"),
+		expectedNetworkConfig("eth1.100", "eth1", "10.0.0.11", "10.0.0.0/24"),
 	}
 	// For the "admin-api" extra-binding we expect to see only addresses from
 	// the "public" space.
 	expectedConfigWithExtraBindingName := []params.NetworkConfig{
-		{Address: "8.8.8.10"},
-		{Address: "8.8.4.10"},
+		expectedNetworkConfig("eth0", "", "8.8.8.10", "8.8.0.0/16"),
+		expectedNetworkConfig("eth1", "", "8.8.4.10", "8.8.0.0/16"),
 	}
 
 	result, err := s.base.uniter.NetworkConfig(args)
@@ -2617,7 +2764,15 @@ func (s *uniterNetworkConfigSuite) TestNetworkConfigForImplicitlyBoundEndpoint(c
 	privateAddress, err := s.base.machine1.PrivateAddress()
 	c.Assert(err, jc.ErrorIsNil)
 
-	expectedConfig := []params.NetworkConfig{{Address: privateAddress.Value}}
+	var expectedConfig []params.NetworkConfig
+	switch privateAddress.Value {
+	case "10.0.0.20":
+		expectedConfig = append(expectedConfig, expectedNetworkConfig("eth0.100", "eth0", "10.0.0.20", "10.0.0.0/24"))
+	case "10.0.0.21":
+		expectedConfig = append(expectedConfig, expectedNetworkConfig("eth1.100", "eth1", "10.0.0.21", "10.0.0.0/24"))
+	default:
+		c.Fatalf("unexpected private address %q", privateAddress.Value)
+	}
 
 	result, err := s.base.uniter.NetworkConfig(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -168,6 +168,39 @@ func (api *UserManagerAPI) DisableUser(users params.Entities) (params.ErrorResul
 	return api.enableUserImpl(users, "disable", (*state.User).Disable)
 }
 
+// RemoveUser removes one or more users, revoking their access to all
+// models. Users that own models cannot be removed.
+func (api *UserManagerAPI) RemoveUser(users params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(users.Entities)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if len(users.Entities) == 0 {
+		return result, nil
+	}
+	if !api.isAdmin {
+		return result, common.ErrPerm
+	}
+
+	for i, arg := range users.Entities {
+		userTag, err := names.ParseUserTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if userTag.Canonical() == api.apiUser.Canonical() {
+			result.Results[i].Error = common.ServerError(errors.New("cannot remove yourself"))
+			continue
+		}
+		if err := api.state.RemoveUser(userTag); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
 func (api *UserManagerAPI) enableUserImpl(args params.Entities, action string, method func(*state.User) error) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -280,12 +313,23 @@ func (api *UserManagerAPI) setPassword(arg params.EntityPassword) error {
 }
 
 // CreateLocalLoginMacaroon creates a macaroon for the specified users to use
-// for future logins.
+// for future logins. External users may only create macaroons for
+// themselves, having logged in with their identity provider.
 func (api *UserManagerAPI) CreateLocalLoginMacaroon(args params.Entities) (params.MacaroonResults, error) {
 	results := params.MacaroonResults{
 		Results: make([]params.MacaroonResult, len(args.Entities)),
 	}
 	createLocalLoginMacaroon := func(arg params.Entity) (*macaroon.Macaroon, error) {
+		userTag, err := names.ParseUserTag(arg.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !userTag.IsLocal() {
+			if userTag.Canonical() != api.apiUser.Canonical() {
+				return nil, errors.Trace(common.ErrPerm)
+			}
+			return api.createLocalLoginMacaroon(userTag)
+		}
 		user, err := api.getUser(arg.Tag)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -378,6 +378,70 @@ func (s *userManagerSuite) TestEnableUserAsNormalUser(c *gc.C) {
 	c.Assert(barb.IsDisabled(), jc.IsTrue)
 }
 
+func (s *userManagerSuite) TestRemoveUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{alex.Tag().String()},
+			{names.NewLocalUserTag("ellie").String()},
+			{s.AdminUserTag(c).String()},
+			{"not-a-tag"},
+		}}
+	result, err := s.usermanager.RemoveUser(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: nil},
+			{Error: &params.Error{
+				Message: `cannot remove user "ellie@local": user "ellie" not found`,
+				Code:    params.CodeNotFound,
+			}},
+			{Error: &params.Error{
+				Message: "cannot remove yourself",
+			}},
+			{Error: &params.Error{
+				Message: `"not-a-tag" is not a valid tag`,
+			}},
+		}})
+	_, err = s.State.User(alex.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ModelUser(alex.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *userManagerSuite) TestBlockRemoveUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+
+	s.BlockAllChanges(c, "TestBlockRemoveUser")
+	_, err := s.usermanager.RemoveUser(params.Entities{
+		[]params.Entity{{alex.Tag().String()}},
+	})
+	// Check that the call is blocked
+	s.AssertBlocked(c, err, "TestBlockRemoveUser")
+
+	_, err = s.State.User(alex.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *userManagerSuite) TestRemoveUserAsNormalUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	barb := s.Factory.MakeUser(c, &factory.UserParams{Name: "barb"})
+
+	args := params.Entities{
+		[]params.Entity{{barb.Tag().String()}},
+	}
+	_, err = usermanager.RemoveUser(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.State.User(barb.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *userManagerSuite) TestUserInfo(c *gc.C) {
 	userFoo := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", DisplayName: "Foo Bar"})
 	userBar := s.Factory.MakeUser(c, &factory.UserParams{Name: "barfoo", DisplayName: "Bar Foo", Disabled: true})
@@ -722,6 +722,12 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 		return errors.Trace(err)
 	}
 
+	// Pass on the number of operations that may hold the machine
+	// lock at once, so that the machine's agents pick it up.
+	if slots, ok := cfg.MachineLockSlots(); ok {
+		icfg.AgentEnvironment[agent.MachineLockSlots] = strconv.Itoa(slots)
+	}
+
 	if icfg.Controller != nil {
 		// Add NUMACTL preference. Needed to work for both bootstrap and high availability
 		// Only makes sense for controller
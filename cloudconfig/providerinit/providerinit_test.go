@@ -104,6 +104,20 @@ func (s *CloudInitSuite) TestFinishInstanceConfig(c *gc.C) {
 	c.Assert(icfg, jc.DeepEquals, expectedMcfg)
 }
 
+func (s *CloudInitSuite) TestFinishInstanceConfigMachineLockSlots(c *gc.C) {
+	cfg, err := config.New(config.NoDefaults, dummySampleConfig().Merge(testing.Attrs{
+		"authorized-keys":    "we-are-the-keys",
+		"machine-lock-slots": 4,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	icfg := &instancecfg.InstanceConfig{
+		APIInfo: &api.Info{Tag: names.NewLocalUserTag("not-touched")},
+	}
+	err = instancecfg.FinishInstanceConfig(icfg, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(icfg.AgentEnvironment[agent.MachineLockSlots], gc.Equals, "4")
+}
+
 func (s *CloudInitSuite) TestFinishInstanceConfigNonDefault(c *gc.C) {
 	userTag := names.NewLocalUserTag("not-touched")
 	attrs := dummySampleConfig().Merge(testing.Attrs{
@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageBindSummary = `
+Changes the spaces the endpoints of a deployed application are bound to.`[1:]
+
+var usageBindDetails = `
+Endpoint bindings are normally set with the --bind option of ` + "`juju deploy`" + `.
+This command changes them for an application that is already deployed.
+A lone space name binds every endpoint not otherwise listed to that space;
+endpoints not mentioned keep their current binding.
+
+Every machine hosting a unit of the application must have an address in
+each space an endpoint is being bound to. Once the bindings change, the
+units run their config-changed hook and network-get reports addresses
+from the new spaces. The change is recorded in the application's status
+history.
+
+Examples:
+    juju bind mysql db=internal
+    juju bind wordpress public website=dmz
+
+See also: 
+    deploy
+    spaces`[1:]
+
+const bindErrorPrefix = "bindings must be in the form '[<default-space>] [<endpoint-name>=<space> ...]'. "
+
+// NewBindCommand returns a command which changes application endpoint
+// bindings.
+func NewBindCommand() cmd.Command {
+	return modelcmd.Wrap(&bindCommand{})
+}
+
+// bindCommand changes the endpoint bindings of a deployed application.
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Bindings        map[string]string
+	api             serviceBindAPI
+}
+
+// serviceBindAPI defines the methods on the client API that the bind
+// command calls.
+type serviceBindAPI interface {
+	Close() error
+	SetEndpointBindings(application string, bindings map[string]string) error
+}
+
+func (c *bindCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "bind",
+		Args:    "<application name> [<default-space>] [<endpoint-name>=<space> ...]",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	}
+}
+
+func (c *bindCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+		return errors.New("no bindings specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	bindings, err := parseBindExpr(strings.Join(args[1:], " "), bindErrorPrefix)
+	if err != nil {
+		return err
+	}
+	c.Bindings = bindings
+	return nil
+}
+
+func (c *bindCommand) getAPI() (serviceBindAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run changes the endpoint bindings of the application.
+func (c *bindCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetEndpointBindings(c.ApplicationName, c.Bindings)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
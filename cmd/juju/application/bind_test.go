@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type BindSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeServiceBindAPI
+}
+
+var _ = gc.Suite(&BindSuite{})
+
+type fakeServiceBindAPI struct {
+	application string
+	bindings    map[string]string
+	err         error
+}
+
+func (f *fakeServiceBindAPI) Close() error {
+	return nil
+}
+
+func (f *fakeServiceBindAPI) SetEndpointBindings(application string, bindings map[string]string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if application != f.application {
+		return errors.NotFoundf("application %q", application)
+	}
+	f.bindings = bindings
+	return nil
+}
+
+func (s *BindSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeServiceBindAPI{application: "mysql"}
+}
+
+var initBindErrorTests = []struct {
+	args []string
+	err  string
+}{
+	{
+		args: []string{},
+		err:  `no application name specified`,
+	}, {
+		args: []string{"mysql"},
+		err:  `no bindings specified`,
+	}, {
+		args: []string{"mysql/0", "db=internal"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "=internal"},
+		err:  `bindings must be in the form .*Found = without endpoint name.*`,
+	}, {
+		args: []string{"mysql", "db=in=ternal"},
+		err:  `bindings must be in the form .*Found multiple = in binding.*`,
+	}, {
+		args: []string{"mysql", "db=%in"},
+		err:  `bindings must be in the form .*Space name invalid.`,
+	},
+}
+
+func (s *BindSuite) TestInitErrors(c *gc.C) {
+	for i, t := range initBindErrorTests {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(application.NewBindCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *BindSuite) TestBind(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewBindCommandForTest(s.fake), "mysql", "public", "db=internal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.bindings, jc.DeepEquals, map[string]string{
+		"":   "public",
+		"db": "internal",
+	})
+}
+
+func (s *BindSuite) TestBindUnknownApplication(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewBindCommandForTest(s.fake), "wordpress", "db=internal")
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" not found`)
+}
+
+func (s *BindSuite) TestBlockBind(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockBind")
+	testing.RunCommand(c, application.NewBindCommandForTest(s.fake), "mysql", "db=internal")
+
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockBind.*")
+}
@@ -608,12 +608,22 @@ const parseBindErrorPrefix = "--bind must be in the form '[<default-space>] [<en
 // * The above in a space separated list to specify multiple bindings,
 //   e.g. "rel1=space1 ext1=space2 space3"
 func (c *DeployCommand) parseBind() error {
-	bindings := make(map[string]string)
 	if c.BindToSpaces == "" {
 		return nil
 	}
+	bindings, err := parseBindExpr(c.BindToSpaces, parseBindErrorPrefix)
+	if err != nil {
+		return err
+	}
+	c.Bindings = bindings
+	return nil
+}
 
-	for _, s := range strings.Split(c.BindToSpaces, " ") {
+// parseBindExpr parses a space separated list of bindings in the form
+// accepted by parseBind, using errPrefix for any errors reported.
+func parseBindExpr(expr, errPrefix string) (map[string]string, error) {
+	bindings := make(map[string]string)
+	for _, s := range strings.Split(expr, " ") {
 		s = strings.TrimSpace(s)
 		if s == "" {
 			continue
@@ -627,21 +637,20 @@ func (c *DeployCommand) parseBind() error {
 			space = v[0]
 		case 2:
 			if v[0] == "" {
-				return errors.New(parseBindErrorPrefix + "Found = without endpoint name. Use a lone space name to set the default.")
+				return nil, errors.New(errPrefix + "Found = without endpoint name. Use a lone space name to set the default.")
 			}
 			endpoint = v[0]
 			space = v[1]
 		default:
-			return errors.New(parseBindErrorPrefix + "Found multiple = in binding. Did you forget to space-separate the binding list?")
+			return nil, errors.New(errPrefix + "Found multiple = in binding. Did you forget to space-separate the binding list?")
 		}
 
 		if !names.IsValidSpace(space) {
-			return errors.New(parseBindErrorPrefix + "Space name invalid.")
+			return nil, errors.New(errPrefix + "Space name invalid.")
 		}
 		bindings[endpoint] = space
 	}
-	c.Bindings = bindings
-	return nil
+	return bindings, nil
 }
 
 type applicationDeployParams struct {
@@ -32,6 +32,20 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewBindCommandForTest returns a BindCommand with the api provided as specified.
+func NewBindCommandForTest(api serviceBindAPI) cmd.Command {
+	return modelcmd.Wrap(&bindCommand{
+		api: api,
+	})
+}
+
+// NewTrustCommandForTest returns a TrustCommand with the api provided as specified.
+func NewTrustCommandForTest(api serviceTrustAPI) cmd.Command {
+	return modelcmd.Wrap(&trustCommand{
+		api: api,
+	})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
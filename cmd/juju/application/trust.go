@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageTrustSummary = `
+Grants an application access to the model's cloud credential.`[1:]
+
+var usageTrustDetails = `
+Charms that drive the underlying cloud directly, such as load balancer
+or DNS integrators, need the model's cloud credential to do so. Once an
+application is trusted, its units may read the cloud type, endpoint,
+region and credential attributes with the credential-get hook tool.
+Every such read is recorded in the controller's audit log.
+
+Only trust applications whose charms you are prepared to hand the
+model's cloud credential to. Use --remove to revoke the grant.
+
+Examples:
+    juju trust aws-integrator
+    juju trust --remove aws-integrator
+
+See also: 
+    deploy`[1:]
+
+// NewTrustCommand returns a command which grants or revokes an
+// application's access to the model's cloud credential.
+func NewTrustCommand() cmd.Command {
+	return modelcmd.Wrap(&trustCommand{})
+}
+
+// trustCommand grants or revokes an application's access to the
+// model's cloud credential.
+type trustCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Remove          bool
+	api             serviceTrustAPI
+}
+
+// serviceTrustAPI defines the methods on the client API that the trust
+// command calls.
+type serviceTrustAPI interface {
+	Close() error
+	SetTrust(application string, trusted bool) error
+}
+
+func (c *trustCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "trust",
+		Args:    "<application name>",
+		Purpose: usageTrustSummary,
+		Doc:     usageTrustDetails,
+	}
+}
+
+func (c *trustCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Remove, "remove", false, "Revoke the application's access to the cloud credential")
+}
+
+func (c *trustCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *trustCommand) getAPI() (serviceTrustAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run grants or revokes the application's access to the model's
+// cloud credential.
+func (c *trustCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetTrust(c.ApplicationName, !c.Remove)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
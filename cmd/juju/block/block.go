@@ -6,6 +6,7 @@ package block
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/modelcmd"
@@ -15,7 +16,8 @@ import (
 // commands that enable blocks.
 type BaseBlockCommand struct {
 	modelcmd.ModelCommandBase
-	desc string
+	desc  string
+	scope blockScope
 }
 
 // Init initializes the command.
@@ -28,7 +30,7 @@ func (c *BaseBlockCommand) Init(args []string) error {
 	if len(args) == 1 {
 		c.desc = args[0]
 	}
-	return nil
+	return c.scope.validate()
 }
 
 // internalRun blocks commands from running successfully.
@@ -39,6 +41,9 @@ func (c *BaseBlockCommand) internalRun(operation string) error {
 	}
 	defer client.Close()
 
+	if entity := c.scope.entity(); entity != nil {
+		return client.SwitchEntityBlockOn(TypeFromOperation(operation), entity, c.desc)
+	}
 	return client.SwitchBlockOn(TypeFromOperation(operation), c.desc)
 }
 
@@ -51,6 +56,7 @@ func (c *BaseBlockCommand) SetFlags(f *gnuflag.FlagSet) {
 type BlockClientAPI interface {
 	Close() error
 	SwitchBlockOn(blockType, msg string) error
+	SwitchEntityBlockOn(blockType string, entity names.Tag, msg string) error
 }
 
 var getBlockClientAPI = func(p *BaseBlockCommand) (BlockClientAPI, error) {
@@ -113,6 +119,10 @@ from Juju model.
 To disable the block, run unblock command - see "juju help unblock". 
 To by-pass the block, where available, run desired remove command with --force option.
 
+The block may be narrowed to a single application, in which case it only
+applies to removing the application, its units and its relations, or to a
+single machine.
+
 "juju block remove-object" blocks these commands:
     destroy-model
     remove-machine
@@ -124,6 +134,9 @@ Examples:
    To prevent the machines, applications, units and relations from being removed:
    juju block remove-object
 
+   To prevent the units of postgresql from being removed:
+   juju block remove-object --application postgresql "protect the database"
+
 `
 
 // Info provides information about command.
@@ -136,6 +149,12 @@ func (c *removeCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.BaseBlockCommand.SetFlags(f)
+	c.scope.setFlags(f)
+}
+
 // Satisfying Command interface.
 func (c *removeCommand) Run(_ *cmd.Context) error {
 	return c.internalRun(c.Info().Name)
@@ -158,6 +177,10 @@ Juju model.
 To disable the block, run unblock command - see "juju help unblock". 
 To by-pass the block, where available, run desired remove command with --force option.
 
+The block may be narrowed to a single application or machine, in which
+case it only applies to commands that change or remove that application
+(including its units and relations) or machine.
+
 "juju block all-changes" blocks these commands:
     add-machine
     add-relation
@@ -194,6 +217,9 @@ Examples:
    To prevent changes to the model:
    juju block all-changes
 
+   To prevent changes to machine 0:
+   juju block all-changes --machine 0
+
 `
 
 // Info provides information about command.
@@ -206,6 +232,12 @@ func (c *changeCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *changeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.BaseBlockCommand.SetFlags(f)
+	c.scope.setFlags(f)
+}
+
 // Satisfying Command interface.
 func (c *changeCommand) Run(_ *cmd.Context) error {
 	return c.internalRun(c.Info().Name)
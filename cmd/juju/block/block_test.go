@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/cmd/juju/block"
@@ -73,6 +74,37 @@ func (s *BlockCommandSuite) TestBlockChangeOperations(c *gc.C) {
 	s.assertBlock(c, command.Info().Name, "TestBlockChangeOperations")
 }
 
+func (s *BlockCommandSuite) TestBlockApplication(c *gc.C) {
+	command := block.NewRemoveCommand()
+	_, err := testing.RunCommand(c, command, "--application", "postgresql", "protect the database")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlock(c, command.Info().Name, "protect the database")
+	c.Assert(s.mockClient.Entity, gc.Equals, names.NewApplicationTag("postgresql"))
+}
+
+func (s *BlockCommandSuite) TestBlockMachine(c *gc.C) {
+	command := block.NewChangeCommand()
+	_, err := testing.RunCommand(c, command, "--machine", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlock(c, command.Info().Name, "")
+	c.Assert(s.mockClient.Entity, gc.Equals, names.NewMachineTag("0"))
+}
+
+func (s *BlockCommandSuite) TestBlockInvalidScope(c *gc.C) {
+	_, err := testing.RunCommand(c, block.NewRemoveCommand(), "--application", "postgresql", "--machine", "0")
+	c.Assert(err, gc.ErrorMatches, "cannot specify both --application and --machine")
+	_, err = testing.RunCommand(c, block.NewRemoveCommand(), "--application", "Bad_Name")
+	c.Assert(err, gc.ErrorMatches, `application name "Bad_Name" not valid`)
+	_, err = testing.RunCommand(c, block.NewChangeCommand(), "--machine", "zero")
+	c.Assert(err, gc.ErrorMatches, `machine ID "zero" not valid`)
+	c.Assert(s.mockClient.BlockType, gc.Equals, "")
+}
+
+func (s *BlockCommandSuite) TestBlockDestroyNoScope(c *gc.C) {
+	_, err := testing.RunCommand(c, block.NewDestroyCommand(), "--application", "postgresql")
+	c.Assert(err, gc.ErrorMatches, "flag provided but not defined: --application")
+}
+
 func (s *BlockCommandSuite) processErrorTest(c *gc.C, tstError error, blockType block.Block, expectedError error, expectedWarning string) {
 	if tstError != nil {
 		c.Assert(errors.Cause(block.ProcessBlockedError(tstError, blockType)), gc.Equals, expectedError)
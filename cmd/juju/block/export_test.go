@@ -4,7 +4,10 @@
 package block
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -24,6 +27,9 @@ var (
 type MockBlockClient struct {
 	BlockType string
 	Msg       string
+	Entity    names.Tag
+	Owner     string
+	Created   *time.Time
 }
 
 func (c *MockBlockClient) Close() error {
@@ -33,12 +39,28 @@ func (c *MockBlockClient) Close() error {
 func (c *MockBlockClient) SwitchBlockOn(blockType, msg string) error {
 	c.BlockType = blockType
 	c.Msg = msg
+	c.Entity = nil
+	return nil
+}
+
+func (c *MockBlockClient) SwitchEntityBlockOn(blockType string, entity names.Tag, msg string) error {
+	c.BlockType = blockType
+	c.Msg = msg
+	c.Entity = entity
 	return nil
 }
 
 func (c *MockBlockClient) SwitchBlockOff(blockType string) error {
 	c.BlockType = blockType
 	c.Msg = ""
+	c.Entity = nil
+	return nil
+}
+
+func (c *MockBlockClient) SwitchEntityBlockOff(blockType string, entity names.Tag) error {
+	c.BlockType = blockType
+	c.Msg = ""
+	c.Entity = entity
 	return nil
 }
 
@@ -47,12 +69,16 @@ func (c *MockBlockClient) List() ([]params.Block, error) {
 		return []params.Block{}, nil
 	}
 
-	return []params.Block{
-		params.Block{
-			Type:    c.BlockType,
-			Message: c.Msg,
-		},
-	}, nil
+	block := params.Block{
+		Type:    c.BlockType,
+		Message: c.Msg,
+		Owner:   c.Owner,
+		Created: c.Created,
+	}
+	if c.Entity != nil {
+		block.Tag = c.Entity.String()
+	}
+	return []params.Block{block}, nil
 }
 
 func NewUnblockCommandWithClient(client UnblockClientAPI) cmd.Command {
@@ -6,7 +6,9 @@ package block
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"text/tabwriter"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -23,7 +25,11 @@ func newListCommand() cmd.Command {
 const listCommandDoc = `
 List blocks for Juju model.
 This command shows if each block type is enabled. 
-For enabled blocks, block message is shown if it was specified.
+For enabled blocks, block message is shown if it was specified,
+along with the user who enabled the block and when.
+
+Blocks limited to a single application or machine are listed
+after the blocks for the model.
 `
 
 // listCommand list blocks.
@@ -83,9 +89,12 @@ var getBlockListAPI = func(cmd *modelcmd.ModelCommandBase) (BlockListAPI, error)
 
 // BlockInfo defines the serialization behaviour of the block information.
 type BlockInfo struct {
-	Operation string  `yaml:"block" json:"block"`
-	Enabled   bool    `yaml:"enabled" json:"enabled"`
-	Message   *string `yaml:"message,omitempty" json:"message,omitempty"`
+	Operation string     `yaml:"block" json:"block"`
+	Scope     string     `yaml:"scope,omitempty" json:"scope,omitempty"`
+	Enabled   bool       `yaml:"enabled" json:"enabled"`
+	Message   *string    `yaml:"message,omitempty" json:"message,omitempty"`
+	Owner     string     `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Created   *time.Time `yaml:"created,omitempty" json:"created,omitempty"`
 }
 
 // formatBlockInfo takes a set of Block and creates a
@@ -94,14 +103,23 @@ func formatBlockInfo(all []params.Block) []BlockInfo {
 	output := make([]BlockInfo, len(blockArgs))
 
 	info := make(map[string]BlockInfo, len(all))
+	var entityBlocks []BlockInfo
 	// not all block types may be returned from client
 	for _, one := range all {
 		op := OperationFromType(one.Type)
+		message := one.Message
 		bi := BlockInfo{
 			Operation: op,
+			Scope:     DescribeEntity(one.Tag),
 			// If client returned it, it means that it is enabled
 			Enabled: true,
-			Message: &one.Message,
+			Message: &message,
+			Owner:   one.Owner,
+			Created: one.Created,
+		}
+		if bi.Scope != "" {
+			entityBlocks = append(entityBlocks, bi)
+			continue
 		}
 		info[op] = bi
 	}
@@ -114,7 +132,30 @@ func formatBlockInfo(all []params.Block) []BlockInfo {
 		output[i] = BlockInfo{Operation: aType}
 	}
 
-	return output
+	sort.Sort(blockInfoByScope(entityBlocks))
+	return append(output, entityBlocks...)
+}
+
+// blockInfoByScope sorts blocks by scope and then by the
+// order of their operations in blockArgs.
+type blockInfoByScope []BlockInfo
+
+func (b blockInfoByScope) Len() int      { return len(b) }
+func (b blockInfoByScope) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b blockInfoByScope) Less(i, j int) bool {
+	if b[i].Scope != b[j].Scope {
+		return b[i].Scope < b[j].Scope
+	}
+	return operationIndex(b[i].Operation) < operationIndex(b[j].Operation)
+}
+
+func operationIndex(operation string) int {
+	for i, op := range blockArgs {
+		if op == operation {
+			return i
+		}
+	}
+	return len(blockArgs)
 }
 
 // formatBlocks returns block list representation.
@@ -133,12 +174,19 @@ func formatBlocks(value interface{}) ([]byte, error) {
 		if ablock.Enabled {
 			switched = "on"
 		}
-		fmt.Fprintf(tw, "%v\t", ablock.Operation)
+		fmt.Fprintf(tw, "%v\t%v", ablock.Operation, ablock.Scope)
 		if ablock.Message != nil {
 			fmt.Fprintf(tw, "\t=%v, %v", switched, *ablock.Message)
-			continue
+		} else {
+			fmt.Fprintf(tw, "\t=%v", switched)
+		}
+		if ablock.Owner != "" {
+			fmt.Fprintf(tw, " (by %v", ablock.Owner)
+			if ablock.Created != nil {
+				fmt.Fprintf(tw, " at %v", ablock.Created.UTC().Format("2006-01-02 15:04:05Z"))
+			}
+			fmt.Fprint(tw, ")")
 		}
-		fmt.Fprintf(tw, "\t=%v", switched)
 	}
 
 	tw.Flush()
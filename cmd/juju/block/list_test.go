@@ -4,8 +4,11 @@
 package block_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -71,3 +74,41 @@ func (s *listCommandSuite) TestListJson(c *gc.C) {
 	c.Assert(testing.Stdout(ctx), gc.Equals, `[{"block":"destroy-model","enabled":false},{"block":"remove-object","enabled":true,"message":"Test this one"},{"block":"all-changes","enabled":false}]
 `)
 }
+
+func (s *listCommandSuite) TestListApplicationBlock(c *gc.C) {
+	created := time.Date(2016, 9, 1, 12, 0, 0, 0, time.UTC)
+	s.mockClient.SwitchEntityBlockOn(string(multiwatcher.BlockRemove), names.NewApplicationTag("postgresql"), "protect the database")
+	s.mockClient.Owner = "admin@local"
+	s.mockClient.Created = &created
+	ctx, err := testing.RunCommand(c, block.NewListCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+destroy-model                        =off
+remove-object                        =off
+all-changes                          =off
+remove-object application postgresql =on, protect the database (by admin@local at 2016-09-01 12:00:00Z)
+`)
+}
+
+func (s *listCommandSuite) TestListApplicationBlockYaml(c *gc.C) {
+	created := time.Date(2016, 9, 1, 12, 0, 0, 0, time.UTC)
+	s.mockClient.SwitchEntityBlockOn(string(multiwatcher.BlockChange), names.NewMachineTag("0"), "")
+	s.mockClient.Owner = "admin@local"
+	s.mockClient.Created = &created
+	ctx, err := testing.RunCommand(c, block.NewListCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- block: destroy-model
+  enabled: false
+- block: remove-object
+  enabled: false
+- block: all-changes
+  enabled: false
+- block: all-changes
+  scope: machine 0
+  enabled: true
+  message: ""
+  owner: admin@local
+  created: 2016-09-01T12:00:00Z
+`[1:])
+}
@@ -95,11 +95,16 @@ func ProcessBlockedError(err error, block Block) error {
 
 var removeMsg = `
 All operations that remove (or delete or terminate) machines, applications, units or
-relations have been blocked for the current model.
-To unblock removal, run
+relations have been blocked for the current model, or for the application or machine
+concerned. To unblock removal, run
 
     juju unblock remove-object
 
+adding --application or --machine if the block is limited to one of them.
+To see which blocks are enabled, run
+
+    juju block list
+
 `
 var destroyMsg = `
 destroy-model operation has been blocked for the current model.
@@ -109,9 +114,14 @@ To remove the block run
 
 `
 var changeMsg = `
-All operations that change model have been blocked for the current model.
-To unblock changes, run
+All operations that change model have been blocked for the current model, or for
+the application or machine concerned. To unblock changes, run
 
     juju unblock all-changes
 
+adding --application or --machine if the block is limited to one of them.
+To see which blocks are enabled, run
+
+    juju block list
+
 `
@@ -0,0 +1,62 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package block
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+)
+
+// blockScope holds the application or machine that a block
+// applies to. If neither is set, the block applies to the model.
+type blockScope struct {
+	application string
+	machine     string
+}
+
+func (s *blockScope) setFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&s.application, "application", "", "Limit the block to the specified application")
+	f.StringVar(&s.machine, "machine", "", "Limit the block to the specified machine")
+}
+
+func (s *blockScope) validate() error {
+	switch {
+	case s.application != "" && s.machine != "":
+		return errors.New("cannot specify both --application and --machine")
+	case s.application != "" && !names.IsValidApplication(s.application):
+		return errors.NotValidf("application name %q", s.application)
+	case s.machine != "" && !names.IsValidMachine(s.machine):
+		return errors.NotValidf("machine ID %q", s.machine)
+	}
+	return nil
+}
+
+// entity returns the tag of the application or machine in scope,
+// or nil if the scope is the whole model.
+func (s *blockScope) entity() names.Tag {
+	switch {
+	case s.application != "":
+		return names.NewApplicationTag(s.application)
+	case s.machine != "":
+		return names.NewMachineTag(s.machine)
+	}
+	return nil
+}
+
+// DescribeEntity returns a short description of the entity with the
+// given tag string, for display, or "" if it is the model.
+func DescribeEntity(tag string) string {
+	t, err := names.ParseTag(tag)
+	if err != nil {
+		return ""
+	}
+	switch t := t.(type) {
+	case names.ApplicationTag:
+		return "application " + t.Id()
+	case names.MachineTag:
+		return "machine " + t.Id()
+	}
+	return ""
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/modelcmd"
@@ -28,6 +29,7 @@ func NewUnblockCommand() cmd.Command {
 type unblockCommand struct {
 	modelcmd.ModelCommandBase
 	operation string
+	scope     blockScope
 	getClient func() (UnblockClientAPI, error)
 }
 
@@ -86,6 +88,10 @@ all-changes includes all alteration commands
     disable-user
     enable-user
 
+Blocks that were limited to a single application or machine are removed
+by specifying the same application or machine with --application or
+--machine. "juju block list" lists the blocks that are enabled.
+
 Examples:
    To allow the model to be destroyed:
    juju unblock destroy-model
@@ -96,6 +102,9 @@ Examples:
    To allow changes to the model:
    juju unblock all-changes
 
+   To allow the units of postgresql to be removed:
+   juju unblock remove-object --application postgresql
+
 See Also:
    juju help block
 `
@@ -144,12 +153,19 @@ func (c *unblockCommand) Init(args []string) error {
 		return errors.Trace(errors.New("can only specify block type"))
 	}
 
-	return c.assignValidOperation("unblock", args)
+	if err := c.assignValidOperation("unblock", args); err != nil {
+		return err
+	}
+	if c.operation == "destroy-model" && c.scope.entity() != nil {
+		return errors.New("destroy-model blocks cannot be limited to an application or machine")
+	}
+	return c.scope.validate()
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *unblockCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	c.scope.setFlags(f)
 }
 
 // Run unblocks previously blocked commands.
@@ -161,6 +177,9 @@ func (c *unblockCommand) Run(_ *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if entity := c.scope.entity(); entity != nil {
+		return client.SwitchEntityBlockOff(TypeFromOperation(c.operation), entity)
+	}
 	return client.SwitchBlockOff(TypeFromOperation(c.operation))
 }
 
@@ -168,6 +187,7 @@ func (c *unblockCommand) Run(_ *cmd.Context) error {
 type UnblockClientAPI interface {
 	Close() error
 	SwitchBlockOff(blockType string) error
+	SwitchEntityBlockOff(blockType string, entity names.Tag) error
 }
 
 var getUnblockClientAPI = func(p *unblockCommand) (UnblockClientAPI, error) {
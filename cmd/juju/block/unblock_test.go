@@ -8,6 +8,7 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/testing"
@@ -65,3 +66,21 @@ func (s *UnblockCommandSuite) TestUnblockCmdValidDestroyEnvOperationUpperCase(c
 func (s *UnblockCommandSuite) TestUnblockCmdValidDestroyEnvOperation(c *gc.C) {
 	s.assertRunUnblock(c, "destroy-model")
 }
+
+func (s *UnblockCommandSuite) TestUnblockApplication(c *gc.C) {
+	err := s.runUnblockCommand(c, "remove-object", "--application", "postgresql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockClient.BlockType, gc.Equals, block.TypeFromOperation("remove-object"))
+	c.Assert(s.mockClient.Entity, gc.Equals, names.NewApplicationTag("postgresql"))
+}
+
+func (s *UnblockCommandSuite) TestUnblockMachine(c *gc.C) {
+	err := s.runUnblockCommand(c, "all-changes", "--machine", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockClient.BlockType, gc.Equals, block.TypeFromOperation("all-changes"))
+	c.Assert(s.mockClient.Entity, gc.Equals, names.NewMachineTag("1"))
+}
+
+func (s *UnblockCommandSuite) TestUnblockDestroyModelScoped(c *gc.C) {
+	s.assertErrorMatches(c, s.runUnblockCommand(c, "destroy-model", "--machine", "1"), `destroy-model blocks cannot be limited to an application or machine`)
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
 
+	cloudapi "github.com/juju/juju/api/cloud"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
 )
 
 var usageAddCloudSummary = `
@@ -30,14 +32,33 @@ overwrite its configuration.
 Known cloud types: azure, cloudsigma, ec2, gce, joyent, lxd, maas, manual,
 openstack, rackspace
 
+If ` + "`--controller`" + ` is specified, the cloud is added to the named controller
+instead of to the local clouds, so that models can be added to it with
+` + "`juju add-model`" + `. The cloud definition file may then be omitted, in which
+case the cloud is looked up among the clouds known locally. Only
+controller administrators may add clouds to a controller.
+
 Examples:
     juju add-cloud mycloud ~/mycloud.yaml
+    juju add-cloud mycloud ~/mycloud.yaml --controller mycontroller
+    juju add-cloud aws --controller mycontroller
 
 See also: 
+    add-model
     clouds`
 
+// AddCloudAPI defines the controller API methods used by the add-cloud
+// command when adding a cloud to a controller.
+type AddCloudAPI interface {
+	Close() error
+	AddCloud(name string, cloud cloud.Cloud) error
+}
+
 type addCloudCommand struct {
-	cmd.CommandBase
+	modelcmd.ControllerCommandBase
+
+	api             AddCloudAPI
+	cloudByNameFunc func(string) (*cloud.Cloud, error)
 
 	// Replace, if true, existing cloud information is overwritten.
 	Replace bool
@@ -47,17 +68,30 @@ type addCloudCommand struct {
 
 	// CloudFile is the name of the cloud YAML file.
 	CloudFile string
+
+	// Controller is the name of the controller to add the cloud to.
+	// If empty, the cloud is added to the local clouds.
+	Controller string
 }
 
 // NewAddCloudCommand returns a command to add cloud information.
 func NewAddCloudCommand() cmd.Command {
-	return &addCloudCommand{}
+	return wrapAddCloudCommand(&addCloudCommand{
+		cloudByNameFunc: cloud.CloudByName,
+	})
+}
+
+func wrapAddCloudCommand(c *addCloudCommand) cmd.Command {
+	return modelcmd.WrapController(c,
+		modelcmd.ControllerSkipFlags,
+		modelcmd.ControllerSkipDefault,
+	)
 }
 
 func (c *addCloudCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-cloud",
-		Args:    "<cloud name> <cloud definition file>",
+		Args:    "<cloud name> [<cloud definition file>]",
 		Purpose: usageAddCloudSummary,
 		Doc:     usageAddCloudDetails,
 	}
@@ -65,28 +99,52 @@ func (c *addCloudCommand) Info() *cmd.Info {
 
 func (c *addCloudCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Replace, "replace", false, "Overwrite any existing cloud information")
+	f.StringVar(&c.Controller, "controller", "", "Add the cloud to the named controller")
 }
 
 func (c *addCloudCommand) Init(args []string) (err error) {
-	if len(args) < 2 {
+	if c.Controller == "" && len(args) < 2 {
 		return errors.New("Usage: juju add-cloud <cloud name> <cloud definition file>")
 	}
+	if len(args) < 1 {
+		return errors.New("Usage: juju add-cloud <cloud name> [<cloud definition file>] --controller <controller name>")
+	}
 	c.Cloud = args[0]
-	c.CloudFile = args[1]
-	return cmd.CheckEmpty(args[2:])
+	if len(args) > 1 {
+		c.CloudFile = args[1]
+		args = args[2:]
+	} else {
+		args = args[1:]
+	}
+	if c.Controller != "" {
+		if c.Replace {
+			return errors.New("--replace cannot be used with --controller")
+		}
+		if err := c.SetControllerName(c.Controller); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return cmd.CheckEmpty(args)
 }
 
-func (c *addCloudCommand) Run(ctxt *cmd.Context) error {
-	specifiedClouds, err := cloud.ParseCloudMetadataFile(c.CloudFile)
+func (c *addCloudCommand) getAPI() (AddCloudAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
 	if err != nil {
-		return err
+		return nil, errors.Trace(err)
 	}
-	if specifiedClouds == nil {
-		return errors.New("no personal clouds are defined")
+	return cloudapi.NewClient(root), nil
+}
+
+func (c *addCloudCommand) Run(ctxt *cmd.Context) error {
+	if c.Controller != "" {
+		return c.addControllerCloud(ctxt)
 	}
-	newCloud, ok := specifiedClouds[c.Cloud]
-	if !ok {
-		return errors.Errorf("cloud %q not found in file %q", c.Cloud, c.CloudFile)
+	newCloud, err := c.readCloudFile()
+	if err != nil {
+		return err
 	}
 	personalClouds, err := cloud.PersonalCloudMetadata()
 	if err != nil {
@@ -98,6 +156,49 @@ func (c *addCloudCommand) Run(ctxt *cmd.Context) error {
 	if personalClouds == nil {
 		personalClouds = make(map[string]cloud.Cloud)
 	}
-	personalClouds[c.Cloud] = newCloud
+	personalClouds[c.Cloud] = *newCloud
 	return cloud.WritePersonalCloudMetadata(personalClouds)
 }
+
+// readCloudFile returns the definition of the cloud from the cloud
+// definition file.
+func (c *addCloudCommand) readCloudFile() (*cloud.Cloud, error) {
+	specifiedClouds, err := cloud.ParseCloudMetadataFile(c.CloudFile)
+	if err != nil {
+		return nil, err
+	}
+	if specifiedClouds == nil {
+		return nil, errors.New("no personal clouds are defined")
+	}
+	newCloud, ok := specifiedClouds[c.Cloud]
+	if !ok {
+		return nil, errors.Errorf("cloud %q not found in file %q", c.Cloud, c.CloudFile)
+	}
+	return &newCloud, nil
+}
+
+// addControllerCloud adds the cloud to the controller, taking its
+// definition from the cloud definition file if one was specified, or
+// from the locally known clouds otherwise.
+func (c *addCloudCommand) addControllerCloud(ctxt *cmd.Context) error {
+	var newCloud *cloud.Cloud
+	var err error
+	if c.CloudFile != "" {
+		newCloud, err = c.readCloudFile()
+	} else {
+		newCloud, err = c.cloudByNameFunc(c.Cloud)
+	}
+	if err != nil {
+		return err
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if err := client.AddCloud(c.Cloud, *newCloud); err != nil {
+		return errors.Trace(err)
+	}
+	ctxt.Infof("Cloud %q added to controller %q.", c.Cloud, c.ControllerName())
+	return nil
+}
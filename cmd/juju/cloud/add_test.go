@@ -7,11 +7,16 @@ import (
 	"io/ioutil"
 	"path/filepath"
 
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/testing"
 )
 
@@ -129,3 +134,85 @@ clouds:
         endpoint: http://london/1.0
 `[1:])
 }
+
+func (s *addSuite) newControllerStore() *jujuclienttesting.MemStore {
+	store := jujuclienttesting.NewMemStore()
+	store.Controllers["ctrl"] = jujuclient.ControllerDetails{}
+	store.Accounts["ctrl"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"admin@local": {User: "admin@local"},
+		},
+		CurrentAccount: "admin@local",
+	}
+	return store
+}
+
+func (s *addSuite) TestAddToControllerBadArgs(c *gc.C) {
+	addCmd := cloud.NewAddCloudCommandForTest(&fakeAddCloudAPI{}, s.newControllerStore(), nil)
+	_, err := testing.RunCommand(c, addCmd, "--controller", "ctrl")
+	c.Assert(err, gc.ErrorMatches, `Usage: juju add-cloud <cloud name> \[<cloud definition file>\] --controller <controller name>`)
+	_, err = testing.RunCommand(c, addCmd, "--controller", "ctrl", "--replace", "cloud")
+	c.Assert(err, gc.ErrorMatches, "--replace cannot be used with --controller")
+	_, err = testing.RunCommand(c, addCmd, "--controller", "missing", "cloud")
+	c.Assert(err, gc.ErrorMatches, "controller missing not found")
+}
+
+func (s *addSuite) TestAddToControllerFromFile(c *gc.C) {
+	sourceFile := s.createTestCloudData(c)
+	api := &fakeAddCloudAPI{}
+	addCmd := cloud.NewAddCloudCommandForTest(api, s.newControllerStore(), nil)
+	ctx, err := testing.RunCommand(c, addCmd, "garage-maas", sourceFile, "--controller", "ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Cloud \"garage-maas\" added to controller \"ctrl\".\n")
+	api.CheckCalls(c, []jujutesting.StubCall{
+		{"AddCloud", []interface{}{"garage-maas", jujucloud.Cloud{
+			Type:      "mass",
+			AuthTypes: []jujucloud.AuthType{"oauth"},
+			Endpoint:  "http://garagemaas",
+		}}},
+		{"Close", nil},
+	})
+
+	// The local clouds are not changed.
+	data, err := ioutil.ReadFile(osenv.JujuXDGDataHomePath("clouds.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Not(jc.Contains), "garage-maas")
+}
+
+func (s *addSuite) TestAddKnownCloudToController(c *gc.C) {
+	api := &fakeAddCloudAPI{}
+	cloudByName := func(name string) (*jujucloud.Cloud, error) {
+		c.Assert(name, gc.Equals, "aws")
+		return &jujucloud.Cloud{Type: "ec2"}, nil
+	}
+	addCmd := cloud.NewAddCloudCommandForTest(api, s.newControllerStore(), cloudByName)
+	_, err := testing.RunCommand(c, addCmd, "aws", "--controller", "ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	api.CheckCallNames(c, "AddCloud", "Close")
+	api.CheckCall(c, 0, "AddCloud", "aws", jujucloud.Cloud{Type: "ec2"})
+}
+
+func (s *addSuite) TestAddToControllerError(c *gc.C) {
+	api := &fakeAddCloudAPI{}
+	api.SetErrors(errors.New("permission denied"))
+	cloudByName := func(string) (*jujucloud.Cloud, error) {
+		return &jujucloud.Cloud{Type: "ec2"}, nil
+	}
+	addCmd := cloud.NewAddCloudCommandForTest(api, s.newControllerStore(), cloudByName)
+	_, err := testing.RunCommand(c, addCmd, "aws", "--controller", "ctrl")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAddCloudAPI struct {
+	jujutesting.Stub
+}
+
+func (api *fakeAddCloudAPI) Close() error {
+	api.AddCall("Close")
+	return nil
+}
+
+func (api *fakeAddCloudAPI) AddCloud(name string, cloud jujucloud.Cloud) error {
+	api.AddCall("AddCloud", name, cloud)
+	return api.NextErr()
+}
@@ -4,7 +4,10 @@
 package cloud
 
 import (
+	"github.com/juju/cmd"
+
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/jujuclient"
 )
@@ -70,3 +73,31 @@ func NewSetDefaultRegionCommandForTest(testStore jujuclient.CredentialStore) *se
 		store: testStore,
 	}
 }
+
+func NewUpdateCredentialCommandForTest(
+	api UpdateCredentialAPI,
+	store jujuclient.ClientStore,
+	credentialStore jujuclient.CredentialGetter,
+	cloudByNameFunc func(string) (*jujucloud.Cloud, error),
+) cmd.Command {
+	c := &updateCredentialCommand{
+		api:             api,
+		credentialStore: credentialStore,
+		cloudByNameFunc: cloudByNameFunc,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+func NewAddCloudCommandForTest(
+	api AddCloudAPI,
+	store jujuclient.ClientStore,
+	cloudByNameFunc func(string) (*jujucloud.Cloud, error),
+) cmd.Command {
+	c := &addCloudCommand{
+		api:             api,
+		cloudByNameFunc: cloudByNameFunc,
+	}
+	c.SetClientStore(store)
+	return wrapAddCloudCommand(c)
+}
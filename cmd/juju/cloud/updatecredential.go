@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	cloudapi "github.com/juju/juju/api/cloud"
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+type updateCredentialCommand struct {
+	modelcmd.ControllerCommandBase
+
+	api             UpdateCredentialAPI
+	credentialStore jujuclient.CredentialGetter
+	cloudByNameFunc func(string) (*jujucloud.Cloud, error)
+
+	cloud      string
+	credential string
+}
+
+var usageUpdateCredentialSummary = `
+Updates a credential stored in a controller.`[1:]
+
+var usageUpdateCredentialDetails = `
+Uploads a locally stored credential to the controller, adding it if it
+does not exist there or replacing it if it does. Models that were added
+with the credential start using the new credential attributes without
+needing to be restarted, which allows credentials to be rotated.
+
+The credential is stored for the current user, and must first be added
+locally with ` + "`juju add-credential`" + ` or ` + "`juju autoload-credentials`" + `.
+
+Examples:
+    juju update-credential aws mysecrets
+
+See also: 
+    add-credential
+    add-model
+    credentials`
+
+// UpdateCredentialAPI defines the controller API methods used by the
+// update-credential command.
+type UpdateCredentialAPI interface {
+	Close() error
+	UpdateCredential(owner names.UserTag, cloudName, credentialName string, credential jujucloud.Credential) error
+}
+
+// NewUpdateCredentialCommand returns a command to upload a credential
+// to a controller.
+func NewUpdateCredentialCommand() cmd.Command {
+	return modelcmd.WrapController(&updateCredentialCommand{
+		credentialStore: jujuclient.NewFileCredentialStore(),
+		cloudByNameFunc: jujucloud.CloudByName,
+	})
+}
+
+func (c *updateCredentialCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-credential",
+		Args:    "<cloud name> <credential name>",
+		Purpose: usageUpdateCredentialSummary,
+		Doc:     usageUpdateCredentialDetails,
+	}
+}
+
+func (c *updateCredentialCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("Usage: juju update-credential <cloud-name> <credential-name>")
+	}
+	c.cloud = args[0]
+	c.credential = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *updateCredentialCommand) getAPI() (UpdateCredentialAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cloudapi.NewClient(root), nil
+}
+
+func (c *updateCredentialCommand) Run(ctxt *cmd.Context) error {
+	cloud, err := c.cloudByNameFunc(c.cloud)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	credential, _, _, err := modelcmd.GetCredentials(
+		c.credentialStore, "", c.credential, c.cloud, cloud.Type,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	store := c.ClientStore()
+	controllerName := c.ControllerName()
+	accountName, err := store.CurrentAccount(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	account, err := store.AccountByName(controllerName, accountName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	owner := names.NewUserTag(account.User)
+	if err := client.UpdateCredential(owner, c.cloud, c.credential, *credential); err != nil {
+		return errors.Trace(err)
+	}
+	ctxt.Infof("Credential %q for cloud %q updated on controller %q.", c.credential, c.cloud, controllerName)
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/juju/cloud"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	_ "github.com/juju/juju/provider/ec2"
+	"github.com/juju/juju/testing"
+)
+
+type updateCredentialSuite struct {
+	testing.BaseSuite
+	api   *fakeUpdateCredentialAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&updateCredentialSuite{})
+
+func (s *updateCredentialSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.api = &fakeUpdateCredentialAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{Cloud: "aws"}
+	s.store.Accounts["ctrl"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"bob@local": {User: "bob@local"},
+		},
+		CurrentAccount: "bob@local",
+	}
+	s.store.Credentials["aws"] = jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"secrets": jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{
+				"access-key": "key",
+				"secret-key": "sekret",
+			}),
+		},
+	}
+}
+
+func (s *updateCredentialSuite) run(c *gc.C, args ...string) (string, error) {
+	cloudByName := func(string) (*jujucloud.Cloud, error) {
+		return &jujucloud.Cloud{Type: "ec2"}, nil
+	}
+	command := cloud.NewUpdateCredentialCommandForTest(s.api, s.store, s.store, cloudByName)
+	ctx, err := testing.RunCommand(c, command, args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(testing.Stderr(ctx), "\n", "", -1), nil
+}
+
+func (s *updateCredentialSuite) TestBadArgs(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "Usage: juju update-credential <cloud-name> <credential-name>")
+	_, err = s.run(c, "cloud", "credential", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *updateCredentialSuite) TestUpdate(c *gc.C) {
+	output, err := s.run(c, "aws", "secrets")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, `Credential "secrets" for cloud "aws" updated on controller "ctrl".`)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"UpdateCredential", []interface{}{
+			names.NewUserTag("bob@local"), "aws", "secrets",
+			jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{
+				"access-key": "key",
+				"secret-key": "sekret",
+			}),
+		}},
+		{"Close", nil},
+	})
+}
+
+func (s *updateCredentialSuite) TestMissingCredential(c *gc.C) {
+	_, err := s.run(c, "aws", "missing")
+	c.Assert(err, gc.ErrorMatches, `"missing" credential for cloud "aws" not found`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *updateCredentialSuite) TestUpdateError(c *gc.C) {
+	s.api.SetErrors(errors.New("nope"))
+	_, err := s.run(c, "aws", "secrets")
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
+
+type fakeUpdateCredentialAPI struct {
+	jujutesting.Stub
+}
+
+func (api *fakeUpdateCredentialAPI) Close() error {
+	api.AddCall("Close")
+	return nil
+}
+
+func (api *fakeUpdateCredentialAPI) UpdateCredential(owner names.UserTag, cloudName, credentialName string, credential jujucloud.Credential) error {
+	api.AddCall("UpdateCredential", owner, cloudName, credentialName, credential)
+	return api.NextErr()
+}
@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+func newCloneModelCommand() cmd.Command {
+	return modelcmd.WrapController(&cloneModelCommand{}, modelcmd.ControllerSkipFlags)
+}
+
+// cloneModelCommand copies a model into a new model.
+type cloneModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api cloneModelAPI
+
+	model            string
+	newModel         string
+	targetController string
+}
+
+type cloneModelAPI interface {
+	CloneModel(spec controller.CloneModelSpec) (string, error)
+}
+
+const cloneModelDoc = `
+clone-model copies a model on the current controller into a new model,
+either on the same controller or, with --controller, on another one.
+This is useful for spinning up test environments that mirror an
+existing model.
+
+The model's configuration, applications, charms, resources, relations
+and units are copied, but its machines are not: the new model
+provisions fresh machines for the cloned units rather than adopting
+the instances of the source model. Machines that only run the
+controller are left out of the new model. Users of the source model
+are not copied; the new model is owned by the account used to connect
+to the target controller.
+
+In order to clone a model to another controller, the target controller
+must be in the juju client's local configuration cache. See the juju
+"login" command for details of how to do this.
+
+Examples:
+    juju clone-model mymodel mymodel-test
+    juju clone-model mymodel mymodel-test --controller staging
+
+See Also:
+   juju help migrate
+   juju help models
+`
+
+// Info implements cmd.Command.
+func (c *cloneModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "clone-model",
+		Args:    "<model-name> <new-model-name>",
+		Purpose: "copy a model into a new model with freshly provisioned machines",
+		Doc:     cloneModelDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *cloneModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.targetController, "c", "", "Controller to create the new model on")
+	f.StringVar(&c.targetController, "controller", "", "")
+}
+
+// Init implements cmd.Command.
+func (c *cloneModelCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("model not specified")
+	}
+	if len(args) < 2 {
+		return errors.New("new model name not specified")
+	}
+	if len(args) > 2 {
+		return errors.New("too many arguments specified")
+	}
+	c.model = args[0]
+	c.newModel = args[1]
+	if !names.IsValidModelName(c.newModel) {
+		return errors.Errorf("%q is not a valid name: model names may only contain lowercase letters, digits and hyphens", c.newModel)
+	}
+
+	// The source model is always on the current controller; the
+	// --controller flag only selects where the new model is created.
+	currentController, err := c.ClientStore().CurrentController()
+	if errors.IsNotFound(err) {
+		return modelcmd.ErrNoControllersDefined
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.SetControllerName(currentController); err != nil {
+		return errors.Trace(err)
+	}
+	if c.targetController == "" {
+		c.targetController = currentController
+	}
+	return nil
+}
+
+func (c *cloneModelCommand) getCloneSpec() (*controller.CloneModelSpec, string, error) {
+	store := c.ClientStore()
+
+	modelInfo, err := store.ModelByName(c.ControllerName(), c.AccountName(), c.model)
+	if err != nil {
+		return nil, "", err
+	}
+
+	controllerInfo, err := store.ControllerByName(c.targetController)
+	if err != nil {
+		return nil, "", err
+	}
+
+	accountName, err := store.CurrentAccount(c.targetController)
+	if err != nil {
+		return nil, "", err
+	}
+
+	accountInfo, err := store.AccountByName(c.targetController, accountName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &controller.CloneModelSpec{
+		ModelUUID:            modelInfo.ModelUUID,
+		Name:                 c.newModel,
+		TargetControllerUUID: controllerInfo.ControllerUUID,
+		TargetAddrs:          controllerInfo.APIEndpoints,
+		TargetCACert:         controllerInfo.CACert,
+		TargetUser:           accountInfo.User,
+		TargetPassword:       accountInfo.Password,
+	}, accountName, nil
+}
+
+// Run implements cmd.Command.
+func (c *cloneModelCommand) Run(ctx *cmd.Context) error {
+	spec, accountName, err := c.getCloneSpec()
+	if err != nil {
+		return err
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	modelUUID, err := api.CloneModel(*spec)
+	if err != nil {
+		return err
+	}
+	store := c.ClientStore()
+	if err := store.UpdateModel(c.targetController, accountName, c.newModel, jujuclient.ModelDetails{
+		ModelUUID: modelUUID,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Cloned model %q to %q on controller %q", c.model, c.newModel, c.targetController)
+	return nil
+}
+
+func (c *cloneModelCommand) getAPI() (cloneModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
@@ -0,0 +1,173 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type CloneModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeCloneModelAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&CloneModelSuite{})
+
+const (
+	sourceControllerUUID = "eeeeeeee-0bad-400d-8000-4b1d0d06f00d"
+	clonedModelUUID      = "feedface-0bad-400d-8000-4b1d0d06f00d"
+)
+
+func (s *CloneModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclienttesting.NewMemStore()
+
+	// Define the source controller, its account and the model to clone.
+	err := s.store.UpdateController("source", jujuclient.ControllerDetails{
+		ControllerUUID: sourceControllerUUID,
+		APIEndpoints:   []string{"10.0.0.1:17070"},
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("source")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("source", "source@local", jujuclient.AccountDetails{
+		User:     "bob@local",
+		Password: "hunter2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentAccount("source", "source@local")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateModel("source", "source@local", "model", jujuclient.ModelDetails{
+		ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Define the target controller and its account.
+	err = s.store.UpdateController("target", jujuclient.ControllerDetails{
+		ControllerUUID: targetControllerUUID,
+		APIEndpoints:   []string{"1.2.3.4:5"},
+		CACert:         "cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("target", "target@local", jujuclient.AccountDetails{
+		User:     "admin@local",
+		Password: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentAccount("target", "target@local")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeCloneModelAPI{}
+}
+
+func (s *CloneModelSuite) TestMissingModel(c *gc.C) {
+	_, err := s.runCommand(c)
+	c.Assert(err, gc.ErrorMatches, "model not specified")
+}
+
+func (s *CloneModelSuite) TestMissingNewModel(c *gc.C) {
+	_, err := s.runCommand(c, "model")
+	c.Assert(err, gc.ErrorMatches, "new model name not specified")
+}
+
+func (s *CloneModelSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.runCommand(c, "one", "too", "many")
+	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+func (s *CloneModelSuite) TestInvalidNewModelName(c *gc.C) {
+	_, err := s.runCommand(c, "model", "Not_Valid")
+	c.Assert(err, gc.ErrorMatches, `"Not_Valid" is not a valid name: .*`)
+}
+
+func (s *CloneModelSuite) TestSameController(c *gc.C) {
+	ctx, err := s.runCommand(c, "model", "model-test")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, `Cloned model "model" to "model-test" on controller "source"`+"\n")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.CloneModelSpec{
+		ModelUUID:            modelUUID,
+		Name:                 "model-test",
+		TargetControllerUUID: sourceControllerUUID,
+		TargetAddrs:          []string{"10.0.0.1:17070"},
+		TargetCACert:         "somecert",
+		TargetUser:           "bob@local",
+		TargetPassword:       "hunter2",
+	})
+	model, err := s.store.ModelByName("source", "source@local", "model-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.ModelUUID, gc.Equals, clonedModelUUID)
+}
+
+func (s *CloneModelSuite) TestOtherController(c *gc.C) {
+	ctx, err := s.runCommand(c, "model", "model-test", "--controller", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, `Cloned model "model" to "model-test" on controller "target"`+"\n")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.CloneModelSpec{
+		ModelUUID:            modelUUID,
+		Name:                 "model-test",
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "admin@local",
+		TargetPassword:       "secret",
+	})
+	model, err := s.store.ModelByName("target", "target@local", "model-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.ModelUUID, gc.Equals, clonedModelUUID)
+}
+
+func (s *CloneModelSuite) TestModelDoesntExist(c *gc.C) {
+	_, err := s.runCommand(c, "wat", "model-test")
+	c.Check(err, gc.ErrorMatches, "model .+ not found")
+	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
+}
+
+func (s *CloneModelSuite) TestControllerDoesntExist(c *gc.C) {
+	_, err := s.runCommand(c, "model", "model-test", "-c", "wat")
+	c.Check(err, gc.ErrorMatches, "controller wat not found")
+	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
+}
+
+func (s *CloneModelSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.runCommand(c, "model", "model-test")
+	c.Check(err, gc.ErrorMatches, "boom")
+	_, err = s.store.ModelByName("source", "source@local", "model-test")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloneModelSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := &cloneModelCommand{
+		api: s.api,
+	}
+	cmd.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.WrapController(cmd, modelcmd.ControllerSkipFlags), args...)
+}
+
+type fakeCloneModelAPI struct {
+	specSeen *controller.CloneModelSpec
+	err      error
+}
+
+func (a *fakeCloneModelAPI) CloneModel(spec controller.CloneModelSpec) (string, error) {
+	a.specSeen = &spec
+	if a.err != nil {
+		return "", a.err
+	}
+	return clonedModelUUID, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newDebugCodeCommand() cmd.Command {
+	return modelcmd.Wrap(&debugCodeCommand{})
+}
+
+// debugCodeCommand is like debug-hooks, but runs the intercepted hooks
+// in the tmux session rather than leaving the user to run them.
+type debugCodeCommand struct {
+	debugHooksCommand
+}
+
+const debugCodeDoc = `
+Run a hook or action on an application unit inside a tmux session,
+so that it can be debugged using the charm's own tooling.
+
+As with debug-hooks, the hooks and actions named after the unit are
+intercepted, or all of them if none are named. Each is run in its own
+tmux window with the environment variable JUJU_DEBUG_AT set to the
+value of --at, so that a charm framework that supports it can drop
+into its debugger at the chosen point. Juju continues once the hook
+or action completes.
+
+See the "juju help ssh" for information about SSH related options
+accepted by the debug-code command.
+
+Examples:
+
+    juju debug-code mysql/0 config-changed
+    juju debug-code --at=hook mysql/0 backup
+
+See also:
+    debug-hooks
+`
+
+func (c *debugCodeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "debug-code",
+		Args:    "<unit name> [hook or action names]",
+		Purpose: "run a hook or action in a tmux session to debug the charm's code",
+		Doc:     debugCodeDoc,
+	}
+}
+
+func (c *debugCodeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.debugHooksCommand.SetFlags(f)
+	f.StringVar(&c.debugAt, "at", "all", "Where the charm should stop in its debugger, passed as $JUJU_DEBUG_AT")
+}
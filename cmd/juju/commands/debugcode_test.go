@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"encoding/base64"
+	"regexp"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&DebugCodeSuite{})
+
+type DebugCodeSuite struct {
+	SSHCommonSuite
+}
+
+var debugCodeTests = []struct {
+	info     string
+	args     []string
+	error    string
+	hookArgs string
+}{{
+	info:     `debug-at defaults to "all"`,
+	args:     []string{"mysql/0", "start"},
+	hookArgs: "hooks:\n- start\ndebug-at: all\n",
+}, {
+	info:     `actions may be debugged, and debug-at set`,
+	args:     []string{"--at=hook", "mysql/0", "fakeaction"},
+	hookArgs: "hooks:\n- fakeaction\ndebug-at: hook\n",
+}, {
+	info:  `invalid hook`,
+	args:  []string{"mysql/0", "invalid-hook"},
+	error: `unit "mysql/0" does not contain hook "invalid-hook"`,
+}}
+
+// sshScriptRE extracts the base64 encoded client script from the
+// command line passed to the fake ssh.
+var sshScriptRE = regexp.MustCompile(`echo (\S+) \| base64 -d > \$F`)
+
+func (s *DebugCodeSuite) TestDebugCodeCommand(c *gc.C) {
+	//TODO(bogdanteleaga): Fix once debughooks are supported on windows
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Skipping on windows for now")
+	}
+
+	s.setupModel(c)
+
+	for i, t := range debugCodeTests {
+		c.Logf("test %d: %s\n\t%s\n", i, t.info, t.args)
+
+		ctx, err := coretesting.RunCommand(c, newDebugCodeCommand(), t.args...)
+		if t.error != "" {
+			c.Check(err, gc.ErrorMatches, t.error)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		match := sshScriptRE.FindStringSubmatch(coretesting.Stdout(ctx))
+		c.Assert(match, gc.HasLen, 2)
+		script, err := base64.StdEncoding.DecodeString(match[1])
+		c.Assert(err, jc.ErrorIsNil)
+		hookArgs := base64.StdEncoding.EncodeToString([]byte(t.hookArgs))
+		c.Check(string(script), jc.Contains, `echo "`+hookArgs+`" | base64 -d`)
+	}
+}
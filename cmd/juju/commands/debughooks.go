@@ -10,10 +10,13 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	unitdebug "github.com/juju/juju/worker/uniter/runner/debug"
 )
@@ -26,10 +29,18 @@ func newDebugHooksCommand() cmd.Command {
 type debugHooksCommand struct {
 	sshCommand
 	hooks []string
+	// debugAt, if set, makes the session run the hooks itself
+	// with $JUJU_DEBUG_AT set to it; see debug-code.
+	debugAt string
 }
 
 const debugHooksDoc = `
-Interactively debug a hook remotely on an application unit.
+Interactively debug a hook or action remotely on an application unit.
+
+Any hooks or actions named after the unit are intercepted; if none
+are named, all of them are. The intercepted hook or action is not run:
+a tmux window is opened with its environment, from which it may be
+run by hand.
 
 See the "juju help ssh" for information about SSH related options
 accepted by the debug-hooks command.
@@ -38,8 +49,8 @@ accepted by the debug-hooks command.
 func (c *debugHooksCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "debug-hooks",
-		Args:    "<unit name> [hook names]",
-		Purpose: "launch a tmux session to debug a hook",
+		Args:    "<unit name> [hook or action names]",
+		Purpose: "launch a tmux session to debug a hook or action",
 		Doc:     debugHooksDoc,
 	}
 }
@@ -68,6 +79,10 @@ type charmRelationsApi interface {
 	CharmRelations(serviceName string) ([]string, error)
 }
 
+type charmActionsAPI interface {
+	ApplicationCharmActions(params.Entity) (*charm.Actions, error)
+}
+
 func (c *debugHooksCommand) getServiceAPI() (charmRelationsApi, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -76,6 +91,14 @@ func (c *debugHooksCommand) getServiceAPI() (charmRelationsApi, error) {
 	return application.NewClient(root), nil
 }
 
+func (c *debugHooksCommand) getActionAPI() (charmActionsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return action.NewClient(root), nil
+}
+
 func (c *debugHooksCommand) validateHooks() error {
 	if len(c.hooks) == 0 {
 		return nil
@@ -92,6 +115,16 @@ func (c *debugHooksCommand) validateHooks() error {
 	if err != nil {
 		return err
 	}
+	actionAPI, err := c.getActionAPI()
+	if err != nil {
+		return err
+	}
+	actions, err := actionAPI.ApplicationCharmActions(params.Entity{
+		Tag: names.NewApplicationTag(service).String(),
+	})
+	if err != nil {
+		return err
+	}
 
 	validHooks := make(map[string]bool)
 	for _, hook := range hooks.UnitHooks() {
@@ -103,6 +136,9 @@ func (c *debugHooksCommand) validateHooks() error {
 			validHooks[hook] = true
 		}
 	}
+	for name := range actions.ActionSpecs {
+		validHooks[name] = true
+	}
 	for _, hook := range c.hooks {
 		if !validHooks[hook] {
 			names := make([]string, 0, len(validHooks))
@@ -131,7 +167,7 @@ func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	debugctx := unitdebug.NewHooksContext(c.Target)
-	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks)))
+	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks, c.debugAt)))
 	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, script)
 	args := []string{fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd)}
 	c.Args = args
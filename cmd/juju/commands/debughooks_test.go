@@ -60,6 +60,10 @@ var debugHooksTests = []struct {
 	info:     `relation hooks have the relation name prefixed`,
 	args:     []string{"mysql/0", "juju-info-relation-joined"},
 	expected: nil,
+}, {
+	info:     `actions may be debugged by name`,
+	args:     []string{"mysql/0", "fakeaction", "start"},
+	expected: nil,
 }, {
 	info:  `invalid unit syntax`,
 	args:  []string{"mysql"},
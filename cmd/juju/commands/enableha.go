@@ -45,6 +45,9 @@ type enableHACommand struct {
 
 	// NumControllers specifies the number of controllers to make available.
 	NumControllers int
+	// NumWitnesses specifies the number of non-voting witness
+	// controllers to keep ready to stand in for unhealthy voters.
+	NumWitnesses int
 	// Series is used for newly created machines, if specified.
 	// Otherwise,  the environment's default-series is used.
 	Series string
@@ -67,6 +70,15 @@ to ensure that the specified number of controllers are made available.
 
 An odd number of controllers is required.
 
+Unless placement directives are given with --to, new controller machines
+are spread across the availability zones of the cloud, if it has more
+than one.
+
+Witnesses are controllers which hold a copy of the database but do not
+vote in elections for the primary. When a voting controller becomes
+unhealthy, a healthy witness takes its vote until it recovers, so that
+a majority of votes remains available.
+
 Examples:
  juju enable-ha
      Ensure that the controller is still in highly available mode. If
@@ -84,6 +96,10 @@ Examples:
      Ensure that 7 controllers are available, with machines server1 and
      server2 used first, and if necessary, newly created controller
      machines having the default series, and at least 8GB RAM.
+ juju enable-ha -n 3 --witnesses 2
+     Ensure that 3 voting controllers are available, along with 2
+     witness controllers ready to stand in for any that become
+     unhealthy.
 `
 
 // formatSimple marshals value to a yaml-formatted []byte, unless value is nil.
@@ -107,6 +123,10 @@ func formatSimple(value interface{}) ([]byte, error) {
 			"adding machines: %s\n",
 			enableHAResult.Added,
 		},
+		{
+			"adding witnesses: %s\n",
+			enableHAResult.AddedWitnesses,
+		},
 		{
 			"removing machines: %s\n",
 			enableHAResult.Removed,
@@ -146,6 +166,7 @@ func (c *enableHACommand) Info() *cmd.Info {
 
 func (c *enableHACommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumControllers, "n", 0, "number of controllers to make available")
+	f.IntVar(&c.NumWitnesses, "witnesses", 0, "number of non-voting witness controllers to make available")
 	f.StringVar(&c.Series, "series", "", "the charm series")
 	f.StringVar(&c.PlacementSpec, "to", "", "the machine(s) to become controllers, bypasses constraints")
 	f.Var(constraints.ConstraintsValue{&c.Constraints}, "constraints", "additional machine constraints")
@@ -161,6 +182,9 @@ func (c *enableHACommand) Init(args []string) error {
 	if c.NumControllers < 0 || (c.NumControllers%2 != 1 && c.NumControllers != 0) {
 		return fmt.Errorf("must specify a number of controllers odd and non-negative")
 	}
+	if c.NumWitnesses < 0 {
+		return fmt.Errorf("must specify a non-negative number of witnesses")
+	}
 	if c.PlacementSpec != "" {
 		placementSpecs := strings.Split(c.PlacementSpec, ",")
 		c.Placement = make([]string, len(placementSpecs))
@@ -190,6 +214,8 @@ type availabilityInfo struct {
 	Promoted   []string `json:"promoted,omitempty" yaml:"promoted,flow,omitempty"`
 	Demoted    []string `json:"demoted,omitempty" yaml:"demoted,flow,omitempty"`
 	Converted  []string `json:"converted,omitempty" yaml:"converted,flow,omitempty"`
+
+	AddedWitnesses []string `json:"added-witnesses,omitempty" yaml:"added-witnesses,flow,omitempty"`
 }
 
 // MakeHAClient defines the methods
@@ -197,13 +223,13 @@ type availabilityInfo struct {
 // command calls.
 type MakeHAClient interface {
 	Close() error
-	EnableHA(
-		numControllers int, cons constraints.Value, series string,
+	EnableHAWithWitnesses(
+		numControllers, numWitnesses int, cons constraints.Value, series string,
 		placement []string) (params.ControllersChanges, error)
 }
 
 // Run connects to the environment specified on the command line
-// and calls EnableHA.
+// and calls EnableHAWithWitnesses.
 func (c *enableHACommand) Run(ctx *cmd.Context) error {
 	haClient, err := c.newHAClientFunc()
 	if err != nil {
@@ -211,8 +237,9 @@ func (c *enableHACommand) Run(ctx *cmd.Context) error {
 	}
 
 	defer haClient.Close()
-	enableHAResult, err := haClient.EnableHA(
+	enableHAResult, err := haClient.EnableHAWithWitnesses(
 		c.NumControllers,
+		c.NumWitnesses,
 		c.Constraints,
 		c.Series,
 		c.Placement,
@@ -228,6 +255,8 @@ func (c *enableHACommand) Run(ctx *cmd.Context) error {
 		Promoted:   machineTagsToIds(enableHAResult.Promoted...),
 		Demoted:    machineTagsToIds(enableHAResult.Demoted...),
 		Converted:  machineTagsToIds(enableHAResult.Converted...),
+
+		AddedWitnesses: machineTagsToIds(enableHAResult.AddedWitnesses...),
 	}
 	return c.out.Write(ctx, result)
 }
@@ -48,6 +48,7 @@ func (s *EnableHASuite) SetUpTest(c *gc.C) {
 
 type fakeHAClient struct {
 	numControllers int
+	numWitnesses   int
 	cons           constraints.Value
 	err            error
 	series         string
@@ -59,10 +60,11 @@ func (f *fakeHAClient) Close() error {
 	return nil
 }
 
-func (f *fakeHAClient) EnableHA(numControllers int, cons constraints.Value,
+func (f *fakeHAClient) EnableHAWithWitnesses(numControllers, numWitnesses int, cons constraints.Value,
 	series string, placement []string) (params.ControllersChanges, error) {
 
 	f.numControllers = numControllers
+	f.numWitnesses = numWitnesses
 	f.cons = cons
 	f.series = series
 	f.placement = placement
@@ -94,6 +96,9 @@ func (f *fakeHAClient) EnableHA(numControllers int, cons constraints.Value,
 	for i := len(f.result.Converted) + 1; i < numControllers; i++ {
 		f.result.Added = append(f.result.Added, fmt.Sprintf("machine-%d", i))
 	}
+	for i := 0; i < numWitnesses; i++ {
+		f.result.AddedWitnesses = append(f.result.AddedWitnesses, fmt.Sprintf("machine-%d", numControllers+i))
+	}
 
 	return f.result, nil
 }
@@ -208,6 +213,40 @@ func (s *EnableHASuite) TestEnableHAWithPlacement(c *gc.C) {
 	c.Assert(s.fake.placement, gc.DeepEquals, expectedPlacement)
 }
 
+func (s *EnableHASuite) TestEnableHAWithWitnesses(c *gc.C) {
+	ctx, err := s.runEnableHA(c, "-n", "3", "--witnesses", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals,
+		"maintaining machines: 0\n"+
+			"adding machines: 1, 2\n"+
+			"adding witnesses: 3, 4\n\n")
+
+	c.Assert(s.fake.numControllers, gc.Equals, 3)
+	c.Assert(s.fake.numWitnesses, gc.Equals, 2)
+}
+
+func (s *EnableHASuite) TestEnableHAWithWitnessesFormatYaml(c *gc.C) {
+	ctx, err := s.runEnableHA(c, "-n", "3", "--witnesses", "1", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var result map[string][]string
+	err = goyaml.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, map[string][]string{
+		"maintained":      {"0"},
+		"added":           {"1", "2"},
+		"added-witnesses": {"3"},
+	})
+}
+
+func (s *EnableHASuite) TestEnableHANegativeWitnesses(c *gc.C) {
+	_, err := s.runEnableHA(c, "--witnesses", "-1")
+	c.Assert(err, gc.ErrorMatches, "must specify a non-negative number of witnesses")
+
+	// Verify that enable-ha didn't call into the API
+	c.Assert(s.fake.numControllers, gc.Equals, invalidNumServers)
+}
+
 func (s *EnableHASuite) TestEnableHAErrors(c *gc.C) {
 	for _, n := range []int{-1, 2} {
 		_, err := s.runEnableHA(c, "-n", fmt.Sprint(n))
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
 	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
@@ -232,6 +233,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewHookHistoryCommand())
+
+	// Charm secrets.
+	r.Register(secrets.NewListCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newRunCommand())
@@ -240,6 +245,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
 	r.Register(newDebugHooksCommand())
+	r.Register(newDebugCodeCommand())
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -277,6 +283,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewListCommand())
 	r.Register(user.NewEnableCommand())
 	r.Register(user.NewDisableCommand())
+	r.Register(user.NewRemoveCommand())
 	r.Register(user.NewLoginCommand())
 	r.Register(user.NewLogoutCommand())
 
@@ -294,12 +301,17 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGetCommand())
 	r.Register(model.NewSetCommand())
 	r.Register(model.NewUnsetCommand())
+	r.Register(model.NewModelDefaultsCommand())
+	r.Register(model.NewSetModelDefaultsCommand())
+	r.Register(model.NewUnsetModelDefaultsCommand())
 	r.Register(model.NewRetryProvisioningCommand())
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewUsersCommand())
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
+	r.Register(model.NewChangeOwnerCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(newCloneModelCommand())
 
 	if featureflag.Enabled(feature.Migration) {
 		r.Register(newMigrateCommand())
@@ -321,6 +333,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
+	r.Register(application.NewBindCommand())
+	r.Register(application.NewTrustCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
 
@@ -355,6 +369,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage controllers
 	r.Register(controller.NewAddModelCommand())
 	r.Register(controller.NewDestroyCommand())
+	r.Register(controller.NewDisableMaintenanceCommand())
+	r.Register(controller.NewEnableMaintenanceCommand())
 	r.Register(controller.NewListModelsCommand())
 	r.Register(controller.NewKillCommand())
 	r.Register(controller.NewListControllersCommand())
@@ -381,6 +397,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(cloud.NewSetDefaultCredentialCommand())
 	r.Register(cloud.NewAddCredentialCommand())
 	r.Register(cloud.NewRemoveCredentialCommand())
+	r.Register(cloud.NewUpdateCredentialCommand())
 
 	// Juju GUI commands.
 	r.Register(gui.NewGUICommand())
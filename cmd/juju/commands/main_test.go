@@ -372,13 +372,16 @@ var commandNames = []string{
 	"allocate",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"block",
 	"blocks",
 	"bootstrap",
 	"budgets",
 	"cached-images",
+	"change-model-owner",
 	"change-user-password",
 	"charm",
+	"clone-model",
 	"clouds",
 	"collect-metrics",
 	"controllers",
@@ -386,6 +389,7 @@ var commandNames = []string{
 	"create-budget",
 	"create-storage-pool",
 	"credentials",
+	"debug-code",
 	"debug-hooks",
 	"debug-log",
 	"debug-metrics",
@@ -395,9 +399,11 @@ var commandNames = []string{
 	"destroy-relation",
 	"destroy-application",
 	"destroy-unit",
+	"disable-maintenance",
 	"disable-user",
 	"download-backup",
 	"enable-ha",
+	"enable-maintenance",
 	"enable-user",
 	"expose",
 	"get-config",
@@ -426,6 +432,7 @@ var commandNames = []string{
 	"list-machines",
 	"list-models",
 	"list-plans",
+	"list-secrets",
 	"list-shares",
 	"list-ssh-key",
 	"list-ssh-keys",
@@ -438,6 +445,7 @@ var commandNames = []string{
 	"logout",
 	"machine",
 	"machines",
+	"model-defaults",
 	"models",
 	"plans",
 	"publish",
@@ -454,6 +462,7 @@ var commandNames = []string{
 	"remove-ssh-key",
 	"remove-ssh-keys",
 	"remove-unit", // alias for destroy-unit
+	"remove-user",
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
@@ -461,6 +470,7 @@ var commandNames = []string{
 	"run",
 	"run-action",
 	"scp",
+	"secrets",
 	"set-budget",
 	"set-config",
 	"set-configs",
@@ -470,6 +480,7 @@ var commandNames = []string{
 	"set-meter-status",
 	"set-model-config",
 	"set-model-constraints",
+	"set-model-defaults",
 	"set-plan",
 	"ssh-key",
 	"ssh-keys",
@@ -481,6 +492,7 @@ var commandNames = []string{
 	"show-cloud",
 	"show-controller",
 	"show-controllers",
+	"show-hook-history",
 	"show-machine",
 	"show-machines",
 	"show-model",
@@ -496,13 +508,16 @@ var commandNames = []string{
 	"subnets",
 	"switch",
 	"sync-tools",
+	"trust",
 	"unblock",
 	"unexpose",
 	"update-allocation",
 	"upload-backup",
 	"unregister",
 	"unset-model-config",
+	"unset-model-defaults",
 	"update-clouds",
+	"update-credential",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -29,13 +31,16 @@ func newRunCommand() cmd.Command {
 // runCommand is responsible for running arbitrary commands on remote machines.
 type runCommand struct {
 	modelcmd.ModelCommandBase
-	out      cmd.Output
-	all      bool
-	timeout  time.Duration
-	machines []string
-	services []string
-	units    []string
-	commands string
+	out           cmd.Output
+	all           bool
+	timeout       time.Duration
+	batchSize     int
+	batchDelay    time.Duration
+	stopOnFailure bool
+	machines      []string
+	services      []string
+	units         []string
+	commands      string
 }
 
 const runDoc = `
@@ -64,6 +69,19 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+By default the commands are run on all the targets at once. --batch-size
+runs them on at most that many targets at a time, starting each batch only
+when the commands have completed on every target in the previous one, and
+--batch-delay waits for the given time between batches. This allows, for
+example, a service to be restarted on every unit of an application without
+an outage. Targets on which the commands could not be run are reported,
+and the later batches still run. With --stop-on-failure, no further
+batches are started once the commands have failed, or could not be run,
+on any target.
+
+Unless the output format is json, the results for each target are written
+as soon as the commands complete on it.
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 `
@@ -81,6 +99,9 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.BoolVar(&c.all, "all", false, "run the commands on all the machines")
 	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "how long to wait before the remote command is considered to have failed")
+	f.IntVar(&c.batchSize, "batch-size", 0, "run the commands on at most this many targets at a time")
+	f.DurationVar(&c.batchDelay, "batch-delay", 0, "how long to wait between batches")
+	f.BoolVar(&c.stopOnFailure, "stop-on-failure", false, "start no further batches once the commands have failed on any target")
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "one or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "one or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "one or more unit ids")
@@ -108,6 +129,21 @@ func (c *runCommand) Init(args []string) error {
 		}
 	}
 
+	if c.batchSize < 0 {
+		return fmt.Errorf("--batch-size must not be negative")
+	}
+	if c.batchDelay < 0 {
+		return fmt.Errorf("--batch-delay must not be negative")
+	}
+	if c.batchSize == 0 {
+		if c.batchDelay != 0 {
+			return fmt.Errorf("--batch-delay requires --batch-size")
+		}
+		if c.stopOnFailure {
+			return fmt.Errorf("--stop-on-failure requires --batch-size")
+		}
+	}
+
 	var nameErrors []string
 	for _, machineId := range c.machines {
 		if !names.IsValidMachine(machineId) {
@@ -187,6 +223,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.batchSize > 0 {
+		return c.runInBatches(ctx, client)
+	}
+
 	var runResults []params.ActionResult
 	if c.all {
 		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
@@ -205,21 +245,199 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
 
+	actionsToQuery, _ := queryActions(ctx, runResults)
+	if len(actionsToQuery) == 0 {
+		return errors.New("no actions were successfully enqueued, aborting")
+	}
+
+	writer := c.newResultWriter(ctx, len(actionsToQuery))
+	if err := waitForResults(client, actionsToQuery, writer.add); err != nil {
+		return errors.Trace(err)
+	}
+	return writer.flush()
+}
+
+// runInBatches runs the commands on at most batchSize targets at a
+// time, starting each batch only when the previous one has completed.
+func (c *runCommand) runInBatches(ctx *cmd.Context, client RunClient) error {
+	targets, err := c.runTargets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(targets) == 0 {
+		return errors.New("no targets to run the commands on")
+	}
+
+	writer := c.newResultWriter(ctx, len(targets))
+	var notQueued []string
+	for start := 0; start < len(targets); start += c.batchSize {
+		if start > 0 && c.batchDelay > 0 {
+			<-afterFunc(c.batchDelay)
+		}
+		end := start + c.batchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+
+		runResults, err := client.Run(c.batchParams(targets[start:end]))
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		actionsToQuery, failed := queryActions(ctx, runResults)
+		if len(actionsToQuery) == 0 {
+			// Nothing in this batch can run, but the
+			// commands may yet run on the later ones.
+			var batch []string
+			for _, tag := range targets[start:end] {
+				batch = append(batch, tag.Id())
+			}
+			fmt.Fprintf(ctx.GetStderr(), "no actions were enqueued on: %s\n", strings.Join(batch, ", "))
+			notQueued = append(notQueued, batch...)
+			failed = true
+		}
+		err = waitForResults(client, actionsToQuery, func(values map[string]interface{}) error {
+			if resultFailed(values) {
+				failed = true
+			}
+			return writer.add(values)
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		if failed && c.stopOnFailure && end < len(targets) {
+			if err := writer.flush(); err != nil {
+				return errors.Trace(err)
+			}
+			var notRun []string
+			for _, tag := range targets[end:] {
+				notRun = append(notRun, tag.Id())
+			}
+			return errors.Errorf("stopping after failure, commands not run on: %s", strings.Join(notRun, ", "))
+		}
+	}
+	if err := writer.flush(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(notQueued) > 0 {
+		return errors.Errorf("commands not run on: %s", strings.Join(notQueued, ", "))
+	}
+	return nil
+}
+
+// runTargets returns the units and machines on which the commands are
+// to be run, in order, using the model status to find the units of
+// applications and, for --all, the machines in the model.
+func (c *runCommand) runTargets() ([]names.Tag, error) {
+	machines := c.machines
+	units := set.NewStrings(c.units...)
+	if c.all || len(c.services) > 0 {
+		status, err := getRunStatus(c)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get model status")
+		}
+		if c.all {
+			machines = common.SortStringsNaturally(statusMachineIds(status.Machines))
+		}
+		for _, name := range c.services {
+			unitNames, err := statusUnitNames(status, name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units = units.Union(set.NewStrings(unitNames...))
+		}
+	}
+
+	// Units come before machines, as when the commands are run on
+	// all the targets at once.
+	var targets []names.Tag
+	for _, unitName := range common.SortStringsNaturally(units.Values()) {
+		targets = append(targets, names.NewUnitTag(unitName))
+	}
+	for _, machineId := range machines {
+		targets = append(targets, names.NewMachineTag(machineId))
+	}
+	return targets, nil
+}
+
+// batchParams returns the parameters for running the commands on the
+// supplied batch of targets.
+func (c *runCommand) batchParams(targets []names.Tag) params.RunParams {
+	runParams := params.RunParams{
+		Commands: c.commands,
+		Timeout:  c.timeout,
+	}
+	for _, tag := range targets {
+		switch tag := tag.(type) {
+		case names.UnitTag:
+			runParams.Units = append(runParams.Units, tag.Id())
+		case names.MachineTag:
+			runParams.Machines = append(runParams.Machines, tag.Id())
+		}
+	}
+	return runParams
+}
+
+// statusMachineIds returns the ids of the supplied machines and all
+// their containers.
+func statusMachineIds(machines map[string]params.MachineStatus) []string {
+	var ids []string
+	for id, machine := range machines {
+		ids = append(ids, id)
+		ids = append(ids, statusMachineIds(machine.Containers)...)
+	}
+	return ids
+}
+
+// statusUnitNames returns the names of the units of the named application.
+func statusUnitNames(status *params.FullStatus, application string) ([]string, error) {
+	applicationStatus, ok := status.Applications[application]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", application)
+	}
+	var unitNames []string
+	for unitName := range applicationStatus.Units {
+		unitNames = append(unitNames, unitName)
+	}
+	if len(applicationStatus.SubordinateTo) == 0 {
+		return unitNames, nil
+	}
+	// Subordinate units are reported alongside their principals.
+	prefix := application + "/"
+	for _, principal := range status.Applications {
+		for _, unit := range principal.Units {
+			for unitName := range unit.Subordinates {
+				if strings.HasPrefix(unitName, prefix) {
+					unitNames = append(unitNames, unitName)
+				}
+			}
+		}
+	}
+	return unitNames, nil
+}
+
+// queryActions returns the queries for the actions that were enqueued
+// to run the commands, and whether any could not be.
+func queryActions(ctx *cmd.Context, runResults []params.ActionResult) ([]actionQuery, bool) {
+	failed := false
 	actionsToQuery := []actionQuery{}
 	for _, result := range runResults {
 		if result.Error != nil {
 			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
+			failed = true
 			continue
 		}
 		actionTag, err := names.ParseActionTag(result.Action.Tag)
 		if err != nil {
 			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver)
+			failed = true
 			continue
 		}
 
 		receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
 		if err != nil {
 			fmt.Fprintf(ctx.GetStderr(), "got invalid action receiver tag %v for action %v", result.Action.Receiver, result.Action.Tag)
+			failed = true
 			continue
 		}
 		var receiverType string
@@ -238,12 +456,12 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				tag:          receiverTag,
 			}})
 	}
+	return actionsToQuery, failed
+}
 
-	if len(actionsToQuery) == 0 {
-		return errors.New("no actions were successfully enqueued, aborting")
-	}
-
-	values := []interface{}{}
+// waitForResults polls the queried actions until they have all
+// completed, passing the results of each to report as soon as it has.
+func waitForResults(client RunClient, actionsToQuery []actionQuery, report func(map[string]interface{}) error) error {
 	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
 		if err != nil {
@@ -260,40 +478,94 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			if err := report(ConvertActionResults(result, actionsToQuery[i])); err != nil {
+				return errors.Trace(err)
+			}
 		}
 
 		actionsToQuery = newActionsToQuery
+		if len(actionsToQuery) == 0 {
+			break
+		}
 
 		// TODO: use a watcher instead of sleeping
 		// this should be easier once we implement action grouping
 		<-afterFunc(1 * time.Second)
 	}
+	return nil
+}
 
+// resultFailed returns whether the converted results of an action show
+// that the commands failed, or could not be run.
+func resultFailed(values map[string]interface{}) bool {
+	for _, key := range []string{"Error", "ReturnCode", "Message"} {
+		if _, ok := values[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// runResultWriter writes the results of the commands on each target as
+// they complete, unless the output format requires all the results to
+// be written at once.
+type runResultWriter struct {
+	ctx    *cmd.Context
+	out    *cmd.Output
+	stream bool
+	values []interface{}
+}
+
+// newResultWriter returns a runResultWriter for the results of running
+// the commands on the given number of targets.
+func (c *runCommand) newResultWriter(ctx *cmd.Context, targets int) *runResultWriter {
+	return &runResultWriter{
+		ctx: ctx,
+		out: &c.out,
+		// Results written one at a time as single item lists make up
+		// a valid YAML list, but not a valid JSON one; and a single
+		// result is written as though the commands were run locally.
+		stream: c.out.Name() != "json" && targets > 1,
+	}
+}
+
+func (w *runResultWriter) add(values map[string]interface{}) error {
+	if w.stream {
+		return w.out.Write(w.ctx, []interface{}{values})
+	}
+	w.values = append(w.values, values)
+	return nil
+}
+
+// flush writes any results that have not yet been written.
+func (w *runResultWriter) flush() error {
+	if w.stream {
+		return nil
+	}
 	// If we are just dealing with one result, AND we are using the smart
 	// format, then pretend we were running it locally.
-	if len(values) == 1 && c.out.Name() == "smart" {
-		result, ok := values[0].(map[string]interface{})
+	if len(w.values) == 1 && w.out.Name() == "smart" {
+		result, ok := w.values[0].(map[string]interface{})
 		if !ok {
 			return errors.New("couldn't read action output")
 		}
 		if res, ok := result["Error"].(string); ok {
 			return errors.New(res)
 		}
-		ctx.Stdout.Write(formatOutput(result, "Stdout"))
-		ctx.Stderr.Write(formatOutput(result, "Stderr"))
+		w.ctx.Stdout.Write(formatOutput(result, "Stdout"))
+		w.ctx.Stderr.Write(formatOutput(result, "Stderr"))
 		if code, ok := result["ReturnCode"].(int); ok && code != 0 {
 			return cmd.NewRcPassthroughError(code)
 		}
 		// Message should always contain only errors.
 		if res, ok := result["Message"].(string); ok && res != "" {
-			ctx.Stderr.Write([]byte(res))
+			w.ctx.Stderr.Write([]byte(res))
 		}
 
 		return nil
 	}
 
-	return c.out.Write(ctx, values)
+	return w.out.Write(w.ctx, w.values)
 }
 
 type actionReceiver struct {
@@ -323,6 +595,17 @@ var getRunAPIClient = func(c *runCommand) (RunClient, error) {
 	return actionapi.NewClient(root), errors.Trace(err)
 }
 
+// getRunStatus returns the model status, which is used to find the
+// targets of commands run in batches.
+var getRunStatus = func(c *runCommand) (*params.FullStatus, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+	return client.Status(nil)
+}
+
 // getActionResult abstracts over the action CLI function that we use here to fetch results
 var getActionResult = func(c RunClient, actionId string, wait *time.Timer) (params.ActionResult, error) {
 	return action.GetActionResult(c, actionId, wait)
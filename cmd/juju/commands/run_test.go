@@ -158,6 +158,52 @@ func (*RunSuite) TestTimeoutArgParsing(c *gc.C) {
 	}
 }
 
+func (*RunSuite) TestBatchArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		message       string
+		args          []string
+		errMatch      string
+		batchSize     int
+		batchDelay    time.Duration
+		stopOnFailure bool
+	}{{
+		message: "no batches by default",
+		args:    []string{"--all", "sudo reboot"},
+	}, {
+		message:       "batches with delay",
+		args:          []string{"--batch-size=2", "--batch-delay=30s", "--stop-on-failure", "--all", "sudo reboot"},
+		batchSize:     2,
+		batchDelay:    30 * time.Second,
+		stopOnFailure: true,
+	}, {
+		message:  "negative batch size",
+		args:     []string{"--batch-size=-1", "--all", "sudo reboot"},
+		errMatch: "--batch-size must not be negative",
+	}, {
+		message:  "negative batch delay",
+		args:     []string{"--batch-size=1", "--batch-delay=-1s", "--all", "sudo reboot"},
+		errMatch: "--batch-delay must not be negative",
+	}, {
+		message:  "batch delay without batches",
+		args:     []string{"--batch-delay=30s", "--all", "sudo reboot"},
+		errMatch: "--batch-delay requires --batch-size",
+	}, {
+		message:  "stop on failure without batches",
+		args:     []string{"--stop-on-failure", "--all", "sudo reboot"},
+		errMatch: "--stop-on-failure requires --batch-size",
+	}} {
+		c.Log(fmt.Sprintf("%v: %s", i, test.message))
+		cmd := &runCommand{}
+		runCmd := modelcmd.Wrap(cmd)
+		testing.TestInit(c, runCmd, test.args, test.errMatch)
+		if test.errMatch == "" {
+			c.Check(cmd.batchSize, gc.Equals, test.batchSize)
+			c.Check(cmd.batchDelay, gc.Equals, test.batchDelay)
+			c.Check(cmd.stopOnFailure, gc.Equals, test.stopOnFailure)
+		}
+	}
+}
+
 func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 	for i, test := range []struct {
 		message  string
@@ -401,6 +447,182 @@ func (s *RunSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) TestRunInBatches(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setupStatus(c, &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Units: map[string]params.UnitStatus{
+				"mysql/0":  {Subordinates: map[string]params.UnitStatus{"logging/0": {}}},
+				"mysql/1":  {},
+				"mysql/10": {},
+			}},
+			"logging": {SubordinateTo: []string{"mysql"}},
+		},
+	})
+	delays := s.patchAfterFunc()
+	var expected []interface{}
+	for i, unitName := range []string{"logging/0", "mysql/0", "mysql/1", "mysql/10"} {
+		unformatted := mock.setCompleted(unitName, mockResponse{
+			stdout:  fmt.Sprintf("out%d", i),
+			unitTag: names.NewUnitTag(unitName).String(),
+		})
+		expected = append(expected, unformatted)
+	}
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--format=yaml", "--batch-size=2", "--batch-delay=10s",
+		"--application=mysql,logging", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The results for each unit are written as they complete, and
+	// together form a YAML list.
+	yamlFormatted, err := cmd.FormatYaml(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, string(yamlFormatted)+"\n")
+	c.Check(mock.runCalls, jc.DeepEquals, []params.RunParams{{
+		Commands: "hostname",
+		Timeout:  5 * time.Minute,
+		Units:    []string{"logging/0", "mysql/0"},
+	}, {
+		Commands: "hostname",
+		Timeout:  5 * time.Minute,
+		Units:    []string{"mysql/1", "mysql/10"},
+	}})
+	c.Check(*delays, jc.DeepEquals, []time.Duration{10 * time.Second})
+}
+
+func (s *RunSuite) TestRunInBatchesAllMachines(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setupStatus(c, &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0": {Containers: map[string]params.MachineStatus{
+				"0/lxd/0": {},
+			}},
+			"10": {},
+			"2":  {},
+		},
+	})
+	s.patchAfterFunc()
+	for _, machineId := range []string{"0", "0/lxd/0", "2", "10"} {
+		mock.setCompleted(machineId, mockResponse{
+			machineTag: names.NewMachineTag(machineId).String(),
+		})
+	}
+
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--batch-size=3", "--all", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mock.runCalls, jc.DeepEquals, []params.RunParams{{
+		Commands: "hostname",
+		Timeout:  5 * time.Minute,
+		Machines: []string{"0", "0/lxd/0", "2"},
+	}, {
+		Commands: "hostname",
+		Timeout:  5 * time.Minute,
+		Machines: []string{"10"},
+	}})
+}
+
+func (s *RunSuite) TestRunInBatchesStopOnFailure(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.patchAfterFunc()
+	failed := mock.setCompleted("mysql/0", mockResponse{
+		stderr:  "oops",
+		code:    "1",
+		unitTag: "unit-mysql-0",
+	})
+	mock.setCompleted("mysql/1", mockResponse{unitTag: "unit-mysql-1"})
+	mock.setCompleted("mysql/2", mockResponse{unitTag: "unit-mysql-2"})
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--batch-size=1", "--stop-on-failure",
+		"--unit=mysql/0,mysql/1,mysql/2", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "stopping after failure, commands not run on: mysql/1, mysql/2")
+
+	jsonFormatted, err := cmd.FormatJson([]interface{}{failed})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, string(jsonFormatted)+"\n")
+	c.Check(mock.runCalls, gc.HasLen, 1)
+}
+
+func (s *RunSuite) TestRunInBatchesContinueOnFailure(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.patchAfterFunc()
+	mock.setCompleted("mysql/0", mockResponse{
+		code:    "1",
+		unitTag: "unit-mysql-0",
+	})
+	mock.setCompleted("mysql/1", mockResponse{unitTag: "unit-mysql-1"})
+
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--batch-size=1", "--unit=mysql/0,mysql/1", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mock.runCalls, gc.HasLen, 2)
+}
+
+func (s *RunSuite) TestRunInBatchesNotQueued(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.patchAfterFunc()
+	// The mock queues no action for mysql/0.
+	completed := mock.setCompleted("mysql/1", mockResponse{unitTag: "unit-mysql-1"})
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--batch-size=1", "--unit=mysql/0,mysql/1", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "commands not run on: mysql/0")
+
+	jsonFormatted, err := cmd.FormatJson([]interface{}{completed})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, string(jsonFormatted)+"\n")
+	c.Check(testing.Stderr(context), gc.Equals, "no actions were enqueued on: mysql/0\n")
+	c.Check(mock.runCalls, gc.HasLen, 2)
+}
+
+func (s *RunSuite) TestRunInBatchesNotQueuedStopOnFailure(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.patchAfterFunc()
+	mock.setCompleted("mysql/1", mockResponse{unitTag: "unit-mysql-1"})
+
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--batch-size=1", "--stop-on-failure",
+		"--unit=mysql/0,mysql/1", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "stopping after failure, commands not run on: mysql/1")
+	c.Check(mock.runCalls, gc.HasLen, 1)
+}
+
+func (s *RunSuite) TestRunInBatchesUnknownApplication(c *gc.C) {
+	s.setupMockAPI()
+	s.setupStatus(c, &params.FullStatus{})
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--batch-size=1", "--application=mysql", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
+
+func (s *RunSuite) setupStatus(c *gc.C, status *params.FullStatus) {
+	s.PatchValue(&getRunStatus, func(_ *runCommand) (*params.FullStatus, error) {
+		return status, nil
+	})
+}
+
+// patchAfterFunc makes waiting immediate, and returns the durations
+// waited for.
+func (s *RunSuite) patchAfterFunc() *[]time.Duration {
+	var delays []time.Duration
+	s.PatchValue(&afterFunc, func(d time.Duration) <-chan time.Time {
+		delays = append(delays, d)
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	})
+	return &delays
+}
+
 func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	mock := &mockRunAPI{}
 	s.PatchValue(&getRunAPIClient, func(_ *runCommand) (RunClient, error) {
@@ -420,6 +642,7 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+	runCalls        []params.RunParams
 }
 
 type mockResponse struct {
@@ -490,6 +713,28 @@ func (m *mockRunAPI) setResponse(id string, mock mockResponse) {
 	m.runResponses[id] = makeActionResult(mock, actionTag.String())
 }
 
+// setCompleted sets the response for running commands on id, which
+// completes immediately, and returns the result as it will be output.
+func (m *mockRunAPI) setCompleted(id string, mock mockResponse) map[string]interface{} {
+	m.setResponse(id, mock)
+	if m.actionResponses == nil {
+		m.actionResponses = make(map[string]params.ActionResult)
+	}
+	actionId := m.receiverIdMap[id]
+	result := m.runResponses[id]
+	m.actionResponses[actionId] = result
+
+	receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
+	if err != nil {
+		panic(err)
+	}
+	receiverType := "UnitId"
+	if _, ok := receiverTag.(names.MachineTag); ok {
+		receiverType = "MachineId"
+	}
+	return ConvertActionResults(result, makeActionQuery(actionId, receiverType, receiverTag))
+}
+
 func (*mockRunAPI) Close() error {
 	return nil
 }
@@ -526,6 +771,7 @@ func (m *mockRunAPI) RunOnAllMachines(commands string, timeout time.Duration) ([
 func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, error) {
 	var result []params.ActionResult
 
+	m.runCalls = append(m.runCalls, runParams)
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
 	}
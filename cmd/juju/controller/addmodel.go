@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
+	cloudapi "github.com/juju/juju/api/cloud"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
@@ -29,9 +30,12 @@ func NewAddModelCommand() cmd.Command {
 type addModelCommand struct {
 	modelcmd.ControllerCommandBase
 	api             AddModelAPI
+	credentialAPI   CredentialAPI
 	credentialStore jujuclient.CredentialStore
 
 	Name           string
+	ModelCloud     string
+	ModelRegion    string
 	Owner          string
 	CredentialSpec string
 	CloudName      string
@@ -42,11 +46,23 @@ type addModelCommand struct {
 
 const addModelHelpDoc = `
 Adding a model is typically done in order to run a specific workload. The
-model is of the same cloud type as the controller and resides within that
-controller. By default, the controller is the current controller. The
+model resides within the controller, and by default is created in the
+same cloud and region as the controller. By default, the controller is the
+current controller. The
 credentials used to add the model are the ones used to create any future
 resources within the model (` + "`juju deploy`, `juju add-unit`" + `).
 
+If the credential specified with --credential is for the cloud the
+controller runs in, it is uploaded to the controller and the model refers
+to it by name; running ` + "`juju update-credential`" + ` later rotates the
+credential for every model using it.
+
+A model may instead be created in another cloud or region, by specifying
+<cloud> or <cloud>/<region>. Clouds other than the controller's must first
+be added to the controller with ` + "`juju add-cloud --controller`" + `, and the
+controller's own cloud must be added too to use its other regions. Models
+in other clouds or regions require a credential for that cloud.
+
 Model names can be duplicated across controllers but must be unique for
 any given controller. Model names may only contain lowercase letters,
 digits and hyphens, and may not start with a hyphen.
@@ -63,12 +79,13 @@ Examples:
     juju add-model mymodel
     juju add-model mymodel --config aws-creds.yaml --config image-stream=daily
     juju add-model mymodel --credential aws:credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model mymodel aws/us-west-2 --credential aws:credential_name
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-model",
-		Args:    "<model name>",
+		Args:    "<model name> [<cloud>[/<region>]]",
 		Purpose: "Adds a hosted model.",
 		Doc:     strings.TrimSpace(addModelHelpDoc),
 	}
@@ -107,13 +124,30 @@ func (c *addModelCommand) Init(args []string) error {
 		}
 		c.CredentialName = parts[1]
 	}
-	return nil
+
+	if len(args) > 0 {
+		c.ModelCloud, args = args[0], args[1:]
+		if i := strings.Index(c.ModelCloud, "/"); i >= 0 {
+			c.ModelCloud, c.ModelRegion = c.ModelCloud[:i], c.ModelCloud[i+1:]
+		}
+		if c.ModelCloud == "" {
+			return errors.New("cloud name must not be empty")
+		}
+	}
+	return cmd.CheckEmpty(args)
 }
 
 type AddModelAPI interface {
 	Close() error
 	ConfigSkeleton(provider, region string) (params.ModelConfig, error)
-	CreateModel(owner string, account, config map[string]interface{}) (params.Model, error)
+	CreateModel(owner, cloudName, cloudRegion, credential string, account, config map[string]interface{}) (params.Model, error)
+}
+
+// CredentialAPI defines the methods used to upload credentials to the
+// controller.
+type CredentialAPI interface {
+	Close() error
+	UpdateCredential(owner names.UserTag, cloudName, credentialName string, credential cloud.Credential) error
 }
 
 func (c *addModelCommand) getAPI() (AddModelAPI, error) {
@@ -123,6 +157,17 @@ func (c *addModelCommand) getAPI() (AddModelAPI, error) {
 	return c.NewModelManagerAPIClient()
 }
 
+func (c *addModelCommand) getCredentialAPI() (CredentialAPI, error) {
+	if c.credentialAPI != nil {
+		return c.credentialAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cloudapi.NewClient(root), nil
+}
+
 func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	client, err := c.getAPI()
 	if err != nil {
@@ -149,9 +194,15 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		modelOwner = names.NewUserTag(c.Owner).Canonical()
 	}
 
-	serverSkeleton, err := client.ConfigSkeleton(c.CloudType, "")
-	if err != nil {
-		return errors.Trace(err)
+	// The controller's config skeleton only applies to models in its
+	// own cloud region; models elsewhere are configured by the
+	// controller for their cloud.
+	var serverSkeleton params.ModelConfig
+	if c.ModelCloud == "" {
+		serverSkeleton, err = client.ConfigSkeleton(c.CloudType, "")
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	attrs, err := c.getConfigValues(ctx, serverSkeleton)
@@ -160,6 +211,7 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	}
 
 	accountDetails := map[string]interface{}{}
+	var credentialName string
 	if c.CredentialName != "" {
 		cred, _, _, err := modelcmd.GetCredentials(
 			c.credentialStore, "", c.CredentialName, c.CloudName, c.CloudType,
@@ -167,11 +219,30 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		if err != nil {
 			return errors.Trace(err)
 		}
-		for k, v := range cred.Attributes() {
-			accountDetails[k] = v
+		controllerDetails, err := store.ControllerByName(controllerName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		modelCloud := c.ModelCloud
+		if modelCloud == "" {
+			modelCloud = controllerDetails.Cloud
+		}
+		if modelCloud == c.CloudName {
+			// Store the credential in the controller, so it can
+			// be rotated later without touching the model.
+			if err := c.uploadCredential(modelOwner, *cred); err != nil {
+				return errors.Trace(err)
+			}
+			credentialName = c.CredentialName
+		} else if c.ModelCloud != "" {
+			return errors.Errorf("credential is for cloud %q, not %q", c.CloudName, c.ModelCloud)
+		} else {
+			for k, v := range cred.Attributes() {
+				accountDetails[k] = v
+			}
 		}
 	}
-	model, err := client.CreateModel(modelOwner, accountDetails, attrs)
+	model, err := client.CreateModel(modelOwner, c.ModelCloud, c.ModelRegion, credentialName, accountDetails, attrs)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -194,6 +265,16 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *addModelCommand) uploadCredential(owner string, credential cloud.Credential) error {
+	client, err := c.getCredentialAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	err = client.UpdateCredential(names.NewUserTag(owner), c.CloudName, c.CredentialName, credential)
+	return errors.Annotatef(err, "uploading credential %q", c.CredentialName)
+}
+
 func (c *addModelCommand) getConfigValues(ctx *cmd.Context, serverSkeleton params.ModelConfig) (map[string]interface{}, error) {
 	configValues := make(map[string]interface{})
 	for key, value := range serverSkeleton {
@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -64,7 +65,7 @@ func (s *addSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *addSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	command, _ := controller.NewAddModelCommandForTest(s.fake, s.store, s.store)
+	command, _ := controller.NewAddModelCommandForTest(s.fake, s.fake, s.store, s.store)
 	return testing.RunCommand(c, command, args...)
 }
 
@@ -75,6 +76,8 @@ func (s *addSuite) TestInit(c *gc.C) {
 		err    string
 		name   string
 		owner  string
+		cloud  string
+		region string
 		values map[string]interface{}
 	}{
 		{
@@ -104,6 +107,21 @@ func (s *addSuite) TestInit(c *gc.C) {
 		}, {
 			args: []string{"new-model", "--owner", "not=valid"},
 			err:  `"not=valid" is not a valid user`,
+		}, {
+			args:  []string{"new-model", "aws"},
+			name:  "new-model",
+			cloud: "aws",
+		}, {
+			args:   []string{"new-model", "aws/us-west-2"},
+			name:   "new-model",
+			cloud:  "aws",
+			region: "us-west-2",
+		}, {
+			args: []string{"new-model", "/us-west-2"},
+			err:  "cloud name must not be empty",
+		}, {
+			args: []string{"new-model", "aws", "extra"},
+			err:  `unrecognized args: \["extra"\]`,
 		}, {
 			args: []string{"new-model", "--credential", "secrets"},
 			err:  `invalid cloud credential secrets, expected <cloud>:<credential-name>`,
@@ -114,7 +132,7 @@ func (s *addSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, command := controller.NewAddModelCommandForTest(nil, s.store, s.store)
+		wrappedCommand, command := controller.NewAddModelCommandForTest(nil, nil, s.store, s.store)
 		err := testing.InitCommand(wrappedCommand, test.args)
 		if test.err != "" {
 			c.Assert(err, gc.ErrorMatches, test.err)
@@ -124,6 +142,8 @@ func (s *addSuite) TestInit(c *gc.C) {
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(command.Name, gc.Equals, test.name)
 		c.Assert(command.Owner, gc.Equals, test.owner)
+		c.Assert(command.ModelCloud, gc.Equals, test.cloud)
+		c.Assert(command.ModelRegion, gc.Equals, test.region)
 		attrs, err := command.Config.ReadAttrs(nil)
 		c.Assert(err, jc.ErrorIsNil)
 		if len(test.values) == 0 {
@@ -161,6 +181,43 @@ func (s *addSuite) TestCredentialsPassedThrough(c *gc.C) {
 		"access-key": "key",
 		"secret-key": "sekret",
 	})
+	c.Assert(s.fake.credential, gc.Equals, "")
+	c.Assert(s.fake.uploaded, gc.IsNil)
+}
+
+func (s *addSuite) TestCredentialsUploadedForControllerCloud(c *gc.C) {
+	s.store.Controllers["test-master"] = jujuclient.ControllerDetails{Cloud: "aws"}
+	_, err := s.run(c, "test", "--credential", "aws:secrets")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fake.account, gc.HasLen, 0)
+	c.Assert(s.fake.credential, gc.Equals, "secrets")
+	c.Assert(s.fake.uploaded, jc.DeepEquals, []string{"user-bob@local aws secrets"})
+}
+
+func (s *addSuite) TestCredentialUploadError(c *gc.C) {
+	s.store.Controllers["test-master"] = jujuclient.ControllerDetails{Cloud: "aws"}
+	s.fake.uploadErr = errors.New("nope")
+	_, err := s.run(c, "test", "--credential", "aws:secrets")
+	c.Assert(err, gc.ErrorMatches, `uploading credential "secrets": nope`)
+}
+
+func (s *addSuite) TestModelCloudPassedThrough(c *gc.C) {
+	s.store.Controllers["test-master"] = jujuclient.ControllerDetails{Cloud: "maas"}
+	_, err := s.run(c, "test", "aws/us-west-2", "--credential", "aws:secrets")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The controller's config skeleton is not used for other clouds.
+	c.Assert(s.fake.config["type"], gc.IsNil)
+	c.Assert(s.fake.cloud, gc.Equals, "aws")
+	c.Assert(s.fake.region, gc.Equals, "us-west-2")
+	c.Assert(s.fake.credential, gc.Equals, "secrets")
+	c.Assert(s.fake.uploaded, jc.DeepEquals, []string{"user-bob@local aws secrets"})
+}
+
+func (s *addSuite) TestCredentialForOtherCloud(c *gc.C) {
+	_, err := s.run(c, "test", "gce", "--credential", "aws:secrets")
+	c.Assert(err, gc.ErrorMatches, `credential is for cloud "aws", not "gce"`)
 }
 
 func (s *addSuite) TestComandLineConfigPassedThrough(c *gc.C) {
@@ -298,14 +355,20 @@ func (s *addSuite) TestNoEnvCacheOtherUser(c *gc.C) {
 // fakeAddClient is used to mock out the behavior of the real
 // AddModel command.
 type fakeAddClient struct {
-	owner   string
-	account map[string]interface{}
-	config  map[string]interface{}
-	err     error
-	model   params.Model
+	owner      string
+	cloud      string
+	region     string
+	credential string
+	account    map[string]interface{}
+	config     map[string]interface{}
+	err        error
+	model      params.Model
+	uploaded   []string
+	uploadErr  error
 }
 
 var _ controller.AddModelAPI = (*fakeAddClient)(nil)
+var _ controller.CredentialAPI = (*fakeAddClient)(nil)
 
 func (*fakeAddClient) Close() error {
 	return nil
@@ -320,11 +383,22 @@ func (*fakeAddClient) ConfigSkeleton(provider, region string) (params.ModelConfi
 		"controller": false,
 	}, nil
 }
-func (f *fakeAddClient) CreateModel(owner string, account, config map[string]interface{}) (params.Model, error) {
+func (f *fakeAddClient) UpdateCredential(owner names.UserTag, cloudName, credentialName string, credential cloud.Credential) error {
+	if f.uploadErr != nil {
+		return f.uploadErr
+	}
+	f.uploaded = append(f.uploaded, fmt.Sprintf("%s %s %s", owner, cloudName, credentialName))
+	return nil
+}
+
+func (f *fakeAddClient) CreateModel(owner, cloudName, cloudRegion, credential string, account, config map[string]interface{}) (params.Model, error) {
 	if f.err != nil {
 		return params.Model{}, f.err
 	}
 	f.owner = owner
+	f.cloud = cloudName
+	f.region = cloudRegion
+	f.credential = credential
 	f.account = account
 	f.config = config
 	return f.model, nil
@@ -275,19 +275,23 @@ func formatTabularBlockedModels(value interface{}) ([]byte, error) {
 	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
 	fmt.Fprintf(tw, "NAME\tMODEL UUID\tOWNER\tBLOCKS\n")
 	for _, model := range models {
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", model.Name, model.UUID, model.OwnerTag, blocksToStr(model.Blocks))
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", model.Name, model.UUID, model.OwnerTag, blocksToStr(model.Blocks, model.EntityBlocks))
 	}
 	tw.Flush()
 	return out.Bytes(), nil
 }
 
-func blocksToStr(blocks []string) string {
+func blocksToStr(blocks []string, entityBlocks []params.Block) string {
 	result := ""
 	sep := ""
 	for _, blk := range blocks {
 		result = result + sep + block.OperationFromType(blk)
 		sep = ","
 	}
+	for _, blk := range entityBlocks {
+		result = result + sep + fmt.Sprintf("%s (%s)", block.OperationFromType(blk.Type), block.DescribeEntity(blk.Tag))
+		sep = ","
+	}
 
 	return result
 }
@@ -28,6 +28,17 @@ func NewShowControllerCommandForTest(testStore jujuclient.ClientStore) *showCont
 	}
 }
 
+// NewShowControllerCommandWithAPIForTest returns a showControllerCommand
+// with the clientstore and replica set API provided as specified.
+func NewShowControllerCommandWithAPIForTest(testStore jujuclient.ClientStore, api ReplicaSetAPI, apiErr error) *showControllerCommand {
+	return &showControllerCommand{
+		store: testStore,
+		replicaSetAPI: func(string) (ReplicaSetAPI, error) {
+			return api, apiErr
+		},
+	}
+}
+
 type AddModelCommand struct {
 	*addModelCommand
 }
@@ -36,11 +47,13 @@ type AddModelCommand struct {
 // the api provided as specified.
 func NewAddModelCommandForTest(
 	api AddModelAPI,
+	credentialAPI CredentialAPI,
 	store jujuclient.ClientStore,
 	credentialStore jujuclient.CredentialStore,
 ) (cmd.Command, *AddModelCommand) {
 	c := &addModelCommand{
 		api:             api,
+		credentialAPI:   credentialAPI,
 		credentialStore: credentialStore,
 	}
 	c.SetClientStore(store)
@@ -74,6 +87,24 @@ func NewRemoveBlocksCommandForTest(api removeBlocksAPI, store jujuclient.ClientS
 	return modelcmd.WrapController(c)
 }
 
+// NewEnableMaintenanceCommandForTest returns an enable-maintenance
+// command with the API and clock mocked out.
+func NewEnableMaintenanceCommandForTest(api maintenanceAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &enableMaintenanceCommand{clock: clock}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewDisableMaintenanceCommandForTest returns a disable-maintenance
+// command with the API mocked out.
+func NewDisableMaintenanceCommandForTest(api maintenanceAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &disableMaintenanceCommand{}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -95,6 +95,23 @@ func (s *ListBlocksSuite) TestListBlocksTabular(c *gc.C) {
 		"\n")
 }
 
+func (s *ListBlocksSuite) TestListBlocksTabularEntityBlocks(c *gc.C) {
+	s.api.blocks[0].EntityBlocks = []params.Block{{
+		Tag:  "application-postgresql",
+		Type: "BlockRemove",
+	}, {
+		Tag:  "machine-0",
+		Type: "BlockChange",
+	}}
+	ctx, err := s.runListBlocksCommand(c)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"NAME   MODEL UUID  OWNER         BLOCKS\n"+
+		"test1  test1-uuid  cheryl@local  destroy-model,remove-object (application postgresql),all-changes (machine 0)\n"+
+		"test2  test2-uuid  bob@local     destroy-model,all-changes\n"+
+		"\n")
+}
+
 func (s *ListBlocksSuite) TestListBlocksJSON(c *gc.C) {
 	ctx, err := s.runListBlocksCommand(c, "--format", "json")
 	c.Check(err, jc.ErrorIsNil)
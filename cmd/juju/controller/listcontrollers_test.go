@@ -6,6 +6,7 @@ package controller_test
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -13,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/testing"
 )
@@ -46,6 +48,35 @@ mark-test-prodstack  -         -            prodstack
 	s.assertListControllers(c)
 }
 
+func (s *ListControllersSuite) TestListControllersMaintenance(c *gc.C) {
+	s.expectedOutput = `
+CONTROLLER           MODEL     USER         CLOUD/REGION        MAINTENANCE
+aws-test             -         -            aws/us-east-1       upgrading mongo
+mallards*            my-model  admin@local  mallards/mallards1  enabled
+mark-test-prodstack  -         -            prodstack           -
+
+`[1:]
+
+	store := s.createTestClientStore(c)
+	setMaintenance := func(name, reason string, expires *time.Time) {
+		details := store.Controllers[name]
+		details.Maintenance = &jujuclient.MaintenanceDetails{
+			Reason:  reason,
+			Owner:   "admin@local",
+			Started: time.Now(),
+			Expires: expires,
+		}
+		store.Controllers[name] = details
+	}
+	setMaintenance("aws-test", "upgrading mongo", nil)
+	future := time.Now().Add(time.Hour)
+	setMaintenance("mallards", "", &future)
+	// Expired maintenance mode is not shown.
+	past := time.Now().Add(-time.Hour)
+	setMaintenance("mark-test-prodstack", "", &past)
+	s.assertListControllers(c)
+}
+
 func (s *ListControllersSuite) TestListControllersYaml(c *gc.C) {
 	s.expectedOutput = `
 controllers:
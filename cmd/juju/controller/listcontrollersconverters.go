@@ -5,6 +5,7 @@ package controller
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 
@@ -28,6 +29,8 @@ type ControllerItem struct {
 	CACert         string   `yaml:"ca-cert" json:"ca-cert"`
 	Cloud          string   `yaml:"cloud" json:"cloud"`
 	CloudRegion    string   `yaml:"region,omitempty" json:"region,omitempty"`
+
+	Maintenance *MaintenanceItem `yaml:"maintenance,omitempty" json:"maintenance,omitempty"`
 }
 
 // convertControllerDetails takes a map of Controllers and
@@ -44,6 +47,7 @@ func (c *listControllersCommand) convertControllerDetails(storeControllers map[s
 		errs = append(errs, msg)
 	}
 
+	now := time.Now()
 	controllers := map[string]ControllerItem{}
 	for controllerName, details := range storeControllers {
 		serverName := ""
@@ -87,6 +91,7 @@ func (c *listControllersCommand) convertControllerDetails(storeControllers map[s
 			CACert:         details.CACert,
 			Cloud:          details.Cloud,
 			CloudRegion:    details.CloudRegion,
+			Maintenance:    convertMaintenanceDetails(details.Maintenance, now),
 		}
 	}
 	return controllers, errs
@@ -41,7 +41,20 @@ func formatControllersTabular(set ControllerSet) ([]byte, error) {
 		fmt.Fprintln(tw, strings.Join(values, "\t"))
 	}
 
-	print("CONTROLLER", "MODEL", "USER", "CLOUD/REGION")
+	// The maintenance column is only shown if a controller is in
+	// maintenance mode, as it is usually empty.
+	showMaintenance := false
+	for _, c := range set.Controllers {
+		if c.Maintenance != nil {
+			showMaintenance = true
+			break
+		}
+	}
+	if showMaintenance {
+		print("CONTROLLER", "MODEL", "USER", "CLOUD/REGION", "MAINTENANCE")
+	} else {
+		print("CONTROLLER", "MODEL", "USER", "CLOUD/REGION")
+	}
 
 	names := []string{}
 	for name, _ := range set.Controllers {
@@ -66,7 +79,18 @@ func formatControllersTabular(set ControllerSet) ([]byte, error) {
 		if c.CloudRegion != "" {
 			cloudRegion += "/" + c.CloudRegion
 		}
-		print(name, modelName, userName, cloudRegion)
+		if !showMaintenance {
+			print(name, modelName, userName, cloudRegion)
+			continue
+		}
+		maintenance := noValueDisplay
+		if c.Maintenance != nil {
+			maintenance = "enabled"
+			if c.Maintenance.Reason != "" {
+				maintenance = c.Maintenance.Reason
+			}
+		}
+		print(name, modelName, userName, cloudRegion, maintenance)
 	}
 	tw.Flush()
 
@@ -0,0 +1,238 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// MaintenanceItem defines the serialization behaviour of a controller's
+// maintenance mode.
+type MaintenanceItem struct {
+	Reason  string     `yaml:"reason,omitempty" json:"reason,omitempty"`
+	Owner   string     `yaml:"owner" json:"owner"`
+	Started time.Time  `yaml:"started" json:"started"`
+	Expires *time.Time `yaml:"expires,omitempty" json:"expires,omitempty"`
+}
+
+// convertMaintenanceDetails returns the serializable form of the cached
+// maintenance mode, or nil if the controller was not in maintenance
+// mode or its maintenance mode has since expired.
+func convertMaintenanceDetails(details *jujuclient.MaintenanceDetails, now time.Time) *MaintenanceItem {
+	if details == nil {
+		return nil
+	}
+	if details.Expires != nil && !details.Expires.After(now) {
+		return nil
+	}
+	return &MaintenanceItem{
+		Reason:  details.Reason,
+		Owner:   details.Owner,
+		Started: details.Started,
+		Expires: details.Expires,
+	}
+}
+
+// NewEnableMaintenanceCommand returns a command that puts the controller
+// into maintenance mode.
+func NewEnableMaintenanceCommand() cmd.Command {
+	return modelcmd.WrapController(&enableMaintenanceCommand{
+		clock: clock.WallClock,
+	})
+}
+
+// NewDisableMaintenanceCommand returns a command that takes the
+// controller out of maintenance mode.
+func NewDisableMaintenanceCommand() cmd.Command {
+	return modelcmd.WrapController(&disableMaintenanceCommand{})
+}
+
+type maintenanceAPI interface {
+	Close() error
+	EnableMaintenance(reason string, expires time.Time) error
+	DisableMaintenance() error
+}
+
+type maintenanceCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api maintenanceAPI
+}
+
+func (c *maintenanceCommandBase) getAPI() (maintenanceAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// cacheMaintenance records the controller's maintenance mode in the
+// client store, so that it may be reported without connecting. Failure
+// to do so is not fatal, as it is refreshed on the next connection.
+func (c *maintenanceCommandBase) cacheMaintenance(maintenance *jujuclient.MaintenanceDetails) {
+	store := c.ClientStore()
+	controllerName := c.ControllerName()
+	details, err := store.ControllerByName(controllerName)
+	if err == nil {
+		details.Maintenance = maintenance
+		err = store.UpdateController(controllerName, *details)
+	}
+	if err != nil {
+		logger.Warningf("cannot cache controller maintenance mode: %v", err)
+	}
+}
+
+type enableMaintenanceCommand struct {
+	maintenanceCommandBase
+	clock   clock.Clock
+	reason  string
+	expires string
+}
+
+const enableMaintenanceDoc = `
+Puts the controller into maintenance mode.
+
+While the controller is in maintenance mode, users of every model may
+only make calls that do not change anything; all others are rejected
+with an error giving the reason for the maintenance. Agents are not
+affected and keep running.
+
+Maintenance mode lasts until it is disabled with disable-maintenance or,
+if --expires is specified, until the given time. The expiry may be a
+duration from now, such as "2h", or a time in RFC3339 format. Enabling
+maintenance mode again replaces its reason and expiry.
+
+Whether the controller is in maintenance mode is shown by
+"juju controllers" and "juju show-controller".
+
+Examples:
+    juju enable-maintenance --reason "upgrading mongo"
+    juju enable-maintenance --expires 2h
+    juju enable-maintenance --expires 2016-09-01T18:00:00Z
+
+See also:
+    disable-maintenance
+    controllers
+    show-controller
+`
+
+// Info implements Command.Info
+func (c *enableMaintenanceCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "enable-maintenance",
+		Purpose: "Puts the controller into maintenance mode.",
+		Doc:     enableMaintenanceDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *enableMaintenanceCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.reason, "reason", "", "Why the controller is in maintenance mode")
+	f.StringVar(&c.expires, "expires", "", "When maintenance mode ends, as a duration or RFC3339 time")
+}
+
+// Init implements Command.Init.
+func (c *enableMaintenanceCommand) Init(args []string) error {
+	if c.expires != "" {
+		if _, err := c.expiryTime(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// expiryTime returns the time at which maintenance mode should end, or
+// the zero time if it should not expire.
+func (c *enableMaintenanceCommand) expiryTime() (time.Time, error) {
+	if c.expires == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(c.expires); err == nil {
+		if d <= 0 {
+			return time.Time{}, errors.Errorf("expiry duration %q must be positive", c.expires)
+		}
+		return c.clock.Now().Add(d), nil
+	}
+	t, err := time.Parse(time.RFC3339, c.expires)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid expiry %q: expected a duration or RFC3339 time", c.expires)
+	}
+	return t, nil
+}
+
+// Run implements Command.Run
+func (c *enableMaintenanceCommand) Run(ctx *cmd.Context) error {
+	expires, err := c.expiryTime()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if err := client.EnableMaintenance(c.reason, expires); err != nil {
+		return errors.Annotate(err, "cannot enable maintenance mode")
+	}
+
+	maintenance := &jujuclient.MaintenanceDetails{
+		Reason:  c.reason,
+		Started: c.clock.Now().UTC(),
+	}
+	if account, err := c.ClientStore().AccountByName(c.ControllerName(), c.AccountName()); err == nil {
+		maintenance.Owner = account.User
+	}
+	if !expires.IsZero() {
+		expires = expires.UTC()
+		maintenance.Expires = &expires
+	}
+	c.cacheMaintenance(maintenance)
+	return nil
+}
+
+type disableMaintenanceCommand struct {
+	maintenanceCommandBase
+}
+
+const disableMaintenanceDoc = `
+Takes the controller out of maintenance mode, so that users may once
+again make changes to its models. It is not an error if the controller
+is not in maintenance mode.
+
+Examples:
+    juju disable-maintenance
+
+See also:
+    enable-maintenance
+`
+
+// Info implements Command.Info
+func (c *disableMaintenanceCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "disable-maintenance",
+		Purpose: "Takes the controller out of maintenance mode.",
+		Doc:     disableMaintenanceDoc,
+	}
+}
+
+// Run implements Command.Run
+func (c *disableMaintenanceCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if err := client.DisableMaintenance(); err != nil {
+		return errors.Annotate(err, "cannot disable maintenance mode")
+	}
+	c.cacheMaintenance(nil)
+	return nil
+}
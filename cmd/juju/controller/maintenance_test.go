@@ -0,0 +1,142 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type maintenanceSuite struct {
+	baseControllerSuite
+	api   *fakeMaintenanceAPI
+	store *jujuclienttesting.MemStore
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&maintenanceSuite{})
+
+func (s *maintenanceSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeMaintenanceAPI{}
+	s.clock = testing.NewClock(time.Date(2016, 9, 1, 12, 0, 0, 0, time.UTC))
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{
+		ControllerUUID: "fake-uuid",
+		CACert:         "fake-cert",
+	}
+	s.store.Accounts["fake"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"admin@local": {User: "admin@local"},
+		},
+		CurrentAccount: "admin@local",
+	}
+}
+
+func (s *maintenanceSuite) runEnable(c *gc.C, args ...string) error {
+	cmd := controller.NewEnableMaintenanceCommandForTest(s.api, s.store, s.clock)
+	_, err := testing.RunCommand(c, cmd, args...)
+	return err
+}
+
+func (s *maintenanceSuite) runDisable(c *gc.C, args ...string) error {
+	cmd := controller.NewDisableMaintenanceCommandForTest(s.api, s.store)
+	_, err := testing.RunCommand(c, cmd, args...)
+	return err
+}
+
+func (s *maintenanceSuite) TestEnable(c *gc.C) {
+	err := s.runEnable(c, "--reason", "upgrading mongo")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "EnableMaintenance", "upgrading mongo", time.Time{})
+	c.Assert(s.store.Controllers["fake"].Maintenance, jc.DeepEquals, &jujuclient.MaintenanceDetails{
+		Reason:  "upgrading mongo",
+		Owner:   "admin@local",
+		Started: s.clock.Now(),
+	})
+}
+
+func (s *maintenanceSuite) TestEnableExpiresDuration(c *gc.C) {
+	err := s.runEnable(c, "--expires", "2h")
+	c.Assert(err, jc.ErrorIsNil)
+	expires := s.clock.Now().Add(2 * time.Hour)
+	s.api.CheckCall(c, 0, "EnableMaintenance", "", expires)
+	c.Assert(s.store.Controllers["fake"].Maintenance.Expires, jc.DeepEquals, &expires)
+}
+
+func (s *maintenanceSuite) TestEnableExpiresTime(c *gc.C) {
+	err := s.runEnable(c, "--expires", "2016-09-01T18:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "EnableMaintenance", "", time.Date(2016, 9, 1, 18, 0, 0, 0, time.UTC))
+}
+
+func (s *maintenanceSuite) TestEnableInvalidExpiry(c *gc.C) {
+	err := s.runEnable(c, "--expires", "soon")
+	c.Assert(err, gc.ErrorMatches, `invalid expiry "soon": expected a duration or RFC3339 time`)
+	err = s.runEnable(c, "--expires", "-1h")
+	c.Assert(err, gc.ErrorMatches, `expiry duration "-1h" must be positive`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *maintenanceSuite) TestEnableUnrecognizedArg(c *gc.C) {
+	err := s.runEnable(c, "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *maintenanceSuite) TestEnableError(c *gc.C) {
+	s.api.SetErrors(common.ErrPerm)
+	err := s.runEnable(c)
+	c.Assert(err, gc.ErrorMatches, "cannot enable maintenance mode: permission denied")
+	c.Assert(s.store.Controllers["fake"].Maintenance, gc.IsNil)
+}
+
+func (s *maintenanceSuite) TestDisable(c *gc.C) {
+	details := s.store.Controllers["fake"]
+	details.Maintenance = &jujuclient.MaintenanceDetails{Owner: "admin@local"}
+	s.store.Controllers["fake"] = details
+
+	err := s.runDisable(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "DisableMaintenance", "Close")
+	c.Assert(s.store.Controllers["fake"].Maintenance, gc.IsNil)
+}
+
+func (s *maintenanceSuite) TestDisableError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	err := s.runDisable(c)
+	c.Assert(err, gc.ErrorMatches, "cannot disable maintenance mode: boom")
+}
+
+type fakeMaintenanceAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeMaintenanceAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeMaintenanceAPI) EnableMaintenance(reason string, expires time.Time) error {
+	f.MethodCall(f, "EnableMaintenance", reason, expires)
+	return f.NextErr()
+}
+
+func (f *fakeMaintenanceAPI) DisableMaintenance() error {
+	f.MethodCall(f, "DisableMaintenance")
+	return f.NextErr()
+}
@@ -5,11 +5,15 @@ package controller
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/jujuclient"
@@ -22,9 +26,15 @@ var usageShowControllerDetails = `
 Shows extended information about a controller(s) as well as related models
 and accounts. The active model and user accounts are also displayed.
 
+With --replica-set, the controller is contacted and the health of each
+member of its database replica set is shown: its state (for example
+PRIMARY or SECONDARY), whether it is healthy, its number of votes, and
+how far it lags behind the primary.
+
 Examples:
     juju show-controller
     juju show-controller aws google
+    juju show-controller --replica-set
     
 See also: 
     controllers`[1:]
@@ -34,6 +44,7 @@ func NewShowControllerCommand() cmd.Command {
 	cmd := &showControllerCommand{
 		store: jujuclient.NewFileClientStore(),
 	}
+	cmd.replicaSetAPI = cmd.newReplicaSetAPI
 	return modelcmd.WrapBase(cmd)
 }
 
@@ -58,6 +69,7 @@ func (c *showControllerCommand) Info() *cmd.Info {
 func (c *showControllerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.JujuCommandBase.SetFlags(f)
 	f.BoolVar(&c.showPasswords, "show-passwords", false, "Show passwords for displayed accounts")
+	f.BoolVar(&c.showReplicaSet, "replica-set", false, "Show the health of the controller's database replica set")
 	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
@@ -101,6 +113,10 @@ type ShowControllerDetails struct {
 	// This is only available on the client that bootstrapped the controller.
 	BootstrapConfig *BootstrapConfig `yaml:"bootstrap-config,omitempty" json:"bootstrap-config,omitempty"`
 
+	// ReplicaSet holds the health of each member of the controller's
+	// database replica set. It is only filled in on request.
+	ReplicaSet []ReplicaSetMember `yaml:"replica-set,omitempty" json:"replica-set,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -121,6 +137,10 @@ type ControllerDetails struct {
 
 	// CloudRegion is the name of the cloud region that this controller runs in.
 	CloudRegion string `yaml:"region,omitempty" json:"region,omitempty"`
+
+	// Maintenance holds the controller's maintenance mode as last seen
+	// by this client, if it is in maintenance mode.
+	Maintenance *MaintenanceItem `yaml:"maintenance,omitempty" json:"maintenance,omitempty"`
 }
 
 // ModelDetails holds details of a model to show.
@@ -144,6 +164,17 @@ type AccountDetails struct {
 	CurrentModel string `yaml:"current-model,omitempty" json:"current-model,omitempty"`
 }
 
+// ReplicaSetMember holds the health of a member of a controller's
+// database replica set.
+type ReplicaSetMember struct {
+	Machine string `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Address string `yaml:"address" json:"address"`
+	State   string `yaml:"state" json:"state"`
+	Healthy bool   `yaml:"healthy" json:"healthy"`
+	Votes   int    `yaml:"votes" json:"votes"`
+	Lag     string `yaml:"lag,omitempty" json:"lag,omitempty"`
+}
+
 // BootstrapConfig holds the configuration used to bootstrap a controller.
 type BootstrapConfig struct {
 	Config               map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
@@ -163,10 +194,14 @@ func (c *showControllerCommand) convertControllerForShow(controllerName string,
 			CACert:         details.CACert,
 			Cloud:          details.Cloud,
 			CloudRegion:    details.CloudRegion,
+			Maintenance:    convertMaintenanceDetails(details.Maintenance, time.Now()),
 		},
 	}
 	c.convertAccountsForShow(controllerName, &controller)
 	c.convertBootstrapConfigForShow(controllerName, &controller)
+	if c.showReplicaSet {
+		c.convertReplicaSetForShow(controllerName, &controller)
+	}
 	return controller
 }
 
@@ -249,12 +284,63 @@ func (c *showControllerCommand) convertBootstrapConfigForShow(controllerName str
 	}
 }
 
+func (c *showControllerCommand) convertReplicaSetForShow(controllerName string, details *ShowControllerDetails) {
+	api, err := c.replicaSetAPI(controllerName)
+	if err != nil {
+		details.Errors = append(details.Errors, err.Error())
+		return
+	}
+	defer api.Close()
+	members, err := api.ReplicaSetStatus()
+	if err != nil {
+		details.Errors = append(details.Errors, err.Error())
+		return
+	}
+	details.ReplicaSet = make([]ReplicaSetMember, len(members))
+	for i, m := range members {
+		member := ReplicaSetMember{
+			Address: m.Address,
+			State:   m.State,
+			Healthy: m.Healthy,
+			Votes:   m.Votes,
+		}
+		if tag, err := names.ParseMachineTag(m.MachineTag); err == nil {
+			member.Machine = tag.Id()
+		}
+		if m.Lag > 0 {
+			member.Lag = m.Lag.String()
+		}
+		details.ReplicaSet[i] = member
+	}
+}
+
+// ReplicaSetAPI defines the API methods used to show the health of a
+// controller's database replica set.
+type ReplicaSetAPI interface {
+	ReplicaSetStatus() ([]params.ReplicaSetMember, error)
+	Close() error
+}
+
+func (c *showControllerCommand) newReplicaSetAPI(controllerName string) (ReplicaSetAPI, error) {
+	accountName, err := c.store.CurrentAccount(controllerName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	root, err := c.NewAPIRoot(c.store, controllerName, accountName, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(root), nil
+}
+
 type showControllerCommand struct {
 	modelcmd.JujuCommandBase
 
-	out   cmd.Output
-	store jujuclient.ClientStore
+	out           cmd.Output
+	store         jujuclient.ClientStore
+	replicaSetAPI func(controllerName string) (ReplicaSetAPI, error)
 
 	controllerNames []string
 	showPasswords   bool
+	showReplicaSet  bool
 }
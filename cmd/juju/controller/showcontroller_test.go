@@ -5,12 +5,14 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -59,6 +61,130 @@ mallards:
 	s.assertShowController(c, "mallards")
 }
 
+func (s *ShowControllerSuite) TestShowControllerMaintenance(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+    maintenance:
+      reason: upgrading mongo
+      owner: admin@local
+      started: 2016-09-01T12:00:00Z
+`
+	s.createTestClientStore(c)
+
+	s.expectedOutput = `
+mallards:
+  details:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+    maintenance:
+      reason: upgrading mongo
+      owner: admin@local
+      started: 2016-09-01T12:00:00Z
+  accounts:
+    admin@local:
+      user: admin@local
+      models:
+        admin:
+          uuid: abc
+        my-model:
+          uuid: def
+      current-model: my-model
+    bob@local:
+      user: bob@local
+    bob@remote:
+      user: bob@remote
+  current-account: admin@local
+`[1:]
+
+	s.assertShowController(c, "mallards")
+}
+
+func (s *ShowControllerSuite) TestShowControllerReplicaSet(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+`
+	s.createTestClientStore(c)
+	api := &fakeReplicaSetAPI{
+		members: []params.ReplicaSetMember{{
+			MachineTag: "machine-0",
+			Address:    "10.0.0.1:37017",
+			State:      "PRIMARY",
+			Healthy:    true,
+			Votes:      1,
+		}, {
+			MachineTag: "machine-1",
+			Address:    "10.0.0.2:37017",
+			State:      "SECONDARY",
+			Healthy:    true,
+			Votes:      0,
+			Lag:        3 * time.Second,
+		}},
+	}
+	command := controller.NewShowControllerCommandWithAPIForTest(s.store, api, nil)
+	context, err := testing.RunCommand(c, command, "mallards", "--replica-set")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, `
+mallards:
+  details:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+  accounts:
+    admin@local:
+      user: admin@local
+      models:
+        admin:
+          uuid: abc
+        my-model:
+          uuid: def
+      current-model: my-model
+    bob@local:
+      user: bob@local
+    bob@remote:
+      user: bob@remote
+  current-account: admin@local
+  replica-set:
+  - machine: "0"
+    address: 10.0.0.1:37017
+    state: PRIMARY
+    healthy: true
+    votes: 1
+  - machine: "1"
+    address: 10.0.0.2:37017
+    state: SECONDARY
+    healthy: true
+    votes: 0
+    lag: 3s
+`[1:])
+	c.Assert(api.closed, jc.IsTrue)
+}
+
+func (s *ShowControllerSuite) TestShowControllerReplicaSetError(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+`
+	s.createTestClientStore(c)
+	command := controller.NewShowControllerCommandWithAPIForTest(s.store, nil, errors.New("connection refused"))
+	context, err := testing.RunCommand(c, command, "mallards", "--replica-set", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), jc.Contains, `"errors":["connection refused"]`)
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithPasswords(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -286,3 +412,17 @@ func (s *ShowControllerSuite) assertShowController(c *gc.C, args ...string) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(testing.Stdout(context), gc.Equals, s.expectedOutput)
 }
+
+type fakeReplicaSetAPI struct {
+	members []params.ReplicaSetMember
+	closed  bool
+}
+
+func (f *fakeReplicaSetAPI) ReplicaSetStatus() ([]params.ReplicaSetMember, error) {
+	return f.members, nil
+}
+
+func (f *fakeReplicaSetAPI) Close() error {
+	f.closed = true
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageChangeOwnerSummary = `
+Gives ownership of a model to another user.`[1:]
+
+var usageChangeOwnerDetails = `
+By default, the controller is the current controller.
+
+The new owner is given write access to the model, and the model is
+listed under their name. The previous owner keeps their existing
+access to the model, which may be removed with ` + "`juju revoke`" + `. Any
+cloud credential used by the model remains with the previous owner.
+
+Only controller administrators and the current owner of a model may
+change its owner. The owner of the controller model cannot be changed.
+
+Examples:
+Give ownership of model 'mymodel' to user 'joe':
+
+    juju change-model-owner mymodel joe
+
+See also:
+    models
+    revoke
+    remove-user`[1:]
+
+// NewChangeOwnerCommand returns a command used to change the owner
+// of a model.
+func NewChangeOwnerCommand() cmd.Command {
+	return modelcmd.WrapController(&changeOwnerCommand{})
+}
+
+// changeOwnerCommand gives ownership of a model to another user.
+type changeOwnerCommand struct {
+	modelcmd.ControllerCommandBase
+	api ChangeOwnerAPI
+
+	ModelName string
+	Owner     string
+}
+
+// ChangeOwnerAPI defines the API functions used by the
+// change-model-owner command.
+type ChangeOwnerAPI interface {
+	Close() error
+	ChangeModelOwner(modelUUID, owner string) error
+}
+
+// Info implements Command.Info.
+func (c *changeOwnerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "change-model-owner",
+		Args:    "<model name> <user name>",
+		Purpose: usageChangeOwnerSummary,
+		Doc:     usageChangeOwnerDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *changeOwnerCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no model specified")
+	case 1:
+		return errors.New("no user specified")
+	}
+	c.ModelName = args[0]
+	c.Owner = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *changeOwnerCommand) getAPI() (ChangeOwnerAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *changeOwnerCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	modelUUIDs, err := c.ModelUUIDs([]string{c.ModelName})
+	if err != nil {
+		return err
+	}
+	if err := client.ChangeModelOwner(modelUUIDs[0], c.Owner); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Model %q is now owned by %q", c.ModelName, c.Owner)
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type changeOwnerSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeChangeOwnerAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&changeOwnerSuite{})
+
+func (s *changeOwnerSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeChangeOwnerAPI{}
+
+	controllerName := "test-master"
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = controllerName
+	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{}
+	s.store.Accounts[controllerName] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"bob@local": {User: "bob@local"},
+		},
+		CurrentAccount: "bob@local",
+	}
+	s.store.Models = map[string]jujuclient.ControllerAccountModels{
+		controllerName: {
+			AccountModels: map[string]*jujuclient.AccountModels{
+				"bob@local": {
+					Models: map[string]jujuclient.ModelDetails{
+						"foo": {ModelUUID: fooModelUUID},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *changeOwnerSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command, _ := model.NewChangeOwnerCommandForTest(s.fake, s.store)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *changeOwnerSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		errMatch: "no model specified",
+	}, {
+		args:     []string{"foo"},
+		errMatch: "no user specified",
+	}, {
+		args:     []string{"foo", "sam", "extra"},
+		errMatch: `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"foo", "sam"},
+	}} {
+		c.Logf("test %d, args %v", i, test.args)
+		wrappedCommand, command := model.NewChangeOwnerCommandForTest(s.fake, s.store)
+		err := testing.InitCommand(wrappedCommand, test.args)
+		if test.errMatch != "" {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(command.ModelName, gc.Equals, "foo")
+		c.Check(command.Owner, gc.Equals, "sam")
+	}
+}
+
+func (s *changeOwnerSuite) TestChangeOwner(c *gc.C) {
+	ctx, err := s.run(c, "foo", "sam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.modelUUID, gc.Equals, fooModelUUID)
+	c.Assert(s.fake.owner, gc.Equals, "sam")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Model \"foo\" is now owned by \"sam\"\n")
+}
+
+func (s *changeOwnerSuite) TestChangeOwnerFails(c *gc.C) {
+	s.fake.err = errors.New("permission denied")
+	_, err := s.run(c, "foo", "sam")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *changeOwnerSuite) TestBlockChangeOwner(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockChangeOwner")
+	_, err := s.run(c, "foo", "sam")
+	c.Assert(err, gc.ErrorMatches, cmd.ErrSilent.Error())
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockChangeOwner")
+}
+
+type fakeChangeOwnerAPI struct {
+	modelUUID string
+	owner     string
+	err       error
+}
+
+func (f *fakeChangeOwnerAPI) Close() error {
+	return nil
+}
+
+func (f *fakeChangeOwnerAPI) ChangeModelOwner(modelUUID, owner string) error {
+	f.modelUUID = modelUUID
+	f.owner = owner
+	return f.err
+}
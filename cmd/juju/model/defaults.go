@@ -0,0 +1,200 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
+)
+
+// NewModelDefaultsCommand returns a command to display the model
+// defaults of the controller.
+func NewModelDefaultsCommand() cmd.Command {
+	return modelcmd.WrapController(&modelDefaultsCommand{})
+}
+
+type modelDefaultsCommand struct {
+	modelcmd.ControllerCommandBase
+	api ModelDefaultsAPI
+	key string
+	out cmd.Output
+}
+
+const modelDefaultsHelpDoc = `
+New models start from, and keep inheriting, model defaults set on the
+controller. Defaults for a cloud region take precedence over the
+controller-wide defaults, which in turn take precedence over the Juju
+defaults. A model inherits a default for any key it does not set itself.
+
+By default, the defaults of all keys are displayed. If a key is
+specified, only its defaults are displayed.
+
+Examples:
+
+    juju model-defaults
+    juju model-defaults http-proxy
+
+See also: get-model-config
+          set-model-defaults
+          unset-model-defaults
+`
+
+func (c *modelDefaultsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "model-defaults",
+		Args:    "[<model key>]",
+		Purpose: "Displays the default configuration settings for models.",
+		Doc:     strings.TrimSpace(modelDefaultsHelpDoc),
+	}
+}
+
+func (c *modelDefaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatDefaultsTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+func (c *modelDefaultsCommand) Init(args []string) (err error) {
+	c.key, err = cmd.ZeroOrOneArgs(args)
+	return
+}
+
+// ModelDefaultsAPI defines the API methods used by the model-defaults
+// command.
+type ModelDefaultsAPI interface {
+	Close() error
+	ModelDefaults() (config.ModelDefaultAttributes, error)
+}
+
+func (c *modelDefaultsCommand) getAPI() (ModelDefaultsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// defaultValues is the serialisation format of the defaults of a
+// model config key.
+type defaultValues struct {
+	Default    interface{}          `json:"default,omitempty" yaml:"default,omitempty"`
+	Controller interface{}          `json:"controller,omitempty" yaml:"controller,omitempty"`
+	Regions    []regionDefaultValue `json:"regions,omitempty" yaml:"regions,omitempty"`
+}
+
+// regionDefaultValue is the serialisation format of the default of a
+// model config key in a cloud region.
+type regionDefaultValue struct {
+	Cloud  string      `json:"cloud" yaml:"cloud"`
+	Region string      `json:"region" yaml:"region"`
+	Value  interface{} `json:"value" yaml:"value"`
+}
+
+func (c *modelDefaultsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	defaults, err := client.ModelDefaults()
+	if err != nil {
+		return err
+	}
+	result := make(map[string]defaultValues)
+	for attr, values := range defaults {
+		if c.key != "" && attr != c.key {
+			continue
+		}
+		value := defaultValues{
+			Default:    values.Default,
+			Controller: values.Controller,
+		}
+		for _, region := range values.Regions {
+			value.Regions = append(value.Regions, regionDefaultValue{
+				Cloud:  region.CloudName,
+				Region: region.Name,
+				Value:  region.Value,
+			})
+		}
+		result[attr] = value
+	}
+	if c.key != "" && len(result) == 0 {
+		return errors.Errorf("key %q has no defaults", c.key)
+	}
+	return c.out.Write(ctx, result)
+}
+
+// formatDefaultsTabular returns a tabular summary of the model
+// defaults, with a row for each level at which a key has a default.
+func formatDefaultsTabular(value interface{}) ([]byte, error) {
+	defaults, ok := value.(map[string]defaultValues)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", defaults, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	p := func(values ...string) {
+		text := strings.Join(values, "\t")
+		fmt.Fprintln(tw, text)
+	}
+	p("ATTRIBUTE\tFROM\tVALUE")
+
+	var attrs []string
+	for attr := range defaults {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+	for _, attr := range attrs {
+		values := defaults[attr]
+		if values.Default != nil {
+			p(attr, config.JujuDefaultSource, fmt.Sprint(values.Default))
+		}
+		if values.Controller != nil {
+			p(attr, config.JujuControllerSource, fmt.Sprint(values.Controller))
+		}
+		for _, region := range values.Regions {
+			p(attr, region.Cloud+"/"+region.Region, fmt.Sprint(region.Value))
+		}
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+// parseRegion parses a region specified as "[<cloud>/]<region>". If
+// the cloud is omitted, the controller's cloud is assumed.
+func parseRegion(s string) (cloudName, regionName string, err error) {
+	if i := strings.Index(s, "/"); i >= 0 {
+		cloudName, regionName = s[:i], s[i+1:]
+		if cloudName == "" {
+			return "", "", errors.NotValidf("empty cloud name in region %q", s)
+		}
+	} else {
+		regionName = s
+	}
+	if regionName == "" {
+		return "", "", errors.NotValidf("empty region name in region %q", s)
+	}
+	return cloudName, regionName, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type fakeModelDefaultsAPI struct {
+	gitjujutesting.Stub
+	defaults config.ModelDefaultAttributes
+}
+
+func (f *fakeModelDefaultsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelDefaultsAPI) ModelDefaults() (config.ModelDefaultAttributes, error) {
+	f.MethodCall(f, "ModelDefaults")
+	return f.defaults, f.NextErr()
+}
+
+func (f *fakeModelDefaultsAPI) SetModelDefaults(cloudName, cloudRegion string, attrs map[string]interface{}) error {
+	f.MethodCall(f, "SetModelDefaults", cloudName, cloudRegion, attrs)
+	return f.NextErr()
+}
+
+func (f *fakeModelDefaultsAPI) UnsetModelDefaults(cloudName, cloudRegion string, keys ...string) error {
+	f.MethodCall(f, "UnsetModelDefaults", cloudName, cloudRegion, keys)
+	return f.NextErr()
+}
+
+type DefaultsCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeModelDefaultsAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&DefaultsCommandSuite{})
+
+func (s *DefaultsCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeModelDefaultsAPI{
+		defaults: config.ModelDefaultAttributes{
+			"attr": {Default: "foo"},
+			"attr2": {
+				Controller: "bar",
+				Regions: []config.RegionDefaultValue{{
+					CloudName: "dummy",
+					Name:      "dummy-region",
+					Value:     "dummy-value",
+				}},
+			},
+		},
+	}
+	controllerName := "test-master"
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = controllerName
+	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{}
+	s.store.Accounts[controllerName] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"bob@local": {User: "bob@local"},
+		},
+		CurrentAccount: "bob@local",
+	}
+}
+
+func (s *DefaultsCommandSuite) run(c *gc.C, command cmd.Command, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *DefaultsCommandSuite) TestModelDefaultsInitArgCount(c *gc.C) {
+	_, err := s.run(c, model.NewModelDefaultsCommandForTest(s.fake, s.store), "one", "two")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *DefaultsCommandSuite) TestModelDefaultsTabular(c *gc.C) {
+	ctx, err := s.run(c, model.NewModelDefaultsCommandForTest(s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"ATTRIBUTE  FROM                VALUE\n"+
+		"attr       default             foo\n"+
+		"attr2      controller          bar\n"+
+		"attr2      dummy/dummy-region  dummy-value\n")
+	s.fake.CheckCallNames(c, "ModelDefaults", "Close")
+}
+
+func (s *DefaultsCommandSuite) TestModelDefaultsSingleKeyYAML(c *gc.C) {
+	ctx, err := s.run(c, model.NewModelDefaultsCommandForTest(s.fake, s.store), "attr2", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"attr2:\n"+
+		"  controller: bar\n"+
+		"  regions:\n"+
+		"  - cloud: dummy\n"+
+		"    region: dummy-region\n"+
+		"    value: dummy-value\n")
+}
+
+func (s *DefaultsCommandSuite) TestModelDefaultsUnknownKey(c *gc.C) {
+	_, err := s.run(c, model.NewModelDefaultsCommandForTest(s.fake, s.store), "missing")
+	c.Assert(err, gc.ErrorMatches, `key "missing" has no defaults`)
+}
+
+func (s *DefaultsCommandSuite) TestSetModelDefaultsInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no key, value pairs specified",
+	}, {
+		args: []string{"special"},
+		err:  `expected "key=value", got "special"`,
+	}, {
+		args: []string{"--region", "dummy/", "attr=value"},
+		err:  `empty region name in region "dummy/" not valid`,
+	}, {
+		args: []string{"--region", "/dummy-region", "attr=value"},
+		err:  `empty cloud name in region "/dummy-region" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, model.NewSetModelDefaultsCommandForTest(s.fake, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *DefaultsCommandSuite) TestSetModelDefaults(c *gc.C) {
+	_, err := s.run(c, model.NewSetModelDefaultsCommandForTest(s.fake, s.store), "attr=value")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{FuncName: "SetModelDefaults", Args: []interface{}{"", "", map[string]interface{}{"attr": "value"}}},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *DefaultsCommandSuite) TestSetModelDefaultsRegion(c *gc.C) {
+	_, err := s.run(c, model.NewSetModelDefaultsCommandForTest(s.fake, s.store), "--region", "dummy/dummy-region", "attr=value")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetModelDefaults", "dummy", "dummy-region", map[string]interface{}{"attr": "value"})
+}
+
+func (s *DefaultsCommandSuite) TestUnsetModelDefaultsNoKeys(c *gc.C) {
+	_, err := s.run(c, model.NewUnsetModelDefaultsCommandForTest(s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "no keys specified")
+}
+
+func (s *DefaultsCommandSuite) TestUnsetModelDefaultsRegion(c *gc.C) {
+	_, err := s.run(c, model.NewUnsetModelDefaultsCommandForTest(s.fake, s.store), "--region", "dummy-region", "attr", "attr2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{FuncName: "UnsetModelDefaults", Args: []interface{}{"", "dummy-region", []string{"attr", "attr2"}}},
+		{FuncName: "Close"},
+	})
+}
@@ -94,3 +94,43 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+type ChangeOwnerCommand struct {
+	*changeOwnerCommand
+}
+
+// NewChangeOwnerCommandForTest returns a ChangeOwnerCommand with the api provided as specified.
+func NewChangeOwnerCommandForTest(api ChangeOwnerAPI, store jujuclient.ClientStore) (cmd.Command, *ChangeOwnerCommand) {
+	cmd := &changeOwnerCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd), &ChangeOwnerCommand{cmd}
+}
+
+// NewModelDefaultsCommandForTest returns a modelDefaultsCommand with the api provided as specified.
+func NewModelDefaultsCommandForTest(api ModelDefaultsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &modelDefaultsCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewSetModelDefaultsCommandForTest returns a setModelDefaultsCommand with the api provided as specified.
+func NewSetModelDefaultsCommandForTest(api SetModelDefaultsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &setModelDefaultsCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewUnsetModelDefaultsCommandForTest returns an unsetModelDefaultsCommand with the api provided as specified.
+func NewUnsetModelDefaultsCommandForTest(api UnsetModelDefaultsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &unsetModelDefaultsCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
@@ -6,6 +6,7 @@ package model_test
 import (
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
 )
 
@@ -39,6 +40,21 @@ func (f *fakeEnvAPI) ModelGet() (map[string]interface{}, error) {
 	return f.values, nil
 }
 
+func (f *fakeEnvAPI) ModelConfigValues() (config.ConfigValues, error) {
+	result := make(config.ConfigValues)
+	for attr, value := range f.values {
+		source := config.JujuModelConfigSource
+		if attr == "running" {
+			source = config.JujuDefaultSource
+		}
+		result[attr] = config.ConfigValue{
+			Value:  value,
+			Source: source,
+		}
+	}
+	return result, nil
+}
+
 func (f *fakeEnvAPI) ModelSet(config map[string]interface{}) error {
 	f.values = config
 	return f.err
@@ -4,13 +4,17 @@
 package model
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/juju/cmd"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
 )
 
 func NewGetCommand() cmd.Command {
@@ -27,16 +31,25 @@ type getCommand struct {
 }
 
 const getModelHelpDoc = `
-By default, all configuration (keys and values) for the model are
-displayed if a key is not specified.
+By default, all configuration (keys, values and the source of each value)
+for the model are displayed if a key is not specified. The source of a
+value is one of:
+
+    default     the Juju default
+    controller  the controller-wide model default
+    region      the model default for the model's cloud region
+    model       set on the model itself
+
 By default, the model is the current model.
 
 Examples:
 
     juju get-model-config default-series
     juju get-model-config -m mymodel type
+    juju get-model-config --format yaml
 
 See also: models
+          model-defaults
           set-model-config
           unset-model-config
 `
@@ -51,7 +64,12 @@ func (c *getCommand) Info() *cmd.Info {
 }
 
 func (c *getCommand) SetFlags(f *gnuflag.FlagSet) {
-	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"smart":   cmd.FormatSmart,
+		"tabular": formatConfigTabular,
+		"yaml":    cmd.FormatYaml,
+	})
 }
 
 func (c *getCommand) Init(args []string) (err error) {
@@ -61,7 +79,7 @@ func (c *getCommand) Init(args []string) (err error) {
 
 type GetEnvironmentAPI interface {
 	Close() error
-	ModelGet() (map[string]interface{}, error)
+	ModelConfigValues() (config.ConfigValues, error)
 }
 
 func (c *getCommand) getAPI() (GetEnvironmentAPI, error) {
@@ -71,6 +89,13 @@ func (c *getCommand) getAPI() (GetEnvironmentAPI, error) {
 	return c.NewAPIClient()
 }
 
+// configValue is the serialisation format of a model config value
+// and its source.
+type configValue struct {
+	Value  interface{} `json:"value" yaml:"value"`
+	Source string      `json:"source" yaml:"source"`
+}
+
 func (c *getCommand) Run(ctx *cmd.Context) error {
 	client, err := c.getAPI()
 	if err != nil {
@@ -78,17 +103,69 @@ func (c *getCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	attrs, err := client.ModelGet()
+	values, err := client.ModelConfigValues()
 	if err != nil {
 		return err
 	}
 
 	if c.key != "" {
-		if value, found := attrs[c.key]; found {
-			return c.out.Write(ctx, value)
+		if value, found := values[c.key]; found {
+			return c.out.Write(ctx, value.Value)
 		}
-		return fmt.Errorf("key %q not found in %q model.", c.key, attrs["name"])
+		return fmt.Errorf("key %q not found in %q model.", c.key, values["name"].Value)
 	}
 	// If key is empty, write out the whole lot.
-	return c.out.Write(ctx, attrs)
+	result := make(map[string]configValue)
+	for attr, value := range values {
+		result[attr] = configValue{
+			Value:  value.Value,
+			Source: value.Source,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+// formatConfigTabular returns a tabular summary of the model config
+// values and their sources. Single values are formatted as they are
+// with the smart formatter.
+func formatConfigTabular(value interface{}) ([]byte, error) {
+	values, ok := value.(map[string]configValue)
+	if !ok {
+		return cmd.FormatSmart(value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	p := func(values ...string) {
+		text := strings.Join(values, "\t")
+		fmt.Fprintln(tw, text)
+	}
+	p("ATTRIBUTE\tFROM\tVALUE")
+
+	var attrs []string
+	for attr := range values {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+	for _, attr := range attrs {
+		value := values[attr]
+		// Only the first line of multi-line values, such as
+		// certificates, is shown.
+		lines := strings.Split(strings.TrimSpace(fmt.Sprint(value.Value)), "\n")
+		text := lines[0]
+		if len(lines) > 1 {
+			text += " ..."
+		}
+		p(attr, value.Source, text)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
 }
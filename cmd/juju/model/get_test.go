@@ -58,9 +58,37 @@ func (s *GetSuite) TestAllValues(c *gc.C) {
 
 	output := strings.TrimSpace(testing.Stdout(context))
 	expected := "" +
-		"name: test-model\n" +
-		"running: true\n" +
-		"special: special value"
+		"ATTRIBUTE  FROM     VALUE\n" +
+		"name       model    test-model\n" +
+		"running    default  true\n" +
+		"special    model    special value"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *GetSuite) TestAllValuesMultiLine(c *gc.C) {
+	s.fake.values["ca-cert"] = "first line\nsecond line\n"
+	context, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(testing.Stdout(context))
+	c.Assert(output, jc.Contains, "ca-cert    model    first line ...\n")
+}
+
+func (s *GetSuite) TestAllValuesYAML(c *gc.C) {
+	context, err := s.run(c, "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(testing.Stdout(context))
+	expected := "" +
+		"name:\n" +
+		"  value: test-model\n" +
+		"  source: model\n" +
+		"running:\n" +
+		"  value: true\n" +
+		"  source: default\n" +
+		"special:\n" +
+		"  value: special value\n" +
+		"  source: model"
 	c.Assert(output, gc.Equals, expected)
 }
 
@@ -69,6 +97,8 @@ func (s *GetSuite) TestAllValuesJSON(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	output := strings.TrimSpace(testing.Stdout(context))
-	expected := `{"name":"test-model","running":true,"special":"special value"}`
+	expected := `{"name":{"value":"test-model","source":"model"},` +
+		`"running":{"value":true,"source":"default"},` +
+		`"special":{"value":"special value","source":"model"}}`
 	c.Assert(output, gc.Equals, expected)
 }
@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSetModelDefaultsCommand returns a command to set model defaults
+// on the controller.
+func NewSetModelDefaultsCommand() cmd.Command {
+	return modelcmd.WrapController(&setModelDefaultsCommand{})
+}
+
+type setModelDefaultsCommand struct {
+	modelcmd.ControllerCommandBase
+	api    SetModelDefaultsAPI
+	region string
+	values attributes
+}
+
+const setModelDefaultsHelpDoc = `
+Sets default configuration values for models. The defaults are set for
+all models of the controller, or, if a region is specified, for models
+in that cloud region. If the cloud is omitted from the region, the
+controller's cloud is assumed. Only controller administrators may set
+model defaults.
+
+Models use a default for any key they do not set themselves, including
+existing models.
+
+Examples:
+
+    juju set-model-defaults apt-mirror=http://mirror.example.com
+    juju set-model-defaults --region us-east-1 http-proxy=http://proxy:3128
+    juju set-model-defaults --region aws/eu-west-1 default-series=xenial
+
+See also: model-defaults
+          unset-model-defaults
+          set-model-config
+`
+
+func (c *setModelDefaultsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-model-defaults",
+		Args:    "<model key>=<value> ...",
+		Purpose: "Sets default configuration values for models.",
+		Doc:     strings.TrimSpace(setModelDefaultsHelpDoc),
+	}
+}
+
+func (c *setModelDefaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.region, "region", "", "Set the defaults for the [<cloud>/]<region> cloud region")
+}
+
+func (c *setModelDefaultsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no key, value pairs specified")
+	}
+	if c.region != "" {
+		if _, _, err := parseRegion(c.region); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	options, err := keyvalues.Parse(args, true)
+	if err != nil {
+		return err
+	}
+	c.values = make(attributes)
+	for key, value := range options {
+		c.values[key] = value
+	}
+	return nil
+}
+
+// SetModelDefaultsAPI defines the API methods used by the
+// set-model-defaults command.
+type SetModelDefaultsAPI interface {
+	Close() error
+	SetModelDefaults(cloudName, cloudRegion string, attrs map[string]interface{}) error
+}
+
+func (c *setModelDefaultsCommand) getAPI() (SetModelDefaultsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+func (c *setModelDefaultsCommand) Run(ctx *cmd.Context) error {
+	var cloudName, regionName string
+	if c.region != "" {
+		var err error
+		cloudName, regionName, err = parseRegion(c.region)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return client.SetModelDefaults(cloudName, regionName, c.values)
+}
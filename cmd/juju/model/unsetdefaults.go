@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewUnsetModelDefaultsCommand returns a command to remove model
+// defaults from the controller.
+func NewUnsetModelDefaultsCommand() cmd.Command {
+	return modelcmd.WrapController(&unsetModelDefaultsCommand{})
+}
+
+type unsetModelDefaultsCommand struct {
+	modelcmd.ControllerCommandBase
+	api    UnsetModelDefaultsAPI
+	region string
+	keys   []string
+}
+
+const unsetModelDefaultsHelpDoc = `
+Removes default configuration values for models, from the controller or,
+if a region is specified, from that cloud region. If the cloud is omitted
+from the region, the controller's cloud is assumed. Models then inherit
+the next default in line: the controller-wide default for region
+defaults, or the Juju default. Only controller administrators may unset
+model defaults.
+
+Examples:
+
+    juju unset-model-defaults apt-mirror
+    juju unset-model-defaults --region aws/eu-west-1 default-series
+
+See also: model-defaults
+          set-model-defaults
+          unset-model-config
+`
+
+func (c *unsetModelDefaultsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "unset-model-defaults",
+		Args:    "<model key> ...",
+		Purpose: "Unsets default configuration values for models.",
+		Doc:     strings.TrimSpace(unsetModelDefaultsHelpDoc),
+	}
+}
+
+func (c *unsetModelDefaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.region, "region", "", "Unset the defaults for the [<cloud>/]<region> cloud region")
+}
+
+func (c *unsetModelDefaultsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no keys specified")
+	}
+	if c.region != "" {
+		if _, _, err := parseRegion(c.region); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.keys = args
+	return nil
+}
+
+// UnsetModelDefaultsAPI defines the API methods used by the
+// unset-model-defaults command.
+type UnsetModelDefaultsAPI interface {
+	Close() error
+	UnsetModelDefaults(cloudName, cloudRegion string, keys ...string) error
+}
+
+func (c *unsetModelDefaultsCommand) getAPI() (UnsetModelDefaultsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+func (c *unsetModelDefaultsCommand) Run(ctx *cmd.Context) error {
+	var cloudName, regionName string
+	if c.region != "" {
+		var err error
+		cloudName, regionName, err = parseRegion(c.region)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return client.UnsetModelDefaults(cloudName, regionName, c.keys...)
+}
@@ -0,0 +1,19 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewListCommandForTest returns a secrets command with the api and
+// client store provided as specified.
+func NewListCommandForTest(api ListSecretsAPI, store jujuclient.ClientStore) cmd.Command {
+	command := &listCommand{api: api}
+	command.SetClientStore(store)
+	return modelcmd.Wrap(command)
+}
@@ -0,0 +1,190 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets holds the commands used to inspect the charm
+// secrets stored in a model.
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/osenv"
+)
+
+const listCommandDoc = `
+Lists the secrets added by charms in the model, with their owner,
+label, current revision and the applications they have been granted to.
+Secret values are never shown.
+
+Examples:
+    juju secrets
+    juju secrets --format yaml
+
+See also:
+    status
+`
+
+// NewListCommand returns a command that lists the secrets in a model.
+func NewListCommand() cmd.Command {
+	return modelcmd.Wrap(&listCommand{})
+}
+
+// ListSecretsAPI defines the secrets API methods that the secrets
+// command uses.
+type ListSecretsAPI interface {
+	Close() error
+	ListSecrets() ([]params.SecretMetadata, error)
+}
+
+// listCommand lists the secrets in a model.
+type listCommand struct {
+	modelcmd.ModelCommandBase
+	out     cmd.Output
+	api     ListSecretsAPI
+	isoTime bool
+}
+
+// Info implements Command.Info.
+func (c *listCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secrets",
+		Purpose: "lists the secrets added by charms in the model",
+		Doc:     listCommandDoc,
+		Aliases: []string{"list-secrets"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSecretsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listCommand) Init(args []string) error {
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *listCommand) getAPI() (ListSecretsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	metadata, err := client.ListSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(metadata) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No secrets to display.")
+		return nil
+	}
+	result := make([]secretInfo, len(metadata))
+	for i, m := range metadata {
+		owner := m.OwnerTag
+		if tag, err := names.ParseTag(m.OwnerTag); err == nil {
+			owner = tag.Id()
+		}
+		result[i] = secretInfo{
+			ID:       m.ID,
+			Owner:    owner,
+			Label:    m.Label,
+			Revision: m.Revision,
+			Created:  common.FormatTime(&m.Created, c.isoTime),
+			Updated:  common.FormatTime(&m.Updated, c.isoTime),
+		}
+		for _, grant := range m.Grants {
+			result[i].Grants = append(result[i].Grants, secretGrant{
+				Relation:    grant.RelationKey,
+				Application: grant.Application,
+			})
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+// secretInfo holds a secret's metadata as displayed.
+type secretInfo struct {
+	ID       string        `yaml:"id" json:"id"`
+	Owner    string        `yaml:"owner" json:"owner"`
+	Label    string        `yaml:"label,omitempty" json:"label,omitempty"`
+	Revision int           `yaml:"revision" json:"revision"`
+	Created  string        `yaml:"created" json:"created"`
+	Updated  string        `yaml:"updated" json:"updated"`
+	Grants   []secretGrant `yaml:"grants,omitempty" json:"grants,omitempty"`
+}
+
+// secretGrant holds an application's access to a secret as displayed.
+type secretGrant struct {
+	Relation    string `yaml:"relation" json:"relation"`
+	Application string `yaml:"application" json:"application"`
+}
+
+func formatSecretsTabular(value interface{}) ([]byte, error) {
+	secrets, ok := value.([]secretInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "ID\tOWNER\tLABEL\tREVISION\tUPDATED\tGRANTED\n")
+	for _, s := range secrets {
+		granted := make([]string, len(s.Grants))
+		for i, grant := range s.Grants {
+			granted[i] = grant.Application
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", s.ID, s.Owner, s.Label, s.Revision, s.Updated, strings.Join(granted, ","))
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
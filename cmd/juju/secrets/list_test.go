@@ -0,0 +1,140 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeSecretsAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	created := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.api = &fakeSecretsAPI{
+		secrets: []params.SecretMetadata{{
+			ID:       "a4f1c2e0-0b0d-4e83-8d2e-2b3a4d4b5c6d",
+			OwnerTag: "application-mysql",
+			Label:    "root",
+			Revision: 2,
+			Created:  created,
+			Updated:  created.Add(time.Hour),
+			Grants: []params.SecretGrant{{
+				RelationKey: "wordpress:db mysql:server",
+				Application: "wordpress",
+			}, {
+				RelationKey: "mediawiki:db mysql:server",
+				Application: "mediawiki",
+			}},
+		}, {
+			ID:       "c1d2e3f4-5a6b-4c7d-8e9f-0a1b2c3d4e5f",
+			OwnerTag: "unit-wordpress-0",
+			Revision: 1,
+			Created:  created,
+			Updated:  created,
+		}},
+	}
+}
+
+func (s *ListSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.NewMemStore()
+	store.CurrentControllerName = "testing"
+	store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	store.Accounts["testing"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"admin@local": {User: "admin@local"},
+		},
+		CurrentAccount: "admin@local",
+	}
+	store.Models["testing"] = jujuclient.ControllerAccountModels{
+		AccountModels: map[string]*jujuclient.AccountModels{
+			"admin@local": {
+				Models:       map[string]jujuclient.ModelDetails{"admin": {"model-uuid"}},
+				CurrentModel: "admin",
+			},
+		},
+	}
+	return testing.RunCommand(c, secrets.NewListCommandForTest(s.api, store), args...)
+}
+
+func (s *ListSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runCommand(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.runCommand(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"ID                                    OWNER        LABEL  REVISION  UPDATED               GRANTED\n"+
+		"a4f1c2e0-0b0d-4e83-8d2e-2b3a4d4b5c6d  mysql        root   2         2016-07-01 11:00:00Z  wordpress,mediawiki\n"+
+		"c1d2e3f4-5a6b-4c7d-8e9f-0a1b2c3d4e5f  wordpress/0         1         2016-07-01 10:00:00Z  \n"+
+		"\n")
+}
+
+func (s *ListSuite) TestListYaml(c *gc.C) {
+	ctx, err := s.runCommand(c, "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- id: a4f1c2e0-0b0d-4e83-8d2e-2b3a4d4b5c6d
+  owner: mysql
+  label: root
+  revision: 2
+  created: 2016-07-01 10:00:00Z
+  updated: 2016-07-01 11:00:00Z
+  grants:
+  - relation: wordpress:db mysql:server
+    application: wordpress
+  - relation: mediawiki:db mysql:server
+    application: mediawiki
+- id: c1d2e3f4-5a6b-4c7d-8e9f-0a1b2c3d4e5f
+  owner: wordpress/0
+  revision: 1
+  created: 2016-07-01 10:00:00Z
+  updated: 2016-07-01 10:00:00Z
+`[1:])
+}
+
+func (s *ListSuite) TestListNone(c *gc.C) {
+	s.api.secrets = nil
+	ctx, err := s.runCommand(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No secrets to display.\n")
+}
+
+func (s *ListSuite) TestListError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.runCommand(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeSecretsAPI struct {
+	secrets []params.SecretMetadata
+	err     error
+}
+
+func (f *fakeSecretsAPI) Close() error {
+	return nil
+}
+
+func (f *fakeSecretsAPI) ListSecrets() ([]params.SecretMetadata, error) {
+	return f.secrets, f.err
+}
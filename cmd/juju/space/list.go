@@ -13,6 +13,7 @@ import (
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -25,6 +26,7 @@ func NewListCommand() cmd.Command {
 type listCommand struct {
 	SpaceCommandBase
 	Short bool
+	Drift bool
 	out   cmd.Output
 }
 
@@ -33,13 +35,20 @@ Displays all defined spaces. If --short is not given both spaces and
 their subnets are displayed, otherwise just a list of spaces. The
 --format argument has the same semantics as in other CLI commands -
 "yaml" is the default. The --output argument allows the command
-output to be redirected to a file. `
+output to be redirected to a file.
+
+If --drift is given, the differences last found between the spaces and
+subnets known to the cloud provider and those known to Juju are
+displayed instead. Each difference is reported as resolved when Juju
+was updated to match the provider, or as a conflict (with the reason)
+when it could not be updated safely, e.g. because application
+endpoints are still bound to a space the provider no longer has. `
 
 // Info is defined on the cmd.Command interface.
 func (c *listCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "spaces",
-		Args:    "[--short] [--format yaml|json] [--output <path>]",
+		Args:    "[--short|--drift] [--format yaml|json] [--output <path>]",
 		Purpose: "List known spaces, including associated subnets",
 		Doc:     strings.TrimSpace(listCommandDoc),
 		Aliases: []string{"list-spaces"},
@@ -55,6 +64,7 @@ func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 
 	f.BoolVar(&c.Short, "short", false, "only display spaces.")
+	f.BoolVar(&c.Drift, "drift", false, "display differences between the provider's spaces and Juju's.")
 }
 
 // Init is defined on the cmd.Command interface. It checks the
@@ -64,12 +74,18 @@ func (c *listCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args); err != nil {
 		return errors.Trace(err)
 	}
+	if c.Short && c.Drift {
+		return errors.New("cannot specify both --short and --drift")
+	}
 
 	return nil
 }
 
 // Run implements Command.Run.
 func (c *listCommand) Run(ctx *cmd.Context) error {
+	if c.Drift {
+		return c.RunWithAPI(ctx, c.runDrift)
+	}
 	return c.RunWithAPI(ctx, func(api SpaceAPI, ctx *cmd.Context) error {
 		spaces, err := api.ListSpaces()
 		if err != nil {
@@ -135,6 +151,44 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 	})
 }
 
+func (c *listCommand) runDrift(api SpaceAPI, ctx *cmd.Context) error {
+	drift, err := api.ListSpaceDrift()
+	if err != nil {
+		if errors.IsNotSupported(err) {
+			ctx.Infof("cannot list space drift: %v", err)
+		}
+		return errors.Annotate(err, "cannot list space drift")
+	}
+	if drift.Updated.IsZero() {
+		ctx.Infof("space discovery has not reported any drift yet")
+		return c.out.Write(ctx, nil)
+	}
+
+	result := formattedDriftList{
+		Updated: common.FormatTime(&drift.Updated, true),
+		Drift:   make([]formattedDrift, len(drift.Results)),
+	}
+	for i, entry := range drift.Results {
+		status := statusUnresolved
+		if entry.Resolved {
+			status = statusResolved
+		} else if entry.Conflict != "" {
+			status = statusConflict
+		}
+		result.Drift[i] = formattedDrift{
+			Kind:         entry.Kind,
+			Space:        entry.SpaceName,
+			Subnet:       entry.CIDR,
+			ProviderId:   entry.ProviderId,
+			ToSpace:      entry.ToSpaceName,
+			Status:       status,
+			Conflict:     entry.Conflict,
+			Applications: entry.Applications,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
 const (
 	typeUnknown = "unknown"
 	typeIPv4    = "ipv4"
@@ -142,6 +196,10 @@ const (
 
 	statusInUse       = "in-use"
 	statusTerminating = "terminating"
+
+	statusResolved   = "resolved"
+	statusConflict   = "conflict"
+	statusUnresolved = "unresolved"
 )
 
 // TODO(dimitern): Display space attributes along with subnets (state
@@ -161,3 +219,19 @@ type formattedSubnet struct {
 	Status     string   `json:"status,omitempty" yaml:"status,omitempty"`
 	Zones      []string `json:"zones" yaml:"zones"`
 }
+
+type formattedDriftList struct {
+	Updated string           `json:"updated" yaml:"updated"`
+	Drift   []formattedDrift `json:"drift" yaml:"drift"`
+}
+
+type formattedDrift struct {
+	Kind         string   `json:"kind" yaml:"kind"`
+	Space        string   `json:"space,omitempty" yaml:"space,omitempty"`
+	Subnet       string   `json:"subnet,omitempty" yaml:"subnet,omitempty"`
+	ProviderId   string   `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
+	ToSpace      string   `json:"to-space,omitempty" yaml:"to-space,omitempty"`
+	Status       string   `json:"status" yaml:"status"`
+	Conflict     string   `json:"conflict,omitempty" yaml:"conflict,omitempty"`
+	Applications []string `json:"applications,omitempty" yaml:"applications,omitempty"`
+}
@@ -10,11 +10,13 @@ import (
 	"strings"
 
 	"regexp"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/space"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -53,6 +55,12 @@ func (s *ListSuite) TestInit(c *gc.C) {
 		args:         s.Strings("--format", "JSON"),
 		expectErr:    `invalid value "JSON" for flag --format: unknown format "JSON"`,
 		expectFormat: "yaml",
+	}, {
+		about:        "both --short and --drift specified",
+		args:         s.Strings("--short", "--drift"),
+		expectShort:  true,
+		expectErr:    "cannot specify both --short and --drift",
+		expectFormat: "yaml",
 	}, {
 		about:        "json format",
 		args:         s.Strings("--format", "json"),
@@ -281,3 +289,50 @@ func (s *ListSuite) TestRunWhenSpacesAPIFails(c *gc.C) {
 	s.api.CheckCallNames(c, "ListSpaces", "Close")
 	s.api.CheckCall(c, 0, "ListSpaces")
 }
+
+func (s *ListSuite) TestRunDrift(c *gc.C) {
+	s.api.Drift = params.SpaceDriftResults{
+		Updated: time.Date(2016, 7, 1, 12, 0, 0, 0, time.UTC),
+		Results: []params.SpaceDrift{{
+			Kind:       "subnet-removed",
+			SpaceName:  "space1",
+			CIDR:       "10.0.0.0/24",
+			ProviderId: "subnet-gone",
+			Resolved:   true,
+		}, {
+			Kind:         "space-removed",
+			SpaceName:    "space2",
+			Conflict:     "application endpoints bound to space: mysql",
+			Applications: []string{"mysql"},
+		}},
+	}
+	expected := `
+updated: 2016-07-01 12:00:00Z
+drift:
+- kind: subnet-removed
+  space: space1
+  subnet: 10.0.0.0/24
+  provider-id: subnet-gone
+  status: resolved
+- kind: space-removed
+  space: space2
+  status: conflict
+  conflict: 'application endpoints bound to space: mysql'
+  applications:
+  - mysql
+`[1:]
+
+	s.AssertRunSucceeds(c, "", expected, "--drift")
+
+	s.api.CheckCallNames(c, "ListSpaceDrift", "Close")
+}
+
+func (s *ListSuite) TestRunDriftNotReported(c *gc.C) {
+	s.AssertRunSucceeds(c,
+		`space discovery has not reported any drift yet\n`,
+		"", // empty stdout.
+		"--drift",
+	)
+
+	s.api.CheckCallNames(c, "ListSpaceDrift", "Close")
+}
@@ -126,6 +126,7 @@ type StubAPI struct {
 
 	Spaces  []params.Space
 	Subnets []params.Subnet
+	Drift   params.SpaceDriftResults
 }
 
 var _ space.SpaceAPI = (*StubAPI)(nil)
@@ -193,6 +194,14 @@ func (sa *StubAPI) ListSpaces() ([]params.Space, error) {
 	return sa.Spaces, nil
 }
 
+func (sa *StubAPI) ListSpaceDrift() (params.SpaceDriftResults, error) {
+	sa.MethodCall(sa, "ListSpaceDrift")
+	if err := sa.NextErr(); err != nil {
+		return params.SpaceDriftResults{}, err
+	}
+	return sa.Drift, nil
+}
+
 func (sa *StubAPI) AddSpace(name string, subnetIds []string, public bool) error {
 	sa.MethodCall(sa, "AddSpace", name, subnetIds, public)
 	return sa.NextErr()
@@ -27,6 +27,10 @@ type SpaceAPI interface {
 	// ListSpaces returns all Juju network spaces and their subnets.
 	ListSpaces() ([]params.Space, error)
 
+	// ListSpaceDrift returns the latest differences recorded between
+	// the provider's spaces and subnets and those known to Juju.
+	ListSpaceDrift() (params.SpaceDriftResults, error)
+
 	// AddSpace adds a new Juju network space, associating the
 	// specified subnets with it (optional; can be empty), setting the
 	// space and subnets access to public or private.
@@ -137,6 +141,10 @@ func (m *mvpAPIShim) ListSpaces() ([]params.Space, error) {
 	return m.facade.ListSpaces()
 }
 
+func (m *mvpAPIShim) ListSpaceDrift() (params.SpaceDriftResults, error) {
+	return m.facade.ListSpaceDrift()
+}
+
 // NewAPI returns a SpaceAPI for the root api endpoint that the
 // environment command returns.
 func (c *SpaceCommandBase) NewAPI() (SpaceAPI, error) {
@@ -26,11 +26,12 @@ type errorStatus struct {
 }
 
 type modelStatus struct {
-	Name             string `json:"name"`
-	Controller       string `json:"controller"`
-	Cloud            string `json:"cloud"`
-	Version          string `json:"version"`
-	AvailableVersion string `json:"upgrade-available,omitempty" yaml:"upgrade-available,omitempty"`
+	Name             string   `json:"name"`
+	Controller       string   `json:"controller"`
+	Cloud            string   `json:"cloud"`
+	Version          string   `json:"version"`
+	AvailableVersion string   `json:"upgrade-available,omitempty" yaml:"upgrade-available,omitempty"`
+	Warnings         []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
 }
 
 type machineStatus struct {
@@ -74,6 +75,8 @@ type applicationStatus struct {
 	Relations     map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
 	SubordinateTo []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
 	Units         map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
+	Version       string                `json:"version,omitempty" yaml:"version,omitempty"`
+	MixedVersions bool                  `json:"mixed-versions,omitempty" yaml:"mixed-versions,omitempty"`
 }
 
 type applicationStatusNoMarshal applicationStatus
@@ -102,6 +105,7 @@ type unitStatus struct {
 	WorkloadStatusInfo statusInfoContents `json:"workload-status,omitempty" yaml:"workload-status"`
 	JujuStatusInfo     statusInfoContents `json:"juju-status,omitempty" yaml:"juju-status"`
 	MeterStatus        *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
+	WorkloadVersion    string             `json:"workload-version,omitempty" yaml:"workload-version,omitempty"`
 
 	Charm         string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
 	Machine       string                `json:"machine,omitempty" yaml:"machine,omitempty"`
@@ -4,6 +4,10 @@
 package status
 
 import (
+	"strings"
+
+	"github.com/juju/utils/set"
+
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/state/multiwatcher"
@@ -43,6 +47,7 @@ func (sf *statusFormatter) format() formattedStatus {
 	model := sf.model
 	model.Version = sf.status.Model.Version
 	model.AvailableVersion = sf.status.Model.AvailableVersion
+	model.Warnings = sf.status.Model.Warnings
 	out := formattedStatus{
 		Model:        model,
 		Machines:     make(map[string]machineStatus),
@@ -118,6 +123,8 @@ func (sf *statusFormatter) formatService(name string, service params.Application
 		SubordinateTo: service.SubordinateTo,
 		Units:         make(map[string]unitStatus),
 		StatusInfo:    sf.getServiceStatusInfo(service),
+		Version:       service.WorkloadVersion,
+		MixedVersions: sf.mixedWorkloadVersions(name),
 	}
 	for k, m := range service.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
@@ -130,6 +137,26 @@ func (sf *statusFormatter) formatService(name string, service params.Application
 	return out
 }
 
+// mixedWorkloadVersions reports whether the units of the named
+// application, including subordinate units, have reported different
+// workload versions.
+func (sf *statusFormatter) mixedWorkloadVersions(appName string) bool {
+	versions := set.NewStrings()
+	var addVersions func(units map[string]params.UnitStatus)
+	addVersions = func(units map[string]params.UnitStatus) {
+		for name, u := range units {
+			if strings.HasPrefix(name, appName+"/") && u.WorkloadVersion != "" {
+				versions.Add(u.WorkloadVersion)
+			}
+			addVersions(u.Subordinates)
+		}
+	}
+	for _, app := range sf.status.Applications {
+		addVersions(app.Units)
+	}
+	return versions.Size() > 1
+}
+
 func (sf *statusFormatter) getServiceStatusInfo(service params.ApplicationStatus) statusInfoContents {
 	// TODO(perrito66) add status validation.
 	info := statusInfoContents{
@@ -162,6 +189,7 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		OpenedPorts:        info.unit.OpenedPorts,
 		PublicAddress:      info.unit.PublicAddress,
 		Charm:              info.unit.Charm,
+		WorkloadVersion:    info.unit.WorkloadVersion,
 		Subordinates:       make(map[string]unitStatus),
 	}
 
@@ -0,0 +1,198 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// NewHookHistoryCommand returns a command that reports the hook,
+// action and juju-run executions recorded for the specified unit.
+func NewHookHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&hookHistoryCommand{})
+}
+
+type hookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out         cmd.Output
+	api         HookHistoryAPI
+	backlogSize int
+	isoTime     bool
+	unitName    string
+}
+
+// HookHistoryAPI defines the methods on the client API that the
+// show-hook-history command calls.
+type HookHistoryAPI interface {
+	Close() error
+	HookHistory(unitName string, size int) ([]params.HookExecution, error)
+}
+
+var hookHistoryDoc = `
+This command reports the most recent hook, action and juju-run
+executions on a unit, newest first. For each execution the time it
+started, what was run, the relation and remote unit of relation hooks,
+the exit code and how long it took are shown.
+
+The tail of each execution's stdout and stderr is recorded too, and is
+included when the history is displayed as yaml or json.
+
+Hook history is pruned along with status history, according to the
+max-status-history-age and max-status-history-size model settings.
+
+Examples:
+    juju show-hook-history mysql/0
+    juju show-hook-history mysql/0 -n 5 --format yaml
+
+See also:
+    status-history
+`
+
+// Info implements Command.Info.
+func (c *hookHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-hook-history",
+		Args:    "<unit name>",
+		Purpose: "output past hook, action and juju-run executions for a unit",
+		Doc:     hookHistoryDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *hookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.IntVar(&c.backlogSize, "n", 20, "returns the last N executions")
+	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatHookHistoryTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *hookHistoryCommand) Init(args []string) error {
+	switch {
+	case len(args) > 1:
+		return errors.Errorf("unexpected arguments after unit name.")
+	case len(args) == 0:
+		return errors.Errorf("unit name is missing.")
+	default:
+		c.unitName = args[0]
+	}
+	if !names.IsValidUnit(c.unitName) {
+		return errors.Errorf("%q is not a valid unit name", c.unitName)
+	}
+	if c.backlogSize < 0 {
+		return errors.Errorf("negative number of executions not valid")
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *hookHistoryCommand) getAPI() (HookHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *hookHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	executions, err := client.HookHistory(c.unitName, c.backlogSize)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(executions) == 0 {
+		return errors.Errorf("no hook history available")
+	}
+	history := make([]hookExecution, len(executions))
+	for i, e := range executions {
+		history[i] = hookExecution{
+			Time:       common.FormatTime(&e.Started, c.isoTime),
+			Kind:       e.Kind,
+			Name:       e.Name,
+			RemoteUnit: e.RemoteUnit,
+			ExitCode:   e.ExitCode,
+			Duration:   e.Finished.Sub(e.Started).String(),
+			Output:     e.Output,
+		}
+		if e.RelationId >= 0 {
+			relationId := e.RelationId
+			history[i].RelationId = &relationId
+		}
+	}
+	return c.out.Write(ctx, history)
+}
+
+// hookExecution holds a single hook history entry as displayed.
+type hookExecution struct {
+	Time       string `yaml:"time" json:"time"`
+	Kind       string `yaml:"kind" json:"kind"`
+	Name       string `yaml:"name" json:"name"`
+	RelationId *int   `yaml:"relation-id,omitempty" json:"relation-id,omitempty"`
+	RemoteUnit string `yaml:"remote-unit,omitempty" json:"remote-unit,omitempty"`
+	ExitCode   int    `yaml:"exit-code" json:"exit-code"`
+	Duration   string `yaml:"duration" json:"duration"`
+	Output     string `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+func formatHookHistoryTabular(value interface{}) ([]byte, error) {
+	history, ok := value.([]hookExecution)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", history, value)
+	}
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tKIND\tNAME\tRELATION\tEXIT\tDURATION\n")
+	for _, e := range history {
+		relation := ""
+		if e.RelationId != nil {
+			relation = strconv.Itoa(*e.RelationId)
+			if e.RemoteUnit != "" {
+				relation += " " + e.RemoteUnit
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n", e.Time, e.Kind, e.Name, relation, e.ExitCode, e.Duration)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
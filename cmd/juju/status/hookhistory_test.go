@@ -0,0 +1,158 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type HookHistorySuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeHookHistoryAPI
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	started := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.api = &fakeHookHistoryAPI{
+		executions: []params.HookExecution{{
+			Kind:       "hook",
+			Name:       "db-relation-changed",
+			RelationId: 2,
+			RemoteUnit: "mysql/0",
+			Started:    started.Add(time.Minute),
+			Finished:   started.Add(time.Minute + 3*time.Second),
+			ExitCode:   1,
+			Output:     "no database yet\n",
+		}, {
+			Kind:       "action",
+			Name:       "backup",
+			RelationId: -1,
+			Started:    started,
+			Finished:   started.Add(time.Second),
+		}},
+	}
+}
+
+func (s *HookHistorySuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.NewMemStore()
+	store.CurrentControllerName = "testing"
+	store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	store.Accounts["testing"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"admin@local": {User: "admin@local"},
+		},
+		CurrentAccount: "admin@local",
+	}
+	store.Models["testing"] = jujuclient.ControllerAccountModels{
+		AccountModels: map[string]*jujuclient.AccountModels{
+			"admin@local": {
+				Models:       map[string]jujuclient.ModelDetails{"admin": {"model-uuid"}},
+				CurrentModel: "admin",
+			},
+		},
+	}
+	command := &hookHistoryCommand{api: s.api}
+	command.SetClientStore(store)
+	return testing.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *HookHistorySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "unit name is missing.",
+	}, {
+		args: []string{"mysql/0", "extra"},
+		err:  "unexpected arguments after unit name.",
+	}, {
+		args: []string{"mysql"},
+		err:  `"mysql" is not a valid unit name`,
+	}, {
+		args: []string{"mysql/0", "-n", "-1"},
+		err:  "negative number of executions not valid",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runCommand(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *HookHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := s.runCommand(c, "wordpress/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.unitName, gc.Equals, "wordpress/0")
+	c.Check(s.api.size, gc.Equals, 20)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                  KIND    NAME                 RELATION   EXIT  DURATION\n"+
+		"2016-07-01 10:01:00Z  hook    db-relation-changed  2 mysql/0  1     3s\n"+
+		"2016-07-01 10:00:00Z  action  backup                          0     1s\n"+
+		"\n")
+}
+
+func (s *HookHistorySuite) TestYAML(c *gc.C) {
+	ctx, err := s.runCommand(c, "wordpress/0", "--utc", "-n", "5", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.size, gc.Equals, 5)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+- time: 2016-07-01 10:01:00Z
+  kind: hook
+  name: db-relation-changed
+  relation-id: 2
+  remote-unit: mysql/0
+  exit-code: 1
+  duration: 3s
+  output: |
+    no database yet
+- time: 2016-07-01 10:00:00Z
+  kind: action
+  name: backup
+  exit-code: 0
+  duration: 1s
+`[1:])
+}
+
+func (s *HookHistorySuite) TestNoHistory(c *gc.C) {
+	s.api.executions = nil
+	_, err := s.runCommand(c, "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "no hook history available")
+}
+
+func (s *HookHistorySuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.runCommand(c, "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeHookHistoryAPI struct {
+	executions []params.HookExecution
+	err        error
+	unitName   string
+	size       int
+}
+
+func (f *fakeHookHistoryAPI) Close() error {
+	return nil
+}
+
+func (f *fakeHookHistoryAPI) HookHistory(unitName string, size int) ([]params.HookExecution, error) {
+	f.unitName = unitName
+	f.size = size
+	return f.executions, f.err
+}
@@ -116,11 +116,17 @@ func FormatTabular(value interface{}) ([]byte, error) {
 	// The first set of headers don't use outputHeaders because it adds the blank line.
 	p(header...)
 	p(values...)
+	if len(fs.Model.Warnings) > 0 {
+		outputHeaders("WARNINGS")
+		for _, warning := range fs.Model.Warnings {
+			p(warning)
+		}
+	}
 
 	units := make(map[string]unitStatus)
 	metering := false
 	relations := newRelationFormatter()
-	outputHeaders("APP", "STATUS", "EXPOSED", "CHARM")
+	outputHeaders("APP", "VERSION", "STATUS", "EXPOSED", "CHARM")
 	for _, svcName := range common.SortStringsNaturally(stringKeysFromMap(fs.Applications)) {
 		svc := fs.Applications[svcName]
 		for un, u := range svc.Units {
@@ -131,7 +137,12 @@ func FormatTabular(value interface{}) ([]byte, error) {
 		}
 
 		subs := set.NewStrings(svc.SubordinateTo...)
-		p(svcName, svc.StatusInfo.Current, fmt.Sprintf("%t", svc.Exposed), svc.Charm)
+		version := svc.Version
+		if version != "" && svc.MixedVersions {
+			// Flag that not all units are running this version.
+			version += "*"
+		}
+		p(svcName, version, svc.StatusInfo.Current, fmt.Sprintf("%t", svc.Exposed), svc.Charm)
 		for relType, relatedUnits := range svc.Relations {
 			for _, related := range relatedUnits {
 				relations.add(related, svcName, relType, subs.Contains(related))
@@ -3383,10 +3383,10 @@ func (s *StatusSuite) testStatusWithFormatTabular(c *gc.C, useFeatureFlag bool)
 MODEL  CONTROLLER  CLOUD  VERSION  UPGRADE-AVAILABLE  
 admin  kontroll    dummy  1.2.3    1.2.4              
 
-APP        STATUS       EXPOSED  CHARM                   
-logging                 true     cs:quantal/logging-1    
-mysql      maintenance  true     cs:quantal/mysql-1      
-wordpress  active       true     cs:quantal/wordpress-3  
+APP        VERSION  STATUS       EXPOSED  CHARM                   
+logging                          true     cs:quantal/logging-1    
+mysql               maintenance  true     cs:quantal/mysql-1      
+wordpress           active       true     cs:quantal/wordpress-3  
 
 RELATION           PROVIDES   CONSUMES   TYPE         
 juju-info          logging    mysql      regular      
@@ -3449,8 +3449,8 @@ func (s *StatusSuite) TestFormatTabularHookActionName(c *gc.C) {
 MODEL  CONTROLLER  CLOUD  VERSION  
                                    
 
-APP  STATUS  EXPOSED  CHARM  
-foo          false           
+APP  VERSION  STATUS  EXPOSED  CHARM  
+foo                   false           
 
 UNIT   WORKLOAD     AGENT      MACHINE  PORTS  PUBLIC-ADDRESS  MESSAGE                            
 foo/0  maintenance  executing                                  (config-changed) doing some work   
@@ -3460,6 +3460,78 @@ MACHINE  STATE  DNS  INS-ID  SERIES  AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularWorkloadVersion(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": applicationStatus{
+				Version:       "1.0",
+				MixedVersions: true,
+				Units: map[string]unitStatus{
+					"foo/0": unitStatus{
+						WorkloadVersion: "1.0",
+						Subordinates: map[string]unitStatus{
+							"logging/0": unitStatus{WorkloadVersion: "0.9"},
+						},
+					},
+					"foo/1": unitStatus{
+						WorkloadVersion: "1.1",
+						Subordinates: map[string]unitStatus{
+							"logging/1": unitStatus{WorkloadVersion: "0.9"},
+						},
+					},
+				},
+			},
+			"logging": applicationStatus{
+				Version:       "0.9",
+				SubordinateTo: []string{"foo"},
+			},
+		},
+	}
+	out, err := FormatTabular(status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+MODEL  CONTROLLER  CLOUD  VERSION  
+                                   
+
+APP      VERSION  STATUS  EXPOSED  CHARM  
+foo      1.0*             false           
+logging  0.9              false           
+
+UNIT         WORKLOAD  AGENT  MACHINE  PORTS  PUBLIC-ADDRESS  MESSAGE  
+foo/0                                                                  
+  logging/0                                                            
+foo/1                                                                  
+  logging/1                                                            
+
+MACHINE  STATE  DNS  INS-ID  SERIES  AZ  
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatTabularModelWarnings(c *gc.C) {
+	status := formattedStatus{
+		Model: modelStatus{
+			Warnings: []string{
+				`space "db": space-removed (application endpoints bound to space: mysql)`,
+			},
+		},
+	}
+	out, err := FormatTabular(status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+MODEL  CONTROLLER  CLOUD  VERSION  
+                                   
+
+WARNINGS                                                                 
+space "db": space-removed (application endpoints bound to space: mysql)  
+
+APP  VERSION  STATUS  EXPOSED  CHARM  
+
+UNIT  WORKLOAD  AGENT  MACHINE  PORTS  PUBLIC-ADDRESS  MESSAGE  
+
+MACHINE  STATE  DNS  INS-ID  SERIES  AZ  
+`[1:])
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusApi(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
@@ -3515,8 +3587,8 @@ func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 MODEL  CONTROLLER  CLOUD  VERSION  
                                    
 
-APP  STATUS  EXPOSED  CHARM  
-foo          false           
+APP  VERSION  STATUS  EXPOSED  CHARM  
+foo                   false           
 
 UNIT   WORKLOAD  AGENT  MACHINE  PORTS  PUBLIC-ADDRESS  MESSAGE  
 foo/0                                                            
@@ -4046,3 +4118,41 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 		Applications: map[string]applicationStatus{},
 	})
 }
+
+func (s *StatusSuite) TestFormatMixedWorkloadVersions(c *gc.C) {
+	status := &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"foo": params.ApplicationStatus{
+				WorkloadVersion: "1.0",
+				Units: map[string]params.UnitStatus{
+					"foo/0": params.UnitStatus{
+						WorkloadVersion: "1.0",
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": params.UnitStatus{WorkloadVersion: "0.9"},
+						},
+					},
+					"foo/1": params.UnitStatus{
+						WorkloadVersion: "1.1",
+						Subordinates: map[string]params.UnitStatus{
+							"logging/1": params.UnitStatus{WorkloadVersion: "0.9"},
+						},
+					},
+				},
+			},
+			"logging": params.ApplicationStatus{
+				WorkloadVersion: "0.9",
+				SubordinateTo:   []string{"foo"},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted := formatter.format()
+
+	// The flag is part of the formatted status, so every output
+	// format carries it.
+	c.Check(formatted.Applications["foo"].MixedVersions, jc.IsTrue)
+	c.Check(formatted.Applications["logging"].MixedVersions, jc.IsFalse)
+	out, err := json.Marshal(formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), jc.Contains, `"mixed-versions":true`)
+}
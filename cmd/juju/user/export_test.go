@@ -56,9 +56,13 @@ func NewChangePasswordCommandForTest(api ChangePasswordAPI, store jujuclient.Cli
 // and writer provided as specified.
 func NewLoginCommandForTest(
 	newLoginAPI func(juju.NewAPIConnectionParams) (LoginAPI, error),
+	obtainIDToken func(*cmd.Context, juju.NewAPIConnectionParams) (string, error),
 	store jujuclient.ClientStore,
 ) (cmd.Command, *LoginCommand) {
-	c := &loginCommand{newLoginAPI: newLoginAPI}
+	c := &loginCommand{
+		newLoginAPI:   newLoginAPI,
+		obtainIDToken: obtainIDToken,
+	}
 	c.SetClientStore(store)
 	return modelcmd.WrapController(c), &LoginCommand{c}
 }
@@ -93,3 +97,15 @@ func NewListCommandForTest(api UserInfoAPI, store jujuclient.ClientStore) cmd.Co
 	c.SetClientStore(store)
 	return modelcmd.WrapController(c)
 }
+
+type RemoveCommand struct {
+	*removeCommand
+}
+
+// NewRemoveCommandForTest returns a RemoveCommand with the api provided
+// as specified.
+func NewRemoveCommandForTest(api RemoveUserAPI, store jujuclient.ClientStore) (cmd.Command, *RemoveCommand) {
+	c := &removeCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c), &RemoveCommand{c}
+}
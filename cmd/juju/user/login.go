@@ -26,6 +26,10 @@ will be removed from disk, and a temporary, time-limited
 credential written in its place. Once the credential
 expires, you will be prompted to run "juju login" again.
 
+External users, whose names include a domain other than "local", log
+in with the identity provider the controller is configured to use. A
+web page is opened in a browser to authenticate with the provider.
+
 Examples:
   # Log in as the current user for the controller.
   juju login
@@ -33,6 +37,9 @@ Examples:
   # Log in as the user "bob".
   juju login bob
 
+  # Log in as the external user "bob@example.com".
+  juju login bob@example.com
+
 `
 
 // NewLoginCommand returns a new cmd.Command to handle "juju login".
@@ -45,6 +52,7 @@ func NewLoginCommand() cmd.Command {
 			}
 			return usermanager.NewClient(api), nil
 		},
+		obtainIDToken: obtainIDToken,
 	})
 }
 
@@ -53,6 +61,10 @@ type loginCommand struct {
 	modelcmd.ControllerCommandBase
 	newLoginAPI func(juju.NewAPIConnectionParams) (LoginAPI, error)
 	User        string
+
+	// obtainIDToken obtains an ID token for an external user
+	// from the controller's identity provider.
+	obtainIDToken func(*cmd.Context, juju.NewAPIConnectionParams) (string, error)
 }
 
 // Info implements Command.Info.
@@ -99,7 +111,7 @@ func (c *loginCommand) Run(ctx *cmd.Context) error {
 			return errors.Errorf("you must specify a username")
 		}
 	}
-	if !names.IsValidUserName(user) {
+	if !names.IsValidUser(user) {
 		return errors.NotValidf("user name %q", user)
 	}
 	userTag := names.NewUserTag(user)
@@ -124,17 +136,27 @@ Run "juju logout" first before attempting to log in as a different user.
 		return errors.Trace(err)
 	}
 
-	// Read password from the terminal, and attempt to log in using that.
-	fmt.Fprint(ctx.Stderr, "password: ")
-	password, err := readPassword(ctx.Stdin)
-	fmt.Fprintln(ctx.Stderr)
-	if err != nil {
-		return errors.Trace(err)
-	}
 	params, err := c.NewAPIConnectionParams(store, controllerName, "", "")
 	if err != nil {
 		return errors.Trace(err)
 	}
+	var password string
+	if userTag.IsLocal() {
+		// Read password from the terminal, and attempt to log in using that.
+		fmt.Fprint(ctx.Stderr, "password: ")
+		password, err = readPassword(ctx.Stdin)
+		fmt.Fprintln(ctx.Stderr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		// External users log in using an ID token obtained from
+		// the identity provider in place of a password.
+		password, err = c.obtainIDToken(ctx, params)
+		if err != nil {
+			return errors.Annotate(err, "cannot log in with identity provider")
+		}
+	}
 	if accountDetails != nil {
 		accountDetails.Password = password
 	} else {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/juju/cmd"
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -38,6 +39,9 @@ func (s *LoginCommandSuite) run(c *gc.C, stdin string, args ...string) (*cmd.Con
 		accountDetails := *argsOut.AccountDetails
 		argsOut.AccountDetails = &accountDetails
 		return s.mockAPI, nil
+	}, func(ctx *cmd.Context, args juju.NewAPIConnectionParams) (string, error) {
+		s.mockAPI.AddCall("ObtainIDToken", args.AccountDetails)
+		return "id-token", s.mockAPI.NextErr()
 	}, s.store)
 	ctx := coretesting.Context(c)
 	if stdin == "" {
@@ -72,7 +76,7 @@ func (s *LoginCommandSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, command := user.NewLoginCommandForTest(nil, s.store)
+		wrappedCommand, command := user.NewLoginCommandForTest(nil, nil, s.store)
 		err := coretesting.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(command.User, gc.Equals, test.user)
@@ -139,6 +143,35 @@ func (s *LoginCommandSuite) TestLoginFail(c *gc.C) {
 	s.assertStoreMacaroon(c, "current-user@local", nil)
 }
 
+func (s *LoginCommandSuite) TestLoginExternalUser(c *gc.C) {
+	err := s.store.RemoveAccount("testing", "current-user@local")
+	c.Assert(err, jc.ErrorIsNil)
+	context, args, err := s.run(c, "", "bob@example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(context), gc.Equals, `
+You are now logged in to "testing" as "bob@example.com".
+`[1:],
+	)
+	s.assertStorePassword(c, "bob@example.com", "")
+	s.assertStoreMacaroon(c, "bob@example.com", fakeLocalLoginMacaroon(names.NewUserTag("bob@example.com")))
+	// The ID token is used in place of a password.
+	c.Assert(args.AccountDetails, jc.DeepEquals, &jujuclient.AccountDetails{
+		User:     "bob@example.com",
+		Password: "id-token",
+	})
+	s.mockAPI.CheckCallNames(c, "ObtainIDToken", "CreateLocalLoginMacaroon")
+}
+
+func (s *LoginCommandSuite) TestLoginExternalUserIdentityProviderFails(c *gc.C) {
+	err := s.store.RemoveAccount("testing", "current-user@local")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.SetErrors(errors.New("no identity provider"))
+	_, _, err = s.run(c, "", "bob@example.com")
+	c.Assert(err, gc.ErrorMatches, "cannot log in with identity provider: no identity provider")
+	_, err = s.store.AccountByName("testing", "bob@example.com")
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotFound)
+}
+
 type mockLoginAPI struct {
 	mockChangePasswordAPI
 }
@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"net/url"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/webbrowser"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/juju"
+	"github.com/juju/juju/oidc"
+)
+
+// obtainIDToken obtains an ID token for an external user by asking the
+// controller which OpenID Connect provider it uses, and then having the
+// user authenticate with the provider in a web browser.
+func obtainIDToken(ctx *cmd.Context, args juju.NewAPIConnectionParams) (string, error) {
+	// The identity provider is discovered before logging in.
+	args.AccountDetails = nil
+	conn, err := juju.NewAPIConnection(args)
+	if err != nil {
+		return "", errors.Annotate(err, "connecting to controller")
+	}
+	defer conn.Close()
+	idp, err := api.IdentityProvider(conn)
+	if params.IsCodeNotFound(err) {
+		return "", errors.New("the controller is not configured with an identity provider for external users")
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	provider, err := oidc.Discover(nil, idp.Issuer)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return oidc.Login(oidc.LoginParams{
+		Provider: provider,
+		ClientID: idp.ClientID,
+		OpenBrowser: func(u *url.URL) error {
+			err := webbrowser.Open(u)
+			if err == nil {
+				ctx.Infof("Opening an authorization web page in your browser.")
+				ctx.Infof("If it does not open, open this URL:\n%s", u)
+				return nil
+			}
+			if err == webbrowser.ErrNoBrowser {
+				ctx.Infof("Open this URL in your browser:\n%s", u)
+				return nil
+			}
+			return errors.Annotate(err, "cannot open web browser")
+		},
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageRemoveUserSummary = `
+Deletes a Juju user from a controller.`[1:]
+
+var usageRemoveUserDetails = `
+Removing a user revokes their access to all models on the controller,
+and any login tokens issued to them can no longer be used. The name
+of a removed local user cannot be reused for a new user.
+
+A user that owns models cannot be removed until the ownership of those
+models has been given to another user with ` + "`juju change-model-owner`" + `.
+An external user that is removed may log in again with their identity
+provider, regaining any access granted to their groups.
+
+Due to the irreversible nature of the command, it will prompt for
+confirmation (unless overridden with the '-y' option) before taking
+any action.
+
+Examples:
+    juju remove-user bob
+    juju remove-user -y bob
+
+See also:
+    users
+    disable-user
+    change-model-owner`[1:]
+
+var removeUserMsg = `
+WARNING! This command will remove the user %q from the %q controller.
+
+Continue [y/N]? `[1:]
+
+// RemoveUserAPI defines the usermanager API methods that the remove
+// command uses.
+type RemoveUserAPI interface {
+	RemoveUser(username string) error
+	Close() error
+}
+
+// NewRemoveCommand returns a command used to remove a user.
+func NewRemoveCommand() cmd.Command {
+	return modelcmd.WrapController(&removeCommand{})
+}
+
+// removeCommand removes a user from a controller.
+type removeCommand struct {
+	modelcmd.ControllerCommandBase
+	api       RemoveUserAPI
+	UserName  string
+	assumeYes bool
+}
+
+// Info implements Command.Info.
+func (c *removeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-user",
+		Args:    "<user name>",
+		Purpose: usageRemoveUserSummary,
+		Doc:     usageRemoveUserDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
+}
+
+// Init implements Command.Init.
+func (c *removeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no username supplied")
+	}
+	c.UserName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *removeCommand) Run(ctx *cmd.Context) error {
+	if !c.assumeYes {
+		fmt.Fprintf(ctx.Stdout, removeUserMsg, c.UserName, c.ControllerName())
+		if err := jujucmd.UserConfirmYes(ctx); err != nil {
+			return errors.Annotate(err, "user removal")
+		}
+	}
+
+	if c.api == nil {
+		api, err := c.NewUserManagerAPIClient()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.api = api
+		defer c.api.Close()
+	}
+
+	if err := c.api.RemoveUser(c.UserName); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("User %q removed", c.UserName)
+	return nil
+}
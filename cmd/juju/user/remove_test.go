@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/testing"
+)
+
+type RemoveUserSuite struct {
+	BaseSuite
+	mock *mockRemoveUserAPI
+}
+
+var _ = gc.Suite(&RemoveUserSuite{})
+
+func (s *RemoveUserSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mock = &mockRemoveUserAPI{}
+}
+
+func (s *RemoveUserSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+		user     string
+	}{
+		{
+			errMatch: "no username supplied",
+		}, {
+			args:     []string{"username", "other"},
+			errMatch: `unrecognized args: \["other"\]`,
+		}, {
+			args: []string{"username"},
+			user: "username",
+		},
+	} {
+		c.Logf("test %d, args %v", i, test.args)
+		wrappedCommand, command := user.NewRemoveCommandForTest(nil, s.store)
+		err := testing.InitCommand(wrappedCommand, test.args)
+		if test.errMatch == "" {
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(command.UserName, gc.Equals, test.user)
+		} else {
+			c.Assert(err, gc.ErrorMatches, test.errMatch)
+		}
+	}
+}
+
+func (s *RemoveUserSuite) TestRemove(c *gc.C) {
+	removeCommand, _ := user.NewRemoveCommandForTest(s.mock, s.store)
+	ctx, err := testing.RunCommand(c, removeCommand, "-y", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.removed, jc.DeepEquals, []string{"bob"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "User \"bob\" removed\n")
+}
+
+func (s *RemoveUserSuite) TestRemovePrompts(c *gc.C) {
+	for _, answer := range []string{"y", "yes"} {
+		s.mock.removed = nil
+		removeCommand, _ := user.NewRemoveCommandForTest(s.mock, s.store)
+		err := testing.InitCommand(removeCommand, []string{"bob"})
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		ctx.Stdin = strings.NewReader(answer)
+		err = removeCommand.Run(ctx)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(testing.Stdout(ctx), gc.Matches, `WARNING! .* remove the user "bob" from the "testing" controller(.|\n)*`)
+		c.Assert(s.mock.removed, jc.DeepEquals, []string{"bob"})
+	}
+}
+
+func (s *RemoveUserSuite) TestRemoveAborted(c *gc.C) {
+	removeCommand, _ := user.NewRemoveCommandForTest(s.mock, s.store)
+	err := testing.InitCommand(removeCommand, []string{"bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	ctx.Stdin = strings.NewReader("n")
+	err = removeCommand.Run(ctx)
+	c.Assert(err, gc.ErrorMatches, "user removal: aborted")
+	c.Assert(s.mock.removed, gc.HasLen, 0)
+}
+
+func (s *RemoveUserSuite) TestRemoveFails(c *gc.C) {
+	s.mock.err = errors.New("cannot remove user \"bob@local\": user owns models \"mine\"")
+	removeCommand, _ := user.NewRemoveCommandForTest(s.mock, s.store)
+	_, err := testing.RunCommand(c, removeCommand, "-y", "bob")
+	c.Assert(err, gc.ErrorMatches, `cannot remove user "bob@local": user owns models "mine"`)
+}
+
+func (s *RemoveUserSuite) TestRemoveBlocked(c *gc.C) {
+	s.mock.err = common.OperationBlockedError("TestRemoveBlocked")
+	removeCommand, _ := user.NewRemoveCommandForTest(s.mock, s.store)
+	_, err := testing.RunCommand(c, removeCommand, "-y", "bob")
+	c.Assert(err, gc.ErrorMatches, cmd.ErrSilent.Error())
+	c.Check(c.GetTestLog(), jc.Contains, "TestRemoveBlocked")
+}
+
+type mockRemoveUserAPI struct {
+	removed []string
+	err     error
+}
+
+func (m *mockRemoveUserAPI) Close() error {
+	return nil
+}
+
+func (m *mockRemoveUserAPI) RemoveUser(username string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.removed = append(m.removed, username)
+	return nil
+}
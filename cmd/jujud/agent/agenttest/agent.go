@@ -210,6 +210,7 @@ func (s *AgentSuite) WriteStateAgentConfig(
 			Cert:         coretesting.ServerCert,
 			PrivateKey:   coretesting.ServerKey,
 			CAPrivateKey: coretesting.CAKey,
+			SecretsKey:   coretesting.SecretsKey,
 			StatePort:    gitjujutesting.MgoServer.Port(),
 			APIPort:      apiPort,
 		})
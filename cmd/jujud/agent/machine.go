@@ -858,6 +858,16 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.State, error)
 	if err != nil {
 		return nil, err
 	}
+	// The key for the secrets stored in the model is held only in
+	// the agent's configuration, so that it never enters the database.
+	if info, ok := agentConfig.StateServingInfo(); ok && info.SecretsKey != "" {
+		if err := st.SetSecretsKey(info.SecretsKey); err != nil {
+			st.Close()
+			return nil, errors.Annotate(err, "cannot set secrets key")
+		}
+	} else {
+		logger.Warningf("no secrets key in agent configuration; charm secrets are unavailable")
+	}
 
 	reportOpenedState(st)
 
@@ -1052,6 +1062,11 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 	dataDir := agentConfig.DataDir()
 	logDir := agentConfig.LogDir()
 
+	rateLimit, err := apiserverRateLimitConfig(agentConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	endpoint := net.JoinHostPort("", strconv.Itoa(info.APIPort))
 	listener, err := net.Listen("tcp", endpoint)
 	if err != nil {
@@ -1065,6 +1080,7 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 		LogDir:      logDir,
 		Validator:   a.limitLogins,
 		CertChanged: certChanged,
+		RateLimit:   rateLimit,
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
@@ -1072,6 +1088,40 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 	return w, nil
 }
 
+// apiserverRateLimitConfig returns the API server limits set in the
+// agent configuration. Any limits not set there take their defaults.
+func apiserverRateLimitConfig(agentConfig agent.Config) (apiserver.RateLimitConfig, error) {
+	var cfg apiserver.RateLimitConfig
+	for key, value := range map[string]*int{
+		agent.APIAgentLoginConcurrency:  &cfg.AgentLoginConcurrency,
+		agent.APIClientLoginConcurrency: &cfg.ClientLoginConcurrency,
+		agent.APIMaxUserConnections:     &cfg.MaxUserConnections,
+		agent.APIUserRequestBurst:       &cfg.UserRequestBurst,
+		agent.APIIPRequestBurst:         &cfg.IPRequestBurst,
+	} {
+		if s := agentConfig.Value(key); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return apiserver.RateLimitConfig{}, errors.Errorf("invalid %s: %q", key, s)
+			}
+			*value = n
+		}
+	}
+	for key, value := range map[string]*float64{
+		agent.APIUserRequestRate: &cfg.UserRequestRate,
+		agent.APIIPRequestRate:   &cfg.IPRequestRate,
+	} {
+		if s := agentConfig.Value(key); s != "" {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return apiserver.RateLimitConfig{}, errors.Errorf("invalid %s: %q", key, s)
+			}
+			*value = f
+		}
+	}
+	return cfg, nil
+}
+
 // limitLogins is called by the API server for each login attempt.
 // it returns an error if upgrades or restore are running.
 func (a *MachineAgent) limitLogins(req params.LoginRequest) error {
@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/machineactions"
+	"github.com/juju/juju/worker/machinefirewall"
 	"github.com/juju/juju/worker/machiner"
 	"github.com/juju/juju/worker/migrationminion"
 	"github.com/juju/juju/worker/proxyupdater"
@@ -397,6 +398,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+
+		machineFirewallName: ifFullyUpgraded(machinefirewall.Manifold(machinefirewall.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     machinefirewall.NewFacade,
+			NewFirewall:   machinefirewall.NewIptablesFirewall,
+			NewWorker:     machinefirewall.NewWorker,
+		})),
 	}
 }
 
@@ -443,4 +452,5 @@ const (
 	apiConfigWatcherName     = "api-config-watcher"
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
+	machineFirewallName      = "machine-firewall"
 )
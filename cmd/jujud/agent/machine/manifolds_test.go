@@ -51,6 +51,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"log-sender",
 		"logging-config-updater",
 		"machine-action-runner",
+		"machine-firewall",
 		"machiner",
 		"mgo-txn-resumer",
 		"migration-fortress",
@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/imagemetadata"
 	apimachiner "github.com/juju/juju/api/machiner"
+	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cert"
 	envtesting "github.com/juju/juju/environs/testing"
@@ -1422,3 +1423,59 @@ func (s *MachineSuite) TestReplicasetInitForNewController(c *gc.C) {
 	c.Assert(s.fakeEnsureMongo.EnsureCount, gc.Equals, 1)
 	c.Assert(s.fakeEnsureMongo.InitiateCount, gc.Equals, 0)
 }
+
+type apiserverRateLimitSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&apiserverRateLimitSuite{})
+
+// valuesAgentConfig is an agent.Config holding only the given values.
+type valuesAgentConfig struct {
+	agent.Config
+	values map[string]string
+}
+
+func (c valuesAgentConfig) Value(key string) string {
+	return c.values[key]
+}
+
+func (s *apiserverRateLimitSuite) TestDefaults(c *gc.C) {
+	cfg, err := apiserverRateLimitConfig(valuesAgentConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, apiserver.RateLimitConfig{})
+}
+
+func (s *apiserverRateLimitSuite) TestValues(c *gc.C) {
+	cfg, err := apiserverRateLimitConfig(valuesAgentConfig{values: map[string]string{
+		agent.APIAgentLoginConcurrency:  "20",
+		agent.APIClientLoginConcurrency: "5",
+		agent.APIMaxUserConnections:     "-1",
+		agent.APIUserRequestRate:        "2.5",
+		agent.APIUserRequestBurst:       "10",
+		agent.APIIPRequestRate:          "5",
+		agent.APIIPRequestBurst:         "20",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, apiserver.RateLimitConfig{
+		AgentLoginConcurrency:  20,
+		ClientLoginConcurrency: 5,
+		MaxUserConnections:     -1,
+		UserRequestRate:        2.5,
+		UserRequestBurst:       10,
+		IPRequestRate:          5,
+		IPRequestBurst:         20,
+	})
+}
+
+func (s *apiserverRateLimitSuite) TestInvalidValues(c *gc.C) {
+	_, err := apiserverRateLimitConfig(valuesAgentConfig{values: map[string]string{
+		agent.APIMaxUserConnections: "lots",
+	}})
+	c.Assert(err, gc.ErrorMatches, `invalid API_MAX_USER_CONNECTIONS: "lots"`)
+
+	_, err = apiserverRateLimitConfig(valuesAgentConfig{values: map[string]string{
+		agent.APIUserRequestRate: "fast",
+	}})
+	c.Assert(err, gc.ErrorMatches, `invalid API_USER_REQUEST_RATE: "fast"`)
+}
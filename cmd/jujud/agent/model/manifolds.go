@@ -193,6 +193,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		spaceImporterName: ifNotDead(discoverspaces.Manifold(discoverspaces.ManifoldConfig{
 			EnvironName:   environTrackerName,
 			APICallerName: apiCallerName,
+			ClockName:     clockName,
 			UnlockerName:  spacesImportedGateName,
 
 			NewFacade: discoverspaces.NewFacade,
@@ -191,11 +191,18 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	// Generate the key for encrypting charm secrets. It is kept only
+	// in the controller agents' configuration.
+	secretsKey, err := state.GenerateSecretsKey()
+	if err != nil {
+		return err
+	}
 	info, ok := agentConfig.StateServingInfo()
 	if !ok {
 		return fmt.Errorf("bootstrap machine config has no state serving info")
 	}
 	info.SharedSecret = sharedSecret
+	info.SecretsKey = secretsKey
 	info.SystemIdentity = privateKey
 	err = c.ChangeConfig(func(agentConfig agent.ConfigSetter) error {
 		agentConfig.SetStateServingInfo(info)
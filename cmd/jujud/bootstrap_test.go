@@ -347,6 +347,7 @@ func (s *BootstrapSuite) TestInitializeEnvironment(c *gc.C) {
 	c.Assert(exists, jc.IsTrue)
 	c.Assert(expectInfo.SharedSecret, gc.Equals, "")
 	c.Assert(expectInfo.SystemIdentity, gc.Equals, "")
+	c.Assert(expectInfo.SecretsKey, gc.Equals, "")
 
 	servingInfo := s.fakeEnsureMongo.Info
 	c.Assert(len(servingInfo.SharedSecret), gc.Not(gc.Equals), 0)
@@ -580,6 +581,34 @@ func (s *BootstrapSuite) TestInitialPassword(c *gc.C) {
 	c.Assert(m.HasVote(), jc.IsTrue)
 }
 
+func (s *BootstrapSuite) TestInitialSecretsKey(c *gc.C) {
+	machineConf, cmd, err := s.initBootstrapCommand(c, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = cmd.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The secrets key is generated and written to the agent
+	// configuration.
+	machineConf1, err := agent.ReadConfig(agent.ConfigPath(machineConf.DataDir(), names.NewMachineTag("0")))
+	c.Assert(err, jc.ErrorIsNil)
+	info, ok := machineConf1.StateServingInfo()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(info.SecretsKey, gc.Not(gc.Equals), "")
+
+	st, err := state.Open(testing.ModelTag, &mongo.MongoInfo{
+		Info: mongo.Info{
+			Addrs:  []string{gitjujutesting.MgoServer.Addr()},
+			CACert: testing.CACert,
+		},
+		Password: testPassword,
+	}, mongotest.DialOpts(), environs.NewStatePolicy())
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	err = st.SetSecretsKey(info.SecretsKey)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 var bootstrapArgTests = []struct {
 	input                       []string
 	err                         string
@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	cmdutil "github.com/juju/juju/cmd/jujud/util"
+)
+
+const machineLockDoc = `
+Report the operations holding, and those waiting for, the machine lock
+that governs hook execution and other operations on this machine.
+`
+
+// MachineLockCommand reports on the state of the machine lock, to help
+// diagnose machines on which hooks are not running.
+type MachineLockCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+}
+
+// NewMachineLockCommand returns a new MachineLockCommand.
+func NewMachineLockCommand() *MachineLockCommand {
+	return &MachineLockCommand{}
+}
+
+// Info returns a description of the command.
+func (*MachineLockCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "machine-lock",
+		Purpose: "report who holds and who is waiting for the machine lock",
+		Doc:     machineLockDoc,
+	}
+}
+
+// SetFlags adds the flags for this command to the passed gnuflag.FlagSet.
+func (c *MachineLockCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init initializes the command for running.
+func (c *MachineLockCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run writes a report on the machine lock.
+func (c *MachineLockCommand) Run(ctx *cmd.Context) error {
+	lock, err := cmdutil.HookExecutionLock(cmdutil.DataDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	report, err := lock.Report()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, machineLockReport{
+		Holders: append([]string{}, report.Holders...),
+		Waiting: append([]string{}, report.Waiting...),
+	})
+}
+
+// machineLockReport is the serialisation of a machinelock.Report.
+type machineLockReport struct {
+	Holders []string `yaml:"holders" json:"holders"`
+	Waiting []string `yaml:"waiting" json:"waiting"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/machinelock"
+)
+
+type MachineLockSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&MachineLockSuite{})
+
+func (s *MachineLockSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.PatchValue(&cmdutil.DataDir, c.MkDir())
+}
+
+func (s *MachineLockSuite) TestArgs(c *gc.C) {
+	err := testing.InitCommand(NewMachineLockCommand(), []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *MachineLockSuite) TestNotHeld(c *gc.C) {
+	ctx, err := testing.RunCommand(c, NewMachineLockCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "holders: []\nwaiting: []\n")
+}
+
+func (s *MachineLockSuite) TestHeld(c *gc.C) {
+	lock, err := cmdutil.HookExecutionLock(cmdutil.DataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	release, err := lock.Acquire(machinelock.Spec{
+		Owner:   "unit-mysql-0",
+		Message: "running install hook",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer release()
+
+	ctx, err := testing.RunCommand(c, NewMachineLockCommand(), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `{"holders":["unit-mysql-0: running install hook"],"waiting":[]}`+"\n")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/agent"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/uniter"
 	jujuos "github.com/juju/utils/os"
 )
@@ -176,11 +177,15 @@ func (c *RunCommand) executeNoContext() (*exec.ExecResponse, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	err = lock.Lock("juju-run")
+	release, err := lock.Acquire(machinelock.Spec{
+		Owner:     "juju-run",
+		Message:   "running commands",
+		Exclusive: true,
+	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	defer lock.Unlock()
+	defer release()
 
 	runCmd := c.appendProxyToCommands()
 
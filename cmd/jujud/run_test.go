@@ -21,6 +21,7 @@ import (
 
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/uniter"
 	jujuos "github.com/juju/utils/os"
 )
@@ -180,14 +181,18 @@ func (s *RunTestSuite) TestNoContextAsync(c *gc.C) {
 func (s *RunTestSuite) TestNoContextWithLock(c *gc.C) {
 	lock, err := cmdutil.HookExecutionLock(cmdutil.DataDir)
 	c.Assert(err, jc.ErrorIsNil)
-	lock.Lock("juju-run test")
-	defer lock.Unlock() // in case of failure
+	release, err := lock.Acquire(machinelock.Spec{
+		Owner:   "juju-run-test",
+		Message: "testing",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer release() // in case of failure
 
 	channel := startRunAsync(c, []string{"--no-context", "echo done"})
 	ctx, err := waitForResult(channel, testing.ShortWait)
 	c.Assert(err, gc.ErrorMatches, "timeout")
 
-	lock.Unlock()
+	release()
 
 	ctx, err = waitForResult(channel, testing.LongWait)
 	c.Assert(err, jc.ErrorIsNil)
@@ -6,12 +6,10 @@ package util
 import (
 	"fmt"
 	"io"
-	"path/filepath"
 	"strconv"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
-	"github.com/juju/utils/fslock"
 	"github.com/juju/utils/series"
 
 	"github.com/juju/juju/agent"
@@ -20,6 +18,7 @@ import (
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/upgrader"
 )
 
@@ -227,12 +226,11 @@ func (c *CloseWorker) Wait() error {
 	return err
 }
 
-// HookExecutionLock returns an *fslock.Lock suitable for use as a
-// unit hook execution lock. Other workers may also use this lock if
-// they require isolation from hook execution.
-func HookExecutionLock(dataDir string) (*fslock.Lock, error) {
-	lockDir := filepath.Join(dataDir, "locks")
-	return fslock.NewLock(lockDir, "uniter-hook-execution", fslock.Defaults())
+// HookExecutionLock returns the machine lock that governs unit hook
+// execution. Other workers may also use this lock if they require
+// isolation from hook execution.
+func HookExecutionLock(dataDir string) (*machinelock.Lock, error) {
+	return machinelock.New(machinelock.DefaultConfig(dataDir))
 }
 
 // ParamsStateServingInfoToStateStateServingInfo converts a
@@ -20,6 +20,7 @@ var (
 	PreferFastLXC              = preferFastLXC
 	WriteWgetTmpFile           = &writeWgetTmpFile
 	NewContainerManagerForTest = newContainerManager
+	ConstraintLimits           = constraintLimits
 )
 
 func GetCreateWithCloneValue(mgr container.Manager) bool {
@@ -320,6 +320,12 @@ func (manager *containerManager) CreateContainer(
 			return nil, nil, errors.Annotate(err, "failed to configure the container for loopback devices")
 		}
 	}
+	limitsConfig, hardware := constraintLimits(cons)
+	if limitsConfig != "" {
+		if err := appendToContainerConfig(name, limitsConfig); err != nil {
+			return nil, nil, errors.Annotate(err, "failed to configure the container resource limits")
+		}
+	}
 	// Update the network settings inside the run-time config of the
 	// container (e.g. /var/lib/lxc/<name>/config) before starting it.
 	netConfig := generateNetworkConfig(networkConfig)
@@ -385,11 +391,6 @@ func (manager *containerManager) CreateContainer(
 		return nil, nil, errors.Annotate(err, "container failed to start")
 	}
 
-	arch := arch.HostArch()
-	hardware := &instance.HardwareCharacteristics{
-		Arch: &arch,
-	}
-
 	callback(status.StatusRunning, "Container started", nil)
 	return &lxcInstance{lxcContainer, name}, hardware, nil
 }
@@ -765,6 +766,41 @@ lxc.cgroup.devices.allow = c 10:237 rwm
 	return appendToContainerConfig(name, allowLoopDevicesCfg)
 }
 
+// cpuCFSPeriod is the CFS scheduler period, in microseconds, used when
+// limiting the CPU time available to a container.
+const cpuCFSPeriod = 100000
+
+// constraintLimits translates the given constraints into cgroup
+// settings for the container config, and returns the hardware
+// characteristics the container will have once those limits apply.
+// CPU cores are enforced as a CFS quota rather than a cpuset, so that
+// the host scheduler remains free to balance containers across all
+// of its CPUs.
+func constraintLimits(cons constraints.Value) (string, *instance.HardwareCharacteristics) {
+	hostArch := arch.HostArch()
+	hardware := &instance.HardwareCharacteristics{
+		Arch: &hostArch,
+	}
+	var config []string
+	if cons.Mem != nil && *cons.Mem > 0 {
+		mem := *cons.Mem
+		config = append(config, fmt.Sprintf("lxc.cgroup.memory.limit_in_bytes = %dM", mem))
+		hardware.Mem = &mem
+	}
+	if cons.CpuCores != nil && *cons.CpuCores > 0 {
+		cores := *cons.CpuCores
+		config = append(config,
+			fmt.Sprintf("lxc.cgroup.cpu.cfs_period_us = %d", cpuCFSPeriod),
+			fmt.Sprintf("lxc.cgroup.cpu.cfs_quota_us = %d", cores*cpuCFSPeriod),
+		)
+		hardware.CpuCores = &cores
+	}
+	if len(config) == 0 {
+		return "", hardware
+	}
+	return "\n" + strings.Join(config, "\n") + "\n", hardware
+}
+
 func (manager *containerManager) DestroyContainer(id instance.Id) error {
 	start := time.Now()
 	name := string(id)
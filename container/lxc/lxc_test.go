@@ -25,6 +25,7 @@ import (
 	"launchpad.net/golxc"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/lxc"
 	"github.com/juju/juju/container/lxc/mock"
@@ -262,6 +263,25 @@ func (*LxcSuite) TestParseConfigLine(c *gc.C) {
 	}
 }
 
+func (s *LxcSuite) TestConstraintLimitsEmpty(c *gc.C) {
+	config, hardware := lxc.ConstraintLimits(constraints.Value{})
+	c.Assert(config, gc.Equals, "")
+	c.Assert(hardware.Arch, gc.NotNil)
+	c.Assert(hardware.Mem, gc.IsNil)
+	c.Assert(hardware.CpuCores, gc.IsNil)
+}
+
+func (s *LxcSuite) TestConstraintLimits(c *gc.C) {
+	config, hardware := lxc.ConstraintLimits(constraints.MustParse("mem=1G cpu-cores=2"))
+	c.Assert(config, gc.Equals, `
+lxc.cgroup.memory.limit_in_bytes = 1024M
+lxc.cgroup.cpu.cfs_period_us = 100000
+lxc.cgroup.cpu.cfs_quota_us = 200000
+`)
+	c.Assert(*hardware.Mem, gc.Equals, uint64(1024))
+	c.Assert(*hardware.CpuCores, gc.Equals, uint64(2))
+}
+
 func (s *LxcSuite) TestUpdateContainerConfig(c *gc.C) {
 	networkConfig := container.BridgeNetworkConfig("nic42", 4321, []network.InterfaceInfo{{
 		DeviceIndex:    0,
@@ -6,6 +6,7 @@
 package lxd
 
 var (
-	NICDevice      = nicDevice
-	NetworkDevices = networkDevices
+	NICDevice        = nicDevice
+	NetworkDevices   = networkDevices
+	ConstraintLimits = constraintLimits
 )
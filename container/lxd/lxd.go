@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/arch"
 
 	"github.com/juju/juju/cloudconfig/containerinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
@@ -152,10 +153,14 @@ func (manager *containerManager) CreateContainer(
 		logger.Infof("instance %q configured with %v network devices", name, nics)
 	}
 
+	limits, hardware := constraintLimits(cons)
+	logger.Debugf("instance %q configured with limits %v", name, limits)
+
 	spec := lxdclient.InstanceSpec{
 		Name:     name,
 		Image:    manager.client.ImageNameForSeries(series),
 		Metadata: metadata,
+		Limits:   limits,
 		Devices:  nics,
 		Profiles: profiles,
 	}
@@ -169,7 +174,7 @@ func (manager *containerManager) CreateContainer(
 
 	callback(status.StatusRunning, "Container started", nil)
 	inst = &lxdInstance{name, manager.client}
-	return
+	return inst, hardware, nil
 }
 
 func (manager *containerManager) DestroyContainer(id instance.Id) error {
@@ -222,6 +227,39 @@ func HasLXDSupport() bool {
 	return true
 }
 
+// constraintLimits translates the given constraints into the LXD
+// resource limits that enforce them, and returns the hardware
+// characteristics the container will have once those limits apply.
+// Constraints that LXD cannot enforce are logged and ignored.
+func constraintLimits(cons constraints.Value) (map[string]string, *instance.HardwareCharacteristics) {
+	hostArch := arch.HostArch()
+	hardware := &instance.HardwareCharacteristics{
+		Arch: &hostArch,
+	}
+	limits := make(map[string]string)
+
+	if cons.Mem != nil && *cons.Mem > 0 {
+		mem := *cons.Mem
+		limits["limits.memory"] = fmt.Sprintf("%dMB", mem)
+		hardware.Mem = &mem
+	}
+	if cons.CpuCores != nil && *cons.CpuCores > 0 {
+		cores := *cons.CpuCores
+		limits["limits.cpu"] = fmt.Sprintf("%d", cores)
+		hardware.CpuCores = &cores
+	}
+	if cons.Arch != nil && *cons.Arch != hostArch {
+		logger.Infof("arch constraint of %q being ignored as not supported", *cons.Arch)
+	}
+	if cons.RootDisk != nil {
+		logger.Infof("root-disk constraint of %v being ignored as not supported", *cons.RootDisk)
+	}
+	if cons.CpuPower != nil {
+		logger.Infof("cpu-power constraint of %v being ignored as not supported", *cons.CpuPower)
+	}
+	return limits, hardware
+}
+
 func nicDevice(deviceName, parentDevice, hwAddr string, mtu int) (lxdclient.Device, error) {
 	device := make(lxdclient.Device)
 
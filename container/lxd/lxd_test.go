@@ -10,6 +10,7 @@ import (
 	stdtesting "testing"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/constraints"
@@ -198,3 +199,24 @@ func (t *LxdSuite) TestNetworkDevicesWithEmptyParentDevice(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, jc.DeepEquals, expected)
 }
+
+func (t *LxdSuite) TestConstraintLimitsEmpty(c *gc.C) {
+	limits, hardware := lxd.ConstraintLimits(constraints.Value{})
+	c.Assert(limits, gc.HasLen, 0)
+	c.Assert(hardware.Arch, gc.NotNil)
+	c.Assert(*hardware.Arch, gc.Equals, arch.HostArch())
+	c.Assert(hardware.Mem, gc.IsNil)
+	c.Assert(hardware.CpuCores, gc.IsNil)
+}
+
+func (t *LxdSuite) TestConstraintLimits(c *gc.C) {
+	cons := constraints.MustParse("mem=2G cpu-cores=4 root-disk=10G")
+	limits, hardware := lxd.ConstraintLimits(cons)
+	c.Assert(limits, jc.DeepEquals, map[string]string{
+		"limits.memory": "2048MB",
+		"limits.cpu":    "4",
+	})
+	c.Assert(*hardware.Mem, gc.Equals, uint64(2048))
+	c.Assert(*hardware.CpuCores, gc.Equals, uint64(4))
+	c.Assert(hardware.RootDisk, gc.IsNil)
+}
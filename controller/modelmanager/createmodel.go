@@ -13,6 +13,7 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/version"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/tools"
@@ -41,6 +42,32 @@ type ModelConfigCreator struct {
 	// If FindTools is nil, agent-version may not be different to the
 	// base configuration.
 	FindTools func(version.Number) (tools.List, error)
+
+	// Cloud, if non-nil, describes the cloud region the model is to
+	// be created in, when that differs from the controller's. The
+	// model config is then built for the cloud's provider, rather
+	// than copied from the controller's.
+	Cloud *CloudSpec
+}
+
+// CloudSpec describes a cloud region in which a model is created.
+type CloudSpec struct {
+	// Type is the provider type of the cloud.
+	Type string
+
+	// Region is the name of the cloud region, if the cloud has
+	// regions.
+	Region string
+
+	// Endpoint is the API endpoint for the cloud region.
+	Endpoint string
+
+	// StorageEndpoint is the storage API endpoint for the cloud
+	// region, if it has one.
+	StorageEndpoint string
+
+	// Credential is the credential used to access the cloud.
+	Credential cloud.Credential
 }
 
 // NewModelConfig returns a new model config given a base (controller) config
@@ -69,7 +96,7 @@ func (c ModelConfigCreator) NewModelConfig(
 	// However, before we can create a valid config, we need to make sure
 	// we copy across fields from the main config that aren't there.
 	baseAttrs := base.AllAttrs()
-	restrictedFields, err := RestrictedProviderFields(base.Type())
+	restrictedFields, err := c.restrictedFields(base)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -90,7 +117,12 @@ func (c ModelConfigCreator) NewModelConfig(
 		}
 		attrs[config.UUIDKey] = uuid.String()
 	}
-	cfg, err := finalizeConfig(isAdmin, base, attrs)
+	var cfg *config.Config
+	if c.Cloud != nil {
+		cfg, err = finalizeCloudConfig(isAdmin, base, *c.Cloud, attrs)
+	} else {
+		cfg, err = finalizeConfig(isAdmin, base, attrs)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -166,6 +198,16 @@ func (c *ModelConfigCreator) checkVersion(base *config.Config, attrs map[string]
 	return nil
 }
 
+// restrictedFields returns the set of config fields that may not differ
+// from the base configuration. Models in other clouds may only not
+// override the controller attributes.
+func (c *ModelConfigCreator) restrictedFields(base *config.Config) ([]string, error) {
+	if c.Cloud != nil {
+		return config.ControllerOnlyConfigAttributes, nil
+	}
+	return RestrictedProviderFields(base.Type())
+}
+
 // RestrictedProviderFields returns the set of config fields that may not be
 // overridden.
 func RestrictedProviderFields(providerType string) ([]string, error) {
@@ -211,6 +253,48 @@ func finalizeConfig(isAdmin bool, controllerCfg *config.Config, attrs map[string
 	return cfg, nil
 }
 
+// finalizeCloudConfig creates the config object from attributes for a
+// model in the specified cloud region, applies the cloud's region,
+// endpoints and credential with the cloud's provider, and then
+// validates the config before returning it.
+func finalizeCloudConfig(isAdmin bool, controllerCfg *config.Config, spec CloudSpec, attrs map[string]interface{}) (*config.Config, error) {
+	provider, err := environs.Provider(spec.Type)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs[config.TypeKey] = spec.Type
+
+	// Controller admins need not re-supply their authorized keys,
+	// but the controller's credentials are never copied to models
+	// in other clouds.
+	if _, ok := attrs[config.AuthKeysConfig]; !ok && isAdmin {
+		attrs[config.AuthKeysConfig] = controllerCfg.AuthorizedKeys()
+	}
+	cfg, err := config.New(config.UseDefaults, attrs)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating config from values failed")
+	}
+	cfg, err = provider.BootstrapConfig(environs.BootstrapConfigParams{
+		Config:               cfg,
+		Credentials:          spec.Credential,
+		CloudRegion:          spec.Region,
+		CloudEndpoint:        spec.Endpoint,
+		CloudStorageEndpoint: spec.StorageEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "applying cloud region")
+	}
+	cfg, err = provider.PrepareForCreateEnvironment(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err = provider.Validate(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "provider validation failed")
+	}
+	return cfg, nil
+}
+
 // maybeCopyControllerSecrets asks the specified provider for all possible config
 // attributes representing credential values and copies those across from the
 // controller config into the new model's config attrs if not already present.
@@ -120,6 +120,58 @@ func (s *ModelConfigCreatorSuite) TestCreateModelForAdminUserCopiesSecrets(c *gc
 	c.Assert(validateCall.Args[1], gc.IsNil)
 }
 
+func (s *ModelConfigCreatorSuite) TestCreateModelInOtherCloud(c *gc.C) {
+	var err error
+	s.baseConfig, err = s.baseConfig.Apply(coretesting.Attrs{
+		"username":        "user",
+		"password":        "password",
+		"authorized-keys": "ssh-key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.creator.Cloud = &modelmanager.CloudSpec{
+		Type:     "fake",
+		Region:   "north",
+		Endpoint: "https://north.example.com",
+		Credential: cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+			"username": "bob",
+			"password": "sekrit",
+		}),
+	}
+	cfg, err := s.newModelConfigAdmin(coretesting.Attrs{
+		"name":       "new-model",
+		"uuid":       utils.MustNewUUID().String(),
+		"restricted": "roswell",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Provider restricted attributes may differ from the controller's,
+	// and the cloud region and credential are applied by the provider.
+	attrs := cfg.AllAttrs()
+	c.Assert(attrs["restricted"], gc.Equals, "roswell")
+	c.Assert(attrs["region"], gc.Equals, "north")
+	c.Assert(attrs["endpoint"], gc.Equals, "https://north.example.com")
+	c.Assert(attrs["username"], gc.Equals, "bob")
+	c.Assert(attrs["password"], gc.Equals, "sekrit")
+	c.Assert(attrs["authorized-keys"], gc.Equals, "ssh-key")
+	c.Assert(attrs["ca-cert"], gc.Equals, s.baseConfig.AllAttrs()["ca-cert"])
+
+	fake.Stub.CheckCallNames(c,
+		"BootstrapConfig",
+		"PrepareForCreateEnvironment",
+		"Validate",
+	)
+}
+
+func (s *ModelConfigCreatorSuite) TestCreateModelInOtherCloudControllerAttrs(c *gc.C) {
+	s.creator.Cloud = &modelmanager.CloudSpec{Type: "fake"}
+	_, err := s.newModelConfig(coretesting.Attrs{
+		"name":            "new-model",
+		"api-port":        12345,
+		"authorized-keys": "ssh-key",
+	})
+	c.Assert(err, gc.ErrorMatches, `specified api-port "12345" does not match controller "17777"`)
+}
+
 func (s *ModelConfigCreatorSuite) TestCreateModelEnsuresRequiredFields(c *gc.C) {
 	var err error
 	s.baseConfig, err = s.baseConfig.Apply(coretesting.Attrs{
@@ -345,6 +397,21 @@ func (p *fakeProvider) Validate(cfg, old *config.Config) (*config.Config, error)
 	return cfg, p.NextErr()
 }
 
+func (p *fakeProvider) BootstrapConfig(args environs.BootstrapConfigParams) (*config.Config, error) {
+	p.MethodCall(p, "BootstrapConfig", args)
+	if err := p.NextErr(); err != nil {
+		return nil, err
+	}
+	attrs := map[string]interface{}{
+		"region":   args.CloudRegion,
+		"endpoint": args.CloudEndpoint,
+	}
+	for k, v := range args.Credentials.Attributes() {
+		attrs[k] = v
+	}
+	return args.Config.Apply(attrs)
+}
+
 func (p *fakeProvider) PrepareForCreateEnvironment(cfg *config.Config) (*config.Config, error) {
 	p.MethodCall(p, "PrepareForCreateEnvironment", cfg)
 	return cfg, p.NextErr()
@@ -49,6 +49,11 @@ type Model interface {
 	// block.
 	Blocks() map[string]string
 
+	// EntityBlocks returns the blocks on individual applications and
+	// machines, as a map of entity tag to a map of block type to the
+	// message associated with that block.
+	EntityBlocks() map[string]map[string]string
+
 	Users() []User
 	AddUser(UserArgs)
 
@@ -217,6 +222,7 @@ type Application interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	Trusted() bool
 	MinUnits() int
 
 	Settings() map[string]interface{}
@@ -253,6 +259,10 @@ type Unit interface {
 	MeterStatusCode() string
 	MeterStatusInfo() string
 
+	State() map[string]string
+
+	WorkloadVersion() string
+
 	// TODO: storage
 
 	Tools() AgentTools
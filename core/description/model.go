@@ -21,6 +21,7 @@ type ModelArgs struct {
 	Config             map[string]interface{}
 	LatestToolsVersion version.Number
 	Blocks             map[string]string
+	EntityBlocks       map[string]map[string]string
 	CloudRegion        string
 }
 
@@ -33,6 +34,7 @@ func NewModel(args ModelArgs) Model {
 		LatestToolsVersion_: args.LatestToolsVersion,
 		Sequences_:          make(map[string]int),
 		Blocks_:             args.Blocks,
+		EntityBlocks_:       args.EntityBlocks,
 		CloudRegion_:        args.CloudRegion,
 	}
 	m.setUsers(nil)
@@ -72,6 +74,8 @@ type model struct {
 	Config_ map[string]interface{} `yaml:"config"`
 	Blocks_ map[string]string      `yaml:"blocks,omitempty"`
 
+	EntityBlocks_ map[string]map[string]string `yaml:"entity-blocks,omitempty"`
+
 	LatestToolsVersion_ version.Number `yaml:"latest-tools,omitempty"`
 
 	Users_        users        `yaml:"users"`
@@ -131,6 +135,11 @@ func (m *model) Blocks() map[string]string {
 	return m.Blocks_
 }
 
+// EntityBlocks implements Model.
+func (m *model) EntityBlocks() map[string]map[string]string {
+	return m.EntityBlocks_
+}
+
 // Implement length-based sort with ByLen type.
 type ByName []User
 
@@ -361,12 +370,16 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"applications": schema.StringMap(schema.Any()),
 		"relations":    schema.StringMap(schema.Any()),
 		"sequences":    schema.StringMap(schema.Int()),
+
+		"entity-blocks": schema.StringMap(schema.StringMap(schema.String())),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
 		"latest-tools": schema.Omit,
 		"blocks":       schema.Omit,
 		"cloud-region": schema.Omit,
+
+		"entity-blocks": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -388,6 +401,12 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		Blocks_:      convertToStringMap(valid["blocks"]),
 		CloudRegion_: valid["cloud-region"].(string),
 	}
+	if entityBlocks, ok := valid["entity-blocks"]; ok {
+		result.EntityBlocks_ = make(map[string]map[string]string)
+		for tag, blocks := range entityBlocks.(map[string]interface{}) {
+			result.EntityBlocks_[tag] = convertToStringMap(blocks)
+		}
+	}
 	result.importAnnotations(valid)
 	sequences := valid["sequences"].(map[string]interface{})
 	for key, value := range sequences {
@@ -85,6 +85,9 @@ func (s *ModelSerializationSuite) TestParsingYAML(c *gc.C) {
 		Blocks: map[string]string{
 			"all-changes": "locked down",
 		},
+		EntityBlocks: map[string]map[string]string{
+			"application-ubuntu": {"remove-object": "keep it"},
+		},
 	}
 	initial := NewModel(args)
 	adminUser := names.NewUserTag("admin@local")
@@ -102,6 +105,7 @@ func (s *ModelSerializationSuite) TestParsingYAML(c *gc.C) {
 	c.Assert(model.Config(), jc.DeepEquals, args.Config)
 	c.Assert(model.LatestToolsVersion(), gc.Equals, args.LatestToolsVersion)
 	c.Assert(model.Blocks(), jc.DeepEquals, args.Blocks)
+	c.Assert(model.EntityBlocks(), jc.DeepEquals, args.EntityBlocks)
 	users := model.Users()
 	c.Assert(users, gc.HasLen, 1)
 	c.Assert(users[0].Name(), gc.Equals, adminUser)
@@ -30,6 +30,7 @@ type application struct {
 	// It means upgrade even if the charm is in an error state.
 	ForceCharm_ bool `yaml:"force-charm,omitempty"`
 	Exposed_    bool `yaml:"exposed,omitempty"`
+	Trusted_    bool `yaml:"trusted,omitempty"`
 	MinUnits_   int  `yaml:"min-units,omitempty"`
 
 	Status_        *status `yaml:"status"`
@@ -63,6 +64,7 @@ type ApplicationArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	Trusted              bool
 	MinUnits             int
 	Settings             map[string]interface{}
 	SettingsRefCount     int
@@ -82,6 +84,7 @@ func newApplication(args ApplicationArgs) *application {
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
+		Trusted_:              args.Trusted,
 		MinUnits_:             args.MinUnits,
 		Settings_:             args.Settings,
 		SettingsRefCount_:     args.SettingsRefCount,
@@ -139,6 +142,11 @@ func (s *application) Exposed() bool {
 	return s.Exposed_
 }
 
+// Trusted implements Application.
+func (s *application) Trusted() bool {
+	return s.Trusted_
+}
+
 // MinUnits implements Application.
 func (s *application) MinUnits() int {
 	return s.MinUnits_
@@ -307,6 +315,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"trusted":             schema.Bool(),
 		"min-units":           schema.Int(),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
@@ -321,6 +330,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"subordinate":   false,
 		"force-charm":   false,
 		"exposed":       false,
+		"trusted":       false,
 		"min-units":     int64(0),
 		"leader":        "",
 		"metrics-creds": "",
@@ -346,6 +356,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		CharmModifiedVersion_: int(valid["charm-mod-version"].(int64)),
 		ForceCharm_:           valid["force-charm"].(bool),
 		Exposed_:              valid["exposed"].(bool),
+		Trusted_:              valid["trusted"].(bool),
 		MinUnits_:             int(valid["min-units"].(int64)),
 		Settings_:             valid["settings"].(map[string]interface{}),
 		SettingsRefCount_:     int(valid["settings-refcount"].(int64)),
@@ -110,6 +110,7 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 		CharmModifiedVersion: 1,
 		ForceCharm:           true,
 		Exposed:              true,
+		Trusted:              true,
 		MinUnits:             42, // no judgement is made by the migration code
 		Settings: map[string]interface{}{
 			"key": "value",
@@ -132,6 +133,7 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 	c.Assert(application.CharmModifiedVersion(), gc.Equals, 1)
 	c.Assert(application.ForceCharm(), jc.IsTrue)
 	c.Assert(application.Exposed(), jc.IsTrue)
+	c.Assert(application.Trusted(), jc.IsTrue)
 	c.Assert(application.MinUnits(), gc.Equals, 42)
 	c.Assert(application.Settings(), jc.DeepEquals, args.Settings)
 	c.Assert(application.SettingsRefCount(), gc.Equals, 1)
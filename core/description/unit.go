@@ -38,6 +38,10 @@ type unit struct {
 	MeterStatusCode_ string `yaml:"meter-status-code,omitempty"`
 	MeterStatusInfo_ string `yaml:"meter-status-info,omitempty"`
 
+	State_ map[string]string `yaml:"state,omitempty"`
+
+	WorkloadVersion_ string `yaml:"workload-version,omitempty"`
+
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
@@ -54,6 +58,13 @@ type UnitArgs struct {
 	MeterStatusCode string
 	MeterStatusInfo string
 
+	// State holds the key/value pairs stored by the unit's charm.
+	State map[string]string
+
+	// WorkloadVersion is the version of the workload reported by the
+	// unit's charm.
+	WorkloadVersion string
+
 	// TODO: storage attachment count
 }
 
@@ -70,6 +81,8 @@ func newUnit(args UnitArgs) *unit {
 		Subordinates_:          subordinates,
 		MeterStatusCode_:       args.MeterStatusCode,
 		MeterStatusInfo_:       args.MeterStatusInfo,
+		State_:                 args.State,
+		WorkloadVersion_:       args.WorkloadVersion,
 		WorkloadStatusHistory_: newStatusHistory(),
 		AgentStatusHistory_:    newStatusHistory(),
 	}
@@ -122,6 +135,16 @@ func (u *unit) MeterStatusInfo() string {
 	return u.MeterStatusInfo_
 }
 
+// State implements Unit.
+func (u *unit) State() map[string]string {
+	return u.State_
+}
+
+// WorkloadVersion implements Unit.
+func (u *unit) WorkloadVersion() string {
+	return u.WorkloadVersion_
+}
+
 // Tools implements Unit.
 func (u *unit) Tools() AgentTools {
 	// To avoid a typed nil, check before returning.
@@ -271,12 +294,18 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 
 		"meter-status-code": schema.String(),
 		"meter-status-info": schema.String(),
+
+		"state": schema.StringMap(schema.String()),
+
+		"workload-version": schema.String(),
 	}
 	defaults := schema.Defaults{
 		"principal":         "",
 		"subordinates":      schema.Omit,
 		"meter-status-code": "",
 		"meter-status-info": "",
+		"state":             schema.Omit,
+		"workload-version":  "",
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -297,6 +326,7 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 		PasswordHash_:          valid["password-hash"].(string),
 		MeterStatusCode_:       valid["meter-status-code"].(string),
 		MeterStatusInfo_:       valid["meter-status-info"].(string),
+		WorkloadVersion_:       valid["workload-version"].(string),
 		WorkloadStatusHistory_: newStatusHistory(),
 		AgentStatusHistory_:    newStatusHistory(),
 	}
@@ -320,6 +350,7 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 	}
 
 	result.Subordinates_ = convertToStringSlice(valid["subordinates"])
+	result.State_ = convertToStringMap(valid["state"])
 
 	// Tools and status are required, so we expect them to be there.
 	tools, err := importAgentTools(valid["tools"].(map[string]interface{}))
@@ -72,6 +72,8 @@ func (s *UnitSerializationSuite) completeUnit() *unit {
 		},
 		MeterStatusCode: "meter code",
 		MeterStatusInfo: "meter info",
+		State:           map[string]string{"initialised": "true"},
+		WorkloadVersion: "1.2.3",
 	}
 	unit := newUnit(args)
 	unit.SetAgentStatus(minimalStatusArgs())
@@ -94,6 +96,8 @@ func (s *UnitSerializationSuite) TestNewUnit(c *gc.C) {
 	})
 	c.Assert(unit.MeterStatusCode(), gc.Equals, "meter code")
 	c.Assert(unit.MeterStatusInfo(), gc.Equals, "meter info")
+	c.Assert(unit.State(), jc.DeepEquals, map[string]string{"initialised": "true"})
+	c.Assert(unit.WorkloadVersion(), gc.Equals, "1.2.3")
 	c.Assert(unit.Tools(), gc.NotNil)
 	c.Assert(unit.WorkloadStatus(), gc.NotNil)
 	c.Assert(unit.AgentStatus(), gc.NotNil)
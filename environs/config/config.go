@@ -46,6 +46,13 @@ const (
 	// instance security groups.
 	FwNone = "none"
 
+	// FwMachine requests that each machine agent manages the host
+	// firewall of its own machine, allowing in only the ports opened
+	// by units of exposed applications. Providers with security groups
+	// also open those ports in a single group for all instances, as in
+	// FwGlobal mode.
+	FwMachine = "machine"
+
 	// DefaultStatePort is the default port the controller is listening on.
 	DefaultStatePort int = 37017
 
@@ -89,6 +96,10 @@ const (
 	// config setting. Only non-zero, positive integer values will
 	// have effect.
 	DefaultLXCDefaultMTU = 0
+
+	// DefaultIdentityGroupsClaim is the default value for
+	// "identity-groups-claim" config setting.
+	DefaultIdentityGroupsClaim = "groups"
 )
 
 // TODO(katco-): Please grow this over time.
@@ -203,10 +214,35 @@ const (
 	// IdentityPublicKey sets the public key of the identity manager.
 	IdentityPublicKey = "identity-public-key"
 
+	// IdentityClientID, when set, makes the controller treat the
+	// identity manager as an OpenID Connect provider, accepting ID
+	// tokens issued to this client id.
+	IdentityClientID = "identity-client-id"
+
+	// IdentityGroupsClaim sets the ID token claim that lists the
+	// groups of an external user.
+	IdentityGroupsClaim = "identity-groups-claim"
+
+	// IdentityGroupAccess maps groups of external users to the level
+	// of access they are granted on the model, in the form
+	// "group=access[,group=access...]".
+	IdentityGroupAccess = "identity-group-access"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// HookTimeout, when set to a positive duration, bounds how long
+	// the uniter lets any hook or action run before killing it. It
+	// overrides any default declared by the charm.
+	HookTimeout = "hook-timeout"
+
+	// MachineLockSlots, when set to a positive integer, is the number
+	// of hooks and other operations that may hold the machine lock at
+	// once on each machine. It is passed to the agents of a machine
+	// when the machine is provisioned.
+	MachineLockSlots = "machine-lock-slots"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -242,6 +278,8 @@ var ControllerOnlyConfigAttributes = []string{
 	ControllerUUIDKey,
 	IdentityURL,
 	IdentityPublicKey,
+	IdentityClientID,
+	IdentityGroupsClaim,
 }
 
 // ParseHarvestMode parses description of harvesting method and
@@ -625,6 +663,26 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[IdentityClientID].(string); ok && v != "" {
+		if cfg.IdentityURL() == "" {
+			return fmt.Errorf("%s requires %s to be set", IdentityClientID, IdentityURL)
+		}
+	}
+
+	if v, ok := cfg.defined[IdentityGroupAccess].(string); ok {
+		if _, err := ParseGroupAccess(v); err != nil {
+			return errors.Annotate(err, "invalid identity group access")
+		}
+	}
+
+	if v, ok := cfg.defined[HookTimeout].(string); ok && v != "" {
+		if timeout, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid hook timeout")
+		} else if timeout < 0 {
+			return errors.Errorf("invalid hook timeout: negative duration %q", v)
+		}
+	}
+
 	caCert, caCertOK := cfg.CACert()
 	caKey, caKeyOK := cfg.CAPrivateKey()
 	if caCertOK || caKeyOK {
@@ -665,6 +723,11 @@ func Validate(cfg, old *Config) error {
 		return errors.Errorf("%s: expected positive integer, got %v", LXCDefaultMTU, lxcDefaultMTU)
 	}
 
+	// Check MachineLockSlots is a positive integer, when set.
+	if slots, ok := cfg.MachineLockSlots(); ok && slots < 1 {
+		return errors.Errorf("%s: expected positive integer, got %v", MachineLockSlots, slots)
+	}
+
 	cfg.defined = ProcessDeprecatedAttributes(cfg.defined)
 	return nil
 }
@@ -989,12 +1052,21 @@ func (c *Config) AdminSecret() string {
 }
 
 // FirewallMode returns whether the firewall should
-// manage ports per machine, globally, or not at all.
-// (FwInstance, FwGlobal, or FwNone).
+// manage ports per machine, globally, on the machines
+// themselves, or not at all.
+// (FwInstance, FwGlobal, FwMachine, or FwNone).
 func (c *Config) FirewallMode() string {
 	return c.mustString("firewall-mode")
 }
 
+// GlobalFirewall reports whether ports are opened in a single
+// firewall for all instances, which is the case in the global
+// and machine firewall modes.
+func (c *Config) GlobalFirewall() bool {
+	mode := c.FirewallMode()
+	return mode == FwGlobal || mode == FwMachine
+}
+
 // AgentVersion returns the proposed version number for the agent tools,
 // and whether it has been set. Once an environment is bootstrapped, this
 // must always be valid.
@@ -1262,6 +1334,70 @@ func (c *Config) IdentityPublicKey() *bakery.PublicKey {
 	return &pubKey
 }
 
+// IdentityClientID returns the OpenID Connect client id the controller
+// accepts ID tokens for, if the identity manager is an OpenID Connect
+// provider.
+func (c *Config) IdentityClientID() string {
+	return c.asString(IdentityClientID)
+}
+
+// IdentityGroupsClaim returns the ID token claim that lists the groups
+// of an external user.
+func (c *Config) IdentityGroupsClaim() string {
+	if claim := c.asString(IdentityGroupsClaim); claim != "" {
+		return claim
+	}
+	return DefaultIdentityGroupsClaim
+}
+
+// MachineLockSlots returns the number of operations that may hold the
+// machine lock at once on each machine, and whether it has been set.
+func (c *Config) MachineLockSlots() (int, bool) {
+	v, ok := c.defined[MachineLockSlots].(int)
+	return v, ok
+}
+
+// HookTimeout returns how long the uniter lets a hook or action run
+// before killing it, or zero if the model does not bound it.
+func (c *Config) HookTimeout() time.Duration {
+	timeout, _ := time.ParseDuration(c.asString(HookTimeout))
+	return timeout
+}
+
+// IdentityGroupAccess returns the level of access granted on the model
+// to members of each group of external users.
+func (c *Config) IdentityGroupAccess() map[string]string {
+	// The value is checked in Validate, so we do not expect
+	// this to fail.
+	access, _ := ParseGroupAccess(c.asString(IdentityGroupAccess))
+	return access
+}
+
+// ParseGroupAccess parses a comma-separated list of group=access
+// pairs, as held by the identity-group-access attribute. The access
+// must be "read" or "write".
+func ParseGroupAccess(s string) (map[string]string, error) {
+	access := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.NotValidf("group access %q", pair)
+		}
+		group, level := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		switch level {
+		case "read", "write":
+		default:
+			return nil, errors.NotValidf("access %q for group %q", level, group)
+		}
+		access[group] = level
+	}
+	return access, nil
+}
+
 // fields holds the validation schema fields derived from configSchema.
 var fields = func() schema.Fields {
 	fs, _, err := configSchema.ValidationSchema()
@@ -1307,6 +1443,11 @@ var alwaysOptional = schema.Defaults{
 	AgentStreamKey:               schema.Omit,
 	IdentityURL:                  schema.Omit,
 	IdentityPublicKey:            schema.Omit,
+	IdentityClientID:             schema.Omit,
+	IdentityGroupsClaim:          schema.Omit,
+	IdentityGroupAccess:          schema.Omit,
+	HookTimeout:                  schema.Omit,
+	MachineLockSlots:             schema.Omit,
 	SetNumaControlPolicyKey:      DefaultNumaControlPolicy,
 	AllowLXCLoopMounts:           false,
 	ResourceTagsKey:              schema.Omit,
@@ -1386,6 +1527,25 @@ func allDefaults() schema.Defaults {
 	return d
 }
 
+// ConfigDefaults returns the Juju defaults of the model config
+// attributes that have one. These are the values models get when
+// neither the model nor any of the model defaults set them.
+func ConfigDefaults() map[string]interface{} {
+	result := make(map[string]interface{})
+	for attr, val := range defaults {
+		if val == schema.Omit || strings.HasPrefix(attr, "bootstrap-") {
+			continue
+		}
+		result[attr] = val
+	}
+	for _, attr := range ControllerOnlyConfigAttributes {
+		delete(result, attr)
+	}
+	delete(result, AdminSecretKey)
+	delete(result, "ca-private-key")
+	return result
+}
+
 // allowedWithDefaultsOnly holds those attributes
 // that are only allowed in a configuration that is
 // being created with UseDefaults.
@@ -1421,6 +1581,7 @@ var immutableAttributes = []string{
 	"lxc-clone-aufs",
 	IdentityURL,
 	IdentityPublicKey,
+	IdentityClientID,
 }
 
 var (
@@ -1717,11 +1878,17 @@ that port).
 
 'none' requests that no firewalling should be performed
 inside the model. It's useful for clouds without support for either
-global or per instance security groups.`,
+global or per instance security groups.
+
+'machine' requests that each machine agent manages the host firewall
+(iptables) of its own machine, so that only the ports opened by units
+of exposed applications are reachable. On clouds with security groups,
+such as AWS and OpenStack, those ports are also opened in a single
+firewall for all instances, as in 'global' mode.`,
 		Type: environschema.Tstring,
 		// Note that we need the empty value because it can
 		// be found in legacy environments.
-		Values:    []interface{}{FwInstance, FwGlobal, FwNone, ""},
+		Values:    []interface{}{FwInstance, FwGlobal, FwNone, FwMachine, ""},
 		Immutable: true,
 		Group:     environschema.EnvironGroup,
 	},
@@ -1887,9 +2054,35 @@ data of the store. (default false)`,
 		Group:       environschema.JujuGroup,
 		Immutable:   true,
 	},
+	IdentityClientID: {
+		Description: "The OpenID Connect client id of the controller. If this is set, the identity manager at IdentityURL is an OpenID Connect provider.",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+		Immutable:   true,
+	},
+	IdentityGroupsClaim: {
+		Description: "The ID token claim listing the groups of an external user (default \"groups\")",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	IdentityGroupAccess: {
+		Description: "The access granted on the model to external users in each group, as group=access pairs separated by commas, where access is read or write",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	AutomaticallyRetryHooks: {
 		Description: "Determines whether the uniter should automatically retry failed hooks",
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	HookTimeout: {
+		Description: "How long a hook or action may run before it is killed, such as 30m, overriding any default declared by the charm (default: no limit)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MachineLockSlots: {
+		Description: "How many hooks and other operations may hold the machine lock at once on each machine provisioned after it is set (default 1)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -225,6 +225,19 @@ var configTests = []configTest{
 			"lxc-default-mtu": -42,
 		}),
 		err: `lxc-default-mtu: expected positive integer, got -42`,
+	}, {
+		about:       "Machine lock slots set explicitly",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"machine-lock-slots": 4,
+		}),
+	}, {
+		about:       "Machine lock slots invalid (zero)",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"machine-lock-slots": 0,
+		}),
+		err: `machine-lock-slots: expected positive integer, got 0`,
 	}, {
 		about:       "CA cert & key from path",
 		useDefaults: config.UseDefaults,
@@ -496,13 +509,19 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"firewall-mode": config.FwNone,
 		}),
+	}, {
+		about:       "Machine firewall mode",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"firewall-mode": config.FwMachine,
+		}),
 	}, {
 		about:       "Illegal firewall mode",
 		useDefaults: config.UseDefaults,
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"firewall-mode": "illegal",
 		}),
-		err: `firewall-mode: expected one of \[instance global none ], got "illegal"`,
+		err: `firewall-mode: expected one of \[instance global none machine ], got "illegal"`,
 	}, {
 		about:       "ssl-hostname-verification off",
 		useDefaults: config.UseDefaults,
@@ -826,6 +845,56 @@ var configTests = []configTest{
 			"identity-public-key": "o/yOqSNWncMo1GURWuez/dGR30TscmmuIxgjztpoHEY=",
 		}),
 	},
+	{
+		about:       "Identity client id without identity URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"identity-client-id": "juju",
+		}),
+		err: `identity-client-id requires identity-url to be set`,
+	},
+	{
+		about:       "Valid OpenID Connect identity provider",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"identity-url":          "https://test-identity",
+			"identity-client-id":    "juju",
+			"identity-groups-claim": "roles",
+			"identity-group-access": "devs=read, ops=write",
+		}),
+	},
+	{
+		about:       "Invalid identity group access",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"identity-group-access": "devs=superuser",
+		}),
+		err: `invalid identity group access: access "superuser" for group "devs" not valid`,
+	},
+	{
+		about:       "Malformed identity group access",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"identity-group-access": "devs",
+		}),
+		err: `invalid identity group access: group access "devs" not valid`,
+	},
+	{
+		about:       "Invalid hook timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "soon",
+		}),
+		err: `invalid hook timeout: time: invalid duration "?soon"?`,
+	},
+	{
+		about:       "Negative hook timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "-5m",
+		}),
+		err: `invalid hook timeout: negative duration "-5m"`,
+	},
 }
 
 func missingAttributeNoDefault(attrName string) configTest {
@@ -1066,6 +1135,20 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(cfg.IdentityPublicKey(), gc.DeepEquals, &pk)
 	}
+	if clientID, ok := test.attrs["identity-client-id"]; ok {
+		c.Assert(cfg.IdentityClientID(), gc.Equals, clientID)
+	}
+	if claim, ok := test.attrs["identity-groups-claim"]; ok {
+		c.Assert(cfg.IdentityGroupsClaim(), gc.Equals, claim)
+	} else {
+		c.Assert(cfg.IdentityGroupsClaim(), gc.Equals, config.DefaultIdentityGroupsClaim)
+	}
+	if _, ok := test.attrs["identity-group-access"]; ok {
+		c.Assert(cfg.IdentityGroupAccess(), jc.DeepEquals, map[string]string{
+			"devs": "read",
+			"ops":  "write",
+		})
+	}
 
 	dev, _ := test.attrs["development"].(bool)
 	c.Assert(cfg.Development(), gc.Equals, dev)
@@ -1083,6 +1166,7 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 
 	if m, _ := test.attrs["firewall-mode"].(string); m != "" {
 		c.Assert(cfg.FirewallMode(), gc.Equals, m)
+		c.Assert(cfg.GlobalFirewall(), gc.Equals, m == config.FwGlobal || m == config.FwMachine)
 	}
 	if secret, _ := test.attrs["admin-secret"].(string); secret != "" {
 		c.Assert(cfg.AdminSecret(), gc.Equals, secret)
@@ -1582,6 +1666,29 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestHookTimeoutDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestHookTimeout(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"hook-timeout": "30m"})
+	c.Assert(config.HookTimeout(), gc.Equals, 30*time.Minute)
+}
+
+func (s *ConfigSuite) TestMachineLockSlots(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	_, ok := config.MachineLockSlots()
+	c.Assert(ok, jc.IsFalse)
+
+	config = newTestConfig(c, testing.Attrs{
+		"machine-lock-slots": 4})
+	slots, ok := config.MachineLockSlots()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(slots, gc.Equals, 4)
+}
+
 func (s *ConfigSuite) TestCloudImageBaseURL(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{})
@@ -1735,6 +1842,18 @@ func (s *ConfigSuite) TestSchemaWithExtraOverlap(c *gc.C) {
 	c.Assert(schema, gc.IsNil)
 }
 
+func (s *ConfigSuite) TestConfigDefaults(c *gc.C) {
+	defaults := config.ConfigDefaults()
+	c.Assert(defaults["firewall-mode"], gc.Equals, config.FwInstance)
+	c.Assert(defaults[config.AutomaticallyRetryHooks], gc.Equals, true)
+	for _, attr := range []string{
+		config.ApiPort, config.StatePort, "bootstrap-timeout", "logging-config",
+	} {
+		_, ok := defaults[attr]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", attr))
+	}
+}
+
 func (s *ConfigSuite) TestGenerateControllerCertAndKey(c *gc.C) {
 	// Add a cert.
 	s.FakeHomeSuite.Home.AddFiles(c, gitjujutesting.TestFile{".ssh/id_rsa.pub", "rsa\n"})
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+// These constants define the sources of model config values. A model
+// inherits its config from the Juju defaults, the controller-wide
+// defaults and the defaults for its cloud region, in that order, and
+// then overrides any of those with its own values.
+const (
+	// JujuDefaultSource is used to label a config value which comes
+	// from the Juju defaults.
+	JujuDefaultSource = "default"
+
+	// JujuControllerSource is used to label a config value which
+	// comes from the controller-wide model defaults.
+	JujuControllerSource = "controller"
+
+	// JujuRegionSource is used to label a config value which comes
+	// from the model defaults of the model's cloud region.
+	JujuRegionSource = "region"
+
+	// JujuModelConfigSource is used to label a config value which
+	// has been set on the model itself.
+	JujuModelConfigSource = "model"
+)
+
+// ConfigValue encapsulates a configuration value and its source.
+type ConfigValue struct {
+	// Value is the value of the config attribute.
+	Value interface{}
+
+	// Source is the label of the layer the value came from.
+	Source string
+}
+
+// ConfigValues is a map of configuration values keyed on attribute
+// name.
+type ConfigValues map[string]ConfigValue
+
+// RegionDefaultValue holds the default value of a config attribute
+// for a cloud region.
+type RegionDefaultValue struct {
+	// CloudName is the name of the cloud the region belongs to.
+	CloudName string
+
+	// Name is the name of the region.
+	Name string
+
+	// Value is the default value of the attribute in the region.
+	Value interface{}
+}
+
+// AttributeDefaultValues holds the default values of a config
+// attribute at each level at which defaults may be set.
+type AttributeDefaultValues struct {
+	// Default is the Juju default value, or nil if there is none.
+	Default interface{}
+
+	// Controller is the controller-wide default value, or nil if
+	// there is none.
+	Controller interface{}
+
+	// Regions holds the default values for the cloud regions that
+	// have one.
+	Regions []RegionDefaultValue
+}
+
+// ModelDefaultAttributes is a map of the default values of config
+// attributes, keyed on attribute name.
+type ModelDefaultAttributes map[string]AttributeDefaultValues
@@ -46,7 +46,7 @@ func (s *cmdControllerSuite) run(c *gc.C, args ...string) *cmd.Context {
 
 func (s *cmdControllerSuite) createModelAdminUser(c *gc.C, modelname string, isServer bool) params.Model {
 	modelManager := modelmanager.NewClient(s.APIState)
-	model, err := modelManager.CreateModel(s.AdminUserTag(c).Id(), nil, map[string]interface{}{
+	model, err := modelManager.CreateModel(s.AdminUserTag(c).Id(), "", "", "", nil, map[string]interface{}{
 		"name":       modelname,
 		"controller": isServer,
 	})
@@ -57,7 +57,7 @@ func (s *cmdControllerSuite) createModelAdminUser(c *gc.C, modelname string, isS
 func (s *cmdControllerSuite) createModelNormalUser(c *gc.C, modelname string, isServer bool) {
 	s.run(c, "add-user", "test")
 	modelManager := modelmanager.NewClient(s.APIState)
-	_, err := modelManager.CreateModel(names.NewLocalUserTag("test").Id(), nil, map[string]interface{}{
+	_, err := modelManager.CreateModel(names.NewLocalUserTag("test").Id(), "", "", "", nil, map[string]interface{}{
 		"name":            modelname,
 		"authorized-keys": "ssh-key",
 		"controller":      isServer,
@@ -16,6 +16,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/jujuclient"
@@ -174,6 +175,12 @@ func NewAPIConnection(args NewAPIConnectionParams) (api.Connection, error) {
 	if err != nil {
 		logger.Errorf("cannot cache API addresses: %v", err)
 	}
+	// Likewise the controller's maintenance mode, so that it may be
+	// reported without connecting.
+	err = updateControllerMaintenance(args.Store, args.ControllerName, controllerDetails, st.Maintenance())
+	if err != nil {
+		logger.Errorf("cannot cache controller maintenance mode: %v", err)
+	}
 	return st, nil
 }
 
@@ -449,6 +456,58 @@ func updateControllerAddresses(
 	return errors.Trace(err)
 }
 
+// MaintenanceDetails returns the client representation of the
+// maintenance mode reported by a controller, or nil if info is nil.
+func MaintenanceDetails(info *params.MaintenanceInfo) *jujuclient.MaintenanceDetails {
+	if info == nil {
+		return nil
+	}
+	owner := info.OwnerTag
+	if tag, err := names.ParseUserTag(info.OwnerTag); err == nil {
+		owner = tag.Canonical()
+	}
+	details := &jujuclient.MaintenanceDetails{
+		Reason:  info.Reason,
+		Owner:   owner,
+		Started: info.Started,
+	}
+	if info.Expires != nil {
+		expires := *info.Expires
+		details.Expires = &expires
+	}
+	return details
+}
+
+func updateControllerMaintenance(
+	store jujuclient.ControllerStore,
+	controllerName string, controllerDetails *jujuclient.ControllerDetails,
+	info *params.MaintenanceInfo,
+) error {
+	maintenance := MaintenanceDetails(info)
+	if maintenanceEqual(maintenance, controllerDetails.Maintenance) {
+		return nil
+	}
+	controllerDetails.Maintenance = maintenance
+	err := store.UpdateController(controllerName, *controllerDetails)
+	return errors.Trace(err)
+}
+
+// maintenanceEqual reports whether a and b describe the same
+// maintenance mode. Times are compared by instant, as their locations
+// are not preserved when cached.
+func maintenanceEqual(a, b *jujuclient.MaintenanceDetails) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Reason != b.Reason || a.Owner != b.Owner || !a.Started.Equal(b.Started) {
+		return false
+	}
+	if a.Expires == nil || b.Expires == nil {
+		return a.Expires == b.Expires
+	}
+	return a.Expires.Equal(*b.Expires)
+}
+
 // serverAddress returns the given string address:port as network.HostPort.
 //
 // TODO(axw) fix the tests that pass invalid addresses, and drop this.
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
@@ -147,6 +148,35 @@ func (s *NewAPIClientSuite) TestWithBootstrapConfig(c *gc.C) {
 	c.Assert(controllerBefore, gc.DeepEquals, controllerAfter)
 }
 
+func (s *NewAPIClientSuite) TestCachesMaintenance(c *gc.C) {
+	store := newClientStore(c, "noconfig")
+
+	started := time.Date(2016, 9, 1, 12, 0, 0, 0, time.UTC)
+	expectState := mockedAPIState(mockedHostPort | mockedModelTag)
+	expectState.maintenance = &params.MaintenanceInfo{
+		Reason:   "upgrading mongo",
+		OwnerTag: "user-admin@local",
+		Started:  started,
+	}
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		return expectState, nil
+	}
+
+	_, err := newAPIConnectionFromNames(c, "noconfig", "admin@local", "admin", store, apiOpen, noBootstrapConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.Controllers["noconfig"].Maintenance, jc.DeepEquals, &jujuclient.MaintenanceDetails{
+		Reason:  "upgrading mongo",
+		Owner:   "admin@local",
+		Started: started,
+	})
+
+	// Once maintenance mode is disabled, the cached details are removed.
+	expectState.maintenance = nil
+	_, err = newAPIConnectionFromNames(c, "noconfig", "admin@local", "admin", store, apiOpen, noBootstrapConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.Controllers["noconfig"].Maintenance, gc.IsNil)
+}
+
 func (s *NewAPIClientSuite) TestWithInfoError(c *gc.C) {
 	store := newClientStore(c, "noconfig")
 	err := store.UpdateController("noconfig", jujuclient.ControllerDetails{
@@ -4,6 +4,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 )
 
@@ -15,6 +16,7 @@ type mockAPIState struct {
 	apiHostPorts  [][]network.HostPort
 	modelTag      string
 	controllerTag string
+	maintenance   *params.MaintenanceInfo
 }
 
 func (s *mockAPIState) Close() error {
@@ -40,6 +42,10 @@ func (s *mockAPIState) ControllerTag() (names.ModelTag, error) {
 	return names.ParseModelTag(s.controllerTag)
 }
 
+func (s *mockAPIState) Maintenance() *params.MaintenanceInfo {
+	return s.maintenance
+}
+
 func panicAPIOpen(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
 	panic("api.Open called unexpectedly")
 }
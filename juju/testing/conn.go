@@ -396,6 +396,10 @@ func newState(environ environs.Environ, mongoInfo *mongo.MongoInfo) (*state.Stat
 	} else if err != nil {
 		return nil, err
 	}
+	if err := st.SetSecretsKey(testing.SecretsKey); err != nil {
+		st.Close()
+		return nil, err
+	}
 	if err := updateSecrets(environ, st); err != nil {
 		st.Close()
 		return nil, fmt.Errorf("unable to push secrets: %v", err)
@@ -3,7 +3,11 @@
 
 package jujuclient
 
-import "github.com/juju/juju/cloud"
+import (
+	"time"
+
+	"github.com/juju/juju/cloud"
+)
 
 // ControllerDetails holds the details needed to connect to a controller.
 type ControllerDetails struct {
@@ -31,6 +35,27 @@ type ControllerDetails struct {
 	// CloudRegion is the name of the cloud region that this controller
 	// runs in. This will be empty for clouds without regions.
 	CloudRegion string `yaml:"region,omitempty"`
+
+	// Maintenance holds the controller's maintenance mode as last seen
+	// when connecting to it. It is nil if the controller was not in
+	// maintenance mode.
+	Maintenance *MaintenanceDetails `yaml:"maintenance,omitempty"`
+}
+
+// MaintenanceDetails holds details of a controller's maintenance mode.
+type MaintenanceDetails struct {
+	// Reason holds why the controller is in maintenance mode.
+	Reason string `yaml:"reason,omitempty"`
+
+	// Owner is the name of the user that enabled maintenance mode.
+	Owner string `yaml:"owner"`
+
+	// Started holds when maintenance mode was enabled.
+	Started time.Time `yaml:"started"`
+
+	// Expires holds when maintenance mode ends of its own accord, or
+	// nil if it does not expire.
+	Expires *time.Time `yaml:"expires,omitempty"`
 }
 
 // ModelDetails holds details of a model.
@@ -10,9 +10,11 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/api"
@@ -58,30 +60,62 @@ func ImportModel(st *state.State, bytes []byte) (*state.Model, *state.State, err
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-
-	controllerModel, err := st.ControllerModel()
+	if err := updateConfigForController(st, model); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dbModel, dbState, err := st.Import(model)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+	return dbModel, dbState, nil
+}
 
-	controllerConfig, err := controllerModel.Config()
+// ImportFreshModel deserializes a model description from the bytes,
+// and imports it as a fresh deployment of a new model with the given
+// name and owner, for which new machines will be provisioned. The
+// model config is transformed as for ImportModel. This is used to
+// clone a model, rather than to migrate it.
+func ImportFreshModel(st *state.State, bytes []byte, name string, owner names.UserTag) (*state.Model, *state.State, error) {
+	model, err := description.Deserialize(bytes)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-
-	model.UpdateConfig(controllerValues(controllerConfig))
-
-	if err := updateConfigFromProvider(model, controllerConfig); err != nil {
+	uuid, err := utils.NewUUID()
+	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-
-	dbModel, dbState, err := st.Import(model)
+	model.UpdateConfig(map[string]interface{}{
+		config.NameKey: name,
+		config.UUIDKey: uuid.String(),
+	})
+	if err := updateConfigForController(st, model); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dbModel, dbState, err := st.ImportFresh(model, owner)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 	return dbModel, dbState, nil
 }
 
+// updateConfigForController updates the model's config with the
+// details of the controller it is being imported into.
+func updateConfigForController(st *state.State, model description.Model) error {
+	controllerModel, err := st.ControllerModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	controllerConfig, err := controllerModel.Config()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	model.UpdateConfig(controllerValues(controllerConfig))
+
+	return errors.Trace(updateConfigFromProvider(model, controllerConfig))
+}
+
 func controllerValues(config *config.Config) map[string]interface{} {
 	result := make(map[string]interface{})
 
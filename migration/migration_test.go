@@ -106,6 +106,30 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	c.Assert(attrs["controller-uuid"], gc.Equals, controllerConfig.UUID())
 }
 
+func (s *ImportSuite) TestImportFreshModel(c *gc.C) {
+	bytes, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	original, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	owner := names.NewUserTag("bob@external")
+
+	dbModel, dbState, err := migration.ImportFreshModel(s.State, bytes, "clone", owner)
+	c.Assert(err, jc.ErrorIsNil)
+	defer dbState.Close()
+
+	c.Assert(dbModel.Name(), gc.Equals, "clone")
+	c.Assert(dbModel.Owner(), gc.Equals, owner)
+	c.Assert(dbModel.UUID(), gc.Not(gc.Equals), original.UUID())
+	c.Assert(dbModel.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+}
+
+func (s *ImportSuite) TestImportFreshModelBadBytes(c *gc.C) {
+	model, st, err := migration.ImportFreshModel(s.State, []byte("not a model"), "clone", s.Owner)
+	c.Check(st, gc.IsNil)
+	c.Check(model, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
+}
+
 func (s *ImportSuite) TestUploadBinariesTools(c *gc.C) {
 	// Create a model that has three different tools versions:
 	// one for a machine, one for a container, and one for a unit agent.
@@ -0,0 +1,181 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// DefaultLoginTimeout is how long Login waits for the user to
+// authenticate with the provider, if LoginParams.Timeout is zero.
+const DefaultLoginTimeout = 5 * time.Minute
+
+// LoginParams holds the parameters for Login.
+type LoginParams struct {
+	// Provider is the provider to authenticate with.
+	Provider *Provider
+
+	// ClientID is the client to request an ID token for.
+	ClientID string
+
+	// Client is used to redeem the authorization code. If it is
+	// nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// OpenBrowser is called with the URL the user must visit to
+	// authenticate with the provider.
+	OpenBrowser func(*url.URL) error
+
+	// Timeout is how long to wait for the user to authenticate.
+	Timeout time.Duration
+}
+
+// Login obtains an ID token for the user by running the authorization
+// code flow against the provider. The authorization response is
+// received on a loopback redirect URI (RFC 8252), and the code is
+// protected with a PKCE challenge (RFC 7636), as befits a native
+// client without a client secret.
+func Login(p LoginParams) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", errors.Annotate(err, "cannot listen for authorization response")
+	}
+	defer listener.Close()
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr())
+
+	state, err := randomString()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authURL, err := url.Parse(p.Provider.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.Annotate(err, "invalid authorization endpoint")
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", "openid email profile")
+	query.Set("state", state)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	type response struct {
+		code string
+		err  error
+	}
+	responses := make(chan response, 1)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/callback" {
+				http.NotFound(w, req)
+				return
+			}
+			var resp response
+			switch values := req.URL.Query(); {
+			case values.Get("state") != state:
+				resp.err = errors.New("authorization response has unexpected state")
+			case values.Get("error") != "":
+				resp.err = errors.Errorf("authorization failed: %s", values.Get("error"))
+			case values.Get("code") == "":
+				resp.err = errors.New("authorization response has no code")
+			default:
+				resp.code = values.Get("code")
+			}
+			if resp.err != nil {
+				http.Error(w, resp.err.Error(), http.StatusBadRequest)
+			} else {
+				fmt.Fprintln(w, "Login successful. You may now close this window.")
+			}
+			select {
+			case responses <- resp:
+			default:
+			}
+		}),
+	}
+	go server.Serve(listener)
+
+	if err := p.OpenBrowser(authURL); err != nil {
+		return "", errors.Annotate(err, "cannot open authorization URL")
+	}
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = DefaultLoginTimeout
+	}
+	var resp response
+	select {
+	case resp = <-responses:
+	case <-time.After(timeout):
+		return "", errors.New("timed out waiting for authorization")
+	}
+	if resp.err != nil {
+		return "", errors.Trace(resp.err)
+	}
+	return redeemCode(p, resp.code, redirectURI, verifier)
+}
+
+// redeemCode exchanges the authorization code for an ID token at the
+// provider's token endpoint.
+func redeemCode(p LoginParams, code, redirectURI, verifier string) (string, error) {
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.PostForm(p.Provider.TokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.ClientID},
+		"code_verifier": {verifier},
+	})
+	if err != nil {
+		return "", errors.Annotate(err, "cannot redeem authorization code")
+	}
+	defer resp.Body.Close()
+	var result struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", errors.Annotate(err, "cannot decode token response")
+	}
+	if result.Error != "" {
+		if result.ErrorDescription != "" {
+			return "", errors.Errorf("cannot redeem authorization code: %s: %s", result.Error, result.ErrorDescription)
+		}
+		return "", errors.Errorf("cannot redeem authorization code: %s", result.Error)
+	}
+	if result.IDToken == "" {
+		return "", errors.New("token response has no ID token")
+	}
+	return result.IDToken, nil
+}
+
+// randomString returns a random string suitable for use as a state
+// value or PKCE code verifier.
+func randomString() (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b[:]), nil
+}
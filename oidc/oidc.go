@@ -0,0 +1,357 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package oidc implements the parts of OpenID Connect used to
+// authenticate external users: discovery of a provider's endpoints,
+// verification of the ID tokens it issues, and the browser-based
+// authorization code flow used by the juju client to obtain them.
+package oidc
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+// Provider holds the endpoints of an OpenID Connect provider, as
+// published in its discovery document.
+type Provider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover fetches the discovery document of the provider with the
+// given issuer URL.
+func Discover(client *http.Client, issuer string) (*Provider, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+	var p Provider
+	if err := getJSON(client, issuer+"/.well-known/openid-configuration", &p); err != nil {
+		return nil, errors.Annotatef(err, "cannot discover identity provider %q", issuer)
+	}
+	if strings.TrimSuffix(p.Issuer, "/") != issuer {
+		return nil, errors.Errorf("identity provider %q reports issuer %q", issuer, p.Issuer)
+	}
+	if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JWKSURI == "" {
+		return nil, errors.Errorf("identity provider %q has incomplete discovery document", issuer)
+	}
+	return &p, nil
+}
+
+// Claims holds the claims of a verified ID token that are of interest
+// to Juju.
+type Claims struct {
+	Issuer  string
+	Subject string
+	Email   string
+	Name    string
+	Groups  []string
+	Expiry  time.Time
+
+	// EmailVerified reports whether the provider has verified
+	// that the user owns Email.
+	EmailVerified bool
+}
+
+// minKeyRefreshInterval is the shortest time allowed between fetches
+// of a provider's signing keys, so that tokens naming unknown keys
+// cannot be used to make the controller flood the provider with
+// requests.
+const minKeyRefreshInterval = time.Minute
+
+// Verifier verifies ID tokens issued by a provider to a client.
+type Verifier struct {
+	// Provider is the provider that issues the ID tokens.
+	Provider *Provider
+
+	// ClientID is the client the ID tokens must be issued to.
+	ClientID string
+
+	// GroupsClaim is the claim listing the groups of the user.
+	// If it is empty, no groups are reported.
+	GroupsClaim string
+
+	// Client is used to fetch the provider's signing keys.
+	// If it is nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// Clock is used to check token expiry. If it is nil,
+	// the wall clock is used.
+	Clock clock.Clock
+
+	// mu guards the fields below it.
+	mu   sync.Mutex
+	keys map[string]*rsa.PublicKey
+
+	// fetched holds when the signing keys were last fetched.
+	fetched time.Time
+
+	// fetching is closed when the fetch of the signing keys in
+	// progress completes. It is nil when no fetch is in progress.
+	fetching chan struct{}
+}
+
+// Verify checks the signature, issuer, audience and expiry of the
+// given ID token, and returns its claims.
+func (v *Verifier) Verify(rawToken string) (*Claims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.NotValidf("ID token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errors.Annotate(err, "cannot decode ID token header")
+	}
+	if header.Alg != "RS256" {
+		return nil, errors.NotSupportedf("ID token signing algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode ID token signature")
+	}
+	key, err := v.key(header.Kid)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errors.New("invalid ID token signature")
+	}
+
+	var payload struct {
+		Issuer   string   `json:"iss"`
+		Subject  string   `json:"sub"`
+		Audience audience `json:"aud"`
+		Expiry   int64    `json:"exp"`
+		Email    string   `json:"email"`
+		Verified flag     `json:"email_verified"`
+		Name     string   `json:"name"`
+	}
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, errors.Annotate(err, "cannot decode ID token claims")
+	}
+	if strings.TrimSuffix(payload.Issuer, "/") != strings.TrimSuffix(v.Provider.Issuer, "/") {
+		return nil, errors.Errorf("ID token issued by %q, expected %q", payload.Issuer, v.Provider.Issuer)
+	}
+	if !payload.Audience.contains(v.ClientID) {
+		return nil, errors.Errorf("ID token not issued to client %q", v.ClientID)
+	}
+	expiry := time.Unix(payload.Expiry, 0)
+	if !v.clock().Now().Before(expiry) {
+		return nil, errors.New("ID token expired")
+	}
+	claims := &Claims{
+		Issuer:  payload.Issuer,
+		Subject: payload.Subject,
+		Email:   payload.Email,
+		Name:    payload.Name,
+		Expiry:  expiry,
+
+		EmailVerified: bool(payload.Verified),
+	}
+	if v.GroupsClaim != "" {
+		claims.Groups, err = groupsClaim(parts[1], v.GroupsClaim)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return claims, nil
+}
+
+func (v *Verifier) clock() clock.Clock {
+	if v.Clock == nil {
+		return clock.WallClock
+	}
+	return v.Clock
+}
+
+// key returns the provider's signing key with the given id. The
+// provider's key set is fetched again if the key is not known, as
+// the provider may have rotated its keys, but no more often than
+// minKeyRefreshInterval. The fetch is made without holding v.mu, and
+// concurrent callers wait for the fetch in progress rather than
+// starting their own.
+func (v *Verifier) key(id string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	for v.fetching != nil {
+		if key, ok := v.keys[id]; ok {
+			v.mu.Unlock()
+			return key, nil
+		}
+		fetching := v.fetching
+		v.mu.Unlock()
+		<-fetching
+		v.mu.Lock()
+	}
+	if key, ok := v.keys[id]; ok {
+		v.mu.Unlock()
+		return key, nil
+	}
+	now := v.clock().Now()
+	if !v.fetched.IsZero() && now.Sub(v.fetched) < minKeyRefreshInterval {
+		v.mu.Unlock()
+		return nil, errors.NotFoundf("ID token signing key %q", id)
+	}
+	v.fetched = now
+	fetching := make(chan struct{})
+	v.fetching = fetching
+	v.mu.Unlock()
+
+	keys, err := fetchKeys(v.Client, v.Provider.JWKSURI)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.fetching = nil
+	close(fetching)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	v.keys = keys
+	key, ok := keys[id]
+	if !ok {
+		return nil, errors.NotFoundf("ID token signing key %q", id)
+	}
+	return key, nil
+}
+
+// jsonWebKey holds the fields of an RSA JSON Web Key (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// fetchKeys fetches the RSA keys in the key set at the given URL,
+// keyed on key id.
+func fetchKeys(client *http.Client, url string) (map[string]*rsa.PublicKey, error) {
+	var keySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := getJSON(client, url, &keySet); err != nil {
+		return nil, errors.Annotate(err, "cannot fetch ID token signing keys")
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range keySet.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot decode key %q", k.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot decode key %q", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+// groupsClaim returns the groups listed in the named claim of the
+// given token payload. The claim may be a list of strings or a single
+// string.
+func groupsClaim(segment, name string) ([]string, error) {
+	var claims map[string]json.RawMessage
+	if err := decodeSegment(segment, &claims); err != nil {
+		return nil, errors.Annotate(err, "cannot decode ID token claims")
+	}
+	raw, ok := claims[name]
+	if !ok {
+		return nil, nil
+	}
+	var groups audience
+	if err := json.Unmarshal(raw, &groups); err != nil {
+		return nil, errors.Annotatef(err, "cannot decode %q claim", name)
+	}
+	return []string(groups), nil
+}
+
+// audience holds a claim that may be a single string or a list of
+// strings, such as the "aud" claim.
+type audience []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*a = audience{s}
+		return nil
+	}
+	var l []string
+	if err := json.Unmarshal(data, &l); err != nil {
+		return err
+	}
+	*a = audience(l)
+	return nil
+}
+
+func (a audience) contains(s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// flag holds a boolean claim. Some providers encode boolean claims,
+// such as "email_verified", as the strings "true" and "false".
+type flag bool
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *flag) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*f = s == "true"
+		return nil
+	}
+	var b bool
+	if err := json.Unmarshal(data, &b); err != nil {
+		return err
+	}
+	*f = flag(b)
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func getJSON(client *http.Client, url string, v interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
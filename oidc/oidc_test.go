@@ -0,0 +1,251 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	stdtesting "testing"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/oidc"
+	"github.com/juju/juju/oidc/oidctest"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func TestAll(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type oidcSuite struct {
+	issuer *oidctest.Issuer
+	user   oidctest.User
+}
+
+var _ = gc.Suite(&oidcSuite{})
+
+func (s *oidcSuite) SetUpSuite(c *gc.C) {
+	s.user = oidctest.User{
+		Email:  "bob@example.com",
+		Name:   "Bob Brown",
+		Groups: []string{"devs", "ops"},
+	}
+	s.issuer = oidctest.NewIssuer("juju", s.user)
+}
+
+func (s *oidcSuite) TearDownSuite(c *gc.C) {
+	s.issuer.Close()
+}
+
+func (s *oidcSuite) SetUpTest(c *gc.C) {
+	s.issuer.SetUser(s.user)
+}
+
+func (s *oidcSuite) newVerifier(c *gc.C, clientID string) *oidc.Verifier {
+	provider, err := oidc.Discover(s.issuer.Client(), s.issuer.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	return &oidc.Verifier{
+		Provider:    provider,
+		ClientID:    clientID,
+		GroupsClaim: "groups",
+		Client:      s.issuer.Client(),
+	}
+}
+
+func (s *oidcSuite) TestDiscover(c *gc.C) {
+	provider, err := oidc.Discover(s.issuer.Client(), s.issuer.URL+"/")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, jc.DeepEquals, &oidc.Provider{
+		Issuer:                s.issuer.URL,
+		AuthorizationEndpoint: s.issuer.URL + "/auth",
+		TokenEndpoint:         s.issuer.URL + "/token",
+		JWKSURI:               s.issuer.URL + "/keys",
+	})
+}
+
+func (s *oidcSuite) TestDiscoverNotFound(c *gc.C) {
+	_, err := oidc.Discover(s.issuer.Client(), s.issuer.URL+"/missing")
+	c.Assert(err, gc.ErrorMatches, `cannot discover identity provider ".*/missing": GET .*: 404 Not Found`)
+}
+
+func (s *oidcSuite) TestVerify(c *gc.C) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	claims, err := s.newVerifier(c, "juju").Verify(s.issuer.IDToken(s.user, expiry))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims, jc.DeepEquals, &oidc.Claims{
+		Issuer:  s.issuer.URL,
+		Subject: "bob@example.com",
+		Email:   "bob@example.com",
+		Name:    "Bob Brown",
+		Groups:  []string{"devs", "ops"},
+		Expiry:  expiry,
+
+		EmailVerified: true,
+	})
+}
+
+func (s *oidcSuite) TestVerifyEmailUnverified(c *gc.C) {
+	user := s.user
+	user.EmailUnverified = true
+	claims, err := s.newVerifier(c, "juju").Verify(s.issuer.IDToken(user, time.Now().Add(time.Hour)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims.EmailVerified, jc.IsFalse)
+}
+
+func (s *oidcSuite) TestVerifyUnknownKeyRefreshLimited(c *gc.C) {
+	v := s.newVerifier(c, "juju")
+	clock := coretesting.NewClock(time.Now())
+	v.Clock = clock
+	token := s.issuer.IDToken(s.user, time.Now().Add(time.Hour))
+	_, err := v.Verify(token)
+	c.Assert(err, jc.ErrorIsNil)
+	fetches := s.issuer.KeyFetches()
+
+	// Tokens naming an unknown key do not cause the keys to be
+	// fetched again until the refresh interval has passed.
+	unknown := withKeyID(c, token, "unknown")
+	for i := 0; i < 3; i++ {
+		_, err = v.Verify(unknown)
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	c.Assert(s.issuer.KeyFetches(), gc.Equals, fetches)
+
+	clock.Advance(time.Minute)
+	_, err = v.Verify(unknown)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.issuer.KeyFetches(), gc.Equals, fetches+1)
+
+	// Known keys are still used.
+	_, err = v.Verify(token)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// withKeyID returns the given token with the key id in its header
+// replaced.
+func withKeyID(c *gc.C, token, kid string) string {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": kid,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	parts := strings.Split(token, ".")
+	parts[0] = base64.RawURLEncoding.EncodeToString(header)
+	return strings.Join(parts, ".")
+}
+
+func (s *oidcSuite) TestVerifyNoGroupsClaim(c *gc.C) {
+	v := s.newVerifier(c, "juju")
+	v.GroupsClaim = "roles"
+	claims, err := v.Verify(s.issuer.IDToken(s.user, time.Now().Add(time.Hour)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims.Groups, gc.HasLen, 0)
+}
+
+func (s *oidcSuite) TestVerifyExpired(c *gc.C) {
+	v := s.newVerifier(c, "juju")
+	token := s.issuer.IDToken(s.user, time.Now().Add(time.Hour))
+	v.Clock = coretesting.NewClock(time.Now().Add(2 * time.Hour))
+	_, err := v.Verify(token)
+	c.Assert(err, gc.ErrorMatches, "ID token expired")
+}
+
+func (s *oidcSuite) TestVerifyWrongAudience(c *gc.C) {
+	_, err := s.newVerifier(c, "other").Verify(s.issuer.IDToken(s.user, time.Now().Add(time.Hour)))
+	c.Assert(err, gc.ErrorMatches, `ID token not issued to client "other"`)
+}
+
+func (s *oidcSuite) TestVerifyOtherIssuer(c *gc.C) {
+	other := oidctest.NewIssuer("juju", s.user)
+	defer other.Close()
+	token := other.IDToken(s.user, time.Now().Add(time.Hour))
+	// The other issuer's key has the same id, so the signature
+	// check fails.
+	_, err := s.newVerifier(c, "juju").Verify(token)
+	c.Assert(err, gc.ErrorMatches, "invalid ID token signature")
+}
+
+func (s *oidcSuite) TestVerifyTamperedClaims(c *gc.C) {
+	token := s.issuer.IDToken(s.user, time.Now().Add(time.Hour))
+	other := s.issuer.IDToken(oidctest.User{Email: "admin@example.com"}, time.Now().Add(time.Hour))
+	parts, otherParts := strings.Split(token, "."), strings.Split(other, ".")
+	parts[1] = otherParts[1]
+	_, err := s.newVerifier(c, "juju").Verify(strings.Join(parts, "."))
+	c.Assert(err, gc.ErrorMatches, "invalid ID token signature")
+}
+
+func (s *oidcSuite) TestVerifyMalformed(c *gc.C) {
+	_, err := s.newVerifier(c, "juju").Verify("not-a-token")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *oidcSuite) browser(c *gc.C) func(*url.URL) error {
+	return func(u *url.URL) error {
+		c.Check(u.Query().Get("code_challenge_method"), gc.Equals, "S256")
+		resp, err := s.issuer.Client().Get(u.String())
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		c.Check(resp.StatusCode, gc.Equals, http.StatusOK)
+		return nil
+	}
+}
+
+func (s *oidcSuite) TestLogin(c *gc.C) {
+	provider, err := oidc.Discover(s.issuer.Client(), s.issuer.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	token, err := oidc.Login(oidc.LoginParams{
+		Provider:    provider,
+		ClientID:    "juju",
+		Client:      s.issuer.Client(),
+		OpenBrowser: s.browser(c),
+		Timeout:     coretesting.LongWait,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	claims, err := s.newVerifier(c, "juju").Verify(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims.Email, gc.Equals, "bob@example.com")
+	c.Assert(claims.Groups, jc.DeepEquals, []string{"devs", "ops"})
+}
+
+func (s *oidcSuite) TestLoginUnknownClient(c *gc.C) {
+	provider, err := oidc.Discover(s.issuer.Client(), s.issuer.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = oidc.Login(oidc.LoginParams{
+		Provider: provider,
+		ClientID: "other",
+		Client:   s.issuer.Client(),
+		OpenBrowser: func(u *url.URL) error {
+			resp, err := s.issuer.Client().Get(u.String())
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			return errors.Errorf("authorization page: %s", resp.Status)
+		},
+		Timeout: coretesting.ShortWait,
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot open authorization URL: authorization page: 400 Bad Request")
+}
+
+func (s *oidcSuite) TestLoginTimeout(c *gc.C) {
+	provider, err := oidc.Discover(s.issuer.Client(), s.issuer.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = oidc.Login(oidc.LoginParams{
+		Provider:    provider,
+		ClientID:    "juju",
+		OpenBrowser: func(*url.URL) error { return nil },
+		Timeout:     coretesting.ShortWait,
+	})
+	c.Assert(err, gc.ErrorMatches, "timed out waiting for authorization")
+}
@@ -0,0 +1,249 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package oidctest provides a stand-in OpenID Connect provider for
+// testing and local development.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// keyID is the id of the issuer's signing key.
+const keyID = "oidctest"
+
+// User describes the user the Issuer authenticates.
+type User struct {
+	Email  string
+	Name   string
+	Groups []string
+
+	// EmailUnverified records that the issuer has not verified
+	// that the user owns Email.
+	EmailUnverified bool
+}
+
+// Issuer is a stand-in OpenID Connect provider. It authenticates every
+// authorization request as its current user without prompting, and
+// issues ID tokens signed with a key generated when it starts.
+type Issuer struct {
+	*httptest.Server
+
+	// ClientID is the only client the issuer issues tokens to.
+	ClientID string
+
+	key *rsa.PrivateKey
+
+	mu             sync.Mutex
+	user           User
+	authorizations map[string]authorization
+	nextCode       int
+	keyFetches     int
+}
+
+// authorization records an authorization code issued by the Issuer.
+type authorization struct {
+	user        User
+	redirectURI string
+	challenge   string
+}
+
+// NewIssuer starts and returns an Issuer that issues ID tokens to the
+// given client for the given user. It must be closed after use.
+func NewIssuer(clientID string, user User) *Issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	i := &Issuer{
+		ClientID:       clientID,
+		key:            key,
+		user:           user,
+		authorizations: make(map[string]authorization),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", i.serveDiscovery)
+	mux.HandleFunc("/auth", i.serveAuth)
+	mux.HandleFunc("/token", i.serveToken)
+	mux.HandleFunc("/keys", i.serveKeys)
+	i.Server = httptest.NewTLSServer(mux)
+	return i
+}
+
+// SetUser sets the user the issuer authenticates.
+func (i *Issuer) SetUser(user User) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.user = user
+}
+
+// KeyFetches returns the number of times the issuer's signing keys
+// have been fetched.
+func (i *Issuer) KeyFetches() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.keyFetches
+}
+
+// Client returns an HTTP client that trusts the issuer's certificate.
+func (i *Issuer) Client() *http.Client {
+	pool := x509.NewCertPool()
+	cert, err := x509.ParseCertificate(i.TLS.Certificates[0].Certificate[0])
+	if err != nil {
+		panic(err)
+	}
+	pool.AddCert(cert)
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+		},
+	}
+}
+
+// IDToken returns an ID token for the given user that expires at the
+// given time, as issued to the issuer's client.
+func (i *Issuer) IDToken(user User, expiry time.Time) string {
+	return i.sign(map[string]interface{}{
+		"iss":    i.URL,
+		"sub":    user.Email,
+		"aud":    i.ClientID,
+		"iat":    time.Now().Unix(),
+		"exp":    expiry.Unix(),
+		"email":  user.Email,
+		"name":   user.Name,
+		"groups": user.Groups,
+
+		"email_verified": !user.EmailUnverified,
+	})
+}
+
+// sign returns a JWT holding the given claims, signed with the
+// issuer's key.
+func (i *Issuer) sign(claims map[string]interface{}) string {
+	header := encodeSegment(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": keyID,
+	})
+	payload := encodeSegment(claims)
+	digest := sha256.Sum256([]byte(header + "." + payload))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (i *Issuer) serveDiscovery(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 i.URL,
+		"authorization_endpoint": i.URL + "/auth",
+		"token_endpoint":         i.URL + "/token",
+		"jwks_uri":               i.URL + "/keys",
+	})
+}
+
+func (i *Issuer) serveAuth(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	if query.Get("client_id") != i.ClientID {
+		http.Error(w, "unknown client", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme != "http" || redirectURI.Host == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	response := redirectURI.Query()
+	response.Set("state", query.Get("state"))
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" {
+		response.Set("error", "unsupported_response_type")
+	} else {
+		i.mu.Lock()
+		i.nextCode++
+		code := fmt.Sprintf("code-%d", i.nextCode)
+		i.authorizations[code] = authorization{
+			user:        i.user,
+			redirectURI: query.Get("redirect_uri"),
+			challenge:   query.Get("code_challenge"),
+		}
+		i.mu.Unlock()
+		response.Set("code", code)
+	}
+	redirectURI.RawQuery = response.Encode()
+	http.Redirect(w, req, redirectURI.String(), http.StatusSeeOther)
+}
+
+func (i *Issuer) serveToken(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	code := req.PostForm.Get("code")
+	i.mu.Lock()
+	auth, ok := i.authorizations[code]
+	delete(i.authorizations, code)
+	i.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(req.PostForm.Get("code_verifier")))
+	switch {
+	case req.PostForm.Get("grant_type") != "authorization_code":
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+	case !ok,
+		req.PostForm.Get("client_id") != i.ClientID,
+		req.PostForm.Get("redirect_uri") != auth.redirectURI,
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != auth.challenge:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+	default:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"token_type":   "Bearer",
+			"access_token": code,
+			"expires_in":   3600,
+			"id_token":     i.IDToken(auth.user, time.Now().Add(time.Hour)),
+		})
+	}
+}
+
+func (i *Issuer) serveKeys(w http.ResponseWriter, req *http.Request) {
+	i.mu.Lock()
+	i.keyFetches++
+	i.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(i.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(i.key.E)).Bytes()),
+		}},
+	})
+}
+
+func encodeSegment(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -164,3 +164,38 @@ func DistributeInstances(env ZonedEnviron, candidates, group []instance.Id) ([]i
 	}
 	return eligible, nil
 }
+
+// DistributeZones returns the names of the availability zones in which
+// count new instances of the specified group should be started, so that
+// the group is spread as evenly as possible across the available zones.
+// Each new instance is assigned to the least populated zone, taking
+// into account the instances assigned before it; zones with the same
+// population are chosen in order of name. If the environ has fewer
+// than two available zones, there is nothing to spread the group
+// across, and DistributeZones returns nil.
+//
+// As with AvailabilityZoneAllocations, an empty group is taken
+// to mean all instances in the environ.
+func DistributeZones(env ZonedEnviron, group []instance.Id, count int) ([]string, error) {
+	zoneInstances, err := internalAvailabilityZoneAllocations(env, group)
+	if err != nil || len(zoneInstances) < 2 {
+		return nil, err
+	}
+	population := make([]int, len(zoneInstances))
+	for i, zone := range zoneInstances {
+		population[i] = len(zone.Instances)
+	}
+	zones := make([]string, count)
+	for i := range zones {
+		best := 0
+		for j := range zoneInstances {
+			if population[j] < population[best] ||
+				population[j] == population[best] && zoneInstances[j].ZoneName < zoneInstances[best].ZoneName {
+				best = j
+			}
+		}
+		zones[i] = zoneInstances[best].ZoneName
+		population[best]++
+	}
+	return zones, nil
+}
@@ -254,3 +254,48 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 		c.Assert(eligible, jc.SameContents, test.eligible)
 	}
 }
+
+func (s *AvailabilityZoneSuite) TestDistributeZones(c *gc.C) {
+	s.PatchValue(common.InternalAvailabilityZoneAllocations, func(_ common.ZonedEnviron, group []instance.Id) ([]common.AvailabilityZoneInstances, error) {
+		c.Assert(group, gc.DeepEquals, []instance.Id{"i0", "i1"})
+		return []common.AvailabilityZoneInstances{{
+			ZoneName: "az2",
+		}, {
+			ZoneName:  "az0",
+			Instances: []instance.Id{"i0"},
+		}, {
+			ZoneName:  "az1",
+			Instances: []instance.Id{"i1"},
+		}}, nil
+	})
+	zones, err := common.DistributeZones(&s.env, []instance.Id{"i0", "i1"}, 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.DeepEquals, []string{"az2", "az0", "az1", "az2"})
+}
+
+func (s *AvailabilityZoneSuite) TestDistributeZonesNoZones(c *gc.C) {
+	s.PatchValue(common.InternalAvailabilityZoneAllocations, func(_ common.ZonedEnviron, group []instance.Id) ([]common.AvailabilityZoneInstances, error) {
+		return nil, nil
+	})
+	zones, err := common.DistributeZones(&s.env, nil, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.IsNil)
+}
+
+func (s *AvailabilityZoneSuite) TestDistributeZonesSingleZone(c *gc.C) {
+	s.PatchValue(common.InternalAvailabilityZoneAllocations, func(_ common.ZonedEnviron, group []instance.Id) ([]common.AvailabilityZoneInstances, error) {
+		return []common.AvailabilityZoneInstances{{ZoneName: "az0"}}, nil
+	})
+	zones, err := common.DistributeZones(&s.env, nil, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.IsNil)
+}
+
+func (s *AvailabilityZoneSuite) TestDistributeZonesErrors(c *gc.C) {
+	resultErr := fmt.Errorf("whatever")
+	s.PatchValue(common.InternalAvailabilityZoneAllocations, func(_ common.ZonedEnviron, group []instance.Id) ([]common.AvailabilityZoneInstances, error) {
+		return nil, resultErr
+	})
+	_, err := common.DistributeZones(&s.env, nil, 2)
+	c.Assert(err, gc.Equals, resultErr)
+}
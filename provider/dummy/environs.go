@@ -729,6 +729,9 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 		if err != nil {
 			panic(err)
 		}
+		if err := st.SetSecretsKey(testing.SecretsKey); err != nil {
+			panic(err)
+		}
 		if err := st.SetModelConstraints(args.ModelConstraints); err != nil {
 			panic(err)
 		}
@@ -1459,7 +1462,7 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
-	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+	if mode := e.ecfg().FirewallMode(); !e.ecfg().GlobalFirewall() {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
 	}
 	estate, err := e.state()
@@ -1475,7 +1478,7 @@ func (e *environ) OpenPorts(ports []network.PortRange) error {
 }
 
 func (e *environ) ClosePorts(ports []network.PortRange) error {
-	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+	if mode := e.ecfg().FirewallMode(); !e.ecfg().GlobalFirewall() {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model", mode)
 	}
 	estate, err := e.state()
@@ -1491,7 +1494,7 @@ func (e *environ) ClosePorts(ports []network.PortRange) error {
 }
 
 func (e *environ) Ports() (ports []network.PortRange, err error) {
-	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+	if mode := e.ecfg().FirewallMode(); !e.ecfg().GlobalFirewall() {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model", mode)
 	}
 	estate, err := e.state()
@@ -340,6 +340,11 @@ var configTests = []configTest{
 			"firewall-mode": "none",
 		},
 		firewallMode: config.FwNone,
+	}, {
+		config: attrs{
+			"firewall-mode": "machine",
+		},
+		firewallMode: config.FwMachine,
 	}, {
 		config: attrs{
 			"ssl-hostname-verification": false,
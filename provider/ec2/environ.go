@@ -1348,7 +1348,7 @@ func (e *environ) portsInGroup(name string) (ports []network.PortRange, err erro
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
-	if e.Config().FirewallMode() != config.FwGlobal {
+	if !e.Config().GlobalFirewall() {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
 	}
 	if err := e.openPortsInGroup(e.globalGroupName(), ports); err != nil {
@@ -1359,7 +1359,7 @@ func (e *environ) OpenPorts(ports []network.PortRange) error {
 }
 
 func (e *environ) ClosePorts(ports []network.PortRange) error {
-	if e.Config().FirewallMode() != config.FwGlobal {
+	if !e.Config().GlobalFirewall() {
 		return errors.Errorf("invalid firewall mode %q for closing ports on model", e.Config().FirewallMode())
 	}
 	if err := e.closePortsInGroup(e.globalGroupName(), ports); err != nil {
@@ -1370,7 +1370,7 @@ func (e *environ) ClosePorts(ports []network.PortRange) error {
 }
 
 func (e *environ) Ports() ([]network.PortRange, error) {
-	if e.Config().FirewallMode() != config.FwGlobal {
+	if !e.Config().GlobalFirewall() {
 		return nil, errors.Errorf("invalid firewall mode %q for retrieving ports from model", e.Config().FirewallMode())
 	}
 	return e.portsInGroup(e.globalGroupName())
@@ -1638,7 +1638,7 @@ func (e *environ) setUpGroups(machineId string, apiPort int) ([]ec2.SecurityGrou
 	switch e.Config().FirewallMode() {
 	case config.FwInstance:
 		machineGroup, err = e.ensureGroup(e.machineGroupName(machineId), nil)
-	case config.FwGlobal:
+	case config.FwGlobal, config.FwMachine:
 		machineGroup, err = e.ensureGroup(e.globalGroupName(), nil)
 	}
 	if err != nil {
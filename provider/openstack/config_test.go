@@ -362,6 +362,12 @@ var configTests = []configTest{
 			"firewall-mode": "none",
 		}),
 		firewallMode: config.FwNone,
+	}, {
+		summary: "machine firewall-mode",
+		config: requiredConfig.Merge(testing.Attrs{
+			"firewall-mode": "machine",
+		}),
+		firewallMode: config.FwMachine,
 	}, {
 		config: requiredConfig.Merge(testing.Attrs{
 			"future": "hammerstein",
@@ -100,7 +100,7 @@ func (c *defaultFirewaller) SetUpGroups(machineId string, apiPort int) ([]nova.S
 	switch c.environ.Config().FirewallMode() {
 	case config.FwInstance:
 		machineGroup, err = c.ensureGroup(c.machineGroupName(machineId), nil)
-	case config.FwGlobal:
+	case config.FwGlobal, config.FwMachine:
 		machineGroup, err = c.ensureGroup(c.globalGroupName(), nil)
 	}
 	if err != nil {
@@ -295,7 +295,7 @@ func deleteSecurityGroup(novaclient *nova.Client, name, id string) {
 
 // OpenPorts implements Firewaller interface.
 func (c *defaultFirewaller) OpenPorts(ports []network.PortRange) error {
-	if c.environ.Config().FirewallMode() != config.FwGlobal {
+	if !c.environ.Config().GlobalFirewall() {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
 			c.environ.Config().FirewallMode())
 	}
@@ -308,7 +308,7 @@ func (c *defaultFirewaller) OpenPorts(ports []network.PortRange) error {
 
 // ClosePorts implements Firewaller interface.
 func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
-	if c.environ.Config().FirewallMode() != config.FwGlobal {
+	if !c.environ.Config().GlobalFirewall() {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
 			c.environ.Config().FirewallMode())
 	}
@@ -321,7 +321,7 @@ func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
 
 // Ports implements Firewaller interface.
 func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
-	if c.environ.Config().FirewallMode() != config.FwGlobal {
+	if !c.environ.Config().GlobalFirewall() {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
 			c.environ.Config().FirewallMode())
 	}
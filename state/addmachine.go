@@ -42,6 +42,12 @@ type MachineTemplate struct {
 	// It is ignored if Jobs does not contain JobManageModel.
 	NoVote bool
 
+	// Witness holds whether a machine running a controller is
+	// a witness, which keeps a copy of the database but never
+	// wants to vote. Witnesses always have NoVote set.
+	// It is ignored if Jobs does not contain JobManageModel.
+	Witness bool
+
 	// Addresses holds the addresses to be associated with the
 	// new machine.
 	//
@@ -464,7 +470,8 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		Addresses:               fromNetworkAddresses(template.Addresses, OriginMachine),
 		PreferredPrivateAddress: fromNetworkAddress(privateAddr, OriginMachine),
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
-		NoVote:                  template.NoVote,
+		NoVote:                  template.NoVote || template.Witness,
+		Witness:                 template.Witness,
 		Placement:               template.Placement,
 	}
 }
@@ -735,6 +742,20 @@ func (st *State) maintainControllersOps(mdocs []*machineDoc, currentInfo *Contro
 func (st *State) EnableHA(
 	numControllers int, cons constraints.Value, series string, placement []string,
 ) (ControllersChanges, error) {
+	return st.EnableHAWithWitnesses(numControllers, 0, cons, series, placement)
+}
+
+// EnableHAWithWitnesses is like EnableHA, but also adds witness
+// controller machines as necessary to make the number of live witnesses
+// equal to numWitnesses. Witnesses keep a copy of the database but do
+// not vote, so they may be added in any number; the peer grouper gives
+// a witness a vote only while it stands in for an unhealthy voting
+// controller. If numWitnesses is zero, the current number of witnesses
+// is maintained. New witnesses are placed after any new voting
+// controllers.
+func (st *State) EnableHAWithWitnesses(
+	numControllers, numWitnesses int, cons constraints.Value, series string, placement []string,
+) (ControllersChanges, error) {
 
 	if numControllers < 0 || (numControllers != 0 && numControllers%2 != 1) {
 		return ControllersChanges{}, errors.New("number of controllers must be odd and non-negative")
@@ -742,6 +763,12 @@ func (st *State) EnableHA(
 	if numControllers > replicaset.MaxPeers {
 		return ControllersChanges{}, errors.Errorf("controller count is too large (allowed %d)", replicaset.MaxPeers)
 	}
+	if numWitnesses < 0 {
+		return ControllersChanges{}, errors.New("number of witnesses must be non-negative")
+	}
+	if numWitnesses > replicaset.MaxPeers {
+		return ControllersChanges{}, errors.Errorf("witness count is too large (allowed %d)", replicaset.MaxPeers)
+	}
 	var change ControllersChanges
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		currentInfo, err := st.ControllerInfo()
@@ -763,13 +790,22 @@ func (st *State) EnableHA(
 		if err != nil {
 			return nil, err
 		}
+		desiredWitnessCount := numWitnesses
+		if desiredWitnessCount == 0 {
+			desiredWitnessCount = intent.witnessCount
+		}
+		if len(intent.witnesses) > desiredWitnessCount {
+			return nil, errors.New("cannot reduce witness count")
+		}
+		intent.newWitnessCount = desiredWitnessCount - len(intent.witnesses)
+
 		voteCount := 0
 		for _, m := range intent.maintain {
 			if m.WantsVote() {
 				voteCount++
 			}
 		}
-		if voteCount == desiredControllerCount && len(intent.remove) == 0 {
+		if voteCount == desiredControllerCount && len(intent.remove) == 0 && intent.newWitnessCount == 0 {
 			return nil, jujutxn.ErrNoOperations
 		}
 		// Promote as many machines as we can to fulfil the shortfall.
@@ -785,7 +821,8 @@ func (st *State) EnableHA(
 
 		intent.newCount = desiredControllerCount - voteCount
 
-		logger.Infof("%d new machines; promoting %v; converting %v", intent.newCount, intent.promote, intent.convert)
+		logger.Infof("%d new machines; %d new witnesses; promoting %v; converting %v",
+			intent.newCount, intent.newWitnessCount, intent.promote, intent.convert)
 
 		var ops []txn.Op
 		ops, change, err = st.enableHAIntentionOps(intent, currentInfo, cons, series)
@@ -806,6 +843,10 @@ type ControllersChanges struct {
 	Promoted   []string
 	Demoted    []string
 	Converted  []string
+
+	// AddedWitnesses holds the ids of new witness machines,
+	// which are not included in Added.
+	AddedWitnesses []string
 }
 
 // enableHAIntentionOps returns operations to fulfil the desired intent.
@@ -842,8 +883,9 @@ func (st *State) enableHAIntentionOps(
 		placementCount++
 		return result
 	}
-	mdocs := make([]*machineDoc, intent.newCount)
+	mdocs := make([]*machineDoc, intent.newCount+intent.newWitnessCount)
 	for i := range mdocs {
+		witness := i >= intent.newCount
 		template := MachineTemplate{
 			Series: series,
 			Jobs: []MachineJob{
@@ -852,6 +894,7 @@ func (st *State) enableHAIntentionOps(
 			},
 			Constraints: cons,
 			Placement:   getPlacement(),
+			Witness:     witness,
 		}
 		mdoc, addOps, err := st.addMachineOps(template)
 		if err != nil {
@@ -859,7 +902,11 @@ func (st *State) enableHAIntentionOps(
 		}
 		mdocs[i] = mdoc
 		ops = append(ops, addOps...)
-		change.Added = append(change.Added, mdoc.Id)
+		if witness {
+			change.AddedWitnesses = append(change.AddedWitnesses, mdoc.Id)
+		} else {
+			change.Added = append(change.Added, mdoc.Id)
+		}
 
 	}
 	for _, m := range intent.remove {
@@ -896,10 +943,17 @@ var controllerAvailable = func(m *Machine) (bool, error) {
 }
 
 type enableHAIntent struct {
-	newCount  int
-	placement []string
+	newCount        int
+	newWitnessCount int
+	placement       []string
 
 	promote, maintain, demote, remove, convert []*Machine
+
+	// witnesses holds the available witness machines, which are
+	// also included in maintain. witnessCount holds the number of
+	// witnesses to be maintained or replaced.
+	witnesses    []*Machine
+	witnessCount int
 }
 
 // enableHAIntentions returns what we would like
@@ -952,6 +1006,24 @@ func (st *State) enableHAIntentions(info *ControllerInfo, placement []string) (*
 			return nil, err
 		}
 		logger.Infof("machine %q, available %v, wants vote %v, has vote %v", m, available, m.WantsVote(), m.HasVote())
+		if m.IsWitness() {
+			// Witnesses never want the vote, so are neither
+			// promoted nor demoted. An unavailable witness is
+			// removed, and replaced, once it no longer holds
+			// a vote.
+			switch {
+			case available:
+				intent.maintain = append(intent.maintain, m)
+				intent.witnesses = append(intent.witnesses, m)
+				intent.witnessCount++
+			case m.HasVote():
+				intent.maintain = append(intent.maintain, m)
+			default:
+				intent.remove = append(intent.remove, m)
+				intent.witnessCount++
+			}
+			continue
+		}
 		if available {
 			if m.WantsVote() {
 				intent.maintain = append(intent.maintain, m)
@@ -1033,7 +1105,7 @@ func removeControllerOps(m *Machine) []txn.Op {
 		Assert: bson.D{{"novote", true}, {"hasvote", false}},
 		Update: bson.D{
 			{"$pull", bson.D{{"jobs", JobManageModel}}},
-			{"$set", bson.D{{"novote", false}}},
+			{"$set", bson.D{{"novote", false}, {"witness", false}}},
 		},
 	}, {
 		C:      controllersC,
@@ -140,6 +140,14 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection holds the external users that have logged in to
+		// the controller, with the groups reported by their identity
+		// provider and the last time they connected to the API server.
+		externalUsersC: {
+			global:    true,
+			rawAccess: true,
+		},
+
 		// This collection is used as a unique key restraint. The _id field is
 		// a concatenation of multiple fields that form a compound index,
 		// allowing us to ensure users cannot have the same name for two
@@ -163,6 +171,14 @@ func allCollections() collectionSchema {
 		// was implemented.
 		actionresultsC: {global: true},
 
+		// This collection holds the cloud credentials stored in the
+		// controller, which models reference by name.
+		cloudCredentialsC: {global: true},
+
+		// This collection holds the clouds registered with the
+		// controller, in addition to the one it runs in.
+		cloudsC: {global: true},
+
 		// This collection holds storage items for a macaroon bakery.
 		bakeryStorageItemsC: {
 			global:  true,
@@ -242,6 +258,16 @@ func allCollections() collectionSchema {
 		},
 		relationScopesC: {},
 
+		// This collection holds charm secrets, owned by applications
+		// and units and encrypted at rest.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}, {
+				Key: []string{"model-uuid", "grants.application"},
+			}},
+		},
+
 		// -----
 
 		// These collections hold information associated with machines.
@@ -306,6 +332,7 @@ func allCollections() collectionSchema {
 		ipAddressesC:          {},
 		endpointBindingsC:     {},
 		openedPortsC:          {},
+		spaceDriftC:           {},
 
 		// -----
 
@@ -364,6 +391,16 @@ func allCollections() collectionSchema {
 		// ======================
 
 		// metrics; status-history; logs; ..?
+
+		// This collection holds a record of each hook, action and
+		// juju-run execution on each unit. It is pruned along with
+		// the status history.
+		hookHistoryC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "started"},
+			}},
+		},
 	}
 }
 
@@ -382,14 +419,18 @@ const (
 	blocksC                  = "blocks"
 	charmsC                  = "charms"
 	cleanupsC                = "cleanups"
+	cloudCredentialsC        = "cloudCredentials"
 	cloudimagemetadataC      = "cloudimagemetadata"
+	cloudsC                  = "clouds"
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
 	controllersC             = "controllers"
+	externalUsersC           = "externalUsers"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
+	hookHistoryC             = "hookhistory"
 	instanceDataC            = "instanceData"
 	legacyipaddressesC       = "ipaddresses"
 	leaseC                   = "lease"
@@ -412,12 +453,14 @@ const (
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	secretsC                 = "secrets"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
 	settingsC                = "settings"
 	settingsrefsC            = "settingsrefs"
 	sshHostKeysC             = "sshhostkeys"
+	spaceDriftC              = "spacedrift"
 	spacesC                  = "spaces"
 	statusesC                = "statuses"
 	statusesHistoryC         = "statuseshistory"
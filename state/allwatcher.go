@@ -318,12 +318,19 @@ func (u *backingUnit) updated(st *State, store *multiwatcherStore, id string) er
 		info.PortRanges = portRanges
 		info.Ports = compatiblePorts
 
+		workloadVersion, err := getStatus(st, globalWorkloadVersionKey(u.Name), "workload version")
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "reading workload version for %q", u.Name)
+		}
+		info.WorkloadVersion = workloadVersion.Message
+
 	} else {
 		// The entry already exists, so preserve the current status and ports.
 		oldInfo := oldInfo.(*multiwatcher.UnitInfo)
 		// Unit and workload status.
 		info.JujuStatus = oldInfo.JujuStatus
 		info.WorkloadStatus = oldInfo.WorkloadStatus
+		info.WorkloadVersion = oldInfo.WorkloadVersion
 		info.Ports = oldInfo.Ports
 		info.PortRanges = oldInfo.PortRanges
 	}
@@ -618,6 +625,11 @@ func (s *backingStatus) updated(st *State, store *multiwatcherStore, id string)
 		return nil
 	case *multiwatcher.UnitInfo:
 		newInfo := *info
+		if strings.HasSuffix(id, "#sat#workload-version") {
+			newInfo.WorkloadVersion = s.StatusInfo
+			info0 = &newInfo
+			break
+		}
 		// Get the unit's current recorded status from state.
 		// It's needed to reset the unit status when a unit comes off error.
 		statusInfo, err := getStatus(st, unitGlobalKey(newInfo.Name), "unit")
@@ -944,6 +956,7 @@ func backingEntityIdForGlobalKey(modelUUID, key string) (multiwatcher.EntityId,
 			Id:        id,
 		}).EntityId(), true
 	case 'u':
+		id = strings.TrimSuffix(id, "#charm#sat#workload-version")
 		id = strings.TrimSuffix(id, "#charm")
 		return (&multiwatcher.UnitInfo{
 			ModelUUID: modelUUID,
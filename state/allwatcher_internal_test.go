@@ -2656,6 +2656,32 @@ func testChangeUnits(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C, []
 						},
 					}}}
 		},
+		func(c *gc.C, st *State) changeTestCase {
+			wordpress := AddTestingService(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"), owner)
+			u, err := wordpress.AddUnit()
+			c.Assert(err, jc.ErrorIsNil)
+			err = u.SetWorkloadVersion("42.1")
+			c.Assert(err, jc.ErrorIsNil)
+
+			return changeTestCase{
+				about: "workload version is changed if the unit exists in the store",
+				initialContents: []multiwatcher.EntityInfo{&multiwatcher.UnitInfo{
+					ModelUUID:   st.ModelUUID(),
+					Name:        "wordpress/0",
+					Application: "wordpress",
+				}},
+				change: watcher.Change{
+					C:  "statuses",
+					Id: st.docID("u#wordpress/0#charm#sat#workload-version"),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.UnitInfo{
+						ModelUUID:       st.ModelUUID(),
+						Name:            "wordpress/0",
+						Application:     "wordpress",
+						WorkloadVersion: "42.1",
+					}}}
+		},
 		func(c *gc.C, st *State) changeTestCase {
 			wordpress := AddTestingService(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"), owner)
 			u, err := wordpress.AddUnit()
@@ -48,6 +48,7 @@ type applicationDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	Trusted              bool       `bson:"trusted,omitempty"`
 	MinUnits             int        `bson:"minunits"`
 	OwnerTag             string     `bson:"ownertag"`
 	TxnRevno             int64      `bson:"txn-revno"`
@@ -271,6 +272,14 @@ func (s *Application) removeOps(asserts bson.D) []txn.Op {
 		removeStatusOp(s.st, s.globalKey()),
 		removeModelServiceRefOp(s.st, s.Name()),
 	}
+	ops = append(ops, removeEntityBlocksOps(s.st, s.Tag())...)
+	ops = append(ops, s.st.secretsCleanupOps(
+		bson.D{{"$or", []bson.D{
+			{{"owner", s.Tag().String()}},
+			{{"grants.application", s.doc.Name}},
+		}}},
+		cleanupSecretsForRemovedApplication, s.doc.Name,
+	)...)
 	// For local charms, we also delete the charm itself since the
 	// charm is associated 1:1 with the service. Each different deploy
 	// of a local charm creates a new copy with a different revision.
@@ -313,6 +322,38 @@ func (s *Application) setExposed(exposed bool) (err error) {
 	return nil
 }
 
+// IsTrusted returns whether the application's charm has been granted
+// access to the model's cloud credential.
+func (s *Application) IsTrusted() bool {
+	return s.doc.Trusted
+}
+
+// SetTrusted grants the application's charm access to the model's
+// cloud credential. See ClearTrusted and IsTrusted.
+func (s *Application) SetTrusted() error {
+	return s.setTrusted(true)
+}
+
+// ClearTrusted revokes the application's access to the model's cloud
+// credential. See SetTrusted and IsTrusted.
+func (s *Application) ClearTrusted() error {
+	return s.setTrusted(false)
+}
+
+func (s *Application) setTrusted(trusted bool) error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"trusted", trusted}}}},
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return errors.Errorf("cannot set trusted flag for application %q to %v: %v", s, trusted, onAbort(err, errNotAlive))
+	}
+	s.doc.Trusted = trusted
+	return nil
+}
+
 // Charm returns the service's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (s *Application) Charm() (ch *Charm, force bool, err error) {
@@ -965,12 +1006,17 @@ func (s *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		StatusInfo: MessageWaitForAgentInit,
 		Updated:    now.UnixNano(),
 	}
+	workloadVersionDoc := statusDoc{
+		Status:  status.StatusUnknown,
+		Updated: now.UnixNano(),
+	}
 
 	ops := addUnitOps(s.st, addUnitOpsArgs{
-		unitDoc:           udoc,
-		agentStatusDoc:    agentStatusDoc,
-		workloadStatusDoc: unitStatusDoc,
-		meterStatusDoc:    &meterStatusDoc{Code: MeterNotSet.String()},
+		unitDoc:            udoc,
+		agentStatusDoc:     agentStatusDoc,
+		workloadStatusDoc:  unitStatusDoc,
+		workloadVersionDoc: workloadVersionDoc,
+		meterStatusDoc:     &meterStatusDoc{Code: MeterNotSet.String()},
 	})
 
 	ops = append(ops, storageOps...)
@@ -1103,6 +1149,7 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeMeterStatusOp(s.st, u.globalMeterStatusKey()),
 		removeStatusOp(s.st, u.globalAgentKey()),
 		removeStatusOp(s.st, u.globalKey()),
+		removeStatusOp(s.st, u.globalWorkloadVersionKey()),
 		removeConstraintsOp(s.st, u.globalAgentKey()),
 		annotationRemoveOp(s.st, u.globalKey()),
 		s.st.newCleanupOp(cleanupRemovedUnit, u.doc.Name),
@@ -1381,6 +1428,102 @@ func (s *Application) EndpointBindings() (map[string]string, error) {
 	return bindings, nil
 }
 
+// SetEndpointBindings merges the given bindings into the existing endpoint
+// bindings of the application. Endpoints not mentioned in bindings keep
+// their current space. Every machine hosting a unit of the application must
+// have at least one address in each space an endpoint is being bound to.
+// Units are notified through their config settings watcher, so they run
+// config-changed and see the new addresses in network-get. The
+// private-address relation setting of each unit is updated for rebound
+// endpoints, so units on the other side of those relations run
+// relation-changed.
+func (s *Application) SetEndpointBindings(bindings map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set endpoint bindings for application %q", s.doc.Name)
+	if len(bindings) == 0 {
+		return nil
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		ch, _, err := s.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units, err := s.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitOps, unitAddresses, err := validateEndpointBindingsForUnits(s.st, bindings, units)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relations, err := s.Relations()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relationOps, err := relationAddressOps(s.st, relations, s.doc.Name, bindings, units, unitAddresses)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOp, err := updateEndpointBindingsOp(s.st, s.globalKey(), bindings, ch.Meta())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:  applicationsC,
+			Id: s.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"charmurl", s.doc.CharmURL},
+				{"unitcount", s.doc.UnitCount},
+				{"relationcount", s.doc.RelationCount},
+			},
+		}, bindingsOp, {
+			// Bump the settings version so units watching their
+			// config settings run config-changed and pick up the
+			// new bindings.
+			C:      settingsC,
+			Id:     s.settingsKey(),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$inc", bson.D{{"version", 1}}}},
+		}}
+		ops = append(ops, unitOps...)
+		return append(ops, relationOps...), nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return err
+	}
+	s.recordEndpointBindingsChange(bindings)
+	return nil
+}
+
+// recordEndpointBindingsChange adds an entry to the application's status
+// history describing the given bindings change, leaving the current
+// status untouched.
+func (s *Application) recordEndpointBindingsChange(bindings map[string]string) {
+	current, err := s.Status()
+	if err != nil {
+		logger.Errorf("cannot record endpoint bindings change for %q: %v", s.doc.Name, err)
+		return
+	}
+	data := make(map[string]interface{}, len(bindings))
+	for endpoint, space := range bindings {
+		data[endpoint] = space
+	}
+	probablyUpdateStatusHistory(s.st, s.globalKey(), statusDoc{
+		Status:     current.Status,
+		StatusInfo: "endpoint bindings changed",
+		StatusData: escapeKeys(data),
+		Updated:    GetClock().Now().UnixNano(),
+	})
+}
+
 // defaultEndpointBindings returns a map with each endpoint from the current
 // charm metadata bound to an empty space. If no charm URL is set yet, it
 // returns an empty map.
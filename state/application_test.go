@@ -1346,6 +1346,28 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestServiceTrusted(c *gc.C) {
+	c.Assert(s.mysql.IsTrusted(), jc.IsFalse)
+
+	err := s.mysql.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsTrue)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsTrue)
+
+	err = s.mysql.ClearTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsFalse)
+
+	_, err = s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetTrusted()
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -2421,6 +2443,141 @@ func (s *ServiceSuite) TestEndpointBindingsWithExplictOverrides(c *gc.C) {
 	s.assertServiceRemovedWithItsBindings(c, service)
 }
 
+func (s *ServiceSuite) TestSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", ch, nil)
+
+	err = service.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	setBindings, err := service.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setBindings, jc.DeepEquals, map[string]string{
+		"server":  "db",
+		"client":  "",
+		"cluster": "",
+	})
+
+	history, err := service.StatusHistory(status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Message, gc.Equals, "endpoint bindings changed")
+	c.Assert(history[0].Data, jc.DeepEquals, map[string]interface{}{"server": "db"})
+}
+
+func (s *ServiceSuite) TestSetEndpointBindingsUnknownEndpoint(c *gc.C) {
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", ch, nil)
+
+	err := service.SetEndpointBindings(map[string]string{"foo": ""})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "yoursql": unknown endpoint "foo" not valid`)
+}
+
+func (s *ServiceSuite) addUnitOnMachineWithAddress(c *gc.C, service *state.Application, cidrAddress string) *state.Unit {
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		CIDRAddress:  cidrAddress,
+		ConfigMethod: state.StaticAddress,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *ServiceSuite) TestSetEndpointBindingsChecksUnitMachineAddresses(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24", SpaceName: "ha"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", "", []string{"10.0.0.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("ha", "", []string{"10.0.1.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", ch, nil)
+	unit := s.addUnitOnMachineWithAddress(c, service, "10.0.0.10/24")
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = service.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = service.SetEndpointBindings(map[string]string{"cluster": "ha"})
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(
+		`cannot set endpoint bindings for application "yoursql": `+
+			`machine %q hosting unit "yoursql/0" has no addresses in space\(s\) ha`, machineId,
+	))
+
+	setBindings, err := service.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setBindings["cluster"], gc.Equals, "")
+}
+
+func (s *ServiceSuite) addDBSpaceWithUnit(c *gc.C) (*state.Application, *state.Unit) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", "", []string{"10.0.0.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", ch, nil)
+	unit := s.addUnitOnMachineWithAddress(c, service, "10.0.0.10/24")
+	return service, unit
+}
+
+func (s *ServiceSuite) TestSetEndpointBindingsUpdatesRelationAddresses(c *gc.C) {
+	service, unit := s.addDBSpaceWithUnit(c)
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "yoursql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"private-address": "192.168.0.10"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = service.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := ru.ReadSettings("yoursql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["private-address"], gc.Equals, "10.0.0.10")
+}
+
+func (s *ServiceSuite) TestSetEndpointBindingsWithAddressRemoved(c *gc.C) {
+	service, unit := s.addDBSpaceWithUnit(c)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		addresses, err := machine.AllAddresses()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(addresses, gc.HasLen, 1)
+		err = addresses[0].Remove()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = service.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(
+		`cannot set endpoint bindings for application "yoursql": `+
+			`machine %q hosting unit "yoursql/0" has no addresses in space\(s\) db`, machineId,
+	))
+}
+
 func (s *ServiceSuite) TestSetCharmExtraBindingsUseDefaults(c *gc.C) {
 	_, err := s.State.AddSpace("db", "", nil, true)
 	c.Assert(err, jc.ErrorIsNil)
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -32,6 +33,16 @@ type Block interface {
 
 	// Message returns explanation that accompanies this block.
 	Message() string
+
+	// Owner returns the canonical name of the user that switched the
+	// block on. It is empty if the block was created before owners
+	// were recorded.
+	Owner() string
+
+	// Created returns when the block was switched on. It is the zero
+	// time if the block was created before creation times were
+	// recorded.
+	Created() time.Time
 }
 
 // BlockType specifies block type for enum benefit.
@@ -115,6 +126,8 @@ type blockDoc struct {
 	Tag       string    `bson:"tag"`
 	Type      BlockType `bson:"type"`
 	Message   string    `bson:"message,omitempty"`
+	Owner     string    `bson:"owner,omitempty"`
+	Created   time.Time `bson:"created,omitempty"`
 }
 
 // Id is part of the state.Block interface.
@@ -146,10 +159,36 @@ func (b *block) Type() BlockType {
 	return b.doc.Type
 }
 
+// Owner is part of the state.Block interface.
+func (b *block) Owner() string {
+	return b.doc.Owner
+}
+
+// Created is part of the state.Block interface.
+func (b *block) Created() time.Time {
+	return b.doc.Created
+}
+
+// BlockArgs holds the arguments for switching a block on.
+type BlockArgs struct {
+	// Type is the type of the block.
+	Type BlockType
+
+	// Entity is the tag of the application or machine that the block
+	// applies to. If it is nil, the block applies to the whole model.
+	Entity names.Tag
+
+	// Owner is the user switching the block on.
+	Owner names.UserTag
+
+	// Message explains why the block is in place.
+	Message string
+}
+
 // SwitchBlockOn enables block of specified type for the
 // current model.
 func (st *State) SwitchBlockOn(t BlockType, msg string) error {
-	return setModelBlock(st, t, msg)
+	return setModelBlock(st, BlockArgs{Type: t, Message: msg})
 }
 
 // SwitchBlockOff disables block of specified type for the
@@ -158,6 +197,37 @@ func (st *State) SwitchBlockOff(t BlockType) error {
 	return RemoveModelBlock(st, t)
 }
 
+// AddBlock switches on a block for the current model or, if
+// args.Entity is set, for a single application or machine in it.
+// Blocks on an application also apply to its units. Only one block of
+// each type can exist for the model and each entity.
+func (st *State) AddBlock(args BlockArgs) error {
+	if args.Entity == nil {
+		return setModelBlock(st, args)
+	}
+	return setEntityBlock(st, args)
+}
+
+// RemoveBlock switches off the block of the specified type for the
+// current model or, if entity is not nil, for the given application or
+// machine.
+func (st *State) RemoveBlock(t BlockType, entity names.Tag) error {
+	if entity == nil {
+		return RemoveModelBlock(st, t)
+	}
+	ops := []txn.Op{{
+		C:      blocksC,
+		Id:     st.docID(entityBlockId(t, entity)),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.Errorf("block %v for %s is already OFF", t.String(), names.ReadableString(entity))
+	}
+	return errors.Trace(err)
+}
+
 // GetBlockForType returns the Block of the specified type for the current model
 // where
 //     not found -> nil, false, nil
@@ -168,7 +238,7 @@ func (st *State) GetBlockForType(t BlockType) (Block, bool, error) {
 	defer closer()
 
 	doc := blockDoc{}
-	err := all.Find(bson.D{{"type", t}}).One(&doc)
+	err := all.Find(bson.D{{"type", t}, {"tag", st.ModelTag().String()}}).One(&doc)
 
 	switch err {
 	case nil:
@@ -180,6 +250,27 @@ func (st *State) GetBlockForType(t BlockType) (Block, bool, error) {
 	}
 }
 
+// GetBlockForEntity returns the Block of the specified type for the
+// given application or machine, with the same results as
+// GetBlockForType. Blocks that apply to the whole model are not
+// returned.
+func (st *State) GetBlockForEntity(t BlockType, entity names.Tag) (Block, bool, error) {
+	all, closer := st.getCollection(blocksC)
+	defer closer()
+
+	doc := blockDoc{}
+	err := all.FindId(entityBlockId(t, entity)).One(&doc)
+
+	switch err {
+	case nil:
+		return &block{doc}, true, nil
+	case mgo.ErrNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, errors.Annotatef(err, "cannot get block of type %v for %s", t.String(), names.ReadableString(entity))
+	}
+}
+
 // AllBlocks returns all blocks in the model.
 func (st *State) AllBlocks() ([]Block, error) {
 	blocksCollection, closer := st.getCollection(blocksC)
@@ -242,7 +333,8 @@ func (st *State) RemoveAllBlocksForController() error {
 // setModelBlock updates the blocks collection with the
 // specified block.
 // Only one instance of each block type can exist in model.
-func setModelBlock(st *State, t BlockType, msg string) error {
+func setModelBlock(st *State, args BlockArgs) error {
+	t := args.Type
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		_, exists, err := st.GetBlockForType(t)
 		if err != nil {
@@ -253,11 +345,98 @@ func setModelBlock(st *State, t BlockType, msg string) error {
 		if exists {
 			return nil, errors.Errorf("block %v is already ON", t.String())
 		}
-		return createModelBlockOps(st, t, msg)
+		return createModelBlockOps(st, args)
 	}
 	return st.run(buildTxn)
 }
 
+// entityBlockId returns the id of the block of the specified type for
+// the given entity. Unlike model blocks, whose ids are sequential,
+// entity block ids are derived from the entity so that they can be
+// removed along with it.
+func entityBlockId(t BlockType, entity names.Tag) string {
+	return fmt.Sprintf("%s#%s", entity.String(), t.String())
+}
+
+// setEntityBlock adds the specified block for an application or
+// machine. Only one instance of each block type can exist for each
+// entity.
+func setEntityBlock(st *State, args BlockArgs) error {
+	var entityOp txn.Op
+	switch tag := args.Entity.(type) {
+	case names.ApplicationTag:
+		entityOp = txn.Op{
+			C:      applicationsC,
+			Id:     st.docID(tag.Id()),
+			Assert: isAliveDoc,
+		}
+	case names.MachineTag:
+		entityOp = txn.Op{
+			C:      machinesC,
+			Id:     st.docID(tag.Id()),
+			Assert: isAliveDoc,
+		}
+	default:
+		return errors.NotValidf("block on %s", names.ReadableString(args.Entity))
+	}
+	if args.Type == DestroyBlock {
+		return errors.NotValidf("block %v on %s", args.Type.String(), names.ReadableString(args.Entity))
+	}
+	doc := blockDoc{
+		DocID:     st.docID(entityBlockId(args.Type, args.Entity)),
+		ModelUUID: st.ModelUUID(),
+		Tag:       args.Entity.String(),
+		Type:      args.Type,
+		Message:   args.Message,
+		Owner:     blockOwner(args.Owner),
+		Created:   nowToTheSecond(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, exists, err := st.GetBlockForEntity(args.Type, args.Entity)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if exists {
+			return nil, errors.Errorf("block %v for %s is already ON", args.Type.String(), names.ReadableString(args.Entity))
+		}
+		if attempt > 0 {
+			// The block does not exist, so the entity must be
+			// missing or no longer alive.
+			return nil, errors.Errorf("%s is not alive", names.ReadableString(args.Entity))
+		}
+		return []txn.Op{entityOp, {
+			C:      blocksC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// blockOwner returns the name to record as the owner of a block, which
+// is empty if the owner is not known.
+func blockOwner(owner names.UserTag) string {
+	if owner.Name() == "" {
+		return ""
+	}
+	return owner.Canonical()
+}
+
+// removeEntityBlocksOps returns the operations required to remove any
+// blocks for the given application or machine.
+func removeEntityBlocksOps(st *State, entity names.Tag) []txn.Op {
+	var ops []txn.Op
+	for _, t := range []BlockType{RemoveBlock, ChangeBlock} {
+		ops = append(ops, txn.Op{
+			C:      blocksC,
+			Id:     st.docID(entityBlockId(t, entity)),
+			Remove: true,
+		})
+	}
+	return ops
+}
+
 // newBlockId returns a sequential block id for this model.
 func newBlockId(st *State) (string, error) {
 	seq, err := st.sequence("block")
@@ -267,7 +446,7 @@ func newBlockId(st *State) (string, error) {
 	return fmt.Sprint(seq), nil
 }
 
-func createModelBlockOps(st *State, t BlockType, msg string) ([]txn.Op, error) {
+func createModelBlockOps(st *State, args BlockArgs) ([]txn.Op, error) {
 	id, err := newBlockId(st)
 	if err != nil {
 		return nil, errors.Annotatef(err, "getting new block id")
@@ -279,8 +458,10 @@ func createModelBlockOps(st *State, t BlockType, msg string) ([]txn.Op, error) {
 		DocID:     st.docID(id),
 		ModelUUID: st.ModelUUID(),
 		Tag:       st.ModelTag().String(),
-		Type:      t,
-		Message:   msg,
+		Type:      args.Type,
+		Message:   args.Message,
+		Owner:     blockOwner(args.Owner),
+		Created:   nowToTheSecond(),
 	}
 	insertOp := txn.Op{
 		C:      blocksC,
@@ -186,6 +186,110 @@ func (s *blockSuite) TestModelUUID(c *gc.C) {
 	c.Assert(blocks[0].ModelUUID(), gc.Equals, st.ModelUUID())
 }
 
+func (s *blockSuite) TestAddBlockRecordsOwner(c *gc.C) {
+	err := s.State.AddBlock(state.BlockArgs{
+		Type:    state.ChangeBlock,
+		Owner:   s.Owner,
+		Message: "freeze",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	assertEnvHasBlock(c, s.State, state.ChangeBlock, "freeze")
+
+	blocks, err := s.State.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 1)
+	c.Assert(blocks[0].Owner(), gc.Equals, s.Owner.Canonical())
+	c.Assert(blocks[0].Created().IsZero(), jc.IsFalse)
+
+	err = s.State.RemoveBlock(state.ChangeBlock, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEnvBlock(c, s.State)
+}
+
+func (s *blockSuite) TestApplicationBlock(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.AddBlock(state.BlockArgs{
+		Type:    state.RemoveBlock,
+		Entity:  app.Tag(),
+		Owner:   s.Owner,
+		Message: "protect the database",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The block only applies to the application.
+	s.assertNoTypedBlock(c, state.RemoveBlock)
+	b, found, err := s.State.GetBlockForEntity(state.RemoveBlock, app.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(b.Message(), gc.Equals, "protect the database")
+	c.Assert(b.Owner(), gc.Equals, s.Owner.Canonical())
+	tag, err := b.Tag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, app.Tag())
+
+	_, found, err = s.State.GetBlockForEntity(state.ChangeBlock, app.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+
+	err = s.State.AddBlock(state.BlockArgs{Type: state.RemoveBlock, Entity: app.Tag()})
+	c.Assert(err, gc.ErrorMatches, `block BlockRemove for application "mysql" is already ON`)
+
+	err = s.State.RemoveBlock(state.RemoveBlock, app.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, found, err = s.State.GetBlockForEntity(state.RemoveBlock, app.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+
+	err = s.State.RemoveBlock(state.RemoveBlock, app.Tag())
+	c.Assert(err, gc.ErrorMatches, `block BlockRemove for application "mysql" is already OFF`)
+}
+
+func (s *blockSuite) TestMachineBlock(c *gc.C) {
+	m := s.Factory.MakeMachine(c, nil)
+	err := s.State.AddBlock(state.BlockArgs{Type: state.ChangeBlock, Entity: m.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+	_, found, err := s.State.GetBlockForEntity(state.ChangeBlock, m.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+
+	// Removing the machine removes its blocks.
+	err = m.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, found, err = s.State.GetBlockForEntity(state.ChangeBlock, m.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+}
+
+func (s *blockSuite) TestApplicationBlockRemovedWithApplication(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.AddBlock(state.BlockArgs{Type: state.ChangeBlock, Entity: app.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEnvBlock(c, s.State)
+}
+
+func (s *blockSuite) TestEntityBlockInvalid(c *gc.C) {
+	err := s.State.AddBlock(state.BlockArgs{
+		Type:   state.ChangeBlock,
+		Entity: names.NewUnitTag("mysql/0"),
+	})
+	c.Assert(err, gc.ErrorMatches, `block on unit "mysql/0" not valid`)
+
+	app := s.Factory.MakeApplication(c, nil)
+	err = s.State.AddBlock(state.BlockArgs{Type: state.DestroyBlock, Entity: app.Tag()})
+	c.Assert(err, gc.ErrorMatches, `block BlockDestroy on application "mysql" not valid`)
+
+	err = s.State.AddBlock(state.BlockArgs{
+		Type:   state.ChangeBlock,
+		Entity: names.NewApplicationTag("missing"),
+	})
+	c.Assert(err, gc.ErrorMatches, `application "missing" is not alive`)
+}
+
 func (s *blockSuite) createTestModel(c *gc.C) (*state.Model, *state.State) {
 	uuid, err := utils.NewUUID()
 	c.Assert(err, jc.ErrorIsNil)
@@ -31,6 +31,8 @@ const (
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
 	cleanupModelsForDyingController      cleanupKind = "models"
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
+	cleanupSecretsForRemovedApplication  cleanupKind = "applicationSecrets"
+	cleanupSecretGrantsForRelation       cleanupKind = "relationSecretGrants"
 )
 
 // cleanupDoc represents a potentially large set of documents that should be
@@ -108,6 +110,10 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupModelsForDyingController()
 		case cleanupMachinesForDyingModel:
 			err = st.cleanupMachinesForDyingModel()
+		case cleanupSecretsForRemovedApplication:
+			err = st.cleanupSecretsForRemovedApplication(doc.Prefix)
+		case cleanupSecretGrantsForRelation:
+			err = st.removeSecretGrants(doc.Prefix, "")
 		default:
 			handler, ok := cleanupHandlers[doc.Kind]
 			if !ok {
@@ -342,7 +348,19 @@ func (st *State) cleanupRemovedUnit(unitId string) error {
 			return err
 		}
 	}
-	return nil
+	if err := st.removeUnitState(unitId); err != nil {
+		return errors.Trace(err)
+	}
+	return st.removeOwnedSecrets(names.NewUnitTag(unitId))
+}
+
+// cleanupSecretsForRemovedApplication removes the secrets owned by the
+// application, and any access to secrets it was granted.
+func (st *State) cleanupSecretsForRemovedApplication(applicationName string) error {
+	if err := st.removeOwnedSecrets(names.NewApplicationTag(applicationName)); err != nil {
+		return errors.Trace(err)
+	}
+	return st.removeSecretGrants("", applicationName)
 }
 
 // cleanupDyingMachine marks resources owned by the machine as dying, to ensure
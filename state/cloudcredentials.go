@@ -0,0 +1,212 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/cloud"
+)
+
+// CloudCredential holds a cloud credential stored in the controller.
+type CloudCredential struct {
+	// Owner is the user that owns the credential.
+	Owner names.UserTag
+
+	// Cloud is the name of the cloud the credential is for.
+	Cloud string
+
+	// Name is the name of the credential, unique for the owner
+	// and cloud.
+	Name string
+
+	// AuthType is the authentication type of the credential.
+	AuthType cloud.AuthType
+
+	// Attributes holds the credential attributes, including any
+	// secrets.
+	Attributes map[string]string
+
+	// Revision is incremented each time the credential is updated.
+	Revision int
+}
+
+// Credential returns the credential as a cloud.Credential.
+func (c CloudCredential) Credential() cloud.Credential {
+	return cloud.NewCredential(c.AuthType, c.Attributes)
+}
+
+// cloudCredentialDoc records a cloud credential stored in the controller.
+type cloudCredentialDoc struct {
+	DocID      string            `bson:"_id"`
+	Owner      string            `bson:"owner"`
+	Cloud      string            `bson:"cloud"`
+	Name       string            `bson:"name"`
+	AuthType   string            `bson:"auth-type"`
+	Attributes map[string]string `bson:"attributes,omitempty"`
+	Revision   int               `bson:"revision"`
+}
+
+func (doc cloudCredentialDoc) credential() CloudCredential {
+	return CloudCredential{
+		Owner:      names.NewUserTag(doc.Owner),
+		Cloud:      doc.Cloud,
+		Name:       doc.Name,
+		AuthType:   cloud.AuthType(doc.AuthType),
+		Attributes: doc.Attributes,
+		Revision:   doc.Revision,
+	}
+}
+
+// cloudCredentialDocID returns the id of the document holding the
+// named credential for the given owner and cloud.
+func cloudCredentialDocID(owner names.UserTag, cloudName, credentialName string) string {
+	return fmt.Sprintf("%s#%s#%s", owner.Canonical(), cloudName, credentialName)
+}
+
+func validateCloudCredentialKey(owner names.UserTag, cloudName, credentialName string) error {
+	if owner.Id() == "" {
+		return errors.NotValidf("empty owner")
+	}
+	if cloudName == "" {
+		return errors.NotValidf("empty cloud name")
+	}
+	if credentialName == "" {
+		return errors.NotValidf("empty credential name")
+	}
+	return nil
+}
+
+// UpdateCloudCredential adds the named credential for the given owner
+// and cloud, or replaces it if it already exists, incrementing its
+// revision. Models referencing the credential pick up the change
+// through their config watchers.
+func (st *State) UpdateCloudCredential(owner names.UserTag, cloudName, credentialName string, credential cloud.Credential) error {
+	if err := validateCloudCredentialKey(owner, cloudName, credentialName); err != nil {
+		return errors.Trace(err)
+	}
+	if credential.AuthType() == "" {
+		return errors.NotValidf("empty auth-type")
+	}
+	id := cloudCredentialDocID(owner, cloudName, credentialName)
+	buildTxn := func(int) ([]txn.Op, error) {
+		existing, err := st.CloudCredential(owner, cloudName, credentialName)
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      cloudCredentialsC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &cloudCredentialDoc{
+					DocID:      id,
+					Owner:      owner.Canonical(),
+					Cloud:      cloudName,
+					Name:       credentialName,
+					AuthType:   string(credential.AuthType()),
+					Attributes: credential.Attributes(),
+					Revision:   1,
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      cloudCredentialsC,
+			Id:     id,
+			Assert: bson.D{{"revision", existing.Revision}},
+			Update: bson.D{{"$set", bson.D{
+				{"auth-type", string(credential.AuthType())},
+				{"attributes", credential.Attributes()},
+				{"revision", existing.Revision + 1},
+			}}},
+		}}, nil
+	}
+	err := st.run(buildTxn)
+	return errors.Annotatef(err, "cannot update credential %q", credentialName)
+}
+
+// CloudCredential returns the named credential for the given owner and
+// cloud.
+func (st *State) CloudCredential(owner names.UserTag, cloudName, credentialName string) (CloudCredential, error) {
+	coll, closer := st.getCollection(cloudCredentialsC)
+	defer closer()
+
+	var doc cloudCredentialDoc
+	err := coll.FindId(cloudCredentialDocID(owner, cloudName, credentialName)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return CloudCredential{}, errors.NotFoundf(
+			"credential %q for user %q on cloud %q", credentialName, owner.Canonical(), cloudName,
+		)
+	} else if err != nil {
+		return CloudCredential{}, errors.Annotatef(err, "cannot get credential %q", credentialName)
+	}
+	return doc.credential(), nil
+}
+
+// CloudCredentials returns the credentials for the given owner and
+// cloud, keyed on credential name.
+func (st *State) CloudCredentials(owner names.UserTag, cloudName string) (map[string]CloudCredential, error) {
+	coll, closer := st.getCollection(cloudCredentialsC)
+	defer closer()
+
+	var docs []cloudCredentialDoc
+	err := coll.Find(bson.D{
+		{"owner", owner.Canonical()},
+		{"cloud", cloudName},
+	}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get credentials for user %q on cloud %q", owner.Canonical(), cloudName)
+	}
+	credentials := make(map[string]CloudCredential)
+	for _, doc := range docs {
+		credentials[doc.Name] = doc.credential()
+	}
+	return credentials, nil
+}
+
+// WatchCloudCredential returns a NotifyWatcher which notifies when the
+// named credential for the given owner and cloud changes.
+func (st *State) WatchCloudCredential(owner names.UserTag, cloudName, credentialName string) NotifyWatcher {
+	return newDocWatcher(st, []docKey{{
+		cloudCredentialsC,
+		cloudCredentialDocID(owner, cloudName, credentialName),
+	}})
+}
+
+// assertCloudCredentialExistsOp returns an operation asserting that
+// the named credential exists.
+func assertCloudCredentialExistsOp(owner names.UserTag, cloudName, credentialName string) txn.Op {
+	return txn.Op{
+		C:      cloudCredentialsC,
+		Id:     cloudCredentialDocID(owner, cloudName, credentialName),
+		Assert: txn.DocExists,
+	}
+}
+
+// modelCloudCredential returns the credential referenced by the model,
+// and whether it references one at all.
+func (st *State) modelCloudCredential() (CloudCredential, bool, error) {
+	model, err := st.Model()
+	if err != nil {
+		return CloudCredential{}, false, errors.Trace(err)
+	}
+	credentialName, ok := model.CloudCredential()
+	if !ok {
+		return CloudCredential{}, false, nil
+	}
+	cloudName, _, err := st.modelCloudName()
+	if err != nil {
+		return CloudCredential{}, false, errors.Trace(err)
+	}
+	credential, err := st.CloudCredential(model.cloudCredentialOwner(), cloudName, credentialName)
+	if err != nil {
+		return CloudCredential{}, false, errors.Trace(err)
+	}
+	return credential, true, nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type CloudCredentialsSuite struct {
+	ConnSuite
+	owner     names.UserTag
+	cloudName string
+}
+
+var _ = gc.Suite(&CloudCredentialsSuite{})
+
+func (s *CloudCredentialsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.owner = s.Owner
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	s.cloudName = info.CloudName
+}
+
+func (s *CloudCredentialsSuite) updateCredential(c *gc.C, secret string) {
+	err := s.State.UpdateCloudCredential(s.owner, s.cloudName, "default", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": secret},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredential(c *gc.C) {
+	s.updateCredential(c, "pork")
+	credential, err := s.State.CloudCredential(s.owner, s.cloudName, "default")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, jc.DeepEquals, state.CloudCredential{
+		Owner:      s.owner,
+		Cloud:      s.cloudName,
+		Name:       "default",
+		AuthType:   cloud.UserPassAuthType,
+		Attributes: map[string]string{"secret": "pork"},
+		Revision:   1,
+	})
+
+	s.updateCredential(c, "beef")
+	credential, err = s.State.CloudCredential(s.owner, s.cloudName, "default")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential.Attributes, jc.DeepEquals, map[string]string{"secret": "beef"})
+	c.Assert(credential.Revision, gc.Equals, 2)
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialInvalid(c *gc.C) {
+	err := s.State.UpdateCloudCredential(s.owner, s.cloudName, "", cloud.NewCredential(
+		cloud.UserPassAuthType, nil,
+	))
+	c.Assert(err, gc.ErrorMatches, "empty credential name not valid")
+	err = s.State.UpdateCloudCredential(s.owner, s.cloudName, "default", cloud.NewCredential("", nil))
+	c.Assert(err, gc.ErrorMatches, "empty auth-type not valid")
+}
+
+func (s *CloudCredentialsSuite) TestCloudCredentialNotFound(c *gc.C) {
+	_, err := s.State.CloudCredential(s.owner, s.cloudName, "missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `credential "missing" for user ".*" on cloud ".*" not found`)
+}
+
+func (s *CloudCredentialsSuite) TestCloudCredentials(c *gc.C) {
+	s.updateCredential(c, "pork")
+	err := s.State.UpdateCloudCredential(names.NewUserTag("bob"), s.cloudName, "other", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "lamb"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+
+	credentials, err := s.State.CloudCredentials(s.owner, s.cloudName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credentials, gc.HasLen, 1)
+	c.Assert(credentials["default"].Attributes, jc.DeepEquals, map[string]string{"secret": "pork"})
+}
+
+func (s *CloudCredentialsSuite) TestWatchCloudCredential(c *gc.C) {
+	w := s.State.WatchCloudCredential(s.owner, s.cloudName, "default")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.updateCredential(c, "pork")
+	wc.AssertOneChange()
+	s.updateCredential(c, "beef")
+	wc.AssertOneChange()
+}
+
+func (s *CloudCredentialsSuite) newModel(c *gc.C, credentialName string) (*state.State, error) {
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name":            "testing",
+		"uuid":            uuid.String(),
+		"controller-uuid": s.State.ModelUUID(),
+	})
+	_, st, err := s.State.NewModel(state.ModelArgs{
+		Config:          cfg,
+		Owner:           s.owner,
+		CloudCredential: credentialName,
+	})
+	return st, err
+}
+
+func (s *CloudCredentialsSuite) TestNewModelMissingCloudCredential(c *gc.C) {
+	_, err := s.newModel(c, "missing")
+	c.Assert(err, gc.ErrorMatches, `cannot create model: credential "missing" .* not found`)
+}
+
+func (s *CloudCredentialsSuite) TestModelConfigUsesCloudCredential(c *gc.C) {
+	s.updateCredential(c, "pork")
+	st, err := s.newModel(c, "default")
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	credentialName, ok := model.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(credentialName, gc.Equals, "default")
+
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["secret"], gc.Equals, "pork")
+
+	w := st.WatchForModelConfigChanges()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, st, w)
+	wc.AssertOneChange()
+
+	// Rotating the credential changes the model config.
+	s.updateCredential(c, "beef")
+	wc.AssertOneChange()
+	cfg, err = st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["secret"], gc.Equals, "beef")
+
+	// Updating the model config doesn't copy the credential into
+	// the model settings, and the credential still wins.
+	err = st.UpdateModelConfig(map[string]interface{}{"secret": "lamb"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["secret"], gc.Equals, "beef")
+}
+
+func (s *CloudCredentialsSuite) TestNewModelDoesNotStoreCredentialAttributes(c *gc.C) {
+	s.updateCredential(c, "pork")
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	// The config of a new model includes the credential attributes,
+	// as it must to be validated by the provider.
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name":            "testing",
+		"uuid":            uuid.String(),
+		"controller-uuid": s.State.ModelUUID(),
+		"secret":          "pork",
+	})
+	_, st, err := s.State.NewModel(state.ModelArgs{
+		Config:          cfg,
+		Owner:           s.owner,
+		CloudCredential: "default",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	settings, closer := state.GetRawCollection(st, "settings")
+	defer closer()
+	var doc struct {
+		Settings map[string]interface{} `bson:"settings"`
+	}
+	err = settings.FindId(st.ModelUUID() + ":e").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.Settings["name"], gc.Equals, "testing")
+	_, ok := doc.Settings["secret"]
+	c.Assert(ok, jc.IsFalse)
+
+	modelCfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelCfg.AllAttrs()["secret"], gc.Equals, "pork")
+}
+
+func (s *CloudCredentialsSuite) TestExportIncludesCloudCredential(c *gc.C) {
+	s.updateCredential(c, "pork")
+	st, err := s.newModel(c, "default")
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	// The credential isn't migrated, so its attributes are exported
+	// with the model config.
+	model, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Config()["secret"], gc.Equals, "pork")
+}
@@ -0,0 +1,262 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/cloud"
+)
+
+// cloudDoc records a cloud that models hosted by the controller may be
+// deployed to, in addition to the cloud the controller runs in.
+type cloudDoc struct {
+	DocID           string                 `bson:"_id"`
+	Name            string                 `bson:"name"`
+	Type            string                 `bson:"type"`
+	AuthTypes       []string               `bson:"auth-types,omitempty"`
+	Endpoint        string                 `bson:"endpoint,omitempty"`
+	StorageEndpoint string                 `bson:"storage-endpoint,omitempty"`
+	Regions         []cloudRegionSubdoc    `bson:"regions,omitempty"`
+	Config          map[string]interface{} `bson:"config,omitempty"`
+}
+
+// cloudRegionSubdoc records a region of a cloud. Regions are stored in
+// a slice rather than a map, because the first region is the default.
+type cloudRegionSubdoc struct {
+	Name            string `bson:"name"`
+	Endpoint        string `bson:"endpoint,omitempty"`
+	StorageEndpoint string `bson:"storage-endpoint,omitempty"`
+}
+
+func newCloudDoc(name string, in cloud.Cloud) *cloudDoc {
+	authTypes := make([]string, len(in.AuthTypes))
+	for i, authType := range in.AuthTypes {
+		authTypes[i] = string(authType)
+	}
+	regions := make([]cloudRegionSubdoc, len(in.Regions))
+	for i, region := range in.Regions {
+		regions[i] = cloudRegionSubdoc{
+			Name:            region.Name,
+			Endpoint:        region.Endpoint,
+			StorageEndpoint: region.StorageEndpoint,
+		}
+	}
+	return &cloudDoc{
+		DocID:           name,
+		Name:            name,
+		Type:            in.Type,
+		AuthTypes:       authTypes,
+		Endpoint:        in.Endpoint,
+		StorageEndpoint: in.StorageEndpoint,
+		Regions:         regions,
+		Config:          in.Config,
+	}
+}
+
+func (doc cloudDoc) toCloud() cloud.Cloud {
+	var authTypes []cloud.AuthType
+	for _, authType := range doc.AuthTypes {
+		authTypes = append(authTypes, cloud.AuthType(authType))
+	}
+	var regions []cloud.Region
+	for _, region := range doc.Regions {
+		regions = append(regions, cloud.Region{
+			Name:            region.Name,
+			Endpoint:        region.Endpoint,
+			StorageEndpoint: region.StorageEndpoint,
+		})
+	}
+	return cloud.Cloud{
+		Type:            doc.Type,
+		AuthTypes:       authTypes,
+		Endpoint:        doc.Endpoint,
+		StorageEndpoint: doc.StorageEndpoint,
+		Regions:         regions,
+		Config:          doc.Config,
+	}
+}
+
+// AddCloud registers the named cloud with the controller, so that
+// models may be added to it. The cloud the controller runs in may
+// also be registered, to make its regions available to new models.
+func (st *State) AddCloud(name string, in cloud.Cloud) error {
+	if name == "" {
+		return errors.NotValidf("empty cloud name")
+	}
+	if in.Type == "" {
+		return errors.NotValidf("empty cloud type")
+	}
+	if err := checkCloudConfig(in.Config); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      cloudsC,
+		Id:     name,
+		Assert: txn.DocMissing,
+		Insert: newCloudDoc(name, in),
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.AlreadyExistsf("cloud %q", name)
+	}
+	return errors.Annotatef(err, "cannot add cloud %q", name)
+}
+
+// Cloud returns the named cloud registered with the controller.
+func (st *State) Cloud(name string) (cloud.Cloud, error) {
+	coll, closer := st.getCollection(cloudsC)
+	defer closer()
+
+	var doc cloudDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+	} else if err != nil {
+		return cloud.Cloud{}, errors.Annotatef(err, "cannot get cloud %q", name)
+	}
+	return doc.toCloud(), nil
+}
+
+// Clouds returns the clouds registered with the controller, keyed on
+// cloud name.
+func (st *State) Clouds() (map[string]cloud.Cloud, error) {
+	coll, closer := st.getCollection(cloudsC)
+	defer closer()
+
+	var docs []cloudDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get clouds")
+	}
+	clouds := make(map[string]cloud.Cloud)
+	for _, doc := range docs {
+		clouds[doc.Name] = doc.toCloud()
+	}
+	return clouds, nil
+}
+
+// validateCloudRegion returns an error if the cloud has regions, and
+// the named region is not one of them.
+func validateCloudRegion(c cloud.Cloud, regionName string) error {
+	if len(c.Regions) == 0 {
+		return nil
+	}
+	for _, region := range c.Regions {
+		if region.Name == regionName {
+			return nil
+		}
+	}
+	return errors.NotValidf("region %q", regionName)
+}
+
+// assertCloudExistsOp returns an operation asserting that the named
+// cloud is registered with the controller.
+func assertCloudExistsOp(name string) txn.Op {
+	return txn.Op{
+		C:      cloudsC,
+		Id:     name,
+		Assert: txn.DocExists,
+	}
+}
+
+// modelCloudName returns the name of the cloud the model is deployed
+// to, and whether that is the cloud the controller runs in.
+func (st *State) modelCloudName() (string, bool, error) {
+	model, err := st.Model()
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+	cloudName := model.Cloud()
+	if cloudName == "" {
+		// Models created before clouds were recorded
+		// are always in the controller cloud.
+		cloudName = info.CloudName
+	}
+	return cloudName, cloudName == info.CloudName, nil
+}
+
+// CloudSpec describes the cloud region a model is deployed to, along
+// with the credential used to access it.
+type CloudSpec struct {
+	// Type is the provider type of the cloud.
+	Type string
+
+	// Name is the name of the cloud.
+	Name string
+
+	// Region is the name of the cloud region, if the cloud has
+	// regions.
+	Region string
+
+	// Endpoint is the API endpoint for the cloud region.
+	Endpoint string
+
+	// StorageEndpoint is the storage API endpoint for the cloud
+	// region, if it has one.
+	StorageEndpoint string
+
+	// Credential is the controller-stored credential the model
+	// uses, or nil if the model's credential is held in its config.
+	Credential *cloud.Credential
+}
+
+// ModelCloudSpec returns the cloud region the model is deployed to,
+// and the controller-stored credential it uses, if any.
+func (st *State) ModelCloudSpec() (CloudSpec, error) {
+	model, err := st.Model()
+	if err != nil {
+		return CloudSpec{}, errors.Trace(err)
+	}
+	cloudName, isControllerCloud, err := st.modelCloudName()
+	if err != nil {
+		return CloudSpec{}, errors.Trace(err)
+	}
+	spec := CloudSpec{
+		Name:   cloudName,
+		Region: model.CloudRegion(),
+	}
+	c, err := st.Cloud(cloudName)
+	switch {
+	case err == nil:
+		spec.Type = c.Type
+		spec.Endpoint = c.Endpoint
+		spec.StorageEndpoint = c.StorageEndpoint
+		for _, region := range c.Regions {
+			if region.Name != spec.Region {
+				continue
+			}
+			if region.Endpoint != "" {
+				spec.Endpoint = region.Endpoint
+			}
+			if region.StorageEndpoint != "" {
+				spec.StorageEndpoint = region.StorageEndpoint
+			}
+		}
+	case errors.IsNotFound(err) && isControllerCloud:
+		// The controller's own cloud need not be registered;
+		// its provider type is recorded in the model config.
+		cfg, err := st.ModelConfig()
+		if err != nil {
+			return CloudSpec{}, errors.Trace(err)
+		}
+		spec.Type = cfg.Type()
+	default:
+		return CloudSpec{}, errors.Trace(err)
+	}
+	credential, ok, err := st.modelCloudCredential()
+	if err != nil {
+		return CloudSpec{}, errors.Trace(err)
+	}
+	if ok {
+		cred := credential.Credential()
+		spec.Credential = &cred
+	}
+	return spec, nil
+}
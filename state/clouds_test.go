@@ -0,0 +1,216 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type CloudsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CloudsSuite{})
+
+var otherCloud = cloud.Cloud{
+	Type:      "dummy",
+	AuthTypes: []cloud.AuthType{cloud.UserPassAuthType},
+	Endpoint:  "https://other.example.com",
+	Regions: []cloud.Region{{
+		Name:     "north",
+		Endpoint: "https://north.other.example.com",
+	}, {
+		Name: "south",
+	}},
+	Config: map[string]interface{}{
+		"apt-mirror": "http://mirror.other.example.com",
+	},
+}
+
+func (s *CloudsSuite) TestAddCloud(c *gc.C) {
+	err := s.State.AddCloud("other", otherCloud)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stored, err := s.State.Cloud("other")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, otherCloud)
+
+	clouds, err := s.State.Clouds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clouds, jc.DeepEquals, map[string]cloud.Cloud{"other": otherCloud})
+}
+
+func (s *CloudsSuite) TestAddCloudAlreadyExists(c *gc.C) {
+	err := s.State.AddCloud("other", otherCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddCloud("other", otherCloud)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `cannot add cloud "other": cloud "other" already exists`)
+}
+
+func (s *CloudsSuite) TestAddCloudInvalid(c *gc.C) {
+	err := s.State.AddCloud("", otherCloud)
+	c.Assert(err, gc.ErrorMatches, "empty cloud name not valid")
+	err = s.State.AddCloud("other", cloud.Cloud{})
+	c.Assert(err, gc.ErrorMatches, "empty cloud type not valid")
+	err = s.State.AddCloud("other", cloud.Cloud{
+		Type:   "dummy",
+		Config: map[string]interface{}{"agent-version": "1.2.3"},
+	})
+	c.Assert(err, gc.ErrorMatches, "cloud config cannot contain agent-version")
+}
+
+func (s *CloudsSuite) TestCloudNotFound(c *gc.C) {
+	_, err := s.State.Cloud("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cloud "missing" not found`)
+}
+
+func (s *CloudsSuite) newModel(c *gc.C, cloudName, cloudRegion string) (*state.State, error) {
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name":            "testing",
+		"uuid":            uuid.String(),
+		"controller-uuid": s.State.ModelUUID(),
+	})
+	_, st, err := s.State.NewModel(state.ModelArgs{
+		CloudName:   cloudName,
+		CloudRegion: cloudRegion,
+		Config:      cfg,
+		Owner:       s.Owner,
+	})
+	return st, err
+}
+
+func (s *CloudsSuite) TestNewModelInOtherCloud(c *gc.C) {
+	err := s.State.AddCloud("other", otherCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := s.newModel(c, "other", "south")
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Cloud(), gc.Equals, "other")
+	c.Assert(model.CloudRegion(), gc.Equals, "south")
+
+	// The model picks up the config of its own cloud.
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AptMirror(), gc.Equals, "http://mirror.other.example.com")
+	cfg, err = s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AptMirror(), gc.Equals, "")
+}
+
+func (s *CloudsSuite) TestNewModelDefaultsToControllerCloud(c *gc.C) {
+	st, err := s.newModel(c, "", "")
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Cloud(), gc.Equals, info.CloudName)
+}
+
+func (s *CloudsSuite) TestNewModelUnknownCloud(c *gc.C) {
+	_, err := s.newModel(c, "missing", "")
+	c.Assert(err, gc.ErrorMatches, `cannot create model: cloud "missing" not found`)
+}
+
+func (s *CloudsSuite) TestNewModelUnknownRegion(c *gc.C) {
+	err := s.State.AddCloud("other", otherCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.newModel(c, "other", "east")
+	c.Assert(err, gc.ErrorMatches, `cannot create model: region "east" not valid`)
+}
+
+func (s *CloudsSuite) TestNewModelCredentialForModelCloud(c *gc.C) {
+	err := s.State.AddCloud("other", otherCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateCloudCredential(s.Owner, "other", "default", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "beef"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name":            "testing",
+		"uuid":            uuid.String(),
+		"controller-uuid": s.State.ModelUUID(),
+	})
+	_, st, err := s.State.NewModel(state.ModelArgs{
+		CloudName:       "other",
+		CloudRegion:     "north",
+		CloudCredential: "default",
+		Config:          cfg,
+		Owner:           s.Owner,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	cfg, err = st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["secret"], gc.Equals, "beef")
+}
+
+func (s *CloudsSuite) TestModelCloudSpecControllerCloud(c *gc.C) {
+	spec, err := s.State.ModelCloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, state.CloudSpec{
+		Type:   "dummy",
+		Name:   info.CloudName,
+		Region: "some-region",
+	})
+}
+
+func (s *CloudsSuite) TestModelCloudSpecOtherCloud(c *gc.C) {
+	err := s.State.AddCloud("other", otherCloud)
+	c.Assert(err, jc.ErrorIsNil)
+	credential := cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "beef"},
+	)
+	err = s.State.UpdateCloudCredential(s.Owner, "other", "default", credential)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name":            "testing",
+		"uuid":            uuid.String(),
+		"controller-uuid": s.State.ModelUUID(),
+	})
+	_, st, err := s.State.NewModel(state.ModelArgs{
+		CloudName:       "other",
+		CloudRegion:     "north",
+		CloudCredential: "default",
+		Config:          cfg,
+		Owner:           s.Owner,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	spec, err := st.ModelCloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, state.CloudSpec{
+		Type:       "dummy",
+		Name:       "other",
+		Region:     "north",
+		Endpoint:   "https://north.other.example.com",
+		Credential: &credential,
+	})
+}
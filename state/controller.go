@@ -17,6 +17,12 @@ const (
 	defaultModelSettingsGlobalKey = "defaultModelSettings"
 )
 
+// regionSettingsGlobalKey returns the key for the default settings
+// shared across models in the given cloud region.
+func regionSettingsGlobalKey(cloudName, regionName string) string {
+	return defaultModelSettingsGlobalKey + "#" + cloudName + "#" + regionName
+}
+
 func controllerOnlyAttribute(attr string) bool {
 	for _, a := range config.ControllerOnlyConfigAttributes {
 		if attr == a {
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/set"
@@ -271,6 +273,160 @@ func validateEndpointBindingsForCharm(st *State, bindings map[string]string, cha
 	return nil
 }
 
+// validateEndpointBindingsForUnits verifies that each machine hosting one of
+// the given units has at least one address in every space referred to by
+// bindings. Units not yet assigned to a machine are skipped, as placement
+// takes the bindings into account when they are assigned. It returns the
+// operations asserting that the unit assignments and machine addresses the
+// check relied on are unchanged, along with the address found for each space
+// on the machine of every assigned unit, keyed by unit name.
+func validateEndpointBindingsForUnits(st *State, bindings map[string]string, units []*Unit) ([]txn.Op, map[string]map[string]*Address, error) {
+	wantSpaces := set.NewStrings()
+	for _, space := range bindings {
+		if space != "" {
+			wantSpaces.Add(space)
+		}
+	}
+	if wantSpaces.IsEmpty() {
+		return nil, nil, nil
+	}
+
+	var ops []txn.Op
+	machineAddresses := make(map[string]map[string]*Address)
+	unitAddresses := make(map[string]map[string]*Address)
+	for _, unit := range units {
+		machineID, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			machineID = ""
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		// A subordinate is on its principal's machine.
+		assignedUnit := unit.doc.Name
+		if !unit.IsPrincipal() {
+			assignedUnit = unit.doc.Principal
+		}
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     st.docID(assignedUnit),
+			Assert: bson.D{{"machineid", machineID}},
+		})
+		if machineID == "" {
+			continue
+		}
+		spaceAddresses, seen := machineAddresses[machineID]
+		if !seen {
+			spaceAddresses, err = machineSpaceAddresses(st, machineID)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			machineAddresses[machineID] = spaceAddresses
+		}
+		missing := wantSpaces.Difference(spaceNames(spaceAddresses))
+		if !missing.IsEmpty() {
+			return nil, nil, errors.Errorf(
+				"machine %q hosting unit %q has no addresses in space(s) %s",
+				machineID, unit.Name(), strings.Join(missing.SortedValues(), ", "),
+			)
+		}
+		unitAddresses[unit.doc.Name] = spaceAddresses
+		if !seen {
+			for _, space := range wantSpaces.SortedValues() {
+				addr := spaceAddresses[space]
+				ops = append(ops, txn.Op{
+					C:      ipAddressesC,
+					Id:     addr.DocID(),
+					Assert: bson.D{{"subnet-cidr", addr.SubnetCIDR()}},
+				})
+			}
+		}
+	}
+	return ops, unitAddresses, nil
+}
+
+// machineSpaceAddresses returns one address of the given machine for each
+// space the machine has an address in, keyed by space name.
+func machineSpaceAddresses(st *State, machineID string) (map[string]*Address, error) {
+	machine, err := st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addresses, err := machine.AllAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaceAddresses := make(map[string]*Address)
+	for _, addr := range addresses {
+		subnet, err := addr.Subnet()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		space := subnet.SpaceName()
+		if _, found := spaceAddresses[space]; space != "" && !found {
+			spaceAddresses[space] = addr
+		}
+	}
+	return spaceAddresses, nil
+}
+
+// spaceNames returns the space names used as keys in spaceAddresses.
+func spaceNames(spaceAddresses map[string]*Address) set.Strings {
+	names := set.NewStrings()
+	for space := range spaceAddresses {
+		names.Add(space)
+	}
+	return names
+}
+
+// relationAddressOps returns the operations updating the private-address
+// setting of each given unit in every relation of the application using an
+// endpoint rebound to a space, so the units on the other side of the
+// relation see the address in the new space via relation-changed. Units
+// that have not yet entered a relation scope are skipped, as they set
+// their own settings when they do.
+func relationAddressOps(
+	st *State, relations []*Relation, applicationName string,
+	bindings map[string]string, units []*Unit, unitAddresses map[string]map[string]*Address,
+) ([]txn.Op, error) {
+	var ops []txn.Op
+	for _, relation := range relations {
+		ep, err := relation.Endpoint(applicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		space := bindings[ep.Name]
+		if space == "" {
+			continue
+		}
+		for _, unit := range units {
+			spaceAddresses := unitAddresses[unit.doc.Name]
+			if spaceAddresses == nil {
+				continue
+			}
+			address := spaceAddresses[space].Value()
+			ru, err := relation.Unit(unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			settings, err := readSettings(st, settingsC, ru.key())
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if current, _ := settings.Get("private-address"); current == address {
+				continue
+			}
+			op := settings.assertUnchangedOp()
+			op.Update = setUnsetUpdateSettings(bson.M{"private-address": address}, nil)
+			ops = append(ops, op)
+		}
+	}
+	return ops, nil
+}
+
 // DefaultEndpointBindingsForCharm populates a bindings map containing each
 // endpoint of the given charm metadata (relation name or extra-binding name)
 // bound to an empty space.
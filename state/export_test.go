@@ -506,3 +506,7 @@ func LeadershipLeases(st *State) (map[string]lease.Info, error) {
 func DeleteCharm(st *State, curl *charm.URL) error {
 	return st.deleteCharm(curl)
 }
+
+func ResetSecretsKey(st *State) {
+	st.secretsKey = nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ExternalUser represents a user authenticated by an external identity
+// provider. External users are not added to the controller like local
+// users; they are recorded when they first log in, and their identity
+// details are refreshed each time they log in with the provider.
+type ExternalUser struct {
+	st  *State
+	doc externalUserDoc
+}
+
+// externalUserDoc is updated by the apiserver whenever an external
+// user logs in. Like the last login times of local users, it is not
+// updated using mgo.txn, and so must never appear in transaction
+// asserts.
+type externalUserDoc struct {
+	DocID       string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	DisplayName string    `bson:"display-name"`
+	Groups      []string  `bson:"groups"`
+	DateCreated time.Time `bson:"date-created"`
+	LastLogin   time.Time `bson:"last-login,omitempty"`
+}
+
+func externalUserDocID(user names.UserTag) string {
+	return strings.ToLower(user.Canonical())
+}
+
+// UpdateExternalUser records the display name and groups of an external
+// user, as reported by the identity provider the user logged in with.
+// The user is recorded if they have not been seen before.
+func (st *State) UpdateExternalUser(user names.UserTag, displayName string, groups []string) error {
+	if user.IsLocal() {
+		return errors.NotValidf("local user %q as external user", user.Canonical())
+	}
+	externalUsers, closer := st.getCollection(externalUsersC)
+	defer closer()
+
+	_, err := externalUsers.Writeable().UpsertId(externalUserDocID(user), bson.D{
+		{"$set", bson.D{
+			{"name", user.Canonical()},
+			{"display-name", displayName},
+			{"groups", groups},
+		}},
+		{"$setOnInsert", bson.D{
+			{"date-created", nowToTheSecond()},
+		}},
+	})
+	return errors.Annotatef(err, "cannot update external user %q", user.Canonical())
+}
+
+// ExternalUser returns the external user with the given tag.
+func (st *State) ExternalUser(user names.UserTag) (*ExternalUser, error) {
+	externalUsers, closer := st.getCollection(externalUsersC)
+	defer closer()
+
+	var doc externalUserDoc
+	err := externalUsers.FindId(externalUserDocID(user)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("external user %q", user.Canonical())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get external user %q", user.Canonical())
+	}
+	return newExternalUser(st, doc), nil
+}
+
+// AllExternalUsers returns all the external users that have logged in
+// to the controller, sorted by name.
+func (st *State) AllExternalUsers() ([]*ExternalUser, error) {
+	externalUsers, closer := st.getCollection(externalUsersC)
+	defer closer()
+
+	var docs []externalUserDoc
+	if err := externalUsers.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get external users")
+	}
+	users := make([]*ExternalUser, len(docs))
+	for i, doc := range docs {
+		users[i] = newExternalUser(st, doc)
+	}
+	return users, nil
+}
+
+// removeExternalUser deletes the record of an external user. The user
+// will be recorded again if they log in with the identity provider.
+func (st *State) removeExternalUser(user names.UserTag) error {
+	externalUsers, closer := st.getCollection(externalUsersC)
+	defer closer()
+
+	err := externalUsers.Writeable().RemoveId(externalUserDocID(user))
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("external user %q", user.Canonical())
+	}
+	return errors.Annotatef(err, "cannot remove external user %q", user.Canonical())
+}
+
+func newExternalUser(st *State, doc externalUserDoc) *ExternalUser {
+	// Times are inserted as UTC, but read out as local time. So we
+	// convert them back to UTC here.
+	doc.DateCreated = doc.DateCreated.UTC()
+	doc.LastLogin = doc.LastLogin.UTC()
+	return &ExternalUser{st: st, doc: doc}
+}
+
+// Tag implements Entity.Tag.
+func (u *ExternalUser) Tag() names.Tag {
+	return u.UserTag()
+}
+
+// UserTag returns the tag of the user.
+func (u *ExternalUser) UserTag() names.UserTag {
+	return names.NewUserTag(u.doc.Name)
+}
+
+// Name returns the canonical name of the user.
+func (u *ExternalUser) Name() string {
+	return u.doc.Name
+}
+
+// DisplayName returns the display name of the user, as last reported
+// by the identity provider.
+func (u *ExternalUser) DisplayName() string {
+	return u.doc.DisplayName
+}
+
+// Groups returns the groups of the user, as last reported by the
+// identity provider.
+func (u *ExternalUser) Groups() []string {
+	return u.doc.Groups
+}
+
+// DateCreated returns when the user first logged in.
+func (u *ExternalUser) DateCreated() time.Time {
+	return u.doc.DateCreated
+}
+
+// LastLogin returns when the user last connected through the API, or
+// a NeverLoggedInError if the user has never connected.
+func (u *ExternalUser) LastLogin() (time.Time, error) {
+	if u.doc.LastLogin.IsZero() {
+		return time.Time{}, errors.Trace(NeverLoggedInError(u.doc.Name))
+	}
+	return u.doc.LastLogin, nil
+}
+
+// UpdateLastLogin sets the last login time of the user to be now (to
+// the nearest second).
+func (u *ExternalUser) UpdateLastLogin() error {
+	externalUsers, closer := u.st.getCollection(externalUsersC)
+	defer closer()
+
+	now := nowToTheSecond()
+	err := externalUsers.Writeable().UpdateId(u.doc.DocID, bson.D{
+		{"$set", bson.D{{"last-login", now}}},
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot update last login of external user %q", u.doc.Name)
+	}
+	u.doc.LastLogin = now
+	return nil
+}
+
+// Refresh implements Authenticator.Refresh.
+func (u *ExternalUser) Refresh() error {
+	latest, err := u.st.ExternalUser(u.UserTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	u.doc = latest.doc
+	return nil
+}
+
+// SetPassword implements Authenticator.SetPassword. External users
+// do not have passwords.
+func (u *ExternalUser) SetPassword(string) error {
+	return errors.New("cannot set password on external user")
+}
+
+// PasswordValid implements Authenticator.PasswordValid. External users
+// do not have passwords.
+func (u *ExternalUser) PasswordValid(string) bool {
+	return false
+}
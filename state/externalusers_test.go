@@ -0,0 +1,181 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type ExternalUserSuite struct {
+	ConnSuite
+	user names.UserTag
+}
+
+var _ = gc.Suite(&ExternalUserSuite{})
+
+func (s *ExternalUserSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.user = names.NewUserTag("bob@example.com")
+}
+
+func (s *ExternalUserSuite) TestUpdateExternalUser(c *gc.C) {
+	now := state.NowToTheSecond()
+	err := s.State.UpdateExternalUser(s.user, "Bob Brown", []string{"devs"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	user, err := s.State.ExternalUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.UserTag(), gc.Equals, s.user)
+	c.Assert(user.Name(), gc.Equals, "bob@example.com")
+	c.Assert(user.DisplayName(), gc.Equals, "Bob Brown")
+	c.Assert(user.Groups(), jc.DeepEquals, []string{"devs"})
+	c.Assert(user.DateCreated().Before(now), jc.IsFalse)
+	_, err = user.LastLogin()
+	c.Assert(err, jc.Satisfies, state.IsNeverLoggedInError)
+
+	err = s.State.UpdateExternalUser(s.user, "Bob", []string{"ops"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.DisplayName(), gc.Equals, "Bob")
+	c.Assert(user.Groups(), jc.DeepEquals, []string{"ops"})
+}
+
+func (s *ExternalUserSuite) TestUpdateExternalUserLocal(c *gc.C) {
+	err := s.State.UpdateExternalUser(names.NewLocalUserTag("bob"), "Bob", nil)
+	c.Assert(err, gc.ErrorMatches, `local user "bob@local" as external user not valid`)
+}
+
+func (s *ExternalUserSuite) TestExternalUserNotFound(c *gc.C) {
+	_, err := s.State.ExternalUser(s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.FindEntity(s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ExternalUserSuite) TestUpdateLastLogin(c *gc.C) {
+	err := s.State.UpdateExternalUser(s.user, "Bob", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	entity, err := s.State.FindEntity(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	user, ok := entity.(*state.ExternalUser)
+	c.Assert(ok, jc.IsTrue)
+
+	now := state.NowToTheSecond()
+	err = user.UpdateLastLogin()
+	c.Assert(err, jc.ErrorIsNil)
+	user, err = s.State.ExternalUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	lastLogin, err := user.LastLogin()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lastLogin.Before(now), jc.IsFalse)
+	c.Assert(user.PasswordValid(""), jc.IsFalse)
+}
+
+func (s *ExternalUserSuite) TestAllExternalUsers(c *gc.C) {
+	for _, name := range []string{"sam@example.com", "bob@example.com"} {
+		err := s.State.UpdateExternalUser(names.NewUserTag(name), "", nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	users, err := s.State.AllExternalUsers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, gc.HasLen, 2)
+	c.Assert(users[0].Name(), gc.Equals, "bob@example.com")
+	c.Assert(users[1].Name(), gc.Equals, "sam@example.com")
+}
+
+func (s *ExternalUserSuite) setGroupAccess(c *gc.C, access string) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"identity-group-access": access,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ExternalUserSuite) TestSyncGroupModelUser(c *gc.C) {
+	s.setGroupAccess(c, "devs=read,ops=write")
+	err := s.State.UpdateExternalUser(s.user, "Bob", []string{"devs"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := s.State.SyncGroupModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelReadAccess)
+	c.Assert(modelUser.Group(), gc.Equals, "devs")
+	c.Assert(modelUser.DisplayName(), gc.Equals, "Bob")
+	c.Assert(modelUser.CreatedBy(), gc.Equals, s.Owner.Canonical())
+
+	// Joining a group with more access upgrades the model user.
+	err = s.State.UpdateExternalUser(s.user, "Bob", []string{"devs", "ops"})
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err = s.State.SyncGroupModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+	c.Assert(modelUser.Group(), gc.Equals, "ops")
+
+	// Leaving all the groups with access removes the model user.
+	err = s.State.UpdateExternalUser(s.user, "Bob", []string{"testers"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.SyncGroupModelUser(s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ModelUser(s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ExternalUserSuite) TestSyncGroupModelUserGroupAccessRevoked(c *gc.C) {
+	s.setGroupAccess(c, "devs=read")
+	err := s.State.UpdateExternalUser(s.user, "Bob", []string{"devs"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.SyncGroupModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setGroupAccess(c, "")
+	_, err = s.State.SyncGroupModelUser(s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ExternalUserSuite) TestSyncGroupModelUserExplicitAccess(c *gc.C) {
+	s.setGroupAccess(c, "ops=write")
+	err := s.State.UpdateExternalUser(s.user, "Bob", []string{"ops"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddModelUser(state.ModelUserSpec{
+		User:      s.user,
+		CreatedBy: s.Owner,
+		Access:    state.ModelReadAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Explicitly granted access is not managed through groups.
+	modelUser, err := s.State.SyncGroupModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelReadAccess)
+	c.Assert(modelUser.Group(), gc.Equals, "")
+}
+
+func (s *ExternalUserSuite) TestSyncGroupModelUserSetAccess(c *gc.C) {
+	s.setGroupAccess(c, "devs=read")
+	err := s.State.UpdateExternalUser(s.user, "Bob", []string{"devs"})
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err := s.State.SyncGroupModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Setting the access explicitly takes the model user out of
+	// group management.
+	err = modelUser.SetAccess(state.ModelAdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateExternalUser(s.user, "Bob", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err = s.State.SyncGroupModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+	c.Assert(modelUser.Group(), gc.Equals, "")
+}
+
+func (s *ExternalUserSuite) TestSyncGroupModelUserNoAccess(c *gc.C) {
+	_, err := s.State.SyncGroupModelUser(s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
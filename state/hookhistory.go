@@ -0,0 +1,192 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxHookOutputSize bounds the amount of output stored for a single
+// hook execution; only the tail of longer output is kept.
+const maxHookOutputSize = 4 * 1024
+
+// HookExecution records a single execution of a hook, action or
+// juju-run command on a unit.
+type HookExecution struct {
+	// Kind identifies what was executed: "hook", "action" or "juju-run".
+	Kind string
+
+	// Name holds the name of the hook or action, or the commands run.
+	Name string
+
+	// RelationId holds the id of the relation for relation hooks,
+	// and -1 otherwise.
+	RelationId int
+
+	// RemoteUnit holds the name of the remote unit for relation
+	// hooks, if any.
+	RemoteUnit string
+
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode holds the exit code of the executed process.
+	ExitCode int
+
+	// Output holds the tail of the combined stdout and stderr of the
+	// executed process.
+	Output string
+}
+
+type hookHistoryDoc struct {
+	ModelUUID  string `bson:"model-uuid"`
+	Unit       string `bson:"unit"`
+	Kind       string `bson:"kind"`
+	Name       string `bson:"name"`
+	RelationId int    `bson:"relation-id"`
+	RemoteUnit string `bson:"remote-unit,omitempty"`
+	Started    int64  `bson:"started"`
+	Finished   int64  `bson:"finished"`
+	ExitCode   int    `bson:"exit-code"`
+	Output     string `bson:"output"`
+}
+
+// AddHookExecution records the execution of a hook, action or
+// juju-run command in the unit's hook history. Output longer than
+// the history allows is truncated to its tail.
+func (u *Unit) AddHookExecution(e HookExecution) error {
+	output := e.Output
+	if len(output) > maxHookOutputSize {
+		output = output[len(output)-maxHookOutputSize:]
+	}
+	doc := &hookHistoryDoc{
+		Unit:       u.Name(),
+		Kind:       e.Kind,
+		Name:       e.Name,
+		RelationId: e.RelationId,
+		RemoteUnit: e.RemoteUnit,
+		Started:    e.Started.UnixNano(),
+		Finished:   e.Finished.UnixNano(),
+		ExitCode:   e.ExitCode,
+		Output:     output,
+	}
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+	if err := history.Writeable().Insert(doc); err != nil {
+		return errors.Annotatef(err, "cannot record hook execution for unit %q", u.Name())
+	}
+	return nil
+}
+
+// HookHistory returns at most size of the unit's most recent hook
+// executions, newest first. A size of zero returns them all.
+func (u *Unit) HookHistory(size int) ([]HookExecution, error) {
+	if size < 0 {
+		return nil, errors.NotValidf("negative size")
+	}
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+
+	query := history.Find(bson.D{{"unit", u.Name()}}).Sort("-started")
+	if size > 0 {
+		query = query.Limit(size)
+	}
+	var docs []hookHistoryDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u.Name())
+	}
+	results := make([]HookExecution, len(docs))
+	for i, doc := range docs {
+		results[i] = HookExecution{
+			Kind:       doc.Kind,
+			Name:       doc.Name,
+			RelationId: doc.RelationId,
+			RemoteUnit: doc.RemoteUnit,
+			Started:    time.Unix(0, doc.Started).UTC(),
+			Finished:   time.Unix(0, doc.Finished).UTC(),
+			ExitCode:   doc.ExitCode,
+			Output:     doc.Output,
+		}
+	}
+	return results, nil
+}
+
+func (u *Unit) eraseHookHistory() error {
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+	_, err := history.Writeable().RemoveAll(bson.D{{"unit", u.Name()}})
+	return errors.Trace(err)
+}
+
+// PruneHookHistory removes hook history entries until only those
+// newer than <maxHistoryTime> remain and also ensures that the
+// collection is smaller than <maxHistoryMB> after the deletion.
+func PruneHookHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	if maxHistoryMB < 0 {
+		return errors.NotValidf("non-positive maxHistoryMB")
+	}
+	if maxHistoryTime < 0 {
+		return errors.NotValidf("non-positive maxHistoryTime")
+	}
+	if maxHistoryMB == 0 && maxHistoryTime == 0 {
+		return errors.NotValidf("backlog size and time constraints are both 0")
+	}
+	history, closer := st.getRawCollection(hookHistoryC)
+	defer closer()
+	return errors.Trace(pruneHistory(history, "started", maxHistoryTime, maxHistoryMB))
+}
+
+// pruneHistory removes documents from the history collection whose
+// timeField is older than maxHistoryTime, and then the oldest
+// documents until the collection is no larger than maxHistoryMB.
+// A zero limit is not applied.
+func pruneHistory(history *mgo.Collection, timeField string, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	// TODO(perrito666): 2016-04-26 lp:1558657
+	if maxHistoryTime > 0 {
+		t := time.Now().Add(-maxHistoryTime)
+		_, err := history.RemoveAll(bson.D{
+			{timeField, bson.M{"$lt": t.UnixNano()}},
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if maxHistoryMB == 0 {
+		return nil
+	}
+	collMB, err := getCollectionMB(history)
+	if err != nil {
+		return errors.Annotatef(err, "retrieving %s collection size", history.Name)
+	}
+	if collMB <= maxHistoryMB {
+		return nil
+	}
+	count, err := history.Count()
+	if err == mgo.ErrNotFound || count <= 0 {
+		return nil
+	}
+	if err != nil {
+		return errors.Annotatef(err, "counting %s records", history.Name)
+	}
+	// We are making the assumption that entry sizes can be averaged for
+	// large numbers and we will get a reasonable approach on the size.
+	sizePerEntry := float64(collMB) / float64(count)
+	if sizePerEntry == 0 {
+		return errors.Errorf("unexpected result calculating %s entry size", history.Name)
+	}
+	deleteEntries := count - int(float64(collMB-maxHistoryMB)/sizePerEntry)
+	var result bson.M
+	err = history.Find(nil).Sort("-" + timeField).Skip(deleteEntries).One(&result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = history.RemoveAll(bson.D{
+		{timeField, bson.M{"$lt": result[timeField]}},
+	})
+	return errors.Trace(err)
+}
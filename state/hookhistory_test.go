@@ -0,0 +1,141 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type HookHistorySuite struct {
+	statetesting.StateSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *HookHistorySuite) addExecutions(c *gc.C, unit *state.Unit, count int, age time.Duration) {
+	started := time.Now().Add(-age)
+	for i := 0; i < count; i++ {
+		err := unit.AddHookExecution(state.HookExecution{
+			Kind:       "hook",
+			Name:       fmt.Sprintf("hook-%d", i),
+			RelationId: -1,
+			Started:    started.Add(time.Duration(i) * time.Second),
+			Finished:   started.Add(time.Duration(i)*time.Second + time.Millisecond),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *HookHistorySuite) TestAddHookExecution(c *gc.C) {
+	started := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	execution := state.HookExecution{
+		Kind:       "hook",
+		Name:       "db-relation-changed",
+		RelationId: 3,
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Finished:   started.Add(5 * time.Second),
+		ExitCode:   1,
+		Output:     "no database yet\n",
+	}
+	err := s.unit.AddHookExecution(execution)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookExecution{execution})
+}
+
+func (s *HookHistorySuite) TestAddHookExecutionTruncatesOutput(c *gc.C) {
+	output := strings.Repeat("a", 5000) + strings.Repeat("b", 4096)
+	err := s.unit.AddHookExecution(state.HookExecution{
+		Kind:    "action",
+		Name:    "backup",
+		Started: time.Now(),
+		Output:  output,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Output, gc.Equals, strings.Repeat("b", 4096))
+}
+
+func (s *HookHistorySuite) TestHookHistoryNewestFirst(c *gc.C) {
+	s.addExecutions(c, s.unit, 5, time.Hour)
+
+	history, err := s.unit.HookHistory(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Check(history[0].Name, gc.Equals, "hook-4")
+	c.Check(history[1].Name, gc.Equals, "hook-3")
+	c.Check(history[2].Name, gc.Equals, "hook-2")
+}
+
+func (s *HookHistorySuite) TestHookHistoryPerUnit(c *gc.C) {
+	other := s.Factory.MakeUnit(c, nil)
+	s.addExecutions(c, s.unit, 2, time.Hour)
+	s.addExecutions(c, other, 3, time.Hour)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	history, err = other.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+}
+
+func (s *HookHistorySuite) TestHookHistoryNegativeSize(c *gc.C) {
+	_, err := s.unit.HookHistory(-1)
+	c.Assert(err, gc.ErrorMatches, "negative size not valid")
+}
+
+func (s *HookHistorySuite) TestRemoveUnitErasesHookHistory(c *gc.C) {
+	s.addExecutions(c, s.unit, 2, time.Hour)
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *HookHistorySuite) TestPruneHookHistoryByDate(c *gc.C) {
+	s.addExecutions(c, s.unit, 10, 0)
+	s.addExecutions(c, s.unit, 10, 24*time.Hour)
+
+	err := state.PruneHookHistory(s.State, 10*time.Hour, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 10)
+	for _, execution := range history {
+		c.Check(execution.Started.After(time.Now().Add(-10*time.Hour)), jc.IsTrue)
+	}
+}
+
+func (s *HookHistorySuite) TestPruneHookHistoryBadArgs(c *gc.C) {
+	err := state.PruneHookHistory(s.State, 0, 0)
+	c.Assert(err, gc.ErrorMatches, "backlog size and time constraints are both 0 not valid")
+	err = state.PruneHookHistory(s.State, -time.Hour, 1)
+	c.Assert(err, gc.ErrorMatches, "non-positive maxHistoryTime not valid")
+}
@@ -82,6 +82,7 @@ func (s *InitializeSuite) TestInitialize(c *gc.C) {
 	model, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(model.Tag(), gc.Equals, modelTag)
+	c.Assert(model.Cloud(), gc.Equals, "dummy")
 	c.Assert(model.CloudRegion(), gc.Equals, "some-region")
 	// Check that the owner has been created.
 	c.Assert(model.Owner(), gc.Equals, owner)
@@ -117,6 +117,7 @@ type machineDoc struct {
 	Jobs          []MachineJob
 	NoVote        bool
 	HasVote       bool
+	Witness       bool `bson:"witness,omitempty"`
 	PasswordHash  string
 	Clean         bool
 
@@ -292,6 +293,13 @@ func (m *Machine) WantsVote() bool {
 	return wantsVote(m.doc.Jobs, m.doc.NoVote)
 }
 
+// IsWitness reports whether the machine is a witness controller,
+// which keeps a copy of the database but does not want to take
+// part in peer voting.
+func (m *Machine) IsWitness() bool {
+	return m.doc.Witness && hasJob(m.doc.Jobs, JobManageModel)
+}
+
 // HasVote reports whether that machine is currently a voting
 // member of the replica set.
 func (m *Machine) HasVote() bool {
@@ -886,6 +894,7 @@ func (m *Machine) Remove() (err error) {
 	ops = append(ops, removeContainerRefOps(m.st, m.Id())...)
 	ops = append(ops, filesystemOps...)
 	ops = append(ops, volumeOps...)
+	ops = append(ops, removeEntityBlocksOps(m.st, m.Tag())...)
 	ipAddresses, err := m.st.AllocatedIPAddresses(m.Id())
 	if err != nil {
 		return errors.Trace(err)
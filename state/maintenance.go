@@ -0,0 +1,139 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// controllerMaintenanceKey is the key for the document recording
+// whether the controller is in maintenance mode.
+const controllerMaintenanceKey = "maintenance"
+
+// ControllerMaintenance describes the controller's maintenance mode.
+// While the controller is in maintenance mode, users may only make
+// read-only API calls; agents are not affected.
+type ControllerMaintenance struct {
+	// Reason holds why the controller is in maintenance mode.
+	Reason string
+
+	// Owner is the user that enabled maintenance mode.
+	Owner names.UserTag
+
+	// Started holds when maintenance mode was enabled.
+	Started time.Time
+
+	// Expires holds when maintenance mode ends of its own accord. It
+	// is the zero time if maintenance mode does not expire.
+	Expires time.Time
+}
+
+// maintenanceDoc records that the controller is in maintenance mode.
+type maintenanceDoc struct {
+	DocID   string     `bson:"_id"`
+	Reason  string     `bson:"reason,omitempty"`
+	Owner   string     `bson:"owner"`
+	Started time.Time  `bson:"started"`
+	Expires *time.Time `bson:"expires,omitempty"`
+}
+
+// EnableMaintenance puts the controller into maintenance mode until it
+// is disabled or, if expires is not the zero time, until then. If the
+// controller is already in maintenance mode, its reason and expiry are
+// replaced.
+func (st *State) EnableMaintenance(owner names.UserTag, reason string, expires time.Time) error {
+	doc := maintenanceDoc{
+		DocID:   controllerMaintenanceKey,
+		Reason:  reason,
+		Owner:   owner.Canonical(),
+		Started: nowToTheSecond(),
+	}
+	if !expires.IsZero() {
+		if !expires.After(GetClock().Now()) {
+			return errors.NotValidf("expiry time %v in the past", expires)
+		}
+		expires = expires.UTC()
+		doc.Expires = &expires
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		controllers, closer := st.getCollection(controllersC)
+		defer closer()
+		n, err := controllers.FindId(controllerMaintenanceKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     controllerMaintenanceKey,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		}
+		set := bson.D{
+			{"reason", doc.Reason},
+			{"owner", doc.Owner},
+			{"started", doc.Started},
+		}
+		var update bson.D
+		if doc.Expires != nil {
+			set = append(set, bson.DocElem{"expires", *doc.Expires})
+		} else {
+			update = append(update, bson.DocElem{"$unset", bson.D{{"expires", nil}}})
+		}
+		update = append(update, bson.DocElem{"$set", set})
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     controllerMaintenanceKey,
+			Assert: txn.DocExists,
+			Update: update,
+		}}, nil
+	}
+	return errors.Annotate(st.run(buildTxn), "cannot enable maintenance mode")
+}
+
+// DisableMaintenance takes the controller out of maintenance mode. It
+// is not an error if the controller is not in maintenance mode.
+func (st *State) DisableMaintenance() error {
+	err := st.runTransaction([]txn.Op{{
+		C:      controllersC,
+		Id:     controllerMaintenanceKey,
+		Remove: true,
+	}})
+	return errors.Annotate(err, "cannot disable maintenance mode")
+}
+
+// ControllerMaintenance returns the controller's maintenance mode. It
+// returns an error satisfying errors.IsNotFound if the controller is
+// not in maintenance mode, or its maintenance mode has expired.
+func (st *State) ControllerMaintenance() (*ControllerMaintenance, error) {
+	controllers, closer := st.getCollection(controllersC)
+	defer closer()
+
+	var doc maintenanceDoc
+	err := controllers.FindId(controllerMaintenanceKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("controller maintenance")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller maintenance")
+	}
+	maintenance := &ControllerMaintenance{
+		Reason:  doc.Reason,
+		Owner:   names.NewUserTag(doc.Owner),
+		Started: doc.Started,
+	}
+	if doc.Expires != nil {
+		if !doc.Expires.After(GetClock().Now()) {
+			return nil, errors.NotFoundf("controller maintenance")
+		}
+		maintenance.Expires = *doc.Expires
+	}
+	return maintenance, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MaintenanceSuite struct {
+	ConnSuite
+	clock *coretesting.Clock
+}
+
+var _ = gc.Suite(&MaintenanceSuite{})
+
+func (s *MaintenanceSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Now())
+	s.PatchValue(&state.GetClock, func() clock.Clock {
+		return s.clock
+	})
+}
+
+func (s *MaintenanceSuite) TestNotInMaintenance(c *gc.C) {
+	_, err := s.State.ControllerMaintenance()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "controller maintenance not found")
+}
+
+func (s *MaintenanceSuite) TestEnableMaintenance(c *gc.C) {
+	err := s.State.EnableMaintenance(s.Owner, "upgrading mongo", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	maintenance, err := s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance.Reason, gc.Equals, "upgrading mongo")
+	c.Assert(maintenance.Owner, gc.Equals, s.Owner)
+	c.Assert(maintenance.Started.IsZero(), jc.IsFalse)
+	c.Assert(maintenance.Expires.IsZero(), jc.IsTrue)
+}
+
+func (s *MaintenanceSuite) TestEnableMaintenanceHostedModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err := st.EnableMaintenance(s.Owner, "", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Maintenance mode applies to the whole controller.
+	_, err = s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MaintenanceSuite) TestEnableMaintenanceReplaces(c *gc.C) {
+	expires := s.clock.Now().Add(time.Hour)
+	err := s.State.EnableMaintenance(s.Owner, "first", expires)
+	c.Assert(err, jc.ErrorIsNil)
+
+	other := s.Factory.MakeUser(c, nil).UserTag()
+	err = s.State.EnableMaintenance(other, "second", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	maintenance, err := s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance.Reason, gc.Equals, "second")
+	c.Assert(maintenance.Owner, gc.Equals, other)
+	c.Assert(maintenance.Expires.IsZero(), jc.IsTrue)
+}
+
+func (s *MaintenanceSuite) TestMaintenanceExpires(c *gc.C) {
+	// Times are stored with millisecond precision.
+	expires := s.clock.Now().Truncate(time.Second).Add(time.Hour)
+	err := s.State.EnableMaintenance(s.Owner, "", expires)
+	c.Assert(err, jc.ErrorIsNil)
+
+	maintenance, err := s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance.Expires.Equal(expires), jc.IsTrue)
+
+	s.clock.Advance(time.Hour)
+	_, err = s.State.ControllerMaintenance()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MaintenanceSuite) TestEnableMaintenanceExpiryInPast(c *gc.C) {
+	err := s.State.EnableMaintenance(s.Owner, "", s.clock.Now().Add(-time.Minute))
+	c.Assert(err, gc.ErrorMatches, "expiry time .* in the past not valid")
+	_, err = s.State.ControllerMaintenance()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MaintenanceSuite) TestDisableMaintenance(c *gc.C) {
+	err := s.State.EnableMaintenance(s.Owner, "", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DisableMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ControllerMaintenance()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Disabling again is not an error.
+	err = s.State.DisableMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MaintenanceSuite) TestWatchControllerMaintenance(c *gc.C) {
+	w := s.State.WatchControllerMaintenance()
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.EnableMaintenance(s.Owner, "upgrading mongo", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.EnableMaintenance(s.Owner, "upgrading juju", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.DisableMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -49,7 +49,25 @@ func (st *State) Export() (description.Model, error) {
 		return nil, errors.New("missing model config")
 	}
 
-	blocks, err := export.readBlocks()
+	// Cloud credentials stored in the controller are not migrated, so
+	// the attributes of the model's credential are exported with its
+	// config, as they were before credentials were stored; the target
+	// controller may not hold the credential.
+	exportedConfig := make(map[string]interface{})
+	for k, v := range modelConfig.Settings {
+		exportedConfig[k] = v
+	}
+	credential, ok, err := export.st.modelCloudCredential()
+	if err != nil {
+		return nil, errors.Annotate(err, "cloud credential")
+	}
+	if ok {
+		for k, v := range credential.Attributes {
+			exportedConfig[k] = v
+		}
+	}
+
+	blocks, entityBlocks, err := export.readBlocks()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -57,9 +75,10 @@ func (st *State) Export() (description.Model, error) {
 	args := description.ModelArgs{
 		CloudRegion:        dbModel.CloudRegion(),
 		Owner:              dbModel.Owner(),
-		Config:             modelConfig.Settings,
+		Config:             exportedConfig,
 		LatestToolsVersion: dbModel.LatestToolsVersion(),
 		Blocks:             blocks,
+		EntityBlocks:       entityBlocks,
 	}
 	export.model = description.NewModel(args)
 	modelKey := dbModel.globalKey()
@@ -126,23 +145,35 @@ func (e *exporter) sequences() error {
 	return nil
 }
 
-func (e *exporter) readBlocks() (map[string]string, error) {
+// readBlocks returns the blocks on the model, as a map of block type
+// to message, and the blocks on individual applications and machines,
+// as a map of entity tag to the same.
+func (e *exporter) readBlocks() (map[string]string, map[string]map[string]string, error) {
 	blocks, closer := e.st.getCollection(blocksC)
 	defer closer()
 
 	var docs []blockDoc
 	if err := blocks.Find(nil).All(&docs); err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	result := make(map[string]string)
+	entityResult := make(map[string]map[string]string)
+	modelTag := e.st.ModelTag().String()
 	for _, doc := range docs {
-		// We don't care about the id, uuid, or tag.
-		// The uuid and tag both refer to the model uuid, and the
-		// id is opaque - even though it is sequence generated.
-		result[doc.Type.MigrationValue()] = doc.Message
+		// We don't care about the id, uuid, or owner. The uuid
+		// refers to the model uuid, and the id is opaque - even
+		// though it is sequence generated.
+		if doc.Tag == modelTag {
+			result[doc.Type.MigrationValue()] = doc.Message
+			continue
+		}
+		if entityResult[doc.Tag] == nil {
+			entityResult[doc.Tag] = make(map[string]string)
+		}
+		entityResult[doc.Tag][doc.Type.MigrationValue()] = doc.Message
 	}
-	return result, nil
+	return result, entityResult, nil
 }
 
 func (e *exporter) modelUsers() error {
@@ -451,6 +482,7 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 		CharmModifiedVersion: application.doc.CharmModifiedVersion,
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
+		Trusted:              application.doc.Trusted,
 		MinUnits:             application.doc.MinUnits,
 		Settings:             applicationSettingsDoc.Settings,
 		SettingsRefCount:     refCount,
@@ -489,6 +521,12 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 			MeterStatusCode: unitMeterStatus.Code,
 			MeterStatusInfo: unitMeterStatus.Info,
 		}
+		if versionDoc, found := e.status[unit.globalWorkloadVersionKey()]; found {
+			args.WorkloadVersion, _ = versionDoc["statusinfo"].(string)
+		}
+		if stateDoc, found := e.modelSettings[unitStateSettingsKey(unit.Name())]; found {
+			args.State = unitStateFromSettings(stateDoc.Settings)
+		}
 		if principalName, isSubordinate := unit.PrincipalName(); isSubordinate {
 			args.Principal = names.NewUnitTag(principalName)
 		}
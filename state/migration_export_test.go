@@ -152,6 +152,32 @@ func (s *MigrationExportSuite) TestModelInfo(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestEntityBlocks(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	application := s.Factory.MakeApplication(c, nil)
+	err := s.State.AddBlock(state.BlockArgs{
+		Type:    state.RemoveBlock,
+		Entity:  machine.Tag(),
+		Message: "keep the machine",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddBlock(state.BlockArgs{
+		Type:    state.ChangeBlock,
+		Entity:  application.Tag(),
+		Message: "hands off",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(model.Blocks(), gc.HasLen, 0)
+	c.Assert(model.EntityBlocks(), jc.DeepEquals, map[string]map[string]string{
+		machine.Tag().String():     {"remove-object": "keep the machine"},
+		application.Tag().String(): {"all-changes": "hands off"},
+	})
+}
+
 func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	// Make sure we have some last connection times for the admin user,
 	// and create a few other users.
@@ -378,6 +404,32 @@ func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	c.Assert(opened[0].UnitName(), gc.Equals, unit.Name())
 }
 
+func (s *MigrationExportSuite) TestUnitsState(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.UpdateState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	units := model.Applications()[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].State(), jc.DeepEquals, map[string]string{"initialised": "true"})
+}
+
+func (s *MigrationExportSuite) TestUnitsWorkloadVersion(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.SetWorkloadVersion("9.4.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	units := model.Applications()[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].WorkloadVersion(), gc.Equals, "9.4.1")
+}
+
 func (s *MigrationExportSuite) TestRelations(c *gc.C) {
 	// Need to remove owner from application.
 	ignored := s.Owner
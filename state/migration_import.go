@@ -8,8 +8,10 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
@@ -29,7 +31,24 @@ import (
 var initialLeaderClaimTime = time.Minute
 
 // Import the database agnostic model representation into the database.
-func (st *State) Import(model description.Model) (_ *Model, _ *State, err error) {
+func (st *State) Import(model description.Model) (*Model, *State, error) {
+	return st.importModel(model, model.Owner(), false)
+}
+
+// ImportFresh imports the database agnostic model representation into
+// the database as a fresh deployment of the model, rather than as the
+// model itself. The applications, units, relations and machines are
+// imported, but the machines are not associated with their instances,
+// so the provisioner starts new ones, and none of the agents' passwords
+// or tools are kept, so the agents are set up afresh. The model is owned
+// by the specified user, who is its only user.
+//
+// As with Import, the new model is left in the importing migration mode.
+func (st *State) ImportFresh(model description.Model, owner names.UserTag) (*Model, *State, error) {
+	return st.importModel(model, owner, true)
+}
+
+func (st *State) importModel(model description.Model, owner names.UserTag, fresh bool) (_ *Model, _ *State, err error) {
 	logger := loggo.GetLogger("juju.state.import-model")
 	logger.Debugf("import starting for model %s", model.Tag().Id())
 	// At this stage, attempting to import a model with the same
@@ -59,7 +78,7 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	dbModel, newSt, err := st.NewModel(ModelArgs{
 		CloudRegion:   model.CloudRegion(),
 		Config:        cfg,
-		Owner:         model.Owner(),
+		Owner:         owner,
 		MigrationMode: MigrationModeImporting,
 	})
 	if err != nil {
@@ -78,6 +97,7 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 		dbModel: dbModel,
 		model:   model,
 		logger:  logger,
+		fresh:   fresh,
 	}
 	if err := restore.sequences(); err != nil {
 		return nil, nil, errors.Annotate(err, "sequences")
@@ -91,8 +111,12 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 		return nil, nil, errors.Annotate(err, "model constraints")
 	}
 
-	if err := restore.modelUsers(); err != nil {
-		return nil, nil, errors.Annotate(err, "modelUsers")
+	if !fresh {
+		// A fresh deployment keeps the owner added when the
+		// model was created as its only user.
+		if err := restore.modelUsers(); err != nil {
+			return nil, nil, errors.Annotate(err, "modelUsers")
+		}
 	}
 	if err := restore.machines(); err != nil {
 		return nil, nil, errors.Annotate(err, "machines")
@@ -103,6 +127,11 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.relations(); err != nil {
 		return nil, nil, errors.Annotate(err, "relations")
 	}
+	// The applications and machines must exist before the blocks
+	// on them can be added.
+	if err := restore.entityBlocks(); err != nil {
+		return nil, nil, errors.Annotate(err, "entity blocks")
+	}
 
 	// NOTE: at the end of the import make sure that the mode of the model
 	// is set to "imported" not "active" (or whatever we call it). This way
@@ -120,6 +149,12 @@ type importer struct {
 	dbModel *Model
 	model   description.Model
 	logger  loggo.Logger
+	// fresh holds whether the model is being imported as a fresh
+	// deployment; see ImportFresh.
+	fresh bool
+	// skippedMachines holds the ids of the controller machines left
+	// out of a fresh deployment.
+	skippedMachines set.Strings
 	// applicationUnits is populated at the end of loading the applications, and is a
 	// map of application name to units of that application.
 	applicationUnits map[string][]*Unit
@@ -138,14 +173,8 @@ func (i *importer) modelExtras() error {
 		}
 	}
 
-	blockType := map[string]BlockType{
-		"destroy-model": DestroyBlock,
-		"remove-object": RemoveBlock,
-		"all-changes":   ChangeBlock,
-	}
-
 	for blockName, message := range i.model.Blocks() {
-		block, ok := blockType[blockName]
+		block, ok := migrationBlockTypes[blockName]
 		if !ok {
 			return errors.Errorf("unknown block type: %q", blockName)
 		}
@@ -154,6 +183,44 @@ func (i *importer) modelExtras() error {
 	return nil
 }
 
+// migrationBlockTypes maps the block types recorded in a model
+// description to the block types they represent.
+var migrationBlockTypes = map[string]BlockType{
+	"destroy-model": DestroyBlock,
+	"remove-object": RemoveBlock,
+	"all-changes":   ChangeBlock,
+}
+
+// entityBlocks adds the blocks on individual applications and
+// machines.
+func (i *importer) entityBlocks() error {
+	i.logger.Debugf("importing entity blocks")
+	for tagString, blocks := range i.model.EntityBlocks() {
+		tag, err := names.ParseTag(tagString)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if tag.Kind() == names.MachineTagKind && i.skippedMachines.Contains(tag.Id()) {
+			continue
+		}
+		for blockName, message := range blocks {
+			block, ok := migrationBlockTypes[blockName]
+			if !ok {
+				return errors.Errorf("unknown block type: %q", blockName)
+			}
+			if err := i.st.AddBlock(BlockArgs{
+				Type:    block,
+				Entity:  tag,
+				Message: message,
+			}); err != nil {
+				return errors.Annotatef(err, "block on %s", names.ReadableString(tag))
+			}
+		}
+	}
+	i.logger.Debugf("importing entity blocks succeeded")
+	return nil
+}
+
 func (i *importer) sequences() error {
 	sequenceValues := i.model.Sequences()
 	docs := make([]interface{}, 0, len(sequenceValues))
@@ -231,7 +298,16 @@ func (i *importer) modelUsers() error {
 
 func (i *importer) machines() error {
 	i.logger.Debugf("importing machines")
+	i.skippedMachines = set.NewStrings()
+	hosting := i.unitMachines()
 	for _, m := range i.model.Machines() {
+		if i.fresh && isControllerOnlyMachine(m, hosting) {
+			// The new model has no use for the controller machines
+			// of the model it was cloned from.
+			i.logger.Debugf("skipping controller machine %s", m.Id())
+			i.skippedMachines.Add(m.Id())
+			continue
+		}
 		if err := i.machine(m); err != nil {
 			i.logger.Errorf("error importing machine: %s", err)
 			return errors.Annotate(err, m.Id())
@@ -242,6 +318,32 @@ func (i *importer) machines() error {
 	return nil
 }
 
+// unitMachines returns the ids of the machines hosting units in the
+// model being imported.
+func (i *importer) unitMachines() set.Strings {
+	result := set.NewStrings()
+	for _, application := range i.model.Applications() {
+		for _, unit := range application.Units() {
+			result.Add(unit.Machine().Id())
+		}
+	}
+	return result
+}
+
+// isControllerOnlyMachine reports whether the given machine does
+// nothing but manage the model: it hosts no units or containers.
+func isControllerOnlyMachine(m description.Machine, hosting set.Strings) bool {
+	if len(m.Containers()) > 0 || hosting.Contains(m.Id()) {
+		return false
+	}
+	for _, job := range m.Jobs() {
+		if job == "api-server" {
+			return true
+		}
+	}
+	return false
+}
+
 func (i *importer) machine(m description.Machine) error {
 	// Import this machine, then import its containers.
 	i.logger.Debugf("importing machine %s", m.Id())
@@ -274,6 +376,20 @@ func (i *importer) machine(m description.Machine) error {
 		ModelUUID: i.st.ModelUUID(),
 		Status:    status.StatusStarted,
 	}
+	if i.fresh {
+		// The machine is yet to be provisioned.
+		now := time.Now().UnixNano()
+		machineStatusDoc = statusDoc{
+			ModelUUID: i.st.ModelUUID(),
+			Status:    status.StatusPending,
+			Updated:   now,
+		}
+		instanceStatusDoc = statusDoc{
+			ModelUUID: i.st.ModelUUID(),
+			Status:    status.StatusPending,
+			Updated:   now,
+		}
+	}
 	cons := i.constraints(m.Constraints())
 	prereqOps, machineOp := i.st.baseNewMachineOps(
 		mdoc,
@@ -283,7 +399,7 @@ func (i *importer) machine(m description.Machine) error {
 	)
 
 	// 3. create op for adding in instance data
-	if instance := m.Instance(); instance != nil {
+	if instance := m.Instance(); instance != nil && !i.fresh {
 		prereqOps = append(prereqOps, i.machineInstanceOp(mdoc, instance))
 	}
 
@@ -313,8 +429,10 @@ func (i *importer) machine(m description.Machine) error {
 			return errors.Trace(err)
 		}
 	}
-	if err := i.importStatusHistory(machine.globalKey(), m.StatusHistory()); err != nil {
-		return errors.Trace(err)
+	if !i.fresh {
+		if err := i.importStatusHistory(machine.globalKey(), m.StatusHistory()); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Now that this machine exists in the database, process each of the
@@ -405,6 +523,23 @@ func (i *importer) makeMachineDoc(m description.Machine) (*machineDoc, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if i.fresh {
+		jobs = freshMachineJobs(jobs)
+		// A new instance will be provisioned for the machine, so
+		// nothing about the existing one carries over.
+		return &machineDoc{
+			DocID:         i.st.docID(id),
+			Id:            id,
+			ModelUUID:     i.st.ModelUUID(),
+			Series:        m.Series(),
+			ContainerType: m.ContainerType(),
+			Life:          Alive,
+			Jobs:          jobs,
+			NoVote:        true,
+			Clean:         true,
+			Placement:     m.Placement(),
+		}, nil
+	}
 	return &machineDoc{
 		DocID:                    i.st.docID(id),
 		Id:                       id,
@@ -450,6 +585,27 @@ func (i *importer) makeMachineJobs(jobs []string) ([]MachineJob, error) {
 	return result, nil
 }
 
+// freshMachineJobs returns the given jobs without JobManageModel, as a
+// fresh deployment must not gain controller machines. The machine
+// hosts units in its place.
+func freshMachineJobs(jobs []MachineJob) []MachineJob {
+	result := make([]MachineJob, 0, len(jobs))
+	hostUnits := false
+	for _, job := range jobs {
+		switch job {
+		case JobManageModel:
+			continue
+		case JobHostUnits:
+			hostUnits = true
+		}
+		result = append(result, job)
+	}
+	if !hostUnits {
+		result = append(result, JobHostUnits)
+	}
+	return result
+}
+
 func (i *importer) makeTools(t description.AgentTools) *tools.Tools {
 	if t == nil {
 		return nil
@@ -566,8 +722,10 @@ func (i *importer) application(s description.Application) error {
 			return errors.Trace(err)
 		}
 	}
-	if err := i.importStatusHistory(svc.globalKey(), s.StatusHistory()); err != nil {
-		return errors.Trace(err)
+	if !i.fresh {
+		if err := i.importStatusHistory(svc.globalKey(), s.StatusHistory()); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	for _, unit := range s.Units() {
@@ -576,7 +734,8 @@ func (i *importer) application(s description.Application) error {
 		}
 	}
 
-	if s.Leader() != "" {
+	// The units of a fresh deployment will elect their own leader.
+	if s.Leader() != "" && !i.fresh {
 		if err := i.st.LeadershipClaimer().ClaimLeadership(
 			s.Name(),
 			s.Leader(),
@@ -609,15 +768,42 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 		return errors.NotValidf("missing workload status")
 	}
 	workloadStatusDoc := i.makeStatusDoc(workloadStatus)
+	workloadVersionDoc := statusDoc{
+		Status:     status.StatusActive,
+		StatusInfo: u.WorkloadVersion(),
+		Updated:    workloadStatusDoc.Updated,
+	}
+	if i.fresh {
+		// The unit is yet to be deployed to its new machine.
+		now := time.Now().UnixNano()
+		agentStatusDoc = statusDoc{
+			Status:  status.StatusAllocating,
+			Updated: now,
+		}
+		workloadStatusDoc = statusDoc{
+			Status:     status.StatusUnknown,
+			StatusInfo: MessageWaitForAgentInit,
+			Updated:    now,
+		}
+		workloadVersionDoc = statusDoc{
+			Status:  status.StatusUnknown,
+			Updated: now,
+		}
+	}
+	meterStatus := &meterStatusDoc{
+		Code: u.MeterStatusCode(),
+		Info: u.MeterStatusInfo(),
+	}
+	if i.fresh {
+		meterStatus = &meterStatusDoc{Code: MeterNotSet.String()}
+	}
 
 	ops := addUnitOps(i.st, addUnitOpsArgs{
-		unitDoc:           udoc,
-		agentStatusDoc:    agentStatusDoc,
-		workloadStatusDoc: workloadStatusDoc,
-		meterStatusDoc: &meterStatusDoc{
-			Code: u.MeterStatusCode(),
-			Info: u.MeterStatusInfo(),
-		},
+		unitDoc:            udoc,
+		agentStatusDoc:     agentStatusDoc,
+		workloadStatusDoc:  workloadStatusDoc,
+		workloadVersionDoc: workloadVersionDoc,
+		meterStatusDoc:     meterStatus,
 	})
 
 	// If the unit is a principal, add it to its machine.
@@ -638,6 +824,11 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 		ops = append(ops, createConstraintsOp(i.st, agentGlobalKey, i.constraints(cons)))
 	}
 
+	// The units of a fresh deployment start without any charm state.
+	if state := u.State(); len(state) > 0 && !i.fresh {
+		ops = append(ops, createSettingsOp(settingsC, unitStateSettingsKey(u.Name()), stringMapToInterfaces(state)))
+	}
+
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
 	}
@@ -648,6 +839,9 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 			return errors.Trace(err)
 		}
 	}
+	if i.fresh {
+		return nil
+	}
 	if err := i.importStatusHistory(unit.globalKey(), u.WorkloadStatusHistory()); err != nil {
 		return errors.Trace(err)
 	}
@@ -676,6 +870,7 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		Trusted:              s.Trusted(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
 	}, nil
@@ -713,6 +908,19 @@ func (i *importer) makeUnitDoc(s description.Application, u description.Unit) (*
 		}
 	}
 
+	if i.fresh {
+		// The unit agent will be deployed afresh.
+		return &unitDoc{
+			Name:         u.Name(),
+			Application:  s.Name(),
+			Series:       s.Series(),
+			CharmURL:     charmUrl,
+			Principal:    u.Principal().Id(),
+			Subordinates: subordinates,
+			MachineId:    u.Machine().Id(),
+			Life:         Alive,
+		}, nil
+	}
 	return &unitDoc{
 		Name:         u.Name(),
 		Application:  s.Name(),
@@ -270,6 +270,38 @@ func (s *MigrationImportSuite) TestMachines(c *gc.C) {
 	c.Assert(newCons.String(), gc.Equals, cons.String())
 }
 
+func (s *MigrationImportSuite) TestEntityBlocks(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	application := s.Factory.MakeApplication(c, nil)
+	err := s.State.AddBlock(state.BlockArgs{
+		Type:    state.RemoveBlock,
+		Entity:  machine.Tag(),
+		Message: "keep the machine",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddBlock(state.BlockArgs{
+		Type:    state.ChangeBlock,
+		Entity:  application.Tag(),
+		Message: "hands off",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	block, found, err := newSt.GetBlockForEntity(state.RemoveBlock, machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(block.Message(), gc.Equals, "keep the machine")
+	block, found, err = newSt.GetBlockForEntity(state.ChangeBlock, application.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(block.Message(), gc.Equals, "hands off")
+	_, found, err = newSt.GetBlockForEntity(state.ChangeBlock, machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+}
+
 func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	// Add a service with both settings and leadership settings.
 	cons := constraints.MustParse("arch=amd64 mem=8G")
@@ -285,8 +317,9 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = service.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
-	// Expose the service.
+	// Expose and trust the service.
 	c.Assert(service.SetExposed(), jc.ErrorIsNil)
+	c.Assert(service.SetTrusted(), jc.ErrorIsNil)
 	err = s.State.SetAnnotations(service, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, service, status.StatusActive, 5)
@@ -308,6 +341,7 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	c.Assert(imported.ApplicationTag(), gc.Equals, exported.ApplicationTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.IsTrusted(), jc.IsTrue)
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -466,6 +500,146 @@ func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestUnitsState(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.UpdateState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err := imported.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"initialised": "true"})
+}
+
+func (s *MigrationImportSuite) TestUnitsWorkloadVersion(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.SetWorkloadVersion("9.4.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	version, err := imported.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "9.4.1")
+}
+
+func (s *MigrationImportSuite) importFreshModel(c *gc.C, owner names.UserTag) (*state.Model, *state.State) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := utils.MustNewUUID().String()
+	in := newModel(out, uuid, "fresh")
+
+	newModel, newSt, err := s.State.ImportFresh(in, owner)
+	c.Assert(err, jc.ErrorIsNil)
+	return newModel, newSt
+}
+
+func (s *MigrationImportSuite) TestImportFreshOwner(c *gc.C) {
+	s.newModelUser(c, "bravo@external", false, time.Time{})
+	owner := s.Factory.MakeUser(c, &factory.UserParams{Name: "charlie"}).UserTag()
+
+	newModel, newSt := s.importFreshModel(c, owner)
+	defer newSt.Close()
+
+	c.Assert(newModel.Owner(), gc.Equals, owner)
+	c.Assert(newModel.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+	users, err := newModel.Users()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, gc.HasLen, 1)
+	c.Assert(users[0].UserTag(), gc.Equals, owner)
+}
+
+func (s *MigrationImportSuite) TestImportFreshMachines(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64 mem=8G")
+	exported := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: cons,
+	})
+	s.primeStatusHistory(c, exported, status.StatusStarted, 5)
+
+	_, newSt := s.importFreshModel(c, s.Owner)
+	defer newSt.Close()
+
+	imported, err := newSt.Machine(exported.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Series(), gc.Equals, exported.Series())
+	c.Assert(imported.Jobs(), jc.DeepEquals, exported.Jobs())
+	_, err = imported.InstanceId()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Assert(imported.Addresses(), gc.HasLen, 0)
+
+	machineStatus, err := imported.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus.Status, gc.Equals, status.StatusPending)
+	history, err := imported.StatusHistory(status.StatusHistoryFilter{Size: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+
+	newCons, err := imported.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newCons.String(), gc.Equals, cons.String())
+}
+
+func (s *MigrationImportSuite) TestImportFreshControllerMachines(c *gc.C) {
+	controller := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+	hosting := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel, state.JobHostUnits},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Machine: hosting})
+
+	_, newSt := s.importFreshModel(c, s.Owner)
+	defer newSt.Close()
+
+	_, err := newSt.Machine(controller.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	imported, err := newSt.Machine(hosting.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Jobs(), jc.DeepEquals, []state.MachineJob{state.JobHostUnits})
+}
+
+func (s *MigrationImportSuite) TestImportFreshUnits(c *gc.C) {
+	exported, pwd := s.Factory.MakeUnitReturningPassword(c, nil)
+	err := exported.SetMeterStatus("GREEN", "some info")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importFreshModel(c, s.Owner)
+	defer newSt.Close()
+
+	imported, err := newSt.Unit(exported.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.PasswordValid(pwd), jc.IsFalse)
+
+	exportedMachineId, err := exported.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	importedMachineId, err := imported.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(importedMachineId, gc.Equals, exportedMachineId)
+
+	agentStatus, err := imported.AgentStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentStatus.Status, gc.Equals, status.StatusAllocating)
+	meterStatus, err := imported.GetMeterStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(meterStatus.Code, gc.Equals, state.MeterNotSet)
+
+	application, err := imported.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	exportedApplication, err := exported.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	importedURL, _ := application.CharmURL()
+	exportedURL, _ := exportedApplication.CharmURL()
+	c.Assert(importedURL, gc.DeepEquals, exportedURL)
+}
+
 func (s *MigrationImportSuite) TestDestroyEmptyModel(c *gc.C) {
 	newModel, newSt := s.importModel(c)
 	defer newSt.Close()
@@ -57,9 +57,15 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guimetadataC,
 		// This is controller global, not migrated.
 		guisettingsC,
+		// Cloud credentials are controller global, and are not migrated;
+		// a model's credential attributes are exported with its config.
+		cloudCredentialsC,
+		// Clouds are registered with the controller, and are not migrated.
+		cloudsC,
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
+		externalUsersC,
 		// userenvnameC is just to provide a unique key constraint.
 		usermodelnameC,
 		// Metrics aren't migrated.
@@ -112,6 +118,13 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// The SSH host keys for each machine will be reported as each
 		// machine agent starts up.
 		sshHostKeysC,
+
+		// The space drift report is recomputed by the discoverspaces
+		// worker in the target model.
+		spaceDriftC,
+
+		// Hook execution history is diagnostic, and is not migrated.
+		hookHistoryC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -127,6 +140,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		"payloads",
 		"resources",
 		endpointBindingsC,
+		// Secret values are encrypted with a controller specific key,
+		// so models with secrets cannot be migrated.
+		secretsC,
 
 		// storage
 		blockDevicesC,
@@ -311,6 +327,7 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"Trusted",
 		"MinUnits",
 		"MetricCredentials",
 	)
@@ -459,11 +476,17 @@ func (s *MigrationSuite) TestBlockDocFields(c *gc.C) {
 		// ModelUUID shouldn't be exported, and is inherited
 		// from the model definition.
 		"ModelUUID",
-		// Tag is just string representation of the model tag,
-		// which also contains the model-uuid.
-		"Tag",
+		// The owner and creation time are informational, and
+		// the user that switches the block on need not exist
+		// in the target controller.
+		"Owner",
+		"Created",
 	)
 	migrated := set.NewStrings(
+		// Tag is exported for blocks on applications and
+		// machines; for model blocks it is just the string
+		// representation of the model tag.
+		"Tag",
 		"Type",
 		"Message",
 	)
@@ -60,9 +60,23 @@ type modelDoc struct {
 	ServerUUID    string        `bson:"server-uuid"`
 	MigrationMode MigrationMode `bson:"migration-mode"`
 
+	// Cloud is the name of the cloud to which the model is deployed.
+	// It is empty for models created before clouds were recorded,
+	// which are deployed to the controller's cloud.
+	Cloud string `bson:"cloud,omitempty"`
+
 	// CloudRegion is the name of the cloud region to which the model is deployed.
 	CloudRegion string `bson:"cloud-region,omitempty"`
 
+	// CloudCredential is the name of the controller-stored cloud
+	// credential used by the model.
+	CloudCredential string `bson:"cloud-credential,omitempty"`
+
+	// CloudCredentialOwner is the owner of the cloud credential. It is
+	// empty if the credential is owned by the model owner, and is set
+	// when ownership of the model is changed.
+	CloudCredentialOwner string `bson:"cloud-credential-owner,omitempty"`
+
 	// LatestAvailableTools is a string representing the newest version
 	// found while checking streams for new versions.
 	LatestAvailableTools string `bson:"available-tools,omitempty"`
@@ -140,9 +154,20 @@ func (st *State) AllModels() ([]*Model, error) {
 
 // ModelArgs is a params struct for creating a new model.
 type ModelArgs struct {
+	// CloudName is the name of the cloud to which the model is
+	// deployed. If empty, the controller's cloud is used; any other
+	// cloud must have been registered with AddCloud.
+	CloudName string
+
 	// CloudRegion is the name of the cloud region to which the model is deployed.
 	CloudRegion string
 
+	// CloudCredential is the name of the cloud credential, owned by
+	// the model owner and stored in the controller, that the model
+	// uses. It may be empty, in which case the credential attributes
+	// must be included in the model config.
+	CloudCredential string
+
 	// Config is the model config.
 	Config *config.Config
 
@@ -202,14 +227,43 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		}
 	}()
 
-	cloudCfg, err := st.CloudConfig()
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cloudName := args.CloudName
+	if cloudName == "" {
+		cloudName = info.CloudName
+	}
+	var cloudOps []txn.Op
+	if cloudName != info.CloudName {
+		modelCloud, err := st.Cloud(cloudName)
+		if err != nil {
+			return nil, nil, errors.Annotate(err, "cannot create model")
+		}
+		if err := validateCloudRegion(modelCloud, args.CloudRegion); err != nil {
+			return nil, nil, errors.Annotate(err, "cannot create model")
+		}
+		cloudOps = append(cloudOps, assertCloudExistsOp(cloudName))
+	}
+	cloudCfg, err := st.InheritedModelConfig(cloudName, args.CloudRegion)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "could not read cloud config for new model")
 	}
-	ops, err := newSt.modelSetupOps(args.Config, args.CloudRegion, cloudCfg, owner, args.MigrationMode)
+	var credential *CloudCredential
+	if args.CloudCredential != "" {
+		cred, err := st.CloudCredential(owner, cloudName, args.CloudCredential)
+		if err != nil {
+			return nil, nil, errors.Annotate(err, "cannot create model")
+		}
+		credential = &cred
+		cloudOps = append(cloudOps, assertCloudCredentialExistsOp(owner, cloudName, args.CloudCredential))
+	}
+	ops, err := newSt.modelSetupOps(args.Config, cloudName, args.CloudRegion, credential, cloudCfg, owner, args.MigrationMode)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "failed to create new model")
 	}
+	ops = append(ops, cloudOps...)
 	err = newSt.runTransaction(ops)
 	if err == txn.ErrAborted {
 
@@ -283,11 +337,24 @@ func (m *Model) Name() string {
 	return m.doc.Name
 }
 
+// Cloud returns the name of the cloud to which the model is deployed.
+// It is empty for models created before clouds were recorded, which
+// are deployed to the controller's cloud.
+func (m *Model) Cloud() string {
+	return m.doc.Cloud
+}
+
 // CloudRegion returns the name of the cloud region to which the model is deployed.
 func (m *Model) CloudRegion() string {
 	return m.doc.CloudRegion
 }
 
+// CloudCredential returns the name of the controller-stored cloud
+// credential used by the model, and whether the model uses one.
+func (m *Model) CloudCredential() (string, bool) {
+	return m.doc.CloudCredential, m.doc.CloudCredential != ""
+}
+
 // MigrationMode returns whether the model is active or being migrated.
 func (m *Model) MigrationMode() MigrationMode {
 	return m.doc.MigrationMode
@@ -319,11 +386,118 @@ func (m *Model) Life() Life {
 }
 
 // Owner returns tag representing the owner of the model.
-// The owner is the user that created the model.
+// The owner is the user that created the model, unless
+// ownership has since been changed with SetOwner.
 func (m *Model) Owner() names.UserTag {
 	return names.NewUserTag(m.doc.Owner)
 }
 
+// cloudCredentialOwner returns the owner of the model's cloud
+// credential, which stays with the model's previous owner when
+// ownership of the model is changed.
+func (m *Model) cloudCredentialOwner() names.UserTag {
+	if m.doc.CloudCredentialOwner != "" {
+		return names.NewUserTag(m.doc.CloudCredentialOwner)
+	}
+	return m.Owner()
+}
+
+// SetOwner transfers ownership of the model to the given user, who
+// is given admin access to the model. The previous owner keeps their
+// existing access, and remains the owner of any cloud credential
+// used by the model. The controller model's owner cannot be changed.
+func (m *Model) SetOwner(owner names.UserTag) error {
+	if err := m.setOwner(owner); err != nil {
+		return errors.Annotatef(err, "cannot change owner of model %q", m.Name())
+	}
+	return nil
+}
+
+func (m *Model) setOwner(owner names.UserTag) error {
+	if m.doc.UUID == m.doc.ServerUUID {
+		return errors.New("controller model owner cannot be changed")
+	}
+	st, closeState, err := m.getState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closeState()
+
+	var displayName string
+	if owner.IsLocal() {
+		user, err := st.User(owner)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		displayName = user.DisplayName()
+	}
+	usermodelnames, closer := st.getCollection(usermodelnameC)
+	defer closer()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life != Alive {
+			return nil, errors.New("model is no longer alive")
+		}
+		oldOwner := m.Owner()
+		if oldOwner.Canonical() == owner.Canonical() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		newIndex := userModelNameIndex(owner.Canonical(), m.doc.Name)
+		if n, err := usermodelnames.FindId(newIndex).Count(); err != nil {
+			return nil, errors.Trace(err)
+		} else if n > 0 {
+			return nil, errors.AlreadyExistsf("model %q for %s", m.doc.Name, owner.Canonical())
+		}
+
+		set := bson.D{{"owner", owner.Canonical()}}
+		if m.doc.CloudCredential != "" && m.doc.CloudCredentialOwner == "" {
+			set = append(set, bson.DocElem{"cloud-credential-owner", oldOwner.Canonical()})
+		}
+		ops := []txn.Op{{
+			C:      modelsC,
+			Id:     m.doc.UUID,
+			Assert: bson.D{{"life", Alive}, {"owner", m.doc.Owner}},
+			Update: bson.D{{"$set", set}},
+		}, {
+			C:      usermodelnameC,
+			Id:     userModelNameIndex(oldOwner.Canonical(), m.doc.Name),
+			Assert: txn.DocExists,
+			Remove: true,
+		}, createUniqueOwnerModelNameOp(owner, m.doc.Name)}
+
+		modelUser, err := st.ModelUser(owner)
+		if errors.IsNotFound(err) {
+			ops = append(ops, createModelUserOp(
+				m.doc.UUID, owner, oldOwner, displayName, nowToTheSecond(), ModelAdminAccess,
+			))
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		} else if modelUser.Access() != ModelAdminAccess || modelUser.Group() != "" {
+			// Access granted through a group would be revoked
+			// along with the group, so record it explicitly.
+			ops = append(ops, txn.Op{
+				C:      modelUsersC,
+				Id:     modelUserID(owner),
+				Assert: txn.DocExists,
+				Update: bson.D{
+					{"$set", bson.D{{"access", ModelAdminAccess}}},
+					{"$unset", bson.D{{"group", nil}}},
+				},
+			})
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return m.Refresh()
+}
+
 // Status returns the status of the model.
 func (m *Model) Status() (status.StatusInfo, error) {
 	st, closeState, err := m.getState()
@@ -449,6 +623,19 @@ func (m *Model) Users() ([]*ModelUser, error) {
 	return modelUsers, nil
 }
 
+// removeUser revokes the user's access to the model, if they have any.
+func (m *Model) removeUser(user names.UserTag) error {
+	st, closeState, err := m.getState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closeState()
+	if err := st.RemoveModelUser(user); err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // Destroy sets the models's lifecycle to Dying, preventing
 // addition of services or machines to state. If called on
 // an empty hosted model, the lifecycle will be advanced
@@ -776,15 +963,17 @@ func ensureDestroyable(st *State) error {
 
 // createModelOp returns the operation needed to create
 // an model document with the given name and UUID.
-func createModelOp(st *State, owner names.UserTag, name, uuid, server, cloudRegion string, mode MigrationMode) txn.Op {
+func createModelOp(st *State, owner names.UserTag, name, uuid, server, cloudName, cloudRegion, cloudCredential string, mode MigrationMode) txn.Op {
 	doc := &modelDoc{
-		UUID:          uuid,
-		Name:          name,
-		Life:          Alive,
-		Owner:         owner.Canonical(),
-		ServerUUID:    server,
-		MigrationMode: mode,
-		CloudRegion:   cloudRegion,
+		UUID:            uuid,
+		Name:            name,
+		Life:            Alive,
+		Owner:           owner.Canonical(),
+		ServerUUID:      server,
+		MigrationMode:   mode,
+		Cloud:           cloudName,
+		CloudRegion:     cloudRegion,
+		CloudCredential: cloudCredential,
 	}
 	return txn.Op{
 		C:      modelsC,
@@ -175,6 +175,90 @@ func (s *ModelSuite) TestSetMigrationMode(c *gc.C) {
 	c.Assert(env.MigrationMode(), gc.Equals, state.MigrationModeExporting)
 }
 
+func (s *ModelSuite) TestSetOwner(c *gc.C) {
+	cfg, _ := s.createTestModelConfig(c)
+	model, st, err := s.State.NewModel(state.ModelArgs{Config: cfg, Owner: s.Owner, CloudRegion: "some-region"})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+
+	err = model.SetOwner(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Owner(), gc.Equals, bob)
+
+	model, err = s.State.GetModel(model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Owner(), gc.Equals, bob)
+
+	// The new owner is given admin access, and the previous
+	// owner keeps theirs.
+	modelUser, err := st.ModelUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+	modelUser, err = st.ModelUser(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+
+	// The previous owner can now create another model
+	// with the same name.
+	cfg2 := testing.CustomModelConfig(c, testing.Attrs{
+		"name": cfg.Name(),
+		"uuid": utils.MustNewUUID().String(),
+	})
+	_, st2, err := s.State.NewModel(state.ModelArgs{Config: cfg2, Owner: s.Owner, CloudRegion: "some-region"})
+	c.Assert(err, jc.ErrorIsNil)
+	st2.Close()
+}
+
+func (s *ModelSuite) TestSetOwnerUpgradesAccess(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	_, err := st.AddModelUser(state.ModelUserSpec{User: bob, CreatedBy: s.Owner, Access: state.ModelReadAccess})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetOwner(bob)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := st.ModelUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+}
+
+func (s *ModelSuite) TestSetOwnerNameClash(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	st1 := s.Factory.MakeModel(c, &factory.ModelParams{Name: "foo", Owner: bob})
+	defer st1.Close()
+	st2 := s.Factory.MakeModel(c, &factory.ModelParams{Name: "foo"})
+	defer st2.Close()
+
+	model, err := st2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetOwner(bob)
+	c.Assert(err, gc.ErrorMatches, `cannot change owner of model "foo": model "foo" for bob@local already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(model.Owner(), gc.Equals, s.Owner)
+}
+
+func (s *ModelSuite) TestSetOwnerUnknownUser(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetOwner(names.NewUserTag("nobody"))
+	c.Assert(err, gc.ErrorMatches, `cannot change owner of model ".*": user "nobody" not found`)
+}
+
+func (s *ModelSuite) TestSetOwnerControllerModel(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"}).UserTag()
+	err = model.SetOwner(bob)
+	c.Assert(err, gc.ErrorMatches, `cannot change owner of model ".*": controller model owner cannot be changed`)
+}
+
 func (s *ModelSuite) TestControllerModel(c *gc.C) {
 	env, err := s.State.ControllerModel()
 	c.Assert(err, jc.ErrorIsNil)
@@ -4,7 +4,12 @@
 package state
 
 import (
+	"reflect"
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/environs/config"
 )
@@ -16,7 +21,7 @@ func (st *State) ModelConfig() (*config.Config, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	defaultModelSettings, err := readSettings(st, controllersC, defaultModelSettingsGlobalKey)
+	inheritedAttrs, err := st.modelInheritedConfig()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -28,15 +33,28 @@ func (st *State) ModelConfig() (*config.Config, error) {
 	// settings attributes.
 	attrs := controllerSettings.Map()
 
-	// Merge in the cloud settings.
-	for k, v := range defaultModelSettings.Map() {
+	// Merge in the model defaults of the model's cloud region.
+	for k, v := range inheritedAttrs {
 		attrs[k] = v
 	}
 
-	// Finally, any model specific settings are added.
+	// Then any model specific settings are added.
 	for k, v := range modelSettings.Map() {
 		attrs[k] = v
 	}
+
+	// Finally, the attributes of the model's cloud credential, if it
+	// has one, override the rest, so rotating the credential updates
+	// the config of every model using it.
+	credential, ok, err := st.modelCloudCredential()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if ok {
+		for k, v := range credential.Attributes {
+			attrs[k] = v
+		}
+	}
 	return config.New(config.NoDefaults, attrs)
 }
 
@@ -134,15 +152,30 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 		}
 	}
 
-	// Remove any attributes that are the same as what's in cloud config.
-	//TODO(wallyworld) if/when cloud config becomes mutable, we must check for concurrent changes
-	// when writing config to ensure the validation we do here remains true
-	cloudAttrs, err := st.CloudConfig()
+	// Remove any attributes that are the same as what's inherited
+	// from the model defaults.
+	//TODO(wallyworld) we must check for concurrent changes to the model
+	// defaults when writing config to ensure the validation we do here
+	// remains true
+	inheritedAttrs, err := st.modelInheritedConfig()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	for attr, sharedValue := range cloudAttrs {
-		if newValue, ok := validAttrs[attr]; ok && newValue == sharedValue {
+	for attr, sharedValue := range inheritedAttrs {
+		if newValue, ok := validAttrs[attr]; ok && reflect.DeepEqual(newValue, sharedValue) {
+			delete(validAttrs, attr)
+			modelSettings.Delete(attr)
+		}
+	}
+
+	// Credential attributes are never written to the model settings;
+	// they're always read from the model's cloud credential.
+	credential, ok, err := st.modelCloudCredential()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ok {
+		for attr := range credential.Attributes {
 			delete(validAttrs, attr)
 			modelSettings.Delete(attr)
 		}
@@ -152,3 +185,285 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 	_, err = modelSettings.Write()
 	return errors.Trace(err)
 }
+
+// RegionSpec identifies a region of a cloud known to the controller.
+type RegionSpec struct {
+	// Cloud is the name of the cloud.
+	Cloud string
+
+	// Region is the name of the region.
+	Region string
+}
+
+// modelConfigSource holds a layer of config inherited by models,
+// labelled with its source.
+type modelConfigSource struct {
+	name  string
+	attrs map[string]interface{}
+}
+
+// inheritedConfigSources returns the layers of config inherited by
+// models in the given cloud region, from the least to the most
+// specific.
+func (st *State) inheritedConfigSources(cloudName, regionName string) ([]modelConfigSource, error) {
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerAttrs, err := st.CloudConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cloudName != info.CloudName {
+		// Models in other clouds inherit the config registered
+		// with their cloud on top of the controller-wide defaults.
+		c, err := st.Cloud(cloudName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for k, v := range c.Config {
+			controllerAttrs[k] = v
+		}
+	}
+	regionAttrs, err := st.regionModelDefaults(cloudName, regionName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []modelConfigSource{
+		{config.JujuDefaultSource, config.ConfigDefaults()},
+		{config.JujuControllerSource, controllerAttrs},
+		{config.JujuRegionSource, regionAttrs},
+	}, nil
+}
+
+// regionModelDefaults returns the model defaults for the given cloud
+// region.
+func (st *State) regionModelDefaults(cloudName, regionName string) (map[string]interface{}, error) {
+	if regionName == "" {
+		return map[string]interface{}{}, nil
+	}
+	settings, err := readSettings(st, controllersC, regionSettingsGlobalKey(cloudName, regionName))
+	if errors.IsNotFound(err) {
+		return map[string]interface{}{}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return settings.Map(), nil
+}
+
+// InheritedModelConfig returns the config inherited by models in the
+// given cloud region from the controller-wide model defaults and the
+// model defaults of the region. The Juju defaults are not included.
+func (st *State) InheritedModelConfig(cloudName, regionName string) (map[string]interface{}, error) {
+	sources, err := st.inheritedConfigSources(cloudName, regionName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs := make(map[string]interface{})
+	for _, source := range sources[1:] {
+		for k, v := range source.attrs {
+			attrs[k] = v
+		}
+	}
+	return attrs, nil
+}
+
+// modelInheritedConfig returns the config inherited by the model
+// represented by this state.
+func (st *State) modelInheritedConfig() (map[string]interface{}, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloudName, _, err := st.modelCloudName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.InheritedModelConfig(cloudName, model.CloudRegion())
+}
+
+// ModelConfigValues returns the config values of the model represented
+// by this state, each labelled with its source: the Juju defaults, the
+// controller-wide model defaults, the model defaults of the model's
+// cloud region, or the model itself.
+func (st *State) ModelConfigValues() (config.ConfigValues, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloudName, _, err := st.modelCloudName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sources, err := st.inheritedConfigSources(cloudName, model.CloudRegion())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(config.ConfigValues)
+	for attr, value := range cfg.AllAttrs() {
+		result[attr] = config.ConfigValue{
+			Value:  value,
+			Source: configValueSource(attr, value, sources),
+		}
+	}
+	return result, nil
+}
+
+// configValueSource returns the label of the source of a model config
+// value. The value comes from the most specific source that defines
+// the attribute, if it has the same value there; otherwise it has been
+// set on the model.
+func configValueSource(attr string, value interface{}, sources []modelConfigSource) string {
+	for i := len(sources) - 1; i >= 0; i-- {
+		if sourceValue, ok := sources[i].attrs[attr]; ok {
+			if reflect.DeepEqual(sourceValue, value) {
+				return sources[i].name
+			}
+			break
+		}
+	}
+	if controllerOnlyAttribute(attr) {
+		return config.JujuControllerSource
+	}
+	return config.JujuModelConfigSource
+}
+
+// ModelConfigDefaultValues returns the default values of the model
+// config attributes at each level at which they are defined: the Juju
+// defaults, the controller-wide model defaults, and the model defaults
+// of each cloud region.
+func (st *State) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
+	controllerAttrs, err := st.CloudConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(config.ModelDefaultAttributes)
+	for attr, value := range config.ConfigDefaults() {
+		result[attr] = config.AttributeDefaultValues{Default: value}
+	}
+	for attr, value := range controllerAttrs {
+		values := result[attr]
+		values.Controller = value
+		result[attr] = values
+	}
+
+	coll, closer := st.getCollection(controllersC)
+	defer closer()
+	prefix := defaultModelSettingsGlobalKey + "#"
+	var docs []settingsDoc
+	err = coll.Find(bson.D{{"_id", bson.D{{"$regex", "^" + prefix}}}}).Sort("_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read region model defaults")
+	}
+	for _, doc := range docs {
+		parts := strings.SplitN(strings.TrimPrefix(doc.DocID, prefix), "#", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		attrs := make([]string, 0, len(doc.Settings))
+		for attr := range doc.Settings {
+			attrs = append(attrs, attr)
+		}
+		sort.Strings(attrs)
+		for _, attr := range attrs {
+			values := result[attr]
+			values.Regions = append(values.Regions, config.RegionDefaultValue{
+				CloudName: parts[0],
+				Name:      parts[1],
+				Value:     doc.Settings[attr],
+			})
+			result[attr] = values
+		}
+	}
+	return result, nil
+}
+
+// checkModelDefaultAttribute returns an error if the attribute cannot
+// be set as a model default.
+func checkModelDefaultAttribute(attr string) error {
+	if controllerOnlyAttribute(attr) {
+		return errors.Errorf("cannot set controller attribute %q as a model default", attr)
+	}
+	switch attr {
+	case config.NameKey, config.UUIDKey, config.TypeKey, config.AgentVersionKey, config.AdminSecretKey:
+		return errors.Errorf("cannot set %q as a model default", attr)
+	}
+	return nil
+}
+
+// validateRegionSpec returns an error if the region is not a region
+// of a cloud known to the controller.
+func (st *State) validateRegionSpec(spec RegionSpec) error {
+	if spec.Region == "" {
+		return errors.NotValidf("empty region name")
+	}
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c, err := st.Cloud(spec.Cloud)
+	if errors.IsNotFound(err) && spec.Cloud == info.CloudName {
+		// The controller's own cloud need not be registered,
+		// in which case its regions are not known.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return validateCloudRegion(c, spec.Region)
+}
+
+// UpdateModelConfigDefaultValues adds, updates or removes the model
+// defaults of the given cloud region, or the controller-wide model
+// defaults if no region is specified. Models inherit the change for
+// the attributes they do not set themselves.
+func (st *State) UpdateModelConfigDefaultValues(updateAttrs map[string]interface{}, removeAttrs []string, region *RegionSpec) error {
+	if len(updateAttrs)+len(removeAttrs) == 0 {
+		return nil
+	}
+	for attr := range updateAttrs {
+		if err := checkModelDefaultAttribute(attr); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	key := defaultModelSettingsGlobalKey
+	if region != nil {
+		if err := st.validateRegionSpec(*region); err != nil {
+			return errors.Trace(err)
+		}
+		key = regionSettingsGlobalKey(region.Cloud, region.Region)
+	}
+
+	// Check the new values are valid, and coerce them to the
+	// types models use, by applying them to this model's config.
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	validCfg, err := cfg.Apply(updateAttrs)
+	if err != nil {
+		return errors.Annotate(err, "invalid model defaults")
+	}
+	validAttrs := validCfg.AllAttrs()
+	values := make(map[string]interface{})
+	for attr := range updateAttrs {
+		values[attr] = validAttrs[attr]
+	}
+
+	settings, err := readSettings(st, controllersC, key)
+	if errors.IsNotFound(err) && region != nil {
+		settings, err = createSettings(st, controllersC, key, nil)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, attr := range removeAttrs {
+		settings.Delete(attr)
+	}
+	settings.Update(values)
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
@@ -0,0 +1,54 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+type ModelConfigSourceSuite struct{}
+
+var _ = gc.Suite(&ModelConfigSourceSuite{})
+
+func (s *ModelConfigSourceSuite) TestConfigValueSource(c *gc.C) {
+	sources := []modelConfigSource{{
+		name: config.JujuDefaultSource,
+		attrs: map[string]interface{}{
+			"list":    []interface{}{"a", "b"},
+			"mapping": map[string]interface{}{"a": "b"},
+		},
+	}, {
+		name: "region",
+		attrs: map[string]interface{}{
+			"mapping": map[string]interface{}{"a": "c"},
+		},
+	}}
+	for i, test := range []struct {
+		attr   string
+		value  interface{}
+		source string
+	}{{
+		attr:   "list",
+		value:  []interface{}{"a", "b"},
+		source: config.JujuDefaultSource,
+	}, {
+		attr:   "list",
+		value:  []interface{}{"b"},
+		source: config.JujuModelConfigSource,
+	}, {
+		attr:   "mapping",
+		value:  map[string]interface{}{"a": "c"},
+		source: "region",
+	}, {
+		// The most specific source defining the attribute decides.
+		attr:   "mapping",
+		value:  map[string]interface{}{"a": "b"},
+		source: config.JujuModelConfigSource,
+	}} {
+		c.Logf("test %d: %s", i, test.attr)
+		c.Check(configValueSource(test.attr, test.value, sources), gc.Equals, test.source)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type ModelConfigSuite struct {
@@ -114,3 +115,164 @@ func (s *ModelConfigSuite) TestModelConfigInheritsCloudValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.AllAttrs()["apt-mirror"], gc.Equals, "http://mirror")
 }
+
+func (s *ModelConfigSuite) TestModelConfigInheritsRegionValue(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://mirror",
+		"http-proxy": "http://proxy",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://regionmirror",
+	}, nil, &state.RegionSpec{Cloud: "dummy", Region: "some-region"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["apt-mirror"], gc.Equals, "http://regionmirror")
+	c.Assert(cfg.AllAttrs()["http-proxy"], gc.Equals, "http://proxy")
+
+	// Defaults for other regions are not inherited.
+	err = s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"http-proxy": "http://otherproxy",
+	}, nil, &state.RegionSpec{Cloud: "dummy", Region: "other-region"})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["http-proxy"], gc.Equals, "http://proxy")
+}
+
+func (s *ModelConfigSuite) TestModelConfigValues(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror":  "http://mirror",
+		"http-proxy":  "http://proxy",
+		"https-proxy": "https://proxy",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"http-proxy": "http://regionproxy",
+	}, nil, &state.RegionSpec{Cloud: "dummy", Region: "some-region"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"https-proxy": "https://modelproxy",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.State.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["firewall-mode"], jc.DeepEquals, config.ConfigValue{
+		Value:  config.FwInstance,
+		Source: config.JujuDefaultSource,
+	})
+	c.Assert(values["api-port"].Source, gc.Equals, config.JujuControllerSource)
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.ConfigValue{
+		Value:  "http://mirror",
+		Source: config.JujuControllerSource,
+	})
+	c.Assert(values["http-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value:  "http://regionproxy",
+		Source: config.JujuRegionSource,
+	})
+	c.Assert(values["https-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value:  "https://modelproxy",
+		Source: config.JujuModelConfigSource,
+	})
+	c.Assert(values["name"], jc.DeepEquals, config.ConfigValue{
+		Value:  "testenv",
+		Source: config.JujuModelConfigSource,
+	})
+}
+
+func (s *ModelConfigSuite) TestModelConfigDefaultValues(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror":    "http://mirror",
+		"firewall-mode": config.FwGlobal,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://regionmirror",
+	}, nil, &state.RegionSpec{Cloud: "dummy", Region: "some-region"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defaults, err := s.State.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["firewall-mode"], jc.DeepEquals, config.AttributeDefaultValues{
+		Default:    config.FwInstance,
+		Controller: config.FwGlobal,
+	})
+	c.Assert(defaults["apt-mirror"], jc.DeepEquals, config.AttributeDefaultValues{
+		Controller: "http://mirror",
+		Regions: []config.RegionDefaultValue{{
+			CloudName: "dummy",
+			Name:      "some-region",
+			Value:     "http://regionmirror",
+		}},
+	})
+
+	// Removing a default leaves the others in place.
+	err = s.State.UpdateModelConfigDefaultValues(nil, []string{"apt-mirror"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defaults, err = s.State.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["apt-mirror"].Controller, gc.IsNil)
+	c.Assert(defaults["apt-mirror"].Regions, gc.HasLen, 1)
+	c.Assert(defaults["firewall-mode"].Controller, gc.Equals, config.FwGlobal)
+}
+
+func (s *ModelConfigSuite) TestUpdateModelConfigDefaultValuesCoercesValues(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"development": "true",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defaults, err := s.State.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(defaults["development"].Controller, gc.Equals, true)
+}
+
+func (s *ModelConfigSuite) TestUpdateModelConfigDefaultValuesInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs  map[string]interface{}
+		region *state.RegionSpec
+		err    string
+	}{{
+		attrs: map[string]interface{}{"api-port": 1234},
+		err:   `cannot set controller attribute "api-port" as a model default`,
+	}, {
+		attrs: map[string]interface{}{"name": "foo"},
+		err:   `cannot set "name" as a model default`,
+	}, {
+		attrs: map[string]interface{}{"firewall-mode": "foo"},
+		err:   `invalid model defaults: .*firewall-mode.*`,
+	}, {
+		attrs:  map[string]interface{}{"apt-mirror": "http://mirror"},
+		region: &state.RegionSpec{Cloud: "dummy"},
+		err:    `empty region name not valid`,
+	}, {
+		attrs:  map[string]interface{}{"apt-mirror": "http://mirror"},
+		region: &state.RegionSpec{Cloud: "missing", Region: "some-region"},
+		err:    `cloud "missing" not found`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.State.UpdateModelConfigDefaultValues(test.attrs, nil, test.region)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ModelConfigSuite) TestWatchForModelConfigChangesRegionDefaults(c *gc.C) {
+	w := s.State.WatchForModelConfigChanges()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://regionmirror",
+	}, nil, &state.RegionSpec{Cloud: "dummy", Region: "some-region"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://otherregionmirror",
+	}, nil, &state.RegionSpec{Cloud: "dummy", Region: "other-region"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
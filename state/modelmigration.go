@@ -367,6 +367,13 @@ func (st *State) CreateModelMigration(spec ModelMigrationSpec) (ModelMigration,
 		} else if isActive {
 			return nil, errors.New("already in progress")
 		}
+		// Secret values are encrypted with a key specific to this
+		// controller, so they cannot be carried to another one.
+		if hasSecrets, err := st.hasSecrets(); err != nil {
+			return nil, errors.Trace(err)
+		} else if hasSecrets {
+			return nil, errors.New("model has secrets, which cannot be migrated")
+		}
 
 		seq, err := st.sequence("modelmigration")
 		if err != nil {
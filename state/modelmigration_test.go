@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type ModelMigrationSuite struct {
@@ -204,6 +205,22 @@ func (s *ModelMigrationSuite) TestCreateMigrationWhenModelNotAlive(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "failed to create migration: model is not alive")
 }
 
+func (s *ModelMigrationSuite) TestCreateMigrationWithSecrets(c *gc.C) {
+	err := s.State2.SetSecretsKey(coretesting.SecretsKey)
+	c.Assert(err, jc.ErrorIsNil)
+	f := factory.NewFactory(s.State2)
+	unit := f.MakeUnit(c, nil)
+	_, err = s.State2.AddSecret(state.AddSecretArgs{
+		Owner: unit.Tag(),
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Check(mig, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "failed to create migration: model has secrets, which cannot be migrated")
+}
+
 func (s *ModelMigrationSuite) TestMigrationToSameController(c *gc.C) {
 	spec := s.stdSpec
 	spec.TargetInfo.ControllerTag = s.State.ModelTag()
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,6 +33,10 @@ type modelUserDoc struct {
 	CreatedBy   string      `bson:"createdby"`
 	DateCreated time.Time   `bson:"datecreated"`
 	Access      ModelAccess `bson:"access"`
+
+	// Group, if set, is the group of external users through which
+	// the user was granted access to the model.
+	Group string `bson:"group,omitempty"`
 }
 
 // ModelAccess represents the level of access granted to a user on a model.
@@ -109,7 +114,15 @@ func (e *ModelUser) Access() ModelAccess {
 	return e.doc.Access
 }
 
-// SetAccess changes the user's access permissions on the model.
+// Group returns the group of external users through which the user
+// was granted access to the model, or "" if the user was granted
+// access explicitly.
+func (e *ModelUser) Group() string {
+	return e.doc.Group
+}
+
+// SetAccess changes the user's access permissions on the model. Access
+// set explicitly is no longer managed through the user's groups.
 func (e *ModelUser) SetAccess(access ModelAccess) error {
 	switch access {
 	case ModelReadAccess, ModelAdminAccess:
@@ -120,7 +133,10 @@ func (e *ModelUser) SetAccess(access ModelAccess) error {
 		C:      modelUsersC,
 		Id:     e.st.docID(strings.ToLower(e.UserName())),
 		Assert: txn.DocExists,
-		Update: bson.D{{"$set", bson.D{{"access", access}}}},
+		Update: bson.D{
+			{"$set", bson.D{{"access", access}}},
+			{"$unset", bson.D{{"group", nil}}},
+		},
 	}
 	err := e.st.runTransaction([]txn.Op{op})
 	return errors.Trace(err)
@@ -251,6 +267,108 @@ func (st *State) AddModelUser(spec ModelUserSpec) (*ModelUser, error) {
 	return st.ModelUser(spec.User)
 }
 
+// SyncGroupModelUser reconciles an external user's access to the model
+// with the access granted to the user's groups by the model's
+// identity-group-access config, using the groups recorded when the
+// user last logged in with their identity provider. Access granted
+// through a group is recorded as a model user, which is updated or
+// removed as the user's groups or the model's group access change;
+// model users added explicitly are left alone.
+//
+// It returns the user's model user, or a NotFound error if the user
+// has no access to the model.
+func (st *State) SyncGroupModelUser(user names.UserTag) (*ModelUser, error) {
+	existing, err := st.ModelUser(user)
+	if err == nil && (user.IsLocal() || existing.Group() == "") {
+		return existing, nil
+	} else if err != nil && (user.IsLocal() || !errors.IsNotFound(err)) {
+		return nil, errors.Trace(err)
+	}
+
+	var displayName string
+	var groups []string
+	externalUser, err := st.ExternalUser(user)
+	if err == nil {
+		displayName = externalUser.DisplayName()
+		groups = externalUser.Groups()
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	group, access := groupModelAccess(cfg.IdentityGroupAccess(), groups)
+
+	var ops []txn.Op
+	switch {
+	case existing == nil && access == ModelUndefinedAccess:
+		return nil, errors.NotFoundf("model user %q", user.Canonical())
+	case existing == nil:
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		op := createModelUserOp(
+			st.ModelUUID(), user, model.Owner(), displayName, nowToTheSecond(), access,
+		)
+		op.Insert.(*modelUserDoc).Group = group
+		ops = append(ops, op)
+	case access == ModelUndefinedAccess:
+		ops = append(ops, txn.Op{
+			C:      modelUsersC,
+			Id:     modelUserID(user),
+			Assert: bson.D{{"group", existing.Group()}},
+			Remove: true,
+		})
+	case access != existing.Access() || group != existing.Group():
+		ops = append(ops, txn.Op{
+			C:      modelUsersC,
+			Id:     modelUserID(user),
+			Assert: bson.D{{"group", existing.Group()}},
+			Update: bson.D{{"$set", bson.D{
+				{"access", access},
+				{"group", group},
+			}}},
+		})
+	default:
+		return existing, nil
+	}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		// The model user was changed concurrently; the
+		// next login will try again.
+		return nil, errors.Errorf("cannot sync model access for %q: model user changed", user.Canonical())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot sync model access for %q", user.Canonical())
+	}
+	return st.ModelUser(user)
+}
+
+// groupModelAccess returns the highest level of model access granted
+// to any of the given groups, and the group granting it. Ties are
+// broken in favour of the first group in sorted order.
+func groupModelAccess(groupAccess map[string]string, groups []string) (string, ModelAccess) {
+	sorted := append([]string(nil), groups...)
+	sort.Strings(sorted)
+	var bestGroup string
+	bestAccess := ModelUndefinedAccess
+	for _, group := range sorted {
+		var access ModelAccess
+		switch groupAccess[group] {
+		case "read":
+			access = ModelReadAccess
+		case "write":
+			access = ModelAdminAccess
+		default:
+			continue
+		}
+		if bestAccess == ModelUndefinedAccess || (bestAccess == ModelReadAccess && access == ModelAdminAccess) {
+			bestGroup, bestAccess = group, access
+		}
+	}
+	return bestGroup, bestAccess
+}
+
 // modelUserID returns the document id of the model user
 func modelUserID(user names.UserTag) string {
 	username := user.Canonical()
@@ -4,10 +4,13 @@
 package state
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/network"
@@ -109,3 +112,94 @@ func (st *State) SetUpgradeMongoMode(v mongo.Version) (UpgradeMongoParams, error
 func (st *State) ResumeReplication(members []replicaset.Member) error {
 	return replicaset.Add(st.session, members...)
 }
+
+// replicaSetMachineKey is the replica set member tag holding the id of
+// the juju machine hosting the member. It must match the key used by
+// the peergrouper worker.
+const replicaSetMachineKey = "juju-machine-id"
+
+// ReplicaSetMemberStatus describes the health of one member of the
+// controller's MongoDB replica set.
+type ReplicaSetMemberStatus struct {
+	// MachineId holds the id of the controller machine hosting the
+	// member, or "" if the member was not added by juju.
+	MachineId string
+	Address   string
+
+	// State holds the member's replica set state, for example
+	// PRIMARY or SECONDARY.
+	State   string
+	Healthy bool
+	Votes   int
+
+	// Lag holds how far the member's oplog is behind the primary's.
+	// It is zero for the primary itself, and when there is no primary.
+	Lag time.Duration
+}
+
+// replicaSetStatus holds the parts of the replSetGetStatus command
+// result that are of interest to ReplicaSetStatus.
+type replicaSetStatus struct {
+	Members []replicaSetStatusMember `bson:"members"`
+}
+
+type replicaSetStatusMember struct {
+	Id         int       `bson:"_id"`
+	Address    string    `bson:"name"`
+	Health     float64   `bson:"health"`
+	State      string    `bson:"stateStr"`
+	OptimeDate time.Time `bson:"optimeDate"`
+}
+
+// ReplicaSetStatus returns the health of each member of the
+// controller's MongoDB replica set.
+func (st *State) ReplicaSetStatus() ([]ReplicaSetMemberStatus, error) {
+	members, err := replicaset.CurrentMembers(st.session)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot obtain current replicaset members")
+	}
+	var status replicaSetStatus
+	session := st.session.Copy()
+	defer session.Close()
+	err = session.DB("admin").Run(bson.D{{"replSetGetStatus", 1}}, &status)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot obtain replicaset status")
+	}
+	return replicaSetMemberStatuses(members, status), nil
+}
+
+// replicaSetMemberStatuses combines the replica set configuration with
+// its reported status.
+func replicaSetMemberStatuses(members []replicaset.Member, status replicaSetStatus) []ReplicaSetMemberStatus {
+	var primaryOptime time.Time
+	for _, m := range status.Members {
+		if m.State == "PRIMARY" {
+			primaryOptime = m.OptimeDate
+		}
+	}
+	byId := make(map[int]replicaset.Member)
+	for _, m := range members {
+		byId[m.Id] = m
+	}
+	result := make([]ReplicaSetMemberStatus, len(status.Members))
+	for i, m := range status.Members {
+		votes := 1
+		config := byId[m.Id]
+		if config.Votes != nil {
+			votes = *config.Votes
+		}
+		var lag time.Duration
+		if !primaryOptime.IsZero() && m.OptimeDate.Before(primaryOptime) {
+			lag = primaryOptime.Sub(m.OptimeDate)
+		}
+		result[i] = ReplicaSetMemberStatus{
+			MachineId: config.Tags[replicaSetMachineKey],
+			Address:   m.Address,
+			State:     m.State,
+			Healthy:   m.Health == 1,
+			Votes:     votes,
+			Lag:       lag,
+		}
+	}
+	return result
+}
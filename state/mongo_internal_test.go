@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/replicaset"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+type replicaSetStatusSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&replicaSetStatusSuite{})
+
+func (s *replicaSetStatusSuite) TestReplicaSetMemberStatuses(c *gc.C) {
+	noVote := 0
+	members := []replicaset.Member{{
+		Id:      1,
+		Address: "10.0.0.1:37017",
+		Tags:    map[string]string{replicaSetMachineKey: "0"},
+	}, {
+		Id:      2,
+		Address: "10.0.0.2:37017",
+		Tags:    map[string]string{replicaSetMachineKey: "1"},
+	}, {
+		Id:      3,
+		Address: "10.0.0.3:37017",
+		Votes:   &noVote,
+	}}
+	now := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	status := replicaSetStatus{
+		Members: []replicaSetStatusMember{{
+			Id:         1,
+			Address:    "10.0.0.1:37017",
+			Health:     1,
+			State:      "PRIMARY",
+			OptimeDate: now,
+		}, {
+			Id:         2,
+			Address:    "10.0.0.2:37017",
+			Health:     1,
+			State:      "SECONDARY",
+			OptimeDate: now.Add(-3 * time.Second),
+		}, {
+			Id:      3,
+			Address: "10.0.0.3:37017",
+			Health:  0,
+			State:   "(not reachable/healthy)",
+		}},
+	}
+	c.Assert(replicaSetMemberStatuses(members, status), jc.DeepEquals, []ReplicaSetMemberStatus{{
+		MachineId: "0",
+		Address:   "10.0.0.1:37017",
+		State:     "PRIMARY",
+		Healthy:   true,
+		Votes:     1,
+	}, {
+		MachineId: "1",
+		Address:   "10.0.0.2:37017",
+		State:     "SECONDARY",
+		Healthy:   true,
+		Votes:     1,
+		Lag:       3 * time.Second,
+	}, {
+		Address: "10.0.0.3:37017",
+		State:   "(not reachable/healthy)",
+		Votes:   0,
+	}})
+}
+
+func (s *replicaSetStatusSuite) TestReplicaSetMemberStatusesNoPrimary(c *gc.C) {
+	status := replicaSetStatus{
+		Members: []replicaSetStatusMember{{
+			Id:         1,
+			Address:    "10.0.0.1:37017",
+			Health:     1,
+			State:      "SECONDARY",
+			OptimeDate: time.Now(),
+		}},
+	}
+	c.Assert(replicaSetMemberStatuses(nil, status), jc.DeepEquals, []ReplicaSetMemberStatus{{
+		Address: "10.0.0.1:37017",
+		State:   "SECONDARY",
+		Healthy: true,
+		Votes:   1,
+	}})
+}
@@ -203,8 +203,9 @@ type UnitInfo struct {
 	PortRanges     []network.PortRange
 	Subordinate    bool
 	// Workload and agent state are modelled separately.
-	WorkloadStatus StatusInfo
-	JujuStatus     StatusInfo
+	WorkloadStatus  StatusInfo
+	JujuStatus      StatusInfo
+	WorkloadVersion string
 }
 
 // EntityId returns a unique identifier for a unit across
@@ -137,7 +137,7 @@ func Initialize(owner names.UserTag, info *mongo.MongoInfo, cloudName, cloudRegi
 
 	logger.Infof("initializing controller model %s", uuid)
 
-	modelOps, err := st.modelSetupOps(cfg, cloudRegion, cloudCfg, owner, MigrationModeActive)
+	modelOps, err := st.modelSetupOps(cfg, cloudName, cloudRegion, nil, cloudCfg, owner, MigrationModeActive)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -192,7 +192,9 @@ func Initialize(owner names.UserTag, info *mongo.MongoInfo, cloudName, cloudRegi
 }
 
 // modelSetupOps returns the transactions necessary to set up a model.
-func (st *State) modelSetupOps(cfg *config.Config, cloudRegion string, cloudCfg map[string]interface{}, owner names.UserTag, mode MigrationMode) ([]txn.Op, error) {
+// The attributes of the model's cloud credential, if it has one, are
+// not written to the model's settings.
+func (st *State) modelSetupOps(cfg *config.Config, cloudName, cloudRegion string, credential *CloudCredential, cloudCfg map[string]interface{}, owner names.UserTag, mode MigrationMode) ([]txn.Op, error) {
 	if err := checkCloudConfig(cloudCfg); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -226,10 +228,20 @@ func (st *State) modelSetupOps(cfg *config.Config, cloudRegion string, cloudCfg
 	}
 
 	modelCfg := modelConfig(cloudCfg, cfg.AllAttrs())
+	var cloudCredential string
+	if credential != nil {
+		// Credential attributes are never written to the model
+		// settings; they're always read from the model's cloud
+		// credential.
+		for attr := range credential.Attributes {
+			delete(modelCfg, attr)
+		}
+		cloudCredential = credential.Name
+	}
 	ops = append(ops,
 		createSettingsOp(settingsC, modelGlobalKey, modelCfg),
 		createModelEntityRefsOp(st, modelUUID),
-		createModelOp(st, owner, cfg.Name(), modelUUID, controllerUUID, cloudRegion, mode),
+		createModelOp(st, owner, cfg.Name(), modelUUID, controllerUUID, cloudName, cloudRegion, cloudCredential, mode),
 		createUniqueOwnerModelNameOp(owner, cfg.Name()),
 		modelUserOp,
 	)
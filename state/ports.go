@@ -5,11 +5,13 @@ package state
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 
 	"github.com/juju/errors"
 	statetxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -406,6 +408,99 @@ func (m *Machine) AllPorts() ([]*Ports, error) {
 	return results, nil
 }
 
+// ExposedPortRanges returns the sorted port ranges opened on this
+// machine (on all networks) by units of exposed applications. These
+// are the ranges which must be reachable from outside the model.
+func (m *Machine) ExposedPortRanges() ([]network.PortRange, error) {
+	allPorts, err := m.AllPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	exposed := make(map[string]bool)
+	seen := make(map[network.PortRange]bool)
+	var result []network.PortRange
+	for _, ports := range allPorts {
+		for portRange, unitName := range ports.AllPortRanges() {
+			applicationName, err := names.UnitApplication(unitName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			isExposed, ok := exposed[applicationName]
+			if !ok {
+				application, err := m.st.Application(applicationName)
+				if err != nil && !errors.IsNotFound(err) {
+					return nil, errors.Trace(err)
+				}
+				isExposed = err == nil && application.IsExposed()
+				exposed[applicationName] = isExposed
+			}
+			if !isExposed || seen[portRange] {
+				continue
+			}
+			seen[portRange] = true
+			result = append(result, portRange)
+		}
+	}
+	network.SortPortRanges(result)
+	return result, nil
+}
+
+// ModelIngressCIDRs returns the sorted CIDRs from which the machines of
+// the model must always be reachable, whatever is exposed: the address
+// of each machine, and the subnet of each of its link-layer addresses.
+// Loopback addresses are skipped.
+func (st *State) ModelIngressCIDRs() ([]string, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := set.NewStrings()
+	for _, machine := range machines {
+		for _, addr := range machine.Addresses() {
+			if cidr := hostCIDR(addr.Value); cidr != "" {
+				cidrs.Add(cidr)
+			}
+		}
+		addresses, err := machine.AllAddresses()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, addr := range addresses {
+			if addr.ConfigMethod() == LoopbackAddress {
+				continue
+			}
+			if cidr := hostCIDR(addr.Value()); cidr != "" {
+				cidrs.Add(cidr)
+			}
+			_, subnet, err := net.ParseCIDR(addr.SubnetCIDR())
+			if err == nil && !subnet.IP.IsLoopback() {
+				cidrs.Add(subnet.String())
+			}
+		}
+	}
+	return cidrs.SortedValues(), nil
+}
+
+// hostCIDR returns the single host CIDR of the given IP address, or
+// an empty string if it's not a valid non-loopback address.
+func hostCIDR(value string) string {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		var err error
+		if ip, _, err = net.ParseCIDR(value); err != nil {
+			return ""
+		}
+	}
+	if ip.IsLoopback() {
+		return ""
+	}
+	bits := 128
+	if ip.To4() != nil {
+		ip, bits = ip.To4(), 32
+	}
+	return (&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}).String()
+}
+
 // addPortsDocOps returns the ops for adding a number of port ranges
 // to a new ports document. portsAssert allows specifying an assert
 // statement for on the openedPorts collection op.
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/network"
@@ -737,3 +738,120 @@ func (p *PortRangeSuite) TestSanitizeBounds(c *gc.C) {
 		c.Check(t.input.SanitizeBounds(), jc.DeepEquals, t.output)
 	}
 }
+
+func (s *PortsDocSuite) TestExposedPortRanges(c *gc.C) {
+	err := s.portsOnSubnet.OpenPorts(state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "TCP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.portsWithoutSubnet.OpenPorts(state.PortRange{
+		FromPort: 80,
+		ToPort:   80,
+		UnitName: s.unit2.Name(),
+		Protocol: "TCP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ranges, err := s.machine.ExposedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ranges, gc.HasLen, 0)
+
+	err = s.service.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	ranges, err = s.machine.ExposedPortRanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ranges, jc.DeepEquals, []network.PortRange{
+		{80, 80, "TCP"},
+		{100, 200, "TCP"},
+	})
+}
+
+func (s *PortsDocSuite) TestWatchExposedPorts(c *gc.C) {
+	w := s.machine.WatchExposedPorts()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.portsWithoutSubnet.OpenPorts(state.PortRange{
+		FromPort: 80,
+		ToPort:   80,
+		UnitName: s.unit1.Name(),
+		Protocol: "TCP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.service.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Ports opened on other machines are ignored.
+	f := factory.NewFactory(s.State)
+	machine := f.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	unit := f.MakeUnit(c, &factory.UnitParams{Application: s.service, Machine: machine})
+	wc.AssertOneChange() // the application's unit count changed
+	ports, err := state.GetOrCreatePorts(s.State, machine.Id(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ports.OpenPorts(state.PortRange{
+		FromPort: 443,
+		ToPort:   443,
+		UnitName: unit.Name(),
+		Protocol: "TCP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *PortsDocSuite) TestModelIngressCIDRs(c *gc.C) {
+	err := s.machine.SetProviderAddresses(
+		network.NewAddress("10.0.0.5"),
+		network.NewAddress("127.0.0.1"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		CIDRAddress:  "192.168.1.10/24",
+		ConfigMethod: state.StaticAddress,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.State.ModelIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{
+		"10.0.0.5/32",
+		"192.168.1.0/24",
+		"192.168.1.10/32",
+	})
+}
+
+func (s *PortsDocSuite) TestWatchModelIngressCIDRs(c *gc.C) {
+	w := s.State.WatchModelIngressCIDRs()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.machine.SetProviderAddresses(network.NewAddress("10.0.0.5"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Machine changes which don't affect the CIDRs are ignored.
+	err = s.machine.SetAgentVersion(version.MustParseBinary("2.0.1-quantal-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	f := factory.NewFactory(s.State)
+	machine := f.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	err = machine.SetProviderAddresses(network.NewAddress("10.0.0.6"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -224,7 +224,12 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 		})
 	}
 	cleanupOp := r.st.newCleanupOp(cleanupRelationSettings, fmt.Sprintf("r#%d#", r.Id()))
-	return append(ops, cleanupOp), nil
+	ops = append(ops, cleanupOp)
+	ops = append(ops, r.st.secretsCleanupOps(
+		bson.D{{"grants.relation", r.doc.Key}},
+		cleanupSecretGrantsForRelation, r.doc.Key,
+	)...)
+	return ops, nil
 }
 
 // Id returns the integer internal relation key. This is exposed
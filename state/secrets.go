@@ -0,0 +1,574 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/leadership"
+)
+
+// secretsKeySize is the size in bytes of the key used to encrypt
+// secret values.
+const secretsKeySize = 32
+
+// GenerateSecretsKey generates a random key, suitable for passing to
+// SetSecretsKey, for encrypting the values of secrets. It is created
+// at bootstrap and held only in the configuration of the controller
+// agents.
+func GenerateSecretsKey() (string, error) {
+	buf := make([]byte, secretsKeySize)
+	if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+		return "", errors.Annotate(err, "cannot generate secrets key")
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// SetSecretsKey sets the base64 encoded key used to encrypt the values
+// of secrets. The key is never written to the database, so that the
+// contents of the secrets collection are of no use on their own. It
+// must be set before the State is used, and is shared with the States
+// returned by ForModel.
+func (st *State) SetSecretsKey(key string) error {
+	if key == "" {
+		return errors.NotValidf("empty secrets key")
+	}
+	data, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return errors.NotValidf("secrets key")
+	}
+	if len(data) != secretsKeySize {
+		return errors.NotValidf("secrets key of %d bytes", len(data))
+	}
+	st.secretsKey = data
+	return nil
+}
+
+// SecretsKey returns the base64 encoded key used to encrypt the values
+// of secrets, or "" if it has not been set.
+func (st *State) SecretsKey() string {
+	if len(st.secretsKey) == 0 {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(st.secretsKey)
+}
+
+// secretDoc represents a charm secret: a set of key/value pairs owned
+// by an application or a unit, encrypted at rest, that may be shared
+// with the applications at the other end of the owner's relations.
+type secretDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Owner     string `bson:"owner"`
+	Label     string `bson:"label,omitempty"`
+
+	// Revision is incremented each time the secret's value changes.
+	Revision int `bson:"revision"`
+
+	// Data holds the nonce-prefixed, encrypted, JSON encoded value.
+	Data []byte `bson:"data"`
+
+	Created time.Time        `bson:"created"`
+	Updated time.Time        `bson:"updated"`
+	Grants  []secretGrantDoc `bson:"grants"`
+}
+
+// secretGrantDoc records that the units of an application may read a
+// secret, by virtue of being related to its owner.
+type secretGrantDoc struct {
+	Relation    string `bson:"relation"`
+	Application string `bson:"application"`
+}
+
+// SecretGrant records that the units of an application may read a
+// secret by virtue of a relation to the secret's owner.
+type SecretGrant struct {
+	// RelationKey identifies the relation through which access was
+	// granted.
+	RelationKey string
+
+	// Application is the name of the application granted access.
+	Application string
+}
+
+// Secret represents a charm secret.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+// Id returns the secret's id.
+func (s *Secret) Id() string {
+	return s.st.localID(s.doc.DocID)
+}
+
+// Owner returns the tag of the application or unit owning the secret.
+func (s *Secret) Owner() (names.Tag, error) {
+	return names.ParseTag(s.doc.Owner)
+}
+
+// Label returns the label the owner gave the secret, if any.
+func (s *Secret) Label() string {
+	return s.doc.Label
+}
+
+// Revision returns the revision of the secret's value; it starts at 1
+// and is incremented each time the value is rotated.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// Created returns the time the secret was added.
+func (s *Secret) Created() time.Time {
+	return s.doc.Created
+}
+
+// Updated returns the time the secret's value last changed.
+func (s *Secret) Updated() time.Time {
+	return s.doc.Updated
+}
+
+// Grants returns the applications that have been granted access to
+// the secret.
+func (s *Secret) Grants() []SecretGrant {
+	grants := make([]SecretGrant, len(s.doc.Grants))
+	for i, g := range s.doc.Grants {
+		grants[i] = SecretGrant{
+			RelationKey: g.Relation,
+			Application: g.Application,
+		}
+	}
+	return grants
+}
+
+// OwnedBy reports whether the secret is owned by the given unit, or
+// by the application the unit belongs to.
+func (s *Secret) OwnedBy(unit names.UnitTag) bool {
+	if s.doc.Owner == unit.String() {
+		return true
+	}
+	applicationName, err := names.UnitApplication(unit.Id())
+	if err != nil {
+		return false
+	}
+	return s.doc.Owner == names.NewApplicationTag(applicationName).String()
+}
+
+// CanRead reports whether the given unit may read the secret's value:
+// either it owns the secret, or its application has been granted
+// access to it.
+func (s *Secret) CanRead(unit names.UnitTag) bool {
+	if s.OwnedBy(unit) {
+		return true
+	}
+	applicationName, err := names.UnitApplication(unit.Id())
+	if err != nil {
+		return false
+	}
+	for _, g := range s.doc.Grants {
+		if g.Application == applicationName {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the decrypted value of the secret.
+func (s *Secret) Value() (map[string]string, error) {
+	key, err := s.st.getSecretsKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err := decryptSecretValue(key, s.Id(), s.doc.Data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot decrypt secret %q", s.Id())
+	}
+	return value, nil
+}
+
+// AddSecretArgs holds the arguments to State.AddSecret.
+type AddSecretArgs struct {
+	// Owner is the application or unit that owns the secret.
+	Owner names.Tag
+
+	// Label optionally names the secret. Adding a secret with the
+	// label of one its owner already has rotates that secret's value
+	// instead of adding a new one.
+	Label string
+
+	// Data holds the secret's value.
+	Data map[string]string
+
+	// Token must be supplied for secrets owned by an application; it
+	// ensures the secret is only changed by the application's leader.
+	Token leadership.Token
+}
+
+// AddSecret adds a new secret, or rotates the value of the secret with
+// the same owner and label, and returns the secret's id.
+func (st *State) AddSecret(args AddSecretArgs) (string, error) {
+	owner, err := validateSecretOwner(args.Owner, args.Token)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(args.Data) == 0 {
+		return "", errors.NotValidf("empty secret value")
+	}
+	key, err := st.getSecretsKey()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	var id string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		now := nowToTheSecond()
+		if args.Label != "" {
+			existing, err := st.secretByLabel(owner, args.Label)
+			if err == nil {
+				id = existing.Id()
+				data, err := encryptSecretValue(key, id, args.Data)
+				if err != nil {
+					return nil, errors.Annotate(err, "cannot encrypt secret value")
+				}
+				return []txn.Op{{
+					C:      secretsC,
+					Id:     existing.doc.DocID,
+					Assert: bson.D{{"revision", existing.doc.Revision}},
+					Update: bson.D{{"$set", bson.D{
+						{"data", data},
+						{"updated", now},
+						{"revision", existing.doc.Revision + 1},
+					}}},
+				}}, nil
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		uuid, err := utils.NewUUID()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		id = uuid.String()
+		data, err := encryptSecretValue(key, id, args.Data)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot encrypt secret value")
+		}
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     st.docID(id),
+			Assert: txn.DocMissing,
+			Insert: &secretDoc{
+				DocID:     st.docID(id),
+				ModelUUID: st.ModelUUID(),
+				Owner:     owner,
+				Label:     args.Label,
+				Revision:  1,
+				Data:      data,
+				Created:   now,
+				Updated:   now,
+				Grants:    []secretGrantDoc{},
+			},
+		}}, nil
+	}
+	if args.Token != nil {
+		buildTxn = buildTxnWithLeadership(buildTxn, args.Token)
+	}
+	if err := st.run(buildTxn); err != nil {
+		return "", errors.Annotate(err, "cannot add secret")
+	}
+	return id, nil
+}
+
+// Secret returns the secret with the given id.
+func (st *State) Secret(id string) (*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var doc secretDoc
+	err := secrets.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// AllSecrets returns all the secrets in the model.
+func (st *State) AllSecrets() ([]*Secret, error) {
+	return st.findSecrets(nil)
+}
+
+// hasSecrets reports whether there are any secrets in the model.
+func (st *State) hasSecrets() (bool, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	n, err := secrets.Count()
+	if err != nil {
+		return false, errors.Annotate(err, "cannot count secrets")
+	}
+	return n > 0, nil
+}
+
+func (st *State) secretByLabel(owner, label string) (*Secret, error) {
+	secrets, err := st.findSecrets(bson.D{{"owner", owner}, {"label", label}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(secrets) == 0 {
+		return nil, errors.NotFoundf("secret %q", label)
+	}
+	return secrets[0], nil
+}
+
+func (st *State) findSecrets(query bson.D) ([]*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	if err := secrets.Find(query).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	results := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		results[i] = &Secret{st: st, doc: doc}
+	}
+	return results, nil
+}
+
+// GrantSecret grants the units of the named application, related to
+// the secret's owner by the given relation, access to the secret.
+// The token must be supplied if the secret is owned by an application.
+func (st *State) GrantSecret(id string, relation *Relation, application string, token leadership.Token) error {
+	if _, err := relation.Endpoint(application); err != nil {
+		return errors.Annotatef(err, "cannot grant secret %q", id)
+	}
+	grant := secretGrantDoc{
+		Relation:    relation.String(),
+		Application: application,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		secret, err := st.Secret(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, g := range secret.doc.Grants {
+			if g == grant {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		return []txn.Op{{
+			C:      relationsC,
+			Id:     relation.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      secretsC,
+			Id:     secret.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$push", bson.D{{"grants", grant}}}},
+		}}, nil
+	}
+	if token != nil {
+		buildTxn = buildTxnWithLeadership(buildTxn, token)
+	}
+	return errors.Annotatef(st.run(buildTxn), "cannot grant secret %q", id)
+}
+
+// RevokeSecret revokes the access to the secret previously granted to
+// the named application over the given relation. The token must be
+// supplied if the secret is owned by an application.
+func (st *State) RevokeSecret(id string, relation *Relation, application string, token leadership.Token) error {
+	grant := secretGrantDoc{
+		Relation:    relation.String(),
+		Application: application,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		secret, err := st.Secret(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, g := range secret.doc.Grants {
+			if g == grant {
+				return []txn.Op{{
+					C:      secretsC,
+					Id:     secret.doc.DocID,
+					Assert: txn.DocExists,
+					Update: bson.D{{"$pull", bson.D{{"grants", grant}}}},
+				}}, nil
+			}
+		}
+		return nil, jujutxn.ErrNoOperations
+	}
+	if token != nil {
+		buildTxn = buildTxnWithLeadership(buildTxn, token)
+	}
+	return errors.Annotatef(st.run(buildTxn), "cannot revoke secret %q", id)
+}
+
+// SecretRevisions returns the current revision of each secret the unit
+// has been granted access to, keyed by secret id. Secrets the unit
+// owns are not included.
+func (u *Unit) SecretRevisions() (map[string]int, error) {
+	secrets, err := u.st.findSecrets(bson.D{{"grants.application", u.ApplicationName()}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	revisions := make(map[string]int)
+	for _, secret := range secrets {
+		if secret.OwnedBy(u.UnitTag()) {
+			continue
+		}
+		revisions[secret.Id()] = secret.Revision()
+	}
+	return revisions, nil
+}
+
+// removeOwnedSecrets removes all the secrets owned by the given
+// application or unit.
+func (st *State) removeOwnedSecrets(owner names.Tag) error {
+	secrets, err := st.findSecrets(bson.D{{"owner", owner.String()}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, secret := range secrets {
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     secret.doc.DocID,
+			Remove: true,
+		})
+	}
+	return errors.Trace(st.runSecretOps(ops))
+}
+
+// removeSecretGrants removes any access to secrets granted over the
+// relation with the given key, or to the given application when
+// relationKey is empty.
+func (st *State) removeSecretGrants(relationKey, application string) error {
+	query := bson.D{{"relation", relationKey}}
+	if relationKey == "" {
+		query = bson.D{{"application", application}}
+	}
+	secrets, err := st.findSecrets(bson.D{{"grants", bson.D{{"$elemMatch", query}}}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, secret := range secrets {
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     secret.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$pull", bson.D{{"grants", query}}}},
+		})
+	}
+	return errors.Trace(st.runSecretOps(ops))
+}
+
+// secretsCleanupOps returns an op scheduling a cleanup of the given
+// kind if any secrets match the query, so that removing entities with
+// no secrets involved leaves nothing to clean up.
+func (st *State) secretsCleanupOps(query bson.D, kind cleanupKind, prefix string) []txn.Op {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+	if n, err := secrets.Find(query).Count(); err == nil && n == 0 {
+		return nil
+	}
+	return []txn.Op{st.newCleanupOp(kind, prefix)}
+}
+
+// runSecretOps runs the given ops, if any, ignoring secrets that have
+// been removed in the meantime.
+func (st *State) runSecretOps(ops []txn.Op) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := st.runTransaction(ops); err != nil && err != txn.ErrAborted {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func validateSecretOwner(owner names.Tag, token leadership.Token) (string, error) {
+	switch owner.(type) {
+	case names.ApplicationTag:
+		if token == nil {
+			return "", errors.NotValidf("application owned secret without leadership token")
+		}
+	case names.UnitTag:
+	default:
+		return "", errors.NotValidf("secret owner %q", owner)
+	}
+	return owner.String(), nil
+}
+
+// getSecretsKey returns the key for encrypting secret values.
+func (st *State) getSecretsKey() ([]byte, error) {
+	if len(st.secretsKey) == 0 {
+		return nil, errors.New("secrets key not set")
+	}
+	return st.secretsKey, nil
+}
+
+// encryptSecretValue encrypts the value of the secret with the given
+// id. The id is authenticated along with the value, so that the data
+// of one secret cannot be passed off as that of another.
+func encryptSecretValue(key []byte, id string, value map[string]string) ([]byte, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, []byte(id)), nil
+}
+
+func decryptSecretValue(key []byte, id string, data []byte) (map[string]string, error) {
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("secret data too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var value map[string]string
+	if err := json.Unmarshal(plaintext, &value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return value, nil
+}
+
+func newSecretsCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,321 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	wordpress *state.Application
+	mysql     *state.Application
+	wpUnit    *state.Unit
+	dbUnit    *state.Unit
+	relation  *state.Relation
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	err := s.State.SetSecretsKey(testing.SecretsKey)
+	c.Assert(err, jc.ErrorIsNil)
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.wpUnit, err = s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.dbUnit, err = s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) addSecret(c *gc.C, label string, data map[string]string) string {
+	id, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.mysql.Tag(),
+		Label: label,
+		Data:  data,
+		Token: &fakeToken{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return id
+}
+
+func (s *SecretsSuite) TestAddSecret(c *gc.C) {
+	id := s.addSecret(c, "root", map[string]string{"password": "sekrit"})
+
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Id(), gc.Equals, id)
+	owner, err := secret.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(owner, gc.Equals, s.mysql.Tag())
+	c.Check(secret.Label(), gc.Equals, "root")
+	c.Check(secret.Revision(), gc.Equals, 1)
+	c.Check(secret.Created().IsZero(), jc.IsFalse)
+	c.Check(secret.Grants(), gc.HasLen, 0)
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *SecretsSuite) TestAddSecretEncryptedAtRest(c *gc.C) {
+	id := s.addSecret(c, "", map[string]string{"password": "sekrit"})
+
+	secrets, closer := state.GetRawCollection(s.State, "secrets")
+	defer closer()
+	var doc bson.M
+	err := secrets.Find(bson.D{{"_id", s.State.ModelUUID() + ":" + id}}).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	data, ok := doc["data"].([]byte)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(bytes.Contains(data, []byte("sekrit")), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestSecretDataBoundToId(c *gc.C) {
+	id := s.addSecret(c, "", map[string]string{"password": "sekrit"})
+	other := s.addSecret(c, "", map[string]string{"password": "other"})
+
+	// Copying the data of one secret into another must not make the
+	// other secret's value readable.
+	secrets, closer := state.GetRawCollection(s.State, "secrets")
+	defer closer()
+	var doc bson.M
+	err := secrets.Find(bson.D{{"_id", s.State.ModelUUID() + ":" + id}}).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	err = secrets.UpdateId(
+		s.State.ModelUUID()+":"+other,
+		bson.D{{"$set", bson.D{{"data", doc["data"]}}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.State.Secret(other)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = secret.Value()
+	c.Assert(err, gc.ErrorMatches, `cannot decrypt secret ".*": .*`)
+}
+
+func (s *SecretsSuite) TestSecretsKeyNotSet(c *gc.C) {
+	id := s.addSecret(c, "", map[string]string{"password": "sekrit"})
+	st, err := s.State.ForModel(s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	state.ResetSecretsKey(st)
+
+	secret, err := st.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = secret.Value()
+	c.Assert(err, gc.ErrorMatches, "secrets key not set")
+	_, err = st.AddSecret(state.AddSecretArgs{
+		Owner: s.dbUnit.Tag(),
+		Data:  map[string]string{"key": "value"},
+	})
+	c.Assert(err, gc.ErrorMatches, "secrets key not set")
+}
+
+func (s *SecretsSuite) TestSetSecretsKeyInvalid(c *gc.C) {
+	for i, key := range []string{"", "not base64!", "c2hvcnQ="} {
+		c.Logf("test %d: %q", i, key)
+		err := s.State.SetSecretsKey(key)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+	c.Assert(s.State.SecretsKey(), gc.Equals, testing.SecretsKey)
+}
+
+func (s *SecretsSuite) TestGenerateSecretsKey(c *gc.C) {
+	key, err := state.GenerateSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.Not(gc.Equals), testing.SecretsKey)
+	other, err := state.GenerateSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other, gc.Not(gc.Equals), key)
+
+	err = s.State.SetSecretsKey(key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.State.SecretsKey(), gc.Equals, key)
+}
+
+func (s *SecretsSuite) TestAddSecretRotatesByLabel(c *gc.C) {
+	id := s.addSecret(c, "root", map[string]string{"password": "sekrit"})
+	rotated := s.addSecret(c, "root", map[string]string{"password": "n3w"})
+	c.Assert(rotated, gc.Equals, id)
+
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Revision(), gc.Equals, 2)
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "n3w"})
+
+	all, err := s.State.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *SecretsSuite) TestAddSecretUnitOwned(c *gc.C) {
+	id, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.dbUnit.Tag(),
+		Data:  map[string]string{"key": "value"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.OwnedBy(s.dbUnit.UnitTag()), jc.IsTrue)
+	c.Check(secret.OwnedBy(names.NewUnitTag("mysql/1")), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestAddSecretInvalid(c *gc.C) {
+	_, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.mysql.Tag(),
+		Data:  map[string]string{"key": "value"},
+	})
+	c.Check(err, gc.ErrorMatches, "application owned secret without leadership token not valid")
+
+	_, err = s.State.AddSecret(state.AddSecretArgs{
+		Owner: names.NewMachineTag("0"),
+		Data:  map[string]string{"key": "value"},
+	})
+	c.Check(err, gc.ErrorMatches, `secret owner "machine-0" not valid`)
+
+	_, err = s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.dbUnit.Tag(),
+	})
+	c.Check(err, gc.ErrorMatches, "empty secret value not valid")
+}
+
+func (s *SecretsSuite) TestAddSecretNotLeader(c *gc.C) {
+	_, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.mysql.Tag(),
+		Data:  map[string]string{"key": "value"},
+		Token: &failToken{},
+	})
+	c.Check(err, gc.ErrorMatches, "cannot add secret: prerequisites failed: something bad happened")
+}
+
+func (s *SecretsSuite) TestSecretNotFound(c *gc.C) {
+	_, err := s.State.Secret("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestGrantAndRevoke(c *gc.C) {
+	id := s.addSecret(c, "root", map[string]string{"password": "sekrit"})
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead(s.wpUnit.UnitTag()), jc.IsFalse)
+
+	err = s.State.GrantSecret(id, s.relation, "wordpress", &fakeToken{})
+	c.Assert(err, jc.ErrorIsNil)
+	// Granting twice is a no-op.
+	err = s.State.GrantSecret(id, s.relation, "wordpress", &fakeToken{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err = s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), jc.DeepEquals, []state.SecretGrant{{
+		RelationKey: s.relation.String(),
+		Application: "wordpress",
+	}})
+	c.Check(secret.CanRead(s.wpUnit.UnitTag()), jc.IsTrue)
+	revisions, err := s.wpUnit.SecretRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, jc.DeepEquals, map[string]int{id: 1})
+
+	err = s.State.RevokeSecret(id, s.relation, "wordpress", &fakeToken{})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead(s.wpUnit.UnitTag()), jc.IsFalse)
+	revisions, err = s.wpUnit.SecretRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestGrantNotInRelation(c *gc.C) {
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	id := s.addSecret(c, "", map[string]string{"password": "sekrit"})
+	err := s.State.GrantSecret(id, s.relation, "logging", &fakeToken{})
+	c.Assert(err, gc.ErrorMatches, `cannot grant secret ".*": application "logging" is not a member of "wordpress:db mysql:server"`)
+}
+
+func (s *SecretsSuite) TestWatchSecrets(c *gc.C) {
+	w := s.wpUnit.WatchSecrets()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	id := s.addSecret(c, "root", map[string]string{"password": "sekrit"})
+	wc.AssertOneChange()
+	err := s.State.GrantSecret(id, s.relation, "wordpress", &fakeToken{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	s.addSecret(c, "root", map[string]string{"password": "n3w"})
+	wc.AssertOneChange()
+
+	revisions, err := s.wpUnit.SecretRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, jc.DeepEquals, map[string]int{id: 2})
+}
+
+func (s *SecretsSuite) TestRemoveUnitRemovesOwnedSecrets(c *gc.C) {
+	id, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.dbUnit.Tag(),
+		Data:  map[string]string{"key": "value"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.dbUnit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.dbUnit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestRemoveRelationRevokesGrants(c *gc.C) {
+	id := s.addSecret(c, "", map[string]string{"password": "sekrit"})
+	err := s.State.GrantSecret(id, s.relation, "wordpress", &fakeToken{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesOwnedSecrets(c *gc.C) {
+	id := s.addSecret(c, "", map[string]string{"password": "sekrit"})
+	err := s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.dbUnit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.dbUnit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
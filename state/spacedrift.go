@@ -0,0 +1,363 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
+)
+
+// SpaceDriftKind describes how the spaces and subnets reported by the
+// provider differ from those recorded in state.
+type SpaceDriftKind string
+
+const (
+	// SubnetAddedDrift indicates a subnet known to the provider was not
+	// yet recorded in state.
+	SubnetAddedDrift SpaceDriftKind = "subnet-added"
+
+	// SubnetRemovedDrift indicates a subnet recorded in state is no
+	// longer known to the provider.
+	SubnetRemovedDrift SpaceDriftKind = "subnet-removed"
+
+	// SubnetMovedDrift indicates the provider now reports a subnet in a
+	// different space than the one recorded in state.
+	SubnetMovedDrift SpaceDriftKind = "subnet-moved"
+
+	// SpaceRemovedDrift indicates a space recorded in state is no longer
+	// known to the provider.
+	SpaceRemovedDrift SpaceDriftKind = "space-removed"
+)
+
+// SpaceDrift describes a single difference between the provider's view
+// of spaces and subnets and the one recorded in state.
+type SpaceDrift struct {
+	// Kind describes the type of difference.
+	Kind SpaceDriftKind
+
+	// SpaceName is the name of the space the drift applies to. For
+	// subnet drift, it's the space the subnet is recorded in (or, for
+	// new subnets, was added to).
+	SpaceName string
+
+	// CIDR is the CIDR of the affected subnet; empty for space drift.
+	CIDR string
+
+	// ProviderId is the provider id of the affected subnet or space.
+	ProviderId network.Id
+
+	// ToSpaceName is only set for SubnetMovedDrift and holds the name
+	// of the space the provider now reports the subnet in.
+	ToSpaceName string
+
+	// Resolved is true when state was updated to match the provider.
+	Resolved bool
+
+	// Conflict describes why the drift could not be resolved safely.
+	// It is empty when there is no conflict.
+	Conflict string
+
+	// Applications holds the names of the applications with endpoints
+	// bound to a space which is no longer known to the provider.
+	Applications []string
+}
+
+// spaceDriftDoc records the latest drift report for a model.
+type spaceDriftDoc struct {
+	DocID     string               `bson:"_id"`
+	ModelUUID string               `bson:"model-uuid"`
+	Updated   time.Time            `bson:"updated"`
+	Entries   []spaceDriftEntryDoc `bson:"entries"`
+}
+
+type spaceDriftEntryDoc struct {
+	Kind         string   `bson:"kind"`
+	SpaceName    string   `bson:"space-name,omitempty"`
+	CIDR         string   `bson:"cidr,omitempty"`
+	ProviderId   string   `bson:"providerid,omitempty"`
+	ToSpaceName  string   `bson:"to-space-name,omitempty"`
+	Resolved     bool     `bson:"resolved,omitempty"`
+	Conflict     string   `bson:"conflict,omitempty"`
+	Applications []string `bson:"applications,omitempty"`
+}
+
+// spaceDriftKey is the key of the single drift document in each model.
+const spaceDriftKey = "spacedrift"
+
+// ReconcileSpaceDrift updates state to match the provider wherever it
+// is safe to do so, and records the resulting drift report, replacing
+// any previous one. Subnets the provider no longer knows about are
+// removed, and subnets the provider moved are moved, unless they have
+// addresses in use by machines. Spaces the provider no longer knows
+// about are removed once they have no subnets left, unless application
+// endpoints are still bound to them. Anything that cannot be updated
+// safely is reported as a conflict. The recorded drift is returned.
+func (st *State) ReconcileSpaceDrift(drift []SpaceDrift) ([]SpaceDrift, error) {
+	result := make([]SpaceDrift, len(drift))
+	copy(result, drift)
+
+	// Reconcile subnets first, so that spaces emptied by the provider
+	// can be removed in the same pass.
+	for i, entry := range result {
+		var err error
+		switch entry.Kind {
+		case SubnetAddedDrift:
+			result[i].Resolved = true
+		case SubnetRemovedDrift:
+			err = st.reconcileRemovedSubnet(&result[i])
+		case SubnetMovedDrift:
+			err = st.reconcileMovedSubnet(&result[i])
+		case SpaceRemovedDrift:
+		default:
+			return nil, errors.NotValidf("space drift kind %q", entry.Kind)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	for i, entry := range result {
+		if entry.Kind != SpaceRemovedDrift {
+			continue
+		}
+		if err := st.reconcileRemovedSpace(&result[i]); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := st.setSpaceDrift(result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func (st *State) reconcileRemovedSubnet(entry *SpaceDrift) error {
+	subnet, err := st.Subnet(entry.CIDR)
+	if errors.IsNotFound(err) {
+		entry.Resolved = true
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if conflict, err := st.subnetInUse(entry.CIDR); err != nil {
+		return errors.Trace(err)
+	} else if conflict != "" {
+		entry.Conflict = conflict
+		return nil
+	}
+	if err := subnet.EnsureDead(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := subnet.Remove(); err != nil {
+		return errors.Trace(err)
+	}
+	entry.Resolved = true
+	return nil
+}
+
+func (st *State) reconcileMovedSubnet(entry *SpaceDrift) error {
+	subnet, err := st.Subnet(entry.CIDR)
+	if errors.IsNotFound(err) {
+		entry.Conflict = "subnet not found"
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if subnet.SpaceName() == entry.ToSpaceName {
+		entry.Resolved = true
+		return nil
+	}
+	if _, err := st.Space(entry.ToSpaceName); errors.IsNotFound(err) {
+		entry.Conflict = fmt.Sprintf("space %q not found", entry.ToSpaceName)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if conflict, err := st.subnetInUse(entry.CIDR); err != nil {
+		return errors.Trace(err)
+	} else if conflict != "" {
+		entry.Conflict = conflict
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      subnetsC,
+		Id:     subnet.ID(),
+		Assert: bson.D{{"space-name", subnet.SpaceName()}},
+		Update: bson.D{{"$set", bson.D{{"space-name", entry.ToSpaceName}}}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		entry.Conflict = "subnet changed concurrently"
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	entry.Resolved = true
+	return nil
+}
+
+func (st *State) reconcileRemovedSpace(entry *SpaceDrift) error {
+	space, err := st.Space(entry.SpaceName)
+	if errors.IsNotFound(err) {
+		entry.Resolved = true
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	applications, err := st.applicationsBoundToSpace(entry.SpaceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(applications) > 0 {
+		entry.Applications = applications
+		entry.Conflict = fmt.Sprintf(
+			"application endpoints bound to space: %s",
+			strings.Join(applications, ", "),
+		)
+		return nil
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(subnets) > 0 {
+		entry.Conflict = fmt.Sprintf("space still has %d subnet(s)", len(subnets))
+		return nil
+	}
+	if err := space.EnsureDead(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := space.Remove(); err != nil {
+		return errors.Trace(err)
+	}
+	entry.Resolved = true
+	return nil
+}
+
+// subnetInUse returns a non-empty description when any machine has an
+// address in the subnet with the given CIDR.
+func (st *State) subnetInUse(cidr string) (string, error) {
+	addresses, closer := st.getCollection(ipAddressesC)
+	defer closer()
+
+	count, err := addresses.Find(bson.D{{"subnet-cidr", cidr}}).Count()
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot count addresses in subnet %q", cidr)
+	}
+	if count == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("subnet has %d address(es) in use", count), nil
+}
+
+// applicationsBoundToSpace returns the sorted names of applications with
+// at least one endpoint bound to the given space.
+func (st *State) applicationsBoundToSpace(spaceName string) ([]string, error) {
+	endpointBindings, closer := st.getCollection(endpointBindingsC)
+	defer closer()
+
+	var docs []endpointBindingsDoc
+	if err := endpointBindings.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get endpoint bindings")
+	}
+	var applications []string
+	for _, doc := range docs {
+		for _, boundSpace := range doc.Bindings {
+			if boundSpace != spaceName {
+				continue
+			}
+			// The document id is the application's global key.
+			localID := st.localID(doc.DocID)
+			applications = append(applications, strings.TrimPrefix(localID, applicationGlobalKey("")))
+			break
+		}
+	}
+	sort.Strings(applications)
+	return applications, nil
+}
+
+func (st *State) setSpaceDrift(drift []SpaceDrift) error {
+	entries := make([]spaceDriftEntryDoc, len(drift))
+	for i, entry := range drift {
+		entries[i] = spaceDriftEntryDoc{
+			Kind:         string(entry.Kind),
+			SpaceName:    entry.SpaceName,
+			CIDR:         entry.CIDR,
+			ProviderId:   string(entry.ProviderId),
+			ToSpaceName:  entry.ToSpaceName,
+			Resolved:     entry.Resolved,
+			Conflict:     entry.Conflict,
+			Applications: entry.Applications,
+		}
+	}
+	updated := GetClock().Now().UTC()
+
+	spaceDrift, closer := st.getCollection(spaceDriftC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		count, err := spaceDrift.FindId(spaceDriftKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			return []txn.Op{{
+				C:      spaceDriftC,
+				Id:     st.docID(spaceDriftKey),
+				Assert: txn.DocMissing,
+				Insert: &spaceDriftDoc{
+					ModelUUID: st.ModelUUID(),
+					Updated:   updated,
+					Entries:   entries,
+				},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      spaceDriftC,
+			Id:     st.docID(spaceDriftKey),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"updated", updated},
+				{"entries", entries},
+			}}},
+		}}, nil
+	}
+	return errors.Annotate(st.run(buildTxn), "cannot record space drift")
+}
+
+// SpaceDrift returns the latest drift report recorded for the model, and
+// the time it was recorded. If no report has been recorded yet, no
+// entries and a zero time are returned.
+func (st *State) SpaceDrift() ([]SpaceDrift, time.Time, error) {
+	spaceDrift, closer := st.getCollection(spaceDriftC)
+	defer closer()
+
+	var doc spaceDriftDoc
+	err := spaceDrift.FindId(spaceDriftKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, time.Time{}, nil
+	} else if err != nil {
+		return nil, time.Time{}, errors.Annotate(err, "cannot get space drift")
+	}
+	drift := make([]SpaceDrift, len(doc.Entries))
+	for i, entry := range doc.Entries {
+		drift[i] = SpaceDrift{
+			Kind:         SpaceDriftKind(entry.Kind),
+			SpaceName:    entry.SpaceName,
+			CIDR:         entry.CIDR,
+			ProviderId:   network.Id(entry.ProviderId),
+			ToSpaceName:  entry.ToSpaceName,
+			Resolved:     entry.Resolved,
+			Conflict:     entry.Conflict,
+			Applications: entry.Applications,
+		}
+	}
+	return drift, doc.Updated, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type SpaceDriftSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SpaceDriftSuite{})
+
+func (s *SpaceDriftSuite) addSpaceWithSubnet(c *gc.C, spaceName, cidr string) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:       cidr,
+		SpaceName:  spaceName,
+		ProviderId: "subnet-" + cidr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace(spaceName, "space-"+spaceName, []string{cidr}, false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SpaceDriftSuite) TestSpaceDriftNotRecorded(c *gc.C) {
+	drift, updated, err := s.State.SpaceDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drift, gc.HasLen, 0)
+	c.Assert(updated.IsZero(), jc.IsTrue)
+}
+
+func (s *SpaceDriftSuite) TestReconcileSpaceDriftRemovesUnusedSubnetAndSpace(c *gc.C) {
+	s.addSpaceWithSubnet(c, "db", "10.0.0.0/24")
+
+	drift, err := s.State.ReconcileSpaceDrift([]state.SpaceDrift{{
+		Kind:      state.SpaceRemovedDrift,
+		SpaceName: "db",
+	}, {
+		Kind:      state.SubnetRemovedDrift,
+		SpaceName: "db",
+		CIDR:      "10.0.0.0/24",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drift, jc.DeepEquals, []state.SpaceDrift{{
+		Kind:      state.SpaceRemovedDrift,
+		SpaceName: "db",
+		Resolved:  true,
+	}, {
+		Kind:      state.SubnetRemovedDrift,
+		SpaceName: "db",
+		CIDR:      "10.0.0.0/24",
+		Resolved:  true,
+	}})
+
+	_, err = s.State.Subnet("10.0.0.0/24")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.Space("db")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	recorded, updated, err := s.State.SpaceDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, jc.DeepEquals, drift)
+	c.Assert(updated.IsZero(), jc.IsFalse)
+}
+
+func (s *SpaceDriftSuite) TestReconcileSpaceDriftMovesSubnet(c *gc.C) {
+	s.addSpaceWithSubnet(c, "db", "10.0.0.0/24")
+	s.addSpaceWithSubnet(c, "ha", "10.0.1.0/24")
+
+	drift, err := s.State.ReconcileSpaceDrift([]state.SpaceDrift{{
+		Kind:        state.SubnetMovedDrift,
+		SpaceName:   "db",
+		CIDR:        "10.0.0.0/24",
+		ToSpaceName: "ha",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drift[0].Resolved, jc.IsTrue)
+	c.Assert(drift[0].Conflict, gc.Equals, "")
+
+	subnet, err := s.State.Subnet("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.SpaceName(), gc.Equals, "ha")
+}
+
+func (s *SpaceDriftSuite) TestReconcileSpaceDriftReportsConflicts(c *gc.C) {
+	s.addSpaceWithSubnet(c, "db", "10.0.0.0/24")
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	s.AddTestingServiceWithBindings(c, "yoursql", ch, map[string]string{"server": "db"})
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		CIDRAddress:  "10.0.0.10/24",
+		ConfigMethod: state.StaticAddress,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	drift, err := s.State.ReconcileSpaceDrift([]state.SpaceDrift{{
+		Kind:      state.SubnetRemovedDrift,
+		SpaceName: "db",
+		CIDR:      "10.0.0.0/24",
+	}, {
+		Kind:      state.SpaceRemovedDrift,
+		SpaceName: "db",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drift, jc.DeepEquals, []state.SpaceDrift{{
+		Kind:      state.SubnetRemovedDrift,
+		SpaceName: "db",
+		CIDR:      "10.0.0.0/24",
+		Conflict:  "subnet has 1 address(es) in use",
+	}, {
+		Kind:         state.SpaceRemovedDrift,
+		SpaceName:    "db",
+		Conflict:     "application endpoints bound to space: yoursql",
+		Applications: []string{"yoursql"},
+	}})
+
+	_, err = s.State.Subnet("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Space("db")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SpaceDriftSuite) TestReconcileSpaceDriftInvalidKind(c *gc.C) {
+	_, err := s.State.ReconcileSpaceDrift([]state.SpaceDrift{{Kind: "bad"}})
+	c.Assert(err, gc.ErrorMatches, `space drift kind "bad" not valid`)
+}
@@ -88,6 +88,10 @@ type State struct {
 	// relatively-skewed.
 	leaseClientId string
 
+	// secretsKey is used to encrypt the values of secrets; see
+	// SetSecretsKey.
+	secretsKey []byte
+
 	// workers is responsible for keeping the various sub-workers
 	// available by starting new ones as they fail. It doesn't do
 	// that yet, but having a type that collects them together is the
@@ -237,6 +241,7 @@ func (st *State) ForModel(model names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.secretsKey = st.secretsKey
 	if err := newSt.start(st.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -734,6 +739,9 @@ func (st *State) FindEntity(tag names.Tag) (Entity, error) {
 	case names.UnitTag:
 		return st.Unit(id)
 	case names.UserTag:
+		if !tag.IsLocal() {
+			return st.ExternalUser(tag)
+		}
 		return st.User(tag)
 	case names.ApplicationTag:
 		return st.Application(id)
@@ -3697,6 +3697,72 @@ func (s *StateSuite) TestEnableHADefaultsTo3(c *gc.C) {
 	c.Assert(m3.IsManager(), jc.IsTrue)
 }
 
+func (s *StateSuite) TestEnableHAWithWitnesses(c *gc.C) {
+	// Don't use agent presence to decide on machine availability.
+	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
+		return true, nil
+	})
+	changes, err := s.State.EnableHAWithWitnesses(3, 2, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.DeepEquals, []string{"0", "1", "2"})
+	c.Assert(changes.AddedWitnesses, gc.DeepEquals, []string{"3", "4"})
+
+	// Witnesses are controllers, but do not vote.
+	s.assertControllerInfo(c,
+		[]string{"0", "1", "2", "3", "4"},
+		[]string{"0", "1", "2"}, nil)
+	m3, err := s.State.Machine("3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m3.IsManager(), jc.IsTrue)
+	c.Assert(m3.IsWitness(), jc.IsTrue)
+	c.Assert(m3.WantsVote(), jc.IsFalse)
+
+	// Witnesses are maintained, and never promoted.
+	changes, err = s.State.EnableHA(0, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 0)
+	c.Assert(changes.Promoted, gc.HasLen, 0)
+	c.Assert(changes.AddedWitnesses, gc.HasLen, 0)
+}
+
+func (s *StateSuite) TestEnableHAWitnessesFailsWithBadCount(c *gc.C) {
+	// Don't use agent presence to decide on machine availability.
+	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
+		return true, nil
+	})
+	_, err := s.State.EnableHAWithWitnesses(3, -1, constraints.Value{}, "", nil)
+	c.Assert(err, gc.ErrorMatches, "number of witnesses must be non-negative")
+	_, err = s.State.EnableHAWithWitnesses(3, replicaset.MaxPeers+1, constraints.Value{}, "", nil)
+	c.Assert(err, gc.ErrorMatches, `witness count is too large \(allowed \d+\)`)
+
+	_, err = s.State.EnableHAWithWitnesses(3, 2, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.EnableHAWithWitnesses(3, 1, constraints.Value{}, "quantal", nil)
+	c.Assert(err, gc.ErrorMatches, "failed to create new controller machines: cannot reduce witness count")
+}
+
+func (s *StateSuite) TestEnableHAReplacesUnavailableWitness(c *gc.C) {
+	_, err := s.State.EnableHAWithWitnesses(3, 1, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
+		return m.Id() != "3", nil
+	})
+
+	// The unavailable witness does not hold a vote, so it
+	// is removed immediately and replaced.
+	changes, err := s.State.EnableHA(0, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Removed, gc.DeepEquals, []string{"3"})
+	c.Assert(changes.AddedWitnesses, gc.DeepEquals, []string{"4"})
+	s.assertControllerInfo(c,
+		[]string{"0", "1", "2", "4"},
+		[]string{"0", "1", "2"}, nil)
+	m3, err := s.State.Machine("3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m3.IsManager(), jc.IsFalse)
+	c.Assert(m3.IsWitness(), jc.IsFalse)
+}
+
 func (s *StateSuite) TestEnableHAConcurrentSame(c *gc.C) {
 	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
 		return true, nil
@@ -285,58 +285,10 @@ func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB in
 	}
 	history, closer := st.getRawCollection(statusesHistoryC)
 	defer closer()
-
-	// Status Record Age
-	// TODO(perrito666): 2016-04-26 lp:1558657
-	if maxHistoryTime > 0 {
-		t := time.Now().Add(-maxHistoryTime)
-		_, err := history.RemoveAll(bson.D{
-			{"updated", bson.M{"$lt": t.UnixNano()}},
-		})
-		if err != nil {
-			return errors.Trace(err)
-		}
-	}
-	if maxHistoryMB == 0 {
-		return nil
-	}
-	// Collection Size
-	collMB, err := getCollectionMB(history)
-	if err != nil {
-		return errors.Annotate(err, "retrieving status history collection size")
-	}
-	if collMB <= maxHistoryMB {
-		return nil
-	}
 	// TODO(perrito666) explore if there would be any beneffit from having the
 	// size limit be per model
-	count, err := history.Count()
-	if err == mgo.ErrNotFound || count <= 0 {
-		return nil
-	}
-	if err != nil {
-		return errors.Annotate(err, "counting status history records")
-	}
-	// We are making the assumption that status sizes can be averaged for
-	// large numbers and we will get a reasonable approach on the size.
 	// Note: Capped collections are not used for this because they, currently
 	// at least, lack a way to be resized and the size is expected to change
 	// as real life data of the history usage is gathered.
-	sizePerStatus := float64(collMB) / float64(count)
-	if sizePerStatus == 0 {
-		return errors.New("unexpected result calculating status history entry size")
-	}
-	deleteStatuses := count - int(float64(collMB-maxHistoryMB)/sizePerStatus)
-	result := historicalStatusDoc{}
-	err = history.Find(nil).Sort("-updated").Skip(deleteStatuses).One(&result)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	_, err = history.RemoveAll(bson.D{
-		{"updated", bson.M{"$lt": result.Updated}},
-	})
-	if err != nil {
-		return errors.Trace(err)
-	}
-	return nil
+	return errors.Trace(pruneHistory(history, "updated", maxHistoryTime, maxHistoryMB))
 }
@@ -166,6 +166,12 @@ func unitGlobalKey(name string) string {
 	return "u#" + name + "#charm"
 }
 
+// globalWorkloadVersionKey returns the global database key for the
+// workload version status key for the named unit.
+func globalWorkloadVersionKey(name string) string {
+	return unitGlobalKey(name) + "#sat#workload-version"
+}
+
 // globalAgentKey returns the global database key for the unit.
 func (u *Unit) globalAgentKey() string {
 	return unitAgentGlobalKey(u.doc.Name)
@@ -181,6 +187,12 @@ func (u *Unit) globalKey() string {
 	return unitGlobalKey(u.doc.Name)
 }
 
+// globalWorkloadVersionKey returns the global database key for the
+// workload version status key for this unit.
+func (u *Unit) globalWorkloadVersionKey() string {
+	return globalWorkloadVersionKey(u.doc.Name)
+}
+
 // Life returns whether the unit is Alive, Dying or Dead.
 func (u *Unit) Life() Life {
 	return u.doc.Life
@@ -318,6 +330,9 @@ func (u *Unit) eraseHistory() error {
 	if _, err := historyW.RemoveAll(bson.D{{"statusid", u.globalAgentKey()}}); err != nil {
 		return err
 	}
+	if _, err := historyW.RemoveAll(bson.D{{"statusid", u.globalWorkloadVersionKey()}}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -622,7 +637,15 @@ func (u *Unit) Remove() (err error) {
 		}
 		return nil, jujutxn.ErrNoOperations
 	}
-	return unit.st.run(buildTxn)
+	if err := unit.st.run(buildTxn); err != nil {
+		return err
+	}
+	// The hook history is kept while the unit is dying, so that its
+	// final hooks can be inspected, and removed with the unit.
+	if err := unit.eraseHookHistory(); err != nil {
+		logger.Errorf("cannot delete hook history for unit %q: %v", unit.Name(), err)
+	}
+	return nil
 }
 
 // Resolved returns the resolved mode for the unit.
@@ -846,6 +869,68 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 	})
 }
 
+// WorkloadVersion returns the version of the running workload set by
+// the charm (eg, the version of postgresql that is running, as opposed
+// to the version of the postgresql charm).
+func (u *Unit) WorkloadVersion() (string, error) {
+	info, err := getStatus(u.st, u.globalWorkloadVersionKey(), "workload version")
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return info.Message, nil
+}
+
+// SetWorkloadVersion sets the version of the workload that the unit
+// is currently running.
+func (u *Unit) SetWorkloadVersion(version string) error {
+	// Store in status rather than an attribute of the unit doc - we
+	// want to avoid everything being an attr of the main docs to
+	// stop a swarm of watchers being notified for irrelevant changes,
+	// and it gives us version history for free.
+	globalKey := u.globalWorkloadVersionKey()
+	doc := statusDoc{
+		Status:     status.StatusActive,
+		StatusInfo: version,
+		Updated:    time.Now().UnixNano(),
+	}
+	probablyUpdateStatusHistory(u.st, globalKey, doc)
+
+	updateSource := updateStatusSource(u.st, globalKey, doc)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		_, err := u.st.readTxnRevno(statusesC, globalKey)
+		if errors.Cause(err) != mgo.ErrNotFound {
+			return updateSource(attempt)
+		}
+		// Units added before workload versions were recorded
+		// have no document to update, so create one.
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: txn.DocExists,
+		}, createStatusOp(u.st, globalKey, doc)}, nil
+	}
+	err := u.st.run(buildTxn)
+	return errors.Annotate(err, "cannot set workload version")
+}
+
+// WorkloadVersionHistory returns the versions of the workload that the
+// unit has reported, most recent first.
+func (u *Unit) WorkloadVersionHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		st:        u.st,
+		globalKey: u.globalWorkloadVersionKey(),
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
+
 // OpenPortsOnSubnet opens the given port range and protocol for the unit on the
 // given subnet, which can be empty. When non-empty, subnetID must refer to an
 // existing, alive subnet, otherwise an error is returned. Returns an error if
@@ -2251,10 +2336,11 @@ func (u *Unit) StorageConstraints() (map[string]StorageConstraints, error) {
 }
 
 type addUnitOpsArgs struct {
-	unitDoc           *unitDoc
-	agentStatusDoc    statusDoc
-	workloadStatusDoc statusDoc
-	meterStatusDoc    *meterStatusDoc
+	unitDoc            *unitDoc
+	agentStatusDoc     statusDoc
+	workloadStatusDoc  statusDoc
+	workloadVersionDoc statusDoc
+	meterStatusDoc     *meterStatusDoc
 }
 
 // addUnitOps returns the operations required to add a unit to the units
@@ -2268,6 +2354,7 @@ func addUnitOps(st *State, args addUnitOpsArgs) []txn.Op {
 	// TODO: consider storageOps
 	return []txn.Op{
 		createStatusOp(st, unitGlobalKey(name), args.workloadStatusDoc),
+		createStatusOp(st, globalWorkloadVersionKey(name), args.workloadVersionDoc),
 		createStatusOp(st, agentGlobalKey, args.agentStatusDoc),
 		createMeterStatusOp(st, agentGlobalKey, args.meterStatusDoc),
 		{
@@ -112,6 +112,25 @@ func (s *UnitSuite) TestWatchConfigSettingsNeedsCharmURL(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "unit charm not set")
 }
 
+func (s *UnitSuite) TestWatchConfigSettingsEndpointBindings(c *gc.C) {
+	err := s.unit.SetCharmURL(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := s.unit.WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Changing the endpoint bindings is reported as a config change.
+	err = s.service.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *UnitSuite) TestWatchConfigSettings(c *gc.C) {
 	err := s.unit.SetCharmURL(s.charm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -570,6 +589,48 @@ func (s *UnitSuite) TestRefresh(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *UnitSuite) TestWorkloadVersion(c *gc.C) {
+	version, err := s.unit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "")
+
+	err = s.unit.SetWorkloadVersion("3.0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetWorkloadVersion("3.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	version, err = s.unit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "3.1")
+
+	history, err := s.unit.WorkloadVersionHistory(status.StatusHistoryFilter{Size: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Message, gc.Equals, "3.1")
+	c.Assert(history[1].Message, gc.Equals, "3.0")
+}
+
+func (s *UnitSuite) TestSetWorkloadVersionWithoutStatusDoc(c *gc.C) {
+	// Units added before workload versions were recorded have no
+	// status document for them.
+	statuses, closer := state.GetRawCollection(s.State, "statuses")
+	defer closer()
+	err := statuses.RemoveId(s.State.ModelUUID() + ":u#" + s.unit.Name() + "#charm#sat#workload-version")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.SetWorkloadVersion("3.0")
+	c.Assert(err, jc.ErrorIsNil)
+	version, err := s.unit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "3.0")
+
+	err = s.unit.SetWorkloadVersion("3.1")
+	c.Assert(err, jc.ErrorIsNil)
+	version, err = s.unit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "3.1")
+}
+
 func (s *UnitSuite) TestSetCharmURLSuccess(c *gc.C) {
 	preventUnitDestroyRemove(c, s.unit)
 	curl, ok := s.unit.CharmURL()
@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// maxUnitStateSize bounds the total size of the keys and values a
+// charm may store in a unit's state.
+const maxUnitStateSize = 64 * 1024
+
+// unitStateSettingsKey returns the settings key of the document
+// holding the charm state of the named unit.
+func unitStateSettingsKey(unitName string) string {
+	return "u#" + unitName + "#state"
+}
+
+// State returns the key/value pairs stored by the unit's charm with
+// state-set. If nothing has been set yet, it will return an empty map;
+// this is not an error.
+func (u *Unit) State() (map[string]string, error) {
+	doc, err := readSettingsDoc(u.st, settingsC, unitStateSettingsKey(u.doc.Name))
+	if errors.IsNotFound(err) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unitStateFromSettings(doc.Settings), nil
+}
+
+// UpdateState updates the unit's charm state with the supplied values.
+// Empty values in the supplied map will be cleared in the database. The
+// update fails if the resulting state would exceed the size limit.
+func (u *Unit) UpdateState(updates map[string]string) error {
+	key := unitStateSettingsKey(u.doc.Name)
+	sets := bson.M{}
+	unsets := bson.M{}
+	for unescapedKey, value := range updates {
+		key := escapeReplacer.Replace(unescapedKey)
+		if value == "" {
+			unsets[key] = 1
+		} else {
+			sets[key] = value
+		}
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life == Dead {
+			return nil, errors.Errorf("unit %q is dead", u.doc.Name)
+		}
+		unitOp := txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}
+
+		var current map[string]interface{}
+		doc, err := readSettingsDoc(u.st, settingsC, key)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		} else if err == nil {
+			current = doc.Settings
+		}
+		merged := make(map[string]interface{})
+		for key, value := range current {
+			merged[key] = value
+		}
+		for key := range unsets {
+			delete(merged, key)
+		}
+		for key, value := range sets {
+			merged[key] = value
+		}
+		if isNullSettingsChange(current, merged) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		state := unitStateFromSettings(merged)
+		if size := unitStateSize(state); size > maxUnitStateSize {
+			return nil, errors.Errorf(
+				"unit state of %d bytes exceeds the limit of %d bytes", size, maxUnitStateSize,
+			)
+		}
+
+		if doc == nil {
+			return []txn.Op{unitOp, createSettingsOp(settingsC, key, stringMapToInterfaces(state))}, nil
+		}
+		return []txn.Op{unitOp, {
+			C:      settingsC,
+			Id:     key,
+			Assert: bson.D{{"version", doc.Version}},
+			Update: setUnsetUpdateSettings(sets, unsets),
+		}}, nil
+	}
+	if err := u.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update state of unit %q", u.doc.Name)
+	}
+	return nil
+}
+
+// removeUnitState removes the charm state stored for the named unit,
+// if any.
+func (st *State) removeUnitState(unitName string) error {
+	err := removeSettings(st, settingsC, unitStateSettingsKey(unitName))
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func unitStateFromSettings(settings map[string]interface{}) map[string]string {
+	result := make(map[string]string)
+	for escapedKey, interfaceValue := range settings {
+		key := unescapeReplacer.Replace(escapedKey)
+		if value, _ := interfaceValue.(string); value != "" {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func unitStateSize(state map[string]string) int {
+	size := 0
+	for key, value := range state {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+func isNullSettingsChange(current, updated map[string]interface{}) bool {
+	if len(current) != len(updated) {
+		return false
+	}
+	for key, value := range updated {
+		if currentValue, found := current[key]; !found || currentValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+func stringMapToInterfaces(in map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for key, value := range in {
+		out[key] = value
+	}
+	return out
+}
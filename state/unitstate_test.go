@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type UnitStateSuite struct {
+	statetesting.StateSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitStateSuite{})
+
+func (s *UnitStateSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *UnitStateSuite) TestStateEmpty(c *gc.C) {
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+}
+
+func (s *UnitStateSuite) TestUpdateState(c *gc.C) {
+	err := s.unit.UpdateState(map[string]string{
+		"initialised": "true",
+		"db.password": "sekrit",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{
+		"initialised": "true",
+		"db.password": "sekrit",
+	})
+
+	err = s.unit.UpdateState(map[string]string{
+		"initialised": "",
+		"db.password": "changed",
+		"$cursor":     "42",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err = s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{
+		"db.password": "changed",
+		"$cursor":     "42",
+	})
+}
+
+func (s *UnitStateSuite) TestUpdateStateNoChange(c *gc.C) {
+	err := s.unit.UpdateState(map[string]string{"initialised": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.UpdateState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.UpdateState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitStateSuite) TestUpdateStateTooLarge(c *gc.C) {
+	err := s.unit.UpdateState(map[string]string{"big": strings.Repeat("x", 60*1024)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.UpdateState(map[string]string{"bigger": strings.Repeat("x", 8*1024)})
+	c.Assert(err, gc.ErrorMatches, `cannot update state of unit "[^"]+": unit state of \d+ bytes exceeds the limit of 65536 bytes`)
+
+	// Shrinking the state makes room again.
+	err = s.unit.UpdateState(map[string]string{"big": "", "bigger": strings.Repeat("x", 8*1024)})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitStateSuite) TestUpdateStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.UpdateState(map[string]string{"initialised": "true"})
+	c.Assert(err, gc.ErrorMatches, `cannot update state of unit "[^"]+": unit "[^"]+" is dead`)
+}
+
+func (s *UnitStateSuite) TestRemoveUnitRemovesState(c *gc.C) {
+	err := s.unit.UpdateState(map[string]string{"initialised": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+}
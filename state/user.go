@@ -130,6 +130,8 @@ func (st *State) getUser(name string, udoc *userDoc) error {
 	err := users.Find(bson.D{{"_id", name}}).One(udoc)
 	if err == mgo.ErrNotFound {
 		err = errors.NotFoundf("user %q", name)
+	} else if err == nil && udoc.Removed {
+		err = errors.NewNotFound(nil, fmt.Sprintf("user %q has been removed", name))
 	}
 	// DateCreated is inserted as UTC, but read out as local time. So we
 	// convert it back to UTC here.
@@ -156,7 +158,7 @@ func (st *State) AllUsers(includeDeactivated bool) ([]*User, error) {
 	users, closer := st.getCollection(usersC)
 	defer closer()
 
-	var query bson.D
+	query := bson.D{{"removed", bson.D{{"$ne", true}}}}
 	if !includeDeactivated {
 		query = append(query, bson.DocElem{"deactivated", false})
 	}
@@ -193,6 +195,8 @@ type userDoc struct {
 	PasswordSalt string    `bson:"passwordsalt"`
 	CreatedBy    string    `bson:"createdby"`
 	DateCreated  time.Time `bson:"datecreated"`
+	// Removed users are kept so that their names cannot be reused.
+	Removed bool `bson:"removed,omitempty"`
 }
 
 type userLastLoginDoc struct {
@@ -423,6 +427,77 @@ func (u *User) IsDisabled() bool {
 	return u.doc.Deactivated
 }
 
+// RemoveUser removes the user, revoking their access to all models.
+// Local users are marked as removed rather than deleted, so that their
+// name cannot be reused by a new user who could then log in with any
+// macaroons issued to the removed one; records of external users are
+// deleted. A user that owns models cannot be removed until ownership
+// of the models has been changed.
+func (st *State) RemoveUser(tag names.UserTag) error {
+	if err := st.removeUser(tag); err != nil {
+		return errors.Annotatef(err, "cannot remove user %q", tag.Canonical())
+	}
+	return nil
+}
+
+func (st *State) removeUser(tag names.UserTag) error {
+	models, closer := st.getCollection(modelsC)
+	defer closer()
+
+	var owned []modelDoc
+	if err := models.Find(bson.D{{"owner", tag.Canonical()}}).All(&owned); err != nil {
+		return errors.Trace(err)
+	}
+	if len(owned) > 0 {
+		modelNames := make([]string, len(owned))
+		for i, doc := range owned {
+			modelNames[i] = fmt.Sprintf("%q", doc.Name)
+		}
+		sort.Strings(modelNames)
+		return errors.Errorf("user owns models %s", strings.Join(modelNames, ", "))
+	}
+
+	userModels, err := st.ModelsForUser(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if tag.IsLocal() {
+		if err := st.markUserRemoved(tag.Name()); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		err := st.removeExternalUser(tag)
+		if errors.IsNotFound(err) && len(userModels) > 0 {
+			// The user has never logged in, but has been
+			// granted access to models.
+			err = nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, userModel := range userModels {
+		if err := userModel.removeUser(tag); err != nil {
+			return errors.Annotatef(err, "cannot revoke access to model %q", userModel.Name())
+		}
+	}
+	return nil
+}
+
+func (st *State) markUserRemoved(name string) error {
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     strings.ToLower(name),
+		Assert: bson.D{{"removed", bson.D{{"$ne", true}}}},
+		Update: bson.D{{"$set", bson.D{{"removed", true}}}},
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("user %q", name)
+	}
+	return errors.Trace(err)
+}
+
 // userList type is used to provide the methods for sorting.
 type userList []*User
 
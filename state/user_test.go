@@ -240,6 +240,70 @@ func (s *UserSuite) TestAllUsers(c *gc.C) {
 	c.Check(users[6].Name(), gc.Equals, "test-admin")
 }
 
+func (s *UserSuite) TestRemoveUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	factory.NewFactory(st).MakeModelUser(c, &factory.ModelUserParams{User: "bob"})
+
+	err := s.State.RemoveUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.User(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `user "bob" has been removed`)
+	_, err = s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = st.ModelUser(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	users, err := s.State.AllUsers(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, gc.HasLen, 1)
+	c.Assert(users[0].Name(), gc.Equals, "test-admin")
+
+	// The name of a removed user cannot be reused.
+	_, err = s.State.AddUser("bob", "Bob", "password", "test-admin")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	err = s.State.RemoveUser(user.UserTag())
+	c.Assert(err, gc.ErrorMatches, `cannot remove user "bob@local": user "bob" not found`)
+}
+
+func (s *UserSuite) TestRemoveUserOwningModels(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "bobs-model", Owner: user.UserTag()})
+	defer st.Close()
+
+	err := s.State.RemoveUser(user.UserTag())
+	c.Assert(err, gc.ErrorMatches, `cannot remove user "bob@local": user owns models "bobs-model"`)
+	_, err = s.State.User(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UserSuite) TestRemoveControllerModelOwner(c *gc.C) {
+	err := s.State.RemoveUser(s.Owner)
+	c.Assert(err, gc.ErrorMatches, `cannot remove user "test-admin@local": user owns models .*`)
+}
+
+func (s *UserSuite) TestRemoveExternalUser(c *gc.C) {
+	bob := names.NewUserTag("bob@external")
+	err := s.State.UpdateExternalUser(bob, "Bob", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddModelUser(state.ModelUserSpec{User: bob, CreatedBy: s.Owner})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ExternalUser(bob)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ModelUser(bob)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveUser(bob)
+	c.Assert(err, gc.ErrorMatches, `cannot remove user "bob@external": external user "bob@external" not found`)
+}
+
 func (s *UserSuite) TestAddUserNoSecretKey(c *gc.C) {
 	u, err := s.State.AddUser("bob", "display", "pass", "admin")
 	c.Assert(err, jc.ErrorIsNil)
@@ -1268,6 +1268,13 @@ func (st *State) WatchControllerInfo() NotifyWatcher {
 	return newEntityWatcher(st, controllersC, modelGlobalKey)
 }
 
+// WatchControllerMaintenance returns a NotifyWatcher that notifies
+// when the controller enters or leaves maintenance mode, or its
+// maintenance mode is changed.
+func (st *State) WatchControllerMaintenance() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, controllerMaintenanceKey)
+}
+
 // Watch returns a watcher for observing changes to a machine.
 func (m *Machine) Watch() NotifyWatcher {
 	return newEntityWatcher(m.st, machinesC, m.doc.DocID)
@@ -1308,9 +1315,10 @@ func (st *State) WatchRestoreInfoChanges() NotifyWatcher {
 }
 
 // WatchForModelConfigChanges returns a NotifyWatcher waiting for the Model
-// Config to change.
+// Config to change. This includes changes to the model defaults of the
+// model's cloud region and to the model's cloud credential, if it has one.
 func (st *State) WatchForModelConfigChanges() NotifyWatcher {
-	return newDocWatcher(st, []docKey{
+	docKeys := []docKey{
 		{
 			settingsC,
 			st.docID(modelGlobalKey),
@@ -1321,7 +1329,62 @@ func (st *State) WatchForModelConfigChanges() NotifyWatcher {
 			controllersC,
 			defaultModelSettingsGlobalKey,
 		},
-	})
+	}
+	if regionKey, ok := st.modelRegionSettingsKey(); ok {
+		docKeys = append(docKeys, regionKey)
+	}
+	if credentialKey, ok := st.modelCloudCredentialKey(); ok {
+		docKeys = append(docKeys, credentialKey)
+	}
+	return newDocWatcher(st, docKeys)
+}
+
+// modelRegionSettingsKey returns the key of the model defaults document
+// of the model's cloud region, and whether the model is in a region.
+// Errors are logged rather than returned, since the model's other config
+// is still worth watching.
+func (st *State) modelRegionSettingsKey() (docKey, bool) {
+	model, err := st.Model()
+	if err != nil {
+		logger.Errorf("cannot read model to watch its region model defaults: %v", err)
+		return docKey{}, false
+	}
+	if model.CloudRegion() == "" {
+		return docKey{}, false
+	}
+	cloudName, _, err := st.modelCloudName()
+	if err != nil {
+		logger.Errorf("cannot read model cloud to watch its region model defaults: %v", err)
+		return docKey{}, false
+	}
+	return docKey{
+		controllersC,
+		regionSettingsGlobalKey(cloudName, model.CloudRegion()),
+	}, true
+}
+
+// modelCloudCredentialKey returns the key of the model's cloud credential
+// document, and whether the model has one. Errors are logged rather than
+// returned, since the model's other config is still worth watching.
+func (st *State) modelCloudCredentialKey() (docKey, bool) {
+	model, err := st.Model()
+	if err != nil {
+		logger.Errorf("cannot read model to watch its cloud credential: %v", err)
+		return docKey{}, false
+	}
+	credentialName, ok := model.CloudCredential()
+	if !ok {
+		return docKey{}, false
+	}
+	cloudName, _, err := st.modelCloudName()
+	if err != nil {
+		logger.Errorf("cannot read model cloud to watch its cloud credential: %v", err)
+		return docKey{}, false
+	}
+	return docKey{
+		cloudCredentialsC,
+		cloudCredentialDocID(model.cloudCredentialOwner(), cloudName, credentialName),
+	}, true
 }
 
 // WatchForUnitAssignment watches for new services that request units to be
@@ -1756,6 +1819,63 @@ func (w *cleanupWatcher) loop() (err error) {
 	}
 }
 
+// secretsWatcher notifies of changes in the secrets collection.
+type secretsWatcher struct {
+	commonWatcher
+	out chan struct{}
+}
+
+var _ Watcher = (*secretsWatcher)(nil)
+
+// WatchSecrets returns a NotifyWatcher that notifies when any secret in
+// the unit's model is added, rotated, granted, revoked or removed. The
+// unit is expected to check SecretRevisions for the changes relevant
+// to it.
+func (u *Unit) WatchSecrets() NotifyWatcher {
+	return newSecretsWatcher(u.st)
+}
+
+func newSecretsWatcher(st *State) NotifyWatcher {
+	w := &secretsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *secretsWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *secretsWatcher) loop() (err error) {
+	in := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(secretsC, in, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(secretsC, in)
+
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // actionStatusWatcher is a StringsWatcher that filters notifications
 // to Action Id's that match the ActionReceiver and ActionStatus set
 // provided.
@@ -2451,6 +2571,154 @@ func (w *rebootWatcher) loop() error {
 	}
 }
 
+// WatchExposedPorts returns a NotifyWatcher which notifies when the
+// ports opened on the machine, or the exposed state of any application,
+// change. It's used to keep the rules of a machine-managed firewall in
+// sync with ExposedPortRanges.
+func (m *Machine) WatchExposedPorts() NotifyWatcher {
+	return newExposedPortsWatcher(m.st, m.Id())
+}
+
+type exposedPortsWatcher struct {
+	commonWatcher
+	machineId string
+	out       chan struct{}
+}
+
+var _ NotifyWatcher = (*exposedPortsWatcher)(nil)
+
+func newExposedPortsWatcher(st *State, machineId string) NotifyWatcher {
+	w := &exposedPortsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		machineId:     machineId,
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for the exposedPortsWatcher.
+func (w *exposedPortsWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *exposedPortsWatcher) loop() error {
+	portsPrefix := portsGlobalKey(w.machineId, "")
+	portsFilter := func(key interface{}) bool {
+		if id, ok := key.(string); ok {
+			if id, err := w.st.strictLocalID(id); err == nil {
+				return strings.HasPrefix(id, portsPrefix)
+			}
+		}
+		return false
+	}
+	portsCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(openedPortsC, portsCh, portsFilter)
+	defer w.watcher.UnwatchCollection(openedPortsC, portsCh)
+	applicationsCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(applicationsC, applicationsCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(applicationsC, applicationsCh)
+
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-portsCh:
+			if _, ok := collect(ch, portsCh, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case ch := <-applicationsCh:
+			if _, ok := collect(ch, applicationsCh, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// WatchModelIngressCIDRs returns a NotifyWatcher which notifies when
+// the CIDRs returned by ModelIngressCIDRs change.
+func (st *State) WatchModelIngressCIDRs() NotifyWatcher {
+	return newModelIngressCIDRsWatcher(st)
+}
+
+type modelIngressCIDRsWatcher struct {
+	commonWatcher
+	out chan struct{}
+}
+
+var _ NotifyWatcher = (*modelIngressCIDRsWatcher)(nil)
+
+func newModelIngressCIDRsWatcher(st *State) NotifyWatcher {
+	w := &modelIngressCIDRsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for the modelIngressCIDRsWatcher.
+func (w *modelIngressCIDRsWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *modelIngressCIDRsWatcher) loop() error {
+	machinesCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(machinesC, machinesCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(machinesC, machinesCh)
+	addressesCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(ipAddressesC, addressesCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(ipAddressesC, addressesCh)
+
+	cidrs, err := w.st.ModelIngressCIDRs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-machinesCh:
+			if _, ok := collect(ch, machinesCh, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+		case ch := <-addressesCh:
+			if _, ok := collect(ch, addressesCh, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+		case out <- struct{}{}:
+			out = nil
+			continue
+		}
+		newCIDRs, err := w.st.ModelIngressCIDRs()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !reflect.DeepEqual(newCIDRs, cidrs) {
+			cidrs = newCIDRs
+			out = w.out
+		}
+	}
+}
+
 // blockDevicesWatcher notifies about changes to all block devices
 // associated with a machine.
 type blockDevicesWatcher struct {
@@ -21,6 +21,10 @@ const ShortWait = 50 * time.Millisecond
 // test suite
 const LongWait = 10 * time.Second
 
+// SecretsKey is a valid key for encrypting the values of charm
+// secrets.
+const SecretsKey = "gWnFpz+yvGRfExAMXpKxuJPIrC7K1RzF3Eg+x3e+5vU="
+
 var LongAttempt = &utils.AttemptStrategy{
 	Total: LongWait,
 	Delay: ShortWait,
@@ -76,6 +76,10 @@ type InstanceSpec struct {
 	// Metadata is the instance metadata.
 	Metadata map[string]string
 
+	// Limits holds resource limit settings (e.g. "limits.memory" or
+	// "limits.cpu") that are applied to the container as-is.
+	Limits map[string]string
+
 	// Devices to be added at container initialisation time
 	Devices
 
@@ -87,7 +91,11 @@ type InstanceSpec struct {
 }
 
 func (spec InstanceSpec) config() map[string]string {
-	return resolveMetadata(spec.Metadata)
+	config := resolveMetadata(spec.Metadata)
+	for key, val := range spec.Limits {
+		config[key] = val
+	}
+	return config
 }
 
 func (spec InstanceSpec) info(namespace string) *shared.ContainerInfo {
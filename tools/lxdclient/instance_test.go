@@ -100,3 +100,20 @@ func (s *instanceSuite) TestNewInstanceSummaryArchitectures(c *gc.C) {
 	summary = lxdclient.NewInstanceSummary(&info)
 	c.Check(summary.Hardware.Architecture, gc.Equals, "unknown")
 }
+
+func (s *instanceSuite) TestInstanceSpecSummaryLimits(c *gc.C) {
+	spec := lxdclient.InstanceSpec{
+		Name: "container-name",
+		Metadata: map[string]string{
+			"something": "something value",
+		},
+		Limits: map[string]string{
+			"limits.cpu":    "4",
+			"limits.memory": "2048MB",
+		},
+	}
+	summary := spec.Summary("")
+	c.Check(summary.Hardware.NumCores, gc.Equals, uint(4))
+	c.Check(summary.Hardware.MemoryMB, gc.Equals, uint(2048))
+	c.Check(summary.Metadata, gc.DeepEquals, map[string]string{"something": "something value"})
+}
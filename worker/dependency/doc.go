@@ -193,7 +193,7 @@ In each worker package, write a `manifold.go` containing the following:
                 if err := getResource(config.APICallerName, &apicaller); err != nil {
                     return nil, err
                 }
-                var machineLock *fslock.Lock
+                var machineLock *machinelock.Lock
                 if err := getResource(config.MachineLockName, &machineLock); err != nil {
                     return nil, err
                 }
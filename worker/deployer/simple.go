@@ -148,8 +148,9 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 			APIAddresses:   result.APIAddresses,
 			CACert:         ctx.agentConfig.CACert(),
 			Values: map[string]string{
-				agent.ContainerType: containerType,
-				agent.Namespace:     namespace,
+				agent.ContainerType:    containerType,
+				agent.Namespace:        namespace,
+				agent.MachineLockSlots: ctx.agentConfig.Value(agent.MachineLockSlots),
 			},
 		})
 	if err != nil {
@@ -22,6 +22,7 @@ func (*ConfigSuite) TestAllSet(c *gc.C) {
 	config := discoverspaces.Config{
 		Facade:   fakeFacade{},
 		Environ:  fakeEnviron{},
+		Clock:    fakeClock{},
 		NewName:  fakeNewName,
 		Unlocker: fakeUnlocker{},
 	}
@@ -32,6 +33,7 @@ func (*ConfigSuite) TestNilUnlocker(c *gc.C) {
 	config := discoverspaces.Config{
 		Facade:  fakeFacade{},
 		Environ: fakeEnviron{},
+		Clock:   fakeClock{},
 		NewName: fakeNewName,
 	}
 	checkConfigValid(c, config)
@@ -44,14 +46,25 @@ func checkConfigValid(c *gc.C, config discoverspaces.Config) {
 func (*ConfigSuite) TestNilFacade(c *gc.C) {
 	config := discoverspaces.Config{
 		Environ: fakeEnviron{},
+		Clock:   fakeClock{},
 		NewName: fakeNewName,
 	}
 	checkAlwaysInvalid(c, config, "nil Facade not valid")
 }
 
+func (*ConfigSuite) TestNilClock(c *gc.C) {
+	config := discoverspaces.Config{
+		Facade:  fakeFacade{},
+		Environ: fakeEnviron{},
+		NewName: fakeNewName,
+	}
+	checkAlwaysInvalid(c, config, "nil Clock not valid")
+}
+
 func (*ConfigSuite) TestNilEnviron(c *gc.C) {
 	config := discoverspaces.Config{
 		Facade:  fakeFacade{},
+		Clock:   fakeClock{},
 		NewName: fakeNewName,
 	}
 	checkAlwaysInvalid(c, config, "nil Environ not valid")
@@ -61,6 +74,7 @@ func (*ConfigSuite) TestNilNewName(c *gc.C) {
 	config := discoverspaces.Config{
 		Facade:  fakeFacade{},
 		Environ: fakeEnviron{},
+		Clock:   fakeClock{},
 	}
 	checkAlwaysInvalid(c, config, "nil NewName not valid")
 }
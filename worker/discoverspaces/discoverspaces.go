@@ -4,8 +4,11 @@
 package discoverspaces
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -23,6 +26,7 @@ type Facade interface {
 	AddSubnets(params.AddSubnetsParams) (params.ErrorResults, error)
 	ListSpaces() (params.DiscoverSpacesResults, error)
 	ListSubnets(params.SubnetsFilters) (params.ListSubnetsResults, error)
+	ReconcileSpaceDrift(params.ReconcileSpaceDriftParams) (params.SpaceDriftResults, error)
 }
 
 // NameFunc returns a string derived from base that is not contained in used.
@@ -37,6 +41,10 @@ type Config struct {
 	// Environ exposes the capabilities of a compute substrate.
 	Environ environs.Environ
 
+	// Clock is used to schedule periodic reconciliation of the
+	// discovered spaces and subnets with those known to the model.
+	Clock clock.Clock
+
 	// NewName is used to sanitise, and make unique, space names as
 	// reported by an Environ (for use in juju, via the Facade). You
 	// should probably set it to ConvertSpaceName.
@@ -56,6 +64,9 @@ func (config Config) Validate() error {
 	if config.Environ == nil {
 		return errors.NotValidf("nil Environ")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	if config.NewName == nil {
 		return errors.NotValidf("nil NewName")
 	}
@@ -65,6 +76,18 @@ func (config Config) Validate() error {
 
 var logger = loggo.GetLogger("juju.worker.discoverspaces")
 
+// reconcileInterval is how often the worker compares the provider's
+// spaces and subnets with those known to the model.
+const reconcileInterval = 15 * time.Minute
+
+// The kinds of space drift reported to the controller.
+const (
+	subnetAddedDrift   = "subnet-added"
+	subnetRemovedDrift = "subnet-removed"
+	subnetMovedDrift   = "subnet-moved"
+	spaceRemovedDrift  = "space-removed"
+)
+
 type discoverspacesWorker struct {
 	catacomb catacomb.Catacomb
 	config   Config
@@ -76,9 +99,9 @@ type discoverspacesWorker struct {
 // supplied Unlocker will be Unlock()ed when the first complete
 // discovery and update succeeds.
 //
-// Once that update completes, the worker just waits to be Kill()ed.
-// We should probably poll for changes, really, but I'm making an
-// effort to preserve existing behaviour where possible.
+// After that, the worker periodically repeats discovery, reporting
+// any drift between the provider and the model to the controller,
+// which updates the model where it's safe to do so.
 func NewWorker(config Config) (worker.Worker, error) {
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -108,13 +131,10 @@ func (dw *discoverspacesWorker) Wait() error {
 
 func (dw *discoverspacesWorker) loop() (err error) {
 
-	// TODO(mfoord): we'll have a watcher here checking if we need to
-	// update the spaces/subnets definition.
-	// TODO(fwereade): for now, use a changes channel that apes the
-	// standard initial event behaviour, so we can make the loop
-	// follow the standard structure.
-	changes := make(chan struct{}, 1)
-	changes <- struct{}{}
+	// The provider offers no way to watch for changes to spaces and
+	// subnets, so we poll; the first discovery attempt happens at
+	// once.
+	changes := dw.config.Clock.After(0)
 
 	gate := dw.config.Unlocker
 	for {
@@ -130,6 +150,7 @@ func (dw *discoverspacesWorker) loop() (err error) {
 				gate.Unlock()
 				gate = nil
 			}
+			changes = dw.config.Clock.After(reconcileInterval)
 		}
 	}
 }
@@ -172,11 +193,13 @@ func (dw *discoverspacesWorker) handleSubnets() error {
 		spaceNames.Add(space.Name)
 	}
 
-	// TODO(mfoord): we need to delete spaces and subnets that no longer
-	// exist, so long as they're not in use.
 	var createSpacesArgs params.CreateSpacesParams
 	var addSubnetsArgs params.AddSubnetsParams
+	var drift []params.SpaceDrift
+	providerSpaceIds := make(set.Strings)
+	providerSubnetSpaces := make(map[string]string)
 	for _, space := range providerSpaces {
+		providerSpaceIds.Add(string(space.ProviderId))
 		// Check if the space is already in state, in which case we know
 		// its name.
 		stateSpace, ok := stateSpaceMap[string(space.ProviderId)]
@@ -208,10 +231,10 @@ func (dw *discoverspacesWorker) handleSubnets() error {
 				ProviderId: string(space.ProviderId),
 			})
 		}
-		// TODO(mfoord): currently no way of removing subnets, or
-		// changing the space they're in, so we can only add ones we
-		// don't already know about.
+		// Subnets we already know about are compared with the model
+		// below; here we only add the ones we don't.
 		for _, subnet := range space.Subnets {
+			providerSubnetSpaces[string(subnet.ProviderId)] = spaceTag.Id()
 			if stateSubnetIds.Contains(string(subnet.ProviderId)) {
 				continue
 			}
@@ -228,8 +251,18 @@ func (dw *discoverspacesWorker) handleSubnets() error {
 				SpaceTag:         spaceTag.String(),
 				Zones:            zones,
 			})
+			drift = append(drift, params.SpaceDrift{
+				Kind:       subnetAddedDrift,
+				SpaceName:  spaceTag.Id(),
+				CIDR:       subnet.CIDR,
+				ProviderId: string(subnet.ProviderId),
+			})
 		}
 	}
+	drift = append(drift, stateDrift(
+		stateSubnets.Results, listSpacesResult.Results,
+		providerSubnetSpaces, providerSpaceIds,
+	)...)
 
 	if err := dw.createSpacesFromArgs(createSpacesArgs); err != nil {
 		return errors.Trace(err)
@@ -239,6 +272,80 @@ func (dw *discoverspacesWorker) handleSubnets() error {
 		return errors.Trace(err)
 	}
 
+	if err := dw.reconcileDrift(drift); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+// stateDrift returns the drift of the model's provider-backed subnets and
+// spaces from those known to the provider, given the juju space name
+// for each provider subnet id, and all provider space ids.
+func stateDrift(
+	stateSubnets []params.Subnet,
+	stateSpaces []params.ProviderSpace,
+	providerSubnetSpaces map[string]string,
+	providerSpaceIds set.Strings,
+) []params.SpaceDrift {
+	var drift []params.SpaceDrift
+	for _, subnet := range stateSubnets {
+		if subnet.ProviderId == "" {
+			// Not discovered from the provider, so not ours to check.
+			continue
+		}
+		var spaceName string
+		if spaceTag, err := names.ParseSpaceTag(subnet.SpaceTag); err == nil {
+			spaceName = spaceTag.Id()
+		}
+		providerSpaceName, ok := providerSubnetSpaces[subnet.ProviderId]
+		switch {
+		case !ok:
+			drift = append(drift, params.SpaceDrift{
+				Kind:       subnetRemovedDrift,
+				SpaceName:  spaceName,
+				CIDR:       subnet.CIDR,
+				ProviderId: subnet.ProviderId,
+			})
+		case providerSpaceName != spaceName:
+			drift = append(drift, params.SpaceDrift{
+				Kind:        subnetMovedDrift,
+				SpaceName:   spaceName,
+				CIDR:        subnet.CIDR,
+				ProviderId:  subnet.ProviderId,
+				ToSpaceName: providerSpaceName,
+			})
+		}
+	}
+	for _, space := range stateSpaces {
+		if space.ProviderId == "" || providerSpaceIds.Contains(space.ProviderId) {
+			continue
+		}
+		drift = append(drift, params.SpaceDrift{
+			Kind:       spaceRemovedDrift,
+			SpaceName:  space.Name,
+			ProviderId: space.ProviderId,
+		})
+	}
+	return drift
+}
+
+func (dw *discoverspacesWorker) reconcileDrift(drift []params.SpaceDrift) error {
+	result, err := dw.config.Facade.ReconcileSpaceDrift(params.ReconcileSpaceDriftParams{
+		Drift: drift,
+	})
+	if err != nil {
+		return errors.Annotate(err, "reconciling space drift failed")
+	}
+	for _, entry := range result.Results {
+		if entry.Conflict != "" {
+			logger.Warningf(
+				"cannot reconcile %s (space %q, subnet %q): %s",
+				entry.Kind, entry.SpaceName, entry.CIDR, entry.Conflict,
+			)
+		}
+	}
+	logger.Debugf("reconciled %d space drift entries", len(result.Results))
 	return nil
 }
 
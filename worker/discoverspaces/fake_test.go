@@ -4,6 +4,7 @@
 package discoverspaces_test
 
 import (
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/api/base"
@@ -25,6 +26,10 @@ type fakeFacade struct {
 	discoverspaces.Facade
 }
 
+type fakeClock struct {
+	clock.Clock
+}
+
 type fakeEnviron struct {
 	environs.NetworkingEnviron
 }
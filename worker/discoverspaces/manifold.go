@@ -5,6 +5,7 @@ package discoverspaces
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/discoverspaces"
@@ -17,6 +18,7 @@ import (
 
 type ManifoldConfig struct {
 	APICallerName string
+	ClockName     string
 	EnvironName   string
 	UnlockerName  string
 
@@ -29,7 +31,7 @@ func NewFacade(apiCaller base.APICaller) (Facade, error) {
 }
 
 func Manifold(config ManifoldConfig) dependency.Manifold {
-	inputs := []string{config.APICallerName, config.EnvironName}
+	inputs := []string{config.APICallerName, config.ClockName, config.EnvironName}
 	if config.UnlockerName != "" {
 		inputs = append(inputs, config.UnlockerName)
 	}
@@ -55,6 +57,11 @@ func startFunc(config ManifoldConfig) dependency.StartFunc {
 			return nil, errors.Trace(err)
 		}
 
+		var clock clock.Clock
+		if err := context.Get(config.ClockName, &clock); err != nil {
+			return nil, errors.Trace(err)
+		}
+
 		var apiCaller base.APICaller
 		if err := context.Get(config.APICallerName, &apiCaller); err != nil {
 			return nil, errors.Trace(err)
@@ -67,6 +74,7 @@ func startFunc(config ManifoldConfig) dependency.StartFunc {
 		w, err := config.NewWorker(Config{
 			Facade:   facade,
 			Environ:  environ,
+			Clock:    clock,
 			NewName:  network.ConvertSpaceName,
 			Unlocker: unlocker,
 		})
@@ -26,7 +26,7 @@ func (*ManifoldSuite) TestInputsWithUnlocker(c *gc.C) {
 	config := namesConfig()
 	manifold := discoverspaces.Manifold(config)
 	c.Check(manifold.Inputs, jc.SameContents, []string{
-		"api-caller", "environ", "unlocker",
+		"api-caller", "clock", "environ", "unlocker",
 	})
 }
 
@@ -35,7 +35,7 @@ func (*ManifoldSuite) TestInputsWithoutUnlocker(c *gc.C) {
 	config.UnlockerName = ""
 	manifold := discoverspaces.Manifold(config)
 	c.Check(manifold.Inputs, jc.SameContents, []string{
-		"api-caller", "environ",
+		"api-caller", "clock", "environ",
 	})
 }
 
@@ -62,6 +62,15 @@ func (*ManifoldSuite) TestEnvironMissing(c *gc.C) {
 	c.Check(worker, gc.IsNil)
 }
 
+func (*ManifoldSuite) TestClockMissing(c *gc.C) {
+	resources := resourcesMissing("clock")
+	manifold := discoverspaces.Manifold(namesConfig())
+
+	worker, err := manifold.Start(resources.Context())
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
 func (*ManifoldSuite) TestUnlockerMissing(c *gc.C) {
 	resources := resourcesMissing("unlocker")
 	manifold := discoverspaces.Manifold(namesConfig())
@@ -95,6 +104,7 @@ func (*ManifoldSuite) TestNewWorkerError(c *gc.C) {
 	config.NewWorker = func(cfg discoverspaces.Config) (worker.Worker, error) {
 		c.Check(cfg.Facade, gc.Equals, expectFacade)
 		checkResource(c, cfg.Environ, resources, "environ")
+		checkResource(c, cfg.Clock, resources, "clock")
 		c.Check(cfg.NewName, gc.NotNil) // uncomparable
 		checkResource(c, cfg.Unlocker, resources, "unlocker")
 		return nil, errors.New("lhiis")
@@ -144,6 +154,7 @@ func (*ManifoldSuite) TestNewWorkerSuccess(c *gc.C) {
 func namesConfig() discoverspaces.ManifoldConfig {
 	return discoverspaces.ManifoldConfig{
 		APICallerName: "api-caller",
+		ClockName:     "clock",
 		EnvironName:   "environ",
 		UnlockerName:  "unlocker",
 	}
@@ -152,6 +163,7 @@ func namesConfig() discoverspaces.ManifoldConfig {
 func resourcesMissing(missing ...string) dt.StubResources {
 	resources := dt.StubResources{
 		"api-caller": dt.StubResource{Output: &fakeAPICaller{}},
+		"clock":      dt.StubResource{Output: &fakeClock{}},
 		"environ":    dt.StubResource{Output: &fakeEnviron{}},
 		"unlocker":   dt.StubResource{Output: &fakeUnlocker{}},
 	}
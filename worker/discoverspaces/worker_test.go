@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -33,6 +34,7 @@ type WorkerSuite struct {
 
 	APIConnection api.Connection
 	API           *checkingFacade
+	Clock         *coretesting.Clock
 
 	numCreateSpaceCalls uint32
 	numAddSubnetsCalls  uint32
@@ -68,6 +70,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.AssertConfigParameterUpdated(c, "broken", "")
 
 	s.APIConnection, _ = s.OpenAPIAsNewMachine(c, state.JobManageModel)
+	s.Clock = coretesting.NewClock(time.Now())
 
 	realAPI := s.APIConnection.DiscoverSpaces()
 	s.API = &checkingFacade{
@@ -154,7 +157,7 @@ func (s *WorkerSuite) TestWorkerIdempotent(c *gc.C) {
 	})
 }
 
-func (s *WorkerSuite) TestWorkerIgnoresExistingSpacesAndSubnets(c *gc.C) {
+func (s *WorkerSuite) TestWorkerReconcilesExistingSpacesAndSubnets(c *gc.C) {
 	dummy.SetSupportsSpaceDiscovery(true)
 	spaceTag := names.NewSpaceTag("foo")
 	args := params.CreateSpacesParams{
@@ -180,10 +183,89 @@ func (s *WorkerSuite) TestWorkerIgnoresExistingSpacesAndSubnets(c *gc.C) {
 	c.Assert(subnetResult.Results[0].Error, gc.IsNil)
 
 	s.unlockCheck(c, func(c *gc.C) {
+		// The provider has subnet "1" in the space with id "0", so
+		// it's moved there, leaving the space "foo" unknown to the
+		// provider empty, so it's removed.
 		spaces, err := s.State.AllSpaces()
 		c.Assert(err, jc.ErrorIsNil)
-		c.Assert(spaces, gc.HasLen, 5)
+		c.Assert(spaces, gc.HasLen, 4)
+		_, err = s.State.Space("foo")
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+		subnet, err := s.State.Subnet("192.168.1.0/24")
+		c.Assert(err, jc.ErrorIsNil)
+		space, err := s.State.Space(subnet.SpaceName())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(space.ProviderId(), gc.Equals, network.Id("0"))
+
+		drift, _, err := s.State.SpaceDrift()
+		c.Assert(err, jc.ErrorIsNil)
+		var kinds []state.SpaceDriftKind
+		for _, entry := range drift {
+			c.Check(entry.Resolved, jc.IsTrue)
+			c.Check(entry.Conflict, gc.Equals, "")
+			if entry.Kind != state.SubnetAddedDrift {
+				kinds = append(kinds, entry.Kind)
+			}
+		}
+		c.Assert(kinds, jc.DeepEquals, []state.SpaceDriftKind{
+			state.SubnetMovedDrift, state.SpaceRemovedDrift,
+		})
+	})
+}
+
+func (s *WorkerSuite) TestWorkerReconcilesPeriodically(c *gc.C) {
+	dummy.SetSupportsSpaceDiscovery(true)
+	worker, lock := s.startWorker(c)
+	defer workertest.CleanKill(c, worker)
+	select {
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("discovery never completed")
+	case <-lock.Unlocked():
+	}
+	s.assertDiscoveredSpaces(c)
+
+	// Add a subnet the provider doesn't know about, and check it's
+	// removed once the worker next compares with the provider.
+	_, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:       "10.0.0.0/24",
+		ProviderId: "gone",
+		SpaceName:  "foo",
 	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitAlarms(c, 2)
+	s.Clock.Advance(15 * time.Minute)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		_, err = s.State.Subnet("10.0.0.0/24")
+		if errors.IsNotFound(err) {
+			break
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		if !a.HasNext() {
+			c.Fatalf("subnet never removed")
+		}
+	}
+	drift, _, err := s.State.SpaceDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drift, jc.DeepEquals, []state.SpaceDrift{{
+		Kind:       state.SubnetRemovedDrift,
+		SpaceName:  "foo",
+		CIDR:       "10.0.0.0/24",
+		ProviderId: "gone",
+		Resolved:   true,
+	}})
+	s.assertDiscoveredSpaces(c)
+}
+
+func (s *WorkerSuite) waitAlarms(c *gc.C, count int) {
+	for i := 0; i < count; i++ {
+		select {
+		case <-s.Clock.Alarms():
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for alarm %d", i)
+		}
+	}
 }
 
 func (s *WorkerSuite) startWorker(c *gc.C) (worker.Worker, gate.Lock) {
@@ -197,6 +279,7 @@ func (s *WorkerSuite) startWorker(c *gc.C) (worker.Worker, gate.Lock) {
 	worker, err := discoverspaces.NewWorker(discoverspaces.Config{
 		Facade:   s.API,
 		Environ:  environ,
+		Clock:    s.Clock,
 		NewName:  network.ConvertSpaceName,
 		Unlocker: lock,
 	})
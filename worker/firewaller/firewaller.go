@@ -87,7 +87,10 @@ func (fw *Firewaller) setUp() error {
 	}
 	switch fw.environ.Config().FirewallMode() {
 	case config.FwInstance:
-	case config.FwGlobal:
+	case config.FwGlobal, config.FwMachine:
+		// In machine mode the machine agents also manage their own
+		// firewalls, and the ports are opened in the global firewall
+		// of providers which have one.
 		fw.globalMode = true
 		fw.globalPortRef = make(map[network.PortRange]int)
 	case config.FwNone:
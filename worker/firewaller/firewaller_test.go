@@ -834,3 +834,32 @@ func (s *NoneModeSuite) TestStopImmediately(c *gc.C) {
 	err = workertest.CheckKilled(c, fw)
 	c.Check(err, jc.ErrorIsNil)
 }
+
+type MachineModeSuite struct {
+	firewallerBaseSuite
+}
+
+var _ = gc.Suite(&MachineModeSuite{})
+
+func (s *MachineModeSuite) SetUpTest(c *gc.C) {
+	s.firewallerBaseSuite.setUpTest(c, config.FwMachine)
+}
+
+func (s *MachineModeSuite) TestOpensPortsGlobally(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	s.startInstance(c, m)
+	err = u.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironPorts(c, []network.PortRange{{80, 90, "tcp"}})
+
+	err = u.ClosePorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironPorts(c, nil)
+}
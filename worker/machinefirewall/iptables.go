@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinefirewall
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+// ingressChain is the name of the chain holding the rules managed by
+// juju. The INPUT chain jumps to it, so any rules added to INPUT by the
+// administrator keep working.
+const ingressChain = "juju-ingress"
+
+// runCommand runs the named command with the given input, returning an
+// error which includes its output if it fails. It's a variable so it
+// can be patched in tests.
+var runCommand = func(input, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Annotatef(err, "%s %s: %s", name, strings.Join(args, " "), strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// NewIptablesFirewall returns a Firewall which manages the ingress
+// rules of the host with iptables and ip6tables (which use nftables
+// underneath on recent distributions). Loopback traffic, established
+// connections and ICMP are always allowed, as is all traffic coming in
+// on the given bridges (so containers can use DHCP and DNS on the host)
+// and the given port ranges; everything else is dropped.
+func NewIptablesFirewall(allowed []network.PortRange, bridges []string) Firewall {
+	return &iptablesFirewall{allowed: allowed, bridges: bridges}
+}
+
+type iptablesFirewall struct {
+	allowed []network.PortRange
+	bridges []string
+}
+
+// SetIngressRules is part of the Firewall interface.
+func (fw *iptablesFirewall) SetIngressRules(ranges []network.PortRange, cidrs []string) error {
+	for _, command := range []string{"iptables", "ip6tables"} {
+		if err := fw.setIngressRules(command, ranges, cidrs); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (fw *iptablesFirewall) setIngressRules(command string, ranges []network.PortRange, cidrs []string) error {
+	ipv6 := command == "ip6tables"
+	icmp := "icmp"
+	if ipv6 {
+		icmp = "ipv6-icmp"
+	}
+	rules := [][]string{
+		{"-i", "lo", "-j", "ACCEPT"},
+		{"-m", "conntrack", "--ctstate", "ESTABLISHED,RELATED", "-j", "ACCEPT"},
+		{"-p", icmp, "-j", "ACCEPT"},
+	}
+	for _, bridge := range fw.bridges {
+		rules = append(rules, []string{"-i", bridge, "-j", "ACCEPT"})
+	}
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.Annotatef(err, "invalid CIDR %q", cidr)
+		}
+		if (ip.To4() == nil) != ipv6 {
+			continue
+		}
+		rules = append(rules, []string{"-s", cidr, "-j", "ACCEPT"})
+	}
+	allowed := append(append([]network.PortRange(nil), fw.allowed...), ranges...)
+	for _, portRange := range allowed {
+		protocol := strings.ToLower(portRange.Protocol)
+		if protocol == "icmp" {
+			// ICMP is always allowed.
+			continue
+		}
+		rules = append(rules, []string{
+			"-p", protocol,
+			"--dport", fmt.Sprintf("%d:%d", portRange.FromPort, portRange.ToPort),
+			"-j", "ACCEPT",
+		})
+	}
+	rules = append(rules, []string{"-j", "DROP"})
+
+	// Replace the chain's rules in a single transaction, creating the
+	// chain if necessary. The chain is never seen partly filled, and if
+	// the transaction fails the previous rules are left in place.
+	var input bytes.Buffer
+	fmt.Fprintln(&input, "*filter")
+	fmt.Fprintf(&input, ":%s - [0:0]\n", ingressChain)
+	for _, rule := range rules {
+		fmt.Fprintf(&input, "-A %s %s\n", ingressChain, strings.Join(rule, " "))
+	}
+	fmt.Fprintln(&input, "COMMIT")
+	if err := runCommand(input.String(), command+"-restore", "--noflush"); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Jump to the chain, now that it's filled, if not already doing so.
+	if err := runCommand("", command, "-C", "INPUT", "-j", ingressChain); err != nil {
+		if err := runCommand("", command, "-I", "INPUT", "-j", ingressChain); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
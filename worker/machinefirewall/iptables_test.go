@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinefirewall_test
+
+import (
+	"errors"
+	"strings"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/machinefirewall"
+)
+
+type IptablesSuite struct {
+	jujutesting.IsolationSuite
+
+	commands []string
+	inputs   map[string]string
+	failing  map[string]bool
+}
+
+var _ = gc.Suite(&IptablesSuite{})
+
+func (s *IptablesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.commands = nil
+	s.inputs = make(map[string]string)
+	s.failing = make(map[string]bool)
+	s.PatchValue(machinefirewall.RunCommand, func(input, name string, args ...string) error {
+		command := name + " " + strings.Join(args, " ")
+		s.commands = append(s.commands, command)
+		if input != "" {
+			s.inputs[name] = input
+		}
+		if s.failing[command] {
+			return errors.New("failed")
+		}
+		return nil
+	})
+}
+
+func (s *IptablesSuite) TestSetIngressRules(c *gc.C) {
+	fw := machinefirewall.NewIptablesFirewall([]network.PortRange{{22, 22, "tcp"}}, []string{"lxdbr0"})
+	err := fw.SetIngressRules([]network.PortRange{
+		{80, 80, "TCP"},
+		{53, 53, "udp"},
+		{-1, -1, "icmp"},
+	}, []string{"10.0.0.0/24", "10.0.0.5/32", "2001:db8::/64"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"iptables-restore --noflush",
+		"iptables -C INPUT -j juju-ingress",
+		"ip6tables-restore --noflush",
+		"ip6tables -C INPUT -j juju-ingress",
+	})
+	c.Assert(s.inputs["iptables-restore"], gc.Equals, `*filter
+:juju-ingress - [0:0]
+-A juju-ingress -i lo -j ACCEPT
+-A juju-ingress -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT
+-A juju-ingress -p icmp -j ACCEPT
+-A juju-ingress -i lxdbr0 -j ACCEPT
+-A juju-ingress -s 10.0.0.0/24 -j ACCEPT
+-A juju-ingress -s 10.0.0.5/32 -j ACCEPT
+-A juju-ingress -p tcp --dport 22:22 -j ACCEPT
+-A juju-ingress -p tcp --dport 80:80 -j ACCEPT
+-A juju-ingress -p udp --dport 53:53 -j ACCEPT
+-A juju-ingress -j DROP
+COMMIT
+`)
+	c.Assert(s.inputs["ip6tables-restore"], gc.Equals, `*filter
+:juju-ingress - [0:0]
+-A juju-ingress -i lo -j ACCEPT
+-A juju-ingress -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT
+-A juju-ingress -p ipv6-icmp -j ACCEPT
+-A juju-ingress -i lxdbr0 -j ACCEPT
+-A juju-ingress -s 2001:db8::/64 -j ACCEPT
+-A juju-ingress -p tcp --dport 22:22 -j ACCEPT
+-A juju-ingress -p tcp --dport 80:80 -j ACCEPT
+-A juju-ingress -p udp --dport 53:53 -j ACCEPT
+-A juju-ingress -j DROP
+COMMIT
+`)
+}
+
+func (s *IptablesSuite) TestSetIngressRulesAddsJump(c *gc.C) {
+	s.failing["iptables -C INPUT -j juju-ingress"] = true
+	fw := machinefirewall.NewIptablesFirewall(nil, nil)
+	err := fw.SetIngressRules(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands[:3], jc.DeepEquals, []string{
+		"iptables-restore --noflush",
+		"iptables -C INPUT -j juju-ingress",
+		"iptables -I INPUT -j juju-ingress",
+	})
+}
+
+func (s *IptablesSuite) TestSetIngressRulesError(c *gc.C) {
+	// A failed restore leaves the previous rules in place, and the
+	// chain is not jumped to if it wasn't already.
+	s.failing["iptables-restore --noflush"] = true
+	fw := machinefirewall.NewIptablesFirewall(nil, nil)
+	err := fw.SetIngressRules(nil, nil)
+	c.Assert(err, gc.ErrorMatches, "failed")
+	c.Assert(s.commands, jc.DeepEquals, []string{"iptables-restore --noflush"})
+}
+
+func (s *IptablesSuite) TestSetIngressRulesInvalidCIDR(c *gc.C) {
+	fw := machinefirewall.NewIptablesFirewall(nil, nil)
+	err := fw.SetIngressRules(nil, []string{"10.0.0.5"})
+	c.Assert(err, gc.ErrorMatches, `invalid CIDR "10.0.0.5": .*`)
+	c.Assert(s.commands, gc.HasLen, 0)
+}
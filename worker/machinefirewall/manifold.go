@@ -0,0 +1,140 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinefirewall
+
+import (
+	"runtime"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/container"
+	environsconfig "github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// sshPort is always allowed in, so machines remain reachable by
+// juju ssh and the administrator whatever is exposed.
+const sshPort = 22
+
+// containerBridges are the bridges LXD, LXC and KVM containers are
+// attached to by default. All traffic from them is allowed in, so the
+// containers can get their addresses and resolve names from the host.
+var containerBridges = []string{
+	container.DefaultLxdBridge,
+	container.DefaultLxcBridge,
+	container.DefaultKvmBridge,
+}
+
+// ManifoldConfig defines the names of the manifolds on which the
+// machinefirewall worker depends.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	NewFacade   func(base.APICaller) (Facade, error)
+	NewFirewall func(allowed []network.PortRange, bridges []string) Firewall
+	NewWorker   func(Config) (worker.Worker, error)
+}
+
+// validate is called by start to check for bad configuration.
+func (config ManifoldConfig) validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewFirewall == nil {
+		return errors.NotValidf("nil NewFirewall")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if runtime.GOOS == "windows" {
+		logger.Debugf("host firewall management is not supported on Windows machines")
+		return nil, dependency.ErrUninstall
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	agentConfig := agent.CurrentConfig()
+	tag := agentConfig.Tag()
+	if _, ok := tag.(names.MachineTag); !ok {
+		return nil, errors.New("machinefirewall may only be used with a machine agent")
+	}
+
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The firewall mode of a model can't be changed, so the worker
+	// isn't needed again if the model doesn't use machine firewalls.
+	mode, err := facade.FirewallMode()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mode != environsconfig.FwMachine {
+		logger.Debugf("firewall mode is %q, not managing host firewall", mode)
+		return nil, dependency.ErrUninstall
+	}
+
+	allowed := []network.PortRange{tcpPort(sshPort)}
+	if info, ok := agentConfig.StateServingInfo(); ok {
+		// Controllers must remain reachable by agents and clients,
+		// and by the other controllers' mongo.
+		allowed = append(allowed,
+			tcpPort(info.APIPort),
+			tcpPort(info.StatePort),
+		)
+	}
+
+	worker, err := config.NewWorker(Config{
+		Facade:    facade,
+		MachineId: tag.Id(),
+		Firewall:  config.NewFirewall(allowed, containerBridges),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+func tcpPort(port int) network.PortRange {
+	return network.PortRange{FromPort: port, ToPort: port, Protocol: "tcp"}
+}
+
+// Manifold returns a dependency manifold that runs the machinefirewall
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinefirewall_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/machinefirewall"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+
+	caller  *fakeCaller
+	facade  *fakeFacade
+	config  machinefirewall.ManifoldConfig
+	allowed []network.PortRange
+	bridges []string
+	started *machinefirewall.Config
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.caller = &fakeCaller{}
+	s.facade = &fakeFacade{mode: "machine"}
+	s.allowed = nil
+	s.bridges = nil
+	s.started = nil
+	s.config = machinefirewall.ManifoldConfig{
+		AgentName:     "agent",
+		APICallerName: "api-caller",
+		NewFacade: func(apiCaller base.APICaller) (machinefirewall.Facade, error) {
+			c.Assert(apiCaller, gc.Equals, s.caller)
+			return s.facade, nil
+		},
+		NewFirewall: func(allowed []network.PortRange, bridges []string) machinefirewall.Firewall {
+			s.allowed = allowed
+			s.bridges = bridges
+			return &fakeFirewall{}
+		},
+		NewWorker: func(config machinefirewall.Config) (worker.Worker, error) {
+			s.started = &config
+			return &fakeWorker{}, nil
+		},
+	}
+}
+
+func (s *ManifoldSuite) context(tag names.Tag, controller bool) dependency.Context {
+	return dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: tag, controller: controller},
+		"api-caller": s.caller,
+	})
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := machinefirewall.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"agent", "api-caller"})
+}
+
+func (s *ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	manifold := machinefirewall.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewMachineTag("4")},
+		"api-caller": dependency.ErrMissing,
+	})
+	w, err := manifold.Start(context)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartNotMachine(c *gc.C) {
+	manifold := machinefirewall.Manifold(s.config)
+	w, err := manifold.Start(s.context(names.NewUnitTag("mysql/0"), false))
+	c.Assert(err, gc.ErrorMatches, "machinefirewall may only be used with a machine agent")
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartOtherFirewallMode(c *gc.C) {
+	s.facade.mode = "instance"
+	manifold := machinefirewall.Manifold(s.config)
+	w, err := manifold.Start(s.context(names.NewMachineTag("4"), false))
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+	c.Assert(w, gc.IsNil)
+	c.Assert(s.started, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
+	manifold := machinefirewall.Manifold(s.config)
+	w, err := manifold.Start(s.context(names.NewMachineTag("4"), false))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, gc.NotNil)
+	c.Assert(s.started.MachineId, gc.Equals, "4")
+	c.Assert(s.started.Facade, gc.Equals, s.facade)
+	c.Assert(s.allowed, jc.DeepEquals, []network.PortRange{{22, 22, "tcp"}})
+	c.Assert(s.bridges, jc.DeepEquals, []string{"lxdbr0", "lxcbr0", "virbr0"})
+}
+
+func (s *ManifoldSuite) TestStartControllerAllowsControllerPorts(c *gc.C) {
+	manifold := machinefirewall.Manifold(s.config)
+	_, err := manifold.Start(s.context(names.NewMachineTag("0"), true))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.allowed, jc.DeepEquals, []network.PortRange{
+		{22, 22, "tcp"},
+		{17070, 17070, "tcp"},
+		{37017, 37017, "tcp"},
+	})
+}
+
+type fakeAgent struct {
+	agent.Agent
+	tag        names.Tag
+	controller bool
+}
+
+func (mock *fakeAgent) CurrentConfig() agent.Config {
+	return &fakeConfig{tag: mock.tag, controller: mock.controller}
+}
+
+type fakeConfig struct {
+	agent.Config
+	tag        names.Tag
+	controller bool
+}
+
+func (mock *fakeConfig) Tag() names.Tag {
+	return mock.tag
+}
+
+func (mock *fakeConfig) StateServingInfo() (params.StateServingInfo, bool) {
+	if !mock.controller {
+		return params.StateServingInfo{}, false
+	}
+	return params.StateServingInfo{APIPort: 17070, StatePort: 37017}, true
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
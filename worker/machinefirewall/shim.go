@@ -0,0 +1,24 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinefirewall
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apimachinefirewall "github.com/juju/juju/api/machinefirewall"
+	"github.com/juju/juju/worker"
+)
+
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return apimachinefirewall.NewFacade(apiCaller), nil
+}
+
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinefirewall implements a worker which manages the host
+// firewall of a machine when the model uses the "machine" firewall
+// mode, so that only the ports opened by units of exposed applications
+// (and those the agent itself needs) can be reached from outside the
+// model.
+package machinefirewall
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.machinefirewall")
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	FirewallMode() (string, error)
+	WatchExposedPorts(machineId string) (watcher.NotifyWatcher, error)
+	ExposedPortRanges(machineId string) ([]network.PortRange, error)
+	WatchModelIngressCIDRs() (watcher.NotifyWatcher, error)
+	ModelIngressCIDRs() ([]string, error)
+}
+
+// Firewall applies ingress rules to the firewall of the host machine.
+type Firewall interface {
+	// SetIngressRules replaces the ingress rules managed by juju, so
+	// that only the given port ranges, and those the firewall was
+	// configured to always allow, can be reached from outside. All
+	// traffic from the given CIDRs is allowed, so the machines of the
+	// model can always reach each other.
+	SetIngressRules(ranges []network.PortRange, cidrs []string) error
+}
+
+// Config defines the parameters of the machinefirewall worker.
+type Config struct {
+	Facade    Facade
+	MachineId string
+	Firewall  Firewall
+}
+
+// Validate returns an error if Config cannot drive a machinefirewall.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.Firewall == nil {
+		return errors.NotValidf("nil Firewall")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	fw := &machineFirewall{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &fw.catacomb,
+		Work: fw.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return fw, nil
+}
+
+// machineFirewall updates the host firewall whenever the exposed port
+// ranges of the machine, or the CIDRs of the model's machines, change.
+type machineFirewall struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	ranges   []network.PortRange
+	cidrs    []string
+	set      bool
+}
+
+// Kill is part of the worker.Worker interface.
+func (fw *machineFirewall) Kill() {
+	fw.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (fw *machineFirewall) Wait() error {
+	return fw.catacomb.Wait()
+}
+
+func (fw *machineFirewall) loop() error {
+	portsWatcher, err := fw.config.Facade.WatchExposedPorts(fw.config.MachineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := fw.catacomb.Add(portsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	cidrsWatcher, err := fw.config.Facade.WatchModelIngressCIDRs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := fw.catacomb.Add(cidrsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-fw.catacomb.Dying():
+			return fw.catacomb.ErrDying()
+		case _, ok := <-portsWatcher.Changes():
+			if !ok {
+				return errors.New("exposed ports watcher closed")
+			}
+		case _, ok := <-cidrsWatcher.Changes():
+			if !ok {
+				return errors.New("ingress CIDRs watcher closed")
+			}
+		}
+		if err := fw.update(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// update applies the current ingress rules to the host firewall, unless
+// they are already applied.
+func (fw *machineFirewall) update() error {
+	ranges, err := fw.config.Facade.ExposedPortRanges(fw.config.MachineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cidrs, err := fw.config.Facade.ModelIngressCIDRs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if fw.set && reflect.DeepEqual(ranges, fw.ranges) && reflect.DeepEqual(cidrs, fw.cidrs) {
+		return nil
+	}
+	if err := fw.config.Firewall.SetIngressRules(ranges, cidrs); err != nil {
+		return errors.Annotate(err, "cannot update host firewall")
+	}
+	logger.Infof("host firewall of machine %s allows %v, and all traffic from %v", fw.config.MachineId, ranges, cidrs)
+	fw.ranges = ranges
+	fw.cidrs = cidrs
+	fw.set = true
+	return nil
+}
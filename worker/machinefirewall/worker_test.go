@@ -0,0 +1,195 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinefirewall_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/machinefirewall"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	facade   *fakeFacade
+	firewall *fakeFirewall
+	config   machinefirewall.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		mode:         "machine",
+		watcher:      newFakeNotifyWatcher(),
+		cidrsWatcher: newFakeNotifyWatcher(),
+		ranges:       []network.PortRange{{80, 80, "tcp"}},
+		cidrs:        []string{"10.0.0.0/24"},
+	}
+	s.firewall = &fakeFirewall{applied: make(chan appliedRules, 10)}
+	s.config = machinefirewall.Config{
+		Facade:    s.facade,
+		MachineId: "42",
+		Firewall:  s.firewall,
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	s.config.Firewall = nil
+	_, err := machinefirewall.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil Firewall not valid")
+}
+
+func (s *WorkerSuite) TestAppliesRulesOnChange(c *gc.C) {
+	w, err := machinefirewall.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.watcher.changes <- struct{}{}
+	s.assertApplied(c, []network.PortRange{{80, 80, "tcp"}}, []string{"10.0.0.0/24"})
+
+	// Unchanged rules are not applied again.
+	s.facade.watcher.changes <- struct{}{}
+	s.assertNotApplied(c)
+	s.facade.cidrsWatcher.changes <- struct{}{}
+	s.assertNotApplied(c)
+
+	s.facade.setRanges([]network.PortRange{{80, 80, "tcp"}, {443, 443, "tcp"}})
+	s.facade.watcher.changes <- struct{}{}
+	s.assertApplied(c, []network.PortRange{{80, 80, "tcp"}, {443, 443, "tcp"}}, []string{"10.0.0.0/24"})
+
+	s.facade.setCIDRs([]string{"10.0.0.0/24", "10.0.1.0/24"})
+	s.facade.cidrsWatcher.changes <- struct{}{}
+	s.assertApplied(c, []network.PortRange{{80, 80, "tcp"}, {443, 443, "tcp"}}, []string{"10.0.0.0/24", "10.0.1.0/24"})
+}
+
+func (s *WorkerSuite) TestFirewallError(c *gc.C) {
+	s.firewall.err = errors.New("iptables exploded")
+	w, err := machinefirewall.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.facade.watcher.changes <- struct{}{}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot update host firewall: iptables exploded")
+}
+
+func (s *WorkerSuite) assertApplied(c *gc.C, ranges []network.PortRange, cidrs []string) {
+	select {
+	case rules := <-s.firewall.applied:
+		c.Assert(rules, jc.DeepEquals, appliedRules{ranges: ranges, cidrs: cidrs})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for rules to be applied")
+	}
+}
+
+func (s *WorkerSuite) assertNotApplied(c *gc.C) {
+	select {
+	case rules := <-s.firewall.applied:
+		c.Fatalf("unexpected rules applied: %v", rules)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeFacade struct {
+	machinefirewall.Facade
+
+	mu           sync.Mutex
+	mode         string
+	watcher      *fakeNotifyWatcher
+	cidrsWatcher *fakeNotifyWatcher
+	ranges       []network.PortRange
+	cidrs        []string
+}
+
+func (f *fakeFacade) setRanges(ranges []network.PortRange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ranges = ranges
+}
+
+func (f *fakeFacade) setCIDRs(cidrs []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cidrs = cidrs
+}
+
+func (f *fakeFacade) FirewallMode() (string, error) {
+	return f.mode, nil
+}
+
+func (f *fakeFacade) WatchExposedPorts(machineId string) (watcher.NotifyWatcher, error) {
+	return f.watcher, nil
+}
+
+func (f *fakeFacade) ExposedPortRanges(machineId string) ([]network.PortRange, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ranges, nil
+}
+
+func (f *fakeFacade) WatchModelIngressCIDRs() (watcher.NotifyWatcher, error) {
+	return f.cidrsWatcher, nil
+}
+
+func (f *fakeFacade) ModelIngressCIDRs() ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cidrs, nil
+}
+
+type appliedRules struct {
+	ranges []network.PortRange
+	cidrs  []string
+}
+
+type fakeFirewall struct {
+	applied chan appliedRules
+	err     error
+}
+
+func (f *fakeFirewall) SetIngressRules(ranges []network.PortRange, cidrs []string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.applied <- appliedRules{ranges: ranges, cidrs: cidrs}
+	return nil
+}
+
+type fakeNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newFakeNotifyWatcher() *fakeNotifyWatcher {
+	w := &fakeNotifyWatcher{changes: make(chan struct{})}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return w
+}
+
+func (w *fakeNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *fakeNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *fakeNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
@@ -0,0 +1,508 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinelock
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/fslock"
+	"github.com/juju/utils/set"
+)
+
+const (
+	// MaxSlots is the largest number of operations that may hold
+	// the machine lock at once.
+	MaxSlots = 16
+
+	// lockName is the name of the first slot, which is the lock
+	// that was used alone before the machine lock became a
+	// semaphore.
+	lockName = "uniter-hook-execution"
+)
+
+// errSlotBusy is used to stop fslock from waiting for a slot that is
+// already held, so that another slot can be tried instead.
+var errSlotBusy = errors.New("slot busy")
+
+// Config holds the configuration for a machine Lock.
+type Config struct {
+	// LockDir is the directory holding the machine's locks.
+	LockDir string
+
+	// Slots is the number of operations that may hold the lock
+	// at once, unless one of them requires exclusive access.
+	Slots int
+
+	// LockConfig configures the underlying file system locks.
+	LockConfig fslock.LockConfig
+}
+
+// DefaultConfig returns the configuration of a machine lock, held in
+// the locks directory under dataDir, that serialises all operations.
+func DefaultConfig(dataDir string) Config {
+	return Config{
+		LockDir:    filepath.Join(dataDir, "locks"),
+		Slots:      1,
+		LockConfig: fslock.Defaults(),
+	}
+}
+
+// Validate returns an error if the config cannot be used to create
+// a Lock.
+func (config Config) Validate() error {
+	if config.LockDir == "" {
+		return errors.NotValidf("empty LockDir")
+	}
+	if config.Slots < 1 || config.Slots > MaxSlots {
+		return errors.NotValidf("Slots %d (must be between 1 and %d)", config.Slots, MaxSlots)
+	}
+	if config.LockConfig.Clock == nil {
+		return errors.NotValidf("nil LockConfig.Clock")
+	}
+	return nil
+}
+
+// Spec describes an attempt to acquire a machine Lock.
+type Spec struct {
+	// Owner identifies the unit or worker acquiring the lock.
+	// Each owner may hold the lock only once at a time, so that
+	// no single unit can occupy every slot.
+	Owner string
+
+	// Message describes the operation that will hold the lock.
+	Message string
+
+	// Exclusive is true if the operation must run alone on the
+	// machine, as when it installs packages.
+	Exclusive bool
+
+	// Cancel, if not nil, is called while waiting for the lock;
+	// if it returns an error, the wait is abandoned and the error
+	// returned.
+	Cancel func() error
+}
+
+// Lock is a machine-wide semaphore, shared by all the agents on a
+// machine, that governs hook execution and other operations that
+// could interfere with one another. Operations are granted the lock
+// in the order they queued for it.
+//
+// The lock is made up of MaxSlots file system locks, the first of
+// which is the lock previously used to serialise all hook executions.
+// Ordinary operations hold one of the first Slots of these; exclusive
+// operations hold all of them, so agents configured with differing
+// numbers of slots still exclude each other correctly. Exclusive
+// operations also hold a gate, preventing other operations from
+// taking slots as they are released.
+type Lock struct {
+	config Config
+	gate   *fslock.Lock
+	slots  []*fslock.Lock
+}
+
+// New returns a Lock with the supplied configuration.
+func New(config Config) (*Lock, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	gate, err := fslock.NewLock(config.LockDir, lockName+"-exclusive", config.LockConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	slots := make([]*fslock.Lock, MaxSlots)
+	for i := range slots {
+		name := lockName
+		if i > 0 {
+			name = fmt.Sprintf("%s-%d", lockName, i)
+		}
+		slots[i], err = fslock.NewLock(config.LockDir, name, config.LockConfig)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &Lock{
+		config: config,
+		gate:   gate,
+		slots:  slots,
+	}, nil
+}
+
+// Acquire blocks until the lock can be acquired as described by spec,
+// and returns a func that must be called to release it.
+func (l *Lock) Acquire(spec Spec) (func() error, error) {
+	if spec.Owner == "" {
+		return nil, errors.NotValidf("empty Owner")
+	}
+	cancel := spec.Cancel
+	if cancel == nil {
+		cancel = func() error { return nil }
+	}
+	message := fmt.Sprintf("%s: %s", spec.Owner, spec.Message)
+
+	clearWaiting, err := l.recordWaiting(spec.Owner, message)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer clearWaiting()
+
+	owner, err := l.ownerLock(spec.Owner)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := owner.LockWithFunc(message, cancel); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Only now that the owner may take the lock does it queue for
+	// it, so that it cannot hold up those behind it.
+	ticket, leaveQueue, err := l.joinQueue(spec.Owner)
+	if err != nil {
+		owner.Unlock()
+		return nil, errors.Trace(err)
+	}
+	var release func() error
+	if spec.Exclusive {
+		release, err = l.acquireAll(message, ticket, cancel)
+	} else {
+		release, err = l.acquireSlot(message, ticket, cancel)
+	}
+	leaveQueue()
+	if err != nil {
+		owner.Unlock()
+		return nil, errors.Trace(err)
+	}
+	return func() error {
+		err := release()
+		if unlockErr := owner.Unlock(); err == nil {
+			err = unlockErr
+		}
+		return errors.Trace(err)
+	}, nil
+}
+
+// acquireSlot waits until the holder of ticket is first in the queue,
+// and then until it can take any one of the configured slots.
+func (l *Lock) acquireSlot(message, ticket string, cancel func() error) (func() error, error) {
+	slotBusy := func() error { return errSlotBusy }
+	for {
+		first, err := l.firstInQueue(ticket)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if first && !l.gate.IsLocked() {
+			for _, slot := range l.slots[:l.config.Slots] {
+				err := slot.LockWithFunc(message, slotBusy)
+				if err == nil {
+					return slot.Unlock, nil
+				} else if err != errSlotBusy {
+					return nil, errors.Trace(err)
+				}
+			}
+		}
+		if err := cancel(); err != nil {
+			return nil, err
+		}
+		<-l.config.LockConfig.Clock.After(l.config.LockConfig.WaitDelay)
+	}
+}
+
+// acquireAll waits until the holder of ticket is first in the queue,
+// and then takes the gate and every slot in turn.
+func (l *Lock) acquireAll(message, ticket string, cancel func() error) (func() error, error) {
+	for {
+		first, err := l.firstInQueue(ticket)
+		if err != nil {
+			return nil, errors.Trace(err)
+		} else if first {
+			break
+		}
+		if err := cancel(); err != nil {
+			return nil, err
+		}
+		<-l.config.LockConfig.Clock.After(l.config.LockConfig.WaitDelay)
+	}
+	if err := l.gate.LockWithFunc(message, cancel); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var held []*fslock.Lock
+	release := func() error {
+		var err error
+		for i := len(held) - 1; i >= 0; i-- {
+			if unlockErr := held[i].Unlock(); err == nil {
+				err = unlockErr
+			}
+		}
+		if unlockErr := l.gate.Unlock(); err == nil {
+			err = unlockErr
+		}
+		return errors.Trace(err)
+	}
+	for _, slot := range l.slots {
+		if err := slot.LockWithFunc(message, cancel); err != nil {
+			release()
+			return nil, errors.Trace(err)
+		}
+		held = append(held, slot)
+	}
+	return release, nil
+}
+
+// BreakLocks breaks any part of the lock left held by owner; it
+// should only be called when owner is known to have stopped without
+// releasing the lock.
+func (l *Lock) BreakLocks(owner string) error {
+	ownerLock, err := l.ownerLock(owner)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := l.clearQueue(owner); err != nil {
+		return errors.Trace(err)
+	}
+	prefix := owner + ":"
+	locks := append([]*fslock.Lock{ownerLock, l.gate}, l.slots...)
+	for _, lock := range locks {
+		if !lock.IsLocked() {
+			continue
+		}
+		message, err := lock.Message()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !strings.HasPrefix(message, prefix) {
+			continue
+		}
+		if err := lock.BreakLock(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Report describes who holds and who is waiting for a machine Lock.
+type Report struct {
+	// Holders holds the messages of the operations holding the lock.
+	Holders []string
+
+	// Waiting holds the messages of the operations waiting for it.
+	Waiting []string
+}
+
+// Report returns a description of who holds and who is waiting for
+// the lock, to help diagnose stuck machines.
+func (l *Lock) Report() (Report, error) {
+	holders := set.NewStrings()
+	for _, lock := range append([]*fslock.Lock{l.gate}, l.slots...) {
+		if !lock.IsLocked() {
+			continue
+		}
+		message, err := lock.Message()
+		if err != nil {
+			return Report{}, errors.Trace(err)
+		}
+		holders.Add(message)
+	}
+	var waiting []string
+	infos, err := ioutil.ReadDir(l.waitingDir())
+	if err != nil && !os.IsNotExist(err) {
+		return Report{}, errors.Trace(err)
+	}
+	for _, info := range infos {
+		data, err := ioutil.ReadFile(filepath.Join(l.waitingDir(), info.Name()))
+		if os.IsNotExist(err) {
+			// It acquired the lock, or gave up, while we looked.
+			continue
+		} else if err != nil {
+			return Report{}, errors.Trace(err)
+		}
+		waiting = append(waiting, string(data))
+	}
+	sort.Strings(waiting)
+	return Report{
+		Holders: holders.SortedValues(),
+		Waiting: waiting,
+	}, nil
+}
+
+// recordWaiting records that owner is waiting for the lock, so that
+// it can be reported, and returns a func that clears the record.
+func (l *Lock) recordWaiting(owner, message string) (func(), error) {
+	if err := os.Mkdir(l.waitingDir(), 0755); err != nil && !os.IsExist(err) {
+		return nil, errors.Trace(err)
+	}
+	f, err := ioutil.TempFile(l.waitingDir(), lockNamePart(owner)+"-")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(message); err != nil {
+		os.Remove(f.Name())
+		return nil, errors.Trace(err)
+	}
+	return func() { os.Remove(f.Name()) }, nil
+}
+
+func (l *Lock) waitingDir() string {
+	return filepath.Join(l.config.LockDir, lockName+"-waiting")
+}
+
+// joinQueue adds owner, which must hold its owner lock, to the queue
+// for the lock, and returns its ticket and a func that removes it from
+// the queue. Tickets are named for the time they were taken, so that
+// they sort in queue order. Each is written under a temporary name and
+// then renamed into the queue, so that no ticket is ever seen without
+// its owner.
+func (l *Lock) joinQueue(owner string) (string, func(), error) {
+	if err := os.Mkdir(l.queueDir(), 0755); err != nil && !os.IsExist(err) {
+		return "", nil, errors.Trace(err)
+	}
+	// Any ticket owner already has was left behind when it stopped
+	// without releasing the lock, and would hold it up forever.
+	if err := l.clearQueue(owner); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	f, err := ioutil.TempFile(l.queueDir(), tempTicketPrefix)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	tempPath := f.Name()
+	_, err = f.WriteString(owner)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return "", nil, errors.Trace(err)
+	}
+	// The temporary name is unique, so the ticket is too.
+	now := l.config.LockConfig.Clock.Now()
+	unique := strings.TrimPrefix(filepath.Base(tempPath), tempTicketPrefix)
+	ticket := fmt.Sprintf("%020d-%s", now.UnixNano(), unique)
+	path := filepath.Join(l.queueDir(), ticket)
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return "", nil, errors.Trace(err)
+	}
+	return ticket, func() { os.Remove(path) }, nil
+}
+
+// tempTicketPrefix starts the names of tickets that are still being
+// written, which are not part of the queue.
+const tempTicketPrefix = ".ticket-"
+
+// firstInQueue reports whether no ticket taken before the given one
+// is still waiting for the lock. Tickets left behind by owners that
+// no longer hold their owner locks are ignored.
+func (l *Lock) firstInQueue(ticket string) (bool, error) {
+	names, err := l.queuedTickets()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, name := range names {
+		if name >= ticket {
+			break
+		}
+		owner, err := ioutil.ReadFile(filepath.Join(l.queueDir(), name))
+		if os.IsNotExist(err) {
+			// It acquired the lock, or gave up, while we looked.
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if len(owner) == 0 {
+			// Tickets are written whole, so this one is
+			// damaged and its owner cannot be known.
+			continue
+		}
+		ownerLock, err := l.ownerLock(string(owner))
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if ownerLock.IsLocked() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// clearQueue removes any tickets, including any not yet renamed into
+// the queue, left behind by owner.
+func (l *Lock) clearQueue(owner string) error {
+	names, err := l.queueDirNames()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range names {
+		path := filepath.Join(l.queueDir(), name)
+		data, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if string(data) != owner {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// queuedTickets returns the tickets in the queue, in queue order.
+func (l *Lock) queuedTickets() ([]string, error) {
+	names, err := l.queueDirNames()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tickets []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, tempTicketPrefix) {
+			tickets = append(tickets, name)
+		}
+	}
+	return tickets, nil
+}
+
+// queueDirNames returns the names of all the files in the queue
+// directory, sorted by name.
+func (l *Lock) queueDirNames() ([]string, error) {
+	// ReadDir sorts the entries by name, and so tickets into queue order.
+	infos, err := ioutil.ReadDir(l.queueDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(infos))
+	for i, info := range infos {
+		names[i] = info.Name()
+	}
+	return names, nil
+}
+
+func (l *Lock) queueDir() string {
+	return filepath.Join(l.config.LockDir, lockName+"-queue")
+}
+
+// ownerLock returns the lock held by owner while it waits for and
+// holds the machine lock.
+func (l *Lock) ownerLock(owner string) (*fslock.Lock, error) {
+	name := fmt.Sprintf("%s-owner-%s", lockName, lockNamePart(owner))
+	return fslock.NewLock(l.config.LockDir, name, l.config.LockConfig)
+}
+
+var invalidLockNameChars = regexp.MustCompile("[^a-z0-9.-]")
+
+// lockNamePart converts an owner, such as a unit name, into a form
+// that can be used in an fslock name.
+func lockNamePart(owner string) string {
+	return invalidLockNameChars.ReplaceAllString(strings.ToLower(owner), "-")
+}
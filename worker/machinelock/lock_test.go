@@ -0,0 +1,306 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinelock_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/fslock"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/machinelock"
+)
+
+type LockSuite struct {
+	testing.IsolationSuite
+	config machinelock.Config
+}
+
+var _ = gc.Suite(&LockSuite{})
+
+func (s *LockSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = machinelock.DefaultConfig(c.MkDir())
+	s.config.Slots = 2
+	s.config.LockConfig.WaitDelay = 10 * time.Millisecond
+}
+
+func (s *LockSuite) newLock(c *gc.C) *machinelock.Lock {
+	lock, err := machinelock.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return lock
+}
+
+// acquire starts acquiring the lock as described by spec, and returns
+// a channel on which the release func will be delivered.
+func acquire(c *gc.C, lock *machinelock.Lock, spec machinelock.Spec) <-chan func() error {
+	acquired := make(chan func() error, 1)
+	go func() {
+		release, err := lock.Acquire(spec)
+		c.Check(err, jc.ErrorIsNil)
+		acquired <- release
+	}()
+	return acquired
+}
+
+func assertAcquired(c *gc.C, acquired <-chan func() error) func() error {
+	select {
+	case release := <-acquired:
+		c.Assert(release, gc.NotNil)
+		return release
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for lock")
+	}
+	panic("unreachable")
+}
+
+func assertNotAcquired(c *gc.C, acquired <-chan func() error) {
+	select {
+	case <-acquired:
+		c.Fatalf("lock unexpectedly acquired")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *LockSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*machinelock.Config)
+		err    string
+	}{{
+		func(config *machinelock.Config) { config.LockDir = "" },
+		"empty LockDir not valid",
+	}, {
+		func(config *machinelock.Config) { config.Slots = 0 },
+		"Slots 0 \\(must be between 1 and 16\\) not valid",
+	}, {
+		func(config *machinelock.Config) { config.Slots = machinelock.MaxSlots + 1 },
+		"Slots 17 \\(must be between 1 and 16\\) not valid",
+	}, {
+		func(config *machinelock.Config) { config.LockConfig.Clock = nil },
+		"nil LockConfig.Clock not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := machinelock.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *LockSuite) TestAcquireSlots(c *gc.C) {
+	lock := s.newLock(c)
+	release0 := assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/0"}))
+	release1 := assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/1"}))
+
+	// Both slots are taken, so a third unit must wait.
+	acquired := acquire(c, lock, machinelock.Spec{Owner: "u/2"})
+	assertNotAcquired(c, acquired)
+	c.Assert(release0(), jc.ErrorIsNil)
+	release2 := assertAcquired(c, acquired)
+
+	c.Assert(release1(), jc.ErrorIsNil)
+	c.Assert(release2(), jc.ErrorIsNil)
+}
+
+func (s *LockSuite) TestAcquireInOrder(c *gc.C) {
+	s.config.Slots = 1
+	lock := s.newLock(c)
+	release := assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/0"}))
+
+	// Queue the waiters one at a time, so that their order is known.
+	var waiting []<-chan func() error
+	for _, owner := range []string{"u/1", "u/2", "u/3"} {
+		acquired := acquire(c, lock, machinelock.Spec{Owner: owner})
+		assertNotAcquired(c, acquired)
+		waiting = append(waiting, acquired)
+	}
+
+	// Each is granted the lock in turn, however often the others
+	// try for it in the meantime.
+	for i, acquired := range waiting {
+		c.Assert(release(), jc.ErrorIsNil)
+		release = assertAcquired(c, acquired)
+		for _, later := range waiting[i+1:] {
+			assertNotAcquired(c, later)
+		}
+	}
+	c.Assert(release(), jc.ErrorIsNil)
+}
+
+func (s *LockSuite) TestAcquireIgnoresStaleTickets(c *gc.C) {
+	// u/0 stopped while queued for the lock, leaving its ticket
+	// at the head of the queue.
+	queueDir := filepath.Join(s.config.LockDir, "uniter-hook-execution-queue")
+	err := os.MkdirAll(queueDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(queueDir, "00000000000000000001-1"), []byte("u/0"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	lock := s.newLock(c)
+	release := assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/1"}))
+	c.Assert(release(), jc.ErrorIsNil)
+}
+
+func (s *LockSuite) TestAcquireIgnoresEmptyAndUnfinishedTickets(c *gc.C) {
+	// Neither a damaged ticket nor one that was never renamed into
+	// the queue holds anyone up.
+	queueDir := filepath.Join(s.config.LockDir, "uniter-hook-execution-queue")
+	err := os.MkdirAll(queueDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(queueDir, "00000000000000000001-1"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(queueDir, ".ticket-1"), []byte("u/0"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	lock := s.newLock(c)
+	release := assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/1"}))
+	c.Assert(release(), jc.ErrorIsNil)
+
+	// The unfinished ticket is cleared when its owner next queues.
+	release = assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/0"}))
+	c.Assert(release(), jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(queueDir, ".ticket-1"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *LockSuite) TestAcquireOncePerOwner(c *gc.C) {
+	lock := s.newLock(c)
+	release := assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/0"}))
+
+	// There is a free slot, but u/0 already holds the lock.
+	acquired := acquire(c, lock, machinelock.Spec{Owner: "u/0"})
+	assertNotAcquired(c, acquired)
+	c.Assert(release(), jc.ErrorIsNil)
+	release = assertAcquired(c, acquired)
+	c.Assert(release(), jc.ErrorIsNil)
+}
+
+func (s *LockSuite) TestAcquireExclusive(c *gc.C) {
+	lock := s.newLock(c)
+	release := assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/0"}))
+
+	// An exclusive operation waits for everything else to finish...
+	exclusive := acquire(c, lock, machinelock.Spec{Owner: "u/1", Exclusive: true})
+	assertNotAcquired(c, exclusive)
+
+	// ...and stops anything new from starting, despite the free slot.
+	shared := acquire(c, lock, machinelock.Spec{Owner: "u/2"})
+	assertNotAcquired(c, shared)
+
+	c.Assert(release(), jc.ErrorIsNil)
+	release = assertAcquired(c, exclusive)
+	assertNotAcquired(c, shared)
+	c.Assert(release(), jc.ErrorIsNil)
+	release = assertAcquired(c, shared)
+	c.Assert(release(), jc.ErrorIsNil)
+}
+
+func (s *LockSuite) TestAcquireExclusiveAllSlots(c *gc.C) {
+	// An exclusive operation excludes agents configured with more
+	// slots than its own.
+	s.config.Slots = 1
+	lock := s.newLock(c)
+	s.config.Slots = machinelock.MaxSlots
+	other := s.newLock(c)
+
+	release := assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/0", Exclusive: true}))
+	acquired := acquire(c, other, machinelock.Spec{Owner: "u/1"})
+	assertNotAcquired(c, acquired)
+	c.Assert(release(), jc.ErrorIsNil)
+	release = assertAcquired(c, acquired)
+	c.Assert(release(), jc.ErrorIsNil)
+}
+
+func (s *LockSuite) TestAcquireLegacyLock(c *gc.C) {
+	// The first slot is the lock previously used on its own.
+	s.config.Slots = 1
+	lock := s.newLock(c)
+	legacy, err := fslock.NewLock(s.config.LockDir, "uniter-hook-execution", s.config.LockConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	err = legacy.Lock("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	acquired := acquire(c, lock, machinelock.Spec{Owner: "u/0"})
+	assertNotAcquired(c, acquired)
+	c.Assert(legacy.Unlock(), jc.ErrorIsNil)
+	release := assertAcquired(c, acquired)
+	c.Assert(legacy.IsLocked(), jc.IsTrue)
+	c.Assert(release(), jc.ErrorIsNil)
+}
+
+func (s *LockSuite) TestAcquireCancel(c *gc.C) {
+	s.config.Slots = 1
+	lock := s.newLock(c)
+	release := assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/0"}))
+	_, err := lock.Acquire(machinelock.Spec{
+		Owner:  "u/1",
+		Cancel: func() error { return errors.New("stopping") },
+	})
+	c.Assert(err, gc.ErrorMatches, "stopping")
+	c.Assert(release(), jc.ErrorIsNil)
+
+	// Having given up, u/1 may try again later.
+	release = assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "u/1"}))
+	c.Assert(release(), jc.ErrorIsNil)
+}
+
+func (s *LockSuite) TestReport(c *gc.C) {
+	s.config.Slots = 1
+	lock := s.newLock(c)
+	report, err := lock.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, machinelock.Report{})
+
+	release := assertAcquired(c, acquire(c, lock, machinelock.Spec{
+		Owner:   "u/0",
+		Message: "running install hook",
+	}))
+	acquired := acquire(c, lock, machinelock.Spec{
+		Owner:   "u/1",
+		Message: "running start hook",
+	})
+	assertNotAcquired(c, acquired)
+	report, err = lock.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, machinelock.Report{
+		Holders: []string{"u/0: running install hook"},
+		Waiting: []string{"u/1: running start hook"},
+	})
+
+	c.Assert(release(), jc.ErrorIsNil)
+	release = assertAcquired(c, acquired)
+	report, err = lock.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, machinelock.Report{
+		Holders: []string{"u/1: running start hook"},
+	})
+	c.Assert(release(), jc.ErrorIsNil)
+}
+
+func (s *LockSuite) TestBreakLocks(c *gc.C) {
+	lock := s.newLock(c)
+	// Acquire the lock, as the reboot worker does before the machine
+	// restarts, and never release it.
+	assertAcquired(c, acquire(c, lock, machinelock.Spec{Owner: "reboot", Exclusive: true}))
+
+	// Breaking another owner's locks leaves these alone.
+	err := lock.BreakLocks("u/0")
+	c.Assert(err, jc.ErrorIsNil)
+	acquired := acquire(c, lock, machinelock.Spec{Owner: "u/0"})
+	assertNotAcquired(c, acquired)
+
+	// The stale locks held by "reboot" are broken.
+	err = lock.BreakLocks("reboot")
+	c.Assert(err, jc.ErrorIsNil)
+	release := assertAcquired(c, acquired)
+	c.Assert(release(), jc.ErrorIsNil)
+}
@@ -4,17 +4,18 @@
 package machinelock
 
 import (
+	"strconv"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
-	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
 )
 
 // createLock exists to be patched out in export_test.go
-var createLock = cmdutil.HookExecutionLock
+var createLock = New
 
 // ManifoldConfig specifies the names a machinelock manifold should use to
 // address its dependencies.
@@ -25,7 +26,7 @@ type ManifoldConfig engine.AgentManifoldConfig
 // from running concurrently and interfering with one another. Examples (are
 // not limited to): hook executions, package installation, synchronisation
 // of reboots.
-// Clients can access the lock by passing a **fslock.Lock into the out param
+// Clients can access the lock by passing a **Lock into the out param
 // of their dependency.Context's Get method.
 func Manifold(config ManifoldConfig) dependency.Manifold {
 	manifold := engine.AgentManifold(engine.AgentManifoldConfig(config), newWorker)
@@ -33,10 +34,22 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 	return manifold
 }
 
-// newWorker creates a degenerate worker that provides access to an fslock.
+// newWorker creates a degenerate worker that provides access to a Lock.
+// The number of operations that may hold the lock at once is read from
+// the agent's MACHINE_LOCK_SLOTS config value, if set; it is set from
+// the model's machine-lock-slots setting when the machine is
+// provisioned.
 func newWorker(a agent.Agent) (worker.Worker, error) {
-	dataDir := a.CurrentConfig().DataDir()
-	lock, err := createLock(dataDir)
+	agentConfig := a.CurrentConfig()
+	config := DefaultConfig(agentConfig.DataDir())
+	if value := agentConfig.Value(agent.MachineLockSlots); value != "" {
+		slots, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid %s", agent.MachineLockSlots)
+		}
+		config.Slots = slots
+	}
+	lock, err := createLock(config)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -7,7 +7,6 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
-	"github.com/juju/utils/fslock"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/agent"
@@ -22,7 +21,8 @@ type ManifoldSuite struct {
 	testing.Stub
 	manifold dependency.Manifold
 	context  dependency.Context
-	lock     *fslock.Lock
+	lock     *machinelock.Lock
+	values   map[string]string
 }
 
 var _ = gc.Suite(&ManifoldSuite{})
@@ -33,15 +33,16 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.manifold = machinelock.Manifold(machinelock.ManifoldConfig{
 		AgentName: "agent-name",
 	})
+	s.values = map[string]string{}
 	s.context = dt.StubContext(nil, map[string]interface{}{
-		"agent-name": &dummyAgent{},
+		"agent-name": &dummyAgent{values: s.values},
 	})
 
-	lock, err := fslock.NewLock(c.MkDir(), "test-lock", fslock.Defaults())
+	lock, err := machinelock.New(machinelock.DefaultConfig(c.MkDir()))
 	c.Assert(err, jc.ErrorIsNil)
 	s.lock = lock
-	s.PatchValue(machinelock.CreateLock, func(dataDir string) (*fslock.Lock, error) {
-		s.AddCall("createLock", dataDir)
+	s.PatchValue(machinelock.CreateLock, func(config machinelock.Config) (*machinelock.Lock, error) {
+		s.AddCall("createLock", config.LockDir, config.Slots)
 		if err := s.NextErr(); err != nil {
 			return nil, err
 		}
@@ -70,7 +71,7 @@ func (s *ManifoldSuite) TestStartError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "no lock for you")
 	s.CheckCalls(c, []testing.StubCall{{
 		FuncName: "createLock",
-		Args:     []interface{}{"/path/to/data/dir"},
+		Args:     []interface{}{"/path/to/data/dir/locks", 1},
 	}})
 }
 
@@ -84,7 +85,7 @@ func (s *ManifoldSuite) setupWorkerTest(c *gc.C) worker.Worker {
 	})
 	s.CheckCalls(c, []testing.StubCall{{
 		FuncName: "createLock",
-		Args:     []interface{}{"/path/to/data/dir"},
+		Args:     []interface{}{"/path/to/data/dir/locks", 1},
 	}})
 	return worker
 }
@@ -93,16 +94,36 @@ func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
 	s.setupWorkerTest(c)
 }
 
+func (s *ManifoldSuite) TestStartSlots(c *gc.C) {
+	s.values[agent.MachineLockSlots] = "4"
+	worker, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	worker.Kill()
+	c.Check(worker.Wait(), jc.ErrorIsNil)
+	s.CheckCalls(c, []testing.StubCall{{
+		FuncName: "createLock",
+		Args:     []interface{}{"/path/to/data/dir/locks", 4},
+	}})
+}
+
+func (s *ManifoldSuite) TestStartInvalidSlots(c *gc.C) {
+	s.values[agent.MachineLockSlots] = "many"
+	worker, err := s.manifold.Start(s.context)
+	c.Check(worker, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, `invalid MACHINE_LOCK_SLOTS: .*`)
+	s.CheckCalls(c, nil)
+}
+
 func (s *ManifoldSuite) TestOutputSuccess(c *gc.C) {
 	worker := s.setupWorkerTest(c)
-	var lock *fslock.Lock
+	var lock *machinelock.Lock
 	err := s.manifold.Output(worker, &lock)
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(lock, gc.Equals, s.lock)
 }
 
 func (s *ManifoldSuite) TestOutputBadWorker(c *gc.C) {
-	var lock *fslock.Lock
+	var lock *machinelock.Lock
 	err := s.manifold.Output(&dummyWorker{}, &lock)
 	c.Check(err, gc.ErrorMatches, "in should be a \\*valueWorker; is .*")
 	c.Check(lock, gc.IsNil)
@@ -118,14 +139,20 @@ func (s *ManifoldSuite) TestOutputBadTarget(c *gc.C) {
 
 type dummyAgent struct {
 	agent.Agent
+	values map[string]string
 }
 
-func (_ dummyAgent) CurrentConfig() agent.Config {
-	return &dummyAgentConfig{}
+func (a dummyAgent) CurrentConfig() agent.Config {
+	return &dummyAgentConfig{values: a.values}
 }
 
 type dummyAgentConfig struct {
 	agent.Config
+	values map[string]string
+}
+
+func (c dummyAgentConfig) Value(key string) string {
+	return c.values[key]
 }
 
 func (_ dummyAgentConfig) DataDir() string {
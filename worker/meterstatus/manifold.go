@@ -11,7 +11,6 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
-	"github.com/juju/utils/fslock"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
@@ -19,6 +18,7 @@ import (
 	"github.com/juju/juju/api/meterstatus"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/machinelock"
 )
 
 var (
@@ -31,7 +31,7 @@ type ManifoldConfig struct {
 	APICallerName   string
 	MachineLockName string
 
-	NewHookRunner           func(names.UnitTag, *fslock.Lock, agent.Config) HookRunner
+	NewHookRunner           func(names.UnitTag, *machinelock.Lock, agent.Config) HookRunner
 	NewMeterStatusAPIClient func(base.APICaller, names.UnitTag) meterstatus.MeterStatusClient
 
 	NewConnectedStatusWorker func(ConnectedConfig) (worker.Worker, error)
@@ -58,7 +58,7 @@ func newStatusWorker(config ManifoldConfig, context dependency.Context) (worker.
 		return nil, err
 	}
 
-	var machineLock *fslock.Lock
+	var machineLock *machinelock.Lock
 	if err := context.Get(config.MachineLockName, &machineLock); err != nil {
 		return nil, err
 	}
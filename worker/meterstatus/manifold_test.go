@@ -9,7 +9,6 @@ import (
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
-	"github.com/juju/utils/fslock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -21,6 +20,7 @@ import (
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
 	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/meterstatus"
 	"github.com/juju/juju/worker/uniter/runner"
 )
@@ -56,8 +56,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.manifold = meterstatus.Manifold(s.manifoldConfig)
 	s.dataDir = c.MkDir()
 
-	locksDir := c.MkDir()
-	lock, err := fslock.NewLock(locksDir, "machine-lock", fslock.Defaults())
+	lock, err := machinelock.New(machinelock.DefaultConfig(c.MkDir()))
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.resources = dt.StubResources{
@@ -110,7 +109,7 @@ func (s *PatchedManifoldSuite) SetUpTest(c *gc.C) {
 	newMSClient := func(_ base.APICaller, _ names.UnitTag) msapi.MeterStatusClient {
 		return s.msClient
 	}
-	newHookRunner := func(_ names.UnitTag, _ *fslock.Lock, _ agent.Config) meterstatus.HookRunner {
+	newHookRunner := func(_ names.UnitTag, _ *machinelock.Lock, _ agent.Config) meterstatus.HookRunner {
 		return &stubRunner{stub: s.stub}
 	}
 
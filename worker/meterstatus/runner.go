@@ -4,16 +4,15 @@
 package meterstatus
 
 import (
-	"fmt"
-
 	"github.com/juju/errors"
-	"github.com/juju/utils/fslock"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 	"gopkg.in/juju/names.v2"
 	"launchpad.net/tomb"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -24,12 +23,12 @@ type HookRunner interface {
 
 // hookRunner implements functionality for running a hook.
 type hookRunner struct {
-	machineLock *fslock.Lock
+	machineLock *machinelock.Lock
 	config      agent.Config
 	tag         names.UnitTag
 }
 
-func NewHookRunner(tag names.UnitTag, lock *fslock.Lock, config agent.Config) HookRunner {
+func NewHookRunner(tag names.UnitTag, lock *machinelock.Lock, config agent.Config) HookRunner {
 	return &hookRunner{
 		tag:         tag,
 		machineLock: lock,
@@ -38,10 +37,19 @@ func NewHookRunner(tag names.UnitTag, lock *fslock.Lock, config agent.Config) Ho
 }
 
 // acquireExecutionLock acquires the machine-level execution lock and returns a function to be used
-// to unlock it.
-func (w *hookRunner) acquireExecutionLock(interrupt <-chan struct{}) (func() error, error) {
+// to unlock it. The lock is acquired exclusively if the charm in charmDir requires it.
+func (w *hookRunner) acquireExecutionLock(charmDir string, interrupt <-chan struct{}) (func() error, error) {
 	message := "running meter-status-changed hook"
 	logger.Tracef("lock: %v", message)
+	var exclusive bool
+	extras, err := charm.ReadMetaExtras(charmDir)
+	if err == nil {
+		exclusive, err = extras.ExclusiveMachineLock()
+	}
+	if err != nil {
+		logger.Warningf("acquiring machine lock exclusively: %v", err)
+		exclusive = true
+	}
 	checkTomb := func() error {
 		select {
 		case <-interrupt:
@@ -50,13 +58,18 @@ func (w *hookRunner) acquireExecutionLock(interrupt <-chan struct{}) (func() err
 			return nil
 		}
 	}
-	message = fmt.Sprintf("%s: %s", w.tag.String(), message)
-	if err := w.machineLock.LockWithFunc(message, checkTomb); err != nil {
+	release, err := w.machineLock.Acquire(machinelock.Spec{
+		Owner:     w.tag.Id(),
+		Message:   message,
+		Exclusive: exclusive,
+		Cancel:    checkTomb,
+	})
+	if err != nil {
 		return nil, err
 	}
 	return func() error {
 		logger.Tracef("unlock: %v", message)
-		return w.machineLock.Unlock()
+		return release()
 	}, nil
 }
 
@@ -69,7 +82,7 @@ func (w *hookRunner) RunHook(code, info string, interrupt <-chan struct{}) (runE
 		"JUJU_METER_INFO":   info,
 	})
 	r := runner.NewRunner(ctx, paths)
-	unlock, err := w.acquireExecutionLock(interrupt)
+	unlock, err := w.acquireExecutionLock(paths.GetCharmDir(), interrupt)
 	if err != nil {
 		return errors.Annotate(err, "failed to acquire machine lock")
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/metrics/spool"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -82,6 +83,14 @@ func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
+// RecordHookExecution implements runner.Context. Hooks run outside
+// the uniter are not recorded in the unit's hook history.
+func (ctx *hookContext) RecordHookExecution(params.HookExecution) error { return nil }
+
+// HookTimeout implements runner.Context. The model's hook timeout is
+// not known outside the uniter.
+func (ctx *hookContext) HookTimeout() time.Duration { return 0 }
+
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
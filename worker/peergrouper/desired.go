@@ -81,6 +81,7 @@ func desiredPeerGroup(info *peerGroupInfo) ([]replicaset.Member, map[*machineTra
 		changed = true
 	}
 	adjustVotes(toRemoveVote, toAddVote, setVoting)
+	standInWitnesses(info, members, machineVoting, setVoting)
 
 	addNewMembers(members, toKeep, maxId, setVoting, info.mongoSpace)
 	if updateAddresses(members, info.machineTrackers, info.mongoSpace) {
@@ -107,13 +108,20 @@ func isVotingMember(member *replicaset.Member) bool {
 // be removed; toAddVote holds machines which are
 // ready to vote; toKeep holds machines with no desired
 // change to their voting status (this includes machines
-// that are not yet represented in the peer group).
+// that are not yet represented in the peer group, and
+// witnesses standing in for machines that want a vote
+// but are not ready for it).
 func possiblePeerGroupChanges(
 	info *peerGroupInfo,
 	members map[*machineTracker]*replicaset.Member,
 ) (toRemoveVote, toAddVote, toKeep []*machineTracker) {
 	statuses := info.statusesMap(members)
 
+	// standIns holds voting witnesses, and awaiting counts the
+	// machines that want a vote but are not ready for one.
+	var standIns []*machineTracker
+	awaiting := 0
+
 	logger.Debugf("assessing possible peer group changes:")
 	for _, m := range info.machineTrackers {
 		member := members[m]
@@ -130,7 +138,10 @@ func possiblePeerGroupChanges(
 			} else {
 				logger.Debugf("machine %q is not ready (has status: %v)", m.Id(), ok)
 				toKeep = append(toKeep, m)
+				awaiting++
 			}
+		case !wantsVote && isVoting && m.IsWitness():
+			standIns = append(standIns, m)
 		case !wantsVote && isVoting:
 			logger.Debugf("machine %q is a potential non-voter", m.Id())
 			toRemoveVote = append(toRemoveVote, m)
@@ -139,6 +150,19 @@ func possiblePeerGroupChanges(
 			toKeep = append(toKeep, m)
 		}
 	}
+	// A witness keeps its vote while it is standing in for a
+	// machine that is not ready, as otherwise the replica set would
+	// lose a healthy voter.
+	sort.Sort(byId(standIns))
+	for i, m := range standIns {
+		if i < awaiting {
+			logger.Debugf("witness machine %q is standing in for a machine that is not ready", m.Id())
+			toKeep = append(toKeep, m)
+		} else {
+			logger.Debugf("witness machine %q is a potential non-voter", m.Id())
+			toRemoveVote = append(toRemoveVote, m)
+		}
+	}
 	logger.Debugf("assessed")
 	// sort machines to be added and removed so that we
 	// get deterministic behaviour when testing. Earlier
@@ -208,6 +232,42 @@ func adjustVotes(toRemoveVote, toAddVote []*machineTracker, setVoting func(*mach
 	}
 }
 
+// standInWitnesses gives the votes of any voting machines whose
+// members are unhealthy to witness machines that are ready, so that
+// the replica set keeps the same number of healthy voters. A witness
+// standing in for a machine is a candidate to lose its vote as soon as
+// that machine (or any other machine that wants a vote) is ready
+// again, as it does not want the vote itself.
+func standInWitnesses(
+	info *peerGroupInfo,
+	members map[*machineTracker]*replicaset.Member,
+	machineVoting map[*machineTracker]bool,
+	setVoting func(*machineTracker, bool),
+) {
+	statuses := info.statusesMap(members)
+	var unhealthy, standby []*machineTracker
+	for _, m := range info.machineTrackers {
+		status, ok := statuses[m]
+		if !ok {
+			continue
+		}
+		switch {
+		case m.WantsVote() && machineVoting[m] && !status.Healthy:
+			unhealthy = append(unhealthy, m)
+		case m.IsWitness() && !machineVoting[m] && isReady(status):
+			standby = append(standby, m)
+		}
+	}
+	sort.Sort(byId(unhealthy))
+	sort.Sort(byId(standby))
+	n := min(len(unhealthy), len(standby))
+	for i := 0; i < n; i++ {
+		logger.Infof("witness machine %q standing in for unhealthy machine %q", standby[i].Id(), unhealthy[i].Id())
+		setVoting(unhealthy[i], false)
+		setVoting(standby[i], true)
+	}
+}
+
 // addNewMembers adds new members from toKeep
 // to the given set of members, allocating ids from
 // maxId upwards. It calls setVoting to set the voting
@@ -183,6 +183,48 @@ func desiredPeerGroupTests(ipVersion TestIPVersion) []desiredPeerGroupTest {
 			members:       mkMembers("1v 2v 3v", ipVersion),
 			expectVoting:  []bool{true, true, true},
 			expectMembers: nil,
+		}, {
+			about:         "a new witness is added without a vote",
+			machines:      mkMachines("11v 14w", ipVersion),
+			members:       mkMembers("1v", ipVersion),
+			statuses:      mkStatuses("1p", ipVersion),
+			expectVoting:  []bool{true, false},
+			expectMembers: mkMembers("1v 4", ipVersion),
+		}, {
+			about:         "a ready witness does not take a vote from healthy machines",
+			machines:      mkMachines("11v 12v 13v 14w", ipVersion),
+			members:       mkMembers("1v 2v 3v 4", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s 4s", ipVersion),
+			expectVoting:  []bool{true, true, true, false},
+			expectMembers: nil,
+		}, {
+			about:         "a witness stands in for an unhealthy voting machine",
+			machines:      mkMachines("11v 12v 13v 14w", ipVersion),
+			members:       mkMembers("1v 2v 3v 4", ipVersion),
+			statuses:      mkStatuses("1p 2s 3sH 4s", ipVersion),
+			expectVoting:  []bool{true, true, false, true},
+			expectMembers: mkMembers("1v 2v 3 4v", ipVersion),
+		}, {
+			about:         "an unhealthy witness does not stand in",
+			machines:      mkMachines("11v 12v 13v 14w", ipVersion),
+			members:       mkMembers("1v 2v 3v 4", ipVersion),
+			statuses:      mkStatuses("1p 2s 3sH 4sH", ipVersion),
+			expectVoting:  []bool{true, true, true, false},
+			expectMembers: nil,
+		}, {
+			about:         "a recovered machine takes its vote back from a witness",
+			machines:      mkMachines("11v 12v 13v 14w", ipVersion),
+			members:       mkMembers("1v 2v 3 4v", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s 4s", ipVersion),
+			expectVoting:  []bool{true, true, true, false},
+			expectMembers: mkMembers("1v 2v 3v 4", ipVersion),
+		}, {
+			about:         "two witnesses stand in for two unhealthy voting machines",
+			machines:      mkMachines("11v 12v 13v 14v 15v 16w 17w", ipVersion),
+			members:       mkMembers("1v 2v 3v 4v 5v 6 7", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s 4sH 5sH 6s 7s", ipVersion),
+			expectVoting:  []bool{true, true, true, false, false, true, true},
+			expectMembers: mkMembers("1v 2v 3v 4 5 6v 7v", ipVersion),
 		}}
 }
 
@@ -238,6 +280,48 @@ func (*desiredPeerGroupSuite) TestDesiredPeerGroup(c *gc.C) {
 	})
 }
 
+func (*desiredPeerGroupSuite) TestStandInWitnessesKeepVotes(c *gc.C) {
+	machines := mkMachines("11v 12v 13v 14v 15v 16w 17w", testIPv4)
+	trackerMap := make(map[string]*machineTracker)
+	for _, m := range machines {
+		trackerMap[m.Id()] = m
+	}
+	info := &peerGroupInfo{
+		machineTrackers: trackerMap,
+		statuses:        mkStatuses("1p 2s 3s 4sH 5sH 6s 7s", testIPv4),
+		members:         mkMembers("1v 2v 3v 4v 5v 6 7", testIPv4),
+	}
+	checkVoting := func(voting map[*machineTracker]bool, expect ...bool) {
+		for i, m := range machines {
+			c.Check(voting[m], gc.Equals, expect[i], gc.Commentf("machine %s", m.Id()))
+		}
+	}
+
+	// The witnesses stand in for the unhealthy machines.
+	members, voting, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Sort(membersById(members))
+	c.Assert(members, jc.DeepEquals, mkMembers("1v 2v 3v 4 5 6v 7v", testIPv4))
+	checkVoting(voting, true, true, true, false, false, true, true)
+
+	// While the machines are still unhealthy, the witnesses
+	// keep their votes.
+	info.members = members
+	members, voting, err = desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(members, gc.IsNil)
+	checkVoting(voting, true, true, true, false, false, true, true)
+
+	// Once the machines recover, they take their votes back.
+	info.members = mkMembers("1v 2v 3v 4 5 6v 7v", testIPv4)
+	info.statuses = mkStatuses("1p 2s 3s 4s 5s 6s 7s", testIPv4)
+	members, voting, err = desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Sort(membersById(members))
+	c.Assert(members, jc.DeepEquals, mkMembers("1v 2v 3v 4v 5v 6 7", testIPv4))
+	checkVoting(voting, true, true, true, true, true, false, false)
+}
+
 func countVotes(members []replicaset.Member) int {
 	tot := 0
 	for _, m := range members {
@@ -262,7 +346,8 @@ func newFloat64(f float64) *float64 {
 // the given description.
 // Each machine in the description is white-space separated
 // and holds the decimal machine id followed by an optional
-// "v" if the machine wants a vote.
+// "v" if the machine wants a vote, or "w" if the machine
+// is a witness.
 func mkMachines(description string, ipVersion TestIPVersion) []*machineTracker {
 	descrs := parseDescr(description)
 	ms := make([]*machineTracker, len(descrs))
@@ -278,6 +363,7 @@ func mkMachines(description string, ipVersion TestIPVersion) []*machineTracker {
 				Port: mongoPort,
 			}},
 			wantsVote: strings.Contains(d.flags, "v"),
+			witness:   strings.Contains(d.flags, "w"),
 		}
 	}
 	return ms
@@ -28,6 +28,7 @@ type machineTracker struct {
 	// protected by the mutex.
 	id             string
 	wantsVote      bool
+	witness        bool
 	apiHostPorts   []network.HostPort
 	mongoHostPorts []network.HostPort
 }
@@ -40,6 +41,7 @@ func newMachineTracker(stm stateMachine, notifyCh chan struct{}) (*machineTracke
 		apiHostPorts:   stm.APIHostPorts(),
 		mongoHostPorts: stm.MongoHostPorts(),
 		wantsVote:      stm.WantsVote(),
+		witness:        stm.IsWitness(),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &m.catacomb,
@@ -76,6 +78,15 @@ func (m *machineTracker) WantsVote() bool {
 	return m.wantsVote
 }
 
+// IsWitness returns whether the machine is a witness controller
+// (according to state), which never wants a vote but may stand in
+// for a voting machine that is unhealthy.
+func (m *machineTracker) IsWitness() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.witness
+}
+
 // WantsVote returns the MongoDB hostports from state.
 func (m *machineTracker) MongoHostPorts() []network.HostPort {
 	m.mu.Lock()
@@ -114,8 +125,8 @@ func (m *machineTracker) GoString() string {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	return fmt.Sprintf("&peergrouper.machine{id: %q, wantsVote: %v, hostPorts: %v}",
-		m.id, m.wantsVote, m.mongoHostPorts)
+	return fmt.Sprintf("&peergrouper.machine{id: %q, wantsVote: %v, witness: %v, hostPorts: %v}",
+		m.id, m.wantsVote, m.witness, m.mongoHostPorts)
 }
 
 func (m *machineTracker) loop() error {
@@ -167,6 +178,10 @@ func (m *machineTracker) hasChanged() (bool, error) {
 		m.wantsVote = wantsVote
 		changed = true
 	}
+	if witness := m.stm.IsWitness(); witness != m.witness {
+		m.witness = witness
+		changed = true
+	}
 	if hps := m.stm.MongoHostPorts(); !hostPortsEqual(hps, m.mongoHostPorts) {
 		m.mongoHostPorts = hps
 		changed = true
@@ -290,6 +290,7 @@ type fakeMachine struct {
 type machineDoc struct {
 	id             string
 	wantsVote      bool
+	witness        bool
 	hasVote        bool
 	instanceId     instance.Id
 	mongoHostPorts []network.HostPort
@@ -339,6 +340,12 @@ func (m *fakeMachine) WantsVote() bool {
 	return m.doc.wantsVote
 }
 
+func (m *fakeMachine) IsWitness() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.doc.witness
+}
+
 func (m *fakeMachine) HasVote() bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
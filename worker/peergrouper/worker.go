@@ -42,6 +42,7 @@ type stateMachine interface {
 	Refresh() error
 	Watch() state.NotifyWatcher
 	WantsVote() bool
+	IsWitness() bool
 	HasVote() bool
 	SetHasVote(hasVote bool) error
 	APIHostPorts() []network.HostPort
@@ -11,7 +11,6 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"github.com/juju/utils/exec"
-	"github.com/juju/utils/fslock"
 
 	"github.com/juju/juju/agent"
 	apiprovisioner "github.com/juju/juju/api/provisioner"
@@ -25,6 +24,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/machinelock"
 )
 
 // ContainerSetup is a StringsWatchHandler that is notified when containers
@@ -37,7 +37,7 @@ type ContainerSetup struct {
 	provisioner           *apiprovisioner.State
 	machine               *apiprovisioner.Machine
 	config                agent.Config
-	initLock              *fslock.Lock
+	initLock              *machinelock.Lock
 	addressableContainers bool
 	enableNAT             bool
 	lxcDefaultMTU         int
@@ -61,7 +61,7 @@ type ContainerSetupParams struct {
 	Machine             *apiprovisioner.Machine
 	Provisioner         *apiprovisioner.State
 	Config              agent.Config
-	InitLock            *fslock.Lock
+	InitLock            *machinelock.Lock
 }
 
 // NewContainerSetupHandler returns a StringsWatchHandler which is notified when
@@ -188,10 +188,16 @@ func maybeOverrideDefaultLXCNet(containerType instance.ContainerType, addressabl
 // runInitialiser runs the container initialiser with the initialisation hook held.
 func (cs *ContainerSetup) runInitialiser(containerType instance.ContainerType, initialiser container.Initialiser) error {
 	logger.Debugf("running initialiser for %s containers", containerType)
-	if err := cs.initLock.Lock(fmt.Sprintf("initialise-%s", containerType)); err != nil {
+	// Initialisers install packages, so nothing else may run alongside.
+	release, err := cs.initLock.Acquire(machinelock.Spec{
+		Owner:     cs.config.Tag().String(),
+		Message:   fmt.Sprintf("initialise-%s", containerType),
+		Exclusive: true,
+	})
+	if err != nil {
 		return errors.Annotate(err, "failed to acquire initialization lock")
 	}
-	defer cs.initLock.Unlock()
+	defer release()
 
 	// Only tweak default LXC network config when address allocation
 	// feature flag is enabled.
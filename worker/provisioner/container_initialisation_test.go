@@ -16,7 +16,6 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/featureflag"
-	"github.com/juju/utils/fslock"
 	jujuos "github.com/juju/utils/os"
 	"github.com/juju/utils/packaging/manager"
 	"github.com/juju/utils/series"
@@ -39,6 +38,7 @@ import (
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/provisioner"
 )
 
@@ -49,7 +49,7 @@ type ContainerSetupSuite struct {
 	// Record the apt commands issued as part of container initialisation
 	aptCmdChan  <-chan *exec.Cmd
 	initLockDir string
-	initLock    *fslock.Lock
+	initLock    *machinelock.Lock
 	fakeLXCNet  string
 }
 
@@ -87,8 +87,9 @@ func (s *ContainerSetupSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Create a new container initialisation lock.
-	s.initLockDir = c.MkDir()
-	initLock, err := fslock.NewLock(s.initLockDir, "container-init", fslock.Defaults())
+	lockConfig := machinelock.DefaultConfig(c.MkDir())
+	s.initLockDir = lockConfig.LockDir
+	initLock, err := machinelock.New(lockConfig)
 	c.Assert(err, jc.ErrorIsNil)
 	s.initLock = initLock
 
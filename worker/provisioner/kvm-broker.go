@@ -117,6 +117,7 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		kvmLogger.Errorf("failed to populate machine config: %v", err)
 		return nil, err
 	}
+	setMachineLockSlots(args.InstanceConfig, config)
 
 	storageConfig := &container.StorageConfig{
 		AllowMount: true,
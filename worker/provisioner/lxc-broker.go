@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -146,6 +147,7 @@ func (broker *lxcBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		lxcLogger.Errorf("failed to populate machine config: %v", err)
 		return nil, err
 	}
+	setMachineLockSlots(args.InstanceConfig, config)
 
 	inst, hardware, err := broker.manager.CreateContainer(
 		args.InstanceConfig, args.Constraints,
@@ -732,3 +734,11 @@ func matchHostArchTools(allTools tools.List) (tools.List, error) {
 	}
 	return archTools, nil
 }
+
+// setMachineLockSlots passes the model's machine-lock-slots setting,
+// if any, to the agents of the container being started.
+func setMachineLockSlots(icfg *instancecfg.InstanceConfig, config params.ContainerConfig) {
+	if config.MachineLockSlots > 0 {
+		icfg.AgentEnvironment[agent.MachineLockSlots] = strconv.Itoa(config.MachineLockSlots)
+	}
+}
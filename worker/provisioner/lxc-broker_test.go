@@ -320,6 +320,27 @@ func (s *lxcBrokerSuite) TestStartInstanceHostArch(c *gc.C) {
 	c.Assert(instanceConfig.AgentVersion().Arch, gc.Equals, arch.PPC64EL)
 }
 
+func (s *lxcBrokerSuite) TestStartInstanceMachineLockSlots(c *gc.C) {
+	s.api.fakeContainerConfig.MachineLockSlots = 4
+	instanceConfig := s.instanceConfig(c, "1/lxc/0")
+	possibleTools := coretools.List{&coretools.Tools{
+		Version: version.MustParseBinary("2.3.4-quantal-amd64"),
+		URL:     "http://tools.testing.invalid/2.3.4-quantal-amd64.tgz",
+	}}
+	callback := func(settableStatus status.Status, info string, data map[string]interface{}) error {
+		return nil
+	}
+
+	_, err := s.broker.StartInstance(environs.StartInstanceParams{
+		Constraints:    constraints.Value{},
+		Tools:          possibleTools,
+		InstanceConfig: instanceConfig,
+		StatusCallback: callback,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceConfig.AgentEnvironment[agent.MachineLockSlots], gc.Equals, "4")
+}
+
 func (s *lxcBrokerSuite) TestStartInstanceToolsArchNotFound(c *gc.C) {
 	instanceConfig := s.instanceConfig(c, "1/lxc/0")
 
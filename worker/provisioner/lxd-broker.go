@@ -102,6 +102,7 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		lxdLogger.Errorf("failed to populate machine config: %v", err)
 		return nil, err
 	}
+	setMachineLockSlots(args.InstanceConfig, config)
 
 	storageConfig := &container.StorageConfig{}
 	inst, hardware, err := broker.manager.CreateContainer(
@@ -13,6 +13,7 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/machinelock"
 )
 
 // ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
@@ -38,7 +39,7 @@ func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	lock, err := cmdutil.HookExecutionLock(cmdutil.DataDir)
+	lock, err := machinelock.New(machinelock.DefaultConfig(cmdutil.DataDir))
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
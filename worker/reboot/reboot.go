@@ -3,7 +3,6 @@ package reboot
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
-	"github.com/juju/utils/fslock"
 	"gopkg.in/juju/names.v2"
 	"launchpad.net/tomb"
 
@@ -12,12 +11,17 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/machinelock"
 )
 
 var logger = loggo.GetLogger("juju.worker.reboot")
 
 const RebootMessage = "preparing for reboot"
 
+// rebootOwner identifies the reboot worker as the holder of the
+// machine lock.
+const rebootOwner = "reboot"
+
 // The reboot worker listens for changes to the reboot flag and
 // exists with worker.ErrRebootMachine if the machine should reboot or
 // with worker.ErrShutdownMachine if it should shutdown. This will be picked
@@ -27,10 +31,10 @@ type Reboot struct {
 	tomb        tomb.Tomb
 	st          reboot.State
 	tag         names.MachineTag
-	machineLock *fslock.Lock
+	machineLock *machinelock.Lock
 }
 
-func NewReboot(st reboot.State, agentConfig agent.Config, machineLock *fslock.Lock) (worker.Worker, error) {
+func NewReboot(st reboot.State, agentConfig agent.Config, machineLock *machinelock.Lock) (worker.Worker, error) {
 	tag, ok := agentConfig.Tag().(names.MachineTag)
 	if !ok {
 		return nil, errors.Errorf("Expected names.MachineTag, got %T: %v", agentConfig.Tag(), agentConfig.Tag())
@@ -47,19 +51,9 @@ func NewReboot(st reboot.State, agentConfig agent.Config, machineLock *fslock.Lo
 }
 
 func (r *Reboot) checkForRebootState() error {
-	if r.machineLock.IsLocked() == false {
-		return nil
-	}
-
-	msg, err := r.machineLock.Message()
-	if err != nil {
-		return errors.Trace(err)
-	}
-	if msg != RebootMessage {
-		return nil
-	}
-	// Not a lock held by the machne agent in order to reboot
-	err = r.machineLock.BreakLock()
+	// Break the lock if it was left held by the machine agent in
+	// order to reboot.
+	err := r.machineLock.BreakLocks(rebootOwner)
 	return errors.Trace(err)
 }
 
@@ -79,15 +73,28 @@ func (r *Reboot) Handle(_ <-chan struct{}) error {
 	logger.Debugf("Reboot worker got action: %v", rAction)
 	switch rAction {
 	case params.ShouldReboot:
-		r.machineLock.Lock(RebootMessage)
+		r.acquireLock()
 		return worker.ErrRebootMachine
 	case params.ShouldShutdown:
-		r.machineLock.Lock(RebootMessage)
+		r.acquireLock()
 		return worker.ErrShutdownMachine
 	}
 	return nil
 }
 
+// acquireLock waits for everything else on the machine to finish,
+// and then holds the machine lock until the machine restarts.
+func (r *Reboot) acquireLock() {
+	_, err := r.machineLock.Acquire(machinelock.Spec{
+		Owner:     rebootOwner,
+		Message:   RebootMessage,
+		Exclusive: true,
+	})
+	if err != nil {
+		logger.Errorf("cannot acquire machine lock: %v", err)
+	}
+}
+
 func (r *Reboot) TearDown() error {
 	// nothing to teardown.
 	return nil
@@ -5,7 +5,6 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
-	"github.com/juju/utils/fslock"
 	"github.com/juju/utils/series"
 	gc "gopkg.in/check.v1"
 
@@ -16,6 +15,7 @@ import (
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/reboot"
 )
 
@@ -39,8 +39,7 @@ type rebootSuite struct {
 	ct            *state.Machine
 	ctRebootState apireboot.State
 
-	lock       *fslock.Lock
-	lockReboot *fslock.Lock
+	lock *machinelock.Lock
 }
 
 var _ = gc.Suite(&rebootSuite{})
@@ -74,7 +73,7 @@ func (s *rebootSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.ctRebootState, gc.NotNil)
 
-	lock, err := fslock.NewLock(c.MkDir(), "fake", fslock.Defaults())
+	lock, err := machinelock.New(machinelock.DefaultConfig(c.MkDir()))
 	c.Assert(err, jc.ErrorIsNil)
 	s.lock = lock
 }
@@ -107,12 +106,25 @@ func (s *rebootSuite) TestContainerCatchesParentFlag(c *gc.C) {
 }
 
 func (s *rebootSuite) TestCleanupIsDoneOnBoot(c *gc.C) {
-	s.lock.Lock(reboot.RebootMessage)
-
+	// The worker holds the lock when it asks for a reboot.
 	wrk, err := reboot.NewReboot(s.rebootState, s.AgentConfigForTag(c, s.machine.Tag()), s.lock)
 	c.Assert(err, jc.ErrorIsNil)
+	err = s.rebootState.RequestReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(wrk.Wait(), gc.Equals, worker.ErrRebootMachine)
+	report, err := s.lock.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Holders, jc.DeepEquals, []string{"reboot: " + reboot.RebootMessage})
+
+	// After the reboot, it is released.
+	err = s.rebootState.ClearReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	wrk, err = reboot.NewReboot(s.rebootState, s.AgentConfigForTag(c, s.machine.Tag()), s.lock)
+	c.Assert(err, jc.ErrorIsNil)
 	wrk.Kill()
 	c.Assert(wrk.Wait(), gc.IsNil)
 
-	c.Assert(s.lock.IsLocked(), jc.IsFalse)
+	report, err = s.lock.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Holders, gc.HasLen, 0)
 }
@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// MetaExtras holds the fields of a charm's metadata.yaml that affect
+// how the uniter runs the charm, but are not part of charm.Meta.
+type MetaExtras struct {
+	// HookTimeoutValue is the charm's default limit on how long
+	// its hooks and actions may run.
+	HookTimeoutValue string `yaml:"hook-timeout"`
+
+	// MachineLockValue is "exclusive" if the charm's hooks must not
+	// run alongside any others on the machine, or "shared" (or empty)
+	// if they may.
+	MachineLockValue string `yaml:"machine-lock"`
+}
+
+// ReadMetaExtras returns the MetaExtras declared in the metadata.yaml
+// of the charm deployed in charmDir. If there is no metadata.yaml, as
+// before the charm is deployed, no extras are declared.
+func ReadMetaExtras(charmDir string) (MetaExtras, error) {
+	var extras MetaExtras
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return extras, nil
+	} else if err != nil {
+		return extras, errors.Trace(err)
+	}
+	if err := goyaml.Unmarshal(data, &extras); err != nil {
+		return extras, errors.Annotate(err, "cannot parse charm metadata")
+	}
+	return extras, nil
+}
+
+// HookTimeout returns the hook timeout declared by the charm, or zero
+// if there is none.
+func (extras MetaExtras) HookTimeout() (time.Duration, error) {
+	if extras.HookTimeoutValue == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(extras.HookTimeoutValue)
+	if err != nil {
+		return 0, errors.Annotate(err, "invalid hook-timeout in charm metadata")
+	}
+	if timeout < 0 {
+		return 0, errors.Errorf("invalid hook-timeout in charm metadata: negative duration %q", extras.HookTimeoutValue)
+	}
+	return timeout, nil
+}
+
+// ExclusiveMachineLock returns whether the charm requires the machine
+// lock to itself while its hooks run, as when they install packages.
+func (extras MetaExtras) ExclusiveMachineLock() (bool, error) {
+	switch extras.MachineLockValue {
+	case "", "shared":
+		return false, nil
+	case "exclusive":
+		return true, nil
+	}
+	return false, errors.NotValidf("machine-lock %q in charm metadata", extras.MachineLockValue)
+}
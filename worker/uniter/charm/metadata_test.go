@@ -0,0 +1,83 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/charm"
+)
+
+type MetaExtrasSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MetaExtrasSuite{})
+
+func (s *MetaExtrasSuite) readExtras(c *gc.C, metadata string) charm.MetaExtras {
+	charmDir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(charmDir, "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	extras, err := charm.ReadMetaExtras(charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	return extras
+}
+
+func (s *MetaExtrasSuite) TestNoMetadata(c *gc.C) {
+	extras, err := charm.ReadMetaExtras(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(extras, jc.DeepEquals, charm.MetaExtras{})
+	timeout, err := extras.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, time.Duration(0))
+	exclusive, err := extras.ExclusiveMachineLock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exclusive, jc.IsFalse)
+}
+
+func (s *MetaExtrasSuite) TestInvalidMetadata(c *gc.C) {
+	charmDir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(charmDir, "metadata.yaml"), []byte("]["), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = charm.ReadMetaExtras(charmDir)
+	c.Assert(err, gc.ErrorMatches, "cannot parse charm metadata: .*")
+}
+
+func (s *MetaExtrasSuite) TestHookTimeout(c *gc.C) {
+	extras := s.readExtras(c, "name: foo\nhook-timeout: 5m\n")
+	timeout, err := extras.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, 5*time.Minute)
+
+	extras = s.readExtras(c, "hook-timeout: soon\n")
+	_, err = extras.HookTimeout()
+	c.Assert(err, gc.ErrorMatches, `invalid hook-timeout in charm metadata: time: invalid duration "?soon"?`)
+
+	extras = s.readExtras(c, "hook-timeout: -5m\n")
+	_, err = extras.HookTimeout()
+	c.Assert(err, gc.ErrorMatches, `invalid hook-timeout in charm metadata: negative duration "-5m"`)
+}
+
+func (s *MetaExtrasSuite) TestExclusiveMachineLock(c *gc.C) {
+	for metadata, expect := range map[string]bool{
+		"name: foo\n":               false,
+		"machine-lock: shared\n":    false,
+		"machine-lock: exclusive\n": true,
+	} {
+		exclusive, err := s.readExtras(c, metadata).ExclusiveMachineLock()
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(exclusive, gc.Equals, expect)
+	}
+
+	_, err := s.readExtras(c, "machine-lock: sometimes\n").ExclusiveMachineLock()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `machine-lock "sometimes" in charm metadata not valid`)
+}
@@ -6,7 +6,6 @@ package uniter
 import (
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
-	"github.com/juju/utils/fslock"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
@@ -17,6 +16,7 @@ import (
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/fortress"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/uniter/operation"
 )
 
@@ -57,7 +57,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				// leader-deposed hook -- but that's not done yet.
 				return nil, err
 			}
-			var machineLock *fslock.Lock
+			var machineLock *machinelock.Lock
 			if err := context.Get(config.MachineLockName, &machineLock); err != nil {
 				return nil, err
 			}
@@ -191,6 +191,8 @@ type mockUnit struct {
 	configSettingsWatcher *mockNotifyWatcher
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
+	secretsWatcher        *mockNotifyWatcher
+	secretRevisions       map[string]int
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -233,6 +235,14 @@ func (u *mockUnit) WatchActionNotifications() (watcher.StringsWatcher, error) {
 	return u.actionWatcher, nil
 }
 
+func (u *mockUnit) WatchSecrets() (watcher.NotifyWatcher, error) {
+	return u.secretsWatcher, nil
+}
+
+func (u *mockUnit) SecretRevisions() (map[string]int, error) {
+	return u.secretRevisions, nil
+}
+
 type mockService struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
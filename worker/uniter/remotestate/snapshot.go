@@ -68,6 +68,11 @@ type Snapshot struct {
 	// Commands is the list of IDs of commands to be
 	// executed by this unit.
 	Commands []string
+
+	// SecretRevisions contains the current revision of
+	// each secret the unit has been granted access to,
+	// keyed by secret ID.
+	SecretRevisions map[string]int
 }
 
 type RelationSnapshot struct {
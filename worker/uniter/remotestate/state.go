@@ -32,6 +32,8 @@ type Unit interface {
 	WatchConfigSettings() (watcher.NotifyWatcher, error)
 	WatchStorage() (watcher.StringsWatcher, error)
 	WatchActionNotifications() (watcher.StringsWatcher, error)
+	WatchSecrets() (watcher.NotifyWatcher, error)
+	SecretRevisions() (map[string]int, error)
 }
 
 type Application interface {
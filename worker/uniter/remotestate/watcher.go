@@ -126,6 +126,10 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.Actions, w.current.Actions)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	snapshot.SecretRevisions = make(map[string]int)
+	for id, revision := range w.current.SecretRevisions {
+		snapshot.SecretRevisions[id] = revision
+	}
 	return snapshot
 }
 
@@ -256,6 +260,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenSecretsChange bool
+	secretsw, err := w.unit.WatchSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(secretsw); err != nil {
+		return errors.Trace(err)
+	}
+	requiredEvents++
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -364,6 +378,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenActionsChange)
 
+		case _, ok := <-secretsw.Changes():
+			logger.Debugf("got secrets change: ok=%t", ok)
+			if !ok {
+				return errors.New("secrets watcher closed")
+			}
+			if err := w.secretsChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenSecretsChange)
+
 		case keys, ok := <-relationsw.Changes():
 			logger.Debugf("got relations change: ok=%t", ok)
 			if !ok {
@@ -524,6 +548,19 @@ func (w *RemoteStateWatcher) leaderSettingsChanged() error {
 	return nil
 }
 
+// secretsChanged responds to changes in the secrets the unit has
+// been granted access to.
+func (w *RemoteStateWatcher) secretsChanged() error {
+	revisions, err := w.unit.SecretRevisions()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.SecretRevisions = revisions
+	w.mu.Unlock()
+	return nil
+}
+
 func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) error {
 	w.mu.Lock()
 	w.current.Leader = isLeader
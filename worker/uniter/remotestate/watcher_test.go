@@ -52,6 +52,8 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			configSettingsWatcher: newMockNotifyWatcher(),
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
+			secretsWatcher:        newMockNotifyWatcher(),
+			secretRevisions:       map[string]int{"secret-id": 1},
 		},
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
@@ -106,6 +108,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.configSettingsWatcher.changes <- struct{}{}
 	s.st.unit.storageWatcher.changes <- []string{}
 	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.secretsWatcher.changes <- struct{}{}
 	s.st.unit.service.serviceWatcher.changes <- struct{}{}
 	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.service.relationsWatcher.changes <- []string{}
@@ -119,6 +122,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.configSettingsWatcher.changes <- struct{}{}
 	st.unit.storageWatcher.changes <- []string{}
 	st.unit.actionWatcher.changes <- []string{}
+	st.unit.secretsWatcher.changes <- struct{}{}
 	st.unit.service.serviceWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.service.relationsWatcher.changes <- []string{}
@@ -141,6 +145,7 @@ func (s *WatcherSuite) TestSnapshot(c *gc.C) {
 		ConfigVersion:         2, // config settings and addresses
 		LeaderSettingsVersion: 1,
 		Leader:                true,
+		SecretRevisions:       map[string]int{"secret-id": 1},
 	})
 }
 
@@ -195,6 +200,19 @@ func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action"})
 }
 
+func (s *WatcherSuite) TestSecretsChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.unit.secretRevisions = map[string]int{"secret-id": 2, "other-id": 1}
+	s.st.unit.secretsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRevisions, jc.DeepEquals, map[string]int{
+		"secret-id": 2,
+		"other-id":  1,
+	})
+}
+
 func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	s.st.unit.resolved = params.ResolvedRetryHooks
 	signalAll(s.st, s.leadership)
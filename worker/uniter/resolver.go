@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
@@ -271,6 +273,10 @@ func (s *uniterResolver) nextOp(
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
 	}
 
+	if id, ok := nextChangedSecret(localState, remoteState); ok {
+		return opFactory.NewRunHook(hook.Info{Kind: hook.SecretChanged, SecretId: id})
+	}
+
 	op, err := s.config.Relations.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
@@ -283,3 +289,20 @@ func (s *uniterResolver) nextOp(
 
 	return nil, resolver.ErrNoOperation
 }
+
+// nextChangedSecret returns the ID of a secret whose revision has changed
+// since the last secret-changed hook committed for it, if there is one.
+// Secrets are considered in ID order so that hooks run deterministically.
+func nextChangedSecret(localState resolver.LocalState, remoteState remotestate.Snapshot) (string, bool) {
+	ids := make([]string, 0, len(remoteState.SecretRevisions))
+	for id, revision := range remoteState.SecretRevisions {
+		if localState.SecretRevisions[id] != revision {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return "", false
+	}
+	sort.Strings(ids)
+	return ids[0], true
+}
@@ -90,6 +90,11 @@ type LocalState struct {
 	// been committed.
 	LeaderSettingsVersion int
 
+	// SecretRevisions holds the revision of each secret from
+	// remotestate.Snapshot for which a secret-changed hook has
+	// been committed, keyed by secret ID.
+	SecretRevisions map[string]int
+
 	// CompletedActions is the set of actions that have been completed.
 	// This is used to prevent us re running actions requested by the
 	// controller.
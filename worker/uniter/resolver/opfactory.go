@@ -120,6 +120,14 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func() {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.SecretChanged:
+		v := s.RemoteState.SecretRevisions[info.SecretId]
+		op = onCommitWrapper{op, func() {
+			if s.LocalState.SecretRevisions == nil {
+				s.LocalState.SecretRevisions = make(map[string]int)
+			}
+			s.LocalState.SecretRevisions[info.SecretId] = v
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
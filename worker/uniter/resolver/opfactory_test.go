@@ -126,6 +126,29 @@ func (s *ResolverOpFactorySuite) testLeaderSettingsChanged(
 	c.Assert(f.LocalState.UpdateStatusVersion, gc.Equals, 3)
 }
 
+func (s *ResolverOpFactorySuite) TestSecretChanged(c *gc.C) {
+	s.testSecretChanged(c, resolver.ResolverOpFactory.NewRunHook)
+	s.testSecretChanged(c, resolver.ResolverOpFactory.NewSkipHook)
+}
+
+func (s *ResolverOpFactorySuite) testSecretChanged(
+	c *gc.C, meth func(resolver.ResolverOpFactory, hook.Info) (operation.Operation, error),
+) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	f.RemoteState.SecretRevisions = map[string]int{"a": 1, "b": 2}
+
+	op, err := meth(f, hook.Info{Kind: hook.SecretChanged, SecretId: "a"})
+	c.Assert(err, jc.ErrorIsNil)
+	f.RemoteState.SecretRevisions = map[string]int{"a": 3, "b": 4}
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the revision of the secret the hook ran for should be
+	// recorded, as it was when the operation was constructed.
+	c.Assert(f.LocalState.SecretRevisions, jc.DeepEquals, map[string]int{"a": 1})
+}
+
 func (s *ResolverOpFactorySuite) TestUpgrade(c *gc.C) {
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewUpgrade)
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewRevertUpgrade)
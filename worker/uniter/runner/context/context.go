@@ -159,6 +159,10 @@ type HookContext struct {
 	// proxySettings are the current proxy settings that the uniter knows about.
 	proxySettings proxy.Settings
 
+	// hookTimeout is the model's bound on how long a hook or action
+	// may run, or zero if the model does not set one.
+	hookTimeout time.Duration
+
 	// meterStatus is the status of the unit's metering.
 	meterStatus *meterStatus
 
@@ -189,6 +193,10 @@ type HookContext struct {
 	// storageId is the tag of the storage instance associated with the running hook.
 	storageTag names.StorageTag
 
+	// secretId is the ID of the secret whose change triggered the
+	// running hook. It is only set for secret-changed hooks.
+	secretId string
+
 	// hasRunSetStatus is true if a call to the status-set was made during the
 	// invocation of a hook.
 	// This attribute is persisted to local uniter state at the end of the hook
@@ -202,6 +210,16 @@ type HookContext struct {
 	// hook run, so the actual add will happen in a flush.
 	storageAddConstraints map[string][]params.StorageConstraints
 
+	// unitState holds the unit's charm state as read from the controller,
+	// with any changes made by the running hook applied. It is read
+	// lazily, on first use.
+	unitState map[string]string
+
+	// unitStateChanges holds the changes made to the unit's charm state
+	// by the running hook, with empty values for deleted keys. The
+	// changes are written to the controller in a flush.
+	unitStateChanges map[string]string
+
 	// clock is used for any time operations.
 	clock clock.Clock
 
@@ -352,6 +370,12 @@ func (ctx *HookContext) SetUnitStatus(unitStatus jujuc.StatusInfo) error {
 	)
 }
 
+// SetUnitWorkloadVersion sets the workload version for the unit.
+// Implements jujuc.ContextVersion.
+func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
+	return ctx.unit.SetWorkloadVersion(version)
+}
+
 // SetApplicationStatus will set the given status to the service to which this
 // unit's belong, only if this unit is the leader.
 func (ctx *HookContext) SetApplicationStatus(serviceStatus jujuc.StatusInfo) error {
@@ -384,6 +408,21 @@ func (ctx *HookContext) ResetExecutionSetUnitStatus() {
 	ctx.hasRunStatusSet = false
 }
 
+// RecordHookExecution records the execution of a hook, action or
+// juju-run command in the unit's hook history, adding the relation
+// and remote unit of the context, if any.
+func (ctx *HookContext) RecordHookExecution(execution params.HookExecution) error {
+	execution.RelationId = ctx.relationId
+	execution.RemoteUnit = ctx.remoteUnitName
+	return ctx.unit.AddHookExecution(execution)
+}
+
+// HookTimeout returns the model's bound on how long a hook or action
+// may run, or zero if the model does not set one.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *HookContext) PublicAddress() (string, error) {
 	if ctx.publicAddress == "" {
 		return "", errors.NotFoundf("public address")
@@ -460,6 +499,27 @@ func (ctx *HookContext) OpenedPorts() []network.PortRange {
 	return unitRanges
 }
 
+// GoalState is part of the jujuc.ContextUnit interface. It is not
+// cached, so that a charm waiting for its peers sees them arrive.
+func (ctx *HookContext) GoalState() (*params.GoalState, error) {
+	goalState, err := ctx.unit.GoalState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &goalState, nil
+}
+
+// CloudSpec is part of the jujuc.ContextUnit interface. It is not
+// cached, so that each retrieval is checked and audited by the
+// controller.
+func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
+	spec, err := ctx.unit.CloudSpec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &spec, nil
+}
+
 func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	if ctx.configSettings == nil {
 		var err error
@@ -591,6 +651,9 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.secretId != "" {
+		vars = append(vars, "JUJU_SECRET_ID="+context.secretId)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -713,6 +776,16 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	if len(ctx.unitStateChanges) > 0 && writeChanges {
+		if err := ctx.unit.UpdateState(ctx.unitStateChanges); err != nil {
+			err = errors.Annotatef(err, "cannot write unit state")
+			logger.Errorf("%v", err)
+			if ctxErr == nil {
+				ctxErr = err
+			}
+		}
+	}
+
 	// TODO (tasdomas) 2014 09 03: context finalization needs to modified to apply all
 	//                             changes in one api call to minimize the risk
 	//                             of partial failures.
@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -98,6 +99,37 @@ func (s *InterfaceSuite) TestUnitNetworkConfig(c *gc.C) {
 	c.Check(netConfig, gc.IsNil)
 }
 
+func (s *InterfaceSuite) TestGoalState(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	goalState, err := ctx.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(goalState.Units, gc.HasLen, 1)
+	_, ok := goalState.Units[ctx.UnitName()]
+	c.Check(ok, jc.IsTrue)
+}
+
+func (s *InterfaceSuite) TestCloudSpec(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	_, err := ctx.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, `application "u" is not trusted with the cloud credential`)
+
+	err = s.service.SetTrusted()
+	c.Assert(err, jc.ErrorIsNil)
+	spec, err := ctx.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.Type, gc.Equals, "dummy")
+}
+
+func (s *InterfaceSuite) TestSetUnitWorkloadVersion(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	err := ctx.SetUnitWorkloadVersion("2.1-beta")
+	c.Assert(err, jc.ErrorIsNil)
+
+	version, err := s.unit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "2.1-beta")
+}
+
 func (s *InterfaceSuite) TestUnitStatus(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	defer context.PatchCachedStatus(ctx.(runner.Context), "maintenance", "working", map[string]interface{}{"hello": "world"})()
@@ -135,6 +167,56 @@ func (s *InterfaceSuite) TestSetUnitStatusUpdatesFlag(c *gc.C) {
 	c.Assert(ctx.(runner.Context).HasExecutionSetUnitStatus(), jc.IsTrue)
 }
 
+func (s *InterfaceSuite) TestRecordHookExecution(c *gc.C) {
+	ctx := s.GetContext(c, 1, "u/123")
+	started := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	err := ctx.(runner.Context).RecordHookExecution(params.HookExecution{
+		Kind:     "hook",
+		Name:     "db-relation-changed",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Output:   "failed\n",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Name, gc.Equals, "db-relation-changed")
+	c.Check(history[0].RelationId, gc.Equals, 1)
+	c.Check(history[0].RemoteUnit, gc.Equals, "u/123")
+	c.Check(history[0].ExitCode, gc.Equals, 1)
+	c.Check(history[0].Output, gc.Equals, "failed\n")
+}
+
+func (s *InterfaceSuite) TestSecrets(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	id, err := ctx.AddSecret(jujuc.SecretOwnerUnit, "admin", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := ctx.SecretValue(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+
+	err = ctx.GrantSecret(id, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), jc.DeepEquals, []state.SecretGrant{{
+		RelationKey: s.relunits[0].Relation().String(),
+		Application: "db0",
+	}})
+
+	err = ctx.RevokeSecret(id, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), gc.HasLen, 0)
+
+	err = ctx.GrantSecret(id, 99)
+	c.Assert(err, gc.ErrorMatches, "relation not found")
+}
+
 func (s *InterfaceSuite) TestUnitStatusCaching(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	unitStatus, err := ctx.UnitStatus()
@@ -213,6 +213,9 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.SecretChanged {
+		ctx.secretId = hookInfo.SecretId
+	}
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -290,6 +293,7 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 		return err
 	}
 	ctx.proxySettings = environConfig.ProxySettings()
+	ctx.hookTimeout = environConfig.HookTimeout()
 
 	// Calling these last, because there's a potential race: they're not guaranteed
 	// to be set in time to be needed for a hook. If they're not, we just leave them
@@ -162,6 +162,32 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestSecretChangedHookContext(c *gc.C) {
+	hi := hook.Info{
+		Kind:     hook.SecretChanged,
+		SecretId: "some-secret-id",
+	}
+	ctx, err := s.factory.HookContext(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertCoreContext(c, ctx)
+	s.AssertNotActionContext(c, ctx)
+	s.AssertNotRelationContext(c, ctx)
+	s.AssertNotStorageContext(c, ctx)
+	c.Assert(context.ContextSecretId(ctx), gc.Equals, "some-secret-id")
+}
+
+func (s *ContextFactorySuite) TestHookContextHookTimeout(c *gc.C) {
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, time.Duration(0))
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"hook-timeout": "30m"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err = s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, 30*time.Minute)
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
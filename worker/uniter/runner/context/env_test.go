@@ -94,6 +94,11 @@ func (s *EnvSuite) setRelation(ctx *context.HookContext) (expectVars []string) {
 	}
 }
 
+func (s *EnvSuite) setSecret(ctx *context.HookContext) (expectVars []string) {
+	context.SetEnvironmentHookContextSecret(ctx, "some-secret-id")
+	return []string{"JUJU_SECRET_ID=some-secret-id"}
+}
+
 func (s *EnvSuite) TestEnvSetsPath(c *gc.C) {
 	paths := context.OSDependentEnvVars(MockEnvPaths{})
 	c.Assert(paths, gc.Not(gc.HasLen), 0)
@@ -146,4 +151,9 @@ func (s *EnvSuite) TestEnvUbuntu(c *gc.C) {
 	actualVars, err = ctx.HookVars(paths)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, relationVars)
+
+	secretVars := s.setSecret(ctx)
+	actualVars, err = ctx.HookVars(paths)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, relationVars, secretVars)
 }
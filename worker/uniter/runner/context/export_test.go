@@ -103,6 +103,14 @@ func SetEnvironmentHookContextRelation(
 	}
 }
 
+func ContextSecretId(context *HookContext) string {
+	return context.secretId
+}
+
+func SetEnvironmentHookContextSecret(context *HookContext, secretId string) {
+	context.secretId = secretId
+}
+
 func PatchCachedStatus(ctx jujuc.Context, status, info string, data map[string]interface{}) func() {
 	hctx := ctx.(*HookContext)
 	oldStatus := hctx.status
@@ -207,6 +207,42 @@ func (s *FlushContextSuite) TestRunHookAddUnitStorageOnSuccess(c *gc.C) {
 	c.Assert(all, gc.HasLen, 0)
 }
 
+func (s *FlushContextSuite) TestRunHookUnitStateOnFailure(c *gc.C) {
+	ctx := s.context(c)
+	err := ctx.SetUnitStateValue("initialised", "true")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with an error.
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+}
+
+func (s *FlushContextSuite) TestRunHookUnitStateOnSuccess(c *gc.C) {
+	err := s.unit.UpdateState(map[string]string{"stale": "yes", "keep": "me"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.context(c)
+	err = ctx.SetUnitStateValue("initialised", "true")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteUnitStateValue("stale")
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err := ctx.UnitState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"initialised": "true", "keep": "me"})
+
+	// Flush the context with a success.
+	err = ctx.Flush("success", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err = s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"initialised": "true", "keep": "me"})
+}
+
 func (s *HookContextSuite) context(c *gc.C) *context.HookContext {
 	uuid, err := utils.NewUUID()
 	c.Assert(err, jc.ErrorIsNil)
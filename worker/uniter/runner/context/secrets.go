@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// AddSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) AddSecret(owner jujuc.SecretOwner, label string, data map[string]string) (string, error) {
+	var ownerTag names.Tag
+	switch owner {
+	case jujuc.SecretOwnerUnit:
+		ownerTag = ctx.unit.Tag()
+	case jujuc.SecretOwnerApplication:
+		ownerTag = ctx.unit.ApplicationTag()
+	default:
+		return "", errors.NotValidf("secret owner %q", owner)
+	}
+	return ctx.state.AddSecret(ownerTag, label, data)
+}
+
+// SecretValue is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) SecretValue(id string) (map[string]string, error) {
+	return ctx.state.SecretValue(id)
+}
+
+// GrantSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) GrantSecret(id string, relationId int) error {
+	tag, err := ctx.relationTag(relationId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.state.GrantSecret(id, tag)
+}
+
+// RevokeSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) RevokeSecret(id string, relationId int) error {
+	tag, err := ctx.relationTag(relationId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.state.RevokeSecret(id, tag)
+}
+
+func (ctx *HookContext) relationTag(relationId int) (names.RelationTag, error) {
+	r, found := ctx.relations[relationId]
+	if !found {
+		return names.RelationTag{}, errors.NotFoundf("relation")
+	}
+	return r.ru.Relation().Tag(), nil
+}
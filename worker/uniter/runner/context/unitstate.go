@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/errors"
+)
+
+// UnitState is part of the jujuc.ContextUnitState interface.
+func (ctx *HookContext) UnitState() (map[string]string, error) {
+	if err := ctx.ensureUnitState(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(ctx.unitState))
+	for key, value := range ctx.unitState {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// SetUnitStateValue is part of the jujuc.ContextUnitState interface.
+func (ctx *HookContext) SetUnitStateValue(key, value string) error {
+	if key == "" {
+		return errors.NotValidf("empty key")
+	}
+	if value == "" {
+		return ctx.DeleteUnitStateValue(key)
+	}
+	if err := ctx.ensureUnitState(); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.unitState[key] = value
+	ctx.recordUnitStateChange(key, value)
+	return nil
+}
+
+// DeleteUnitStateValue is part of the jujuc.ContextUnitState interface.
+func (ctx *HookContext) DeleteUnitStateValue(key string) error {
+	if err := ctx.ensureUnitState(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, found := ctx.unitState[key]; !found {
+		return nil
+	}
+	delete(ctx.unitState, key)
+	ctx.recordUnitStateChange(key, "")
+	return nil
+}
+
+func (ctx *HookContext) ensureUnitState() error {
+	if ctx.unitState != nil {
+		return nil
+	}
+	state, err := ctx.unit.State()
+	if err != nil {
+		return errors.Annotate(err, "cannot read unit state")
+	}
+	if state == nil {
+		state = make(map[string]string)
+	}
+	ctx.unitState = state
+	return nil
+}
+
+func (ctx *HookContext) recordUnitStateChange(key, value string) {
+	if ctx.unitStateChanges == nil {
+		ctx.unitStateChanges = make(map[string]string)
+	}
+	ctx.unitStateChanges[key] = value
+}
@@ -14,18 +14,27 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 )
 
-// ServerSession represents a "juju debug-hooks" session.
+// ServerSession represents a "juju debug-hooks" or "juju debug-code"
+// session.
 type ServerSession struct {
 	*HooksContext
-	hooks set.Strings
+	hooks   set.Strings
+	debugAt string
 }
 
-// MatchHook returns true if the specified hook name matches
-// the hook specified by the debug-hooks client.
+// MatchHook returns true if the specified hook or action name
+// matches one specified by the debug-hooks client.
 func (s *ServerSession) MatchHook(hookName string) bool {
 	return s.hooks.IsEmpty() || s.hooks.Contains(hookName)
 }
 
+// DebugAt returns the value of $JUJU_DEBUG_AT requested by a
+// "juju debug-code" client, or "" for an interactive debug-hooks
+// session.
+func (s *ServerSession) DebugAt() string {
+	return s.debugAt
+}
+
 // waitClientExit executes flock, waiting for the SSH client to exit.
 // This is a var so it can be replaced for testing.
 var waitClientExit = func(s *ServerSession) {
@@ -33,10 +42,19 @@ var waitClientExit = func(s *ServerSession) {
 	exec.Command("flock", path, "-c", "true").Run()
 }
 
-// RunHook "runs" the hook with the specified name via debug-hooks.
-func (s *ServerSession) RunHook(hookName, charmDir string, env []string) error {
+// RunHook "runs" the hook or action with the specified name via
+// debug-hooks. For a debug-code session, hookPath is the path of the
+// hook's executable, which is run with $JUJU_DEBUG_AT set instead of
+// leaving the user at a shell; it is ignored otherwise.
+func (s *ServerSession) RunHook(hookName, charmDir, hookPath string, env []string) error {
 	env = append(env, "JUJU_HOOK_NAME="+hookName)
-	cmd := exec.Command("/bin/bash", "-s")
+	if s.debugAt != "" {
+		if hookPath == "" {
+			return errors.New("debug-code session without hook path")
+		}
+		env = append(env, "JUJU_DEBUG_AT="+s.debugAt)
+	}
+	cmd := exec.Command("/bin/bash", "-s", hookPath)
 	cmd.Env = env
 	cmd.Dir = charmDir
 	cmd.Stdin = bytes.NewBufferString(debugHooksServerScript)
@@ -76,11 +94,12 @@ func (c *HooksContext) FindSession() (*ServerSession, error) {
 		return nil, err
 	}
 	hooks := set.NewStrings(args.Hooks...)
-	session := &ServerSession{c, hooks}
+	session := &ServerSession{c, hooks, args.DebugAt}
 	return session, nil
 }
 
 const debugHooksServerScript = `set -e
+HOOK_PATH=$1
 export JUJU_DEBUG=$(mktemp -d)
 exec > $JUJU_DEBUG/debug.log >&1
 
@@ -92,6 +111,15 @@ FILTER='^\(LS_COLORS\|LESSOPEN\|LESSCLOSE\|PWD\)='
 export | grep -v $FILTER > $JUJU_DEBUG/env.sh
 
 # Create welcome message display for the hook environment.
+if [ -n "$JUJU_DEBUG_AT" ]; then
+cat > $JUJU_DEBUG/welcome.msg <<END
+This is a Juju debug-code tmux session. Remember:
+1. $JUJU_HOOK_NAME is being run for you, with JUJU_DEBUG_AT=$JUJU_DEBUG_AT set so that the charm can stop in its debugger.
+2. When it completes, the window will close and Juju will continue running.
+3. CTRL+a is tmux prefix.
+
+END
+else
 cat > $JUJU_DEBUG/welcome.msg <<END
 This is a Juju debug-hooks tmux session. Remember:
 1. You need to execute hooks manually if you want them to run for trapped events.
@@ -102,12 +130,21 @@ More help and info is available in the online documentation:
 https://juju.ubuntu.com/docs/authors-hook-debug.html
 
 END
+fi
 
 cat > $JUJU_DEBUG/init.sh <<END
 #!/bin/bash
 cat $JUJU_DEBUG/welcome.msg
 trap 'echo \$? > $JUJU_DEBUG/hook_exit_status' EXIT
 END
+if [ -n "$JUJU_DEBUG_AT" ]; then
+# Run the hook itself, exiting the shell with its status.
+cat >> $JUJU_DEBUG/init.sh <<END
+cd "$PWD"
+"$HOOK_PATH"
+exit \$?
+END
+fi
 chmod +x $JUJU_DEBUG/init.sh
 
 # Create an internal script which will load the hook environment.
@@ -117,7 +117,7 @@ func (s *DebugHooksServerSuite) TestRunHookExceptional(c *gc.C) {
 	s.PatchValue(&waitClientExit, func(*ServerSession) {
 		flockAcquired <- struct{}{}
 	})
-	err = session.RunHook("myhook", s.tmpdir, os.Environ())
+	err = session.RunHook("myhook", s.tmpdir, "", os.Environ())
 	c.Assert(err, gc.ErrorMatches, "signal: [kK]illed")
 	waitForFlock()
 
@@ -133,7 +133,7 @@ func (s *DebugHooksServerSuite) TestRunHookExceptional(c *gc.C) {
 		flockAcquired <- struct{}{}
 	})
 	go func() { ch <- true }() // asynchronously release the flock
-	err = session.RunHook("myhook", s.tmpdir, os.Environ())
+	err = session.RunHook("myhook", s.tmpdir, "", os.Environ())
 	waitForFlock()
 	c.Assert(clientExited, jc.IsTrue)
 	c.Assert(err, gc.ErrorMatches, "signal: [kK]illed")
@@ -156,15 +156,84 @@ func (s *DebugHooksServerSuite) TestRunHook(c *gc.C) {
 	c.Assert(cmd.Start(), gc.IsNil)
 	ch := make(chan error)
 	go func() {
-		ch <- session.RunHook(hookName, s.tmpdir, os.Environ())
+		ch <- session.RunHook(hookName, s.tmpdir, "", os.Environ())
 	}()
 
 	// Wait until either we find the debug dir, or the flock is released.
+	debugdir := s.findDebugDir(c, ch)
+
+	envsh := filepath.Join(s.tmpdir, debugdir.Name(), "env.sh")
+	s.verifyEnvshFile(c, envsh, hookName)
+
+	hookpid := filepath.Join(s.tmpdir, debugdir.Name(), "hook.pid")
+	err = ioutil.WriteFile(hookpid, []byte("not a pid"), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// RunHook should complete without waiting to be
+	// killed, and despite the exit lock being held.
+	err = <-ch
+	c.Assert(err, jc.ErrorIsNil)
+	cmd.Process.Kill() // kill flock
+}
+
+func (s *DebugHooksServerSuite) TestRunHookDebugCode(c *gc.C) {
+	err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte(`{hooks: [myaction], debug-at: all}`), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	session, err := s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.MatchHook("myaction"), jc.IsTrue)
+	c.Assert(session.DebugAt(), gc.Equals, "all")
+
+	cmd := exec.Command("flock", s.ctx.ClientExitFileLock(), "-c", "sleep 5s")
+	c.Assert(cmd.Start(), gc.IsNil)
+	ch := make(chan error)
+	hookPath := filepath.Join(s.tmpdir, "actions", "myaction")
+	go func() {
+		ch <- session.RunHook("myaction", s.tmpdir, hookPath, os.Environ())
+	}()
+	debugdir := s.findDebugDir(c, ch)
+
+	envsh := filepath.Join(s.tmpdir, debugdir.Name(), "env.sh")
+	s.verifyEnvshFile(c, envsh, "myaction")
+	data, err := ioutil.ReadFile(envsh)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), jc.Contains, `JUJU_DEBUG_AT="all"`)
+
+	// Rather than leaving the user at a shell, the session
+	// runs the action itself.
+	data, err = ioutil.ReadFile(filepath.Join(s.tmpdir, debugdir.Name(), "init.sh"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), jc.Contains, fmt.Sprintf("\n%q\n", hookPath))
+	data, err = ioutil.ReadFile(filepath.Join(s.tmpdir, debugdir.Name(), "welcome.msg"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), jc.Contains, "This is a Juju debug-code tmux session.")
+
+	hookpid := filepath.Join(s.tmpdir, debugdir.Name(), "hook.pid")
+	err = ioutil.WriteFile(hookpid, []byte("not a pid"), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	err = <-ch
+	c.Assert(err, jc.ErrorIsNil)
+	cmd.Process.Kill() // kill flock
+}
+
+func (s *DebugHooksServerSuite) TestRunHookDebugCodeWithoutHookPath(c *gc.C) {
+	err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte(`{debug-at: all}`), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	session, err := s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = session.RunHook("myhook", s.tmpdir, "", os.Environ())
+	c.Assert(err, gc.ErrorMatches, "debug-code session without hook path")
+}
+
+// findDebugDir waits until the debug-hooks server script has created
+// its debug dir, failing if the hook completes first.
+func (s *DebugHooksServerSuite) findDebugDir(c *gc.C, ch <-chan error) os.FileInfo {
 	ticker := time.Tick(10 * time.Millisecond)
 	var debugdir os.FileInfo
 	for debugdir == nil {
 		select {
-		case err = <-ch:
+		case <-ch:
 			// flock was released before we found the debug dir.
 			c.Error("could not find hook.sh")
 
@@ -193,19 +262,7 @@ func (s *DebugHooksServerSuite) TestRunHook(c *gc.C) {
 			time.Sleep(10 * time.Millisecond)
 		}
 	}
-
-	envsh := filepath.Join(s.tmpdir, debugdir.Name(), "env.sh")
-	s.verifyEnvshFile(c, envsh, hookName)
-
-	hookpid := filepath.Join(s.tmpdir, debugdir.Name(), "hook.pid")
-	err = ioutil.WriteFile(hookpid, []byte("not a pid"), 0777)
-	c.Assert(err, jc.ErrorIsNil)
-
-	// RunHook should complete without waiting to be
-	// killed, and despite the exit lock being held.
-	err = <-ch
-	c.Assert(err, jc.ErrorIsNil)
-	cmd.Process.Kill() // kill flock
+	return debugdir
 }
 
 func (s *DebugHooksServerSuite) verifyEnvshFile(c *gc.C, envshPath string, hookName string) {
@@ -5,6 +5,7 @@ package runner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -28,3 +29,28 @@ func IsBadActionError(err error) bool {
 func NewBadActionError(actionName, problem string) error {
 	return &badActionError{actionName, problem}
 }
+
+// hookTimedOutError is returned when a hook or action is killed for
+// running longer than its timeout.
+type hookTimedOutError struct {
+	kind    string
+	name    string
+	timeout time.Duration
+}
+
+func (e *hookTimedOutError) Error() string {
+	return fmt.Sprintf("%s %q timed out after %v", e.kind, e.name, e.timeout)
+}
+
+// NewHookTimedOutError returns an error reporting that the named hook
+// or action was killed for running longer than timeout.
+func NewHookTimedOutError(kind, name string, timeout time.Duration) error {
+	return &hookTimedOutError{kind, name, timeout}
+}
+
+// IsHookTimedOutError returns whether the error, or its cause, reports
+// a hook or action killed for running longer than its timeout.
+func IsHookTimedOutError(err error) bool {
+	_, ok := errors.Cause(err).(*hookTimedOutError)
+	return ok
+}
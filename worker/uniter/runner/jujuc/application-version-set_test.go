@@ -0,0 +1,65 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+)
+
+type ApplicationVersionSetSuite struct {
+	jujuctesting.ContextSuite
+}
+
+var _ = gc.Suite(&ApplicationVersionSetSuite{})
+
+func (s *ApplicationVersionSetSuite) TestInitErrors(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no version specified",
+	}, {
+		args: []string{"1.2", "3.4"},
+		err:  `unrecognized args: \["3.4"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(hctx, cmdString("application-version-set"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ApplicationVersionSetSuite) TestSetVersion(c *gc.C) {
+	hctx, info := s.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("application-version-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"dia de los muertos"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(info.Version.WorkloadVersion, gc.Equals, "dia de los muertos")
+	s.Stub.CheckCallNames(c, "SetUnitWorkloadVersion")
+}
+
+func (s *ApplicationVersionSetSuite) TestSetVersionError(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	s.Stub.SetErrors(errors.New("uh oh spaghettio"))
+	com, err := jujuc.NewCommand(hctx, cmdString("application-version-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"cannae"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot set workload version: uh oh spaghettio\n")
+}
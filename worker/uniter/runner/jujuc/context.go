@@ -52,6 +52,9 @@ type HookContext interface {
 	ContextStorage
 	ContextComponents
 	ContextRelations
+	ContextSecrets
+	ContextUnitState
+	ContextVersion
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -105,6 +108,15 @@ type ContextUnit interface {
 
 	// Config returns the current service configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
+
+	// GoalState returns the intended deployment around the executing
+	// unit: the units of its application and of its related applications.
+	GoalState() (*params.GoalState, error)
+
+	// CloudSpec returns the cloud the model is deployed to, including
+	// the credential used to access it. It fails unless the unit's
+	// application has been trusted with the credential.
+	CloudSpec() (*params.CloudSpec, error)
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
@@ -231,6 +243,64 @@ type ContextRelations interface {
 	RelationIds() ([]int, error)
 }
 
+// SecretOwner identifies which entity owns a secret added by a hook:
+// the executing unit, or the unit's application.
+type SecretOwner string
+
+const (
+	// SecretOwnerUnit is the executing unit.
+	SecretOwnerUnit SecretOwner = "unit"
+
+	// SecretOwnerApplication is the executing unit's application. Only
+	// the application's leader may manage the application's secrets.
+	SecretOwnerApplication SecretOwner = "application"
+)
+
+// ContextSecrets is the part of a hook context related to charm secrets.
+type ContextSecrets interface {
+	// AddSecret adds a secret with the given owner and value, or
+	// rotates the value of the owner's existing secret with the
+	// given label, and returns the secret's ID.
+	AddSecret(owner SecretOwner, label string, data map[string]string) (string, error)
+
+	// SecretValue returns the value of the secret with the given ID.
+	SecretValue(id string) (map[string]string, error)
+
+	// GrantSecret grants the application at the other end of the
+	// relation with the given id access to the secret with the
+	// given ID.
+	GrantSecret(id string, relationId int) error
+
+	// RevokeSecret revokes access to the secret with the given ID
+	// from the application at the other end of the relation with
+	// the given id.
+	RevokeSecret(id string, relationId int) error
+}
+
+// ContextUnitState is the part of a hook context related to the charm
+// state stored for the unit by the controller.
+type ContextUnitState interface {
+	// UnitState returns the charm state stored for the unit, including
+	// any changes made in the current context.
+	UnitState() (map[string]string, error)
+
+	// SetUnitStateValue sets the value of a key in the unit's charm
+	// state. The change is written when the hook completes successfully.
+	SetUnitStateValue(key, value string) error
+
+	// DeleteUnitStateValue removes a key from the unit's charm state.
+	// The change is written when the hook completes successfully.
+	DeleteUnitStateValue(key string) error
+}
+
+// ContextVersion is the part of a hook context related to the version
+// of the unit's workload.
+type ContextVersion interface {
+	// SetUnitWorkloadVersion sets the version of the workload that
+	// the unit is running.
+	SetUnitWorkloadVersion(version string) error
+}
+
 // ContextComponent is a single modular Juju component as it relates to
 // the current unit and hook. Components should implement this interfaces
 // in a type-safe way. Ensuring checked type-conversions are preformed on
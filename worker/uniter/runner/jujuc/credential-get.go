@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+)
+
+const credentialGetDoc = `
+credential-get prints the cloud the model is deployed to: its provider
+type, name, region and endpoints, along with the credential used to
+access it, including any secret attributes.
+
+This is only permitted once the model owner has granted the charm's
+application access with "juju trust <application>". Each use is recorded
+in the controller's audit log.
+`
+
+// credentialGetCommand implements the credential-get command.
+type credentialGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewCredentialGetCommand returns a new credentialGetCommand with the
+// given context.
+func NewCredentialGetCommand(ctx Context) (cmd.Command, error) {
+	return &credentialGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *credentialGetCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "credential-get",
+		Purpose: "print the model's cloud and the credential used to access it",
+		Doc:     credentialGetDoc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *credentialGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *credentialGetCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+type formattedCredential struct {
+	AuthType   string            `json:"auth-type" yaml:"auth-type"`
+	Attributes map[string]string `json:"attrs,omitempty" yaml:"attrs,omitempty"`
+}
+
+type formattedCloudSpec struct {
+	Type            string               `json:"type" yaml:"type"`
+	Name            string               `json:"name" yaml:"name"`
+	Region          string               `json:"region,omitempty" yaml:"region,omitempty"`
+	Endpoint        string               `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	StorageEndpoint string               `json:"storage-endpoint,omitempty" yaml:"storage-endpoint,omitempty"`
+	Credential      *formattedCredential `json:"credential,omitempty" yaml:"credential,omitempty"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *credentialGetCommand) Run(ctx *cmd.Context) error {
+	spec, err := c.ctx.CloudSpec()
+	if err != nil {
+		return errors.Annotatef(err, "cannot read cloud credential")
+	}
+	result := formattedCloudSpec{
+		Type:            spec.Type,
+		Name:            spec.Name,
+		Region:          spec.Region,
+		Endpoint:        spec.Endpoint,
+		StorageEndpoint: spec.StorageEndpoint,
+	}
+	if spec.Credential != nil {
+		result.Credential = &formattedCredential{
+			AuthType:   spec.Credential.AuthType,
+			Attributes: spec.Credential.Attributes,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
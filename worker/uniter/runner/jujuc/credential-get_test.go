@@ -0,0 +1,92 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+)
+
+type CredentialGetSuite struct {
+	jujuctesting.ContextSuite
+}
+
+var _ = gc.Suite(&CredentialGetSuite{})
+
+func (s *CredentialGetSuite) newHookContext(c *gc.C) jujuc.Context {
+	hctx, info := s.NewHookContext()
+	info.Unit.CloudSpec = params.CloudSpec{
+		Type:     "openstack",
+		Name:     "canonistack",
+		Region:   "lcy02",
+		Endpoint: "https://keystone.example.com/v2.0/",
+		Credential: &params.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "fred",
+				"password": "secret",
+			},
+		},
+	}
+	return hctx
+}
+
+func (s *CredentialGetSuite) TestInitError(c *gc.C) {
+	com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = testing.InitCommand(com, []string{"foo"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *CredentialGetSuite) TestCredentialGet(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		out  string
+	}{{
+		args: nil,
+		out: `
+type: openstack
+name: canonistack
+region: lcy02
+endpoint: https://keystone.example.com/v2.0/
+credential:
+  auth-type: userpass
+  attrs:
+    password: secret
+    username: fred
+`[1:],
+	}, {
+		args: []string{"--format", "json"},
+		out: `{"type":"openstack","name":"canonistack","region":"lcy02","endpoint":"https://keystone.example.com/v2.0/",` +
+			`"credential":{"auth-type":"userpass","attrs":{"password":"secret","username":"fred"}}}` + "\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("credential-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *CredentialGetSuite) TestCredentialGetNotTrusted(c *gc.C) {
+	hctx := s.newHookContext(c)
+	s.Stub.SetErrors(errors.Unauthorizedf(`application "mysql" is not trusted with the cloud credential`))
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals,
+		`error: cannot read cloud credential: application "mysql" is not trusted with the cloud credential`+"\n")
+}
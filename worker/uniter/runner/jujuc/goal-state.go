@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const goalStateDoc = `
+goal-state prints the units the executing unit should expect to see, both
+in its own application and on each of its relations (keyed by endpoint
+name). This includes units that are still being assigned or provisioned,
+so a charm can wait for all of its peers before forming a cluster.
+
+Each unit has a status of "waiting", "active" or "dying", and the time it
+last changed.
+`
+
+// goalStateCommand implements the goal-state command.
+type goalStateCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewGoalStateCommand returns a new goalStateCommand with the given context.
+func NewGoalStateCommand(ctx Context) (cmd.Command, error) {
+	return &goalStateCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *goalStateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "goal-state",
+		Purpose: "print the status of the charm's peers and related units",
+		Doc:     goalStateDoc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *goalStateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *goalStateCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+type formattedGoalStateStatus struct {
+	Status string `json:"status" yaml:"status"`
+	Since  string `json:"since,omitempty" yaml:"since,omitempty"`
+}
+
+type formattedUnitsGoalState map[string]formattedGoalStateStatus
+
+type formattedGoalState struct {
+	Units     formattedUnitsGoalState            `json:"units" yaml:"units"`
+	Relations map[string]formattedUnitsGoalState `json:"relations" yaml:"relations"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *goalStateCommand) Run(ctx *cmd.Context) error {
+	goalState, err := c.ctx.GoalState()
+	if err != nil {
+		return errors.Annotatef(err, "cannot read goal state")
+	}
+	result := formattedGoalState{
+		Units:     formatUnitsGoalState(goalState.Units),
+		Relations: make(map[string]formattedUnitsGoalState),
+	}
+	for endpoint, units := range goalState.Relations {
+		result.Relations[endpoint] = formatUnitsGoalState(units)
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatUnitsGoalState(units params.UnitsGoalState) formattedUnitsGoalState {
+	result := make(formattedUnitsGoalState)
+	for name, unitStatus := range units {
+		formatted := formattedGoalStateStatus{Status: unitStatus.Status}
+		if unitStatus.Since != nil {
+			formatted.Since = unitStatus.Since.UTC().Format(time.RFC3339)
+		}
+		result[name] = formatted
+	}
+	return result
+}
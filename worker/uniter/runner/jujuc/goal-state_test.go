@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+)
+
+type GoalStateSuite struct {
+	jujuctesting.ContextSuite
+}
+
+var _ = gc.Suite(&GoalStateSuite{})
+
+func (s *GoalStateSuite) newHookContext(c *gc.C) jujuc.Context {
+	hctx, info := s.NewHookContext()
+	since := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	info.Unit.GoalState = params.GoalState{
+		Units: params.UnitsGoalState{
+			"mysql/0": {Status: "active", Since: &since},
+			"mysql/1": {Status: "waiting"},
+		},
+		Relations: map[string]params.UnitsGoalState{
+			"server": {
+				"wordpress/0": {Status: "dying", Since: &since},
+			},
+		},
+	}
+	return hctx
+}
+
+func (s *GoalStateSuite) TestInitError(c *gc.C) {
+	com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = testing.InitCommand(com, []string{"foo"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *GoalStateSuite) TestGoalState(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		out  string
+	}{{
+		args: nil,
+		out: `
+units:
+  mysql/0:
+    status: active
+    since: "2016-07-01T10:00:00Z"
+  mysql/1:
+    status: waiting
+relations:
+  server:
+    wordpress/0:
+      status: dying
+      since: "2016-07-01T10:00:00Z"
+`[1:],
+	}, {
+		args: []string{"--format", "json"},
+		out: `{"units":{"mysql/0":{"status":"active","since":"2016-07-01T10:00:00Z"},"mysql/1":{"status":"waiting"}},` +
+			`"relations":{"server":{"wordpress/0":{"status":"dying","since":"2016-07-01T10:00:00Z"}}}}` + "\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("goal-state"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *GoalStateSuite) TestGoalStateError(c *gc.C) {
+	hctx := s.newHookContext(c)
+	s.Stub.SetErrors(errors.New("splat"))
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot read goal state: splat\n")
+}
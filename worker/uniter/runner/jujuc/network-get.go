@@ -5,10 +5,13 @@ package jujuc
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
 )
 
 // NetworkGetCommand implements the network-get command.
@@ -18,6 +21,9 @@ type NetworkGetCommand struct {
 
 	bindingName    string
 	primaryAddress bool
+	bindAddress    bool
+	ingressAddress bool
+	egressSubnets  bool
 
 	out cmd.Output
 }
@@ -29,11 +35,20 @@ func NewNetworkGetCommand(ctx Context) (cmd.Command, error) {
 
 // Info is part of the cmd.Command interface.
 func (c *NetworkGetCommand) Info() *cmd.Info {
-	args := "<binding-name> --primary-address"
+	args := "<binding-name> [--primary-address] [--bind-address] [--ingress-address] [--egress-subnets]"
 	doc := `
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. By default
+it returns the list of interfaces and associated addresses in the space for
+the binding, as well as the ingress addresses remote units should use to
+connect to this unit, and the egress subnets its traffic originates from.
+
+If one or more of the flags below are given, only the matching values are
+returned:
+--primary-address returns the IP address the local unit should advertise
+as its endpoint to its peers;
+--bind-address returns the address the local unit should listen on;
+--ingress-address returns the address remote units should connect to;
+--egress-subnets returns the subnets outbound traffic originates from.
 `
 	return &cmd.Info{
 		Name:    "network-get",
@@ -47,6 +62,9 @@ the IP address the local unit should advertise as its endpoint to its peers.
 func (c *NetworkGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.BoolVar(&c.primaryAddress, "primary-address", false, "get the primary address for the binding")
+	f.BoolVar(&c.bindAddress, "bind-address", false, "get the address for the binding on which the unit should listen")
+	f.BoolVar(&c.ingressAddress, "ingress-address", false, "get the ingress address for the binding")
+	f.BoolVar(&c.egressSubnets, "egress-subnets", false, "get the egress subnets for the binding")
 }
 
 // Init is part of the cmd.Command interface.
@@ -60,13 +78,33 @@ func (c *NetworkGetCommand) Init(args []string) error {
 		return fmt.Errorf("no binding name specified")
 	}
 
-	if !c.primaryAddress {
-		return fmt.Errorf("--primary-address is currently required")
-	}
-
 	return cmd.CheckEmpty(args[1:])
 }
 
+// interfaceAddress describes a single address assigned to a network
+// interface.
+type interfaceAddress struct {
+	Address string `json:"address" yaml:"address"`
+	CIDR    string `json:"cidr,omitempty" yaml:"cidr,omitempty"`
+}
+
+// interfaceInfo describes a network interface with addresses in the
+// space a binding is bound to.
+type interfaceInfo struct {
+	MACAddress    string             `json:"macaddress,omitempty" yaml:"macaddress,omitempty"`
+	InterfaceName string             `json:"interfacename,omitempty" yaml:"interfacename,omitempty"`
+	InterfaceType string             `json:"interfacetype,omitempty" yaml:"interfacetype,omitempty"`
+	MTU           int                `json:"mtu,omitempty" yaml:"mtu,omitempty"`
+	Addresses     []interfaceAddress `json:"addresses" yaml:"addresses"`
+}
+
+// networkInfo is the full network-get output for a binding.
+type networkInfo struct {
+	BindAddresses    []interfaceInfo `json:"bind-addresses" yaml:"bind-addresses"`
+	IngressAddresses []string        `json:"ingress-addresses" yaml:"ingress-addresses"`
+	EgressSubnets    []string        `json:"egress-subnets" yaml:"egress-subnets"`
+}
+
 func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 	netConfig, err := c.ctx.NetworkConfig(c.bindingName)
 	if err != nil {
@@ -75,10 +113,82 @@ func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 	if len(netConfig) < 1 {
 		return fmt.Errorf("no network config found for binding %q", c.bindingName)
 	}
+	info := newNetworkInfo(netConfig)
 
+	values := make(map[string]interface{})
 	if c.primaryAddress {
-		return c.out.Write(ctx, netConfig[0].Address)
+		values["primary-address"] = netConfig[0].Address
+	}
+	if c.bindAddress {
+		values["bind-address"] = info.BindAddresses[0].Addresses[0].Address
 	}
+	if c.ingressAddress {
+		values["ingress-address"] = info.IngressAddresses[0]
+	}
+	if c.egressSubnets {
+		values["egress-subnets"] = info.EgressSubnets
+	}
+
+	switch len(values) {
+	case 0:
+		return c.out.Write(ctx, info)
+	case 1:
+		for _, value := range values {
+			return c.out.Write(ctx, value)
+		}
+	}
+	return c.out.Write(ctx, values)
+}
 
-	return nil // never reached as --primary-address is required.
+// newNetworkInfo groups the given network config by interface, and derives
+// the ingress addresses and egress subnets from it.
+func newNetworkInfo(netConfig []params.NetworkConfig) networkInfo {
+	var info networkInfo
+	interfaceIndex := make(map[string]int)
+	seenAddresses := make(map[string]bool)
+	for _, config := range netConfig {
+		key := config.InterfaceName + "/" + config.MACAddress
+		index, ok := interfaceIndex[key]
+		if !ok {
+			index = len(info.BindAddresses)
+			interfaceIndex[key] = index
+			info.BindAddresses = append(info.BindAddresses, interfaceInfo{
+				MACAddress:    config.MACAddress,
+				InterfaceName: config.InterfaceName,
+				InterfaceType: config.InterfaceType,
+				MTU:           config.MTU,
+			})
+		}
+		iface := &info.BindAddresses[index]
+		iface.Addresses = append(iface.Addresses, interfaceAddress{
+			Address: config.Address,
+			CIDR:    config.CIDR,
+		})
+
+		if seenAddresses[config.Address] {
+			continue
+		}
+		seenAddresses[config.Address] = true
+		info.IngressAddresses = append(info.IngressAddresses, config.Address)
+		if subnet := hostSubnet(config.Address); subnet != "" {
+			info.EgressSubnets = append(info.EgressSubnets, subnet)
+		}
+	}
+	return info
+}
+
+// hostSubnet returns the single-host subnet (/32 for IPv4, /128 for IPv6)
+// containing the given address, or "" when it is not an IP address.
+func hostSubnet(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		var err error
+		if ip, _, err = net.ParseCIDR(address); err != nil {
+			return ""
+		}
+	}
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
 }
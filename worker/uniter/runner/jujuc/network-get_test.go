@@ -25,10 +25,26 @@ func (s *NetworkGetSuite) createCommand(c *gc.C) cmd.Command {
 	hctx := s.GetHookContext(c, -1, "")
 
 	presetBindings := make(map[string][]params.NetworkConfig)
-	presetBindings["known-relation"] = []params.NetworkConfig{
-		{Address: "10.10.0.23"},
-		{Address: "192.168.1.111"},
-	}
+	presetBindings["known-relation"] = []params.NetworkConfig{{
+		MACAddress:    "aa:bb:cc:dd:ee:f0",
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		CIDR:          "10.10.0.0/24",
+		Address:       "10.10.0.23",
+	}, {
+		MACAddress:    "aa:bb:cc:dd:ee:f0",
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		CIDR:          "10.10.0.0/24",
+		Address:       "10.10.0.24",
+	}, {
+		MACAddress:    "aa:bb:cc:dd:ee:f1",
+		InterfaceName: "eth1",
+		InterfaceType: "ethernet",
+		MTU:           9000,
+		CIDR:          "192.168.1.0/24",
+		Address:       "192.168.1.111",
+	}}
 	presetBindings["known-extra"] = []params.NetworkConfig{
 		{Address: "10.20.1.42"},
 		{Address: "fc00::1/64"},
@@ -62,10 +78,10 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 		args:    []string{""},
 		out:     `no binding name specified`,
 	}, {
-		summary: "binding name given, no --primary-address given",
-		code:    2,
-		args:    []string{"foo"},
-		out:     `--primary-address is currently required`,
+		summary: "unknown binding given, no flags",
+		args:    []string{"unknown"},
+		code:    1,
+		out:     "insert server error for unknown binding here",
 	}, {
 		summary: "unknown binding given, with --primary-address",
 		args:    []string{"unknown", "--primary-address"},
@@ -88,6 +104,54 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 		summary: "implicitly bound binding name given with --primary-address",
 		args:    []string{"known-unbound", "--primary-address"},
 		out:     "10.33.1.8", // preferred private address used for unspecified bindings.
+	}, {
+		summary: "explicitly bound relation name given without flags",
+		args:    []string{"known-relation"},
+		out: `
+bind-addresses:
+- macaddress: aa:bb:cc:dd:ee:f0
+  interfacename: eth0
+  interfacetype: ethernet
+  addresses:
+  - address: 10.10.0.23
+    cidr: 10.10.0.0/24
+  - address: 10.10.0.24
+    cidr: 10.10.0.0/24
+- macaddress: aa:bb:cc:dd:ee:f1
+  interfacename: eth1
+  interfacetype: ethernet
+  mtu: 9000
+  addresses:
+  - address: 192.168.1.111
+    cidr: 192.168.1.0/24
+ingress-addresses:
+- 10.10.0.23
+- 10.10.0.24
+- 192.168.1.111
+egress-subnets:
+- 10.10.0.23/32
+- 10.10.0.24/32
+- 192.168.1.111/32`[1:],
+	}, {
+		summary: "explicitly bound relation name given with --bind-address",
+		args:    []string{"known-relation", "--bind-address"},
+		out:     "10.10.0.23",
+	}, {
+		summary: "explicitly bound extra-binding name given with --ingress-address",
+		args:    []string{"known-extra", "--ingress-address"},
+		out:     "10.20.1.42",
+	}, {
+		summary: "explicitly bound extra-binding name given with --egress-subnets",
+		args:    []string{"known-extra", "--egress-subnets"},
+		out:     "10.20.1.42/32\nfc00::1/128",
+	}, {
+		summary: "explicitly bound extra-binding name given with --ingress-address and --egress-subnets",
+		args:    []string{"known-extra", "--ingress-address", "--egress-subnets", "--format", "yaml"},
+		out: `
+egress-subnets:
+- 10.20.1.42/32
+- fc00::1/128
+ingress-address: 10.20.1.42`[1:],
 	}} {
 		c.Logf("test %d: %s", i, t.summary)
 		com := s.createCommand(c)
@@ -112,23 +176,38 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 func (s *NetworkGetSuite) TestHelp(c *gc.C) {
 
 	var helpTemplate = `
-Usage: network-get [options] <binding-name> --primary-address
+Usage: network-get [options] <binding-name> [--primary-address] [--bind-address] [--ingress-address] [--egress-subnets]
 
 Summary:
 get network config
 
 Options:
+--bind-address  (= false)
+    get the address for the binding on which the unit should listen
+--egress-subnets  (= false)
+    get the egress subnets for the binding
 --format  (= smart)
     Specify output format (json|smart|yaml)
+--ingress-address  (= false)
+    get the ingress address for the binding
 -o, --output (= "")
     Specify an output file
 --primary-address  (= false)
     get the primary address for the binding
 
 Details:
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. By default
+it returns the list of interfaces and associated addresses in the space for
+the binding, as well as the ingress addresses remote units should use to
+connect to this unit, and the egress subnets its traffic originates from.
+
+If one or more of the flags below are given, only the matching values are
+returned:
+--primary-address returns the IP address the local unit should advertise
+as its endpoint to its peers;
+--bind-address returns the address the local unit should listen on;
+--ingress-address returns the address remote units should connect to;
+--egress-subnets returns the subnets outbound traffic originates from.
 `[1:]
 
 	com := s.createCommand(c)
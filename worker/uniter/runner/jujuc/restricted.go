@@ -25,6 +25,12 @@ type RestrictedContext struct{}
 // ConfigSettings implements jujuc.Context.
 func (*RestrictedContext) ConfigSettings() (charm.Settings, error) { return nil, ErrRestrictedContext }
 
+// GoalState implements jujuc.Context.
+func (*RestrictedContext) GoalState() (*params.GoalState, error) { return nil, ErrRestrictedContext }
+
+// CloudSpec implements jujuc.Context.
+func (*RestrictedContext) CloudSpec() (*params.CloudSpec, error) { return nil, ErrRestrictedContext }
+
 // UnitStatus implements jujuc.Context.
 func (*RestrictedContext) UnitStatus() (*StatusInfo, error) { return nil, ErrRestrictedContext }
 
@@ -117,6 +123,33 @@ func (*RestrictedContext) HookRelation() (ContextRelation, error) {
 // RemoteUnitName implements jujuc.Context.
 func (*RestrictedContext) RemoteUnitName() (string, error) { return "", ErrRestrictedContext }
 
+// AddSecret implements jujuc.Context.
+func (*RestrictedContext) AddSecret(SecretOwner, string, map[string]string) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// SecretValue implements jujuc.Context.
+func (*RestrictedContext) SecretValue(string) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GrantSecret implements jujuc.Context.
+func (*RestrictedContext) GrantSecret(string, int) error { return ErrRestrictedContext }
+
+// RevokeSecret implements jujuc.Context.
+func (*RestrictedContext) RevokeSecret(string, int) error { return ErrRestrictedContext }
+
+// UnitState implements jujuc.Context.
+func (*RestrictedContext) UnitState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// SetUnitStateValue implements jujuc.Context.
+func (*RestrictedContext) SetUnitStateValue(string, string) error { return ErrRestrictedContext }
+
+// DeleteUnitStateValue implements jujuc.Context.
+func (*RestrictedContext) DeleteUnitStateValue(string) error { return ErrRestrictedContext }
+
 // ActionParams implements jujuc.Context.
 func (*RestrictedContext) ActionParams() (map[string]interface{}, error) {
 	return nil, ErrRestrictedContext
@@ -137,3 +170,6 @@ func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext
 }
+
+// SetUnitWorkloadVersion implements jujuc.Context.
+func (*RestrictedContext) SetUnitWorkloadVersion(string) error { return ErrRestrictedContext }
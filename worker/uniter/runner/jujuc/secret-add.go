@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+	"launchpad.net/gnuflag"
+)
+
+const secretAddDoc = `
+"secret-add" stores the supplied key/value pairs as a secret, and prints
+the secret's id. The value is encrypted by the controller, and can only
+be read by the secret's owner and by applications it has been granted to
+using secret-grant.
+
+A secret is owned by the executing unit unless --owner application is
+given, in which case only the application's leader may manage it.
+
+If --label is given and the owner already has a secret with that label,
+the secret's value is replaced, and applications it has been granted to
+run their secret-changed hook.
+`
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	cmd.CommandBase
+	ctx   Context
+	owner string
+	label string
+	data  map[string]string
+}
+
+// NewSecretAddCommand returns a new secretAddCommand with the given context.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretAddCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secret-add",
+		Args:    "<key>=<value> [...]",
+		Purpose: "add or rotate a secret",
+		Doc:     secretAddDoc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.owner, "owner", string(SecretOwnerUnit), "the owner of the secret, either unit or application")
+	f.StringVar(&c.label, "label", "", "a label for the secret, unique to its owner")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretAddCommand) Init(args []string) (err error) {
+	switch SecretOwner(c.owner) {
+	case SecretOwnerUnit, SecretOwnerApplication:
+	default:
+		return errors.Errorf(`invalid owner %q; expected "unit" or "application"`, c.owner)
+	}
+	if len(args) == 0 {
+		return errors.New("no secret value specified")
+	}
+	c.data, err = keyvalues.Parse(args, false)
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	id, err := c.ctx.AddSecret(SecretOwner(c.owner), c.label, c.data)
+	if err != nil {
+		return errors.Annotate(err, "cannot add secret")
+	}
+	fmt.Fprintln(ctx.Stdout, id)
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+)
+
+type SecretAddSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret value specified",
+	}, {
+		args: []string{"nonsense"},
+		err:  `expected "key=value", got "nonsense"`,
+	}, {
+		args: []string{"--owner", "machine", "foo=bar"},
+		err:  `invalid owner "machine"; expected "unit" or "application"`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx, _ := s.newHookContext(-1, "")
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "error: "+t.err+"\n")
+	}
+}
+
+func (s *SecretAddSuite) TestAdd(c *gc.C) {
+	hctx, info := s.newHookContext(-1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--label", "db", "password=sekrit", "user=admin"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "secret-0\n")
+	c.Check(info.Secrets.Secrets, jc.DeepEquals, map[string]*jujuctesting.Secret{
+		"secret-0": {
+			Owner: jujuc.SecretOwnerUnit,
+			Label: "db",
+			Data:  map[string]string{"password": "sekrit", "user": "admin"},
+		},
+	})
+}
+
+func (s *SecretAddSuite) TestAddApplicationOwned(c *gc.C) {
+	hctx, info := s.newHookContext(-1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--owner", "application", "password=sekrit"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "secret-0\n")
+	c.Check(info.Secrets.Secrets["secret-0"].Owner, gc.Equals, jujuc.SecretOwnerApplication)
+}
+
+func (s *SecretAddSuite) TestAddError(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	s.Stub.SetErrors(errors.New("not the leader"))
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--owner", "application", "password=sekrit"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot add secret: not the leader\n")
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+)
+
+const secretGetDoc = `
+"secret-get" prints the value of a secret owned by the executing unit or
+its application, or granted to its application. If a key is given, only
+the value for that key is printed.
+
+In the secret-changed hook, the id of the changed secret is available in
+the JUJU_SECRET_ID environment variable.
+`
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	id  string
+	key string
+	out cmd.Output
+}
+
+// NewSecretGetCommand returns a new secretGetCommand with the given context.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGetCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<id> [<key>]",
+		Purpose: "print the value of a secret",
+		Doc:     secretGetDoc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret id specified")
+	}
+	c.id, args = args[0], args[1:]
+	if len(args) > 0 {
+		c.key, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	value, err := c.ctx.SecretValue(c.id)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read secret %q", c.id)
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, value)
+	}
+	if v, ok := value[c.key]; ok {
+		return c.out.Write(ctx, v)
+	}
+	return c.out.Write(ctx, nil)
+}
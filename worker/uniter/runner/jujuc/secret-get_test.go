@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+)
+
+type SecretGetSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) newHookContext(c *gc.C) jujuc.Context {
+	hctx, info := s.relationSuite.newHookContext(-1, "")
+	info.Secrets.SetSecret("secret-0", &jujuctesting.Secret{
+		Owner: jujuc.SecretOwnerUnit,
+		Data:  map[string]string{"password": "sekrit", "user": "admin"},
+	})
+	return hctx
+}
+
+func (s *SecretGetSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret id specified",
+	}, {
+		args: []string{"secret-0", "password", "user"},
+		err:  `unrecognized args: \["user"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("secret-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretGetSuite) TestGet(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		out  string
+	}{{
+		args: []string{"secret-0"},
+		out:  "password: sekrit\nuser: admin\n",
+	}, {
+		args: []string{"secret-0", "password"},
+		out:  "sekrit\n",
+	}, {
+		args: []string{"secret-0", "missing"},
+		out:  "",
+	}, {
+		args: []string{"--format", "json", "secret-0"},
+		out:  `{"password":"sekrit","user":"admin"}` + "\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("secret-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *SecretGetSuite) TestGetNotFound(c *gc.C) {
+	com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-1"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `error: cannot read secret "secret-1": secret "secret-1" not found`+"\n")
+}
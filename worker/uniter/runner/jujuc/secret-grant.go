@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+)
+
+const secretGrantDoc = `
+"secret-grant" allows the application at the other end of a relation to
+read a secret owned by the executing unit or its application. If no
+relation is specified then the current relation is used. Units of the
+grantee application run their secret-changed hook whenever the secret's
+value is rotated.
+`
+
+const secretRevokeDoc = `
+"secret-revoke" withdraws access to a secret previously granted with
+secret-grant to the application at the other end of a relation. If no
+relation is specified then the current relation is used.
+`
+
+// secretGrantCommand implements the secret-grant and secret-revoke commands.
+type secretGrantCommand struct {
+	cmd.CommandBase
+	ctx             Context
+	info            *cmd.Info
+	action          func(ctx Context, id string, relationId int) error
+	id              string
+	relationId      int
+	relationIdProxy gnuflag.Value
+}
+
+// NewSecretGrantCommand returns a new secret-grant command with the
+// given context.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	return newSecretGrantCommand(ctx, &cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<id>",
+		Purpose: "grant access to a secret over a relation",
+		Doc:     secretGrantDoc,
+	}, Context.GrantSecret)
+}
+
+// NewSecretRevokeCommand returns a new secret-revoke command with the
+// given context.
+func NewSecretRevokeCommand(ctx Context) (cmd.Command, error) {
+	return newSecretGrantCommand(ctx, &cmd.Info{
+		Name:    "secret-revoke",
+		Args:    "<id>",
+		Purpose: "revoke access to a secret over a relation",
+		Doc:     secretRevokeDoc,
+	}, Context.RevokeSecret)
+}
+
+func newSecretGrantCommand(
+	ctx Context,
+	info *cmd.Info,
+	action func(ctx Context, id string, relationId int) error,
+) (cmd.Command, error) {
+	c := &secretGrantCommand{ctx: ctx, info: info, action: action}
+	rV, err := newRelationIdValue(ctx, &c.relationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.relationIdProxy = rV
+	return c, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	return c.info
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGrantCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(c.relationIdProxy, "r", "specify a relation by id")
+	f.Var(c.relationIdProxy, "relation", "")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGrantCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret id specified")
+	}
+	c.id = args[0]
+	if c.relationId == -1 {
+		return errors.New("no relation id specified")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGrantCommand) Run(_ *cmd.Context) error {
+	return errors.Trace(c.action(c.ctx, c.id, c.relationId))
+}
@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+)
+
+type SecretGrantSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) newHookContext(relid int, grants ...int) (jujuc.Context, *jujuctesting.Secret) {
+	hctx, info := s.relationSuite.newHookContext(relid, "")
+	secret := &jujuctesting.Secret{
+		Owner:  jujuc.SecretOwnerUnit,
+		Data:   map[string]string{"password": "sekrit"},
+		Grants: grants,
+	}
+	info.Secrets.SetSecret("secret-0", secret)
+	return hctx, secret
+}
+
+func (s *SecretGrantSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		relid int
+		args  []string
+		err   string
+	}{{
+		relid: 1,
+		args:  nil,
+		err:   "no secret id specified",
+	}, {
+		relid: -1,
+		args:  []string{"secret-0"},
+		err:   "no relation id specified",
+	}, {
+		relid: -1,
+		args:  []string{"-r", "peer0:x", "secret-0"},
+		err:   `invalid value "peer0:x" for flag -r: invalid relation id`,
+	}, {
+		relid: 1,
+		args:  []string{"secret-0", "extra"},
+		err:   `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		for _, name := range []string{"secret-grant", "secret-revoke"} {
+			hctx, _ := s.newHookContext(t.relid)
+			com, err := jujuc.NewCommand(hctx, cmdString(name))
+			c.Assert(err, jc.ErrorIsNil)
+			err = testing.InitCommand(com, t.args)
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *SecretGrantSuite) TestGrant(c *gc.C) {
+	hctx, secret := s.newHookContext(1)
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-0"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(secret.Grants, jc.DeepEquals, []int{1})
+}
+
+func (s *SecretGrantSuite) TestGrantOtherRelation(c *gc.C) {
+	hctx, secret := s.newHookContext(-1)
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"-r", "peer0:0", "secret-0"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(secret.Grants, jc.DeepEquals, []int{0})
+}
+
+func (s *SecretGrantSuite) TestRevoke(c *gc.C) {
+	hctx, secret := s.newHookContext(1, 0, 1)
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-revoke"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-0"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(secret.Grants, jc.DeepEquals, []int{0})
+}
+
+func (s *SecretGrantSuite) TestGrantNotFound(c *gc.C) {
+	hctx, _ := s.newHookContext(1)
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-1"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `error: secret "secret-1" not found`+"\n")
+}
@@ -61,6 +61,13 @@ var baseCommands = map[string]creator{
 	"status-get" + cmdSuffix:    NewStatusGetCommand,
 	"status-set" + cmdSuffix:    NewStatusSetCommand,
 	"network-get" + cmdSuffix:   NewNetworkGetCommand,
+	"state-get" + cmdSuffix:     NewStateGetCommand,
+	"state-set" + cmdSuffix:     NewStateSetCommand,
+	"state-delete" + cmdSuffix:  NewStateDeleteCommand,
+	"goal-state" + cmdSuffix:    NewGoalStateCommand,
+
+	"application-version-set" + cmdSuffix: NewApplicationVersionSetCommand,
+	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
 }
 
 var storageCommands = map[string]creator{
@@ -69,6 +76,13 @@ var storageCommands = map[string]creator{
 	"storage-list" + cmdSuffix: NewStorageListCommand,
 }
 
+var secretCommands = map[string]creator{
+	"secret-add" + cmdSuffix:    NewSecretAddCommand,
+	"secret-get" + cmdSuffix:    NewSecretGetCommand,
+	"secret-grant" + cmdSuffix:  NewSecretGrantCommand,
+	"secret-revoke" + cmdSuffix: NewSecretRevokeCommand,
+}
+
 var leaderCommands = map[string]creator{
 	"is-leader" + cmdSuffix:  NewIsLeaderCommand,
 	"leader-get" + cmdSuffix: NewLeaderGetCommand,
@@ -85,6 +99,7 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(secretCommands)
 	add(registeredCommands)
 	return all
 }
@@ -265,6 +265,16 @@ var newCommandTests = []struct {
 	{"storage-get", ""},
 	{"status-get", ""},
 	{"status-set", ""},
+	{"secret-add", ""},
+	{"secret-get", ""},
+	{"secret-grant", ""},
+	{"secret-revoke", ""},
+	{"state-get", ""},
+	{"state-set", ""},
+	{"state-delete", ""},
+	{"goal-state", ""},
+	{"credential-get", ""},
+	{"application-version-set", ""},
 	// The error message contains .exe on Windows
 	{"random", "unknown command: random(.exe)?"},
 }
@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+const stateDeleteDoc = `
+state-delete removes the given keys from the unit's charm state. The changes
+are written to the controller when the hook completes successfully, and are
+discarded if it fails.
+`
+
+// stateDeleteCommand implements the state-delete command.
+type stateDeleteCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	keys []string
+}
+
+// NewStateDeleteCommand returns a new stateDeleteCommand with the given context.
+func NewStateDeleteCommand(ctx Context) (cmd.Command, error) {
+	return &stateDeleteCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "state-delete",
+		Args:    "<key> [...]",
+		Purpose: "delete unit charm state",
+		Doc:     stateDeleteDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no keys specified")
+	}
+	c.keys = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Run(_ *cmd.Context) error {
+	for _, key := range c.keys {
+		if err := c.ctx.DeleteUnitStateValue(key); err != nil {
+			return errors.Annotatef(err, "cannot delete unit state")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+)
+
+type StateDeleteSuite struct {
+	jujuctesting.ContextSuite
+}
+
+var _ = gc.Suite(&StateDeleteSuite{})
+
+func (s *StateDeleteSuite) TestInitErrors(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = testing.InitCommand(com, nil)
+	c.Check(err, gc.ErrorMatches, "no keys specified")
+}
+
+func (s *StateDeleteSuite) TestDelete(c *gc.C) {
+	hctx, info := s.NewHookContext()
+	info.SetUnitStateValue("initialised", "true")
+	info.SetUnitStateValue("cursor", "42")
+	info.SetUnitStateValue("keep", "me")
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"initialised", "cursor", "missing"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(info.State, jc.DeepEquals, map[string]string{"keep": "me"})
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+)
+
+type StateGetSuite struct {
+	jujuctesting.ContextSuite
+}
+
+var _ = gc.Suite(&StateGetSuite{})
+
+func (s *StateGetSuite) newHookContext(c *gc.C) jujuc.Context {
+	hctx, info := s.NewHookContext()
+	info.SetUnitStateValue("initialised", "true")
+	info.SetUnitStateValue("cursor", "42")
+	return hctx
+}
+
+func (s *StateGetSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"foo=bar"},
+		err:  `invalid key "foo=bar"`,
+	}, {
+		args: []string{"foo", "bar"},
+		err:  `unrecognized args: \["bar"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("state-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *StateGetSuite) TestGet(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		out  string
+	}{{
+		args: nil,
+		out:  "cursor: \"42\"\ninitialised: \"true\"\n",
+	}, {
+		args: []string{"-"},
+		out:  "cursor: \"42\"\ninitialised: \"true\"\n",
+	}, {
+		args: []string{"cursor"},
+		out:  "42\n",
+	}, {
+		args: []string{"missing"},
+		out:  "",
+	}, {
+		args: []string{"--format", "json"},
+		out:  `{"cursor":"42","initialised":"true"}` + "\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("state-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *StateGetSuite) TestGetError(c *gc.C) {
+	hctx := s.newHookContext(c)
+	s.Stub.SetErrors(errors.New("splat"))
+	com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot read unit state: splat\n")
+}
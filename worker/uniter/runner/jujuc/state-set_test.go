@@ -0,0 +1,68 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+)
+
+type StateSetSuite struct {
+	jujuctesting.ContextSuite
+}
+
+var _ = gc.Suite(&StateSetSuite{})
+
+func (s *StateSetSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no key/value pairs specified",
+	}, {
+		args: []string{"nonsense"},
+		err:  `expected "key=value", got "nonsense"`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx, _ := s.NewHookContext()
+		com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *StateSetSuite) TestSet(c *gc.C) {
+	hctx, info := s.NewHookContext()
+	info.SetUnitStateValue("stale", "yes")
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"initialised=true", "cursor=42", "stale="})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(info.State, jc.DeepEquals, map[string]string{
+		"initialised": "true",
+		"cursor":      "42",
+	})
+	s.Stub.CheckCallNames(c, "SetUnitStateValue", "SetUnitStateValue", "DeleteUnitStateValue")
+}
+
+func (s *StateSetSuite) TestSetError(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	s.Stub.SetErrors(errors.New("splat"))
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"initialised=true"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot set unit state: splat\n")
+}
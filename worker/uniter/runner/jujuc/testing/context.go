@@ -20,6 +20,9 @@ type ContextInfo struct {
 	Storage
 	Components
 	Relations
+	Secrets
+	UnitState
+	Version
 	RelationHook
 	ActionHook
 }
@@ -59,6 +62,9 @@ type Context struct {
 	ContextStorage
 	ContextComponents
 	ContextRelations
+	ContextSecrets
+	ContextUnitState
+	ContextVersion
 	ContextRelationHook
 	ContextActionHook
 }
@@ -84,6 +90,12 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextComponents.info = &info.Components
 	ctx.ContextRelations.stub = stub
 	ctx.ContextRelations.info = &info.Relations
+	ctx.ContextSecrets.stub = stub
+	ctx.ContextSecrets.info = &info.Secrets
+	ctx.ContextUnitState.stub = stub
+	ctx.ContextUnitState.info = &info.UnitState
+	ctx.ContextVersion.stub = stub
+	ctx.ContextVersion.info = &info.Version
 	ctx.ContextRelationHook.stub = stub
 	ctx.ContextRelationHook.info = &info.RelationHook
 	ctx.ContextActionHook.stub = stub
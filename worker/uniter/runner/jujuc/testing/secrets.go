@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Secret holds the values for a secret in the hook context.
+type Secret struct {
+	Owner  jujuc.SecretOwner
+	Label  string
+	Data   map[string]string
+	Grants []int
+}
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	Secrets map[string]*Secret
+}
+
+// SetSecret adds the secret to the context.
+func (s *Secrets) SetSecret(id string, secret *Secret) {
+	if s.Secrets == nil {
+		s.Secrets = make(map[string]*Secret)
+	}
+	s.Secrets[id] = secret
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// AddSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) AddSecret(owner jujuc.SecretOwner, label string, data map[string]string) (string, error) {
+	c.stub.AddCall("AddSecret", owner, label, data)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	for id, secret := range c.info.Secrets {
+		if label != "" && secret.Owner == owner && secret.Label == label {
+			secret.Data = data
+			return id, nil
+		}
+	}
+	id := fmt.Sprintf("secret-%d", len(c.info.Secrets))
+	c.info.SetSecret(id, &Secret{Owner: owner, Label: label, Data: data})
+	return id, nil
+}
+
+// SecretValue implements jujuc.ContextSecrets.
+func (c *ContextSecrets) SecretValue(id string) (map[string]string, error) {
+	c.stub.AddCall("SecretValue", id)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	secret, ok := c.info.Secrets[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	return secret.Data, nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(id string, relationId int) error {
+	c.stub.AddCall("GrantSecret", id, relationId)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	secret, ok := c.info.Secrets[id]
+	if !ok {
+		return errors.NotFoundf("secret %q", id)
+	}
+	secret.Grants = append(secret.Grants, relationId)
+	return nil
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) RevokeSecret(id string, relationId int) error {
+	c.stub.AddCall("RevokeSecret", id, relationId)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	secret, ok := c.info.Secrets[id]
+	if !ok {
+		return errors.NotFoundf("secret %q", id)
+	}
+	var grants []int
+	for _, granted := range secret.Grants {
+		if granted != relationId {
+			grants = append(grants, granted)
+		}
+	}
+	secret.Grants = grants
+	return nil
+}
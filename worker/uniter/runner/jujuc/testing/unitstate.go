@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"github.com/juju/errors"
+)
+
+// UnitState holds the values for the hook context.
+type UnitState struct {
+	State map[string]string
+}
+
+// SetUnitStateValue sets the value of a key in the unit's charm state.
+func (s *UnitState) SetUnitStateValue(key, value string) {
+	if s.State == nil {
+		s.State = make(map[string]string)
+	}
+	s.State[key] = value
+}
+
+// ContextUnitState is a test double for jujuc.ContextUnitState.
+type ContextUnitState struct {
+	contextBase
+	info *UnitState
+}
+
+// UnitState implements jujuc.ContextUnitState.
+func (c *ContextUnitState) UnitState() (map[string]string, error) {
+	c.stub.AddCall("UnitState")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.info.State, nil
+}
+
+// SetUnitStateValue implements jujuc.ContextUnitState.
+func (c *ContextUnitState) SetUnitStateValue(key, value string) error {
+	c.stub.AddCall("SetUnitStateValue", key, value)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	c.info.SetUnitStateValue(key, value)
+	return nil
+}
+
+// DeleteUnitStateValue implements jujuc.ContextUnitState.
+func (c *ContextUnitState) DeleteUnitStateValue(key string) error {
+	c.stub.AddCall("DeleteUnitStateValue", key)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	delete(c.info.State, key)
+	return nil
+}
@@ -18,6 +18,10 @@ type hookLogger struct {
 	mu      sync.Mutex
 	stopped bool
 	logger  loggo.Logger
+
+	// tail, if set, keeps the most recent output of the hook. It
+	// must not be read until the logger has been stopped.
+	tail *tailBuffer
 }
 
 func (l *hookLogger) run() {
@@ -38,6 +42,10 @@ func (l *hookLogger) run() {
 			return
 		}
 		l.logger.Infof("%s", line)
+		if l.tail != nil {
+			l.tail.Write(line)
+			l.tail.Write([]byte("\n"))
+		}
 		l.mu.Unlock()
 	}
 }
@@ -58,3 +66,28 @@ func (l *hookLogger) stop() {
 	l.stopped = true
 	l.mu.Unlock()
 }
+
+// tailBuffer is an io.Writer that keeps only the last size bytes
+// written to it.
+type tailBuffer struct {
+	size int
+	buf  []byte
+}
+
+func newTailBuffer(size int) *tailBuffer {
+	return &tailBuffer{size: size}
+}
+
+// Write is part of the io.Writer interface.
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if excess := len(t.buf) - t.size; excess > 0 {
+		t.buf = append(t.buf[:0], t.buf[excess:]...)
+	}
+	return len(p), nil
+}
+
+// Bytes returns the bytes most recently written.
+func (t *tailBuffer) Bytes() []byte {
+	return t.buf
+}
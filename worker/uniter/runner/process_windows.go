@@ -0,0 +1,19 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on Windows, which has no process groups
+// that can be signalled as a whole.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the process. Any children it has started are
+// left running.
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
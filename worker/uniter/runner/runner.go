@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"syscall"
 	"time"
 	"unicode/utf8"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/utils/clock"
 	utilexec "github.com/juju/utils/exec"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
@@ -52,6 +54,8 @@ type Context interface {
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
+	RecordHookExecution(execution params.HookExecution) error
+	HookTimeout() time.Duration
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -72,6 +76,17 @@ func (runner *runner) Context() Context {
 	return runner.context
 }
 
+// Kinds of execution recorded in a unit's hook history.
+const (
+	executionKindHook    = "hook"
+	executionKindAction  = "action"
+	executionKindJujuRun = "juju-run"
+)
+
+// maxOutputTail bounds the amount of output kept for recording in the
+// unit's hook history.
+const maxOutputTail = 4 * 1024
+
 // RunCommands exists to satisfy the Runner interface.
 func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, error) {
 	result, err := runner.runCommandsWithTimeout(commands, 0, clock.WallClock)
@@ -98,6 +113,7 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 		Clock:       clock,
 	}
 
+	started := time.Now()
 	err = command.Run()
 	if err != nil {
 		return nil, err
@@ -114,7 +130,12 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	}
 
 	// Block and wait for process to finish
-	return command.WaitWithCancel(cancel)
+	result, err := command.WaitWithCancel(cancel)
+	if result != nil {
+		output := append(append([]byte{}, result.Stdout...), result.Stderr...)
+		runner.recordExecution(executionKindJujuRun, commands, started, result.Code, output)
+	}
+	return result, err
 }
 
 // runJujuRunAction is the function that executes when a juju-run action is ran.
@@ -221,17 +242,41 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		env = mergeWindowsEnvironment(env, os.Environ())
 	}
 
+	kind := executionKindHook
+	if charmLocation == "actions" {
+		kind = executionKindAction
+	}
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
-		logger.Infof("executing %s via debug-hooks", hookName)
-		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
+		err = runner.runDebugHook(session, hookName, env, charmLocation, kind)
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		err = runner.runCharmHook(hookName, env, charmLocation, kind)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+// runDebugHook runs the hook via the given debug-hooks session. A
+// debug-code session runs the hook itself, so the hook is looked up
+// just as it is for running it directly, and is skipped if the charm
+// does not have it.
+func (runner *runner) runDebugHook(session *debug.ServerSession, hookName string, env []string, charmLocation, kind string) error {
+	charmDir := runner.paths.GetCharmDir()
+	var hookPath string
+	if session.DebugAt() != "" {
+		hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
+		if err != nil {
+			return err
+		}
+		hookPath = hook
+	}
+	logger.Infof("executing %s via debug-hooks", hookName)
+	started := time.Now()
+	err := session.RunHook(hookName, charmDir, hookPath, env)
+	runner.recordExecution(kind, hookName, started, exitCode(err), nil)
+	return err
+}
+
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation, kind string) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
 	if err != nil {
@@ -241,6 +286,7 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	setProcessGroup(ps)
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -251,20 +297,66 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 		r:      outReader,
 		done:   make(chan struct{}),
 		logger: runner.getLogger(hookName),
+		tail:   newTailBuffer(maxOutputTail),
 	}
 	go hookLogger.run()
+	timeout := runner.hookTimeout()
+	started := time.Now()
 	err = ps.Start()
 	outWriter.Close()
+	var timedOut bool
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
-		// Block until execution finishes
-		err = ps.Wait()
+		// Block until execution finishes, or the hook is killed
+		// for running too long.
+		timedOut, err = waitWithTimeout(ps, timeout, clock.WallClock)
 	}
 	hookLogger.stop()
+	runner.recordExecution(kind, hookName, started, exitCode(err), hookLogger.tail.Bytes())
+	if timedOut {
+		err = NewHookTimedOutError(kind, hookName, timeout)
+		logger.Errorf("killed %v", err)
+	}
 	return errors.Trace(err)
 }
 
+// recordExecution records an execution in the unit's hook history.
+// Failures are logged rather than returned: the history is diagnostic,
+// and must not change the outcome of the execution.
+func (runner *runner) recordExecution(kind, name string, started time.Time, code int, output []byte) {
+	if len(output) > maxOutputTail {
+		output = output[len(output)-maxOutputTail:]
+	}
+	err := runner.context.RecordHookExecution(params.HookExecution{
+		Kind:     kind,
+		Name:     name,
+		Started:  started,
+		Finished: time.Now(),
+		ExitCode: code,
+		Output:   string(output),
+	})
+	if errors.IsNotImplemented(err) {
+		logger.Debugf("not recording %s %q: %v", kind, name, err)
+	} else if err != nil {
+		logger.Warningf("cannot record %s %q in hook history: %v", kind, name, err)
+	}
+}
+
+// exitCode returns the exit code of a process that finished with the
+// given error, or -1 if it could not be determined.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := errors.Cause(err).(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -6,6 +6,7 @@ package runner_test
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -19,9 +20,11 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -155,6 +158,8 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	executions      []params.HookExecution
+	hookTimeout     time.Duration
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -186,6 +191,15 @@ func (ctx *MockContext) Flush(badge string, failure error) error {
 	return ctx.flushResult
 }
 
+func (ctx *MockContext) RecordHookExecution(execution params.HookExecution) error {
+	ctx.executions = append(ctx.executions, execution)
+	return nil
+}
+
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *MockContext) ActionParams() (map[string]interface{}, error) {
 	return ctx.actionParams, ctx.actionParamsErr
 }
@@ -252,6 +266,115 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookRecordsExecution(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stdout: "some output",
+		code:   42,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 42")
+	c.Assert(ctx.executions, gc.HasLen, 1)
+	execution := ctx.executions[0]
+	c.Check(execution.Kind, gc.Equals, "hook")
+	c.Check(execution.Name, gc.Equals, "something-happened")
+	c.Check(execution.ExitCode, gc.Equals, 42)
+	c.Check(execution.Output, jc.Contains, "some output")
+	c.Check(execution.Finished.Before(execution.Started), jc.IsFalse)
+}
+
+func (s *RunMockContextSuite) TestRunHookDebugCodeMissingHook(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently debug does not work on windows")
+	}
+	// Fake a debug-code session for the unit.
+	fakebin := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(fakebin, "tmux"), []byte("#!/bin/bash\nexit 0\n"), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchEnvPathPrepend(fakebin)
+	ctx := &MockContext{}
+	debugctx := debug.NewHooksContext(ctx.UnitName())
+	err = ioutil.WriteFile(debugctx.ClientFileLock(), []byte(`{debug-at: all}`), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	defer os.Remove(debugctx.ClientFileLock())
+
+	err = runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(context.IsMissingHookError(ctx.flushFailure), jc.IsTrue)
+	c.Assert(ctx.executions, gc.HasLen, 0)
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	ctx := &MockContext{hookTimeout: 500 * time.Millisecond}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stdout: "some output",
+		hang:   true,
+	}, s.paths.GetCharmDir())
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(t0) < 10*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushFailure, jc.Satisfies, runner.IsHookTimedOutError)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" timed out after 500ms`)
+	c.Assert(ctx.executions, gc.HasLen, 1)
+	c.Check(ctx.executions[0].Output, jc.Contains, "some output")
+	c.Check(ctx.executions[0].ExitCode, gc.Not(gc.Equals), 0)
+}
+
+func (s *RunMockContextSuite) TestRunHookCharmTimeout(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		hang: true,
+	}, s.paths.GetCharmDir())
+	metadata := filepath.Join(s.paths.GetCharmDir(), "metadata.yaml")
+	err := ioutil.WriteFile(metadata, []byte("name: wordpress\nhook-timeout: 500ms\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" timed out after 500ms`)
+
+	// The model's hook timeout overrides the charm's.
+	ctx.hookTimeout = 200 * time.Millisecond
+	err = runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" timed out after 200ms`)
+}
+
+func (s *RunMockContextSuite) TestRunActionTimeout(c *gc.C) {
+	ctx := &MockContext{
+		actionData:  &context.ActionData{},
+		hookTimeout: 500 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "actions",
+		name: hookName,
+		perm: 0700,
+		hang: true,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `action "something-happened" timed out after 500ms`)
+	c.Assert(ctx.executions, gc.HasLen, 1)
+	c.Check(ctx.executions[0].Kind, gc.Equals, "action")
+}
+
+func (s *RunMockContextSuite) TestRunMissingHookNotRecorded(c *gc.C) {
+	ctx := &MockContext{}
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(context.IsMissingHookError(err), jc.IsTrue)
+	c.Assert(ctx.executions, gc.HasLen, 0)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -317,6 +440,18 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "")
 }
 
+func (s *RunMockContextSuite) TestRunCommandsRecordsExecution(c *gc.C) {
+	ctx := &MockContext{}
+	_, err := runner.NewRunner(ctx, s.paths).RunCommands("echo hello; exit 3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.executions, gc.HasLen, 1)
+	execution := ctx.executions[0]
+	c.Check(execution.Kind, gc.Equals, "juju-run")
+	c.Check(execution.Name, gc.Equals, "echo hello; exit 3")
+	c.Check(execution.ExitCode, gc.Equals, 3)
+	c.Check(strings.TrimRight(execution.Output, "\r\n"), gc.Equals, "hello")
+}
+
 func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	timeout := 1 * time.Nanosecond
 	ctx := &MockContext{
@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/worker/uniter/charm"
+)
+
+// hookTimeout returns how long a hook or action may run before it is
+// killed: the model's hook-timeout if it sets one, and otherwise the
+// default declared by the charm, if any. Zero means no limit.
+func (runner *runner) hookTimeout() time.Duration {
+	if timeout := runner.context.HookTimeout(); timeout > 0 {
+		return timeout
+	}
+	timeout, err := charmHookTimeout(runner.paths.GetCharmDir())
+	if err != nil {
+		logger.Warningf("ignoring charm hook timeout: %v", err)
+	}
+	return timeout
+}
+
+// charmHookTimeout returns the hook timeout declared by the charm in
+// charmDir, or zero if there is none.
+func charmHookTimeout(charmDir string) (time.Duration, error) {
+	extras, err := charm.ReadMetaExtras(charmDir)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return extras.HookTimeout()
+}
+
+// waitWithTimeout waits for the started command to finish. If it runs
+// for longer than timeout, its whole process group is killed, taking
+// with it any children that would otherwise keep running and holding
+// its output open. A zero timeout waits indefinitely.
+func waitWithTimeout(ps *exec.Cmd, timeout time.Duration, clock clock.Clock) (timedOut bool, err error) {
+	if timeout <= 0 {
+		return false, ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return false, err
+	case <-clock.After(timeout):
+	}
+	if err := killProcessGroup(ps.Process); err != nil {
+		logger.Errorf("cannot kill process group of %v: %v", ps.Path, err)
+	}
+	return true, <-done
+}
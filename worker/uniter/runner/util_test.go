@@ -199,6 +199,9 @@ type hookSpec struct {
 	stderr string
 	// background holds a string to print in the background after 0.2s.
 	background string
+	// hang, if true, makes the hook block for a long time before
+	// exiting.
+	hang bool
 }
 
 // makeCharm constructs a fake charm dir containing a single named hook
@@ -243,5 +246,8 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
 	}
+	if spec.hang {
+		printf(hangScript)
+	}
 	printf("exit %d", spec.code)
 }
@@ -11,4 +11,6 @@ var (
 	hookName = "something-happened"
 	// Platform specific script used in runner_test.go
 	echoPidScript = "echo $$ > pid"
+	// Platform specific script that blocks for a long time
+	hangScript = "sleep 100"
 )
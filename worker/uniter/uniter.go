@@ -6,7 +6,6 @@ package uniter
 import (
 	"fmt"
 	"os"
-	"strings"
 	"sync"
 	"time"
 
@@ -15,7 +14,6 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/exec"
-	"github.com/juju/utils/fslock"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -26,6 +24,7 @@ import (
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/catacomb"
 	"github.com/juju/juju/worker/fortress"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
@@ -79,7 +78,7 @@ type Uniter struct {
 	leadershipTracker leadership.Tracker
 	charmDirGuard     fortress.Guard
 
-	hookLock *fslock.Lock
+	hookLock *machinelock.Lock
 
 	// TODO(axw) move the runListener and run-command code outside of the
 	// uniter, and introduce a separate worker. Each worker would feed
@@ -111,7 +110,7 @@ type UniterParams struct {
 	LeadershipTracker    leadership.Tracker
 	DataDir              string
 	Downloader           charm.Downloader
-	MachineLock          *fslock.Lock
+	MachineLock          *machinelock.Lock
 	CharmDirGuard        fortress.Guard
 	UpdateStatusSignal   func() <-chan time.Time
 	HookRetryStrategy    params.RetryStrategy
@@ -388,22 +387,10 @@ func (u *Uniter) terminate() error {
 }
 
 func (u *Uniter) setupLocks() error {
-	msg, err := u.hookLock.Message()
-	if err != nil {
-		return errors.Trace(err)
-	}
-	if u.hookLock.IsLocked() && msg != "" {
-		// Look to see if it was us that held the lock before.  If it was, we
-		// should be safe enough to break it, as it is likely that we died
-		// before unlocking, and have been restarted by the init system.
-		parts := strings.SplitN(msg, ":", 2)
-		if len(parts) > 1 && parts[0] == u.unit.Name() {
-			if err := u.hookLock.BreakLock(); err != nil {
-				return err
-			}
-		}
-	}
-	return nil
+	// If we held the lock before, we should be safe enough to break it,
+	// as it is likely that we died before unlocking, and have been
+	// restarted by the init system.
+	return errors.Trace(u.hookLock.BreakLocks(u.unit.Name()))
 }
 
 func (u *Uniter) init(unitTag names.UnitTag) (err error) {
@@ -534,9 +521,15 @@ func (u *Uniter) RunCommands(args RunCommandsArgs) (results *exec.ExecResponse,
 
 // acquireExecutionLock acquires the machine-level execution lock, and
 // returns a func that must be called to unlock it. It's used by operation.Executor
-// when running operations that execute external code.
+// when running operations that execute external code. The lock is shared
+// with other units' operations unless the charm requires it exclusively.
 func (u *Uniter) acquireExecutionLock(message string) (func() error, error) {
 	logger.Debugf("lock: %v", message)
+	exclusive, err := u.charmRequiresExclusiveLock()
+	if err != nil {
+		logger.Warningf("acquiring machine lock exclusively: %v", err)
+		exclusive = true
+	}
 	// We want to make sure we don't block forever when locking, but take the
 	// Uniter's catacomb into account.
 	checkCatacomb := func() error {
@@ -547,16 +540,32 @@ func (u *Uniter) acquireExecutionLock(message string) (func() error, error) {
 			return nil
 		}
 	}
-	message = fmt.Sprintf("%s: %s", u.unit.Name(), message)
-	if err := u.hookLock.LockWithFunc(message, checkCatacomb); err != nil {
+	release, err := u.hookLock.Acquire(machinelock.Spec{
+		Owner:     u.unit.Name(),
+		Message:   message,
+		Exclusive: exclusive,
+		Cancel:    checkCatacomb,
+	})
+	if err != nil {
 		return nil, err
 	}
 	return func() error {
 		logger.Debugf("unlock: %v", message)
-		return u.hookLock.Unlock()
+		return release()
 	}, nil
 }
 
+// charmRequiresExclusiveLock returns whether the deployed charm has
+// declared that its hooks must not run alongside any others on the
+// machine.
+func (u *Uniter) charmRequiresExclusiveLock() (bool, error) {
+	extras, err := charm.ReadMetaExtras(u.paths.GetCharmDir())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return extras.ExclusiveMachineLock()
+}
+
 func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
@@ -576,5 +585,10 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if u.operationExecutor.State().HookTimedOut {
+		// The hook was killed; its remaining output is recorded
+		// in the unit's hook history.
+		statusMessage = fmt.Sprintf("hook timed out: %q", hookName)
+	}
 	return setAgentStatus(u, status.StatusError, statusMessage, statusData)
 }
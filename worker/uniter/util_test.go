@@ -46,6 +46,7 @@ import (
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/fortress"
+	"github.com/juju/juju/worker/machinelock"
 	"github.com/juju/juju/worker/uniter"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -481,8 +482,7 @@ func (s startUniter) step(c *gc.C, ctx *context) {
 		panic(err.Error())
 	}
 	downloader := api.NewCharmDownloader(ctx.apiConn.Client())
-	locksDir := filepath.Join(ctx.dataDir, "locks")
-	lock, err := fslock.NewLock(locksDir, "uniter-hook-execution", fslock.Defaults())
+	lock, err := machinelock.New(machinelock.DefaultConfig(ctx.dataDir))
 	c.Assert(err, jc.ErrorIsNil)
 	operationExecutor := operation.NewExecutor
 	if s.newExecutorFunc != nil {
@@ -1372,6 +1372,8 @@ func renameRelation(c *gc.C, charmPath, oldName, newName string) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// createHookLock returns the first slot of the machine lock, which is
+// all of it when the lock is configured with a single slot.
 func createHookLock(c *gc.C, dataDir string) *fslock.Lock {
 	lockDir := filepath.Join(dataDir, "locks")
 	lock, err := fslock.NewLock(lockDir, "uniter-hook-execution", fslock.Defaults())